@@ -1,5 +1,7 @@
 /*
 Gomega's format package pretty-prints objects.  It explores input objects recursively and generates formatted, indented output with type information.
+
+Output is deterministic: map entries are always rendered sorted by key so that the same object produces the same representation from run to run.
 */
 
 // untested sections: 4
@@ -12,7 +14,11 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
+
+	"github.com/onsi/gomega/internal/gutil"
 )
 
 // Use MaxDepth to set the maximum recursion depth when printing deeply nested objects
@@ -22,6 +28,12 @@ var MaxDepth = uint(10)
 // If MaxLength is set to 0, the Object will not be truncated.
 var MaxLength = 4000
 
+// MaxFormattingMemory (default 16MiB) caps the bytes Object builds up while formatting a single object - every
+// element, entry, and field it renders along the way, not just the final representation.  Once the cap is reached
+// the remaining elements, entries, and fields are summarized and a note is appended to the representation, so that
+// formatting a massive object can't exhaust memory.  Set MaxFormattingMemory to 0 to disable the cap.
+var MaxFormattingMemory = 16 << 20
+
 /*
 By default, all objects (even those that implement fmt.Stringer and fmt.GoStringer) are recursively inspected to generate output.
 
@@ -49,6 +61,21 @@ var TruncateThreshold uint = 50
 // after the first diff location in a truncated string assertion error message.
 var CharactersAroundMismatchToInclude uint = 5
 
+/*
+Set AccessibleOutput = true to have Gomega emit plain-ASCII failure messages that are friendly to screen readers and to diffing tools.
+
+In this mode:
+  - Message and MessageWithDiff label each section explicitly (ACTUAL, EXPECTATION, EXPECTED and DIFF)
+  - non-ASCII characters are escaped using Go's \u/\U syntax
+  - pointer, channel and function addresses are omitted so that output is stable from run to run
+  - lines longer than AccessibleLineWidth are wrapped, with a trailing \ marking each continuation
+*/
+var AccessibleOutput = false
+
+// AccessibleLineWidth (default 100) specifies the maximum line length emitted when AccessibleOutput is enabled.
+// Set AccessibleLineWidth to 0 to disable wrapping.
+var AccessibleLineWidth = 100
+
 var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
 var timeType = reflect.TypeOf(time.Time{})
 
@@ -126,6 +153,9 @@ If expected is omitted, then the message looks like:
 	<message>
 */
 func Message(actual interface{}, message string, expected ...interface{}) string {
+	if AccessibleOutput {
+		return accessibleMessage(Object(actual, 1), message, expected...)
+	}
 	if len(expected) == 0 {
 		return fmt.Sprintf("Expected\n%s\n%s", Object(actual, 1), message)
 	}
@@ -146,6 +176,9 @@ to equal               |
 */
 
 func MessageWithDiff(actual, message, expected string) string {
+	if AccessibleOutput {
+		return accessibleMessageWithDiff(actual, message, expected)
+	}
 	if TruncatedDiff && len(actual) >= int(TruncateThreshold) && len(expected) >= int(TruncateThreshold) {
 		diffPoint := findFirstMismatch(actual, expected)
 		formattedActual := truncateAndFormat(actual, diffPoint)
@@ -171,8 +204,139 @@ func MessageWithDiff(actual, message, expected string) string {
 	return Message(actual, message, expected)
 }
 
+func accessibleMessage(formattedActual string, message string, expected ...interface{}) string {
+	out := fmt.Sprintf("ACTUAL:\n%s\nEXPECTATION: %s", formattedActual, message)
+	if len(expected) > 0 {
+		out += fmt.Sprintf("\nEXPECTED:\n%s", Object(expected[0], 1))
+	}
+	return AccessibleString(out)
+}
+
+func accessibleMessageWithDiff(actual, message, expected string) string {
+	out := accessibleMessage(Object(actual, 1), message, expected)
+	if actual == expected {
+		return out
+	}
+
+	diffPoint := firstMismatchedByte(actual, expected)
+	formattedActual, markerOffset := accessibleSnippet(actual, diffPoint)
+	formattedExpected, _ := accessibleSnippet(expected, diffPoint)
+
+	diff := fmt.Sprintf("DIFF: first mismatch at byte offset %d\n", diffPoint)
+	diff += fmt.Sprintf("%sactual:   %s\n", Indent, formattedActual)
+	diff += fmt.Sprintf("%sexpected: %s\n", Indent, formattedExpected)
+	diff += fmt.Sprintf("%s%s^", Indent, strings.Repeat(" ", len("expected: ")+markerOffset))
+
+	return out + "\n" + AccessibleString(diff)
+}
+
+func firstMismatchedByte(a, b string) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	i := 0
+	for i < n && a[i] == b[i] {
+		i++
+	}
+	// back up to the start of the rune so that the mismatch is reported on a character boundary
+	for i > 0 && i < n && !utf8.RuneStart(a[i]) {
+		i--
+	}
+	return i
+}
+
+// accessibleSnippet returns an ASCII-escaped, quoted excerpt of str around index along with the offset of the
+// character at index within the excerpt.  Since the excerpt preceding index is identical for both strings being
+// compared, the offset can be used to point at the mismatch in either one.
+func accessibleSnippet(str string, index int) (string, int) {
+	leftPadding, rightPadding := "...", "..."
+	start := index - int(CharactersAroundMismatchToInclude)
+	if start <= 0 {
+		start = 0
+		leftPadding = ""
+	}
+	end := index + int(CharactersAroundMismatchToInclude) + 1
+	for end < len(str) && !utf8.RuneStart(str[end]) {
+		end++
+	}
+	if end >= len(str) {
+		end = len(str)
+		rightPadding = ""
+	}
+
+	before := escapedWithASCIIGoSyntax(str[start:index])
+	after := escapedWithASCIIGoSyntax(str[index:end])
+	return fmt.Sprintf("\"%s%s%s%s\"", leftPadding, before, after, rightPadding), len(`"`) + len(leftPadding) + len(before)
+}
+
+func escapedWithASCIIGoSyntax(str string) string {
+	withQuotes := strconv.QuoteToASCII(str)
+	return withQuotes[1 : len(withQuotes)-1]
+}
+
+/*
+AccessibleString returns s unchanged unless AccessibleOutput is enabled.  When it is, AccessibleString escapes any
+non-ASCII characters in s and wraps lines that exceed AccessibleLineWidth.
+
+Gomega applies AccessibleString to every failure message it emits.  Custom matchers that assemble their messages by
+hand do not need to call it.
+*/
+func AccessibleString(s string) string {
+	if !AccessibleOutput {
+		return s
+	}
+
+	lines := strings.Split(s, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		out = append(out, wrapLine(escapeNonASCII(line), AccessibleLineWidth)...)
+	}
+	return strings.Join(out, "\n")
+}
+
+func escapeNonASCII(s string) string {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			result := &strings.Builder{}
+			for _, r := range s {
+				if r < utf8.RuneSelf {
+					result.WriteRune(r)
+				} else {
+					result.WriteString(escapedWithASCIIGoSyntax(string(r)))
+				}
+			}
+			return result.String()
+		}
+	}
+	return s
+}
+
+func wrapLine(line string, width int) []string {
+	if width <= 0 || len(line) <= width {
+		return []string{line}
+	}
+
+	continuation := line[:len(line)-len(strings.TrimLeft(line, " \t"))] + Indent
+	if len(continuation) >= width-1 {
+		continuation = ""
+	}
+
+	out := []string{}
+	for len(line) > width {
+		cut := width - 1
+		out = append(out, line[:cut]+"\\")
+		line = continuation + line[cut:]
+	}
+	return append(out, line)
+}
+
 func escapedWithGoSyntax(str string) string {
 	withQuotes := fmt.Sprintf("%q", str)
+	if len(withQuotes) == len(str)+2 {
+		// nothing needed escaping - return str itself so that CustomFormatters can recognize it
+		return str
+	}
 	return withQuotes[1 : len(withQuotes)-1]
 }
 
@@ -225,6 +389,89 @@ or adjust the parameters in Gomega's 'format' package.
 Learn more here: https://onsi.github.io/gomega/#adjusting-output
 `
 
+const summarizedHelpText = `
+Gomega summarized this representation as formatting it exceeded 'format.MaxFormattingMemory'.
+Consider matching on a smaller part of the object, having the object provide a custom
+'GomegaStringer' representation, or adjusting the parameters in Gomega's 'format' package.
+
+Learn more here: https://onsi.github.io/gomega/#adjusting-output
+`
+
+// formatBudget tracks the bytes built up while formatting a single object against MaxFormattingMemory
+type formatBudget struct {
+	used       int
+	summarized bool
+}
+
+// spend records n bytes built up
+func (b *formatBudget) spend(n int) {
+	b.used += n
+}
+
+// exhausted reports whether formatting should stop, marking the representation as summarized if so
+func (b *formatBudget) exhausted() bool {
+	if MaxFormattingMemory > 0 && b.used >= MaxFormattingMemory {
+		b.summarized = true
+	}
+	return b.summarized
+}
+
+// truncate cuts s short if it would take formatting past MaxFormattingMemory, marking the representation as summarized
+func (b *formatBudget) truncate(s string) string {
+	remaining := MaxFormattingMemory - b.used
+	if MaxFormattingMemory <= 0 || len(s) <= remaining {
+		return s
+	}
+	b.summarized = true
+	if remaining < 0 {
+		remaining = 0
+	}
+	for remaining > 0 && !utf8.RuneStart(s[remaining]) {
+		remaining--
+	}
+	return s[:remaining] + "..."
+}
+
+// FormattingStats describes the memory Object has used formatting objects since the stats were last reset
+type FormattingStats struct {
+	// Objects is the number of objects formatted
+	Objects int
+	// LargestBytes is the most bytes built up formatting a single object
+	LargestBytes int
+	// Summarized is the number of objects that were summarized as they exceeded MaxFormattingMemory
+	Summarized int
+}
+
+var formattingStats = FormattingStats{}
+var formattingStatsLock = &sync.Mutex{}
+
+func recordFormattingStats(budget *formatBudget) {
+	formattingStatsLock.Lock()
+	defer formattingStatsLock.Unlock()
+	formattingStats.Objects += 1
+	if budget.used > formattingStats.LargestBytes {
+		formattingStats.LargestBytes = budget.used
+	}
+	if budget.summarized {
+		formattingStats.Summarized += 1
+	}
+}
+
+// ReadFormattingStats returns the memory Object has used formatting objects since the stats were last reset.  Use it
+// to spot the assertions whose failure messages are expensive to build.
+func ReadFormattingStats() FormattingStats {
+	formattingStatsLock.Lock()
+	defer formattingStatsLock.Unlock()
+	return formattingStats
+}
+
+// ResetFormattingStats resets the stats returned by ReadFormattingStats
+func ResetFormattingStats() {
+	formattingStatsLock.Lock()
+	defer formattingStatsLock.Unlock()
+	formattingStats = FormattingStats{}
+}
+
 func truncateLongStrings(s string) string {
 	if MaxLength > 0 && len(s) > MaxLength {
 		var sb strings.Builder
@@ -254,11 +501,23 @@ Set format.UseStringerRepresentation to true to return object.GoString() or obje
 recursing into the object.
 
 Set PrintContextObjects to true to print the content of objects implementing context.Context
+
+Modify format.MaxFormattingMemory to control how much memory formatting a single object may use
 */
 func Object(object interface{}, indentation uint) string {
 	indent := strings.Repeat(Indent, int(indentation))
 	value := reflect.ValueOf(object)
-	return fmt.Sprintf("%s<%s>: %s", indent, formatType(value), formatValue(value, indentation))
+	budget := &formatBudget{}
+	representation := fmt.Sprintf("%s<%s>: %s", indent, formatType(value), formatValue(value, indentation, budget))
+	recordFormattingStats(budget)
+	if budget.summarized {
+		representation += "\n" + summarizedHelpText
+	}
+	if AccessibleOutput {
+		// lines are wrapped only once the full message is assembled and its final indentation is known
+		return escapeNonASCII(representation)
+	}
+	return representation
 }
 
 /*
@@ -292,6 +551,9 @@ func formatType(v reflect.Value) string {
 	case reflect.Chan:
 		return fmt.Sprintf("%s | len:%d, cap:%d", v.Type(), v.Len(), v.Cap())
 	case reflect.Ptr:
+		if AccessibleOutput {
+			return fmt.Sprintf("%s", v.Type())
+		}
 		return fmt.Sprintf("%s | 0x%x", v.Type(), v.Pointer())
 	case reflect.Slice:
 		return fmt.Sprintf("%s | len:%d, cap:%d", v.Type(), v.Len(), v.Cap())
@@ -302,7 +564,7 @@ func formatType(v reflect.Value) string {
 	}
 }
 
-func formatValue(value reflect.Value, indentation uint) string {
+func formatValue(value reflect.Value, indentation uint, budget *formatBudget) string {
 	if indentation > MaxDepth {
 		return "..."
 	}
@@ -358,28 +620,29 @@ func formatValue(value reflect.Value, indentation uint) string {
 		return fmt.Sprintf("%v", value.Float())
 	case reflect.Complex64, reflect.Complex128:
 		return fmt.Sprintf("%v", value.Complex())
-	case reflect.Chan:
-		return fmt.Sprintf("0x%x", value.Pointer())
-	case reflect.Func:
+	case reflect.Chan, reflect.Func:
+		if AccessibleOutput {
+			return "<address omitted>"
+		}
 		return fmt.Sprintf("0x%x", value.Pointer())
 	case reflect.Ptr:
-		return formatValue(value.Elem(), indentation)
+		return formatValue(value.Elem(), indentation, budget)
 	case reflect.Slice:
-		return truncateLongStrings(formatSlice(value, indentation))
+		return truncateLongStrings(formatSlice(value, indentation, budget))
 	case reflect.String:
-		return truncateLongStrings(formatString(value.String(), indentation))
+		return truncateLongStrings(formatString(budget.truncate(value.String()), indentation))
 	case reflect.Array:
-		return truncateLongStrings(formatSlice(value, indentation))
+		return truncateLongStrings(formatSlice(value, indentation, budget))
 	case reflect.Map:
-		return truncateLongStrings(formatMap(value, indentation))
+		return truncateLongStrings(formatMap(value, indentation, budget))
 	case reflect.Struct:
 		if value.Type() == timeType && value.CanInterface() {
 			t, _ := value.Interface().(time.Time)
 			return t.Format(time.RFC3339Nano)
 		}
-		return truncateLongStrings(formatStruct(value, indentation))
+		return truncateLongStrings(formatStruct(value, indentation, budget))
 	case reflect.Interface:
-		return formatInterface(value, indentation)
+		return formatInterface(value, indentation, budget)
 	default:
 		if value.CanInterface() {
 			return truncateLongStrings(fmt.Sprintf("%#v", value.Interface()))
@@ -410,18 +673,24 @@ func formatString(object interface{}, indentation uint) string {
 	}
 }
 
-func formatSlice(v reflect.Value, indentation uint) string {
+func formatSlice(v reflect.Value, indentation uint, budget *formatBudget) string {
 	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 && isPrintableString(string(v.Bytes())) {
-		return formatString(v.Bytes(), indentation)
+		return formatString(budget.truncate(string(v.Bytes())), indentation)
 	}
 
 	l := v.Len()
-	result := make([]string, l)
+	result := make([]string, 0, l)
 	longest := 0
 	for i := 0; i < l; i++ {
-		result[i] = formatValue(v.Index(i), indentation+1)
-		if len(result[i]) > longest {
-			longest = len(result[i])
+		if budget.exhausted() {
+			result = append(result, fmt.Sprintf("...%d more elements", l-i))
+			break
+		}
+		representation := formatValue(v.Index(i), indentation+1, budget)
+		budget.spend(len(representation))
+		result = append(result, representation)
+		if len(representation) > longest {
+			longest = len(representation)
 		}
 	}
 
@@ -432,16 +701,22 @@ func formatSlice(v reflect.Value, indentation uint) string {
 	return fmt.Sprintf("[%s]", strings.Join(result, ", "))
 }
 
-func formatMap(v reflect.Value, indentation uint) string {
+func formatMap(v reflect.Value, indentation uint, budget *formatBudget) string {
 	l := v.Len()
-	result := make([]string, l)
+	result := make([]string, 0, l)
 
 	longest := 0
-	for i, key := range v.MapKeys() {
+	for i, key := range gutil.SortedMapKeys(v) {
+		if budget.exhausted() {
+			result = append(result, fmt.Sprintf("...%d more entries", l-i))
+			break
+		}
 		value := v.MapIndex(key)
-		result[i] = fmt.Sprintf("%s: %s", formatValue(key, indentation+1), formatValue(value, indentation+1))
-		if len(result[i]) > longest {
-			longest = len(result[i])
+		representation := fmt.Sprintf("%s: %s", formatValue(key, indentation+1, budget), formatValue(value, indentation+1, budget))
+		budget.spend(len(representation))
+		result = append(result, representation)
+		if len(representation) > longest {
+			longest = len(representation)
 		}
 	}
 
@@ -452,16 +727,21 @@ func formatMap(v reflect.Value, indentation uint) string {
 	return fmt.Sprintf("{%s}", strings.Join(result, ", "))
 }
 
-func formatStruct(v reflect.Value, indentation uint) string {
+func formatStruct(v reflect.Value, indentation uint, budget *formatBudget) string {
 	t := v.Type()
 
 	l := v.NumField()
 	result := []string{}
 	longest := 0
 	for i := 0; i < l; i++ {
+		if budget.exhausted() {
+			result = append(result, fmt.Sprintf("...%d more fields", l-i))
+			break
+		}
 		structField := t.Field(i)
 		fieldEntry := v.Field(i)
-		representation := fmt.Sprintf("%s: %s", structField.Name, formatValue(fieldEntry, indentation+1))
+		representation := fmt.Sprintf("%s: %s", structField.Name, formatValue(fieldEntry, indentation+1, budget))
+		budget.spend(len(representation))
 		result = append(result, representation)
 		if len(representation) > longest {
 			longest = len(representation)
@@ -474,8 +754,8 @@ func formatStruct(v reflect.Value, indentation uint) string {
 	return fmt.Sprintf("{%s}", strings.Join(result, ", "))
 }
 
-func formatInterface(v reflect.Value, indentation uint) string {
-	return fmt.Sprintf("<%s>%s", formatType(v.Elem()), formatValue(v.Elem(), indentation))
+func formatInterface(v reflect.Value, indentation uint, budget *formatBudget) string {
+	return fmt.Sprintf("<%s>%s", formatType(v.Elem()), formatValue(v.Elem(), indentation, budget))
 }
 
 func isNilValue(a reflect.Value) bool {
@@ -337,6 +337,80 @@ var _ = Describe("Format", func() {
 		})
 	})
 
+	Describe("AccessibleOutput", func() {
+		BeforeEach(func() {
+			AccessibleOutput = true
+			DeferCleanup(func() {
+				AccessibleOutput = false
+				AccessibleLineWidth = 100
+			})
+		})
+
+		It("labels each section of the message", func() {
+			Expect(Message(3, "to equal", 4)).Should(Equal("ACTUAL:\n    <int>: 3\nEXPECTATION: to equal\nEXPECTED:\n    <int>: 4"))
+			Expect(Message(3, "to be even")).Should(Equal("ACTUAL:\n    <int>: 3\nEXPECTATION: to be even"))
+		})
+
+		It("escapes non-ASCII characters", func() {
+			Expect(Message("• ünïcode", "to be plain")).Should(Equal(`ACTUAL:
+    <string>: \u2022 \u00fcn\u00efcode
+EXPECTATION: to be plain`))
+			Expect(Object([]string{"∑"}, 1)).Should(Equal(`    <[]string | len:1, cap:1>: ["\u2211"]`))
+		})
+
+		It("omits pointer, channel, and function addresses", func() {
+			a := 3
+			Expect(Object(&a, 1)).Should(Equal("    <*int>: 3"))
+			Expect(Object(make(chan bool), 1)).Should(Equal("    <chan bool | len:0, cap:0>: <address omitted>"))
+			Expect(Object(func() {}, 1)).Should(Equal("    <func()>: <address omitted>"))
+		})
+
+		It("wraps long lines", func() {
+			AccessibleLineWidth = 30
+			Expect(Message(strings.Repeat("a", 30), "to be short")).Should(Equal(`ACTUAL:
+    <string>: aaaaaaaaaaaaaaa\
+        aaaaaaaaaaaaaaa
+EXPECTATION: to be short`))
+		})
+
+		It("does not wrap lines when AccessibleLineWidth is 0", func() {
+			AccessibleLineWidth = 0
+			Expect(Message(strings.Repeat("a", 200), "to be long")).Should(Equal("ACTUAL:\n    <string>: " + strings.Repeat("a", 200) + "\nEXPECTATION: to be long"))
+		})
+
+		Describe("MessageWithDiff", func() {
+			It("points at the first mismatch in a DIFF section", func() {
+				Expect(MessageWithDiff("aaaaaaaaaabaaaaaaaaaa", "to equal", "aaaaaaaaaazaaaaaaaaaa")).Should(Equal(`ACTUAL:
+    <string>: aaaaaaaaaabaaaaaaaaaa
+EXPECTATION: to equal
+EXPECTED:
+    <string>: aaaaaaaaaazaaaaaaaaaa
+DIFF: first mismatch at byte offset 10
+    actual:   "...aaaaabaaaaa..."
+    expected: "...aaaaazaaaaa..."
+                       ^`))
+			})
+
+			It("handles strings that differ only in length", func() {
+				Expect(MessageWithDiff("abc", "to equal", "abcd")).Should(HaveSuffix(`DIFF: first mismatch at byte offset 3
+    actual:   "abc"
+    expected: "abcd"
+                  ^`))
+			})
+
+			It("escapes non-ASCII characters in the diff", func() {
+				Expect(MessageWithDiff("•a", "to equal", "•b")).Should(HaveSuffix(`DIFF: first mismatch at byte offset 3
+    actual:   "\u2022a"
+    expected: "\u2022b"
+                     ^`))
+			})
+
+			It("omits the DIFF section when the strings are identical", func() {
+				Expect(MessageWithDiff("abc", "not to equal", "abc")).ShouldNot(ContainSubstring("DIFF"))
+			})
+		})
+	})
+
 	Describe("IndentString", func() {
 		It("should indent the string", func() {
 			Expect(IndentString("foo\n  bar\nbaz", 2)).Should(Equal("        foo\n          bar\n        baz"))
@@ -482,6 +556,17 @@ var _ = Describe("Format", func() {
 				Expect(Object(m, 1)).Should(matchRegexp(`map\[int\]bool \| len:2`, hashMatchingRegexp("3: true", "4: false")))
 			})
 
+			It("should sort the entries by key", func() {
+				m := map[int]bool{4: false, 3: true, 10: true, -1: false}
+				Expect(Object(m, 1)).Should(match("map[int]bool | len:4", "{-1: false, 3: true, 4: false, 10: true}"))
+
+				s := map[string]int{"b": 2, "c": 3, "a": 1}
+				Expect(Object(s, 1)).Should(match("map[string]int | len:3", `{"a": 1, "b": 2, "c": 3}`))
+
+				i := map[interface{}]int{"b": 2, 1: 0, "a": 1, true: 3}
+				Expect(Object(i, 1)).Should(match("map[interface {}]int | len:4", `{<bool>true: 3, <int>1: 0, <string>"a": 1, <string>"b": 2}`))
+			})
+
 			When("the slice contains long entries", func() {
 				It("should format the entries with newlines", func() {
 					m := map[string][]byte{}
@@ -614,6 +699,68 @@ var _ = Describe("Format", func() {
 		})
 	})
 
+	Describe("Limiting the memory used to format an object", func() {
+		summarizedHelpText := `
+Gomega summarized this representation as formatting it exceeded 'format.MaxFormattingMemory'.
+Consider matching on a smaller part of the object, having the object provide a custom
+'GomegaStringer' representation, or adjusting the parameters in Gomega's 'format' package.
+
+Learn more here: https://onsi.github.io/gomega/#adjusting-output
+`
+
+		BeforeEach(func() {
+			originalMaxFormattingMemory, originalMaxLength := MaxFormattingMemory, MaxLength
+			MaxFormattingMemory, MaxLength = 100, 0
+			DeferCleanup(func() {
+				MaxFormattingMemory, MaxLength = originalMaxFormattingMemory, originalMaxLength
+			})
+			ResetFormattingStats()
+		})
+
+		It("summarizes the elements of a slice left once the cap is reached", func() {
+			elements := make([]string, 100)
+			for i := range elements {
+				elements[i] = "abcdefghij"
+			}
+			Expect(Object(elements, 1)).Should(Equal("    <[]string | len:100, cap:100>: [" + strings.Repeat(`"abcdefghij", `, 8) + `"abcd...", ...91 more elements]` + "\n" + summarizedHelpText))
+		})
+
+		It("summarizes the entries of a map left once the cap is reached", func() {
+			entries := map[int]string{}
+			for i := 0; i < 100; i++ {
+				entries[i] = "abcdefghij"
+			}
+			Expect(Object(entries, 1)).Should(ContainSubstring(`6: "abcdefghij", ...93 more entries}`))
+		})
+
+		It("summarizes the fields of a struct left once the cap is reached", func() {
+			type Wide struct {
+				A, B, C string
+			}
+			long := strings.Repeat("a", 50)
+			Expect(Object(Wide{long, long, long}, 1)).Should(ContainSubstring(`B: "` + long[:45] + `...",` + "\n" + `        ...1 more fields,`))
+		})
+
+		It("truncates strings that would exceed the cap", func() {
+			Expect(Object([]string{strings.Repeat("é", 100)}, 1)).Should(HavePrefix("    <[]string | len:1, cap:1>: [\n        \"" + strings.Repeat("é", 50) + "...\",\n    ]\n"))
+			Expect(Object(strings.Repeat("a", 200), 1)).Should(Equal("    <string>: " + strings.Repeat("a", 100) + "...\n" + summarizedHelpText))
+		})
+
+		It("does not limit the memory used when MaxFormattingMemory is 0", func() {
+			MaxFormattingMemory = 0
+			Expect(Object(strings.Repeat("a", 200), 1)).Should(Equal("    <string>: " + strings.Repeat("a", 200)))
+		})
+
+		It("records how much memory formatting used", func() {
+			Object([]string{"abc", "de"}, 1)
+			Object(strings.Repeat("a", 200), 1)
+			Expect(ReadFormattingStats()).Should(Equal(FormattingStats{Objects: 2, LargestBytes: 9, Summarized: 1}))
+
+			ResetFormattingStats()
+			Expect(ReadFormattingStats()).Should(BeZero())
+		})
+	})
+
 	Describe("Handling unexported fields in structs", func() {
 		It("should handle all the various types correctly", func() {
 			a := int(5)
@@ -0,0 +1,174 @@
+package gast
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/internal/gutil"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+CompileSuccessfully succeeds if the Go code compiles.  The code can be a path to a directory (as a string) or a map of
+file names to file contents (a map[string]string or map[string][]byte):
+
+	Expect(outputDir).To(gast.CompileSuccessfully())
+	Expect(map[string]string{
+		"client.go":      generator.Generate(),
+		"client_stub.go": stub,
+	}).To(gast.CompileSuccessfully("integration"))
+
+The files are copied into a temporary module and built with go build, passing along any build tags.  If the files include
+a go.mod it is used, otherwise a go.mod is generated for the running Go version, in which case only the standard library
+can be imported.  On failure, the compiler's errors become the failure message.
+*/
+func CompileSuccessfully(buildTags ...string) types.GomegaMatcher {
+	return &compileSuccessfullyMatcher{buildTags: buildTags}
+}
+
+type compileSuccessfullyMatcher struct {
+	buildTags []string
+
+	// state
+	output string
+}
+
+func (m *compileSuccessfullyMatcher) Match(actual interface{}) (success bool, err error) {
+	moduleDir, err := gutil.MkdirTemp("", "gast-compile")
+	if err != nil {
+		return false, fmt.Errorf("CompileSuccessfully matcher could not create a temporary module: %s", err.Error())
+	}
+	defer os.RemoveAll(moduleDir)
+
+	switch x := actual.(type) {
+	case string:
+		err = copyDir(x, moduleDir)
+	case map[string]string:
+		files := map[string][]byte{}
+		for name, contents := range x {
+			files[name] = []byte(contents)
+		}
+		err = writeFiles(files, moduleDir)
+	case map[string][]byte:
+		err = writeFiles(x, moduleDir)
+	default:
+		return false, fmt.Errorf("CompileSuccessfully matcher expects a directory path or a map of file names to file contents.  Got:\n%s", format.Object(actual, 1))
+	}
+	if err != nil {
+		return false, fmt.Errorf("CompileSuccessfully matcher %s", err.Error())
+	}
+
+	if err := ensureGoMod(moduleDir); err != nil {
+		return false, fmt.Errorf("CompileSuccessfully matcher could not write go.mod: %s", err.Error())
+	}
+
+	args := []string{"build", "-o", os.DevNull}
+	if len(m.buildTags) > 0 {
+		args = append(args, "-tags", strings.Join(m.buildTags, ","))
+	}
+	args = append(args, "./...")
+
+	build := exec.Command("go", args...)
+	build.Dir = moduleDir
+	build.Env = append(os.Environ(), "GOWORK=off")
+	output, err := build.CombinedOutput()
+	m.output = strings.TrimSpace(string(output))
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			return false, fmt.Errorf("CompileSuccessfully matcher could not run go build: %s", err.Error())
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+func (m *compileSuccessfullyMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the code to compile successfully.  go build reported:\n%s", format.IndentString(m.output, 1))
+}
+
+func (m *compileSuccessfullyMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return "Expected the code not to compile.  It did."
+}
+
+// copyDir copies every file under src into dst
+func copyDir(src, dst string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return fmt.Errorf("could not read the directory: %s", err.Error())
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("expects a directory.  %s is not a directory", src)
+	}
+
+	files := map[string][]byte{}
+	err = filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)], err = gutil.ReadFile(path)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("could not read the directory: %s", err.Error())
+	}
+	return writeFiles(files, dst)
+}
+
+func writeFiles(files map[string][]byte, dst string) error {
+	goFiles := 0
+	for name, contents := range files {
+		if filepath.IsAbs(name) || strings.HasPrefix(filepath.Clean(name), "..") {
+			return fmt.Errorf("expects relative file names.  Got %q", name)
+		}
+		if strings.HasSuffix(name, ".go") {
+			goFiles++
+		}
+		path := filepath.Join(dst, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("could not write %s: %s", name, err.Error())
+		}
+		if err := gutil.WriteFile(path, contents); err != nil {
+			return fmt.Errorf("could not write %s: %s", name, err.Error())
+		}
+	}
+	if goFiles == 0 {
+		return fmt.Errorf("found no Go files to compile")
+	}
+	return nil
+}
+
+// ensureGoMod writes a go.mod for the running Go version, unless the code brought its own
+func ensureGoMod(dir string) error {
+	path := filepath.Join(dir, "go.mod")
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	goMod := "module gastcompile\n"
+	if version := goVersion(); version != "" {
+		goMod += "\ngo " + version + "\n"
+	}
+	return gutil.WriteFile(path, []byte(goMod))
+}
+
+// goVersion returns the major.minor version of the running Go, e.g. "1.21", or "" for development builds
+func goVersion() string {
+	parts := strings.SplitN(strings.TrimPrefix(runtime.Version(), "go"), ".", 3)
+	if len(parts) < 2 || !strings.HasPrefix(runtime.Version(), "go") {
+		return ""
+	}
+	minor := parts[1]
+	if i := strings.IndexFunc(minor, func(r rune) bool { return r < '0' || r > '9' }); i >= 0 {
+		minor = minor[:i]
+	}
+	return parts[0] + "." + minor
+}
@@ -0,0 +1,67 @@
+package gast_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gast"
+)
+
+var _ = Describe("CompileSuccessfully", func() {
+	It("should succeed when the files compile", func() {
+		Expect(map[string]string{
+			"main.go":     "package main\n\nimport \"fmt\"\n\nfunc main() { fmt.Println(greeting()) }\n",
+			"greeting.go": "package main\n\nfunc greeting() string { return \"hi\" }\n",
+		}).Should(CompileSuccessfully())
+
+		Expect(map[string][]byte{
+			"lib/lib.go": []byte("package lib\n\nconst Answer = 42\n"),
+		}).Should(CompileSuccessfully())
+	})
+
+	It("should report compiler errors", func() {
+		files := map[string]string{
+			"lib.go": "package lib\n\nfunc Foo() int { return bar }\n",
+		}
+		m := CompileSuccessfully()
+		Expect(m.Match(files)).Should(BeFalse())
+		Expect(m.FailureMessage(files)).Should(HavePrefix("Expected the code to compile successfully.  go build reported:\n"))
+		Expect(m.FailureMessage(files)).Should(ContainSubstring("lib.go:3:25: undefined: bar"))
+	})
+
+	It("should pass along build tags", func() {
+		files := map[string]string{
+			"lib.go":    "package lib\n",
+			"broken.go": "//go:build broken\n\npackage lib\n\nvar _ = missing\n",
+		}
+		Expect(files).Should(CompileSuccessfully())
+		Expect(files).ShouldNot(CompileSuccessfully("integration", "broken"))
+	})
+
+	It("should compile a directory, using its go.mod if it has one", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "lib.go"), []byte("package lib\n\nfunc Max(a, b int) int { return max(a, b) }\n"), 0644)).Should(Succeed())
+		Expect(dir).Should(CompileSuccessfully())
+
+		Expect(os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/lib\n\ngo 1.18\n"), 0644)).Should(Succeed())
+		m := CompileSuccessfully()
+		Expect(m.Match(dir)).Should(BeFalse())
+		Expect(m.FailureMessage(dir)).Should(ContainSubstring("max"))
+	})
+
+	It("should error on invalid input", func() {
+		_, err := CompileSuccessfully().Match(7)
+		Expect(err).Should(MatchError(ContainSubstring("expects a directory path or a map")))
+
+		_, err = CompileSuccessfully().Match(filepath.Join(GinkgoT().TempDir(), "missing"))
+		Expect(err).Should(MatchError(ContainSubstring("could not read the directory")))
+
+		_, err = CompileSuccessfully().Match(map[string]string{"README.md": "hi"})
+		Expect(err).Should(MatchError(ContainSubstring("found no Go files")))
+
+		_, err = CompileSuccessfully().Match(map[string]string{"../escape.go": "package lib\n"})
+		Expect(err).Should(MatchError(ContainSubstring("expects relative file names")))
+	})
+})
@@ -0,0 +1,172 @@
+package gast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+HaveFunction succeeds if the Go source declares a function with the passed-in name.  Methods are named
+"Receiver.Method" - the receiver's pointer-ness is ignored, so "Client.Close" matches both
+func (c Client) Close() and func (c *Client) Close().
+
+Any matchers passed in are applied to the function's *ast.FuncDecl:
+
+	Expect(source).To(HaveFunction("Client.Close", WithSignature("func() error")))
+*/
+func HaveFunction(name string, matchers ...types.GomegaMatcher) types.GomegaMatcher {
+	return &haveFunctionMatcher{
+		name:     name,
+		matchers: matchers,
+	}
+}
+
+type haveFunctionMatcher struct {
+	name     string
+	matchers []types.GomegaMatcher
+
+	// state
+	declared      []string
+	decl          *ast.FuncDecl
+	failedMatcher types.GomegaMatcher
+}
+
+func (m *haveFunctionMatcher) Match(actual interface{}) (success bool, err error) {
+	file, err := parseSource("HaveFunction", actual)
+	if err != nil {
+		return false, err
+	}
+
+	m.declared, m.decl, m.failedMatcher = []string{}, nil, nil
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		name := functionName(funcDecl)
+		m.declared = append(m.declared, name)
+		if name == m.name {
+			m.decl = funcDecl
+		}
+	}
+	sort.Strings(m.declared)
+
+	if m.decl == nil {
+		return false, nil
+	}
+
+	for _, matcher := range m.matchers {
+		success, err := matcher.Match(m.decl)
+		if err != nil {
+			return false, err
+		}
+		if !success {
+			m.failedMatcher = matcher
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (m *haveFunctionMatcher) FailureMessage(actual interface{}) (message string) {
+	if m.decl == nil {
+		return fmt.Sprintf("Expected the source to declare function %s.  It declares:\n%s", m.name, describeNames(m.declared))
+	}
+	return fmt.Sprintf("Function %s failed to satisfy matcher.\n%s", m.name, m.failedMatcher.FailureMessage(m.decl))
+}
+
+func (m *haveFunctionMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if len(m.matchers) == 0 {
+		return fmt.Sprintf("Expected the source not to declare function %s.  It does.", m.name)
+	}
+	return fmt.Sprintf("Expected the source not to declare function %s satisfying the matchers.  It does.", m.name)
+}
+
+func functionName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return funcDecl.Name.Name
+	}
+	return receiverTypeName(funcDecl.Recv.List[0].Type) + "." + funcDecl.Name.Name
+}
+
+func receiverTypeName(expr ast.Expr) string {
+	switch x := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeName(x.X)
+	case *ast.IndexExpr:
+		return receiverTypeName(x.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(x.X)
+	case *ast.Ident:
+		return x.Name
+	default:
+		return typeString(expr)
+	}
+}
+
+func describeNames(names []string) string {
+	if len(names) == 0 {
+		return format.Indent + "(nothing)"
+	}
+	return format.Indent + strings.Join(names, "\n"+format.Indent)
+}
+
+/*
+WithSignature is used with HaveFunction to assert on a function's signature.  The signature is written as a Go function
+type.  Parameter names are ignored, so these are equivalent:
+
+	WithSignature("func(addr string, timeout time.Duration) error")
+	WithSignature("func(string, time.Duration) error")
+
+The receiver of a method is not part of its signature.
+*/
+func WithSignature(signature string) types.GomegaMatcher {
+	return &withSignatureMatcher{
+		signature: signature,
+	}
+}
+
+type withSignatureMatcher struct {
+	signature string
+
+	// state
+	expected string
+	actual   string
+}
+
+func (m *withSignatureMatcher) Match(actual interface{}) (success bool, err error) {
+	funcDecl, ok := actual.(*ast.FuncDecl)
+	if !ok {
+		return false, fmt.Errorf("WithSignature matcher expects an *ast.FuncDecl.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	signature := strings.TrimSpace(m.signature)
+	if !strings.HasPrefix(signature, "func") {
+		signature = "func" + signature
+	}
+	expr, err := parser.ParseExpr(signature)
+	if err != nil {
+		return false, fmt.Errorf("WithSignature matcher could not parse signature %q: %s", m.signature, err.Error())
+	}
+	funcType, ok := expr.(*ast.FuncType)
+	if !ok {
+		return false, fmt.Errorf("WithSignature matcher expects a function type.  Got: %q", m.signature)
+	}
+
+	m.expected, m.actual = signatureString(funcType), signatureString(funcDecl.Type)
+	return m.expected == m.actual, nil
+}
+
+func (m *withSignatureMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(m.actual, "to be the signature", m.expected)
+}
+
+func (m *withSignatureMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(m.actual, "not to be the signature", m.expected)
+}
@@ -0,0 +1,92 @@
+package gast_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gast"
+)
+
+const clientSource = `package client
+
+import "time"
+
+type Option func(*Client)
+
+type Client struct {
+	Addr    string ` + "`json:\"addr\"`" + `
+	Timeout time.Duration ` + "`json:\"timeout,omitempty\" yaml:\"timeout\"`" + `
+	*Logger
+	retries, backoff int
+	Labels  map[string] []string
+}
+
+type Logger struct{}
+
+func NewClient(addr string, opts ...Option) (*Client, error) {
+	return &Client{Addr: addr}, nil
+}
+
+func (c *Client) Close() error { return nil }
+
+func (c Client) String() string { return c.Addr }
+`
+
+var _ = Describe("HaveFunction", func() {
+	It("should find top-level functions and methods", func() {
+		Expect(clientSource).Should(HaveFunction("NewClient"))
+		Expect([]byte(clientSource)).Should(HaveFunction("Client.Close"))
+		Expect(clientSource).Should(HaveFunction("Client.String"))
+		Expect(clientSource).ShouldNot(HaveFunction("Close"))
+		Expect(clientSource).ShouldNot(HaveFunction("Dial"))
+	})
+
+	It("should accept a parsed *ast.File", func() {
+		file, err := parser.ParseFile(token.NewFileSet(), "", clientSource, 0)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(file).Should(HaveFunction("NewClient"))
+	})
+
+	It("should list the declared functions when the function is missing", func() {
+		m := HaveFunction("Dial")
+		Expect(m.Match(clientSource)).Should(BeFalse())
+		Expect(m.FailureMessage(clientSource)).Should(Equal("Expected the source to declare function Dial.  It declares:\n    Client.Close\n    Client.String\n    NewClient"))
+	})
+
+	It("should apply sub-matchers to the *ast.FuncDecl", func() {
+		Expect(clientSource).Should(HaveFunction("NewClient", BeAssignableToTypeOf(&ast.FuncDecl{}), HaveField("Name.Name", "NewClient")))
+		Expect(clientSource).ShouldNot(HaveFunction("NewClient", HaveField("Recv", Not(BeNil()))))
+	})
+
+	Describe("WithSignature", func() {
+		It("should compare signatures, ignoring parameter names", func() {
+			Expect(clientSource).Should(HaveFunction("NewClient", WithSignature("func(addr string, opts ...Option) (*Client, error)")))
+			Expect(clientSource).Should(HaveFunction("NewClient", WithSignature("func(string, ...Option) (*Client, error)")))
+			Expect(clientSource).Should(HaveFunction("NewClient", WithSignature("(string, ...Option) (*Client,error)")))
+			Expect(clientSource).Should(HaveFunction("Client.Close", WithSignature("func() error")))
+			Expect(clientSource).ShouldNot(HaveFunction("NewClient", WithSignature("func(string) (*Client, error)")))
+		})
+
+		It("should report both signatures", func() {
+			m := HaveFunction("Client.Close", WithSignature("func(force bool) error"))
+			Expect(m.Match(clientSource)).Should(BeFalse())
+			Expect(m.FailureMessage(clientSource)).Should(Equal("Function Client.Close failed to satisfy matcher.\nExpected\n    <string>: func() error\nto be the signature\n    <string>: func(bool) error"))
+		})
+
+		It("should error on invalid signatures", func() {
+			_, err := HaveFunction("NewClient", WithSignature("func(")).Match(clientSource)
+			Expect(err).Should(MatchError(ContainSubstring("could not parse signature")))
+		})
+	})
+
+	It("should error on source that does not parse", func() {
+		_, err := HaveFunction("Foo").Match("package foo\nfunc Foo( {")
+		Expect(err).Should(MatchError(ContainSubstring("HaveFunction matcher could not parse the Go source")))
+
+		_, err = HaveFunction("Foo").Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("expects Go source")))
+	})
+})
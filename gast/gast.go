@@ -0,0 +1,78 @@
+/*
+Package gast provides Gomega matchers for Go source code.  It is intended for testing code generators without resorting to
+string-matching the code they generate:
+
+	source := generator.Generate()
+	Expect(source).To(gast.BeGofmted())
+	Expect(source).To(gast.HaveFunction("NewClient", gast.WithSignature("func(addr string, opts ...Option) (*Client, error)")))
+	Expect(source).To(gast.HaveStructField("Client", "Timeout", gast.WithType("time.Duration")))
+
+The matchers accept source text as a string or []byte, or an already-parsed *ast.File.  Source that does not parse is an error.
+*/
+package gast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+func parseSource(matcherName string, actual interface{}) (*ast.File, error) {
+	var src interface{}
+	switch x := actual.(type) {
+	case *ast.File:
+		return x, nil
+	case string:
+		src = x
+	case []byte:
+		src = x
+	default:
+		return nil, fmt.Errorf("%s matcher expects Go source as a string, []byte, or *ast.File.  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("%s matcher could not parse the Go source: %s", matcherName, err.Error())
+	}
+	return file, nil
+}
+
+// typeString renders a type expression in canonical form, e.g. "map[string][]*Foo"
+func typeString(expr ast.Expr) string {
+	return types.ExprString(expr)
+}
+
+// signatureString renders a function type with parameter names dropped, e.g. "func(int, ...string) (bool, error)"
+func signatureString(funcType *ast.FuncType) string {
+	signature := "func(" + strings.Join(fieldListTypes(funcType.Params), ", ") + ")"
+	results := fieldListTypes(funcType.Results)
+	switch {
+	case len(results) == 1:
+		signature += " " + results[0]
+	case len(results) > 1:
+		signature += " (" + strings.Join(results, ", ") + ")"
+	}
+	return signature
+}
+
+func fieldListTypes(fields *ast.FieldList) []string {
+	out := []string{}
+	if fields == nil {
+		return out
+	}
+	for _, field := range fields.List {
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			out = append(out, typeString(field.Type))
+		}
+	}
+	return out
+}
@@ -0,0 +1,13 @@
+package gast_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGast(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gast Suite")
+}
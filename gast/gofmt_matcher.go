@@ -0,0 +1,78 @@
+package gast
+
+import (
+	"fmt"
+	"go/ast"
+	goformat "go/format"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+BeGofmted succeeds if the Go source (a string or []byte) is formatted exactly as gofmt would format it.  On failure
+it reports the first line gofmt would change.
+*/
+func BeGofmted() types.GomegaMatcher {
+	return &beGofmtedMatcher{}
+}
+
+type beGofmtedMatcher struct {
+	// state
+	line      int
+	actual    string
+	formatted string
+}
+
+func (m *beGofmtedMatcher) Match(actual interface{}) (success bool, err error) {
+	var src []byte
+	switch x := actual.(type) {
+	case string:
+		src = []byte(x)
+	case []byte:
+		src = x
+	case *ast.File:
+		return false, fmt.Errorf("BeGofmted matcher expects Go source as a string or []byte - an *ast.File has no formatting to check")
+	default:
+		return false, fmt.Errorf("BeGofmted matcher expects Go source as a string or []byte.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	formatted, err := goformat.Source(src)
+	if err != nil {
+		return false, fmt.Errorf("BeGofmted matcher could not parse the Go source: %s", err.Error())
+	}
+
+	m.line, m.actual, m.formatted = firstDifferingLine(string(src), string(formatted))
+	return m.line == 0, nil
+}
+
+func (m *beGofmtedMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the source to be gofmted.  gofmt would change line %d from\n%s\nto\n%s",
+		m.line, format.IndentString(m.actual, 1), format.IndentString(m.formatted, 1))
+}
+
+func (m *beGofmtedMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return "Expected the source not to be gofmted.  It is."
+}
+
+// firstDifferingLine returns the 1-based number of the first line that differs between a and b (or 0 if they are identical)
+// along with the contents of that line in each
+func firstDifferingLine(a, b string) (int, string, string) {
+	if a == b {
+		return 0, "", ""
+	}
+	aLines, bLines := strings.Split(a, "\n"), strings.Split(b, "\n")
+	for i := 0; ; i++ {
+		var aLine, bLine string
+		if i < len(aLines) {
+			aLine = aLines[i]
+		}
+		if i < len(bLines) {
+			bLine = bLines[i]
+		}
+		if aLine != bLine || i >= len(aLines) || i >= len(bLines) {
+			return i + 1, aLine, bLine
+		}
+	}
+}
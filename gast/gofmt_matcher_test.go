@@ -0,0 +1,29 @@
+package gast_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gast"
+)
+
+var _ = Describe("BeGofmted", func() {
+	It("should succeed on gofmted source", func() {
+		Expect("package foo\n\nfunc Foo() {}\n").Should(BeGofmted())
+		Expect([]byte("package foo\n")).Should(BeGofmted())
+	})
+
+	It("should report the first line gofmt would change", func() {
+		source := "package foo\n\nfunc Foo() {\n  return\n}\n"
+		m := BeGofmted()
+		Expect(m.Match(source)).Should(BeFalse())
+		Expect(m.FailureMessage(source)).Should(Equal("Expected the source to be gofmted.  gofmt would change line 4 from\n      return\nto\n    \treturn"))
+	})
+
+	It("should error on invalid input", func() {
+		_, err := BeGofmted().Match("package foo\nfunc {")
+		Expect(err).Should(MatchError(ContainSubstring("could not parse")))
+
+		_, err = BeGofmted().Match(7)
+		Expect(err).Should(MatchError(ContainSubstring("expects Go source")))
+	})
+})
@@ -0,0 +1,221 @@
+package gast
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+HaveStructField succeeds if the Go source declares a struct type with the passed-in name, and that struct has a field
+with the passed-in name.  Embedded fields are named after their type (without any package qualifier or pointer).
+
+Any matchers passed in are applied to the field's *ast.Field:
+
+	Expect(source).To(HaveStructField("Config", "Timeout", WithType("time.Duration"), WithTag("json", "timeout")))
+*/
+func HaveStructField(structName string, fieldName string, matchers ...types.GomegaMatcher) types.GomegaMatcher {
+	return &haveStructFieldMatcher{
+		structName: structName,
+		fieldName:  fieldName,
+		matchers:   matchers,
+	}
+}
+
+type haveStructFieldMatcher struct {
+	structName string
+	fieldName  string
+	matchers   []types.GomegaMatcher
+
+	// state
+	foundStruct   bool
+	declared      []string
+	field         *ast.Field
+	failedMatcher types.GomegaMatcher
+}
+
+func (m *haveStructFieldMatcher) Match(actual interface{}) (success bool, err error) {
+	file, err := parseSource("HaveStructField", actual)
+	if err != nil {
+		return false, err
+	}
+
+	m.foundStruct, m.declared, m.field, m.failedMatcher = false, []string{}, nil, nil
+	ast.Inspect(file, func(node ast.Node) bool {
+		typeSpec, ok := node.(*ast.TypeSpec)
+		if !ok || typeSpec.Name.Name != m.structName {
+			return true
+		}
+		structType, ok := typeSpec.Type.(*ast.StructType)
+		if !ok {
+			return true
+		}
+		m.foundStruct = true
+		for _, field := range structType.Fields.List {
+			for _, name := range fieldNames(field) {
+				m.declared = append(m.declared, name)
+				if name == m.fieldName {
+					m.field = field
+				}
+			}
+		}
+		return false
+	})
+	sort.Strings(m.declared)
+
+	if m.field == nil {
+		return false, nil
+	}
+
+	for _, matcher := range m.matchers {
+		success, err := matcher.Match(m.field)
+		if err != nil {
+			return false, err
+		}
+		if !success {
+			m.failedMatcher = matcher
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (m *haveStructFieldMatcher) FailureMessage(actual interface{}) (message string) {
+	if !m.foundStruct {
+		return fmt.Sprintf("Expected the source to declare struct %s.  It does not.", m.structName)
+	}
+	if m.field == nil {
+		return fmt.Sprintf("Expected struct %s to have field %s.  It has:\n%s", m.structName, m.fieldName, describeNames(m.declared))
+	}
+	return fmt.Sprintf("Field %s.%s failed to satisfy matcher.\n%s", m.structName, m.fieldName, m.failedMatcher.FailureMessage(m.field))
+}
+
+func (m *haveStructFieldMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if len(m.matchers) == 0 {
+		return fmt.Sprintf("Expected struct %s not to have field %s.  It does.", m.structName, m.fieldName)
+	}
+	return fmt.Sprintf("Expected struct %s not to have field %s satisfying the matchers.  It does.", m.structName, m.fieldName)
+}
+
+func fieldNames(field *ast.Field) []string {
+	if len(field.Names) == 0 {
+		return []string{embeddedFieldName(field.Type)}
+	}
+	names := []string{}
+	for _, name := range field.Names {
+		names = append(names, name.Name)
+	}
+	return names
+}
+
+func embeddedFieldName(expr ast.Expr) string {
+	switch x := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedFieldName(x.X)
+	case *ast.SelectorExpr:
+		return x.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedFieldName(x.X)
+	case *ast.IndexListExpr:
+		return embeddedFieldName(x.X)
+	default:
+		return typeString(expr)
+	}
+}
+
+/*
+WithType is used with HaveStructField to assert on a field's type.  Types are compared in canonical form, so
+whitespace does not matter:
+
+	WithType("map[string] []int")
+*/
+func WithType(typeExpr string) types.GomegaMatcher {
+	return &withTypeMatcher{
+		typeExpr: typeExpr,
+	}
+}
+
+type withTypeMatcher struct {
+	typeExpr string
+
+	// state
+	expected string
+	actual   string
+}
+
+func (m *withTypeMatcher) Match(actual interface{}) (success bool, err error) {
+	field, ok := actual.(*ast.Field)
+	if !ok {
+		return false, fmt.Errorf("WithType matcher expects an *ast.Field.  Got:\n%s", format.Object(actual, 1))
+	}
+	expr, err := parser.ParseExpr(m.typeExpr)
+	if err != nil {
+		return false, fmt.Errorf("WithType matcher could not parse type %q: %s", m.typeExpr, err.Error())
+	}
+
+	m.expected, m.actual = typeString(expr), typeString(field.Type)
+	return m.expected == m.actual, nil
+}
+
+func (m *withTypeMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(m.actual, "to be the type", m.expected)
+}
+
+func (m *withTypeMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(m.actual, "not to be the type", m.expected)
+}
+
+/*
+WithTag is used with HaveStructField to assert on the value of one of a field's struct tags:
+
+	WithTag("json", "timeout,omitempty")
+*/
+func WithTag(key string, value string) types.GomegaMatcher {
+	return &withTagMatcher{
+		key:   key,
+		value: value,
+	}
+}
+
+type withTagMatcher struct {
+	key   string
+	value string
+
+	// state
+	actual string
+	found  bool
+}
+
+func (m *withTagMatcher) Match(actual interface{}) (success bool, err error) {
+	field, ok := actual.(*ast.Field)
+	if !ok {
+		return false, fmt.Errorf("WithTag matcher expects an *ast.Field.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	m.actual, m.found = "", false
+	if field.Tag != nil {
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			return false, fmt.Errorf("WithTag matcher could not parse struct tag %s: %s", field.Tag.Value, err.Error())
+		}
+		m.actual, m.found = reflect.StructTag(tag).Lookup(m.key)
+	}
+	return m.found && m.actual == m.value, nil
+}
+
+func (m *withTagMatcher) FailureMessage(actual interface{}) (message string) {
+	if !m.found {
+		return fmt.Sprintf("Expected the field to have a %q tag.  It does not.", m.key)
+	}
+	return format.Message(m.actual, fmt.Sprintf("to be the %q tag", m.key), m.value)
+}
+
+func (m *withTagMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(m.actual, fmt.Sprintf("not to be the %q tag", m.key), m.value)
+}
@@ -0,0 +1,56 @@
+package gast_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gast"
+)
+
+var _ = Describe("HaveStructField", func() {
+	It("should find named, grouped, and embedded fields", func() {
+		Expect(clientSource).Should(HaveStructField("Client", "Addr"))
+		Expect(clientSource).Should(HaveStructField("Client", "backoff"))
+		Expect(clientSource).Should(HaveStructField("Client", "Logger"))
+		Expect(clientSource).ShouldNot(HaveStructField("Client", "Port"))
+		Expect(clientSource).ShouldNot(HaveStructField("Server", "Addr"))
+	})
+
+	It("should explain what is missing", func() {
+		m := HaveStructField("Client", "Port")
+		Expect(m.Match(clientSource)).Should(BeFalse())
+		Expect(m.FailureMessage(clientSource)).Should(Equal("Expected struct Client to have field Port.  It has:\n    Addr\n    Labels\n    Logger\n    Timeout\n    backoff\n    retries"))
+
+		m = HaveStructField("Server", "Addr")
+		Expect(m.Match(clientSource)).Should(BeFalse())
+		Expect(m.FailureMessage(clientSource)).Should(Equal("Expected the source to declare struct Server.  It does not."))
+	})
+
+	Describe("WithType", func() {
+		It("should compare types in canonical form", func() {
+			Expect(clientSource).Should(HaveStructField("Client", "Timeout", WithType("time.Duration")))
+			Expect(clientSource).Should(HaveStructField("Client", "Labels", WithType("map[string][]string")))
+			Expect(clientSource).Should(HaveStructField("Client", "Logger", WithType("*Logger")))
+			Expect(clientSource).ShouldNot(HaveStructField("Client", "retries", WithType("int64")))
+		})
+
+		It("should report both types", func() {
+			m := HaveStructField("Client", "Timeout", WithType("int"))
+			Expect(m.Match(clientSource)).Should(BeFalse())
+			Expect(m.FailureMessage(clientSource)).Should(Equal("Field Client.Timeout failed to satisfy matcher.\nExpected\n    <string>: time.Duration\nto be the type\n    <string>: int"))
+		})
+	})
+
+	Describe("WithTag", func() {
+		It("should compare the value of a struct tag", func() {
+			Expect(clientSource).Should(HaveStructField("Client", "Timeout", WithTag("json", "timeout,omitempty"), WithTag("yaml", "timeout")))
+			Expect(clientSource).ShouldNot(HaveStructField("Client", "Addr", WithTag("json", "address")))
+			Expect(clientSource).ShouldNot(HaveStructField("Client", "Labels", WithTag("json", "")))
+		})
+
+		It("should report missing tags", func() {
+			m := HaveStructField("Client", "Addr", WithTag("yaml", "addr"))
+			Expect(m.Match(clientSource)).Should(BeFalse())
+			Expect(m.FailureMessage(clientSource)).Should(HaveSuffix(`Expected the field to have a "yaml" tag.  It does not.`))
+		})
+	})
+})
@@ -17,7 +17,6 @@ import (
 	"io"
 	"regexp"
 	"sync"
-	"time"
 )
 
 /*
@@ -193,37 +192,7 @@ func (b *Buffer) Detect(desired string, args ...interface{}) chan bool {
 		b.detectCloser = make(chan interface{})
 	}
 
-	closer := b.detectCloser
-	response := make(chan bool)
-	go func() {
-		ticker := time.NewTicker(10 * time.Millisecond)
-		defer ticker.Stop()
-		defer close(response)
-		for {
-			select {
-			case <-ticker.C:
-				b.lock.Lock()
-				data, cursor := b.contents[b.readCursor:], b.readCursor
-				loc := re.FindIndex(data)
-				b.lock.Unlock()
-
-				if loc != nil {
-					response <- true
-					b.lock.Lock()
-					newCursorPosition := cursor + uint64(loc[1])
-					if newCursorPosition >= b.readCursor {
-						b.readCursor = newCursorPosition
-					}
-					b.lock.Unlock()
-					return
-				}
-			case <-closer:
-				return
-			}
-		}
-	}()
-
-	return response
+	return detect(b, b.detectCloser, re)
 }
 
 /*
@@ -237,19 +206,20 @@ func (b *Buffer) CancelDetects() {
 	b.detectCloser = nil
 }
 
-func (b *Buffer) didSay(re *regexp.Regexp) (bool, []byte) {
+/*
+ScanUnread implements the types.StreamingBuffer interface.  It calls scan with the unread portion of the buffer and
+fast-forwards the read cursor by the number of bytes scan returns.
+
+You typically don't need to call ScanUnread directly and should use the gbytes.Say matcher instead.
+*/
+func (b *Buffer) ScanUnread(scan func(cursor uint64, unread []byte) (advance uint64)) {
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
-	unreadBytes := b.contents[b.readCursor:]
-	copyOfUnreadBytes := make([]byte, len(unreadBytes))
-	copy(copyOfUnreadBytes, unreadBytes)
-
-	loc := re.FindIndex(unreadBytes)
-
-	if loc != nil {
-		b.readCursor += uint64(loc[1])
-		return true, copyOfUnreadBytes
+	unread := b.contents[b.readCursor:]
+	advance := scan(b.readCursor, unread)
+	if advance > uint64(len(unread)) {
+		advance = uint64(len(unread))
 	}
-	return false, copyOfUnreadBytes
+	b.readCursor += advance
 }
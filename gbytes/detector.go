@@ -0,0 +1,116 @@
+package gbytes
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/onsi/gomega/types"
+)
+
+/*
+Detector brings Buffer.Detect to any types.StreamingBuffer.  This is useful when you've plugged your own buffer implementation
+(e.g. a ring buffer) into gbytes:
+
+	detector := gbytes.NewDetector(ringBuffer)
+	select {
+	case <-detector.Detect("You are not logged in"):
+		//log in
+	case <-detector.Detect("Success"):
+		//carry on
+	case <-time.After(time.Second):
+		//welp
+	}
+	detector.CancelDetects()
+
+As with Buffer.Detect, you should always call CancelDetects when you're done with a set of Detect channels.
+*/
+type Detector struct {
+	buffer       types.StreamingBuffer
+	lock         *sync.Mutex
+	detectCloser chan interface{}
+}
+
+/*
+NewDetector returns a Detector that watches the passed-in types.StreamingBuffer
+*/
+func NewDetector(buffer types.StreamingBuffer) *Detector {
+	return &Detector{
+		buffer: buffer,
+		lock:   &sync.Mutex{},
+	}
+}
+
+/*
+Detect takes a regular expression and returns a channel.  It behaves just like Buffer.Detect: the channel will receive true the
+first time data matching the regular expression appears in the unread portion of the buffer.  The channel is subsequently closed
+and the buffer's read-cursor is fast-forwarded to just after the matching region.
+
+You can pass Detect a format string followed by variadic arguments.  This will construct the regexp using fmt.Sprintf.
+*/
+func (d *Detector) Detect(desired string, args ...interface{}) chan bool {
+	formattedRegexp := desired
+	if len(args) > 0 {
+		formattedRegexp = fmt.Sprintf(desired, args...)
+	}
+	re := regexp.MustCompile(formattedRegexp)
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.detectCloser == nil {
+		d.detectCloser = make(chan interface{})
+	}
+
+	return detect(d.buffer, d.detectCloser, re)
+}
+
+/*
+CancelDetects cancels any pending detects and cleans up their goroutines.
+*/
+func (d *Detector) CancelDetects() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.detectCloser != nil {
+		close(d.detectCloser)
+		d.detectCloser = nil
+	}
+}
+
+func detect(buffer types.StreamingBuffer, closer chan interface{}, re *regexp.Regexp) chan bool {
+	response := make(chan bool)
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		defer close(response)
+		for {
+			select {
+			case <-ticker.C:
+				found, target := false, uint64(0)
+				buffer.ScanUnread(func(cursor uint64, unread []byte) uint64 {
+					if loc := re.FindIndex(unread); loc != nil {
+						found, target = true, cursor+uint64(loc[1])
+					}
+					return 0
+				})
+
+				if found {
+					response <- true
+					buffer.ScanUnread(func(cursor uint64, unread []byte) uint64 {
+						if target >= cursor {
+							return target - cursor
+						}
+						return 0
+					})
+					return
+				}
+			case <-closer:
+				return
+			}
+		}
+	}()
+
+	return response
+}
@@ -0,0 +1,65 @@
+package gbytes_test
+
+import (
+	"time"
+
+	. "github.com/onsi/gomega/gbytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Detector", func() {
+	var buffer *lineBuffer
+	var detector *Detector
+
+	BeforeEach(func() {
+		buffer = &lineBuffer{}
+		detector = NewDetector(buffer)
+	})
+
+	AfterEach(func() {
+		detector.CancelDetects()
+	})
+
+	It("should fire the matching channel and fast-forward the read cursor", func() {
+		A := detector.Detect("%s", "a.c")
+		B := detector.Detect("def")
+
+		buffer.Write([]byte("abcde"))
+		Eventually(A).Should(Receive())
+		Eventually(buffer.Cursor).Should(BeEquivalentTo(3))
+		Consistently(B).ShouldNot(Receive())
+
+		buffer.Write([]byte("f"))
+		Eventually(B).Should(Receive())
+		Eventually(buffer.Cursor).Should(BeEquivalentTo(6))
+	})
+
+	It("should close pending channels when CancelDetects is called", func() {
+		A := detector.Detect("abc")
+		detector.CancelDetects()
+
+		Eventually(A).Should(BeClosed())
+	})
+
+	It("should be reusable after CancelDetects", func() {
+		detector.CancelDetects()
+		buffer.Write([]byte("abc"))
+
+		select {
+		case <-detector.Detect("abc"):
+		case <-time.After(time.Second):
+			Fail("should have detected")
+		}
+	})
+
+	It("should also work with a *Buffer", func() {
+		b := BufferWithBytes([]byte("abcdef"))
+		d := NewDetector(b)
+		defer d.CancelDetects()
+
+		Eventually(d.Detect("abc")).Should(Receive())
+		Eventually(b).Should(Say("^def"))
+	})
+})
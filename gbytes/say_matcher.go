@@ -7,6 +7,7 @@ import (
 	"regexp"
 
 	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
 )
 
 //Objects satisfying the BufferProvider can be used with the Say matcher.
@@ -35,6 +36,9 @@ Ditto with consistently.  To assert that a buffer does not receive data matching
 In addition to bytes.Buffers, Say can operate on objects that implement the gbytes.BufferProvider interface.
 In such cases, Say simply operates on the *gbytes.Buffer returned by Buffer()
 
+Say can also operate on any object that implements the types.StreamingBuffer interface.  This allows you to plug
+in your own buffer implementations.
+
 If the buffer is closed, the Say matcher will tell Eventually to abort.
 */
 func Say(expected string, args ...interface{}) *sayMatcher {
@@ -51,31 +55,38 @@ type sayMatcher struct {
 	receivedSayings []byte
 }
 
-func (m *sayMatcher) buffer(actual interface{}) (*Buffer, bool) {
-	var buffer *Buffer
-
+func (m *sayMatcher) buffer(actual interface{}) (types.StreamingBuffer, bool) {
 	switch x := actual.(type) {
 	case *Buffer:
-		buffer = x
+		return x, true
 	case BufferProvider:
-		buffer = x.Buffer()
+		return x.Buffer(), true
+	case types.StreamingBuffer:
+		return x, true
 	default:
 		return nil, false
 	}
-
-	return buffer, true
 }
 
 func (m *sayMatcher) Match(actual interface{}) (success bool, err error) {
 	buffer, ok := m.buffer(actual)
 	if !ok {
-		return false, fmt.Errorf("Say must be passed a *gbytes.Buffer or BufferProvider.  Got:\n%s", format.Object(actual, 1))
+		return false, fmt.Errorf("Say must be passed a *gbytes.Buffer, BufferProvider, or types.StreamingBuffer.  Got:\n%s", format.Object(actual, 1))
 	}
 
-	didSay, sayings := buffer.didSay(m.re)
-	m.receivedSayings = sayings
+	buffer.ScanUnread(func(_ uint64, unread []byte) uint64 {
+		m.receivedSayings = make([]byte, len(unread))
+		copy(m.receivedSayings, unread)
 
-	return didSay, nil
+		loc := m.re.FindIndex(unread)
+		if loc == nil {
+			return 0
+		}
+		success = true
+		return uint64(loc[1])
+	})
+
+	return success, nil
 }
 
 func (m *sayMatcher) FailureMessage(actual interface{}) (message string) {
@@ -95,12 +106,9 @@ func (m *sayMatcher) NegatedFailureMessage(actual interface{}) (message string)
 }
 
 func (m *sayMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
-	switch x := actual.(type) {
-	case *Buffer:
-		return !x.Closed()
-	case BufferProvider:
-		return !x.Buffer().Closed()
-	default:
+	buffer, ok := m.buffer(actual)
+	if !ok {
 		return true
 	}
+	return !buffer.Closed()
 }
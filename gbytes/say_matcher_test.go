@@ -1,6 +1,7 @@
 package gbytes_test
 
 import (
+	"sync"
 	"time"
 
 	. "github.com/onsi/gomega/gbytes"
@@ -17,6 +18,38 @@ func (s *speaker) Buffer() *Buffer {
 	return s.buffer
 }
 
+// lineBuffer is a minimal types.StreamingBuffer that is not backed by a *gbytes.Buffer
+type lineBuffer struct {
+	lock     sync.Mutex
+	contents []byte
+	cursor   uint64
+	closed   bool
+}
+
+func (b *lineBuffer) Write(p []byte) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.contents = append(b.contents, p...)
+}
+
+func (b *lineBuffer) ScanUnread(scan func(cursor uint64, unread []byte) uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.cursor += scan(b.cursor, b.contents[b.cursor:])
+}
+
+func (b *lineBuffer) Cursor() uint64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.cursor
+}
+
+func (b *lineBuffer) Closed() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.closed
+}
+
 var _ = Describe("SayMatcher", func() {
 	var buffer *Buffer
 
@@ -166,4 +199,38 @@ var _ = Describe("SayMatcher", func() {
 			Expect(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
 		})
 	})
+
+	When("actual is a types.StreamingBuffer", func() {
+		It("should scan the unread portion of the buffer", func() {
+			b := &lineBuffer{}
+			Expect(b).ShouldNot(Say("abc"))
+
+			b.Write([]byte("abcdef"))
+			Expect(b).Should(Say("abc"))
+			Expect(b).ShouldNot(Say("abc"))
+			Expect(b).Should(Say("def"))
+		})
+
+		It("should report the unread portion on failure", func() {
+			b := &lineBuffer{}
+			b.Write([]byte("abc"))
+			Expect(b).Should(Say("a"))
+
+			failures := InterceptGomegaFailures(func() {
+				Expect(b).Should(Say("xyz"))
+			})
+			Expect(failures[0]).Should(ContainSubstring("Got stuck at:\n    bc\n"))
+		})
+
+		It("should abort an eventually when closed", func() {
+			b := &lineBuffer{closed: true}
+
+			t := time.Now()
+			failures := InterceptGomegaFailures(func() {
+				Eventually(b).Should(Say("def"))
+			})
+			Expect(failures).Should(HaveLen(1))
+			Expect(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+		})
+	})
 })
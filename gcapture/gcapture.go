@@ -0,0 +1,160 @@
+/*
+Package gcapture captures what in-process code writes to os.Stdout, os.Stderr, and the standard logger so that you
+can make assertions on it with gbytes.Say - without having to move the code under test into a subprocess:
+
+	capture, err := gcapture.Run(func() {
+		cli.PrintUsage()
+	})
+	Expect(err).ShouldNot(HaveOccurred())
+	Expect(capture).Should(gbytes.Say("Usage:"))
+	Expect(capture.Err).ShouldNot(gbytes.Say("deprecated"))
+
+os.Stdout, os.Stderr, and the standard logger are process-wide, so only one capture can be active at a time and specs
+that capture output should not run concurrently with code that writes to them.
+*/
+package gcapture
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/onsi/gomega/gbytes"
+)
+
+var (
+	lock   sync.Mutex
+	active *Capture
+)
+
+/*
+A Capture holds the output captured between a call to Start and a call to Stop.
+
+Out receives everything written to os.Stdout and Err receives everything written to os.Stderr and to the standard
+logger.  Capture satisfies the gbytes.BufferProvider interface and provides Out, so you can Say against it directly.
+*/
+type Capture struct {
+	Out *gbytes.Buffer
+	Err *gbytes.Buffer
+
+	stdout    *os.File
+	stderr    *os.File
+	logWriter io.Writer
+
+	outWriter *os.File
+	errWriter *os.File
+	copying   sync.WaitGroup
+	stopOnce  sync.Once
+	stopErr   error
+}
+
+/*
+Start redirects os.Stdout, os.Stderr, and the standard logger's output into a new Capture.  You must call Stop to
+restore them - typically with DeferCleanup:
+
+	capture, err := gcapture.Start()
+	Expect(err).ShouldNot(HaveOccurred())
+	DeferCleanup(capture.Stop)
+
+Start returns an error if another capture is already active.
+*/
+func Start() (*Capture, error) {
+	lock.Lock()
+	defer lock.Unlock()
+	if active != nil {
+		return nil, errors.New("gcapture is already capturing output - call Stop on the active capture first")
+	}
+
+	outReader, outWriter, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pipe for os.Stdout: %w", err)
+	}
+	errReader, errWriter, err := os.Pipe()
+	if err != nil {
+		outReader.Close()
+		outWriter.Close()
+		return nil, fmt.Errorf("failed to create pipe for os.Stderr: %w", err)
+	}
+
+	capture := &Capture{
+		Out:       gbytes.NewBuffer(),
+		Err:       gbytes.NewBuffer(),
+		stdout:    os.Stdout,
+		stderr:    os.Stderr,
+		logWriter: log.Writer(),
+		outWriter: outWriter,
+		errWriter: errWriter,
+	}
+	capture.copying.Add(2)
+	go capture.copy(capture.Out, outReader)
+	go capture.copy(capture.Err, errReader)
+
+	os.Stdout = outWriter
+	os.Stderr = errWriter
+	log.SetOutput(errWriter)
+	active = capture
+	return capture, nil
+}
+
+func (c *Capture) copy(buffer *gbytes.Buffer, reader *os.File) {
+	defer c.copying.Done()
+	io.Copy(buffer, reader)
+	reader.Close()
+}
+
+/*
+Stop restores os.Stdout, os.Stderr, and the standard logger's output, and waits until everything written before
+the call to Stop has made it into Out and Err.  Both buffers are closed once Stop returns.
+
+It is safe to call Stop more than once.
+*/
+func (c *Capture) Stop() error {
+	c.stopOnce.Do(func() {
+		lock.Lock()
+		os.Stdout = c.stdout
+		os.Stderr = c.stderr
+		log.SetOutput(c.logWriter)
+		active = nil
+		lock.Unlock()
+
+		if err := c.outWriter.Close(); err != nil {
+			c.stopErr = fmt.Errorf("failed to close captured os.Stdout: %w", err)
+		}
+		if err := c.errWriter.Close(); err != nil && c.stopErr == nil {
+			c.stopErr = fmt.Errorf("failed to close captured os.Stderr: %w", err)
+		}
+		c.copying.Wait()
+		c.Out.Close()
+		c.Err.Close()
+	})
+	return c.stopErr
+}
+
+/*
+Buffer implements the gbytes.BufferProvider interface and returns c.Out
+*/
+func (c *Capture) Buffer() *gbytes.Buffer {
+	return c.Out
+}
+
+/*
+Run calls fn while capturing its output and returns the Capture once fn has returned.  os.Stdout, os.Stderr, and
+the standard logger are restored even if fn panics, in which case Run re-panics with the same value once they are.
+*/
+func Run(fn func()) (capture *Capture, err error) {
+	capture, err = Start()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		stopErr := capture.Stop()
+		if err == nil {
+			err = stopErr
+		}
+	}()
+	fn()
+	return capture, nil
+}
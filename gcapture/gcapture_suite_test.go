@@ -0,0 +1,13 @@
+package gcapture_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGcapture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gcapture Suite")
+}
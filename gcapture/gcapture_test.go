@@ -0,0 +1,96 @@
+package gcapture_test
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gcapture"
+)
+
+var _ = Describe("Capturing output", func() {
+	var stdout, stderr *os.File
+
+	BeforeEach(func() {
+		stdout, stderr = os.Stdout, os.Stderr
+	})
+
+	Describe("Run", func() {
+		It("should capture os.Stdout, os.Stderr, and the standard logger", func() {
+			capture, err := gcapture.Run(func() {
+				fmt.Println("hello stdout")
+				fmt.Fprintln(os.Stderr, "hello stderr")
+				log.Print("hello log")
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(capture).Should(gbytes.Say("hello stdout"))
+			Expect(capture.Out).ShouldNot(gbytes.Say("hello"))
+			Expect(capture.Err).Should(gbytes.Say("hello stderr\n"))
+			Expect(capture.Err).Should(gbytes.Say("hello log"))
+			Expect(capture.Out.Closed()).Should(BeTrue())
+			Expect(capture.Err.Closed()).Should(BeTrue())
+		})
+
+		It("should restore os.Stdout, os.Stderr, and the standard logger", func() {
+			logWriter := log.Writer()
+			_, err := gcapture.Run(func() {
+				Expect(os.Stdout).ShouldNot(Equal(stdout))
+				Expect(os.Stderr).ShouldNot(Equal(stderr))
+			})
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(os.Stdout).Should(Equal(stdout))
+			Expect(os.Stderr).Should(Equal(stderr))
+			Expect(log.Writer()).Should(Equal(logWriter))
+		})
+
+		It("should restore them and re-panic when fn panics", func() {
+			logWriter := log.Writer()
+			Expect(func() {
+				gcapture.Run(func() {
+					panic("boom")
+				})
+			}).Should(PanicWith("boom"))
+			Expect(os.Stdout).Should(Equal(stdout))
+			Expect(os.Stderr).Should(Equal(stderr))
+			Expect(log.Writer()).Should(Equal(logWriter))
+
+			_, err := gcapture.Run(func() {})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Describe("Start and Stop", func() {
+		It("should capture output until Stop is called", func() {
+			capture, err := gcapture.Start()
+			Expect(err).ShouldNot(HaveOccurred())
+			DeferCleanup(capture.Stop)
+
+			fmt.Println("while capturing")
+			Eventually(capture).Should(gbytes.Say("while capturing"))
+
+			Expect(capture.Stop()).Should(Succeed())
+			Expect(capture.Stop()).Should(Succeed())
+			Expect(os.Stdout).Should(Equal(stdout))
+			Expect(capture.Out.Closed()).Should(BeTrue())
+		})
+
+		It("should not allow two captures at once", func() {
+			capture, err := gcapture.Start()
+			Expect(err).ShouldNot(HaveOccurred())
+			DeferCleanup(capture.Stop)
+
+			_, err = gcapture.Start()
+			Expect(err).Should(MatchError(ContainSubstring("already capturing output")))
+			_, err = gcapture.Run(func() {})
+			Expect(err).Should(HaveOccurred())
+
+			Expect(capture.Stop()).Should(Succeed())
+			_, err = gcapture.Run(func() {})
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,101 @@
+package gcustom
+
+import (
+	"reflect"
+
+	"github.com/onsi/gomega/types"
+)
+
+var delegateType = reflect.TypeOf(&Delegate{})
+
+/*
+Delegate is passed to the match functions of matchers built by MakeDelegatingMatcher.  Use its Match method to match
+parts of the actual with other matchers - their failure messages are then merged into the failure messages of your
+matcher.
+*/
+type Delegate struct {
+	delegations []delegation
+}
+
+type delegation struct {
+	matcher types.GomegaMatcher
+	actual  any
+	success bool
+}
+
+/*
+Match matches actual with matcher and returns its result.  If your match function goes on to fail because matcher
+did, matcher's failure message is merged into your matcher's.
+*/
+func (d *Delegate) Match(matcher types.GomegaMatcher, actual any) (bool, error) {
+	success, err := matcher.Match(actual)
+	if err == nil {
+		d.delegations = append(d.delegations, delegation{matcher: matcher, actual: actual, success: success})
+	}
+	return success, err
+}
+
+// innerFailure returns the failure message of the matcher that decided the outcome: the last to fail when the
+// outer matcher failed, or the last to succeed when it should have failed but did not
+func (d *Delegate) innerFailure(isFailure bool) string {
+	for i := len(d.delegations) - 1; i >= 0; i-- {
+		inner := d.delegations[i]
+		if isFailure && !inner.success {
+			return inner.matcher.FailureMessage(inner.actual)
+		}
+		if !isFailure && inner.success {
+			return inner.matcher.NegatedFailureMessage(inner.actual)
+		}
+	}
+	return ""
+}
+
+/*
+MakeDelegatingMatcher builds a Gomega-compatible matcher, like MakeMatcher, out of a function that delegates to other
+matchers.  Rather than roll their failure messages into yours by hand, pass the matchers to the *Delegate your function
+is given:
+
+	func HaveHealthyReplica(name string) OmegaMatcher {
+		return gcustom.MakeDelegatingMatcher(func(deployment Deployment, delegate *gcustom.Delegate) (bool, error) {
+			replica, ok := deployment.Replicas[name]
+			if !ok {
+				return false, nil
+			}
+			return delegate.Match(HaveField("Status", Equal("healthy")), replica)
+		}).WithMessage("have a healthy replica named " + name)
+	}
+
+When the matcher fails because a delegated matcher did, the delegated matcher's failure message is appended,
+indented, to the failure message:
+
+	Expected:
+		<formatted deployment>
+	to have a healthy replica named web
+		Value for field 'Status' failed to satisfy matcher.
+		Expected
+			<string>: degraded
+		to equal
+			<string>: healthy
+
+matchFunc must take the actual - of type any, or of a specific type, as with MakeMatcher - and a *Delegate, and return
+(bool, error).  The message can be configured in all the ways MakeMatcher's can.  Templates can place the delegated
+failure message themselves with {{.InnerFailure}}; it is only appended if they don't.
+*/
+func MakeDelegatingMatcher(matchFunc any, args ...any) CustomGomegaMatcher {
+	t := reflect.TypeOf(matchFunc)
+	if !(t.Kind() == reflect.Func && t.NumIn() == 2 && t.In(1) == delegateType && t.NumOut() == 2 && t.Out(0).Kind() == reflect.Bool && t.Out(1).Implements(errInterface)) {
+		panic("MakeDelegatingMatcher must be passed a function that takes an actual and a *gcustom.Delegate and returns (bool, error)")
+	}
+
+	delegate := &Delegate{}
+	matchFuncValue := reflect.ValueOf(matchFunc)
+	singleArgType := reflect.FuncOf([]reflect.Type{t.In(0)}, []reflect.Type{t.Out(0), t.Out(1)}, false)
+	singleArgFunc := reflect.MakeFunc(singleArgType, func(args []reflect.Value) []reflect.Value {
+		delegate.delegations = nil
+		return matchFuncValue.Call([]reflect.Value{args[0], reflect.ValueOf(delegate)})
+	})
+
+	matcher := MakeMatcher(singleArgFunc.Interface(), args...)
+	matcher.delegate = delegate
+	return matcher
+}
@@ -0,0 +1,92 @@
+package gcustom_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gcustom"
+)
+
+var _ = Describe("MakeDelegatingMatcher", func() {
+	haveNameOfLength := func(length int) gcustom.CustomGomegaMatcher {
+		return gcustom.MakeDelegatingMatcher(func(actual someType, delegate *gcustom.Delegate) (bool, error) {
+			return delegate.Match(HaveLen(length), actual.Name)
+		})
+	}
+
+	It("matches with the delegated matchers", func() {
+		Ω(someType{"bob"}).Should(haveNameOfLength(3))
+		Ω(someType{"bob"}).ShouldNot(haveNameOfLength(4))
+	})
+
+	It("checks the type of the actual, like MakeMatcher", func() {
+		success, err := haveNameOfLength(3).Match("bob")
+		Ω(success).Should(BeFalse())
+		Ω(err).Should(MatchError(ContainSubstring("Matcher expected actual of type <gcustom_test.someType>")))
+	})
+
+	It("passes on errors from the delegated matchers", func() {
+		m := gcustom.MakeDelegatingMatcher(func(actual any, delegate *gcustom.Delegate) (bool, error) {
+			return delegate.Match(HaveLen(1), actual)
+		})
+		success, err := m.Match(17)
+		Ω(success).Should(BeFalse())
+		Ω(err).Should(MatchError(ContainSubstring("HaveLen matcher expects a string/array/map/channel/slice")))
+	})
+
+	It("appends the delegated matcher's failure message, indented", func() {
+		m := haveNameOfLength(4).WithMessage("have a name of length 4")
+		Ω(m.Match(someType{"bob"})).Should(BeFalse())
+		Ω(m.FailureMessage(someType{"bob"})).Should(Equal(`Expected:
+    <gcustom_test.someType>: {Name: "bob"}
+to have a name of length 4
+    Expected
+        <string>: bob
+    to have length 4`))
+	})
+
+	It("appends the negated failure message of the delegated matcher that succeeded", func() {
+		m := haveNameOfLength(3).WithMessage("have a name of length 3")
+		Ω(m.Match(someType{"bob"})).Should(BeTrue())
+		Ω(m.NegatedFailureMessage(someType{"bob"})).Should(Equal(`Expected:
+    <gcustom_test.someType>: {Name: "bob"}
+not to have a name of length 3
+    Expected
+        <string>: bob
+    not to have length 3`))
+	})
+
+	It("reports the last delegated matcher to decide the outcome", func() {
+		m := gcustom.MakeDelegatingMatcher(func(actual []int, delegate *gcustom.Delegate) (bool, error) {
+			for _, n := range actual {
+				if success, err := delegate.Match(BeNumerically("<", 10), n); !success || err != nil {
+					return success, err
+				}
+			}
+			return true, nil
+		})
+		Ω(m.Match([]int{1, 2, 11, 3})).Should(BeFalse())
+		Ω(m.FailureMessage([]int{1, 2, 11, 3})).Should(HaveSuffix("\n    Expected\n        <int>: 11\n    to be <\n        <int>: 10"))
+	})
+
+	It("does not append anything when no delegated matcher decided the outcome", func() {
+		m := gcustom.MakeDelegatingMatcher(func(actual someType, delegate *gcustom.Delegate) (bool, error) {
+			return actual.Name != "", nil
+		}, "have a name")
+		Ω(m.Match(someType{})).Should(BeFalse())
+		Ω(m.FailureMessage(someType{})).Should(HaveSuffix("to have a name"))
+	})
+
+	It("lets templates place the delegated failure message themselves", func() {
+		m := haveNameOfLength(4).WithTemplate("{{.To}} have a short name, but:\n{{.InnerFailure}}\n(names must have 4 letters)")
+		Ω(m.Match(someType{"bob"})).Should(BeFalse())
+		Ω(m.FailureMessage(someType{"bob"})).Should(Equal("to have a short name, but:\n    Expected\n        <string>: bob\n    to have length 4\n(names must have 4 letters)"))
+	})
+
+	It("panics when passed an invalid match function", func() {
+		Ω(func() {
+			gcustom.MakeDelegatingMatcher(func(actual any) (bool, error) { return false, errors.New("bam") })
+		}).Should(PanicWith("MakeDelegatingMatcher must be passed a function that takes an actual and a *gcustom.Delegate and returns (bool, error)"))
+	})
+})
@@ -31,11 +31,17 @@ ParseTemplate allows you to precompile templates for MakeMatcher's custom matche
 Use ParseTemplate if you are concerned about performance and would like to avoid repeatedly parsing failure message templates.  The data made available to the template is documented in the WithTemplate() method of CustomGomegaMatcher.
 
 Once parsed you can pass the template in either as an argument to MakeMatcher(matchFunc, <template>) or using MakeMatcher(matchFunc).WithPrecompiledTemplate(template)
+
+You can make additional functions available to the template by passing in one or more template.FuncMaps.  These can override gcustom's format function.
 */
-func ParseTemplate(templ string) (*template.Template, error) {
-	return template.New("template").Funcs(template.FuncMap{
+func ParseTemplate(templ string, funcs ...template.FuncMap) (*template.Template, error) {
+	t := template.New("template").Funcs(template.FuncMap{
 		"format": formatObject,
-	}).Parse(templ)
+	})
+	for _, f := range funcs {
+		t = t.Funcs(f)
+	}
+	return t.Parse(templ)
 }
 
 /*
@@ -130,6 +136,8 @@ type CustomGomegaMatcher struct {
 	matchFunc                   func(actual any) (bool, error)
 	templateMessage             *template.Template
 	templateData                any
+	templateParams              any
+	delegate                    *Delegate
 	customFailureMessage        func(actual any) string
 	customNegatedFailureMessage func(actual any) string
 }
@@ -161,6 +169,8 @@ Templates are provided the following variables and functions:
 {{.To}} - is set to "to" if this is a positive failure message and "not to" if this is a negated failure message
 {{.Actual}} - the actual passed in to the matcher
 {{.FormattedActual}} - a string representing the formatted actual.  This can be multiple lines and is always generated with an indentation of 1
+{{.Params}} - the parameters the matcher was built with, for matchers built by a MatcherFactory
+{{.InnerFailure}} - the failure message of the matcher delegated to, indented by 1, for matchers built by MakeDelegatingMatcher
 {{format <object> <optional-indentation}} - a function that allows you to use Gomega's default formatting from within the template.  The passed-in <object> is formatted and <optional-indentation> can be set to an integer to control indentation.
 
 In addition, you can provide custom data to the template by calling WithTemplate(templateString, data) (where data can be anything).  This is provided to the template as {{.Data}}.
@@ -235,6 +245,8 @@ type templateData struct {
 	FormattedActual string
 	Actual          any
 	Data            any
+	Params          any
+	InnerFailure    string
 }
 
 func (c CustomGomegaMatcher) renderTemplateMessage(actual any, isFailure bool) string {
@@ -248,6 +260,7 @@ func (c CustomGomegaMatcher) renderTemplateMessage(actual any, isFailure bool) s
 			FormattedActual: formattedActual,
 			Actual:          actual,
 			Data:            c.templateData,
+			Params:          c.templateParams,
 		}
 	} else {
 		data = templateData{
@@ -257,6 +270,12 @@ func (c CustomGomegaMatcher) renderTemplateMessage(actual any, isFailure bool) s
 			FormattedActual: formattedActual,
 			Actual:          actual,
 			Data:            c.templateData,
+			Params:          c.templateParams,
+		}
+	}
+	if c.delegate != nil {
+		if innerFailure := c.delegate.innerFailure(isFailure); innerFailure != "" {
+			data.InnerFailure = format.IndentString(innerFailure, 1)
 		}
 	}
 	b := &strings.Builder{}
@@ -264,5 +283,9 @@ func (c CustomGomegaMatcher) renderTemplateMessage(actual any, isFailure bool) s
 	if err != nil {
 		return fmt.Sprintf("Failed to render failure message template: %s", err.Error())
 	}
-	return b.String()
+	message := b.String()
+	if data.InnerFailure != "" && !strings.Contains(message, data.InnerFailure) {
+		message += "\n" + data.InnerFailure
+	}
+	return message
 }
@@ -0,0 +1,129 @@
+package gcustom
+
+import (
+	"fmt"
+	"reflect"
+	"text/template"
+
+	"github.com/onsi/gomega/format"
+)
+
+/*
+MatcherFactory builds custom matchers that share a match function and a failure message template but are each
+parameterized by a strongly-typed value of type P.  Use MakeMatcherFactory to construct one.
+
+MatcherFactories make it easy to build libraries of reusable matchers with rich failure messages:
+
+	var haveWidgetFactory = gcustom.MakeMatcherFactory(func(machine Machine, widget Widget) (bool, error) {
+		return machine.HasWidget(widget), nil
+	}).WithTemplate(
+		"Expected:\n{{.FormattedActual}}\n{{.To}} have widget {{quote .Params.Name}} at version {{.Params.Version}}",
+	).WithTemplateFuncs(template.FuncMap{
+		"quote": strconv.Quote,
+	})
+
+	func HaveWidget(widget Widget) OmegaMatcher {
+		return haveWidgetFactory.Build(widget)
+	}
+
+The template is parsed once, when the factory is configured, rather than every time a matcher is built.
+*/
+type MatcherFactory[P any] struct {
+	matchFunc    func(actual any, params P) (bool, error)
+	templateText string
+	funcs        template.FuncMap
+	template     *template.Template
+	parseErr     error
+}
+
+/*
+MakeMatcherFactory returns a MatcherFactory whose matchers call matchFunc with the actual and the parameters the
+matcher was built with.
+
+As with MakeMatcher, matchFunc can take an actual of a specific type - the matchers will check the type of the actual
+for you - or of type any if you'd rather do your own type-checking.
+
+Matchers built by the factory have the same generic failure messages as MakeMatcher's until you configure a message
+with WithMessage or WithTemplate.
+*/
+func MakeMatcherFactory[A any, P any](matchFunc func(actual A, params P) (bool, error)) MatcherFactory[P] {
+	actualType := reflect.TypeOf((*A)(nil)).Elem()
+	return MatcherFactory[P]{
+		matchFunc: func(actual any, params P) (bool, error) {
+			typedActual, ok := actual.(A)
+			if !ok {
+				if actual != nil || actualType.Kind() != reflect.Interface {
+					return false, fmt.Errorf("Matcher expected actual of type <%s>.  Got:\n%s", actualType, format.Object(actual, 1))
+				}
+				// nil satisfies interface types, but can't be type-asserted to one
+				var zero A
+				typedActual = zero
+			}
+			return matchFunc(typedActual, params)
+		},
+		template: defaultTemplate,
+	}
+}
+
+/*
+WithMessage returns a MatcherFactory whose matchers have failure messages of the form described in
+CustomGomegaMatcher's WithMessage.  The message is a template, so it can refer to the parameters:
+
+	factory.WithMessage("have widget {{.Params.Name}}")
+*/
+func (f MatcherFactory[P]) WithMessage(message string) MatcherFactory[P] {
+	return f.WithTemplate("Expected:\n{{.FormattedActual}}\n{{.To}} " + message)
+}
+
+/*
+WithTemplate returns a MatcherFactory whose matchers render their failure messages with the passed-in template.  In
+addition to the variables and functions documented in CustomGomegaMatcher's WithTemplate, the template can use the
+parameters the matcher was built with, as {{.Params}}, and any functions added with WithTemplateFuncs.
+
+Build panics if the template does not parse, once the functions it calls have been added.
+*/
+func (f MatcherFactory[P]) WithTemplate(templ string) MatcherFactory[P] {
+	f.templateText = templ
+	return f.parse()
+}
+
+/*
+WithTemplateFuncs returns a MatcherFactory whose template can call the passed-in functions.  Functions accumulate
+across calls and can be added before or after the template is set.  They can override gcustom's format function.
+*/
+func (f MatcherFactory[P]) WithTemplateFuncs(funcs template.FuncMap) MatcherFactory[P] {
+	merged := template.FuncMap{}
+	for name, fn := range f.funcs {
+		merged[name] = fn
+	}
+	for name, fn := range funcs {
+		merged[name] = fn
+	}
+	f.funcs = merged
+	return f.parse()
+}
+
+// parse parses the template as soon as it has been set, and again as functions are added - it may call functions
+// that have yet to be added, so errors are only reported by Build
+func (f MatcherFactory[P]) parse() MatcherFactory[P] {
+	if f.templateText != "" {
+		f.template, f.parseErr = ParseTemplate(f.templateText, f.funcs)
+	}
+	return f
+}
+
+// Build returns a matcher parameterized by params.  The matcher is a CustomGomegaMatcher, so it can be configured
+// further - with WithTemplateData, for example.
+func (f MatcherFactory[P]) Build(params P) CustomGomegaMatcher {
+	if f.parseErr != nil {
+		panic(fmt.Sprintf("MatcherFactory's template does not parse: %s", f.parseErr))
+	}
+	matchFunc := f.matchFunc
+	return CustomGomegaMatcher{
+		matchFunc: func(actual any) (bool, error) {
+			return matchFunc(actual, params)
+		},
+		templateMessage: f.template,
+		templateParams:  params,
+	}
+}
@@ -0,0 +1,93 @@
+package gcustom_test
+
+import (
+	"errors"
+	"strings"
+	"text/template"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gcustom"
+)
+
+type widget struct {
+	Name    string
+	Version int
+}
+
+var _ = Describe("MakeMatcherFactory", func() {
+	haveWidget := gcustom.MakeMatcherFactory(func(widgets []widget, expected widget) (bool, error) {
+		if expected.Version < 0 {
+			return false, errors.New("bam")
+		}
+		for _, w := range widgets {
+			if w == expected {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	widgets := []widget{{"sprocket", 2}}
+
+	It("builds matchers that are passed their parameters", func() {
+		Ω(widgets).Should(haveWidget.Build(widget{"sprocket", 2}))
+		Ω(widgets).ShouldNot(haveWidget.Build(widget{"sprocket", 3}))
+
+		success, err := haveWidget.Build(widget{"sprocket", -1}).Match(widgets)
+		Ω(success).Should(BeFalse())
+		Ω(err).Should(MatchError("bam"))
+	})
+
+	It("checks the type of the actual", func() {
+		success, err := haveWidget.Build(widget{"sprocket", 2}).Match("sprocket")
+		Ω(success).Should(BeFalse())
+		Ω(err).Should(MatchError("Matcher expected actual of type <[]gcustom_test.widget>.  Got:\n    <string>: sprocket"))
+	})
+
+	It("passes nil on to match funcs that take an interface", func() {
+		beNilError := gcustom.MakeMatcherFactory(func(err error, _ struct{}) (bool, error) {
+			return err == nil, nil
+		}).Build(struct{}{})
+		Ω(nil).Should(beNilError)
+		Ω(errors.New("bam")).ShouldNot(beNilError)
+	})
+
+	It("renders the default failure messages until configured otherwise", func() {
+		m := haveWidget.Build(widget{"sprocket", 3})
+		Ω(m.FailureMessage(widgets)).Should(HavePrefix("Custom matcher failed for:\n"))
+	})
+
+	It("exposes the parameters to messages and templates", func() {
+		m := haveWidget.WithMessage("have widget {{.Params.Name}} v{{.Params.Version}}").Build(widget{"sprocket", 3})
+		Ω(m.FailureMessage(widgets)).Should(HavePrefix("Expected:\n    <[]gcustom_test.widget | len:1, cap:1>: ["), "should format the actual")
+		Ω(m.FailureMessage(widgets)).Should(HaveSuffix("\nto have widget sprocket v3"))
+		Ω(m.NegatedFailureMessage(widgets)).Should(HaveSuffix("\nnot to have widget sprocket v3"))
+
+		m = haveWidget.WithTemplate("{{.To}} have {{format .Params}}").Build(widget{"sprocket", 3})
+		Ω(m.FailureMessage(widgets)).Should(Equal("to have <gcustom_test.widget>: {Name: sprocket, Version: 3}"))
+	})
+
+	It("makes per-factory template functions available", func() {
+		shouting := haveWidget.WithTemplateFuncs(template.FuncMap{"shout": strings.ToUpper}).WithTemplate("{{.To}} have {{shout .Params.Name}}")
+		Ω(shouting.Build(widget{"sprocket", 3}).FailureMessage(widgets)).Should(Equal("to have SPROCKET"))
+
+		whispering := haveWidget.WithTemplate("{{.To}} have {{shout .Params.Name}}{{bang}}").
+			WithTemplateFuncs(template.FuncMap{"shout": strings.ToLower}).
+			WithTemplateFuncs(template.FuncMap{"bang": func() string { return "!" }})
+		Ω(whispering.Build(widget{"SPROCKET", 3}).FailureMessage(widgets)).Should(Equal("to have sprocket!"))
+
+		Ω(shouting.Build(widget{"sprocket", 3}).FailureMessage(widgets)).Should(Equal("to have SPROCKET"), "configuring a factory should not affect the factories it was derived from")
+	})
+
+	It("panics when building a matcher if the template does not parse", func() {
+		factory := haveWidget.WithTemplate("{{shout .Params.Name}}")
+		Ω(func() { factory.Build(widget{"sprocket", 3}) }).Should(PanicWith(ContainSubstring(`function "shout" not defined`)))
+	})
+
+	It("lets ParseTemplate take template functions, too", func() {
+		templ, err := gcustom.ParseTemplate("{{.To}} {{shout .Data}}", template.FuncMap{"shout": strings.ToUpper})
+		Ω(err).ShouldNot(HaveOccurred())
+		m := gcustom.MakeMatcher(func(a int) (bool, error) { return false, nil }).WithPrecompiledTemplate(templ, "be zero")
+		Ω(m.FailureMessage(1)).Should(Equal("to BE ZERO"))
+	})
+})
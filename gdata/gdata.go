@@ -0,0 +1,408 @@
+/*
+Package gdata provides seeded pseudo-random data builders for randomized tests.
+
+Every Generator has a seed.  A Generator remembers the values it builds and, whenever Gomega prints one of them in a
+failure message, includes the seed:
+
+	g := gdata.New()
+	name := g.String(12)
+	Expect(store.Lookup(name)).To(Equal(name))
+
+fails with something like:
+
+	Expected
+	    <string>:
+	to equal
+	    <string>: aq3XbT0kzL9e [gdata seed: 1677012345678]
+
+Rerunning with the same seed - via gdata.NewWithSeed or by setting the GDATA_SEED environment variable - produces
+the same values, making randomized failures reproducible.
+
+Strings longer than three bytes, floats, time.Times, and the pointers populated by Fill are remembered.  Strings are
+remembered by the call that built them: an equal string built elsewhere is not annotated.  Integers, bools, durations,
+and short strings are too likely to collide with unrelated values and are not annotated.
+*/
+package gdata
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/onsi/gomega/format"
+)
+
+/*
+SeedEnvVar is the environment variable consulted by New.  When it is set to an integer, New uses it as the seed.
+*/
+const SeedEnvVar = "GDATA_SEED"
+
+const alphanumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+/*
+Generator builds pseudo-random values from a seed.  It is safe to use from multiple goroutines, though values are only
+reproducible when they are requested in the same order.
+*/
+type Generator struct {
+	seed int64
+	lock *sync.Mutex
+	rand *rand.Rand
+
+	rememberedLock *sync.Mutex
+	remembered     [2]map[interface{}]interface{}
+}
+
+/*
+New returns a Generator seeded from the GDATA_SEED environment variable or, if that is not set, the current time.
+*/
+func New() *Generator {
+	if seed, err := strconv.ParseInt(os.Getenv(SeedEnvVar), 10, 64); err == nil {
+		return NewWithSeed(seed)
+	}
+	return NewWithSeed(time.Now().UnixNano())
+}
+
+/*
+NewWithSeed returns a Generator with the given seed.  With Ginkgo, GinkgoRandomSeed() makes a good seed:
+
+	g := gdata.NewWithSeed(GinkgoRandomSeed())
+*/
+func NewWithSeed(seed int64) *Generator {
+	g := &Generator{
+		seed:           seed,
+		lock:           &sync.Mutex{},
+		rand:           rand.New(rand.NewSource(seed)),
+		rememberedLock: &sync.Mutex{},
+		remembered:     [2]map[interface{}]interface{}{{}, {}},
+	}
+	track(g)
+	return g
+}
+
+/*
+Seed returns the Generator's seed
+*/
+func (g *Generator) Seed() int64 {
+	return g.seed
+}
+
+/*
+Int returns an int in [min, max)
+*/
+func (g *Generator) Int(min, max int) int {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.intn(min, max)
+}
+
+/*
+Float64 returns a float64 in [min, max)
+*/
+func (g *Generator) Float64(min, max float64) float64 {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.remember(min + g.rand.Float64()*(max-min)).(float64)
+}
+
+/*
+Bool returns a random bool
+*/
+func (g *Generator) Bool() bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.rand.Intn(2) == 1
+}
+
+/*
+String returns an alphanumeric string of length n
+*/
+func (g *Generator) String(n int) string {
+	return g.StringFrom(alphanumeric, n)
+}
+
+/*
+StringFrom returns a string of length n (in runes) drawn from the runes in alphabet
+*/
+func (g *Generator) StringFrom(alphabet string, n int) string {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.remember(g.stringFrom([]rune(alphabet), n)).(string)
+}
+
+/*
+Time returns a time.Time in [start, end).  The returned time has no monotonic clock reading and shares start's location.
+*/
+func (g *Generator) Time(start, end time.Time) time.Time {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.remember(g.time(start, end)).(time.Time)
+}
+
+/*
+Duration returns a time.Duration in [min, max)
+*/
+func (g *Generator) Duration(min, max time.Duration) time.Duration {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return min + time.Duration(g.rand.Int63n(int64(max-min)))
+}
+
+/*
+OneOf returns one of the passed-in choices
+*/
+func (g *Generator) OneOf(choices ...interface{}) interface{} {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return choices[g.rand.Intn(len(choices))]
+}
+
+/*
+Fill populates the struct that ptr points to with random data.  Exported fields are filled recursively: strings are
+alphanumeric and 10 runes long, numbers are non-negative and fit in their type, time.Times fall within the year before
+2020-01-01 UTC, slices and maps get up to three entries, and pointers are allocated.  Unexported fields, interfaces,
+channels, and functions are left untouched.
+
+Fill returns an error if ptr is not a non-nil pointer to a struct.
+*/
+func (g *Generator) Fill(ptr interface{}) error {
+	value := reflect.ValueOf(ptr)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("gdata.Fill expects a non-nil pointer to a struct.  Got:\n%s", format.Object(ptr, 1))
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.fill(value.Elem(), 0)
+	g.remember(ptr)
+	return nil
+}
+
+const maxFillDepth = 5
+
+var fillTimeEnd = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+var timeType = reflect.TypeOf(time.Time{})
+
+func (g *Generator) fill(value reflect.Value, depth int) {
+	if depth > maxFillDepth || !value.CanSet() {
+		return
+	}
+
+	if value.Type() == timeType {
+		value.Set(reflect.ValueOf(g.time(fillTimeEnd.AddDate(-1, 0, 0), fillTimeEnd)))
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.Bool:
+		value.SetBool(g.rand.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(g.nonNegativeInt63(value.Type().Bits() - 1))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value.SetUint(uint64(g.nonNegativeInt63(value.Type().Bits())))
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(g.rand.Float64() * 1000)
+	case reflect.String:
+		value.SetString(g.stringFrom([]rune(alphanumeric), 10))
+	case reflect.Ptr:
+		elem := reflect.New(value.Type().Elem())
+		g.fill(elem.Elem(), depth+1)
+		value.Set(elem)
+	case reflect.Slice:
+		n := g.rand.Intn(4)
+		slice := reflect.MakeSlice(value.Type(), n, n)
+		for i := 0; i < n; i++ {
+			g.fill(slice.Index(i), depth+1)
+		}
+		value.Set(slice)
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			g.fill(value.Index(i), depth+1)
+		}
+	case reflect.Map:
+		m := reflect.MakeMap(value.Type())
+		for i, n := 0, g.rand.Intn(4); i < n; i++ {
+			k, v := reflect.New(value.Type().Key()).Elem(), reflect.New(value.Type().Elem()).Elem()
+			g.fill(k, depth+1)
+			g.fill(v, depth+1)
+			m.SetMapIndex(k, v)
+		}
+		value.Set(m)
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			g.fill(value.Field(i), depth+1)
+		}
+	}
+}
+
+func (g *Generator) intn(min, max int) int {
+	return min + g.rand.Intn(max-min)
+}
+
+func (g *Generator) stringFrom(alphabet []rune, n int) string {
+	s := make([]rune, n)
+	for i := range s {
+		s[i] = alphabet[g.rand.Intn(len(alphabet))]
+	}
+	return string(s)
+}
+
+func (g *Generator) time(start, end time.Time) time.Time {
+	offset := time.Duration(g.rand.Int63n(int64(end.Sub(start))))
+	return start.Add(offset).Round(0)
+}
+
+// nonNegativeInt63 returns a non-negative int64 that fits in the given number of bits
+func (g *Generator) nonNegativeInt63(bits int) int64 {
+	if bits >= 63 {
+		return g.rand.Int63()
+	}
+	return g.rand.Int63n(int64(1) << bits)
+}
+
+/*
+Formatter is a format.CustomFormatter that appends the Generator's seed to the usual representation of the values it
+has built.
+
+gdata registers a single formatter that consults the most recently created Generators the first time a Generator is
+created, so you only need to register Formatter yourself if you keep a Generator around for longer than that:
+
+	DeferCleanup(format.UnregisterCustomFormatter, format.RegisterCustomFormatter(g.Formatter))
+*/
+func (g *Generator) Formatter(value interface{}) (string, bool) {
+	if !g.isRemembered(value) {
+		return "", false
+	}
+
+	var representation string
+	switch v := value.(type) {
+	case string:
+		// the clone is not remembered, so this does not recurse
+		representation = stripType(format.Object(strings.Clone(v), 1))
+	case float64:
+		representation = fmt.Sprintf("%v", v)
+	case time.Time:
+		representation = v.Format(time.RFC3339Nano)
+	default:
+		// pointers populated by Fill - the struct they point to is not remembered, so this does not recurse
+		representation = stripType(format.Object(reflect.ValueOf(value).Elem().Interface(), 0))
+	}
+	return fmt.Sprintf("%s [gdata seed: %d]", representation, g.seed), true
+}
+
+// stripType removes the "<type>: " prefix from a format.Object representation
+func stripType(representation string) string {
+	if i := strings.Index(representation, ">: "); i >= 0 {
+		return representation[i+len(">: "):]
+	}
+	return representation
+}
+
+/*
+Forget clears the Generator's record of the values it has built.  Values built before calling Forget are no longer
+annotated with the seed.  The record is bounded - only the most recent values are remembered - so calling Forget is
+rarely necessary.
+*/
+func (g *Generator) Forget() {
+	g.rememberedLock.Lock()
+	defer g.rememberedLock.Unlock()
+	g.remembered = [2]map[interface{}]interface{}{{}, {}}
+}
+
+// maxRemembered bounds each of the two generations of remembered values
+const maxRemembered = 10000
+
+// minRememberedStringLength is the length, in bytes, below which strings are not remembered
+const minRememberedStringLength = 4
+
+// stringKey identifies a string by its backing array, i.e. by the call that built it rather than by its value
+type stringKey struct {
+	data uintptr
+	len  int
+}
+
+// rememberedKey returns the key under which value is remembered.  Only the kinds of values gdata builds have keys:
+// strings are keyed by their backing array, float64s and time.Times by value, and pointers by identity.  This ensures
+// hashing a key never panics.
+func rememberedKey(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		if len(v) < minRememberedStringLength {
+			return nil, false
+		}
+		return stringKey{(*reflect.StringHeader)(unsafe.Pointer(&v)).Data, len(v)}, true
+	case float64, time.Time:
+		return value, true
+	}
+	if value != nil && reflect.TypeOf(value).Kind() == reflect.Ptr {
+		return value, true
+	}
+	return nil, false
+}
+
+// remember records that the Generator built value.  When the current generation fills up it replaces the previous one.
+// The value itself is kept alongside its key so that a string's backing array can't be reused while it is remembered.
+func (g *Generator) remember(value interface{}) interface{} {
+	key, ok := rememberedKey(value)
+	if !ok {
+		return value
+	}
+	g.rememberedLock.Lock()
+	defer g.rememberedLock.Unlock()
+	if len(g.remembered[0]) >= maxRemembered {
+		g.remembered = [2]map[interface{}]interface{}{{}, g.remembered[0]}
+	}
+	g.remembered[0][key] = value
+	return value
+}
+
+func (g *Generator) isRemembered(value interface{}) bool {
+	key, ok := rememberedKey(value)
+	if !ok {
+		return false
+	}
+	g.rememberedLock.Lock()
+	defer g.rememberedLock.Unlock()
+	_, inCurrent := g.remembered[0][key]
+	_, inPrevious := g.remembered[1][key]
+	return inCurrent || inPrevious
+}
+
+// maxTracked bounds the number of Generators whose values are annotated automatically
+const maxTracked = 8
+
+var registerOnce = &sync.Once{}
+var trackedLock = &sync.Mutex{}
+var tracked []*Generator
+
+// track registers gdata's formatter, the first time it is called, and adds g to the Generators it consults.  Only the
+// most recently created Generators are consulted, so Generators that are no longer used can be garbage collected.
+func track(g *Generator) {
+	registerOnce.Do(func() {
+		format.RegisterCustomFormatter(formatTracked)
+	})
+	trackedLock.Lock()
+	defer trackedLock.Unlock()
+	tracked = append(tracked, g)
+	if len(tracked) > maxTracked {
+		tracked = append([]*Generator{}, tracked[len(tracked)-maxTracked:]...)
+	}
+}
+
+// formatTracked annotates values built by any of the tracked Generators, preferring the most recently created one
+func formatTracked(value interface{}) (string, bool) {
+	trackedLock.Lock()
+	generators := append([]*Generator{}, tracked...)
+	trackedLock.Unlock()
+	for i := len(generators) - 1; i >= 0; i-- {
+		if representation, ok := generators[i].Formatter(value); ok {
+			return representation, true
+		}
+	}
+	return "", false
+}
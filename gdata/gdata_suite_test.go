@@ -0,0 +1,13 @@
+package gdata_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGdata(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gdata Suite")
+}
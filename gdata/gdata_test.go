@@ -0,0 +1,186 @@
+package gdata_test
+
+import (
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/gdata"
+)
+
+type address struct {
+	Street string
+	Zip    uint16
+}
+
+type person struct {
+	Name      string
+	Age       int8
+	Score     float64
+	Admin     bool
+	Born      time.Time
+	Address   *address
+	Nicknames []string
+	Tags      map[string]int
+	secret    string
+}
+
+var _ = Describe("Generator", func() {
+	It("should be reproducible given a seed", func() {
+		a, b := gdata.NewWithSeed(17), gdata.NewWithSeed(17)
+		Expect(a.Seed()).Should(Equal(int64(17)))
+
+		start := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := start.AddDate(10, 0, 0)
+		Expect(a.String(12)).Should(Equal(b.String(12)))
+		Expect(a.Int(0, 1000)).Should(Equal(b.Int(0, 1000)))
+		Expect(a.Time(start, end)).Should(Equal(b.Time(start, end)))
+
+		var p, q person
+		Expect(a.Fill(&p)).Should(Succeed())
+		Expect(b.Fill(&q)).Should(Succeed())
+		Expect(p).Should(Equal(q))
+	})
+
+	It("should honor GDATA_SEED", func() {
+		os.Setenv(gdata.SeedEnvVar, "42")
+		DeferCleanup(os.Unsetenv, gdata.SeedEnvVar)
+		Expect(gdata.New().Seed()).Should(Equal(int64(42)))
+	})
+
+	It("should respect the requested ranges", func() {
+		g := gdata.New()
+		start := time.Now()
+		end := start.Add(time.Hour)
+		for i := 0; i < 100; i++ {
+			Expect(g.Int(-5, 5)).Should(BeNumerically(">=", -5))
+			Expect(g.Int(-5, 5)).Should(BeNumerically("<", 5))
+			Expect(g.Float64(1, 2)).Should(BeNumerically("~", 1.5, 0.5))
+			Expect(g.Duration(time.Second, time.Minute)).Should(BeNumerically(">=", time.Second))
+			Expect(g.StringFrom("ab✓", 4)).Should(MatchRegexp(`^[ab✓]{4}$`))
+			t := g.Time(start, end)
+			Expect(t).Should(BeTemporally(">=", start))
+			Expect(t).Should(BeTemporally("<", end))
+		}
+		Expect(g.OneOf("x", "y")).Should(BeElementOf("x", "y"))
+	})
+
+	Describe("Fill", func() {
+		It("should populate exported fields recursively", func() {
+			var p person
+			Expect(gdata.New().Fill(&p)).Should(Succeed())
+			Expect(p.Name).Should(HaveLen(10))
+			Expect(p.Age).Should(BeNumerically(">=", 0))
+			Expect(p.Born.Year()).Should(Equal(2019))
+			Expect(p.Address).ShouldNot(BeNil())
+			Expect(p.Address.Street).Should(HaveLen(10))
+			Expect(len(p.Nicknames)).Should(BeNumerically("<=", 3))
+			Expect(p.secret).Should(BeEmpty())
+		})
+
+		It("should error when not passed a pointer to a struct", func() {
+			var p person
+			Expect(gdata.New().Fill(p)).Should(MatchError(ContainSubstring("expects a non-nil pointer to a struct")))
+			Expect(gdata.New().Fill((*person)(nil))).ShouldNot(Succeed())
+		})
+	})
+
+	Describe("failure messages", func() {
+		var g *gdata.Generator
+		BeforeEach(func() {
+			g = gdata.NewWithSeed(1234)
+		})
+
+		It("should include the seed when printing generated values", func() {
+			name := g.String(8)
+			failures := InterceptGomegaFailures(func() {
+				Expect("").Should(Equal(name))
+			})
+			Expect(failures).Should(ConsistOf("Expected\n    <string>: \nto equal\n    <string>: " + name + " [gdata seed: 1234]"))
+		})
+
+		It("should annotate generated strings nested in other values", func() {
+			name := g.String(8)
+			Expect(format.Object([]string{name}, 1)).Should(ContainSubstring(name + " [gdata seed: 1234]"))
+		})
+
+		It("should annotate values with the seed of the generator that built them", func() {
+			name := gdata.NewWithSeed(5).String(8)
+			Expect(format.Object(name, 0)).Should(ContainSubstring("[gdata seed: 5]"))
+		})
+
+		It("should not annotate equal strings that the generator did not build", func() {
+			name := g.String(8)
+			copied := string([]byte(name))
+			Expect(format.Object(copied, 0)).ShouldNot(ContainSubstring("gdata"))
+			Expect(format.Object(name, 0)).Should(ContainSubstring("gdata"))
+		})
+
+		It("should not annotate short strings", func() {
+			Expect(format.Object(g.String(0), 0)).ShouldNot(ContainSubstring("gdata"))
+			Expect(format.Object(g.String(3), 0)).ShouldNot(ContainSubstring("gdata"))
+		})
+
+		It("should still work when the Formatter is registered explicitly", func() {
+			DeferCleanup(format.UnregisterCustomFormatter, format.RegisterCustomFormatter(g.Formatter))
+			name := g.String(8)
+			Expect(format.Object(name, 1)).Should(Equal(format.Object(string([]byte(name)), 1) + " [gdata seed: 1234]"))
+		})
+
+		It("should annotate the pointers populated by Fill", func() {
+			a := &address{}
+			Expect(g.Fill(a)).Should(Succeed())
+			failures := InterceptGomegaFailures(func() {
+				Expect(a).Should(BeNil())
+			})
+			Expect(failures[0]).Should(ContainSubstring("[gdata seed: 1234]"))
+			Expect(failures[0]).Should(ContainSubstring("Street: " + a.Street))
+
+			b := *a
+			Expect(format.Object(&b, 0)).ShouldNot(ContainSubstring("gdata seed"))
+		})
+
+		It("should not annotate values that were not generated", func() {
+			g.Float64(0, 1)
+			failures := InterceptGomegaFailures(func() {
+				Expect("foobar").Should(Equal(3.5))
+			})
+			Expect(failures[0]).ShouldNot(ContainSubstring("gdata"))
+		})
+
+		It("should only consult the most recently created generators", func() {
+			name := g.String(8)
+			for i := 0; i < 8; i++ {
+				gdata.NewWithSeed(int64(i))
+			}
+			Expect(format.Object(name, 0)).ShouldNot(ContainSubstring("gdata"))
+		})
+
+		It("should not panic on values that can't be hashed", func() {
+			g.String(8)
+			Expect(format.Object(struct{ V interface{} }{[]int{1}}, 1)).Should(ContainSubstring("[1]"))
+			Expect(format.Object(map[string]interface{}{"a": []int{1}}, 1)).ShouldNot(ContainSubstring("gdata"))
+		})
+
+		It("should forget values after Forget", func() {
+			name := g.String(8)
+			g.Forget()
+			failures := InterceptGomegaFailures(func() {
+				Expect("").Should(Equal(name))
+			})
+			Expect(failures[0]).ShouldNot(ContainSubstring("gdata"))
+		})
+
+		It("should only remember the most recent values", func() {
+			first := g.String(16)
+			for i := 0; i < 20001; i++ {
+				g.String(16)
+			}
+			last := g.String(16)
+			Expect(format.Object(first, 0)).ShouldNot(ContainSubstring("gdata"))
+			Expect(format.Object(last, 0)).Should(ContainSubstring("[gdata seed: 1234]"))
+		})
+	})
+})
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/onsi/gomega/gexec"
+)
+
+func main() {
+	for _, name := range os.Args[1:] {
+		if err := gexec.ReachCheckpoint(name); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
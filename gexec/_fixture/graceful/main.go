@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// graceful handles the signals gexec tests send it according to its first argument:
+//
+//	exit <code> <delay> - exits with code, delay after receiving SIGINT or SIGTERM
+//	ignore              - ignores SIGINT and SIGTERM
+func main() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	fmt.Println("ready")
+
+	if os.Args[1] == "ignore" {
+		time.Sleep(time.Minute)
+		return
+	}
+
+	var code int
+	var delay time.Duration
+	fmt.Sscan(os.Args[2], &code)
+	delay, _ = time.ParseDuration(os.Args[3])
+
+	<-signals
+	time.Sleep(delay)
+	os.Exit(code)
+}
@@ -0,0 +1,108 @@
+package gexec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/onsi/gomega/internal/gutil"
+)
+
+// CheckpointsEnvVar is the environment variable through which Checkpoints tells a process where to publish the
+// checkpoints it reaches
+const CheckpointsEnvVar = "GOMEGA_GEXEC_CHECKPOINTS"
+
+/*
+Checkpoints lets a suite assert on the progress of the processes it starts.  A process publishes each checkpoint it
+reaches by calling ReachCheckpoint, and the suite polls for it:
+
+	checkpoints, err := gexec.NewCheckpoints()
+	Expect(err).ShouldNot(HaveOccurred())
+	DeferCleanup(checkpoints.Clean)
+
+	command := exec.Command(serverPath)
+	command.Env = append(os.Environ(), checkpoints.Env())
+	session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+	Expect(err).ShouldNot(HaveOccurred())
+
+	Eventually(checkpoints.Reached("migrated")).Should(BeTrue())
+
+Checkpoints are appended, one per line, to a file that every process started with Env shares - so a checkpoint can
+be reached by any of them, and the order in which they were reached is preserved.
+*/
+type Checkpoints struct {
+	dir string
+}
+
+// NewCheckpoints creates a temporary file for processes to publish checkpoints to.  Call Clean to remove it.
+func NewCheckpoints() (*Checkpoints, error) {
+	dir, err := gutil.MkdirTemp("", "gexec_checkpoints")
+	if err != nil {
+		return nil, err
+	}
+	return &Checkpoints{dir: dir}, nil
+}
+
+// Path returns the path of the file checkpoints are published to
+func (c *Checkpoints) Path() string {
+	return filepath.Join(c.dir, "checkpoints")
+}
+
+// Env returns the environment variable - in the KEY=value form expected by exec.Cmd's Env - that tells a process to
+// publish its checkpoints to c
+func (c *Checkpoints) Env() string {
+	return CheckpointsEnvVar + "=" + c.Path()
+}
+
+// Names returns the checkpoints reached so far, in the order they were reached
+func (c *Checkpoints) Names() []string {
+	content, err := os.ReadFile(c.Path())
+	if err != nil {
+		return []string{}
+	}
+	names := strings.Split(string(content), "\n")
+	// a process may be part way through publishing the last checkpoint
+	return names[:len(names)-1]
+}
+
+// Reached returns a function that reports whether the named checkpoint has been reached, for polling with Eventually:
+//
+//	Eventually(checkpoints.Reached("migrated")).Should(BeTrue())
+func (c *Checkpoints) Reached(name string) func() bool {
+	return func() bool {
+		for _, reached := range c.Names() {
+			if reached == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Clean removes the file checkpoints are published to
+func (c *Checkpoints) Clean() error {
+	return os.RemoveAll(c.dir)
+}
+
+// ReachCheckpoint publishes the named checkpoint to the Checkpoints whose Env the process was started with.  It does
+// nothing if the process was not started with one, so it can be left in code that also runs outside of tests.
+func ReachCheckpoint(name string) error {
+	path := os.Getenv(CheckpointsEnvVar)
+	if path == "" {
+		return nil
+	}
+	if name == "" || strings.Contains(name, "\n") {
+		return fmt.Errorf("checkpoint names must be non-empty and fit on a single line, got %q", name)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0666)
+	if err != nil {
+		return err
+	}
+	// a single write to a file opened for appending keeps checkpoints from concurrent processes from interleaving
+	_, err = f.Write([]byte(name + "\n"))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+package gexec_test
+
+import (
+	"os"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Checkpoints", func() {
+	var checkpoints *Checkpoints
+
+	BeforeEach(func() {
+		var err error
+		checkpoints, err = NewCheckpoints()
+		Ω(err).ShouldNot(HaveOccurred())
+		DeferCleanup(checkpoints.Clean)
+	})
+
+	It("has not reached any checkpoints to begin with", func() {
+		Ω(checkpoints.Names()).Should(BeEmpty())
+		Ω(checkpoints.Reached("migrated")()).Should(BeFalse())
+	})
+
+	It("points processes at its file", func() {
+		Ω(checkpoints.Env()).Should(Equal(CheckpointsEnvVar + "=" + checkpoints.Path()))
+	})
+
+	Context("when a process reaches checkpoints", func() {
+		var checkpointerPath string
+
+		BeforeEach(func() {
+			var err error
+			checkpointerPath, err = Build("./_fixture/checkpointer")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("reports them in the order they were reached", func() {
+			command := exec.Command(checkpointerPath, "migrated", "serving")
+			command.Env = append(os.Environ(), checkpoints.Env())
+			session, err := Start(command, GinkgoWriter, GinkgoWriter)
+			Ω(err).ShouldNot(HaveOccurred())
+
+			Eventually(checkpoints.Reached("migrated")).Should(BeTrue())
+			Eventually(checkpoints.Reached("serving")).Should(BeTrue())
+			Eventually(session).Should(Exit(0))
+			Ω(checkpoints.Names()).Should(Equal([]string{"migrated", "serving"}))
+			Ω(checkpoints.Reached("stopped")()).Should(BeFalse())
+		})
+
+		It("collects checkpoints from every process started with its Env", func() {
+			for _, name := range []string{"first", "second"} {
+				command := exec.Command(checkpointerPath, name)
+				command.Env = append(os.Environ(), checkpoints.Env())
+				session, err := Start(command, GinkgoWriter, GinkgoWriter)
+				Ω(err).ShouldNot(HaveOccurred())
+				Eventually(session).Should(Exit(0))
+			}
+			Ω(checkpoints.Names()).Should(Equal([]string{"first", "second"}))
+		})
+
+		It("does nothing when the process was not started with a Checkpoints' Env", func() {
+			session, err := Start(exec.Command(checkpointerPath, "migrated"), GinkgoWriter, GinkgoWriter)
+			Ω(err).ShouldNot(HaveOccurred())
+			Eventually(session).Should(Exit(0))
+			Ω(checkpoints.Names()).Should(BeEmpty())
+		})
+	})
+
+	Describe("ReachCheckpoint", func() {
+		BeforeEach(func() {
+			os.Setenv(CheckpointsEnvVar, checkpoints.Path())
+			DeferCleanup(os.Unsetenv, CheckpointsEnvVar)
+		})
+
+		It("publishes checkpoints from the current process", func() {
+			Ω(ReachCheckpoint("migrated")).Should(Succeed())
+			Ω(checkpoints.Reached("migrated")()).Should(BeTrue())
+		})
+
+		It("rejects names that do not fit on a single line", func() {
+			Ω(ReachCheckpoint("")).Should(MatchError(ContainSubstring("single line")))
+			Ω(ReachCheckpoint("migrated\nserving")).Should(MatchError(ContainSubstring("single line")))
+			Ω(checkpoints.Names()).Should(BeEmpty())
+		})
+	})
+})
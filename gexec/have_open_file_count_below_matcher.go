@@ -0,0 +1,68 @@
+package gexec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+// reportedOpenFiles is the number of open files listed when a process holds too many
+const reportedOpenFiles = 20
+
+/*
+HaveOpenFileCountBelow succeeds if actual - a process, identified by its pid, an *os.Process, a started *exec.Cmd,
+or a *gexec.Session - holds fewer than count file descriptors open.  On failure it lists the open files.  Use it to
+catch descriptor leaks in daemons:
+
+	Consistently(session).Should(gexec.HaveOpenFileCountBelow(64))
+	Expect(os.Getpid()).Should(gexec.HaveOpenFileCountBelow(256))
+
+On Linux any process can be inspected.  Other unix systems only allow a process to inspect itself, and
+HaveOpenFileCountBelow errors when asked about another process - or when run on Windows.
+*/
+func HaveOpenFileCountBelow(count int) *HaveOpenFileCountBelowMatcher {
+	return &HaveOpenFileCountBelowMatcher{
+		Count: count,
+	}
+}
+
+type HaveOpenFileCountBelowMatcher struct {
+	Count int
+
+	// state
+	pid   int
+	files []openFile
+}
+
+func (matcher *HaveOpenFileCountBelowMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.pid, err = toPid("HaveOpenFileCountBelow", actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.files, err = openFiles(matcher.pid)
+	if err != nil {
+		return false, fmt.Errorf("HaveOpenFileCountBelow matcher could not list the open files of process %d:\n%s", matcher.pid, format.IndentString(err.Error(), 1))
+	}
+	return len(matcher.files) < matcher.Count, nil
+}
+
+func (matcher *HaveOpenFileCountBelowMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected process %d to have fewer than %d open files, but it has %d:\n%s", matcher.pid, matcher.Count, len(matcher.files), matcher.formatFiles())
+}
+
+func (matcher *HaveOpenFileCountBelowMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected process %d to have at least %d open files, but it has %d", matcher.pid, matcher.Count, len(matcher.files))
+}
+
+func (matcher *HaveOpenFileCountBelowMatcher) formatFiles() string {
+	lines := []string{}
+	for i, file := range matcher.files {
+		if i == reportedOpenFiles {
+			lines = append(lines, fmt.Sprintf("%s...and %d more", format.Indent, len(matcher.files)-reportedOpenFiles))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s%d: %s", format.Indent, file.fd, file.target))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,83 @@
+package gexec_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("HaveOpenFileCountBelow", func() {
+	BeforeEach(func() {
+		if runtime.GOOS == "windows" {
+			Skip("open files cannot be inspected on windows")
+		}
+	})
+
+	countOpenFiles := func() int {
+		m := HaveOpenFileCountBelow(0)
+		Expect(m.Match(os.Getpid())).Should(BeFalse())
+		count, err := strconv.Atoi(regexp.MustCompile(`\d+$`).FindString(m.NegatedFailureMessage(os.Getpid())))
+		Expect(err).ShouldNot(HaveOccurred())
+		return count
+	}
+
+	It("should count the files the current process holds open", func() {
+		before := countOpenFiles()
+		Expect(os.Getpid()).Should(HaveOpenFileCountBelow(before + 1))
+
+		dir := GinkgoT().TempDir()
+		for _, name := range []string{"a", "b", "c"} {
+			f, err := os.Create(filepath.Join(dir, name))
+			Expect(err).ShouldNot(HaveOccurred())
+			DeferCleanup(f.Close)
+		}
+		Expect(os.Getpid()).ShouldNot(HaveOpenFileCountBelow(before + 3))
+		Expect(os.Getpid()).Should(HaveOpenFileCountBelow(before + 4))
+	})
+
+	It("should list the open files on failure", func() {
+		if runtime.GOOS != "linux" {
+			Skip("only linux resolves the targets of open files")
+		}
+		path := filepath.Join(GinkgoT().TempDir(), "leaked.txt")
+		f, err := os.Create(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(f.Close)
+
+		m := HaveOpenFileCountBelow(1)
+		Expect(m.Match(os.Getpid())).Should(BeFalse())
+		Expect(m.FailureMessage(os.Getpid())).Should(SatisfyAll(
+			MatchRegexp(`^Expected process \d+ to have fewer than 1 open files, but it has \d+:\n    0: `),
+			ContainSubstring(": "+path),
+		))
+	})
+
+	It("should inspect other processes on linux", func() {
+		if runtime.GOOS != "linux" {
+			Skip("only linux can inspect other processes")
+		}
+		session, err := Start(exec.Command("sleep", "10000000"), GinkgoWriter, GinkgoWriter)
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(session.Kill)
+
+		Expect(session).Should(HaveOpenFileCountBelow(16))
+		Expect(session).ShouldNot(HaveOpenFileCountBelow(3))
+		Expect(session.Command).Should(HaveOpenFileCountBelow(16))
+		Expect(session.Command.Process).Should(HaveOpenFileCountBelow(16))
+	})
+
+	It("should error when passed something other than a process", func() {
+		_, err := HaveOpenFileCountBelow(10).Match("sleep")
+		Expect(err).Should(MatchError(ContainSubstring("HaveOpenFileCountBelow matcher expects a pid, an *os.Process, a started *exec.Cmd, or a *gexec.Session.")))
+
+		_, err = HaveOpenFileCountBelow(10).Match(exec.Command("sleep", "1"))
+		Expect(err).Should(HaveOccurred())
+	})
+})
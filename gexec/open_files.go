@@ -0,0 +1,81 @@
+package gexec
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+
+	"github.com/onsi/gomega/format"
+)
+
+// openFile is a file descriptor held open by a process
+type openFile struct {
+	fd     int
+	target string
+}
+
+// toPid returns the id of the process a process matcher inspects
+func toPid(matcherName string, actual interface{}) (int, error) {
+	switch x := actual.(type) {
+	case int:
+		return x, nil
+	case *os.Process:
+		if x != nil {
+			return x.Pid, nil
+		}
+	case *exec.Cmd:
+		if x != nil && x.Process != nil {
+			return x.Process.Pid, nil
+		}
+	case *Session:
+		if x != nil && x.Command != nil && x.Command.Process != nil {
+			return x.Command.Process.Pid, nil
+		}
+	}
+	return 0, fmt.Errorf("%s matcher expects a pid, an *os.Process, a started *exec.Cmd, or a *gexec.Session.  Got:\n%s", matcherName, format.Object(actual, 1))
+}
+
+// openFiles lists the file descriptors process pid holds open, ordered by descriptor.  Linux exposes every
+// process's descriptors in /proc; other unix systems only expose the calling process's, in /dev/fd.
+func openFiles(pid int) ([]openFile, error) {
+	var dir string
+	switch {
+	case runtime.GOOS == "linux":
+		dir = filepath.Join("/proc", strconv.Itoa(pid), "fd")
+	case pid == os.Getpid() && runtime.GOOS != "windows":
+		dir = "/dev/fd"
+	default:
+		return nil, fmt.Errorf("the open files of other processes cannot be inspected on %s", runtime.GOOS)
+	}
+
+	f, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+	listingFd := int(f.Fd())
+	names, err := f.Readdirnames(-1)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	files := []openFile{}
+	for _, name := range names {
+		fd, err := strconv.Atoi(name)
+		if err != nil || (pid == os.Getpid() && fd == listingFd) {
+			continue
+		}
+		// /dev/fd entries are not symbolic links on every platform
+		target, err := os.Readlink(filepath.Join(dir, name))
+		if err != nil {
+			target = "unknown"
+		}
+		files = append(files, openFile{fd: fd, target: target})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].fd < files[j].fd })
+	return files, nil
+}
@@ -0,0 +1,345 @@
+package gexec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/gbytes"
+)
+
+/*
+SessionGroup manages a collection of named Sessions.  It is useful for integration tests that run several
+processes at once (e.g. a server, a handful of workers, and a CLI):
+
+	group := gexec.NewSessionGroup(GinkgoWriter)
+	_, err := group.Start("server", exec.Command(serverPath))
+	Expect(err).ShouldNot(HaveOccurred())
+	_, err = group.Start("worker-1", exec.Command(workerPath))
+	Expect(err).ShouldNot(HaveOccurred())
+
+	Eventually(group).Should(gbytes.Say(`\[worker-1\] connected`))
+
+	group.TerminateAll().WaitAll(5 * time.Second)
+	Expect(group).ShouldNot(gexec.AnyExitedNonZero())
+
+Each session's stdout and stderr are captured, as usual, in the session's Out and Err buffers.  In addition, the
+output of every session is interleaved, line by line, into the group's Out buffer and (if non-nil) the outWriter
+passed to NewSessionGroup.  Each line is prefixed with the name of the session that emitted it.  Sessions' output is
+buffered until a full line is available, so lines from different sessions never run into each other; a final line
+without a trailing newline is written when the session exits.
+*/
+type SessionGroup struct {
+	//A *gbytes.Buffer that receives the interleaved, prefixed stdout and stderr of every session in the group
+	Out *gbytes.Buffer
+
+	lock      *sync.Mutex
+	outWriter io.Writer
+	names     []string
+	sessions  map[string]*Session
+	flushed   map[string]chan struct{}
+	signals   map[string]os.Signal
+}
+
+/*
+NewSessionGroup returns an empty SessionGroup.  When outWriter is non-nil, the interleaved and prefixed output
+of every session in the group is also written to outWriter.
+*/
+func NewSessionGroup(outWriter io.Writer) *SessionGroup {
+	return &SessionGroup{
+		Out:       gbytes.NewBuffer(),
+		lock:      &sync.Mutex{},
+		outWriter: outWriter,
+		sessions:  map[string]*Session{},
+		flushed:   map[string]chan struct{}{},
+		signals:   map[string]os.Signal{},
+	}
+}
+
+/*
+Start starts the passed-in command (see gexec.Start) and adds the resulting session to the group under the passed-in name.
+Output emitted by the session is prefixed with "[name] " in the group's Out buffer.
+
+Names must be unique within a group.
+*/
+func (g *SessionGroup) Start(name string, command *exec.Cmd) (*Session, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	if _, ok := g.sessions[name]; ok {
+		return nil, fmt.Errorf("SessionGroup already has a session named %q", name)
+	}
+
+	var writer io.Writer = g.Out
+	if g.outWriter != nil {
+		writer = io.MultiWriter(g.Out, g.outWriter)
+	}
+	prefixedWriter := NewPrefixedWriter(fmt.Sprintf("[%s] ", name), writer)
+	outLines, errLines := newLineWriter(prefixedWriter), newLineWriter(prefixedWriter)
+
+	session, err := Start(command, outLines, errLines)
+	if err != nil {
+		return nil, err
+	}
+
+	flushed := make(chan struct{})
+	go func() {
+		<-session.Exited
+		outLines.flush()
+		errLines.flush()
+		close(flushed)
+	}()
+
+	g.names = append(g.names, name)
+	g.sessions[name] = session
+	g.flushed[name] = flushed
+	return session, nil
+}
+
+/*
+Session returns the session with the passed-in name, or nil if there is no such session.
+*/
+func (g *SessionGroup) Session(name string) *Session {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.sessions[name]
+}
+
+/*
+Sessions returns the sessions in the group in the order they were started.
+*/
+func (g *SessionGroup) Sessions() []*Session {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	sessions := make([]*Session, len(g.names))
+	for i, name := range g.names {
+		sessions[i] = g.sessions[name]
+	}
+	return sessions
+}
+
+/*
+Buffer implements the gbytes.BufferProvider interface and returns g.Out.
+This allows you to make gbytes.Say matcher assertions against the group's interleaved output:
+
+	Eventually(group).Should(gbytes.Say(`\[server\] listening`))
+*/
+func (g *SessionGroup) Buffer() *gbytes.Buffer {
+	return g.Out
+}
+
+/*
+SignalAll sends the passed in signal to every running session in the group.  It does not wait for the processes to exit.
+
+The group remembers the signal it sent each session: AnyExitedNonZero does not count a session that exits because of it.
+
+The group is returned to enable chaining.
+*/
+func (g *SessionGroup) SignalAll(signal os.Signal) *SessionGroup {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	for _, name := range g.names {
+		session := g.sessions[name]
+		if session.ExitCode() == -1 {
+			g.signals[name] = signal
+			session.Signal(signal)
+		}
+	}
+	return g
+}
+
+/*
+TerminateAll sends a SIGTERM signal to every running session in the group.  It does not wait for the processes to exit.
+
+The group is returned to enable chaining.
+*/
+func (g *SessionGroup) TerminateAll() *SessionGroup {
+	return g.SignalAll(syscall.SIGTERM)
+}
+
+/*
+KillAll sends a SIGKILL signal to every running session in the group.  It does not wait for the processes to exit.
+
+The group is returned to enable chaining.
+*/
+func (g *SessionGroup) KillAll() *SessionGroup {
+	return g.SignalAll(syscall.SIGKILL)
+}
+
+/*
+WaitAll waits until every session in the group has exited.  Unlike calling Wait on each session, the timeout applies to the
+group as a whole.  If any session has not exited within the timeout, WaitAll triggers a test failure that names the sessions
+that are still running.
+
+WaitAll uses eventually under the hood and accepts the same timeout/polling intervals that eventually does.
+
+The group is returned to enable chaining.
+*/
+func (g *SessionGroup) WaitAll(timeout ...interface{}) *SessionGroup {
+	EventuallyWithOffset(1, g.runningSessionNames, timeout...).Should(BeEmpty(), "Expected all sessions in the group to exit.  These are still running:")
+	return g
+}
+
+func (g *SessionGroup) runningSessionNames() []string {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	running := []string{}
+	for _, name := range g.names {
+		select {
+		case <-g.flushed[name]:
+		default:
+			running = append(running, name)
+		}
+	}
+	return running
+}
+
+// sessionExit is how a session in the group exited - signalledExitCode is the exit code the session has when it exits
+// because of the signal the group sent it, or 0 if the group did not signal it
+type sessionExit struct {
+	exitCode          int
+	signalledExitCode int
+}
+
+func (e sessionExit) exitedNonZero() bool {
+	return e.exitCode > 0 && e.exitCode != e.signalledExitCode
+}
+
+func (g *SessionGroup) sessionExits() map[string]sessionExit {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	exits := map[string]sessionExit{}
+	for name, session := range g.sessions {
+		exit := sessionExit{exitCode: session.ExitCode()}
+		if signal, ok := g.signals[name].(syscall.Signal); ok {
+			exit.signalledExitCode = 128 + int(signal)
+		}
+		exits[name] = exit
+	}
+	return exits
+}
+
+/*
+AnyExitedNonZero operates on a *SessionGroup and succeeds if any session in the group has exited with a non-zero exit code.
+It is most useful negated, to assert that none of the processes in a group have failed:
+
+	group.TerminateAll().WaitAll()
+	Expect(group).ShouldNot(gexec.AnyExitedNonZero())
+
+Sessions that exit because of a signal the group sent them (via SignalAll, TerminateAll or KillAll) - that is, with
+exit code 128+signal - are not counted.  Sessions that are still running are ignored.  Use WaitAll to wait for them to exit first.
+*/
+func AnyExitedNonZero() *anyExitedNonZeroMatcher {
+	return &anyExitedNonZeroMatcher{}
+}
+
+type anyExitedNonZeroMatcher struct {
+	exits map[string]sessionExit
+}
+
+func (m *anyExitedNonZeroMatcher) Match(actual interface{}) (success bool, err error) {
+	group, ok := actual.(*SessionGroup)
+	if !ok {
+		return false, fmt.Errorf("AnyExitedNonZero must be passed a *gexec.SessionGroup.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	m.exits = group.sessionExits()
+	for _, exit := range m.exits {
+		if exit.exitedNonZero() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *anyExitedNonZeroMatcher) FailureMessage(actual interface{}) (message string) {
+	return "Expected a session in the group to exit with a non-zero exit code.  Exit codes were:\n" + m.describeExits(func(sessionExit) bool { return true })
+}
+
+func (m *anyExitedNonZeroMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return "Expected no session in the group to exit with a non-zero exit code.  These sessions did:\n" + m.describeExits(sessionExit.exitedNonZero)
+}
+
+func (m *anyExitedNonZeroMatcher) describeExits(include func(sessionExit) bool) string {
+	names := []string{}
+	for name := range m.exits {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := []string{}
+	for _, name := range names {
+		exit := m.exits[name]
+		if !include(exit) {
+			continue
+		}
+		if exit.exitCode == -1 {
+			lines = append(lines, fmt.Sprintf("%s[%s] is still running", format.Indent, name))
+		} else if exit.exitCode > 0 && exit.exitCode == exit.signalledExitCode {
+			lines = append(lines, fmt.Sprintf("%s[%s] exited with code %d, because of the signal the group sent it", format.Indent, name, exit.exitCode))
+		} else {
+			lines = append(lines, fmt.Sprintf("%s[%s] exited with code %d", format.Indent, name, exit.exitCode))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *anyExitedNonZeroMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	group, ok := actual.(*SessionGroup)
+	if !ok {
+		return true
+	}
+	for _, exit := range group.sessionExits() {
+		if exit.exitCode == -1 {
+			return true
+		}
+	}
+	return false
+}
+
+// lineWriter passes whole lines on to writer, so that the output of the sessions in a group is interleaved line by line
+type lineWriter struct {
+	lock    *sync.Mutex
+	writer  io.Writer
+	pending []byte
+}
+
+func newLineWriter(writer io.Writer) *lineWriter {
+	return &lineWriter{
+		lock:   &sync.Mutex{},
+		writer: writer,
+	}
+}
+
+func (w *lineWriter) Write(b []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.pending = append(w.pending, b...)
+	if i := bytes.LastIndexByte(w.pending, '\n'); i >= 0 {
+		lines := w.pending[:i+1]
+		w.pending = append([]byte{}, w.pending[i+1:]...)
+		if _, err := w.writer.Write(lines); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// flush writes a final line that has no trailing newline
+func (w *lineWriter) flush() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if len(w.pending) > 0 {
+		w.writer.Write(w.pending)
+		w.pending = nil
+	}
+}
@@ -0,0 +1,165 @@
+//go:build !windows
+// +build !windows
+
+package gexec_test
+
+import (
+	"os/exec"
+	"syscall"
+
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SessionGroup", func() {
+	var group *SessionGroup
+
+	BeforeEach(func() {
+		group = NewSessionGroup(GinkgoWriter)
+		DeferCleanup(func() {
+			group.KillAll()
+		})
+	})
+
+	start := func(name string, script string) *Session {
+		session, err := group.Start(name, exec.Command("sh", "-c", script))
+		Expect(err).ShouldNot(HaveOccurred())
+		return session
+	}
+
+	It("should track sessions by name, in the order they were started", func() {
+		a := start("a", "exit 0")
+		b := start("b", "exit 0")
+
+		Expect(group.Session("a")).Should(BeIdenticalTo(a))
+		Expect(group.Session("b")).Should(BeIdenticalTo(b))
+		Expect(group.Session("c")).Should(BeNil())
+		Expect(group.Sessions()).Should(Equal([]*Session{a, b}))
+	})
+
+	It("should refuse duplicate names", func() {
+		start("a", "exit 0")
+		session, err := group.Start("a", exec.Command("sh", "-c", "exit 0"))
+		Expect(session).Should(BeNil())
+		Expect(err).Should(MatchError(`SessionGroup already has a session named "a"`))
+	})
+
+	It("should interleave prefixed output from every session into Out", func() {
+		a := start("server", "echo listening; echo warming up >&2")
+		start("worker", "echo connected")
+
+		Eventually(group).Should(Say(`\[worker\] connected\n`))
+		group.WaitAll()
+
+		Expect(string(group.Out.Contents())).Should(ContainSubstring("[server] listening\n"))
+		Expect(string(group.Out.Contents())).Should(ContainSubstring("[server] warming up\n"))
+
+		Expect(a.Out).Should(Say("^listening\n"))
+		Expect(a.Err).Should(Say("^warming up\n"))
+	})
+
+	It("should only write whole lines to Out, so sessions' lines never run into each other", func() {
+		start("a", "printf 'par'; sleep 0.3; printf 'tial\\n'; printf 'no newline'")
+		start("b", "sleep 0.1; echo whole")
+		group.WaitAll()
+
+		Expect(string(group.Out.Contents())).Should(Equal("[b] whole\n[a] partial\n[a] no newline"))
+	})
+
+	It("should signal all sessions and wait for them to exit", func() {
+		for _, name := range []string{"a", "b"} {
+			_, err := group.Start(name, exec.Command("sleep", "10000"))
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+
+		group.SignalAll(syscall.SIGTERM).WaitAll()
+		for _, session := range group.Sessions() {
+			Expect(session).Should(Exit(128 + int(syscall.SIGTERM)))
+		}
+	})
+
+	It("should fail WaitAll and name the sessions that are still running", func() {
+		start("fast", "exit 0")
+		_, err := group.Start("slow", exec.Command("sleep", "10000"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		failures := InterceptGomegaFailures(func() {
+			group.WaitAll("100ms")
+		})
+		Expect(failures).Should(HaveLen(1))
+		Expect(failures[0]).Should(ContainSubstring("These are still running"))
+		Expect(failures[0]).Should(ContainSubstring("slow"))
+		Expect(failures[0]).ShouldNot(ContainSubstring("fast"))
+	})
+
+	Describe("AnyExitedNonZero", func() {
+		It("should succeed if any session exited with a non-zero exit code", func() {
+			start("ok", "exit 0")
+			start("broken", "exit 3")
+			group.WaitAll()
+
+			Expect(group).Should(AnyExitedNonZero())
+			failures := InterceptGomegaFailures(func() {
+				Expect(group).ShouldNot(AnyExitedNonZero())
+			})
+			Expect(failures).Should(ConsistOf("Expected no session in the group to exit with a non-zero exit code.  These sessions did:\n    [broken] exited with code 3"))
+		})
+
+		It("should fail if every session exited cleanly", func() {
+			start("a", "exit 0")
+			start("b", "exit 0")
+			group.WaitAll()
+
+			Expect(group).ShouldNot(AnyExitedNonZero())
+			failures := InterceptGomegaFailures(func() {
+				Expect(group).Should(AnyExitedNonZero())
+			})
+			Expect(failures).Should(ConsistOf("Expected a session in the group to exit with a non-zero exit code.  Exit codes were:\n    [a] exited with code 0\n    [b] exited with code 0"))
+		})
+
+		It("should not count sessions that exited because the group signalled them", func() {
+			start("server", "exec sleep 10000")
+			start("worker", "trap 'exit 3' TERM; while true; do sleep 0.05; done")
+			start("cli", "exit 0")
+			Eventually(group.Session("cli")).Should(Exit(0))
+
+			group.TerminateAll().WaitAll()
+			Expect(group.Session("server")).Should(Exit(128 + int(syscall.SIGTERM)))
+			failures := InterceptGomegaFailures(func() {
+				Expect(group).ShouldNot(AnyExitedNonZero())
+			})
+			Expect(failures).Should(ConsistOf("Expected no session in the group to exit with a non-zero exit code.  These sessions did:\n    [worker] exited with code 3"))
+		})
+
+		It("should pass the documented terminate-and-wait pattern", func() {
+			start("a", "exec sleep 10000")
+			start("b", "exec sleep 10000")
+			group.TerminateAll().WaitAll()
+			Expect(group).ShouldNot(AnyExitedNonZero())
+
+			failures := InterceptGomegaFailures(func() {
+				Expect(group).Should(AnyExitedNonZero())
+			})
+			Expect(failures).Should(ConsistOf("Expected a session in the group to exit with a non-zero exit code.  Exit codes were:\n    [a] exited with code 143, because of the signal the group sent it\n    [b] exited with code 143, because of the signal the group sent it"))
+		})
+
+		It("should abort Eventually once every session has exited", func() {
+			start("a", "exit 0")
+			group.WaitAll()
+
+			failures := InterceptGomegaFailures(func() {
+				Eventually(group, "10s").Should(AnyExitedNonZero())
+			})
+			Expect(failures).Should(HaveLen(1))
+		})
+
+		It("should error when not passed a SessionGroup", func() {
+			success, err := AnyExitedNonZero().Match("foo")
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,80 @@
+package gexec
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/onsi/gomega/format"
+)
+
+/*
+The ShutDownGracefully matcher operates on a running session:
+
+	Expect(session).Should(ShutDownGracefully(5 * time.Second))
+
+ShutDownGracefully sends the session an interrupt - or the passed-in signal - and passes if the process then exits
+with status code 0 within deadline.  It fails if the process exits with any other status code, or is still running
+once deadline has passed.  ShutDownGracefully does not kill processes that fail to shut down; use Kill or KillAndWait
+to clean up after them.
+
+As it signals the process, ShutDownGracefully should only be used with Expect, not with Eventually or Consistently.
+*/
+func ShutDownGracefully(deadline time.Duration, optionalSignal ...os.Signal) *shutDownGracefullyMatcher {
+	var signal os.Signal = os.Interrupt
+	if len(optionalSignal) > 0 {
+		signal = optionalSignal[0]
+	}
+
+	return &shutDownGracefullyMatcher{
+		deadline: deadline,
+		signal:   signal,
+	}
+}
+
+type shutDownGracefullyMatcher struct {
+	deadline time.Duration
+	signal   os.Signal
+
+	exited   bool
+	exitCode int
+	elapsed  time.Duration
+}
+
+func (m *shutDownGracefullyMatcher) Match(actual interface{}) (success bool, err error) {
+	session, ok := actual.(*Session)
+	if !ok {
+		return false, fmt.Errorf("ShutDownGracefully must be passed a *gexec.Session.  Got:\n%s", format.Object(actual, 1))
+	}
+	if session.ExitCode() != -1 {
+		return false, fmt.Errorf("ShutDownGracefully must be passed a running session, but the process has already exited with status code %d", session.ExitCode())
+	}
+
+	start := time.Now()
+	session.Signal(m.signal)
+	timer := time.NewTimer(m.deadline)
+	defer timer.Stop()
+	select {
+	case <-session.Exited:
+		m.exited, m.exitCode, m.elapsed = true, session.ExitCode(), time.Since(start)
+		return m.exitCode == 0, nil
+	case <-timer.C:
+		m.exited = false
+		return false, nil
+	}
+}
+
+func (m *shutDownGracefullyMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected process to shut down gracefully within %s of receiving signal %s.  %s", m.deadline, m.signal, m.outcome())
+}
+
+func (m *shutDownGracefullyMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected process not to shut down gracefully within %s of receiving signal %s.  %s", m.deadline, m.signal, m.outcome())
+}
+
+func (m *shutDownGracefullyMatcher) outcome() string {
+	if !m.exited {
+		return "It was still running."
+	}
+	return fmt.Sprintf("It exited with status code %d after %s.", m.exitCode, m.elapsed.Round(time.Millisecond))
+}
@@ -0,0 +1,67 @@
+//go:build !windows
+// +build !windows
+
+package gexec_test
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("ShutDownGracefully", func() {
+	var gracefulPath string
+
+	BeforeEach(func() {
+		var err error
+		gracefulPath, err = Build("./_fixture/graceful")
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	start := func(args ...string) *Session {
+		session, err := Start(exec.Command(gracefulPath, args...), GinkgoWriter, GinkgoWriter)
+		Ω(err).ShouldNot(HaveOccurred())
+		DeferCleanup(func() { session.Kill().Wait() })
+		Eventually(session).Should(Say("ready"))
+		return session
+	}
+
+	It("passes when the process exits cleanly within the deadline", func() {
+		Ω(start("exit", "0", "10ms")).Should(ShutDownGracefully(5 * time.Second))
+	})
+
+	It("sends the passed-in signal", func() {
+		Ω(start("exit", "0", "0s")).Should(ShutDownGracefully(5*time.Second, syscall.SIGTERM))
+	})
+
+	It("fails when the process exits with a non-zero status code", func() {
+		session := start("exit", "3", "0s")
+		matcher := ShutDownGracefully(5 * time.Second)
+		Ω(matcher.Match(session)).Should(BeFalse())
+		Ω(matcher.FailureMessage(session)).Should(MatchRegexp(`^Expected process to shut down gracefully within 5s of receiving signal interrupt\.  It exited with status code 3 after \d+m?s\.$`))
+	})
+
+	It("fails when the process is still running after the deadline", func() {
+		session := start("ignore")
+		matcher := ShutDownGracefully(50 * time.Millisecond)
+		Ω(matcher.Match(session)).Should(BeFalse())
+		Ω(matcher.FailureMessage(session)).Should(Equal("Expected process to shut down gracefully within 50ms of receiving signal interrupt.  It was still running."))
+		Ω(session.ExitCode()).Should(Equal(-1))
+	})
+
+	It("errors when the session has already exited", func() {
+		session := start("exit", "0", "0s").Interrupt().Wait(5 * time.Second)
+		_, err := ShutDownGracefully(time.Second).Match(session)
+		Ω(err).Should(MatchError("ShutDownGracefully must be passed a running session, but the process has already exited with status code 0"))
+	})
+
+	It("errors when not passed a *Session", func() {
+		_, err := ShutDownGracefully(time.Second).Match("session")
+		Ω(err).Should(MatchError(ContainSubstring("ShutDownGracefully must be passed a *gexec.Session")))
+	})
+})
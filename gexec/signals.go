@@ -0,0 +1,131 @@
+package gexec
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/onsi/gomega/format"
+)
+
+/*
+A SignalTrap records the signals delivered to the test process.  Use TrapSignals to set one, and ReceiveSignal to
+assert that the code under test sent - or caused the OS to send - a signal:
+
+	trap := gexec.TrapSignals(syscall.SIGTERM)
+	DeferCleanup(trap.Stop)
+
+	supervisor.Shutdown()
+	Eventually(trap).Should(gexec.ReceiveSignal(syscall.SIGTERM))
+
+While the trap is set the signals it traps no longer have their default effect on the test process - a trapped SIGTERM
+won't terminate it, for example.
+*/
+type SignalTrap struct {
+	signals  chan os.Signal
+	done     chan struct{}
+	lock     *sync.Mutex
+	received []os.Signal
+}
+
+// TrapSignals starts recording the passed-in signals as they are delivered to the test process.  With no signals, it
+// records os.Interrupt and syscall.SIGTERM.  Call Stop to restore their default behavior.
+func TrapSignals(signals ...os.Signal) *SignalTrap {
+	if len(signals) == 0 {
+		// signal.Notify with no signals would trap every signal - including the SIGURGs the Go runtime uses internally
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	trap := &SignalTrap{
+		signals: make(chan os.Signal, 16),
+		done:    make(chan struct{}),
+		lock:    &sync.Mutex{},
+	}
+	signal.Notify(trap.signals, signals...)
+	go func() {
+		for {
+			select {
+			case sig := <-trap.signals:
+				trap.lock.Lock()
+				trap.received = append(trap.received, sig)
+				trap.lock.Unlock()
+			case <-trap.done:
+				return
+			}
+		}
+	}()
+	return trap
+}
+
+// Received returns the signals the trap has recorded, in the order they were delivered
+func (t *SignalTrap) Received() []os.Signal {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return append([]os.Signal{}, t.received...)
+}
+
+// Stop stops recording signals and restores their default behavior.  It is safe to call more than once.
+func (t *SignalTrap) Stop() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	select {
+	case <-t.done:
+	default:
+		signal.Stop(t.signals)
+		close(t.done)
+	}
+}
+
+/*
+The ReceiveSignal matcher operates on a *SignalTrap:
+
+	Eventually(trap).Should(ReceiveSignal(syscall.SIGTERM))
+
+ReceiveSignal passes if the trap has recorded the passed-in signal.
+*/
+func ReceiveSignal(signal os.Signal) *receiveSignalMatcher {
+	return &receiveSignalMatcher{
+		signal: signal,
+	}
+}
+
+type receiveSignalMatcher struct {
+	signal   os.Signal
+	received []os.Signal
+}
+
+func (m *receiveSignalMatcher) Match(actual interface{}) (success bool, err error) {
+	trap, ok := actual.(*SignalTrap)
+	if !ok {
+		return false, fmt.Errorf("ReceiveSignal must be passed a *gexec.SignalTrap.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	m.received = trap.Received()
+	for _, received := range m.received {
+		if received == m.signal {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *receiveSignalMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected to receive signal %s.  %s", m.signal, describeSignals(m.received))
+}
+
+func (m *receiveSignalMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected not to receive signal %s.  %s", m.signal, describeSignals(m.received))
+}
+
+func describeSignals(signals []os.Signal) string {
+	if len(signals) == 0 {
+		return "No signals were received."
+	}
+	names := make([]string, len(signals))
+	for i, signal := range signals {
+		names[i] = signal.String()
+	}
+	return "Received: " + strings.Join(names, ", ")
+}
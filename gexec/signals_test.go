@@ -0,0 +1,74 @@
+//go:build !windows
+// +build !windows
+
+package gexec_test
+
+import (
+	"os"
+	"syscall"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("SignalTrap", func() {
+	var trap *SignalTrap
+
+	BeforeEach(func() {
+		trap = TrapSignals(syscall.SIGUSR1, syscall.SIGUSR2)
+		DeferCleanup(trap.Stop)
+	})
+
+	It("records the signals delivered to the test process", func() {
+		Ω(trap.Received()).Should(BeEmpty())
+		Ω(syscall.Kill(os.Getpid(), syscall.SIGUSR1)).Should(Succeed())
+		Eventually(trap).Should(ReceiveSignal(syscall.SIGUSR1))
+		Ω(trap).ShouldNot(ReceiveSignal(syscall.SIGUSR2))
+
+		Ω(syscall.Kill(os.Getpid(), syscall.SIGUSR2)).Should(Succeed())
+		Eventually(trap.Received).Should(Equal([]os.Signal{syscall.SIGUSR1, syscall.SIGUSR2}))
+	})
+
+	It("stops recording once stopped", func() {
+		trap.Stop()
+		trap.Stop()
+		ignored := TrapSignals(syscall.SIGUSR1)
+		defer ignored.Stop()
+		Ω(syscall.Kill(os.Getpid(), syscall.SIGUSR1)).Should(Succeed())
+		Eventually(ignored).Should(ReceiveSignal(syscall.SIGUSR1))
+		Ω(trap.Received()).Should(BeEmpty())
+	})
+
+	It("does not trap every signal when no signals are passed in", func() {
+		// Ginkgo handles interrupts and terminations itself, so only check that other signals aren't trapped
+		defaults := TrapSignals()
+		defer defaults.Stop()
+		Ω(syscall.Kill(os.Getpid(), syscall.SIGUSR1)).Should(Succeed())
+		Eventually(trap).Should(ReceiveSignal(syscall.SIGUSR1))
+		Consistently(defaults.Received, 50*time.Millisecond).Should(BeEmpty())
+	})
+
+	Describe("ReceiveSignal", func() {
+		It("reports the signals that were received", func() {
+			matcher := ReceiveSignal(syscall.SIGUSR2)
+			Ω(matcher.Match(trap)).Should(BeFalse())
+			Ω(matcher.FailureMessage(trap)).Should(Equal("Expected to receive signal user defined signal 2.  No signals were received."))
+
+			Ω(syscall.Kill(os.Getpid(), syscall.SIGUSR1)).Should(Succeed())
+			Eventually(trap.Received).ShouldNot(BeEmpty())
+			Ω(matcher.Match(trap)).Should(BeFalse())
+			Ω(matcher.FailureMessage(trap)).Should(Equal("Expected to receive signal user defined signal 2.  Received: user defined signal 1"))
+
+			matcher = ReceiveSignal(syscall.SIGUSR1)
+			Ω(matcher.Match(trap)).Should(BeTrue())
+			Ω(matcher.NegatedFailureMessage(trap)).Should(Equal("Expected not to receive signal user defined signal 1.  Received: user defined signal 1"))
+		})
+
+		It("errors when not passed a *SignalTrap", func() {
+			_, err := ReceiveSignal(syscall.SIGUSR1).Match("trap")
+			Ω(err).Should(MatchError(ContainSubstring("ReceiveSignal must be passed a *gexec.SignalTrap")))
+		})
+	})
+})
@@ -0,0 +1,147 @@
+package gfs
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"testing/fstest"
+
+	"github.com/onsi/gomega/format"
+)
+
+/*
+Archive reads a tar, gzipped tar or zip archive into an in-memory fs.FS, so that the gfs matchers - including
+MatchDirectoryTree - can make assertions about its contents.  archive is the content of the archive, as a []byte or
+an io.Reader, or the path to a file on disk holding it:
+
+	archiveFS, err := gfs.Archive("dist/app.tar.gz")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(gfs.File(archiveFS, "bin/app")).To(gfs.HavePermissions(0755))
+
+Symbolic links are kept as links, and hard links are copies of the file they link to.
+*/
+func Archive(archive interface{}) (fs.FS, error) {
+	var content []byte
+	var err error
+	switch x := archive.(type) {
+	case []byte:
+		content = x
+	case string:
+		content, err = os.ReadFile(x)
+	case io.Reader:
+		content, err = io.ReadAll(x)
+	default:
+		return nil, fmt.Errorf("gfs expects an archive as a []byte, an io.Reader, or the path to a file.  Got:\n%s", format.Object(archive, 1))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gfs could not read the archive: %s", err.Error())
+	}
+
+	fsys := fstest.MapFS{}
+	if bytes.HasPrefix(content, []byte("PK\x03\x04")) || bytes.HasPrefix(content, []byte("PK\x05\x06")) {
+		err = readZip(fsys, content)
+	} else {
+		err = readTar(fsys, content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("gfs expects a tar, gzipped tar or zip archive, but reading it failed: %s", err.Error())
+	}
+	return fsys, nil
+}
+
+// archiveName converts the name of an archive entry into an fs.FS path
+func archiveName(name string) (string, error) {
+	cleaned := path.Clean(strings.TrimPrefix(name, "/"))
+	if !fs.ValidPath(cleaned) {
+		return "", fmt.Errorf("the entry %q has an invalid path", name)
+	}
+	return cleaned, nil
+}
+
+func readZip(fsys fstest.MapFS, content []byte) error {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return err
+	}
+	for _, f := range reader.File {
+		name, err := archiveName(f.Name)
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			continue
+		}
+		mapFile := &fstest.MapFile{Mode: f.Mode(), ModTime: f.Modified}
+		if !f.Mode().IsDir() {
+			// zip archives store the target of a symbolic link as its content
+			rc, err := f.Open()
+			if err != nil {
+				return err
+			}
+			mapFile.Data, err = io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return err
+			}
+		}
+		fsys[name] = mapFile
+	}
+	return nil
+}
+
+func readTar(fsys fstest.MapFS, content []byte) error {
+	var r io.Reader = bytes.NewReader(content)
+	if bytes.HasPrefix(content, []byte{0x1f, 0x8b}) {
+		gzipReader, err := gzip.NewReader(r)
+		if err != nil {
+			return err
+		}
+		r = gzipReader
+	}
+	reader := tar.NewReader(r)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name, err := archiveName(header.Name)
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			continue
+		}
+		mapFile := &fstest.MapFile{Mode: header.FileInfo().Mode(), ModTime: header.ModTime}
+		switch header.Typeflag {
+		case tar.TypeSymlink:
+			mapFile.Data = []byte(header.Linkname)
+		case tar.TypeLink:
+			target, err := archiveName(header.Linkname)
+			if err != nil {
+				return err
+			}
+			linked, ok := fsys[target]
+			if !ok {
+				return fmt.Errorf("the entry %q links to %q, which is not an earlier entry in the archive", header.Name, header.Linkname)
+			}
+			copied := *linked
+			mapFile = &copied
+		default:
+			mapFile.Data, err = io.ReadAll(reader)
+			if err != nil {
+				return err
+			}
+		}
+		fsys[name] = mapFile
+	}
+}
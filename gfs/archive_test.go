@@ -0,0 +1,152 @@
+package gfs_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gfs"
+)
+
+type archiveEntry struct {
+	name     string
+	mode     fs.FileMode
+	content  string
+	linkname string
+	typeflag byte
+}
+
+func tarArchive(entries ...archiveEntry) []byte {
+	buffer := &bytes.Buffer{}
+	writer := tar.NewWriter(buffer)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: int64(entry.mode.Perm()), Size: int64(len(entry.content)), Linkname: entry.linkname, Typeflag: entry.typeflag}
+		if header.Typeflag == 0 {
+			header.Typeflag = tar.TypeReg
+			if strings.HasSuffix(entry.name, "/") {
+				header.Typeflag = tar.TypeDir
+			}
+		}
+		if header.Typeflag != tar.TypeReg {
+			header.Size = 0
+		}
+		Expect(writer.WriteHeader(header)).Should(Succeed())
+		if header.Size > 0 {
+			_, err := writer.Write([]byte(entry.content))
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+	}
+	Expect(writer.Close()).Should(Succeed())
+	return buffer.Bytes()
+}
+
+func gzipped(content []byte) []byte {
+	buffer := &bytes.Buffer{}
+	writer := gzip.NewWriter(buffer)
+	_, err := writer.Write(content)
+	Expect(err).ShouldNot(HaveOccurred())
+	Expect(writer.Close()).Should(Succeed())
+	return buffer.Bytes()
+}
+
+func zipArchive(entries ...archiveEntry) []byte {
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate}
+		mode := entry.mode
+		if strings.HasSuffix(entry.name, "/") {
+			mode |= fs.ModeDir
+		}
+		header.SetMode(mode)
+		w, err := writer.CreateHeader(header)
+		Expect(err).ShouldNot(HaveOccurred())
+		if !mode.IsDir() {
+			_, err = w.Write([]byte(entry.content))
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+	}
+	Expect(writer.Close()).Should(Succeed())
+	return buffer.Bytes()
+}
+
+var _ = Describe("Archive", func() {
+	entries := []archiveEntry{
+		{name: "./bin/", mode: 0755},
+		{name: "./bin/app", mode: 0755, content: "#!/bin/sh"},
+		{name: "./README.md", mode: 0644, content: "# app"},
+	}
+
+	expectArchive := func(archiveFS fs.FS) {
+		Expect(fs.ReadFile(archiveFS, "bin/app")).Should(Equal([]byte("#!/bin/sh")))
+		Expect(gfs.File(archiveFS, "bin/app")).Should(gfs.HavePermissions(0755))
+		Expect(gfs.File(archiveFS, "README.md")).Should(gfs.HaveFileContents("# app"))
+		Expect(archiveFS).Should(gfs.MatchDirectoryTree(0, gfs.Entries{"bin/app": nil, "README.md": nil}))
+	}
+
+	It("should read tar archives", func() {
+		archiveFS, err := gfs.Archive(tarArchive(entries...))
+		Expect(err).ShouldNot(HaveOccurred())
+		expectArchive(archiveFS)
+	})
+
+	It("should read gzipped tar archives", func() {
+		archiveFS, err := gfs.Archive(gzipped(tarArchive(entries...)))
+		Expect(err).ShouldNot(HaveOccurred())
+		expectArchive(archiveFS)
+	})
+
+	It("should read zip archives", func() {
+		archiveFS, err := gfs.Archive(zipArchive(entries...))
+		Expect(err).ShouldNot(HaveOccurred())
+		expectArchive(archiveFS)
+	})
+
+	It("should read archives from readers and files", func() {
+		archiveFS, err := gfs.Archive(bytes.NewReader(zipArchive(entries...)))
+		Expect(err).ShouldNot(HaveOccurred())
+		expectArchive(archiveFS)
+
+		path := filepath.Join(GinkgoT().TempDir(), "app.tar.gz")
+		Expect(os.WriteFile(path, gzipped(tarArchive(entries...)), 0644)).Should(Succeed())
+		archiveFS, err = gfs.Archive(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		expectArchive(archiveFS)
+	})
+
+	It("should keep symbolic links and copy hard links", func() {
+		archiveFS, err := gfs.Archive(tarArchive(
+			archiveEntry{name: "releases/1.2.3", mode: 0644, content: "v1.2.3"},
+			archiveEntry{name: "current", linkname: "releases/1.2.3", typeflag: tar.TypeSymlink},
+			archiveEntry{name: "latest", linkname: "releases/1.2.3", typeflag: tar.TypeLink},
+		))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(fs.ReadFile(archiveFS, "latest")).Should(Equal([]byte("v1.2.3")))
+		info, err := fs.Stat(archiveFS, "current")
+		if err == nil && info.Mode()&fs.ModeSymlink == 0 {
+			// since Go 1.25 fstest.MapFS follows symbolic links
+			Expect(fs.ReadFile(archiveFS, "current")).Should(Equal([]byte("v1.2.3")))
+		}
+	})
+
+	It("should error when the archive can't be read", func() {
+		_, err := gfs.Archive(3)
+		Expect(err).Should(MatchError(ContainSubstring("gfs expects an archive as a []byte, an io.Reader, or the path to a file.  Got:")))
+
+		_, err = gfs.Archive(filepath.Join(GinkgoT().TempDir(), "missing.tar"))
+		Expect(err).Should(MatchError(HavePrefix("gfs could not read the archive: ")))
+
+		_, err = gfs.Archive([]byte("not an archive"))
+		Expect(err).Should(MatchError(HavePrefix("gfs expects a tar, gzipped tar or zip archive, but reading it failed: ")))
+
+		_, err = gfs.Archive(tarArchive(archiveEntry{name: "../escape", content: "x"}))
+		Expect(err).Should(MatchError(`gfs expects a tar, gzipped tar or zip archive, but reading it failed: the entry "../escape" has an invalid path`))
+	})
+})
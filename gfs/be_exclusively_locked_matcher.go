@@ -0,0 +1,44 @@
+package gfs
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+BeExclusivelyLocked succeeds if actual - a path on disk - is a file on which an exclusive flock(2) lock is held,
+such that not even a shared lock could be taken right now.  Use it to assert that a daemon holds its lockfile:
+
+	Eventually("/var/run/app.lock").Should(gfs.BeExclusivelyLocked())
+
+Unlike Not(BeLockable()), BeExclusivelyLocked fails if only shared locks are held.  BeExclusivelyLocked errors on
+platforms that do not support flock(2), such as Windows.
+*/
+func BeExclusivelyLocked() types.GomegaMatcher {
+	return &BeExclusivelyLockedMatcher{}
+}
+
+type BeExclusivelyLockedMatcher struct {
+}
+
+func (matcher *BeExclusivelyLockedMatcher) Match(actual interface{}) (success bool, err error) {
+	path, err := toLockPath("BeExclusivelyLocked", actual)
+	if err != nil {
+		return false, err
+	}
+	lockable, err := tryLock(path, false)
+	if err != nil {
+		return false, fmt.Errorf("BeExclusivelyLocked matcher could not lock %s:\n%s", path, format.IndentString(err.Error(), 1))
+	}
+	return !lockable, nil
+}
+
+func (matcher *BeExclusivelyLockedMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected %s to be exclusively locked, but a shared lock could be taken on it", actual)
+}
+
+func (matcher *BeExclusivelyLockedMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected %s not to be exclusively locked, but an exclusive lock is held on it", actual)
+}
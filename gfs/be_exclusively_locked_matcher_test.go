@@ -0,0 +1,46 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package gfs_test
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gfs"
+)
+
+var _ = Describe("BeExclusivelyLocked", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "app.lock")
+		Expect(os.WriteFile(path, nil, 0644)).Should(Succeed())
+	})
+
+	It("should succeed if an exclusive lock is held", func() {
+		Expect(path).ShouldNot(gfs.BeExclusivelyLocked())
+		holdLock(path, syscall.LOCK_EX)
+		Expect(path).Should(gfs.BeExclusivelyLocked())
+	})
+
+	It("should fail if only shared locks are held", func() {
+		holdLock(path, syscall.LOCK_SH)
+		Expect(path).ShouldNot(gfs.BeExclusivelyLocked())
+		Expect(path).ShouldNot(gfs.BeLockable())
+	})
+
+	It("should error if the file does not exist", func() {
+		_, err := gfs.BeExclusivelyLocked().Match(filepath.Join(filepath.Dir(path), "missing.lock"))
+		Expect(err).Should(MatchError(ContainSubstring("BeExclusivelyLocked matcher could not lock")))
+	})
+
+	It("should report the lock", func() {
+		m := gfs.BeExclusivelyLocked()
+		Expect(m.Match(path)).Should(BeFalse())
+		Expect(m.FailureMessage(path)).Should(Equal("Expected " + path + " to be exclusively locked, but a shared lock could be taken on it"))
+		Expect(m.NegatedFailureMessage(path)).Should(Equal("Expected " + path + " not to be exclusively locked, but an exclusive lock is held on it"))
+	})
+})
@@ -0,0 +1,53 @@
+package gfs
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+BeLockable succeeds if actual - a path on disk - is a file on which an exclusive flock(2) lock could be taken right
+now.  BeLockable takes the lock without blocking and releases it immediately:
+
+	Expect("/var/run/app.lock").To(gfs.BeLockable())
+
+BeLockable errors on platforms that do not support flock(2), such as Windows.
+*/
+func BeLockable() types.GomegaMatcher {
+	return &BeLockableMatcher{}
+}
+
+type BeLockableMatcher struct {
+}
+
+func (matcher *BeLockableMatcher) Match(actual interface{}) (success bool, err error) {
+	path, err := toLockPath("BeLockable", actual)
+	if err != nil {
+		return false, err
+	}
+	success, err = tryLock(path, true)
+	if err != nil {
+		return false, fmt.Errorf("BeLockable matcher could not lock %s:\n%s", path, format.IndentString(err.Error(), 1))
+	}
+	return success, nil
+}
+
+func (matcher *BeLockableMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected %s to be lockable, but another lock is held on it", actual)
+}
+
+func (matcher *BeLockableMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected %s not to be lockable, but no lock is held on it", actual)
+}
+
+// toLockPath returns the path of the file whose locks a lock matcher inspects.  Locks belong to files on disk, so
+// a gfs.FileInFS is not supported.
+func toLockPath(matcherName string, actual interface{}) (string, error) {
+	path, ok := actual.(string)
+	if !ok {
+		return "", fmt.Errorf("%s matcher expects a file path.  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+	return path, nil
+}
@@ -0,0 +1,57 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package gfs_test
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gfs"
+)
+
+// holdLock takes a flock(2) lock on path through its own file descriptor, as another process would, until the spec
+// ends
+func holdLock(path string, how int) {
+	f, err := os.Open(path)
+	Expect(err).ShouldNot(HaveOccurred())
+	DeferCleanup(f.Close)
+	Expect(syscall.Flock(int(f.Fd()), how|syscall.LOCK_NB)).Should(Succeed())
+}
+
+var _ = Describe("BeLockable", func() {
+	var path string
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "app.lock")
+		Expect(os.WriteFile(path, nil, 0644)).Should(Succeed())
+	})
+
+	It("should succeed if no lock is held", func() {
+		Expect(path).Should(gfs.BeLockable())
+		Expect(path).Should(gfs.BeLockable(), "the lock should have been released")
+	})
+
+	It("should fail if any lock is held", func() {
+		holdLock(path, syscall.LOCK_SH)
+		Expect(path).ShouldNot(gfs.BeLockable())
+	})
+
+	It("should error if the file does not exist or is not a path", func() {
+		_, err := gfs.BeLockable().Match(filepath.Join(filepath.Dir(path), "missing.lock"))
+		Expect(err).Should(MatchError(ContainSubstring("BeLockable matcher could not lock")))
+
+		_, err = gfs.BeLockable().Match(gfs.File(os.DirFS(filepath.Dir(path)), "app.lock"))
+		Expect(err).Should(MatchError(ContainSubstring("BeLockable matcher expects a file path.")))
+	})
+
+	It("should report the lock", func() {
+		holdLock(path, syscall.LOCK_EX)
+		m := gfs.BeLockable()
+		Expect(m.Match(path)).Should(BeFalse())
+		Expect(m.FailureMessage(path)).Should(Equal("Expected " + path + " to be lockable, but another lock is held on it"))
+		Expect(m.NegatedFailureMessage(path)).Should(Equal("Expected " + path + " not to be lockable, but no lock is held on it"))
+	})
+})
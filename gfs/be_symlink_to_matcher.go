@@ -0,0 +1,81 @@
+package gfs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+BeSymlinkTo succeeds if actual - a path on disk or a gfs.FileInFS - is a symbolic link whose target is exactly
+target.  The target is compared as written in the link, without resolving it:
+
+	Expect("current").To(gfs.BeSymlinkTo("releases/1.2.3"))
+
+Filesystems must implement ReadLink and Lstat to be checked for symbolic links, and BeSymlinkTo errors for
+filesystems that don't.  Paths on disk are always checked with os.Lstat.
+*/
+func BeSymlinkTo(target string) types.GomegaMatcher {
+	return &BeSymlinkToMatcher{
+		Target: target,
+	}
+}
+
+type BeSymlinkToMatcher struct {
+	Target string
+
+	// state
+	file         file
+	exists       bool
+	isSymlink    bool
+	actualTarget string
+}
+
+func (matcher *BeSymlinkToMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.file, err = toFile("BeSymlinkTo", actual)
+	if err != nil {
+		return false, err
+	}
+
+	info, supported, err := matcher.file.lstat()
+	if !supported {
+		return false, fmt.Errorf("BeSymlinkTo matcher requires a filesystem that implements ReadLink and Lstat to check %s", matcher.file.description)
+	}
+	matcher.exists, matcher.isSymlink, matcher.actualTarget = false, false, ""
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("BeSymlinkTo matcher could not stat %s:\n%s", matcher.file.description, format.IndentString(err.Error(), 1))
+	}
+	matcher.exists = true
+	if info.Mode()&fs.ModeSymlink == 0 {
+		return false, nil
+	}
+	matcher.isSymlink = true
+
+	matcher.actualTarget, err = matcher.file.readLink()
+	if err != nil {
+		return false, fmt.Errorf("BeSymlinkTo matcher could not read the link %s:\n%s", matcher.file.description, format.IndentString(err.Error(), 1))
+	}
+	return matcher.actualTarget == matcher.Target, nil
+}
+
+func (matcher *BeSymlinkToMatcher) FailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Expected %s to be a symlink to %s, but ", matcher.file.description, matcher.Target)
+	switch {
+	case !matcher.exists:
+		return message + "it does not exist"
+	case !matcher.isSymlink:
+		return message + "it is not a symlink"
+	default:
+		return message + "it links to " + matcher.actualTarget
+	}
+}
+
+func (matcher *BeSymlinkToMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected %s not to be a symlink to %s", matcher.file.description, matcher.Target)
+}
@@ -0,0 +1,96 @@
+package gfs_test
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"testing/fstest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gfs"
+)
+
+// linkFS is a filesystem that supports symbolic links, whichever version of Go the specs run on
+type linkFS struct {
+	fstest.MapFS
+	links map[string]string
+}
+
+func (l linkFS) Lstat(name string) (fs.FileInfo, error) {
+	if _, ok := l.links[name]; ok {
+		return linkInfo(path.Base(name)), nil
+	}
+	return fs.Stat(l.MapFS, name)
+}
+
+func (l linkFS) ReadLink(name string) (string, error) {
+	return l.links[name], nil
+}
+
+type linkInfo string
+
+func (l linkInfo) Name() string       { return string(l) }
+func (l linkInfo) Size() int64        { return 0 }
+func (l linkInfo) Mode() fs.FileMode  { return fs.ModeSymlink | 0777 }
+func (l linkInfo) ModTime() time.Time { return time.Time{} }
+func (l linkInfo) IsDir() bool        { return false }
+func (l linkInfo) Sys() interface{}   { return nil }
+
+var _ = Describe("BeSymlinkTo", func() {
+	Context("with files on disk", func() {
+		var dir string
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+			Expect(os.WriteFile(filepath.Join(dir, "target.txt"), []byte("hello"), 0644)).Should(Succeed())
+			Expect(os.Symlink("target.txt", filepath.Join(dir, "link"))).Should(Succeed())
+		})
+
+		It("should compare the target of a symbolic link as written", func() {
+			Expect(filepath.Join(dir, "link")).Should(gfs.BeSymlinkTo("target.txt"))
+			Expect(filepath.Join(dir, "link")).ShouldNot(gfs.BeSymlinkTo(filepath.Join(dir, "target.txt")))
+		})
+
+		It("should report why it failed", func() {
+			m := gfs.BeSymlinkTo("other.txt")
+			Expect(m.Match(filepath.Join(dir, "link"))).Should(BeFalse())
+			Expect(m.FailureMessage(nil)).Should(Equal("Expected " + filepath.Join(dir, "link") + " to be a symlink to other.txt, but it links to target.txt"))
+
+			Expect(m.Match(filepath.Join(dir, "target.txt"))).Should(BeFalse())
+			Expect(m.FailureMessage(nil)).Should(Equal("Expected " + filepath.Join(dir, "target.txt") + " to be a symlink to other.txt, but it is not a symlink"))
+
+			Expect(m.Match(filepath.Join(dir, "missing"))).Should(BeFalse())
+			Expect(m.FailureMessage(nil)).Should(Equal("Expected " + filepath.Join(dir, "missing") + " to be a symlink to other.txt, but it does not exist"))
+
+			m = gfs.BeSymlinkTo("target.txt")
+			Expect(m.Match(filepath.Join(dir, "link"))).Should(BeTrue())
+			Expect(m.NegatedFailureMessage(nil)).Should(Equal("Expected " + filepath.Join(dir, "link") + " not to be a symlink to target.txt"))
+		})
+	})
+
+	Context("with files in a filesystem", func() {
+		var filesystem linkFS
+
+		BeforeEach(func() {
+			filesystem = linkFS{
+				MapFS: fstest.MapFS{"releases/1.2.3/app": &fstest.MapFile{Data: []byte("app")}},
+				links: map[string]string{"current": "releases/1.2.3"},
+			}
+		})
+
+		It("should compare the target of a symbolic link", func() {
+			Expect(gfs.File(filesystem, "current")).Should(gfs.BeSymlinkTo("releases/1.2.3"))
+			Expect(gfs.File(filesystem, "current")).ShouldNot(gfs.BeSymlinkTo("releases/1.2.2"))
+			Expect(gfs.File(filesystem, "releases/1.2.3/app")).ShouldNot(gfs.BeSymlinkTo("releases/1.2.3"))
+			Expect(gfs.File(filesystem, "missing")).ShouldNot(gfs.BeSymlinkTo("releases/1.2.3"))
+		})
+
+		It("should error when the filesystem does not support symbolic links", func() {
+			_, err := gfs.BeSymlinkTo("releases/1.2.3").Match(gfs.File(struct{ fs.FS }{filesystem}, "current"))
+			Expect(err).Should(MatchError("BeSymlinkTo matcher requires a filesystem that implements ReadLink and Lstat to check current in struct { fs.FS }"))
+		})
+	})
+})
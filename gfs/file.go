@@ -0,0 +1,101 @@
+package gfs
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/onsi/gomega/format"
+)
+
+/*
+FileInFS identifies a file within a filesystem.  Pass one to the gfs matchers to make assertions about a file in an
+fs.FS, or in any filesystem with a registered Adapter.
+*/
+type FileInFS struct {
+	// Filesystem is resolved with FS when a matcher needs it
+	Filesystem interface{}
+	// Name is the fs.FS path of the file: slash-separated and unrooted, e.g. "config/app.yml"
+	Name string
+}
+
+/*
+File returns a FileInFS that identifies the file called name within filesystem:
+
+	Expect(gfs.File(fstest.MapFS{...}, "config.yml")).To(gfs.HaveFileContents(ContainSubstring("debug: true")))
+*/
+func File(filesystem interface{}, name string) FileInFS {
+	return FileInFS{Filesystem: filesystem, Name: name}
+}
+
+// GomegaString keeps failure messages to the file's name rather than the entire contents of its filesystem
+func (f FileInFS) GomegaString() string {
+	return fmt.Sprintf("%s in %T", f.Name, f.Filesystem)
+}
+
+// readLinkFS is implemented by filesystems that can read symbolic links
+type readLinkFS interface {
+	ReadLink(name string) (string, error)
+	Lstat(name string) (fs.FileInfo, error)
+}
+
+// file gives the gfs matchers uniform access to a file on disk (identified by its path) or in a filesystem
+// (identified by a FileInFS)
+type file struct {
+	path string
+	fsys fs.FS
+	name string
+
+	// description identifies the file in failure messages
+	description string
+}
+
+func toFile(matcherName string, actual interface{}) (file, error) {
+	switch x := actual.(type) {
+	case string:
+		return file{path: x, description: x}, nil
+	case FileInFS:
+		fsys, err := FS(x.Filesystem)
+		if err != nil {
+			return file{}, fmt.Errorf("%s matcher could not resolve the filesystem:\n%s", matcherName, format.IndentString(err.Error(), 1))
+		}
+		return file{fsys: fsys, name: x.Name, description: x.GomegaString()}, nil
+	default:
+		return file{}, fmt.Errorf("%s matcher expects a file path or a gfs.FileInFS.  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+}
+
+func (f file) stat() (fs.FileInfo, error) {
+	if f.fsys == nil {
+		return os.Stat(f.path)
+	}
+	return fs.Stat(f.fsys, f.name)
+}
+
+func (f file) readFile() ([]byte, error) {
+	if f.fsys == nil {
+		return os.ReadFile(f.path)
+	}
+	return fs.ReadFile(f.fsys, f.name)
+}
+
+// lstat and readLink report whether the filesystem supports symbolic links at all
+func (f file) lstat() (fs.FileInfo, bool, error) {
+	if f.fsys == nil {
+		info, err := os.Lstat(f.path)
+		return info, true, err
+	}
+	linkFS, ok := f.fsys.(readLinkFS)
+	if !ok {
+		return nil, false, nil
+	}
+	info, err := linkFS.Lstat(f.name)
+	return info, true, err
+}
+
+func (f file) readLink() (string, error) {
+	if f.fsys == nil {
+		return os.Readlink(f.path)
+	}
+	return f.fsys.(readLinkFS).ReadLink(f.name)
+}
@@ -0,0 +1,38 @@
+package gfs_test
+
+import (
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/gfs"
+)
+
+var _ = Describe("File", func() {
+	It("should identify a file within a filesystem", func() {
+		mapFS := fstest.MapFS{"config.yml": &fstest.MapFile{Data: []byte("debug: true")}}
+		Expect(gfs.File(mapFS, "config.yml")).Should(Equal(gfs.FileInFS{Filesystem: mapFS, Name: "config.yml"}))
+	})
+
+	It("should describe itself by name rather than by the contents of its filesystem", func() {
+		mapFS := fstest.MapFS{"config.yml": &fstest.MapFile{Data: []byte("debug: true")}}
+		Expect(format.Object(gfs.File(mapFS, "config.yml"), 0)).Should(Equal("<gfs.FileInFS>: config.yml in fstest.MapFS"))
+	})
+
+	It("should be resolved through the registered adapters", func() {
+		DeferCleanup(gfs.ResetAdapters)
+		gfs.RegisterAdapter(memoryFSAdapter)
+		Expect(gfs.File(&memoryFS{files: map[string]string{"a.txt": "hello"}}, "a.txt")).Should(gfs.HaveFileContents("hello"))
+	})
+
+	It("should make the matchers error when the filesystem can't be resolved", func() {
+		_, err := gfs.HaveFileContents("hello").Match(gfs.File(17, "a.txt"))
+		Expect(err).Should(MatchError(ContainSubstring("HaveFileContents matcher could not resolve the filesystem:\n    gfs expects an fs.FS")))
+	})
+
+	It("should make the matchers error when passed anything other than a path or a FileInFS", func() {
+		_, err := gfs.HaveFileSize(0).Match(17)
+		Expect(err).Should(MatchError("HaveFileSize matcher expects a file path or a gfs.FileInFS.  Got:\n    <int>: 17"))
+	})
+})
@@ -0,0 +1,104 @@
+/*
+Package gfs resolves the many ways tests hold a filesystem into an io/fs.FS, so that the same assertions can run against
+a directory on disk and an in-memory filesystem.
+
+FS understands an fs.FS (fstest.MapFS, embed.FS, os.DirFS, ...) and a string, which it treats as a directory on disk:
+
+	filesystem, err := gfs.FS("/tmp/output")
+	filesystem, err = gfs.FS(fstest.MapFS{"config.yml": &fstest.MapFile{Data: []byte("debug: true")}})
+
+Other filesystem libraries can be supported by registering a thin adapter.  For example, afero ships an fs.FS wrapper:
+
+	gfs.RegisterAdapter(func(filesystem interface{}) (fs.FS, bool) {
+		if aferoFS, ok := filesystem.(afero.Fs); ok {
+			return afero.NewIOFS(aferoFS), true
+		}
+		return nil, false
+	})
+
+gfs also provides matchers for files - HaveFileContents, HaveFileSize, HavePermissions, and BeSymlinkTo.  They accept a
+path on disk, or a FileInFS identifying a file in any filesystem FS understands:
+
+	Expect(gfs.File(outputFS, "config.yml")).To(gfs.HaveFileContents(ContainSubstring("debug: true")))
+
+BeLockable and BeExclusivelyLocked inspect the flock(2) locks held on a file on disk.
+
+Archive reads a tar, gzipped tar or zip archive into an fs.FS, and HaveArchiveEntry makes assertions about the
+entries of an archive:
+
+	Expect("dist/app.tar.gz").To(gfs.HaveArchiveEntry("bin/app", gfs.HavePermissions(0755)))
+*/
+package gfs
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+
+	"github.com/onsi/gomega/format"
+)
+
+/*
+An Adapter converts a filesystem into an fs.FS.  It returns false if it does not know how to convert the filesystem
+it is passed.
+*/
+type Adapter func(filesystem interface{}) (fs.FS, bool)
+
+var (
+	adaptersLock = &sync.Mutex{}
+	adapters     = []Adapter{}
+)
+
+/*
+RegisterAdapter registers an Adapter with FS.  Adapters are consulted before the built-in conversions, most recently
+registered first, so an adapter can also override how strings or fs.FSs are resolved.  It's a good idea to register
+adapters in a BeforeSuite, or in an init function.
+*/
+func RegisterAdapter(adapter Adapter) {
+	adaptersLock.Lock()
+	defer adaptersLock.Unlock()
+	adapters = append(adapters, adapter)
+}
+
+/*
+ResetAdapters unregisters every Adapter registered with RegisterAdapter.
+*/
+func ResetAdapters() {
+	adaptersLock.Lock()
+	defer adaptersLock.Unlock()
+	adapters = []Adapter{}
+}
+
+/*
+FS converts filesystem into an fs.FS.  It consults the registered Adapters first, then accepts any fs.FS as is and
+treats a string as the path to a directory on disk.  Anything else is an error.
+*/
+func FS(filesystem interface{}) (fs.FS, error) {
+	adaptersLock.Lock()
+	registered := make([]Adapter, len(adapters))
+	copy(registered, adapters)
+	adaptersLock.Unlock()
+
+	for i := len(registered) - 1; i >= 0; i-- {
+		if converted, ok := registered[i](filesystem); ok {
+			return converted, nil
+		}
+	}
+
+	switch x := filesystem.(type) {
+	case fs.FS:
+		return x, nil
+	case string:
+		info, err := os.Stat(x)
+		if err != nil {
+			return nil, fmt.Errorf("gfs could not open the directory %s: %s", x, err.Error())
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("gfs expects a directory.  %s is not a directory", x)
+		}
+		return os.DirFS(x), nil
+	default:
+		return nil, fmt.Errorf("gfs expects an fs.FS, a path to a directory, or a filesystem with a registered adapter.  Got:\n%s", format.Object(filesystem, 1))
+	}
+}
@@ -0,0 +1,84 @@
+package gfs_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gfs"
+)
+
+// memoryFS stands in for a third-party in-memory filesystem that does not implement fs.FS
+type memoryFS struct {
+	files map[string]string
+}
+
+func memoryFSAdapter(filesystem interface{}) (fs.FS, bool) {
+	memory, ok := filesystem.(*memoryFS)
+	if !ok {
+		return nil, false
+	}
+	mapFS := fstest.MapFS{}
+	for name, contents := range memory.files {
+		mapFS[name] = &fstest.MapFile{Data: []byte(contents)}
+	}
+	return mapFS, true
+}
+
+var _ = Describe("FS", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "config.yml"), []byte("debug: true"), 0644)).Should(Succeed())
+		DeferCleanup(gfs.ResetAdapters)
+	})
+
+	It("should treat a string as a directory on disk", func() {
+		filesystem, err := gfs.FS(dir)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(fs.ReadFile(filesystem, "config.yml")).Should(Equal([]byte("debug: true")))
+	})
+
+	It("should accept any fs.FS as is", func() {
+		mapFS := fstest.MapFS{"config.yml": &fstest.MapFile{Data: []byte("debug: true")}}
+		filesystem, err := gfs.FS(mapFS)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(filesystem).Should(Equal(mapFS))
+	})
+
+	It("should consult registered adapters, so the same assertions run against every backend", func() {
+		gfs.RegisterAdapter(memoryFSAdapter)
+
+		for _, backend := range []interface{}{dir, &memoryFS{files: map[string]string{"config.yml": "debug: true"}}} {
+			filesystem, err := gfs.FS(backend)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(fs.ReadFile(filesystem, "config.yml")).Should(Equal([]byte("debug: true")))
+		}
+	})
+
+	It("should prefer the most recently registered adapter", func() {
+		override := fstest.MapFS{}
+		gfs.RegisterAdapter(func(filesystem interface{}) (fs.FS, bool) { return nil, false })
+		gfs.RegisterAdapter(func(filesystem interface{}) (fs.FS, bool) { return override, true })
+
+		Expect(gfs.FS(dir)).Should(Equal(override))
+
+		gfs.ResetAdapters()
+		Expect(gfs.FS(dir)).ShouldNot(Equal(override))
+	})
+
+	It("should error on anything else", func() {
+		_, err := gfs.FS(&memoryFS{})
+		Expect(err).Should(MatchError(ContainSubstring("registered adapter")))
+
+		_, err = gfs.FS(filepath.Join(dir, "missing"))
+		Expect(err).Should(MatchError(ContainSubstring("could not open the directory")))
+
+		_, err = gfs.FS(filepath.Join(dir, "config.yml"))
+		Expect(err).Should(MatchError(ContainSubstring("is not a directory")))
+	})
+})
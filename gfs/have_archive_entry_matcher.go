@@ -0,0 +1,126 @@
+package gfs
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+HaveArchiveEntry succeeds if actual - a tar, gzipped tar or zip archive, as accepted by Archive - has an entry called
+name that satisfies all of entryMatchers:
+
+	Expect("dist/app.tar.gz").To(gfs.HaveArchiveEntry("bin/app", gfs.HavePermissions(0755)))
+	Expect(zipContent).To(gfs.HaveArchiveEntry("config/app.yml", gfs.HaveFileContents(ContainSubstring("debug: false"))))
+
+A name ending in a slash must be a directory.  Each matcher is passed a FileInFS identifying the entry, so the other
+gfs matchers can be used directly.  If the archive has no entry called name, the failure message lists the entries it
+does have.  An archive read from an io.Reader is cached by the matcher for as long as it is passed the same reader, so
+HaveArchiveEntry can be used with Eventually.
+*/
+func HaveArchiveEntry(name string, entryMatchers ...types.GomegaMatcher) types.GomegaMatcher {
+	return &HaveArchiveEntryMatcher{
+		Name:     name,
+		Matchers: entryMatchers,
+	}
+}
+
+type HaveArchiveEntryMatcher struct {
+	Name     string
+	Matchers []types.GomegaMatcher
+
+	// state
+	cachedReader  io.Reader
+	cachedArchive fs.FS
+	archive       fs.FS
+	description   string
+	problem       string
+	failures      []string
+}
+
+func (matcher *HaveArchiveEntryMatcher) Match(actual interface{}) (success bool, err error) {
+	name := strings.TrimSuffix(matcher.Name, "/")
+	if !fs.ValidPath(name) || name == "." {
+		return false, fmt.Errorf("HaveArchiveEntry matcher expects a slash-separated path relative to the root of the archive.  Got: %q", matcher.Name)
+	}
+
+	matcher.description = "the archive"
+	if archivePath, ok := actual.(string); ok {
+		matcher.description = archivePath
+	}
+	reader, isReader := actual.(io.Reader)
+	if isReader && matcher.cachedReader != nil && reader == matcher.cachedReader {
+		// a reader can only be read once, so reuse the archive read from it by the last match
+		matcher.archive = matcher.cachedArchive
+	} else {
+		matcher.archive, err = Archive(actual)
+		if err != nil {
+			return false, fmt.Errorf("HaveArchiveEntry matcher could not read the archive:\n%s", format.IndentString(err.Error(), 1))
+		}
+		// only pointers are cached: they can be compared by identity
+		if isReader && reflect.ValueOf(reader).Kind() == reflect.Ptr {
+			matcher.cachedReader, matcher.cachedArchive = reader, matcher.archive
+		}
+	}
+
+	matcher.problem, matcher.failures = "", []string{}
+	info, err := fs.Stat(matcher.archive, name)
+	if err != nil {
+		matcher.problem = fmt.Sprintf("it has no such entry.  It has:\n%s", format.IndentString(matcher.listEntries(), 1))
+		return false, nil
+	}
+	if strings.HasSuffix(matcher.Name, "/") && !info.IsDir() {
+		matcher.problem = "it is a file, not a directory"
+		return false, nil
+	}
+
+	file := File(matcher.archive, name)
+	for _, entryMatcher := range matcher.Matchers {
+		success, err := entryMatcher.Match(file)
+		if err != nil {
+			return false, fmt.Errorf("HaveArchiveEntry matcher's matcher for %s errored:\n%s", matcher.Name, format.IndentString(err.Error(), 1))
+		}
+		if !success {
+			matcher.failures = append(matcher.failures, entryMatcher.FailureMessage(file))
+		}
+	}
+	return len(matcher.failures) == 0, nil
+}
+
+func (matcher *HaveArchiveEntryMatcher) listEntries() string {
+	names := []string{}
+	fs.WalkDir(matcher.archive, ".", func(name string, d fs.DirEntry, err error) error {
+		if err == nil && name != "." {
+			if d.IsDir() {
+				name += "/"
+			}
+			names = append(names, name)
+		}
+		return nil
+	})
+	if len(names) == 0 {
+		return "no entries"
+	}
+	sort.Strings(names)
+	return strings.Join(names, "\n")
+}
+
+func (matcher *HaveArchiveEntryMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.problem != "" {
+		return fmt.Sprintf("Expected %s to have an entry %s, but %s", matcher.description, matcher.Name, matcher.problem)
+	}
+	return fmt.Sprintf("Expected the entry %s of %s to match, but:\n%s", matcher.Name, matcher.description, format.IndentString(strings.Join(matcher.failures, "\n"), 1))
+}
+
+func (matcher *HaveArchiveEntryMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if len(matcher.Matchers) == 0 {
+		return fmt.Sprintf("Expected %s not to have an entry %s, but it does", matcher.description, matcher.Name)
+	}
+	return fmt.Sprintf("Expected %s not to have an entry %s that matches, but it does", matcher.description, matcher.Name)
+}
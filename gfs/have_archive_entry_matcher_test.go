@@ -0,0 +1,89 @@
+package gfs_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gfs"
+)
+
+var _ = Describe("HaveArchiveEntry", func() {
+	var archive []byte
+
+	BeforeEach(func() {
+		archive = gzipped(tarArchive(
+			archiveEntry{name: "bin/", mode: 0755},
+			archiveEntry{name: "bin/app", mode: 0755, content: "#!/bin/sh"},
+			archiveEntry{name: "config/app.yml", mode: 0600, content: "debug: false"},
+		))
+	})
+
+	It("should succeed if the archive has the entry", func() {
+		Expect(archive).Should(gfs.HaveArchiveEntry("bin/app"))
+		Expect(archive).Should(gfs.HaveArchiveEntry("bin/"))
+		Expect(archive).Should(gfs.HaveArchiveEntry("config"))
+		Expect(archive).ShouldNot(gfs.HaveArchiveEntry("bin/other"))
+		Expect(archive).ShouldNot(gfs.HaveArchiveEntry("bin/app/"))
+	})
+
+	It("should match the entry with all of the matchers", func() {
+		Expect(archive).Should(gfs.HaveArchiveEntry("bin/app", gfs.HavePermissions(0755)))
+		Expect(archive).Should(gfs.HaveArchiveEntry("config/app.yml", gfs.HavePermissions(0600), gfs.HaveFileContents(ContainSubstring("debug"))))
+		Expect(archive).ShouldNot(gfs.HaveArchiveEntry("config/app.yml", gfs.HavePermissions(0600), gfs.HaveFileContents(ContainSubstring("verbose"))))
+	})
+
+	It("should read archives from files", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "app.zip")
+		Expect(os.WriteFile(path, zipArchive(archiveEntry{name: "bin/app", mode: 0755, content: "#!/bin/sh"}), 0644)).Should(Succeed())
+		Expect(path).Should(gfs.HaveArchiveEntry("bin/app", gfs.HavePermissions(0755)))
+	})
+
+	It("should cache archives read from readers", func() {
+		reader := bytes.NewReader(archive)
+		matcher := gfs.HaveArchiveEntry("bin/app")
+		Expect(reader).Should(matcher)
+		Expect(reader).Should(matcher)
+	})
+
+	It("should only reuse the cached archive for the reader it was read from", func() {
+		matcher := gfs.HaveArchiveEntry("bin/app")
+		Expect(bytes.NewReader(archive)).Should(matcher)
+		Expect(bytes.NewReader(zipArchive(archiveEntry{name: "lib/app.so", content: "\x7fELF"}))).ShouldNot(matcher)
+	})
+
+	It("should error when the name is invalid, the archive can't be read or a matcher errors", func() {
+		_, err := gfs.HaveArchiveEntry("../app").Match(archive)
+		Expect(err).Should(MatchError(`HaveArchiveEntry matcher expects a slash-separated path relative to the root of the archive.  Got: "../app"`))
+
+		_, err = gfs.HaveArchiveEntry("bin/app").Match([]byte("not an archive"))
+		Expect(err).Should(MatchError(HavePrefix("HaveArchiveEntry matcher could not read the archive:\n    gfs expects a tar, gzipped tar or zip archive")))
+
+		_, err = gfs.HaveArchiveEntry("bin", gfs.HaveFileContents("x")).Match(archive)
+		Expect(err).Should(MatchError(HavePrefix("HaveArchiveEntry matcher's matcher for bin errored:\n    HaveFileContents matcher could not read bin in fstest.MapFS:")))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect(archive).Should(gfs.HaveArchiveEntry("bin/other"))
+			Expect(archive).Should(gfs.HaveArchiveEntry("bin/app/"))
+			Expect(archive).Should(gfs.HaveArchiveEntry("config/app.yml", gfs.HavePermissions(0644), gfs.HaveFileContents("debug: true")))
+			Expect(archive).ShouldNot(gfs.HaveArchiveEntry("bin/app"))
+			Expect(archive).ShouldNot(gfs.HaveArchiveEntry("bin/app", gfs.HavePermissions(0755)))
+			Expect(tarArchive()).Should(gfs.HaveArchiveEntry("bin/app"))
+		})
+		Expect(failures).Should(Equal([]string{
+			"Expected the archive to have an entry bin/other, but it has no such entry.  It has:\n    bin/\n    bin/app\n    config/\n    config/app.yml",
+			"Expected the archive to have an entry bin/app/, but it is a file, not a directory",
+			"Expected the entry config/app.yml of the archive to match, but:\n" +
+				"    Expected config/app.yml in fstest.MapFS to have permissions -rw-r--r-- (0644), but it has -rw------- (0600)\n" +
+				"    Contents of config/app.yml in fstest.MapFS failed to satisfy matcher.\n" +
+				"    Expected\n        <string>: debug: false\n    to equal\n        <string>: debug: true",
+			"Expected the archive not to have an entry bin/app, but it does",
+			"Expected the archive not to have an entry bin/app that matches, but it does",
+			"Expected the archive to have an entry bin/app, but it has no such entry.  It has:\n    no entries",
+		}))
+	})
+})
@@ -0,0 +1,61 @@
+package gfs
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+HaveFileContents succeeds if actual - a path on disk or a gfs.FileInFS - is a file whose contents satisfy expected.
+The contents are passed to expected as a string.  expected can be a value or a matcher; values are compared with
+Equal:
+
+	Expect("/etc/app/config.yml").To(gfs.HaveFileContents(ContainSubstring("debug: true")))
+	Expect(gfs.File(outputFS, "VERSION")).To(gfs.HaveFileContents("1.2.3\n"))
+*/
+func HaveFileContents(expected interface{}) types.GomegaMatcher {
+	return &HaveFileContentsMatcher{
+		Expected: expected,
+	}
+}
+
+type HaveFileContentsMatcher struct {
+	Expected interface{}
+
+	// state
+	file            file
+	contents        string
+	expectedMatcher types.GomegaMatcher
+}
+
+func (matcher *HaveFileContentsMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.file, err = toFile("HaveFileContents", actual)
+	if err != nil {
+		return false, err
+	}
+	contents, err := matcher.file.readFile()
+	if err != nil {
+		return false, fmt.Errorf("HaveFileContents matcher could not read %s:\n%s", matcher.file.description, format.IndentString(err.Error(), 1))
+	}
+	matcher.contents = string(contents)
+
+	var isMatcher bool
+	matcher.expectedMatcher, isMatcher = matcher.Expected.(types.GomegaMatcher)
+	if !isMatcher {
+		matcher.expectedMatcher = &matchers.EqualMatcher{Expected: matcher.Expected}
+	}
+	return matcher.expectedMatcher.Match(matcher.contents)
+}
+
+func (matcher *HaveFileContentsMatcher) FailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Contents of %s failed to satisfy matcher.\n", matcher.file.description)
+	return message + matcher.expectedMatcher.FailureMessage(matcher.contents)
+}
+
+func (matcher *HaveFileContentsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Contents of %s satisfied matcher, but should not have.\n", matcher.file.description)
+	return message + matcher.expectedMatcher.NegatedFailureMessage(matcher.contents)
+}
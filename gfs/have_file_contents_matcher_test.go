@@ -0,0 +1,48 @@
+package gfs_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gfs"
+)
+
+var _ = Describe("HaveFileContents", func() {
+	var path string
+	var mapFS fstest.MapFS
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "config.yml")
+		Expect(os.WriteFile(path, []byte("debug: true\n"), 0644)).Should(Succeed())
+		mapFS = fstest.MapFS{"config.yml": &fstest.MapFile{Data: []byte("debug: true\n")}}
+	})
+
+	It("should match the contents of a file on disk", func() {
+		Expect(path).Should(gfs.HaveFileContents("debug: true\n"))
+		Expect(path).Should(gfs.HaveFileContents(ContainSubstring("debug")))
+		Expect(path).ShouldNot(gfs.HaveFileContents("debug: false\n"))
+	})
+
+	It("should match the contents of a file in a filesystem", func() {
+		Expect(gfs.File(mapFS, "config.yml")).Should(gfs.HaveFileContents("debug: true\n"))
+		Expect(gfs.File(mapFS, "config.yml")).ShouldNot(gfs.HaveFileContents(ContainSubstring("false")))
+	})
+
+	It("should wrap the failure message of the matcher", func() {
+		m := gfs.HaveFileContents(ContainSubstring("false"))
+		Expect(m.Match(gfs.File(mapFS, "config.yml"))).Should(BeFalse())
+		Expect(m.FailureMessage(nil)).Should(Equal("Contents of config.yml in fstest.MapFS failed to satisfy matcher.\n" + ContainSubstring("false").FailureMessage("debug: true\n")))
+
+		m = gfs.HaveFileContents(ContainSubstring("true"))
+		Expect(m.Match(path)).Should(BeTrue())
+		Expect(m.NegatedFailureMessage(nil)).Should(Equal("Contents of " + path + " satisfied matcher, but should not have.\n" + ContainSubstring("true").NegatedFailureMessage("debug: true\n")))
+	})
+
+	It("should error when the file can't be read", func() {
+		_, err := gfs.HaveFileContents("").Match(gfs.File(mapFS, "missing.yml"))
+		Expect(err).Should(MatchError(ContainSubstring("HaveFileContents matcher could not read missing.yml in fstest.MapFS:")))
+	})
+})
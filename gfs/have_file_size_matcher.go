@@ -0,0 +1,61 @@
+package gfs
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+HaveFileSize succeeds if actual - a path on disk or a gfs.FileInFS - is a file whose size in bytes satisfies
+expected.  The size is passed to expected as an int64.  expected can be a number or a matcher; numbers are compared
+with BeNumerically("==", ...) so their type does not matter:
+
+	Expect("dist/app").To(gfs.HaveFileSize(BeNumerically("<", 20<<20)))
+	Expect(gfs.File(outputFS, "empty.txt")).To(gfs.HaveFileSize(0))
+*/
+func HaveFileSize(expected interface{}) types.GomegaMatcher {
+	return &HaveFileSizeMatcher{
+		Expected: expected,
+	}
+}
+
+type HaveFileSizeMatcher struct {
+	Expected interface{}
+
+	// state
+	file            file
+	size            int64
+	expectedMatcher types.GomegaMatcher
+}
+
+func (matcher *HaveFileSizeMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.file, err = toFile("HaveFileSize", actual)
+	if err != nil {
+		return false, err
+	}
+	info, err := matcher.file.stat()
+	if err != nil {
+		return false, fmt.Errorf("HaveFileSize matcher could not stat %s:\n%s", matcher.file.description, format.IndentString(err.Error(), 1))
+	}
+	matcher.size = info.Size()
+
+	var isMatcher bool
+	matcher.expectedMatcher, isMatcher = matcher.Expected.(types.GomegaMatcher)
+	if !isMatcher {
+		matcher.expectedMatcher = &matchers.BeNumericallyMatcher{Comparator: "==", CompareTo: []interface{}{matcher.Expected}}
+	}
+	return matcher.expectedMatcher.Match(matcher.size)
+}
+
+func (matcher *HaveFileSizeMatcher) FailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Size of %s failed to satisfy matcher.\n", matcher.file.description)
+	return message + matcher.expectedMatcher.FailureMessage(matcher.size)
+}
+
+func (matcher *HaveFileSizeMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Size of %s satisfied matcher, but should not have.\n", matcher.file.description)
+	return message + matcher.expectedMatcher.NegatedFailureMessage(matcher.size)
+}
@@ -0,0 +1,50 @@
+package gfs_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gfs"
+)
+
+var _ = Describe("HaveFileSize", func() {
+	var path string
+	var mapFS fstest.MapFS
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "data.bin")
+		Expect(os.WriteFile(path, make([]byte, 1024), 0644)).Should(Succeed())
+		mapFS = fstest.MapFS{"data.bin": &fstest.MapFile{Data: make([]byte, 10)}}
+	})
+
+	It("should compare numbers of any type", func() {
+		Expect(path).Should(gfs.HaveFileSize(1024))
+		Expect(path).Should(gfs.HaveFileSize(int64(1024)))
+		Expect(path).ShouldNot(gfs.HaveFileSize(uint8(0)))
+		Expect(gfs.File(mapFS, "data.bin")).Should(gfs.HaveFileSize(10.0))
+		Expect(gfs.File(mapFS, "data.bin")).ShouldNot(gfs.HaveFileSize(11))
+	})
+
+	It("should accept matchers", func() {
+		Expect(path).Should(gfs.HaveFileSize(BeNumerically(">", 1000)))
+		Expect(gfs.File(mapFS, "data.bin")).Should(gfs.HaveFileSize(BeNumerically("<", 1000)))
+	})
+
+	It("should wrap the failure message of the matcher", func() {
+		m := gfs.HaveFileSize(BeNumerically(">", 100))
+		Expect(m.Match(gfs.File(mapFS, "data.bin"))).Should(BeFalse())
+		Expect(m.FailureMessage(nil)).Should(Equal("Size of data.bin in fstest.MapFS failed to satisfy matcher.\n" + BeNumerically(">", 100).FailureMessage(int64(10))))
+
+		m = gfs.HaveFileSize(1024)
+		Expect(m.Match(path)).Should(BeTrue())
+		Expect(m.NegatedFailureMessage(nil)).Should(HavePrefix("Size of " + path + " satisfied matcher, but should not have.\n"))
+	})
+
+	It("should error when the file can't be stat'd", func() {
+		_, err := gfs.HaveFileSize(0).Match(path + ".missing")
+		Expect(err).Should(MatchError(ContainSubstring("HaveFileSize matcher could not stat " + path + ".missing:")))
+	})
+})
@@ -0,0 +1,93 @@
+package gfs
+
+import (
+	"fmt"
+	"io/fs"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// permissionBits are the mode bits HavePermissions compares
+const permissionBits = fs.ModePerm | fs.ModeSetuid | fs.ModeSetgid | fs.ModeSticky
+
+// unixSpecialBits are the setuid, setgid and sticky bits as written in Unix octal notation - as in chmod 4755 - along
+// with their fs.FileMode equivalents
+var unixSpecialBits = []struct {
+	unix fs.FileMode
+	mode fs.FileMode
+}{
+	{04000, fs.ModeSetuid},
+	{02000, fs.ModeSetgid},
+	{01000, fs.ModeSticky},
+}
+
+/*
+HavePermissions succeeds if actual - a path on disk or a gfs.FileInFS - is a file whose permission bits (including
+the setuid, setgid and sticky bits) are exactly permissions:
+
+	Expect("bin/run.sh").To(gfs.HavePermissions(0755))
+	Expect(gfs.File(outputFS, "secrets.yml")).To(gfs.HavePermissions(0600))
+
+The special bits can be passed either as fs.FileMode bits or in Unix octal notation, so these are equivalent:
+
+	Expect("bin/sudo").To(gfs.HavePermissions(fs.ModeSetuid | 0755))
+	Expect("bin/sudo").To(gfs.HavePermissions(04755))
+
+HavePermissions errors if permissions has any other mode bits set, such as fs.ModeDir.
+*/
+func HavePermissions(permissions fs.FileMode) types.GomegaMatcher {
+	return &HavePermissionsMatcher{
+		Permissions: permissions,
+	}
+}
+
+type HavePermissionsMatcher struct {
+	Permissions fs.FileMode
+
+	// state
+	file     file
+	expected fs.FileMode
+	actual   fs.FileMode
+}
+
+func (matcher *HavePermissionsMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.expected = matcher.Permissions
+	for _, bit := range unixSpecialBits {
+		if matcher.expected&bit.unix != 0 {
+			matcher.expected = matcher.expected&^bit.unix | bit.mode
+		}
+	}
+	if matcher.expected&^permissionBits != 0 {
+		return false, fmt.Errorf("HavePermissions matcher expects permission bits, but %s has other mode bits set", matcher.Permissions)
+	}
+	matcher.file, err = toFile("HavePermissions", actual)
+	if err != nil {
+		return false, err
+	}
+	info, err := matcher.file.stat()
+	if err != nil {
+		return false, fmt.Errorf("HavePermissions matcher could not stat %s:\n%s", matcher.file.description, format.IndentString(err.Error(), 1))
+	}
+	matcher.actual = info.Mode() & permissionBits
+	return matcher.actual == matcher.expected, nil
+}
+
+func (matcher *HavePermissionsMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected %s to have permissions %s, but it has %s", matcher.file.description, describePermissions(matcher.expected), describePermissions(matcher.actual))
+}
+
+func (matcher *HavePermissionsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected %s not to have permissions %s", matcher.file.description, describePermissions(matcher.expected))
+}
+
+// describePermissions renders permissions symbolically and in Unix octal notation
+func describePermissions(permissions fs.FileMode) string {
+	octal := permissions.Perm()
+	for _, bit := range unixSpecialBits {
+		if permissions&bit.mode != 0 {
+			octal |= bit.unix
+		}
+	}
+	return fmt.Sprintf("%s (%#o)", permissions, uint32(octal))
+}
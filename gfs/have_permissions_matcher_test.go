@@ -0,0 +1,67 @@
+package gfs_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gfs"
+)
+
+var _ = Describe("HavePermissions", func() {
+	var path string
+	var mapFS fstest.MapFS
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "run.sh")
+		Expect(os.WriteFile(path, []byte("#!/bin/sh"), 0644)).Should(Succeed())
+		Expect(os.Chmod(path, 0750)).Should(Succeed())
+		mapFS = fstest.MapFS{
+			"secrets.yml": &fstest.MapFile{Mode: 0600},
+			"shared":      &fstest.MapFile{Mode: fs.ModeDir | fs.ModeSticky | 0777},
+		}
+	})
+
+	It("should compare the permission bits of a file on disk", func() {
+		Expect(path).Should(gfs.HavePermissions(0750))
+		Expect(path).ShouldNot(gfs.HavePermissions(0755))
+	})
+
+	It("should compare the permission bits of a file in a filesystem, including the special bits", func() {
+		Expect(gfs.File(mapFS, "secrets.yml")).Should(gfs.HavePermissions(0600))
+		Expect(gfs.File(mapFS, "shared")).Should(gfs.HavePermissions(fs.ModeSticky | 0777))
+		Expect(gfs.File(mapFS, "shared")).ShouldNot(gfs.HavePermissions(0777))
+	})
+
+	It("should accept the special bits in Unix octal notation", func() {
+		Expect(gfs.File(mapFS, "shared")).Should(gfs.HavePermissions(01777))
+		Expect(gfs.File(mapFS, "shared")).ShouldNot(gfs.HavePermissions(03777))
+
+		m := gfs.HavePermissions(04755)
+		Expect(m.Match(gfs.File(mapFS, "shared"))).Should(BeFalse())
+		Expect(m.FailureMessage(nil)).Should(Equal("Expected shared in fstest.MapFS to have permissions urwxr-xr-x (04755), but it has trwxrwxrwx (01777)"))
+	})
+
+	It("should error when passed other mode bits", func() {
+		_, err := gfs.HavePermissions(fs.ModeDir | 0755).Match(gfs.File(mapFS, "shared"))
+		Expect(err).Should(MatchError("HavePermissions matcher expects permission bits, but drwxr-xr-x has other mode bits set"))
+	})
+
+	It("should report the expected and actual permissions", func() {
+		m := gfs.HavePermissions(0644)
+		Expect(m.Match(gfs.File(mapFS, "secrets.yml"))).Should(BeFalse())
+		Expect(m.FailureMessage(nil)).Should(Equal("Expected secrets.yml in fstest.MapFS to have permissions -rw-r--r-- (0644), but it has -rw------- (0600)"))
+
+		m = gfs.HavePermissions(0750)
+		Expect(m.Match(path)).Should(BeTrue())
+		Expect(m.NegatedFailureMessage(nil)).Should(Equal("Expected " + path + " not to have permissions -rwxr-x--- (0750)"))
+	})
+
+	It("should error when the file can't be stat'd", func() {
+		_, err := gfs.HavePermissions(0644).Match(gfs.File(mapFS, "missing"))
+		Expect(err).Should(MatchError(ContainSubstring("HavePermissions matcher could not stat missing in fstest.MapFS:")))
+	})
+})
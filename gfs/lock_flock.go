@@ -0,0 +1,32 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package gfs
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// tryLock reports whether a lock on the file at path could be taken without blocking.  The lock is released
+// immediately.
+func tryLock(path string, exclusive bool) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	how := syscall.LOCK_SH
+	if exclusive {
+		how = syscall.LOCK_EX
+	}
+	err = syscall.Flock(int(f.Fd()), how|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,12 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package gfs
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func tryLock(path string, exclusive bool) (bool, error) {
+	return false, fmt.Errorf("file locks cannot be inspected on %s", runtime.GOOS)
+}
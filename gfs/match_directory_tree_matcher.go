@@ -0,0 +1,201 @@
+package gfs
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// Options modify the behavior of MatchDirectoryTree
+type Options int
+
+const (
+	// IgnoreExtras tells MatchDirectoryTree to ignore files and directories that aren't listed in its entries
+	IgnoreExtras Options = 1 << iota
+)
+
+// Entries maps the paths of the files and directories in a tree to the matchers they must satisfy
+type Entries map[string]types.GomegaMatcher
+
+/*
+MatchDirectoryTree succeeds if actual - a path on disk, an fs.FS, any filesystem with a registered Adapter, or a
+FileInFS naming a directory - is a directory tree that contains exactly the listed entries:
+
+	Expect(outputDir).To(gfs.MatchDirectoryTree(0, gfs.Entries{
+		"go.mod":      gfs.HaveFileContents(HavePrefix("module example.com/app")),
+		"cmd/":        nil,
+		"cmd/main.go": gfs.HaveFileContents(ContainSubstring("package main")),
+		"bin/run.sh":  gfs.HavePermissions(0755),
+		"README.md":   nil,
+	}))
+
+Entries are slash-separated paths relative to the root of the tree.  A path ending in a slash must be a directory.
+Each entry's matcher is passed a FileInFS identifying the entry, so the other gfs matchers - including a nested
+MatchDirectoryTree - can be used directly.  A nil matcher only requires the entry to exist.
+
+By default, MatchDirectoryTree fails if the tree contains files or directories that aren't listed.  The parent
+directories of listed entries are implicitly listed, and the contents of a directory listed with a non-nil matcher
+are left to that matcher.  Pass IgnoreExtras to allow unlisted entries.  Entries may also be a gstruct.Fields.
+
+Failures report every missing, extra, and mismatched entry at once.
+*/
+func MatchDirectoryTree(options Options, entries map[string]types.GomegaMatcher) types.GomegaMatcher {
+	return &MatchDirectoryTreeMatcher{
+		Options: options,
+		Entries: entries,
+	}
+}
+
+type MatchDirectoryTreeMatcher struct {
+	Options Options
+	Entries map[string]types.GomegaMatcher
+
+	// state
+	description string
+	problems    []string
+}
+
+// treeEntry is an entry of MatchDirectoryTreeMatcher.Entries, with its path parsed
+type treeEntry struct {
+	key     string
+	name    string
+	wantDir bool
+	matcher types.GomegaMatcher
+}
+
+func (matcher *MatchDirectoryTreeMatcher) Match(actual interface{}) (success bool, err error) {
+	root, err := matcher.resolveRoot(actual)
+	if err != nil {
+		return false, err
+	}
+
+	entries := []treeEntry{}
+	for key, entryMatcher := range matcher.Entries {
+		name := strings.TrimSuffix(key, "/")
+		if !fs.ValidPath(name) || name == "." {
+			return false, fmt.Errorf("MatchDirectoryTree matcher expects entries to be slash-separated paths relative to the root of the tree.  Got: %q", key)
+		}
+		entries = append(entries, treeEntry{key: key, name: name, wantDir: strings.HasSuffix(key, "/"), matcher: entryMatcher})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+
+	tree := map[string]bool{}
+	err = fs.WalkDir(root, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name != "." {
+			tree[name] = d.IsDir()
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("MatchDirectoryTree matcher could not walk %s:\n%s", matcher.description, format.IndentString(err.Error(), 1))
+	}
+
+	matcher.problems = []string{}
+	expected := map[string]bool{}
+	delegated := []string{}
+	for _, entry := range entries {
+		for dir := path.Dir(entry.name); dir != "."; dir = path.Dir(dir) {
+			expected[dir] = true
+		}
+		expected[entry.name] = true
+
+		isDir, exists := tree[entry.name]
+		if !exists {
+			matcher.problems = append(matcher.problems, fmt.Sprintf("%s: missing", entry.key))
+			continue
+		}
+		if entry.wantDir && !isDir {
+			matcher.problems = append(matcher.problems, fmt.Sprintf("%s: expected a directory, but it is a file", entry.key))
+			continue
+		}
+		if entry.matcher == nil {
+			continue
+		}
+		if isDir {
+			delegated = append(delegated, entry.name+"/")
+		}
+
+		file := File(root, entry.name)
+		success, err := entry.matcher.Match(file)
+		if err != nil {
+			matcher.problems = append(matcher.problems, fmt.Sprintf("%s: matcher errored:\n%s", entry.key, format.IndentString(err.Error(), 1)))
+		} else if !success {
+			matcher.problems = append(matcher.problems, fmt.Sprintf("%s:\n%s", entry.key, format.IndentString(entry.matcher.FailureMessage(file), 1)))
+		}
+	}
+
+	if matcher.Options&IgnoreExtras == 0 {
+		extras := []string{}
+		for name, isDir := range tree {
+			if expected[name] || hasAnyPrefix(name, delegated) {
+				continue
+			}
+			if isDir {
+				name += "/"
+			}
+			extras = append(extras, name)
+		}
+		sort.Strings(extras)
+		extraDirs := []string{}
+		for _, extra := range extras {
+			// the contents of an extra directory are extra too - there's no need to list them
+			if hasAnyPrefix(extra, extraDirs) {
+				continue
+			}
+			if strings.HasSuffix(extra, "/") {
+				extraDirs = append(extraDirs, extra)
+			}
+			matcher.problems = append(matcher.problems, fmt.Sprintf("%s: unexpected", extra))
+		}
+	}
+
+	return len(matcher.problems) == 0, nil
+}
+
+// resolveRoot returns the tree that actual identifies
+func (matcher *MatchDirectoryTreeMatcher) resolveRoot(actual interface{}) (fs.FS, error) {
+	if file, ok := actual.(FileInFS); ok {
+		matcher.description = file.GomegaString()
+		fsys, err := FS(file.Filesystem)
+		if err != nil {
+			return nil, fmt.Errorf("MatchDirectoryTree matcher could not resolve the filesystem:\n%s", format.IndentString(err.Error(), 1))
+		}
+		return fs.Sub(fsys, file.Name)
+	}
+
+	if dir, ok := actual.(string); ok {
+		matcher.description = dir
+	} else {
+		matcher.description = fmt.Sprintf("%T", actual)
+	}
+	fsys, err := FS(actual)
+	if err != nil {
+		return nil, fmt.Errorf("MatchDirectoryTree matcher could not resolve the directory tree:\n%s", format.IndentString(err.Error(), 1))
+	}
+	return fsys, nil
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (matcher *MatchDirectoryTreeMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the directory tree at %s to match, but:\n%s", matcher.description, format.IndentString(strings.Join(matcher.problems, "\n"), 1))
+}
+
+func (matcher *MatchDirectoryTreeMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the directory tree at %s not to match, but it does", matcher.description)
+}
@@ -0,0 +1,119 @@
+package gfs_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing/fstest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gfs"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("MatchDirectoryTree", func() {
+	var tree fstest.MapFS
+
+	BeforeEach(func() {
+		tree = fstest.MapFS{
+			"go.mod":          &fstest.MapFile{Data: []byte("module example.com/app\n")},
+			"README.md":       &fstest.MapFile{Data: []byte("# app\n")},
+			"cmd/main.go":     &fstest.MapFile{Data: []byte("package main\n")},
+			"bin/run.sh":      &fstest.MapFile{Data: []byte("#!/bin/sh\n"), Mode: 0755},
+			"internal/a/a.go": &fstest.MapFile{Data: []byte("package a\n")},
+			"internal/b/b.go": &fstest.MapFile{Data: []byte("package b\n")},
+		}
+	})
+
+	It("should succeed when the tree contains exactly the listed entries", func() {
+		Expect(tree).Should(gfs.MatchDirectoryTree(0, gfs.Entries{
+			"go.mod":          gfs.HaveFileContents(HavePrefix("module example.com/app")),
+			"README.md":       nil,
+			"cmd/":            nil,
+			"cmd/main.go":     gfs.HaveFileContents(ContainSubstring("package main")),
+			"bin/run.sh":      gfs.HavePermissions(0755),
+			"internal/a/a.go": nil,
+			"internal/b/b.go": nil,
+		}))
+	})
+
+	It("should accept gstruct.Fields", func() {
+		Expect(tree).Should(gfs.MatchDirectoryTree(gfs.IgnoreExtras, gstruct.Fields{
+			"go.mod": gfs.HaveFileContents(HavePrefix("module")),
+		}))
+	})
+
+	It("should work with directories on disk", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(dir, "cmd"), 0755)).Should(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "cmd", "main.go"), []byte("package main\n"), 0644)).Should(Succeed())
+		Expect(dir).Should(gfs.MatchDirectoryTree(0, gfs.Entries{"cmd/main.go": gfs.HaveFileContents("package main\n")}))
+		Expect(dir).ShouldNot(gfs.MatchDirectoryTree(0, gfs.Entries{"cmd/": nil}))
+	})
+
+	It("should allow unlisted entries when passed IgnoreExtras", func() {
+		Expect(tree).ShouldNot(gfs.MatchDirectoryTree(0, gfs.Entries{"go.mod": nil}))
+		Expect(tree).Should(gfs.MatchDirectoryTree(gfs.IgnoreExtras, gfs.Entries{"go.mod": nil}))
+	})
+
+	It("should leave the contents of a directory listed with a matcher to that matcher", func() {
+		Expect(tree).Should(gfs.MatchDirectoryTree(gfs.IgnoreExtras, gfs.Entries{
+			"internal/": gfs.MatchDirectoryTree(0, gfs.Entries{
+				"a/a.go": gfs.HaveFileContents("package a\n"),
+				"b/b.go": nil,
+			}),
+		}))
+		Expect(gfs.File(tree, "internal")).Should(gfs.MatchDirectoryTree(0, gfs.Entries{"a/a.go": nil, "b/b.go": nil}))
+	})
+
+	It("should report every missing, extra, and mismatched entry", func() {
+		m := gfs.MatchDirectoryTree(0, gfs.Entries{
+			"go.mod":      gfs.HaveFileContents(HavePrefix("module example.com/other")),
+			"README.md/":  nil,
+			"LICENSE":     nil,
+			"cmd/main.go": nil,
+			"bin/run.sh":  gfs.HavePermissions(0644),
+		})
+		Expect(m.Match(tree)).Should(BeFalse())
+		Expect(m.FailureMessage(tree)).Should(Equal(`Expected the directory tree at fstest.MapFS to match, but:
+    LICENSE: missing
+    README.md/: expected a directory, but it is a file
+    bin/run.sh:
+        Expected bin/run.sh in fstest.MapFS to have permissions -rw-r--r-- (0644), but it has -rwxr-xr-x (0755)
+    go.mod:
+        Contents of go.mod in fstest.MapFS failed to satisfy matcher.
+        Expected
+            <string>: module example.com/app
+            
+        to have prefix
+            <string>: module example.com/other
+    internal/: unexpected`))
+	})
+
+	It("should report matchers that error", func() {
+		m := gfs.MatchDirectoryTree(gfs.IgnoreExtras, gfs.Entries{"cmd/": gfs.HaveFileContents("")})
+		Expect(m.Match(tree)).Should(BeFalse())
+		Expect(m.FailureMessage(tree)).Should(HavePrefix("Expected the directory tree at fstest.MapFS to match, but:\n    cmd/: matcher errored:\n        HaveFileContents matcher could not read cmd"))
+	})
+
+	It("should describe the tree when negated", func() {
+		m := gfs.MatchDirectoryTree(gfs.IgnoreExtras, gfs.Entries{"go.mod": nil})
+		Expect(m.Match(tree)).Should(BeTrue())
+		Expect(m.NegatedFailureMessage(tree)).Should(Equal("Expected the directory tree at fstest.MapFS not to match, but it does"))
+	})
+
+	It("should error when passed invalid entries", func() {
+		_, err := gfs.MatchDirectoryTree(0, gfs.Entries{"/etc/passwd": nil}).Match(tree)
+		Expect(err).Should(MatchError(`MatchDirectoryTree matcher expects entries to be slash-separated paths relative to the root of the tree.  Got: "/etc/passwd"`))
+		_, err = gfs.MatchDirectoryTree(0, gfs.Entries{"../up": nil}).Match(tree)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should error when the tree can't be resolved or walked", func() {
+		_, err := gfs.MatchDirectoryTree(0, gfs.Entries{}).Match(17)
+		Expect(err).Should(MatchError(ContainSubstring("MatchDirectoryTree matcher could not resolve the directory tree:")))
+
+		_, err = gfs.MatchDirectoryTree(0, gfs.Entries{}).Match(gfs.File(tree, "missing"))
+		Expect(err).Should(MatchError(ContainSubstring("MatchDirectoryTree matcher could not walk missing in fstest.MapFS:")))
+	})
+})
@@ -0,0 +1,66 @@
+package gfuture
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+BeRejectedWith succeeds if actual is a future that has been rejected with an error satisfying expected.  A matcher is
+passed the error itself; anything else is matched with MatchError, so expected can also be an error or a string.  Use
+Eventually to wait for the future to settle:
+
+	Eventually(future).Should(gfuture.BeRejectedWith(context.DeadlineExceeded))
+	Eventually(future).Should(gfuture.BeRejectedWith(MatchError(ContainSubstring("connection refused"))))
+
+Once the future has settled Eventually stops polling, whether or not the matcher succeeded.
+*/
+func BeRejectedWith(expected interface{}) types.GomegaMatcher {
+	return &BeRejectedWithMatcher{
+		Expected: expected,
+	}
+}
+
+type BeRejectedWithMatcher struct {
+	Expected interface{}
+
+	// state
+	state           State
+	expectedMatcher types.GomegaMatcher
+}
+
+func (matcher *BeRejectedWithMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.state, err = toState("BeRejectedWith", actual)
+	if err != nil {
+		return false, err
+	}
+
+	var isMatcher bool
+	matcher.expectedMatcher, isMatcher = matcher.Expected.(types.GomegaMatcher)
+	if !isMatcher {
+		matcher.expectedMatcher = &matchers.MatchErrorMatcher{Expected: matcher.Expected}
+	}
+	if !matcher.state.Settled || matcher.state.Err == nil {
+		return false, nil
+	}
+	return matcher.expectedMatcher.Match(matcher.state.Err)
+}
+
+func (matcher *BeRejectedWithMatcher) FailureMessage(actual interface{}) (message string) {
+	if !matcher.state.Settled || matcher.state.Err == nil {
+		return fmt.Sprintf("Expected a rejected future, but got a %s", describe(matcher.state))
+	}
+	return "Future was rejected with an error that failed to satisfy matcher.\n" + matcher.expectedMatcher.FailureMessage(matcher.state.Err)
+}
+
+func (matcher *BeRejectedWithMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return "Future was rejected with an error that satisfied matcher, but should not have.\n" + matcher.expectedMatcher.NegatedFailureMessage(matcher.state.Err)
+}
+
+// MatchMayChangeInTheFuture returns false once the future has settled, as a settled future never changes
+func (matcher *BeRejectedWithMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	state, err := toState("BeRejectedWith", actual)
+	return err == nil && !state.Settled
+}
@@ -0,0 +1,91 @@
+package gfuture_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gfuture"
+)
+
+var _ = Describe("BeRejectedWith", func() {
+	var future *gfuture.Future[string]
+
+	BeforeEach(func() {
+		future = gfuture.New[string]()
+	})
+
+	It("matches errors, strings, and string matchers with MatchError", func() {
+		future.Reject(fmt.Errorf("fetching: %w", context.DeadlineExceeded))
+		Ω(future).Should(gfuture.BeRejectedWith(context.DeadlineExceeded))
+		Ω(future).Should(gfuture.BeRejectedWith("fetching: context deadline exceeded"))
+		Ω(future).ShouldNot(gfuture.BeRejectedWith(context.Canceled))
+		Ω(future).ShouldNot(gfuture.BeRejectedWith("boom"))
+	})
+
+	It("passes the error to matchers", func() {
+		future.Reject(errors.New("boom"))
+		Ω(future).Should(gfuture.BeRejectedWith(MatchError(ContainSubstring("oo"))))
+		Ω(future).Should(gfuture.BeRejectedWith(HaveOccurred()))
+		Ω(future).ShouldNot(gfuture.BeRejectedWith(MatchError("bang")))
+	})
+
+	It("fails if the future is pending or was resolved", func() {
+		Ω(future).ShouldNot(gfuture.BeRejectedWith(HaveOccurred()))
+		future.Resolve("done")
+		Ω(future).ShouldNot(gfuture.BeRejectedWith(HaveOccurred()))
+	})
+
+	It("errors when actual is not a future", func() {
+		_, err := gfuture.BeRejectedWith("boom").Match(errors.New("boom"))
+		Ω(err).Should(MatchError(ContainSubstring("BeRejectedWith matcher expects a gfuture.Inspectable, such as a *gfuture.Future.  Got:")))
+	})
+
+	Describe("with Eventually", func() {
+		It("waits for the future to be rejected", func() {
+			time.AfterFunc(20*time.Millisecond, func() { future.Reject(errors.New("boom")) })
+			Eventually(future).Should(gfuture.BeRejectedWith("boom"))
+		})
+
+		It("stops polling once the future has settled", func() {
+			future.Resolve("done")
+			t := time.Now()
+			failures := InterceptGomegaFailures(func() {
+				Eventually(future).WithTimeout(time.Second).Should(gfuture.BeRejectedWith("boom"))
+			})
+			Ω(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+			Ω(failures).Should(ConsistOf(ContainSubstring("No future change is possible.  Bailing out early")))
+		})
+	})
+
+	Describe("failure messages", func() {
+		It("reports pending and resolved futures", func() {
+			failures := InterceptGomegaFailures(func() {
+				Ω(future).Should(gfuture.BeRejectedWith("boom"))
+			})
+			Ω(failures).Should(ConsistOf("Expected a rejected future, but got a pending future"))
+
+			future.Resolve("done")
+			failures = InterceptGomegaFailures(func() {
+				Ω(future).Should(gfuture.BeRejectedWith("boom"))
+			})
+			Ω(failures).Should(ConsistOf("Expected a rejected future, but got a future resolved with <string>: \"done\""))
+		})
+
+		It("reports the matcher's failure", func() {
+			future.Reject(errors.New("boom"))
+			failures := InterceptGomegaFailures(func() {
+				Ω(future).Should(gfuture.BeRejectedWith("bang"))
+			})
+			Ω(failures).Should(ConsistOf(And(HavePrefix("Future was rejected with an error that failed to satisfy matcher.\nExpected\n"), HaveSuffix("to match error\n    <string>: bang"))))
+
+			failures = InterceptGomegaFailures(func() {
+				Ω(future).ShouldNot(gfuture.BeRejectedWith("boom"))
+			})
+			Ω(failures).Should(ConsistOf(HavePrefix("Future was rejected with an error that satisfied matcher, but should not have.\n")))
+		})
+	})
+})
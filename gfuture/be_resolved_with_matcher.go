@@ -0,0 +1,64 @@
+package gfuture
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+BeResolvedWith succeeds if actual is a future that has resolved with a value satisfying expected.  expected can be a
+value or a matcher; values are compared with Equal.  Use Eventually to wait for the future to settle:
+
+	Eventually(future).Should(gfuture.BeResolvedWith(ContainSubstring("ok")))
+
+Once the future has settled Eventually stops polling, whether or not the matcher succeeded.
+*/
+func BeResolvedWith(expected interface{}) types.GomegaMatcher {
+	return &BeResolvedWithMatcher{
+		Expected: expected,
+	}
+}
+
+type BeResolvedWithMatcher struct {
+	Expected interface{}
+
+	// state
+	state           State
+	expectedMatcher types.GomegaMatcher
+}
+
+func (matcher *BeResolvedWithMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.state, err = toState("BeResolvedWith", actual)
+	if err != nil {
+		return false, err
+	}
+
+	var isMatcher bool
+	matcher.expectedMatcher, isMatcher = matcher.Expected.(types.GomegaMatcher)
+	if !isMatcher {
+		matcher.expectedMatcher = &matchers.EqualMatcher{Expected: matcher.Expected}
+	}
+	if !matcher.state.Settled || matcher.state.Err != nil {
+		return false, nil
+	}
+	return matcher.expectedMatcher.Match(matcher.state.Value)
+}
+
+func (matcher *BeResolvedWithMatcher) FailureMessage(actual interface{}) (message string) {
+	if !matcher.state.Settled || matcher.state.Err != nil {
+		return fmt.Sprintf("Expected a resolved future, but got a %s", describe(matcher.state))
+	}
+	return "Future resolved with a value that failed to satisfy matcher.\n" + matcher.expectedMatcher.FailureMessage(matcher.state.Value)
+}
+
+func (matcher *BeResolvedWithMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return "Future resolved with a value that satisfied matcher, but should not have.\n" + matcher.expectedMatcher.NegatedFailureMessage(matcher.state.Value)
+}
+
+// MatchMayChangeInTheFuture returns false once the future has settled, as a settled future never changes
+func (matcher *BeResolvedWithMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	state, err := toState("BeResolvedWith", actual)
+	return err == nil && !state.Settled
+}
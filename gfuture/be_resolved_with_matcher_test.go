@@ -0,0 +1,97 @@
+package gfuture_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gfuture"
+)
+
+var _ = Describe("BeResolvedWith", func() {
+	var future *gfuture.Future[string]
+
+	BeforeEach(func() {
+		future = gfuture.New[string]()
+	})
+
+	It("succeeds if the future resolved with a value satisfying the matcher", func() {
+		future.Resolve("done")
+		Ω(future).Should(gfuture.BeResolvedWith("done"))
+		Ω(future).Should(gfuture.BeResolvedWith(HavePrefix("do")))
+		Ω(future).ShouldNot(gfuture.BeResolvedWith("pending"))
+		Ω(future).ShouldNot(gfuture.BeResolvedWith(HavePrefix("pe")))
+	})
+
+	It("fails if the future is pending or was rejected", func() {
+		Ω(future).ShouldNot(gfuture.BeResolvedWith(BeEmpty()))
+		future.Reject(errors.New("boom"))
+		Ω(future).ShouldNot(gfuture.BeResolvedWith(BeEmpty()))
+	})
+
+	It("errors when actual is not a future", func() {
+		success, err := gfuture.BeResolvedWith("done").Match("done")
+		Ω(success).Should(BeFalse())
+		Ω(err).Should(MatchError(ContainSubstring("BeResolvedWith matcher expects a gfuture.Inspectable, such as a *gfuture.Future.  Got:")))
+
+		var nilFuture *gfuture.Future[string]
+		_, err = gfuture.BeResolvedWith("done").Match(nilFuture)
+		Ω(err).Should(HaveOccurred())
+	})
+
+	Describe("with Eventually", func() {
+		It("waits for the future to resolve", func() {
+			time.AfterFunc(20*time.Millisecond, func() { future.Resolve("done") })
+			Eventually(future).Should(gfuture.BeResolvedWith("done"))
+		})
+
+		It("stops polling once the future has settled", func() {
+			future.Reject(errors.New("boom"))
+			t := time.Now()
+			failures := InterceptGomegaFailures(func() {
+				Eventually(future).WithTimeout(time.Second).Should(gfuture.BeResolvedWith("done"))
+			})
+			Ω(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+			Ω(failures).Should(ConsistOf(ContainSubstring("No future change is possible.  Bailing out early")))
+		})
+
+		It("stops polling when the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(20*time.Millisecond, cancel)
+			failures := InterceptGomegaFailures(func() {
+				Eventually(ctx, future).Should(gfuture.BeResolvedWith("done"))
+			})
+			Ω(failures).Should(ConsistOf(ContainSubstring("Context was cancelled")))
+		})
+	})
+
+	Describe("failure messages", func() {
+		It("reports pending and rejected futures", func() {
+			failures := InterceptGomegaFailures(func() {
+				Ω(future).Should(gfuture.BeResolvedWith("done"))
+			})
+			Ω(failures).Should(ConsistOf("Expected a resolved future, but got a pending future"))
+
+			future.Reject(errors.New("boom"))
+			failures = InterceptGomegaFailures(func() {
+				Ω(future).Should(gfuture.BeResolvedWith("done"))
+			})
+			Ω(failures).Should(ConsistOf("Expected a resolved future, but got a future rejected with error: boom"))
+		})
+
+		It("reports the matcher's failure", func() {
+			future.Resolve("pending")
+			failures := InterceptGomegaFailures(func() {
+				Ω(future).Should(gfuture.BeResolvedWith("done"))
+			})
+			Ω(failures).Should(ConsistOf("Future resolved with a value that failed to satisfy matcher.\nExpected\n    <string>: pending\nto equal\n    <string>: done"))
+
+			failures = InterceptGomegaFailures(func() {
+				Ω(future).ShouldNot(gfuture.BeResolvedWith("pending"))
+			})
+			Ω(failures).Should(ConsistOf("Future resolved with a value that satisfied matcher, but should not have.\nExpected\n    <string>: pending\nnot to equal\n    <string>: pending"))
+		})
+	})
+})
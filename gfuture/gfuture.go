@@ -0,0 +1,140 @@
+/*
+Package gfuture provides a Future - a value that is settled, once, at some point in the future - along with matchers
+for asserting on how it settles.  Tests of asynchronous APIs can hand a Future to the code under test in place of a
+hand-rolled channel and struct:
+
+	future := gfuture.New[*Response]()
+	client.FetchAsync("/status", func(resp *Response, err error) { future.Settle(resp, err) })
+	Eventually(future).Should(gfuture.BeResolvedWith(HaveField("StatusCode", 200)))
+
+Once a Future has settled it never changes, so Eventually and Consistently stop polling it as soon as it does.
+*/
+package gfuture
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/onsi/gomega/format"
+)
+
+// State is a snapshot of a future.  Err is non-nil if, and only if, the future was rejected.
+type State struct {
+	Settled bool
+	Value   interface{}
+	Err     error
+}
+
+// Inspectable is implemented by futures that the gfuture matchers can assert on.  *Future[T] is Inspectable.
+type Inspectable interface {
+	State() State
+}
+
+/*
+Future holds a value of type T, or an error, that is settled at most once.  Create Futures with New or Go - the zero
+Future is not usable.  Futures are safe for concurrent use.
+*/
+type Future[T any] struct {
+	once  sync.Once
+	done  chan struct{}
+	value T
+	err   error
+}
+
+// New returns a pending Future
+func New[T any]() *Future[T] {
+	return &Future[T]{done: make(chan struct{})}
+}
+
+/*
+Go runs fn in a new goroutine and returns a Future that settles with its result:
+
+	future := gfuture.Go(func() (int, error) { return store.Count(ctx) })
+*/
+func Go[T any](fn func() (T, error)) *Future[T] {
+	f := New[T]()
+	go func() {
+		f.Settle(fn())
+	}()
+	return f
+}
+
+// Resolve settles the future with value.  It returns false, and does nothing, if the future has already settled.
+func (f *Future[T]) Resolve(value T) bool {
+	return f.Settle(value, nil)
+}
+
+// Reject settles the future with err, which must not be nil.  It returns false, and does nothing, if the future has
+// already settled.
+func (f *Future[T]) Reject(err error) bool {
+	if err == nil {
+		panic("gfuture: Reject requires a non-nil error")
+	}
+	var zero T
+	return f.Settle(zero, err)
+}
+
+// Settle rejects the future if err is non-nil, and resolves it with value otherwise.  It returns false, and does
+// nothing, if the future has already settled.
+func (f *Future[T]) Settle(value T, err error) bool {
+	settled := false
+	f.once.Do(func() {
+		f.value, f.err = value, err
+		close(f.done)
+		settled = true
+	})
+	return settled
+}
+
+// Done returns a channel that is closed once the future has settled
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait blocks until the future settles and returns its value and error, or returns ctx's error if ctx is done first
+func (f *Future[T]) Wait(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// State returns a snapshot of the future
+func (f *Future[T]) State() State {
+	select {
+	case <-f.done:
+		return State{Settled: true, Value: f.value, Err: f.err}
+	default:
+		return State{}
+	}
+}
+
+// GomegaString describes the future's state in failure messages
+func (f *Future[T]) GomegaString() string {
+	return describe(f.State())
+}
+
+func describe(state State) string {
+	switch {
+	case !state.Settled:
+		return "pending future"
+	case state.Err != nil:
+		return fmt.Sprintf("future rejected with error: %s", state.Err.Error())
+	default:
+		return "future resolved with " + format.Object(state.Value, 0)
+	}
+}
+
+// toState returns the state of the future the matcher named matcherName was given as actual
+func toState(matcherName string, actual interface{}) (State, error) {
+	future, ok := actual.(Inspectable)
+	if !ok || reflect.ValueOf(future).Kind() == reflect.Ptr && reflect.ValueOf(future).IsNil() {
+		return State{}, fmt.Errorf("%s matcher expects a gfuture.Inspectable, such as a *gfuture.Future.  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+	return future.State(), nil
+}
@@ -0,0 +1,13 @@
+package gfuture_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGfuture(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gfuture Suite")
+}
@@ -0,0 +1,99 @@
+package gfuture_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/gfuture"
+)
+
+var _ = Describe("Future", func() {
+	var future *gfuture.Future[int]
+
+	BeforeEach(func() {
+		future = gfuture.New[int]()
+	})
+
+	It("starts out pending", func() {
+		Ω(future.State()).Should(Equal(gfuture.State{}))
+		Ω(future.Done()).ShouldNot(BeClosed())
+	})
+
+	It("can be resolved, once", func() {
+		Ω(future.Resolve(3)).Should(BeTrue())
+		Ω(future.State()).Should(Equal(gfuture.State{Settled: true, Value: 3}))
+		Ω(future.Done()).Should(BeClosed())
+
+		Ω(future.Resolve(4)).Should(BeFalse())
+		Ω(future.Reject(errors.New("boom"))).Should(BeFalse())
+		Ω(future.State()).Should(Equal(gfuture.State{Settled: true, Value: 3}))
+	})
+
+	It("can be rejected, once", func() {
+		err := errors.New("boom")
+		Ω(future.Reject(err)).Should(BeTrue())
+		Ω(future.State()).Should(Equal(gfuture.State{Settled: true, Value: 0, Err: err}))
+
+		Ω(future.Resolve(4)).Should(BeFalse())
+		Ω(future.State().Err).Should(Equal(err))
+	})
+
+	It("panics when rejected with a nil error", func() {
+		Ω(func() { future.Reject(nil) }).Should(PanicWith("gfuture: Reject requires a non-nil error"))
+		Ω(future.State().Settled).Should(BeFalse())
+	})
+
+	It("can be settled with a value and an error", func() {
+		Ω(future.Settle(3, nil)).Should(BeTrue())
+		Ω(future.State()).Should(Equal(gfuture.State{Settled: true, Value: 3}))
+
+		other := gfuture.New[int]()
+		err := errors.New("boom")
+		Ω(other.Settle(3, err)).Should(BeTrue())
+		Ω(other.State()).Should(Equal(gfuture.State{Settled: true, Value: 3, Err: err}))
+	})
+
+	Describe("Go", func() {
+		It("settles the future with the result of the function", func() {
+			future := gfuture.Go(func() (string, error) {
+				time.Sleep(10 * time.Millisecond)
+				return "done", nil
+			})
+			Ω(future.State().Settled).Should(BeFalse())
+			Eventually(future.Done()).Should(BeClosed())
+			Ω(future.State()).Should(Equal(gfuture.State{Settled: true, Value: "done"}))
+		})
+	})
+
+	Describe("Wait", func() {
+		It("blocks until the future settles", func() {
+			time.AfterFunc(10*time.Millisecond, func() { future.Resolve(3) })
+			value, err := future.Wait(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(value).Should(Equal(3))
+		})
+
+		It("returns the context's error if the context is done first", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			value, err := future.Wait(ctx)
+			Ω(err).Should(MatchError(context.DeadlineExceeded))
+			Ω(value).Should(BeZero())
+		})
+	})
+
+	It("describes itself in failure messages", func() {
+		Ω(format.Object(future, 0)).Should(HaveSuffix(">: pending future"))
+
+		future.Resolve(3)
+		Ω(format.Object(future, 0)).Should(HaveSuffix(">: future resolved with <int>: 3"))
+
+		rejected := gfuture.New[int]()
+		rejected.Reject(errors.New("boom"))
+		Ω(format.Object(rejected, 0)).Should(HaveSuffix(">: future rejected with error: boom"))
+	})
+})
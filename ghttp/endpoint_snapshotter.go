@@ -0,0 +1,100 @@
+package ghttp
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/onsi/gomega/types"
+)
+
+/*
+EndpointSnapshotter is a types.FailureArtifactProvider that, whenever an assertion fails, GETs each of its URLs and
+writes the full response to a file in Dir.  Point it at the debug endpoints of the system under test - /healthz,
+/debug/pprof/goroutine?debug=1, /metrics - to capture its state at the moment of failure:
+
+	DeferCleanup(gomega.RegisterFailureArtifactProvider(ghttp.NewEndpointSnapshotter(GinkgoT().TempDir(), serverURL+"/healthz")))
+
+The paths of the snapshot files are appended to the failure message.
+*/
+type EndpointSnapshotter struct {
+	// Dir is the directory snapshots are written to.  It is created if necessary.
+	Dir string
+	// URLs are the endpoints to snapshot
+	URLs []string
+	// Client makes the requests.  It defaults to a client with a five second timeout.
+	Client *http.Client
+
+	lock     sync.Mutex
+	failures int
+}
+
+/*
+NewEndpointSnapshotter returns an EndpointSnapshotter that writes snapshots of urls to dir
+*/
+func NewEndpointSnapshotter(dir string, urls ...string) *EndpointSnapshotter {
+	return &EndpointSnapshotter{
+		Dir:    dir,
+		URLs:   urls,
+		Client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+var unsafeFileNameCharacters = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+/*
+CollectFailureArtifacts snapshots every URL.  Snapshot files are named after the failure's sequence number and the URL.
+A URL that cannot be fetched does not prevent the others from being snapshotted - the first error is returned.
+*/
+func (s *EndpointSnapshotter) CollectFailureArtifacts(failure types.FailureMetadata) ([]string, error) {
+	s.lock.Lock()
+	s.failures++
+	sequence := s.failures
+	s.lock.Unlock()
+
+	if err := os.MkdirAll(s.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	paths := []string{}
+	var firstErr error
+	for i, url := range s.URLs {
+		path, err := s.snapshot(url, fmt.Sprintf("failure-%03d-%02d-%s.txt", sequence, i, unsafeFileNameCharacters.ReplaceAllString(url, "_")))
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths, firstErr
+}
+
+func (s *EndpointSnapshotter) snapshot(url string, name string) (string, error) {
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to snapshot %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	dump, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to snapshot %s: %w", url, err)
+	}
+
+	path := filepath.Join(s.Dir, name)
+	if err := os.WriteFile(path, dump, 0644); err != nil {
+		return "", fmt.Errorf("failed to snapshot %s: %w", url, err)
+	}
+	return path, nil
+}
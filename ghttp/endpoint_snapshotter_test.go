@@ -0,0 +1,88 @@
+package ghttp_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("EndpointSnapshotter", func() {
+	var (
+		s   *Server
+		dir string
+	)
+
+	BeforeEach(func() {
+		s = NewServer()
+		s.RouteToHandler("GET", "/healthz", RespondWith(http.StatusOK, "all good", http.Header{"X-Health": []string{"green"}}))
+		s.RouteToHandler("GET", "/metrics", RespondWith(http.StatusServiceUnavailable, "requests_total 17"))
+		dir = filepath.Join(GinkgoT().TempDir(), "snapshots")
+	})
+
+	AfterEach(func() {
+		s.Close()
+	})
+
+	It("writes the response from each URL to a file in the directory", func() {
+		snapshotter := NewEndpointSnapshotter(dir, s.URL()+"/healthz", s.URL()+"/metrics")
+		artifacts, err := snapshotter.CollectFailureArtifacts(types.FailureMetadata{Message: "boom"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(artifacts).To(HaveLen(2))
+
+		Expect(filepath.Dir(artifacts[0])).To(Equal(dir))
+		Expect(filepath.Base(artifacts[0])).To(HavePrefix("failure-001-00-http_"))
+		Expect(filepath.Base(artifacts[0])).To(HaveSuffix("_healthz.txt"))
+		healthz, err := os.ReadFile(artifacts[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(healthz)).To(HavePrefix("HTTP/1.1 200 OK"))
+		Expect(string(healthz)).To(ContainSubstring("X-Health: green"))
+		Expect(string(healthz)).To(HaveSuffix("all good"))
+
+		Expect(filepath.Base(artifacts[1])).To(HavePrefix("failure-001-01-http_"))
+		metrics, err := os.ReadFile(artifacts[1])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(metrics)).To(HavePrefix("HTTP/1.1 503 Service Unavailable"))
+		Expect(string(metrics)).To(HaveSuffix("requests_total 17"))
+	})
+
+	It("numbers the snapshots of each failure", func() {
+		snapshotter := NewEndpointSnapshotter(dir, s.URL()+"/healthz")
+		first, err := snapshotter.CollectFailureArtifacts(types.FailureMetadata{})
+		Expect(err).NotTo(HaveOccurred())
+		second, err := snapshotter.CollectFailureArtifacts(types.FailureMetadata{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(filepath.Base(first[0])).To(HavePrefix("failure-001-00-"))
+		Expect(filepath.Base(second[0])).To(HavePrefix("failure-002-00-"))
+		Expect(filepath.Join(dir, "*")).To(WithTransform(filepath.Glob, HaveLen(2)))
+	})
+
+	It("snapshots the URLs it can reach and returns an error for the ones it can't", func() {
+		unreachable := NewServer()
+		unreachableURL := unreachable.URL() + "/healthz"
+		unreachable.Close()
+
+		snapshotter := NewEndpointSnapshotter(dir, unreachableURL, s.URL()+"/healthz")
+		artifacts, err := snapshotter.CollectFailureArtifacts(types.FailureMetadata{})
+		Expect(err).To(MatchError(ContainSubstring("failed to snapshot " + unreachableURL)))
+		Expect(artifacts).To(HaveLen(1))
+		Expect(filepath.Base(artifacts[0])).To(HavePrefix("failure-001-01-"))
+	})
+
+	It("works with the zero value's client", func() {
+		snapshotter := &EndpointSnapshotter{Dir: dir, URLs: []string{s.URL() + "/healthz"}}
+		artifacts, err := snapshotter.CollectFailureArtifacts(types.FailureMetadata{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(artifacts).To(HaveLen(1))
+	})
+
+	It("is a FailureArtifactProvider", func() {
+		var provider types.FailureArtifactProvider = NewEndpointSnapshotter(dir, s.URL()+"/healthz")
+		Expect(provider).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,258 @@
+/*
+Package glog provides matchers over structured log entries, so that specs can assert on what was logged without
+regex-matching rendered output:
+
+	Expect(records).To(glog.HaveLogEntry(slog.LevelError, ContainSubstring("connection refused"), glog.Fields{
+		"host":    "db.internal",
+		"attempt": BeNumerically(">=", 3),
+	}))
+
+The matchers accept captured log/slog Records, the entries zap's observer records - as a *observer.ObservedLogs or a
+slice of observer.LoggedEntrys - a slice of glog.Entrys, or JSON log lines, one object per line, as a *gbytes.Buffer,
+a string or a []byte.  Observed logs and buffers are read every time the matcher runs, so Eventually polls them.
+
+The fields of slog groups and of nested JSON objects are named after the group or object and the field, separated by a
+dot: "request.id".
+
+glog does not depend on slog or zap: records are read through their fields and methods.
+*/
+package glog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/gbytes"
+)
+
+// Entry is the part of a log entry the glog matchers look at.  Levels are lower case, whatever the logger calls them.
+type Entry struct {
+	Level   string
+	Message string
+	Fields  map[string]interface{}
+}
+
+// String describes the entry on a single line
+func (e Entry) String() string {
+	description := fmt.Sprintf("[%s] %q", e.Level, e.Message)
+	if len(e.Fields) == 0 {
+		return description
+	}
+	return description + " " + renderFields(e.Fields)
+}
+
+func renderFields(fields map[string]interface{}) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	rendered := make([]string, len(keys))
+	for i, key := range keys {
+		rendered[i] = key + "=" + renderValue(fields[key])
+	}
+	return "{" + strings.Join(rendered, ", ") + "}"
+}
+
+func renderValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// Fields maps the names of the fields a log entry is expected to have to their values, or to matchers for them
+type Fields map[string]interface{}
+
+// the keys JSON loggers use for the level and message of an entry
+var (
+	jsonLevelKeys   = []string{"level", "severity"}
+	jsonMessageKeys = []string{"msg", "message"}
+)
+
+// toEntries returns the log entries actual holds or records
+func toEntries(matcherName string, actual interface{}) ([]Entry, error) {
+	switch x := actual.(type) {
+	case nil:
+		return nil, fmt.Errorf("%s matcher expects log entries.  Got nil", matcherName)
+	case *gbytes.Buffer:
+		return parseJSONLines(matcherName, x.Contents())
+	case string:
+		return parseJSONLines(matcherName, []byte(x))
+	case []byte:
+		return parseJSONLines(matcherName, x)
+	}
+
+	value := reflect.ValueOf(actual)
+	if all := value.MethodByName("All"); all.IsValid() && all.Type().NumIn() == 0 && all.Type().NumOut() == 1 && all.Type().Out(0).Kind() == reflect.Slice {
+		value = all.Call(nil)[0]
+	}
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		entries := make([]Entry, value.Len())
+		for i := range entries {
+			entry, ok := toEntry(value.Index(i))
+			if !ok {
+				return nil, fmt.Errorf("%s matcher expects log entries, but element %d is not one.  Got:\n%s", matcherName, i, format.Object(value.Index(i).Interface(), 1))
+			}
+			entries[i] = entry
+		}
+		return entries, nil
+	}
+
+	entry, ok := toEntry(value)
+	if !ok {
+		return nil, fmt.Errorf("%s matcher expects log entries.  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+	return []Entry{entry}, nil
+}
+
+func parseJSONLines(matcherName string, contents []byte) ([]Entry, error) {
+	entries := []Entry{}
+	for i, line := range bytes.Split(contents, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		fields := map[string]interface{}{}
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.UseNumber()
+		if err := decoder.Decode(&fields); err != nil {
+			return nil, fmt.Errorf("%s matcher expects one JSON object per line, but line %d is not one:\n%s", matcherName, i+1, format.IndentString(string(line), 1))
+		}
+		entry := Entry{Fields: map[string]interface{}{}}
+		for key, value := range fields {
+			switch {
+			case entry.Level == "" && contains(jsonLevelKeys, key):
+				entry.Level = strings.ToLower(fmt.Sprint(value))
+			case entry.Message == "" && contains(jsonMessageKeys, key):
+				entry.Message = fmt.Sprint(value)
+			default:
+				addJSONField(entry.Fields, key, value)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// addJSONField adds the fields of nested objects with the object's key as a prefix, as the attributes of slog groups are
+func addJSONField(fields map[string]interface{}, key string, value interface{}) {
+	object, isObject := value.(map[string]interface{})
+	if !isObject {
+		if n, isNumber := value.(json.Number); isNumber {
+			value = jsonNumber(n)
+		}
+		fields[key] = value
+		return
+	}
+	for k, v := range object {
+		addJSONField(fields, key+"."+k, v)
+	}
+}
+
+// jsonNumber decodes integers as int64s, as loggers record them, and other numbers as float64s
+func jsonNumber(n json.Number) interface{} {
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	f, _ := n.Float64()
+	return f
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// toEntry reads a glog.Entry, a slog.Record, or a zap observer.LoggedEntry
+func toEntry(value reflect.Value) (Entry, bool) {
+	for (value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr) && !value.IsNil() {
+		if entry, ok := value.Interface().(*Entry); ok {
+			return *entry, true
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return Entry{}, false
+	}
+	if entry, ok := value.Interface().(Entry); ok {
+		return entry, true
+	}
+
+	message, level := value.FieldByName("Message"), value.FieldByName("Level")
+	if !message.IsValid() || message.Kind() != reflect.String || !level.IsValid() {
+		return Entry{}, false
+	}
+	entry := Entry{
+		Level:   strings.ToLower(stringify(level)),
+		Message: message.String(),
+		Fields:  map[string]interface{}{},
+	}
+
+	// zap's observer.LoggedEntry
+	if contextMap := value.MethodByName("ContextMap"); contextMap.IsValid() {
+		if fields, ok := contextMap.Call(nil)[0].Interface().(map[string]interface{}); ok {
+			entry.Fields = fields
+		}
+		return entry, true
+	}
+
+	// slog.Record, whose Attrs method is only defined on the value - make it addressable to find it either way
+	addressable := reflect.New(value.Type())
+	addressable.Elem().Set(value)
+	if attrs := addressable.MethodByName("Attrs"); attrs.IsValid() && attrs.Type().NumIn() == 1 && attrs.Type().In(0).Kind() == reflect.Func {
+		visit := reflect.MakeFunc(attrs.Type().In(0), func(args []reflect.Value) []reflect.Value {
+			addAttr(entry.Fields, "", args[0])
+			return []reflect.Value{reflect.ValueOf(true)}
+		})
+		attrs.Call([]reflect.Value{visit})
+	}
+	return entry, true
+}
+
+// addAttr adds an slog.Attr to fields.  The attributes of groups are added with the group's name as a prefix, as
+// slog's JSON handler nests them.
+func addAttr(fields map[string]interface{}, prefix string, attr reflect.Value) {
+	key, value := attr.FieldByName("Key"), attr.FieldByName("Value")
+	if !key.IsValid() || key.Kind() != reflect.String || !value.IsValid() {
+		return
+	}
+	if resolve := value.MethodByName("Resolve"); resolve.IsValid() && resolve.Type().NumIn() == 0 && resolve.Type().NumOut() == 1 {
+		value = resolve.Call(nil)[0]
+	}
+	var v interface{}
+	if anyMethod := value.MethodByName("Any"); anyMethod.IsValid() && anyMethod.Type().NumIn() == 0 && anyMethod.Type().NumOut() == 1 {
+		v = anyMethod.Call(nil)[0].Interface()
+	} else if value.CanInterface() {
+		v = value.Interface()
+	}
+
+	name := prefix + key.String()
+	group := reflect.ValueOf(v)
+	if group.Kind() == reflect.Slice && group.Type().Elem().Kind() == reflect.Struct && group.Type().Elem() == attr.Type() {
+		for i := 0; i < group.Len(); i++ {
+			groupPrefix := prefix
+			if key.String() != "" {
+				groupPrefix = name + "."
+			}
+			addAttr(fields, groupPrefix, group.Index(i))
+		}
+		return
+	}
+	fields[name] = v
+}
+
+func stringify(value reflect.Value) string {
+	if stringer, ok := value.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprintf("%v", value.Interface())
+}
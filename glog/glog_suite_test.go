@@ -0,0 +1,13 @@
+package glog_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGlog(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Glog Suite")
+}
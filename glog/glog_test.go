@@ -0,0 +1,145 @@
+package glog_test
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/glog"
+)
+
+// The types below mirror the parts of log/slog and zap's observer that glog reads by reflection
+
+type slogLevel int
+
+func (l slogLevel) String() string {
+	return map[slogLevel]string{-4: "DEBUG", 0: "INFO", 4: "WARN", 8: "ERROR"}[l]
+}
+
+const (
+	slogInfo  slogLevel = 0
+	slogError slogLevel = 8
+)
+
+type slogValue struct{ value interface{} }
+
+func (v slogValue) Any() interface{}   { return v.value }
+func (v slogValue) Resolve() slogValue { return v }
+
+type slogAttr struct {
+	Key   string
+	Value slogValue
+}
+
+func attr(key string, value interface{}) slogAttr { return slogAttr{key, slogValue{value}} }
+
+type slogRecord struct {
+	Time    time.Time
+	Message string
+	Level   slogLevel
+	attrs   []slogAttr
+}
+
+func (r slogRecord) Attrs(f func(slogAttr) bool) {
+	for _, a := range r.attrs {
+		if !f(a) {
+			return
+		}
+	}
+}
+
+type zapLevel int8
+
+func (l zapLevel) String() string { return map[zapLevel]string{0: "info", 1: "warn"}[l] }
+
+type zapEntry struct {
+	Level   zapLevel
+	Time    time.Time
+	Message string
+}
+
+type loggedEntry struct {
+	zapEntry
+	context map[string]interface{}
+}
+
+func (e loggedEntry) ContextMap() map[string]interface{} { return e.context }
+
+type observedLogs struct {
+	lock    sync.Mutex
+	entries []loggedEntry
+}
+
+func (o *observedLogs) All() []loggedEntry {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	return append([]loggedEntry{}, o.entries...)
+}
+
+func (o *observedLogs) add(entry loggedEntry) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.entries = append(o.entries, entry)
+}
+
+var records = []slogRecord{
+	{Message: "user created", Level: slogInfo, attrs: []slogAttr{attr("user", []slogAttr{attr("id", int64(42)), attr("name", "alice")}), attr("admin", true)}},
+	{Message: "connection refused", Level: slogError, attrs: []slogAttr{attr("host", "db.internal"), attr("attempt", int64(3))}},
+}
+
+var _ = Describe("Reading log entries", func() {
+	It("should read slog records, flattening groups", func() {
+		Expect(records).Should(glog.HaveLogEntry(slogInfo, "user created", glog.Fields{"user.id": 42, "user.name": "alice", "admin": true}))
+		Expect([]*slogRecord{&records[1]}).Should(glog.HaveLogEntry("error", "connection refused"))
+		Expect(records[1]).Should(glog.HaveLogEntry("ERROR", "connection refused"))
+	})
+
+	It("should read zap's observed logs every time it matches", func() {
+		logs := &observedLogs{}
+		go func() {
+			defer GinkgoRecover()
+			logs.add(loggedEntry{zapEntry{Level: 1, Message: "retrying"}, map[string]interface{}{"attempt": int64(2)}})
+		}()
+		Eventually(logs).Should(glog.HaveLogEntry("warn", "retrying", glog.Fields{"attempt": 2}))
+		Expect(logs.All()).Should(glog.HaveLogEntry("warn", "retrying"))
+	})
+
+	It("should read JSON lines, flattening nested objects", func() {
+		lines := `{"time":"2024-01-01T00:00:00Z","level":"INFO","msg":"user created","user":{"id":42,"name":"alice"}}
+
+{"ts":1704067200.5,"level":"error","msg":"connection refused","latency":0.25}
+`
+		Expect(lines).Should(glog.HaveLogEntry("info", "user created", glog.Fields{"user.id": int64(42), "user.name": "alice"}))
+		Expect([]byte(lines)).Should(glog.HaveLogEntry("error", "connection refused", glog.Fields{"latency": 0.25}))
+		Expect(`{"severity":"WARNING","message":"disk almost full"}`).Should(glog.HaveLogEntry("warning", "disk almost full"))
+
+		buffer := gbytes.NewBuffer()
+		go func() {
+			defer GinkgoRecover()
+			buffer.Write([]byte(lines))
+		}()
+		Eventually(buffer).Should(glog.HaveLogEntry("error", "connection refused"))
+	})
+
+	It("should match glog.Entrys", func() {
+		Expect([]glog.Entry{{Level: "info", Message: "started"}}).Should(glog.HaveLogEntry("info", "started"))
+	})
+
+	It("should describe entries on a single line", func() {
+		Expect(glog.Entry{Level: "info", Message: "user created", Fields: map[string]interface{}{"name": "alice", "id": 42}}.String()).Should(Equal(`[info] "user created" {id=42, name="alice"}`))
+		Expect(glog.Entry{Level: "info", Message: "started"}.String()).Should(Equal(`[info] "started"`))
+	})
+
+	It("should error when actual holds no log entries", func() {
+		_, err := glog.HaveLogEntry("info", "started").Match(nil)
+		Expect(err).Should(MatchError("HaveLogEntry matcher expects log entries.  Got nil"))
+		_, err = glog.HaveLogEntry("info", "started").Match(17)
+		Expect(err).Should(MatchError(ContainSubstring("HaveLogEntry matcher expects log entries.  Got:")))
+		_, err = glog.HaveLogEntry("info", "started").Match([]interface{}{records[0], 17})
+		Expect(err).Should(MatchError(ContainSubstring("HaveLogEntry matcher expects log entries, but element 1 is not one.  Got:")))
+		_, err = glog.HaveLogEntry("info", "started").Match("{}\nstarted\n")
+		Expect(err).Should(MatchError("HaveLogEntry matcher expects one JSON object per line, but line 2 is not one:\n    started"))
+	})
+})
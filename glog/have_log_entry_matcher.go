@@ -0,0 +1,225 @@
+package glog
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+HaveLogEntry succeeds if actual has a log entry at level, with a message that satisfies message, and with fields.
+
+level can be a level - an slog.Level or a zapcore.Level - its name, or a matcher, which is passed the name in lower case.
+message can be a string or a matcher.  Pass nil for either to accept any level or message.  An entry may have fields other
+than those in fields, whose values can be values or matchers:
+
+	Expect(records).To(glog.HaveLogEntry(slog.LevelInfo, "user created", glog.Fields{"user.id": 42}))
+	Expect(observedLogs).To(glog.HaveLogEntry("warn", HavePrefix("retrying"), glog.Fields{"attempt": BeNumerically(">", 1)}))
+	Eventually(buffer).Should(glog.HaveLogEntry(nil, "server started"))
+
+Numbers are compared with BeNumerically("==", ...), as loggers record them with different types.
+*/
+func HaveLogEntry(level interface{}, message interface{}, fields ...Fields) types.GomegaMatcher {
+	merged := Fields{}
+	for _, f := range fields {
+		for key, value := range f {
+			merged[key] = value
+		}
+	}
+	return &HaveLogEntryMatcher{
+		Level:   level,
+		Message: message,
+		Fields:  merged,
+	}
+}
+
+type HaveLogEntryMatcher struct {
+	Level   interface{}
+	Message interface{}
+	Fields  Fields
+
+	// state
+	entries []Entry
+	reasons []string
+	matched *Entry
+}
+
+func (matcher *HaveLogEntryMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.entries, err = toEntries("HaveLogEntry", actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.reasons, matcher.matched = make([]string, len(matcher.entries)), nil
+	for i, entry := range matcher.entries {
+		matcher.reasons[i], err = matcher.mismatch(entry)
+		if err != nil {
+			return false, err
+		}
+		if matcher.reasons[i] == "" {
+			matcher.matched = &matcher.entries[i]
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// mismatch returns why entry is not the entry the matcher is looking for, or "" if it is.  Entries at the wrong level or
+// with the wrong message are unrelated to the expected one, so their reasons are left out of failure messages.
+func (matcher *HaveLogEntryMatcher) mismatch(entry Entry) (string, error) {
+	if matcher.Level != nil {
+		levelMatcher, isMatcher := matcher.Level.(types.GomegaMatcher)
+		if !isMatcher {
+			levelMatcher = &matchers.EqualMatcher{Expected: levelName(matcher.Level)}
+		}
+		success, err := levelMatcher.Match(entry.Level)
+		if err != nil {
+			return "", fmt.Errorf("HaveLogEntry matcher could not match the level of entry %q:\n%s", entry.Message, format.IndentString(err.Error(), 1))
+		}
+		if !success {
+			return unrelated, nil
+		}
+	}
+
+	if matcher.Message != nil {
+		messageMatcher, isMatcher := matcher.Message.(types.GomegaMatcher)
+		if !isMatcher {
+			messageMatcher = &matchers.EqualMatcher{Expected: matcher.Message}
+		}
+		success, err := messageMatcher.Match(entry.Message)
+		if err != nil {
+			return "", fmt.Errorf("HaveLogEntry matcher could not match the message of entry %q:\n%s", entry.Message, format.IndentString(err.Error(), 1))
+		}
+		if !success {
+			return unrelated, nil
+		}
+	}
+
+	keys := make([]string, 0, len(matcher.Fields))
+	for key := range matcher.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		actualValue, ok := entry.Fields[key]
+		if !ok {
+			return fmt.Sprintf("it has no %s field", key), nil
+		}
+		expected := matcher.Fields[key]
+		valueMatcher, isMatcher := expected.(types.GomegaMatcher)
+		if !isMatcher {
+			valueMatcher = fieldValueMatcher(expected, actualValue)
+		}
+		success, err := valueMatcher.Match(actualValue)
+		if err != nil {
+			return "", fmt.Errorf("HaveLogEntry matcher could not match the %s field of entry %q:\n%s", key, entry.Message, format.IndentString(err.Error(), 1))
+		}
+		if !success {
+			if isMatcher {
+				return fmt.Sprintf("its %s field, %s, does not satisfy the matcher", key, renderValue(actualValue)), nil
+			}
+			return fmt.Sprintf("its %s field is %s, not %s", key, renderValue(actualValue), renderValue(expected)), nil
+		}
+	}
+	return "", nil
+}
+
+const unrelated = "its level or message is different"
+
+// fieldValueMatcher compares numbers numerically, as loggers record them with different types, and other values with Equal
+func fieldValueMatcher(expected interface{}, actual interface{}) types.GomegaMatcher {
+	if isNumber(expected) && isNumber(actual) {
+		return &matchers.BeNumericallyMatcher{Comparator: "==", CompareTo: []interface{}{expected}}
+	}
+	return &matchers.EqualMatcher{Expected: expected}
+}
+
+func isNumber(value interface{}) bool {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// levelName returns the lower case name of a level, whether it is a name or a level with a String method
+func levelName(level interface{}) string {
+	if stringer, ok := level.(fmt.Stringer); ok {
+		return strings.ToLower(stringer.String())
+	}
+	return strings.ToLower(fmt.Sprint(level))
+}
+
+func (matcher *HaveLogEntryMatcher) FailureMessage(actual interface{}) (message string) {
+	if len(matcher.entries) == 0 {
+		return fmt.Sprintf("Expected a log entry %s, but nothing was logged", matcher.description())
+	}
+	lines := make([]string, len(matcher.entries))
+	for i, entry := range matcher.entries {
+		lines[i] = format.Indent + entry.String()
+		if matcher.reasons[i] != unrelated {
+			lines[i] += ": " + matcher.reasons[i]
+		}
+	}
+	return fmt.Sprintf("Expected a log entry %s, but none of the %d logged entries match:\n%s", matcher.description(), len(matcher.entries), strings.Join(lines, "\n"))
+}
+
+func (matcher *HaveLogEntryMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected no log entry %s, but found:\n%s%s", matcher.description(), format.Indent, matcher.matched)
+}
+
+// MatchMayChangeInTheFuture lets Eventually and Consistently keep reading buffers and observed logs, which log entries
+// are added to over time
+func (matcher *HaveLogEntryMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	if _, isBuffer := actual.(*gbytes.Buffer); isBuffer {
+		return true
+	}
+	return actual != nil && reflect.ValueOf(actual).MethodByName("All").IsValid()
+}
+
+func (matcher *HaveLogEntryMatcher) description() string {
+	parts := []string{}
+	switch level := matcher.Level.(type) {
+	case nil:
+	case types.GomegaMatcher:
+		parts = append(parts, "at a level that satisfies the matcher")
+	default:
+		parts = append(parts, "at level "+levelName(level))
+	}
+	switch message := matcher.Message.(type) {
+	case nil:
+	case types.GomegaMatcher:
+		parts = append(parts, "with a message that satisfies the matcher")
+	default:
+		parts = append(parts, fmt.Sprintf("with message %s", renderValue(message)))
+	}
+	if len(matcher.Fields) > 0 {
+		rendered := map[string]interface{}{}
+		for key, value := range matcher.Fields {
+			if _, isMatcher := value.(types.GomegaMatcher); isMatcher {
+				value = rawValue(fmt.Sprintf("<%T>", value))
+			}
+			rendered[key] = value
+		}
+		parts = append(parts, "with fields "+renderFields(rendered))
+	}
+	if len(parts) == 0 {
+		return "of any kind"
+	}
+	return strings.Join(parts, " ")
+}
+
+// rawValue is rendered as is, rather than quoted like a string field
+type rawValue string
+
+func (r rawValue) String() string {
+	return string(r)
+}
@@ -0,0 +1,74 @@
+package glog_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/glog"
+)
+
+var _ = Describe("HaveLogEntry", func() {
+	It("should find entries by level", func() {
+		Expect(records).Should(glog.HaveLogEntry(slogError, nil))
+		Expect(records).Should(glog.HaveLogEntry("Error", nil))
+		Expect(records).Should(glog.HaveLogEntry(BeElementOf("warn", "error"), nil))
+		Expect(records).ShouldNot(glog.HaveLogEntry("warn", nil))
+	})
+
+	It("should find entries by message", func() {
+		Expect(records).Should(glog.HaveLogEntry(nil, "user created"))
+		Expect(records).Should(glog.HaveLogEntry(nil, ContainSubstring("refused")))
+		Expect(records).ShouldNot(glog.HaveLogEntry(nil, "user deleted"))
+		Expect(records).ShouldNot(glog.HaveLogEntry(slogError, "user created"))
+		Expect([]glog.Entry{}).ShouldNot(glog.HaveLogEntry(nil, nil))
+	})
+
+	It("should narrow entries down by a subset of their fields", func() {
+		Expect(records).Should(glog.HaveLogEntry(slogError, "connection refused", glog.Fields{"host": "db.internal"}))
+		Expect(records).Should(glog.HaveLogEntry(slogError, "connection refused", glog.Fields{"attempt": BeNumerically(">=", 3)}, glog.Fields{"host": HaveSuffix(".internal")}))
+		Expect(records).Should(glog.HaveLogEntry(slogError, "connection refused", glog.Fields{"attempt": 3.0}))
+		Expect(records).ShouldNot(glog.HaveLogEntry(slogError, "connection refused", glog.Fields{"attempt": 4}))
+		Expect(records).ShouldNot(glog.HaveLogEntry(slogError, "connection refused", glog.Fields{"attempt": "3"}))
+		Expect(records).ShouldNot(glog.HaveLogEntry(slogError, "connection refused", glog.Fields{"port": 5432}))
+	})
+
+	It("should error when a matcher errors", func() {
+		_, err := glog.HaveLogEntry(BeNumerically(">", 1), nil).Match(records)
+		Expect(err).Should(MatchError(ContainSubstring(`HaveLogEntry matcher could not match the level of entry "user created":`)))
+		_, err = glog.HaveLogEntry(nil, BeNumerically(">", 1)).Match(records)
+		Expect(err).Should(MatchError(ContainSubstring(`HaveLogEntry matcher could not match the message of entry "user created":`)))
+		_, err = glog.HaveLogEntry(nil, nil, glog.Fields{"admin": BeNumerically(">", 1)}).Match(records)
+		Expect(err).Should(MatchError(ContainSubstring(`HaveLogEntry matcher could not match the admin field of entry "user created":`)))
+	})
+
+	Describe("failure messages", func() {
+		It("should list the logged entries, and why those at the level with the message did not match", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(records).Should(glog.HaveLogEntry(slogError, "connection refused", glog.Fields{"attempt": 5, "host": HavePrefix("db")}))
+				Expect(records).Should(glog.HaveLogEntry(slogError, "connection refused", glog.Fields{"host": HavePrefix("cache")}))
+				Expect(records).Should(glog.HaveLogEntry(BeElementOf("error"), ContainSubstring("refused"), glog.Fields{"port": 5432}))
+			})
+			Expect(failures).Should(HaveLen(3))
+			Expect(failures[0]).Should(Equal(`Expected a log entry at level error with message "connection refused" with fields {attempt=5, host=<*matchers.HavePrefixMatcher>}, but none of the 2 logged entries match:
+    [info] "user created" {admin=true, user.id=42, user.name="alice"}
+    [error] "connection refused" {attempt=3, host="db.internal"}: its attempt field is 3, not 5`))
+			Expect(failures[1]).Should(HaveSuffix(`: its host field, "db.internal", does not satisfy the matcher`))
+			Expect(failures[2]).Should(HavePrefix("Expected a log entry at a level that satisfies the matcher with a message that satisfies the matcher with fields {port=5432}, but"))
+			Expect(failures[2]).Should(HaveSuffix(": it has no port field"))
+		})
+
+		It("should say so when nothing was logged", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect("").Should(glog.HaveLogEntry(nil, nil))
+			})
+			Expect(failures).Should(ConsistOf("Expected a log entry of any kind, but nothing was logged"))
+		})
+
+		It("should show the entry that matched when negated", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(records).ShouldNot(glog.HaveLogEntry("info", nil, glog.Fields{"admin": true}))
+			})
+			Expect(failures).Should(ConsistOf(`Expected no log entry at level info with fields {admin=true}, but found:
+    [info] "user created" {admin=true, user.id=42, user.name="alice"}`))
+		})
+	})
+})
@@ -0,0 +1,185 @@
+package gmeasure
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+SaveBaseline writes the passed-in experiment - including every data point of every Measurement - to the file at path as JSON.
+Commit the file alongside your code, then LoadBaseline it in later runs and compare new Measurements against it with BeStatisticallySimilarTo.
+*/
+func SaveBaseline(path string, experiment *Experiment) error {
+	experiment.lock.Lock()
+	data, err := json.MarshalIndent(experiment, "", "  ")
+	experiment.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0666)
+}
+
+/*
+LoadBaseline reads an experiment saved with SaveBaseline from the file at path.
+*/
+func LoadBaseline(path string) (*Experiment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	out := NewExperiment("")
+	err = json.Unmarshal(data, out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load baseline from %s: %w", path, err)
+	}
+	return out, nil
+}
+
+/*
+BeStatisticallySimilarTo succeeds if actual - a Measurement - is not significantly different from the baseline Measurement.
+It runs a two-sided Mann-Whitney U test on the data points of the two Measurements - those their Stats cover, once any
+SamplingConfig.TrimPercent has been applied - and fails if the p-value is less than
+significance - so a significance of 0.01 fails when a difference at least as large as the one observed would happen less than
+1% of the time by chance alone.  Mann-Whitney U compares rankings rather than means, so it is robust to the outliers and skewed
+distributions that are common in benchmarks.
+
+This is typically paired with SaveBaseline and LoadBaseline to fail CI on performance regressions:
+
+	baseline, err := gmeasure.LoadBaseline("testdata/baseline.json")
+	Expect(err).NotTo(HaveOccurred())
+	experiment.SampleDuration("runtime", func(_ int) {
+		// do stuff
+	}, gmeasure.SamplingConfig{N: 100})
+	Expect(experiment.Get("runtime")).To(gmeasure.BeStatisticallySimilarTo(baseline.Get("runtime"), 0.01))
+
+The p-value is computed with a normal approximation (corrected for ties) that is reliable once each Measurement has ten or so data points.
+Both Measurements must have data points and the same Type.  significance must be between 0 and 1.
+*/
+func BeStatisticallySimilarTo(baseline Measurement, significance float64) types.GomegaMatcher {
+	return &BeStatisticallySimilarToMatcher{
+		Baseline:     baseline,
+		Significance: significance,
+	}
+}
+
+type BeStatisticallySimilarToMatcher struct {
+	Baseline     Measurement
+	Significance float64
+
+	// state
+	measurement Measurement
+	pValue      float64
+	larger      bool
+}
+
+func (matcher *BeStatisticallySimilarToMatcher) Match(actual interface{}) (success bool, err error) {
+	measurement, ok := actual.(Measurement)
+	if !ok {
+		return false, fmt.Errorf("BeStatisticallySimilarTo matcher expects a gmeasure.Measurement.  Got:\n%s", format.Object(actual, 1))
+	}
+	if matcher.Significance <= 0 || matcher.Significance >= 1 {
+		return false, fmt.Errorf("BeStatisticallySimilarTo matcher expects a significance between 0 and 1, got %v", matcher.Significance)
+	}
+	a, b := trimmedFloats(measurement), trimmedFloats(matcher.Baseline)
+	if len(a) == 0 {
+		return false, fmt.Errorf("BeStatisticallySimilarTo matcher expects a measurement with data points, but %s has none", describeMeasurement(measurement))
+	}
+	if len(b) == 0 {
+		return false, fmt.Errorf("BeStatisticallySimilarTo matcher expects a baseline with data points, but %s has none", describeMeasurement(matcher.Baseline))
+	}
+	if measurement.Type != matcher.Baseline.Type {
+		return false, fmt.Errorf("BeStatisticallySimilarTo matcher cannot compare %s (a %s measurement) with the baseline %s (a %s measurement)", describeMeasurement(measurement), measurement.Type, describeMeasurement(matcher.Baseline), matcher.Baseline.Type)
+	}
+
+	matcher.measurement = measurement
+	var u float64
+	u, matcher.pValue = mannWhitneyU(a, b)
+	matcher.larger = u > float64(len(a)*len(b))/2
+	return matcher.pValue >= matcher.Significance, nil
+}
+
+func (matcher *BeStatisticallySimilarToMatcher) FailureMessage(actual interface{}) (message string) {
+	direction := "smaller"
+	if matcher.larger {
+		direction = "larger"
+	}
+	return fmt.Sprintf("Expected %s to be statistically similar to the baseline %s, but it is significantly %s (p = %.4g < %v)\n%s",
+		describeMeasurement(matcher.measurement), describeMeasurement(matcher.Baseline), direction, matcher.pValue, matcher.Significance, matcher.summary())
+}
+
+func (matcher *BeStatisticallySimilarToMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected %s not to be statistically similar to the baseline %s, but the difference is not significant (p = %.4g >= %v)\n%s",
+		describeMeasurement(matcher.measurement), describeMeasurement(matcher.Baseline), matcher.pValue, matcher.Significance, matcher.summary())
+}
+
+func (matcher *BeStatisticallySimilarToMatcher) summary() string {
+	actual, baseline := matcher.measurement.Stats(), matcher.Baseline.Stats()
+	return fmt.Sprintf("%sN: %d (baseline: %d)\n%sMedian: %s (baseline: %s)\n%sMean: %s (baseline: %s)",
+		format.Indent, actual.N, baseline.N,
+		format.Indent, actual.StringFor(StatMedian), baseline.StringFor(StatMedian),
+		format.Indent, actual.StringFor(StatMean), baseline.StringFor(StatMean))
+}
+
+// trimmedFloats returns the data points of m that its Stats cover, in ascending order
+func trimmedFloats(m Measurement) []float64 {
+	trimmed, _ := m.trimmed()
+	return trimmed.sortedFloats()
+}
+
+func describeMeasurement(m Measurement) string {
+	if m.ExperimentName == "" {
+		return fmt.Sprintf("measurement %q", m.Name)
+	}
+	return fmt.Sprintf("measurement %q of experiment %q", m.Name, m.ExperimentName)
+}
+
+// mannWhitneyU returns the U statistic of a (the number of pairs in which the value from a is larger than the value from b,
+// with ties counting as half) along with the two-sided p-value of the Mann-Whitney U test.  The p-value uses the normal
+// approximation with a tie correction and a continuity correction.
+func mannWhitneyU(a, b []float64) (u float64, p float64) {
+	type point struct {
+		value float64
+		fromA bool
+	}
+	points := make([]point, 0, len(a)+len(b))
+	for _, v := range a {
+		points = append(points, point{v, true})
+	}
+	for _, v := range b {
+		points = append(points, point{v, false})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].value < points[j].value })
+
+	n1, n2, n := float64(len(a)), float64(len(b)), float64(len(points))
+	rankSumA, tieCorrection := 0.0, 0.0
+	for start := 0; start < len(points); {
+		end := start
+		for end < len(points) && points[end].value == points[start].value {
+			end++
+		}
+		averageRank := float64(start+end+1) / 2
+		for _, pt := range points[start:end] {
+			if pt.fromA {
+				rankSumA += averageRank
+			}
+		}
+		ties := float64(end - start)
+		tieCorrection += ties*ties*ties - ties
+		start = end
+	}
+
+	u = rankSumA - n1*(n1+1)/2
+	mean := n1 * n2 / 2
+	variance := n1 * n2 / 12 * ((n + 1) - tieCorrection/(n*(n-1)))
+	if variance <= 0 {
+		return u, 1
+	}
+	z := math.Max(math.Abs(u-mean)-0.5, 0) / math.Sqrt(variance)
+	return u, math.Erfc(z / math.Sqrt2)
+}
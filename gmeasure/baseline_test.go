@@ -0,0 +1,140 @@
+package gmeasure_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gmeasure"
+)
+
+var _ = Describe("Baselines", func() {
+	var baseline *gmeasure.Experiment
+
+	BeforeEach(func() {
+		baseline = gmeasure.NewExperiment("Baseline")
+		for i := 1; i <= 10; i++ {
+			baseline.RecordValue("length", float64(i), gmeasure.Units("inches"))
+			baseline.RecordDuration("runtime", time.Duration(i)*time.Millisecond)
+		}
+	})
+
+	Describe("saving and loading", func() {
+		var path string
+		BeforeEach(func() {
+			dir, err := os.MkdirTemp("", "gmeasure-baseline")
+			Ω(err).ShouldNot(HaveOccurred())
+			DeferCleanup(os.RemoveAll, dir)
+			path = filepath.Join(dir, "baseline.json")
+		})
+
+		It("round-trips every data point through a JSON file", func() {
+			Ω(gmeasure.SaveBaseline(path, baseline)).Should(Succeed())
+			loaded, err := gmeasure.LoadBaseline(path)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(loaded.Name).Should(Equal("Baseline"))
+			Ω(loaded.Get("length")).Should(Equal(baseline.Get("length")))
+			Ω(loaded.Get("runtime")).Should(Equal(baseline.Get("runtime")))
+
+			loaded.RecordValue("length", 11)
+			Ω(loaded.Get("length").Values).Should(HaveLen(11))
+		})
+
+		It("errors when the file is missing or is not a baseline", func() {
+			_, err := gmeasure.LoadBaseline(path)
+			Ω(err).Should(HaveOccurred())
+
+			Ω(os.WriteFile(path, []byte("not json"), 0666)).Should(Succeed())
+			_, err = gmeasure.LoadBaseline(path)
+			Ω(err).Should(MatchError(ContainSubstring("failed to load baseline from " + path)))
+		})
+	})
+
+	Describe("BeStatisticallySimilarTo", func() {
+		var e *gmeasure.Experiment
+		BeforeEach(func() {
+			e = gmeasure.NewExperiment("Candidate")
+		})
+
+		It("succeeds when the measurements are not significantly different", func() {
+			for i := 10; i >= 1; i-- {
+				e.RecordValue("length", float64(i)+0.5)
+			}
+			Ω(e.Get("length")).Should(gmeasure.BeStatisticallySimilarTo(baseline.Get("length"), 0.05))
+			Ω(baseline.Get("length")).Should(gmeasure.BeStatisticallySimilarTo(baseline.Get("length"), 0.05))
+		})
+
+		It("fails when the measurements are significantly different", func() {
+			for i := 11; i <= 20; i++ {
+				e.RecordDuration("runtime", time.Duration(i)*time.Millisecond)
+			}
+			Ω(e.Get("runtime")).ShouldNot(gmeasure.BeStatisticallySimilarTo(baseline.Get("runtime"), 0.01))
+			Ω(baseline.Get("runtime")).ShouldNot(gmeasure.BeStatisticallySimilarTo(e.Get("runtime"), 0.01))
+		})
+
+		It("handles ties", func() {
+			for i := 0; i < 10; i++ {
+				e.RecordValue("constant", 3)
+				e.RecordValue("other constant", 3)
+			}
+			Ω(e.Get("constant")).Should(gmeasure.BeStatisticallySimilarTo(e.Get("other constant"), 0.05))
+		})
+
+		It("compares the data points that are left once TrimPercent has been applied", func() {
+			// only the middle four of the twenty data points differ
+			sample := func(name string, middle float64, trimPercent float64) {
+				e.SampleValue(name, func(idx int) float64 {
+					switch {
+					case idx < 8:
+						return 0
+					case idx < 12:
+						return middle
+					default:
+						return 100
+					}
+				}, gmeasure.SamplingConfig{N: 20, TrimPercent: trimPercent})
+			}
+			sample("untrimmed", 2, 0)
+			sample("untrimmed baseline", 1, 0)
+			Ω(e.Get("untrimmed")).Should(gmeasure.BeStatisticallySimilarTo(e.Get("untrimmed baseline"), 0.05))
+
+			sample("trimmed", 2, 40)
+			sample("trimmed baseline", 1, 40)
+			Ω(e.GetStats("trimmed").N).Should(Equal(4))
+			Ω(e.Get("trimmed")).ShouldNot(gmeasure.BeStatisticallySimilarTo(e.Get("trimmed baseline"), 0.05))
+		})
+
+		It("errors when the measurements can't be compared", func() {
+			_, err := gmeasure.BeStatisticallySimilarTo(baseline.Get("length"), 0.05).Match(baseline.GetStats("length"))
+			Ω(err).Should(MatchError(ContainSubstring("BeStatisticallySimilarTo matcher expects a gmeasure.Measurement.  Got:")))
+
+			_, err = gmeasure.BeStatisticallySimilarTo(baseline.Get("length"), 1).Match(baseline.Get("length"))
+			Ω(err).Should(MatchError("BeStatisticallySimilarTo matcher expects a significance between 0 and 1, got 1"))
+
+			_, err = gmeasure.BeStatisticallySimilarTo(baseline.Get("length"), 0.05).Match(e.Get("length"))
+			Ω(err).Should(MatchError(`BeStatisticallySimilarTo matcher expects a measurement with data points, but measurement "" has none`))
+
+			_, err = gmeasure.BeStatisticallySimilarTo(baseline.Get("missing"), 0.05).Match(baseline.Get("length"))
+			Ω(err).Should(MatchError(`BeStatisticallySimilarTo matcher expects a baseline with data points, but measurement "" has none`))
+
+			_, err = gmeasure.BeStatisticallySimilarTo(baseline.Get("runtime"), 0.05).Match(baseline.Get("length"))
+			Ω(err).Should(MatchError(`BeStatisticallySimilarTo matcher cannot compare measurement "length" of experiment "Baseline" (a Value measurement) with the baseline measurement "runtime" of experiment "Baseline" (a Duration measurement)`))
+		})
+
+		It("reports the p-value, the direction of the difference and a summary of both measurements", func() {
+			for i := 11; i <= 20; i++ {
+				e.RecordDuration("runtime", time.Duration(i)*time.Millisecond)
+			}
+			failures := InterceptGomegaFailures(func() {
+				Ω(e.Get("runtime")).Should(gmeasure.BeStatisticallySimilarTo(baseline.Get("runtime"), 0.01))
+				Ω(baseline.Get("runtime")).ShouldNot(gmeasure.BeStatisticallySimilarTo(baseline.Get("runtime"), 0.01))
+			})
+			Ω(failures).Should(Equal([]string{
+				"Expected measurement \"runtime\" of experiment \"Candidate\" to be statistically similar to the baseline measurement \"runtime\" of experiment \"Baseline\", but it is significantly larger (p = 0.0001827 < 0.01)\n    N: 10 (baseline: 10)\n    Median: 15.5ms (baseline: 5.5ms)\n    Mean: 15.5ms (baseline: 5.5ms)",
+				"Expected measurement \"runtime\" of experiment \"Baseline\" not to be statistically similar to the baseline measurement \"runtime\" of experiment \"Baseline\", but the difference is not significant (p = 1 >= 0.01)\n    N: 10 (baseline: 10)\n    Median: 5.5ms (baseline: 5.5ms)\n    Mean: 5.5ms (baseline: 5.5ms)",
+			}))
+		})
+	})
+})
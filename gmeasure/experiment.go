@@ -15,6 +15,7 @@ Measurements can be decorated with additional information.  This is supported by
 - Units("any string") - to attach units to a Value Measurement (Duration Measurements always have units of "duration")
 - Style("any Ginkgo color style string") - to attach styling to a Measurement.  This styling is used when rendering console information about the measurement in reports.  Color style strings are documented at TODO.
 - Precision(integer or time.Duration) - to attach precision to a Measurement.  This controls how many decimal places to show for Value Measurements and how to round Duration Measurements when rendering them to screen.
+- RecordMemStats - to also record how many heap allocations, heap bytes, GC cycles and GC pause time the measured code was responsible for, as additional Measurements.
 
 In addition, individual data points in a Measurement can be annotated with an Annotation("any string").  The annotation is associated with the individual data point and is intended to convey additional context about the data point.
 
@@ -52,6 +53,8 @@ SamplingConfig configures the Sample family of experiment methods.
 These methods invoke passed-in functions repeatedly to sample and record a given measurement.
 SamplingConfig is used to control the maximum number of samples or time spent sampling (or both).  When both are specified sampling ends as soon as one of the conditions is met.
 SamplingConfig can also ensure a minimum interval between samples and can enable concurrent sampling.
+SamplingConfig can also warm up the sampled code before sampling begins and can exclude outliers from the Stats of the sampled Measurement.
+Finally, SamplingConfig can record a separate series of data points for each concurrent worker and the aggregate throughput of all workers, for load-test-style experiments.
 */
 type SamplingConfig struct {
 	// N - the maximum number of samples to record
@@ -62,6 +65,14 @@ type SamplingConfig struct {
 	MinSamplingInterval time.Duration
 	// NumParallel - the number of parallel workers to spin up to record samples.  It is an error to specify both MinSamplingInterval and NumParallel.
 	NumParallel int
+	// Warmup - the number of times to invoke the callback before sampling begins.  Warmup invocations are passed negative indices, are not recorded by the SampleX methods, and do not count towards N or Duration.
+	Warmup int
+	// TrimPercent - the percentage of the smallest and of the largest data points to exclude when computing Stats for Measurements recorded by the SampleX methods.  Must be at least 0 and less than 50.
+	TrimPercent float64
+	// PerWorkerSeries - when set, the SampleX methods also record each data point on a Measurement named "NAME: worker W", where W is the zero-based index of the worker that sampled it.
+	PerWorkerSeries bool
+	// RecordThroughput - when set, the SampleX methods record the number of samples taken per second, across all workers, on a Value Measurement named "NAME: throughput" with Units "ops/sec".
+	RecordThroughput bool
 }
 
 // The Units decorator allows you to specify units (an arbitrary string) when recording values.  It is ignored when recording durations.
@@ -141,6 +152,8 @@ type extractedDecorations struct {
 	units           Units
 	precisionBundle PrecisionBundle
 	style           Style
+	trimPercent     float64
+	memStats        bool
 }
 
 func extractDecorations(args []interface{}) extractedDecorations {
@@ -157,6 +170,8 @@ func extractDecorations(args []interface{}) extractedDecorations {
 			out.precisionBundle = arg.(PrecisionBundle)
 		case reflect.TypeOf(out.style):
 			out.style = arg.(Style)
+		case reflect.TypeOf(RecordMemStats):
+			out.memStats = true
 		default:
 			panic(fmt.Sprintf("unrecognized argument %#v", arg))
 		}
@@ -274,13 +289,17 @@ func (e *Experiment) RecordDuration(name string, duration time.Duration, args ..
 /*
 MeasureDuration runs the passed-in callback and times how long it takes to complete.  The resulting duration is recorded on a Duration Measurement with the passed-in name.  If the Measurement does not exist it is created.
 
-MeasureDuration supports the Style(), Precision(), and Annotation() decorations.
+MeasureDuration supports the Style(), Precision(), Annotation(), and RecordMemStats decorations.
 */
 func (e *Experiment) MeasureDuration(name string, callback func(), args ...interface{}) time.Duration {
+	decorations := extractDecorations(args)
+	stopMemStats := measureMemStats(decorations.memStats)
 	t := time.Now()
 	callback()
 	duration := time.Since(t)
-	e.RecordDuration(name, duration, args...)
+	memStats := stopMemStats()
+	e.recordDuration(name, duration, decorations)
+	e.recordMemStats(name, memStats, decorations)
 	return duration
 }
 
@@ -290,16 +309,23 @@ The resulting durations are recorded on a Duration Measurement with the passed-i
 
 The callback is given a zero-based index that increments by one between samples.  The Sampling is configured via the passed-in SamplingConfig
 
-SampleDuration supports the Style(), Precision(), and Annotation() decorations.  When passed an Annotation() the same annotation is applied to all sample measurements.
+SampleDuration supports the Style(), Precision(), Annotation(), and RecordMemStats decorations.  When passed an Annotation() the same annotation is applied to all sample measurements.
 */
 func (e *Experiment) SampleDuration(name string, callback func(idx int), samplingConfig SamplingConfig, args ...interface{}) {
 	decorations := extractDecorations(args)
-	e.Sample(func(idx int) {
+	decorations.trimPercent = samplingConfig.TrimPercent
+	n, elapsed := e.sample(func(idx int, worker int) {
+		stopMemStats := measureMemStats(decorations.memStats)
 		t := time.Now()
 		callback(idx)
 		duration := time.Since(t)
-		e.recordDuration(name, duration, decorations)
+		memStats := stopMemStats()
+		if idx >= 0 {
+			e.recordSampledDuration(name, worker, duration, decorations, samplingConfig)
+			e.recordMemStats(name, memStats, decorations)
+		}
 	}, samplingConfig)
+	e.recordThroughput(name, n, elapsed, decorations, samplingConfig)
 }
 
 /*
@@ -310,16 +336,33 @@ The callback is given a zero-based index that increments by one between samples.
 
 The Sampling is configured via the passed-in SamplingConfig
 
-SampleAnnotatedDuration supports the Style() and Precision() decorations.
+SampleAnnotatedDuration supports the Style(), Precision(), and RecordMemStats decorations.
 */
 func (e *Experiment) SampleAnnotatedDuration(name string, callback func(idx int) Annotation, samplingConfig SamplingConfig, args ...interface{}) {
 	decorations := extractDecorations(args)
-	e.Sample(func(idx int) {
+	decorations.trimPercent = samplingConfig.TrimPercent
+	n, elapsed := e.sample(func(idx int, worker int) {
+		stopMemStats := measureMemStats(decorations.memStats)
 		t := time.Now()
-		decorations.annotation = callback(idx)
+		annotation := callback(idx)
 		duration := time.Since(t)
-		e.recordDuration(name, duration, decorations)
+		memStats := stopMemStats()
+		if idx >= 0 {
+			annotated := decorations
+			annotated.annotation = annotation
+			e.recordSampledDuration(name, worker, duration, annotated, samplingConfig)
+			e.recordMemStats(name, memStats, annotated)
+		}
 	}, samplingConfig)
+	e.recordThroughput(name, n, elapsed, decorations, samplingConfig)
+}
+
+// recordSampledDuration records a duration sampled by worker, and also records it on the worker's own Measurement if samplingConfig.PerWorkerSeries is set
+func (e *Experiment) recordSampledDuration(name string, worker int, duration time.Duration, decorations extractedDecorations, samplingConfig SamplingConfig) {
+	e.recordDuration(name, duration, decorations)
+	if samplingConfig.PerWorkerSeries {
+		e.recordDuration(workerSeriesName(name, worker), duration, decorations)
+	}
 }
 
 func (e *Experiment) recordDuration(name string, duration time.Duration, decorations extractedDecorations) {
@@ -336,6 +379,7 @@ func (e *Experiment) recordDuration(name string, duration time.Duration, decorat
 			PrecisionBundle: decorations.precisionBundle,
 			Style:           string(decorations.style),
 			Annotations:     []string{string(decorations.annotation)},
+			TrimPercent:     decorations.trimPercent,
 		}
 		e.Measurements = append(e.Measurements, measurement)
 	} else {
@@ -344,6 +388,9 @@ func (e *Experiment) recordDuration(name string, duration time.Duration, decorat
 		}
 		e.Measurements[idx].Durations = append(e.Measurements[idx].Durations, duration)
 		e.Measurements[idx].Annotations = append(e.Measurements[idx].Annotations, string(decorations.annotation))
+		if decorations.trimPercent > 0 {
+			e.Measurements[idx].TrimPercent = decorations.trimPercent
+		}
 	}
 }
 
@@ -367,11 +414,15 @@ func (e *Experiment) RecordValue(name string, value float64, args ...interface{}
 /*
 MeasureValue runs the passed-in callback and records the return value on a Value Measurement with the passed-in name.  If the Measurement does not exist it is created.
 
-MeasureValue supports the Style(), Units(), Precision(), and Annotation() decorations.
+MeasureValue supports the Style(), Units(), Precision(), Annotation(), and RecordMemStats decorations.
 */
 func (e *Experiment) MeasureValue(name string, callback func() float64, args ...interface{}) float64 {
+	decorations := extractDecorations(args)
+	stopMemStats := measureMemStats(decorations.memStats)
 	value := callback()
-	e.RecordValue(name, value, args...)
+	memStats := stopMemStats()
+	e.recordValue(name, value, decorations)
+	e.recordMemStats(name, memStats, decorations)
 	return value
 }
 
@@ -380,14 +431,21 @@ SampleValue samples the passed-in callback and records the return value on a Val
 
 The callback is given a zero-based index that increments by one between samples.  The callback must return a float64.  The Sampling is configured via the passed-in SamplingConfig
 
-SampleValue supports the Style(), Units(), Precision(), and Annotation() decorations.  When passed an Annotation() the same annotation is applied to all sample measurements.
+SampleValue supports the Style(), Units(), Precision(), Annotation(), and RecordMemStats decorations.  When passed an Annotation() the same annotation is applied to all sample measurements.
 */
 func (e *Experiment) SampleValue(name string, callback func(idx int) float64, samplingConfig SamplingConfig, args ...interface{}) {
 	decorations := extractDecorations(args)
-	e.Sample(func(idx int) {
+	decorations.trimPercent = samplingConfig.TrimPercent
+	n, elapsed := e.sample(func(idx int, worker int) {
+		stopMemStats := measureMemStats(decorations.memStats)
 		value := callback(idx)
-		e.recordValue(name, value, decorations)
+		memStats := stopMemStats()
+		if idx >= 0 {
+			e.recordSampledValue(name, worker, value, decorations, samplingConfig)
+			e.recordMemStats(name, memStats, decorations)
+		}
 	}, samplingConfig)
+	e.recordThroughput(name, n, elapsed, decorations, samplingConfig)
 }
 
 /*
@@ -397,15 +455,46 @@ The callback is given a zero-based index that increments by one between samples.
 
 The Sampling is configured via the passed-in SamplingConfig
 
-SampleValue supports the Style(), Units(), and Precision() decorations.
+SampleAnnotatedValue supports the Style(), Units(), Precision(), and RecordMemStats decorations.
 */
 func (e *Experiment) SampleAnnotatedValue(name string, callback func(idx int) (float64, Annotation), samplingConfig SamplingConfig, args ...interface{}) {
 	decorations := extractDecorations(args)
-	e.Sample(func(idx int) {
-		var value float64
-		value, decorations.annotation = callback(idx)
-		e.recordValue(name, value, decorations)
+	decorations.trimPercent = samplingConfig.TrimPercent
+	n, elapsed := e.sample(func(idx int, worker int) {
+		stopMemStats := measureMemStats(decorations.memStats)
+		value, annotation := callback(idx)
+		memStats := stopMemStats()
+		if idx >= 0 {
+			annotated := decorations
+			annotated.annotation = annotation
+			e.recordSampledValue(name, worker, value, annotated, samplingConfig)
+			e.recordMemStats(name, memStats, annotated)
+		}
 	}, samplingConfig)
+	e.recordThroughput(name, n, elapsed, decorations, samplingConfig)
+}
+
+// recordSampledValue records a value sampled by worker, and also records it on the worker's own Measurement if samplingConfig.PerWorkerSeries is set
+func (e *Experiment) recordSampledValue(name string, worker int, value float64, decorations extractedDecorations, samplingConfig SamplingConfig) {
+	e.recordValue(name, value, decorations)
+	if samplingConfig.PerWorkerSeries {
+		e.recordValue(workerSeriesName(name, worker), value, decorations)
+	}
+}
+
+// recordThroughput records the number of samples taken per second if samplingConfig.RecordThroughput is set
+func (e *Experiment) recordThroughput(name string, n int, elapsed time.Duration, decorations extractedDecorations, samplingConfig SamplingConfig) {
+	if !samplingConfig.RecordThroughput || elapsed <= 0 {
+		return
+	}
+	decorations.units = "ops/sec"
+	decorations.annotation = Annotation(fmt.Sprintf("%d samples in %s", n, elapsed.Round(time.Millisecond)))
+	decorations.trimPercent = 0
+	e.recordValue(name+": throughput", float64(n)/elapsed.Seconds(), decorations)
+}
+
+func workerSeriesName(name string, worker int) string {
+	return fmt.Sprintf("%s: worker %d", name, worker)
 }
 
 func (e *Experiment) recordValue(name string, value float64, decorations extractedDecorations) {
@@ -422,6 +511,7 @@ func (e *Experiment) recordValue(name string, value float64, decorations extract
 			PrecisionBundle: decorations.precisionBundle,
 			Values:          []float64{value},
 			Annotations:     []string{string(decorations.annotation)},
+			TrimPercent:     decorations.trimPercent,
 		}
 		e.Measurements = append(e.Measurements, measurement)
 	} else {
@@ -430,6 +520,9 @@ func (e *Experiment) recordValue(name string, value float64, decorations extract
 		}
 		e.Measurements[idx].Values = append(e.Measurements[idx].Values, value)
 		e.Measurements[idx].Annotations = append(e.Measurements[idx].Annotations, string(decorations.annotation))
+		if decorations.trimPercent > 0 {
+			e.Measurements[idx].TrimPercent = decorations.trimPercent
+		}
 	}
 }
 
@@ -437,20 +530,36 @@ func (e *Experiment) recordValue(name string, value float64, decorations extract
 Sample samples the passed-in callback repeatedly.  The sampling is governed by the passed in SamplingConfig.
 
 The SamplingConfig can limit the total number of samples and/or the total time spent sampling the callback.
-The SamplingConfig can also instruct Sample to run with multiple concurrent workers.
+The SamplingConfig can also instruct Sample to run with multiple concurrent workers.  Sample returns once every worker has finished its last sample.
 
 The callback is called with a zero-based index that incerements by one between samples.
+
+If SamplingConfig.Warmup is set the callback is first called Warmup times, serially, with the negative indices -Warmup through -1.
+Warmup calls do not count towards N or Duration - callbacks that record measurements themselves should skip recording when idx is negative.
 */
 func (e *Experiment) Sample(callback func(idx int), samplingConfig SamplingConfig) {
+	e.sample(func(idx int, _ int) { callback(idx) }, samplingConfig)
+}
+
+// sample implements Sample, additionally passing the callback the zero-based index of the worker running it (warmup always runs on worker 0).
+// It returns once every sample has completed, and reports the number of samples taken and the time spent taking them, excluding warmup.
+func (e *Experiment) sample(callback func(idx int, worker int), samplingConfig SamplingConfig) (int, time.Duration) {
 	if samplingConfig.N == 0 && samplingConfig.Duration == 0 {
 		panic("you must specify at least one of SamplingConfig.N and SamplingConfig.Duration")
 	}
 	if samplingConfig.MinSamplingInterval > 0 && samplingConfig.NumParallel > 1 {
 		panic("you cannot specify both SamplingConfig.MinSamplingInterval and SamplingConfig.NumParallel")
 	}
-	maxTime := time.Now().Add(100000 * time.Hour)
+	if samplingConfig.TrimPercent < 0 || samplingConfig.TrimPercent >= 50 {
+		panic(fmt.Sprintf("SamplingConfig.TrimPercent must be at least 0 and less than 50, got %v", samplingConfig.TrimPercent))
+	}
+	for idx := -samplingConfig.Warmup; idx < 0; idx++ {
+		callback(idx, 0)
+	}
+	start := time.Now()
+	maxTime := start.Add(100000 * time.Hour)
 	if samplingConfig.Duration > 0 {
-		maxTime = time.Now().Add(samplingConfig.Duration)
+		maxTime = start.Add(samplingConfig.Duration)
 	}
 	maxN := math.MaxInt32
 	if samplingConfig.N > 0 {
@@ -463,14 +572,16 @@ func (e *Experiment) Sample(callback func(idx int), samplingConfig SamplingConfi
 	minSamplingInterval := samplingConfig.MinSamplingInterval
 
 	work := make(chan int)
-	defer close(work)
+	wg := &sync.WaitGroup{}
 	if numParallel > 1 {
 		for worker := 0; worker < numParallel; worker++ {
-			go func() {
+			wg.Add(1)
+			go func(worker int) {
+				defer wg.Done()
 				for idx := range work {
-					callback(idx)
+					callback(idx, worker)
 				}
-			}()
+			}(worker)
 		}
 	}
 
@@ -481,7 +592,7 @@ func (e *Experiment) Sample(callback func(idx int), samplingConfig SamplingConfi
 		if numParallel > 1 {
 			work <- idx
 		} else {
-			callback(idx)
+			callback(idx, 0)
 		}
 		dt := time.Since(t)
 		if numParallel == 1 && dt < minSamplingInterval {
@@ -493,12 +604,15 @@ func (e *Experiment) Sample(callback func(idx int), samplingConfig SamplingConfi
 		}
 		idx += 1
 		if idx >= maxN {
-			return
+			break
 		}
 		if time.Now().Add(avgDt).After(maxTime) {
-			return
+			break
 		}
 	}
+	close(work)
+	wg.Wait()
+	return idx, time.Since(start)
 }
 
 /*
@@ -239,6 +239,96 @@ var _ = Describe("Experiment", func() {
 			Ω(indices).Should(ConsistOf(ints(len(indices))))
 		})
 
+		It("can warm up before sampling, passing negative indices that don't count towards N", func() {
+			e.Sample(func(idx int) {
+				indices = append(indices, idx)
+			}, gmeasure.SamplingConfig{N: 3, Warmup: 2})
+
+			Ω(indices).Should(Equal([]int{-2, -1, 0, 1, 2}))
+		})
+
+		It("does not record warmup samples with the SampleX methods", func() {
+			e.SampleValue("warmed up", func(idx int) float64 {
+				return float64(idx)
+			}, gmeasure.SamplingConfig{N: 3, Warmup: 5})
+			e.SampleAnnotatedDuration("warmed up duration", func(idx int) gmeasure.Annotation {
+				return gmeasure.Annotation(fmt.Sprintf("sample-%d", idx))
+			}, gmeasure.SamplingConfig{N: 2, Warmup: 1})
+
+			Ω(e.Get("warmed up").Values).Should(Equal([]float64{0, 1, 2}))
+			Ω(e.Get("warmed up duration").Annotations).Should(Equal([]string{"sample-0", "sample-1"}))
+		})
+
+		It("can trim outliers from the stats of sampled measurements", func() {
+			e.SampleValue("trimmed", func(idx int) float64 {
+				return []float64{1000, 2, 3, 4, 5, 6, 7, 8, 9, -1000}[idx]
+			}, gmeasure.SamplingConfig{N: 10, TrimPercent: 10})
+
+			measurement := e.Get("trimmed")
+			Ω(measurement.TrimPercent).Should(Equal(10.0))
+			Ω(measurement.Values).Should(HaveLen(10))
+			stats := e.GetStats("trimmed")
+			Ω(stats.N).Should(Equal(8))
+			Ω(stats.Trimmed).Should(Equal(2))
+			Ω(stats.ValueFor(gmeasure.StatMin)).Should(Equal(2.0))
+			Ω(stats.ValueFor(gmeasure.StatMax)).Should(Equal(9.0))
+			Ω(stats.ValueFor(gmeasure.StatMean)).Should(Equal(5.5))
+		})
+
+		It("waits for every parallel sample to complete before returning", func() {
+			lock := &sync.Mutex{}
+			completed := 0
+			e.Sample(func(idx int) {
+				time.Sleep(20 * time.Millisecond)
+				lock.Lock()
+				completed += 1
+				lock.Unlock()
+			}, gmeasure.SamplingConfig{N: 6, NumParallel: 3})
+
+			lock.Lock()
+			defer lock.Unlock()
+			Ω(completed).Should(Equal(6))
+		})
+
+		It("can record a series for each parallel worker", func() {
+			e.SampleValue("load", func(idx int) float64 {
+				time.Sleep(5 * time.Millisecond)
+				return float64(idx)
+			}, gmeasure.SamplingConfig{N: 12, NumParallel: 3, PerWorkerSeries: true}, gmeasure.Units("widgets"))
+
+			Ω(e.Get("load").Values).Should(ConsistOf(0.0, 1.0, 2.0, 3.0, 4.0, 5.0, 6.0, 7.0, 8.0, 9.0, 10.0, 11.0))
+			perWorker := []float64{}
+			for worker := 0; worker < 3; worker++ {
+				series := e.Get(fmt.Sprintf("load: worker %d", worker))
+				Ω(series.Units).Should(Equal("widgets"))
+				perWorker = append(perWorker, series.Values...)
+			}
+			Ω(perWorker).Should(ConsistOf(e.Get("load").Values))
+			Ω(e.Get("load: worker 3").Type).Should(Equal(gmeasure.MeasurementTypeInvalid))
+		})
+
+		It("can record the aggregate throughput", func() {
+			e.SampleAnnotatedDuration("request", func(idx int) gmeasure.Annotation {
+				time.Sleep(10 * time.Millisecond)
+				return gmeasure.Annotation(fmt.Sprintf("request-%d", idx))
+			}, gmeasure.SamplingConfig{N: 20, NumParallel: 4, RecordThroughput: true, Warmup: 2})
+
+			Ω(e.Get("request").Durations).Should(HaveLen(20))
+			Ω(e.Get("request").Annotations).Should(ContainElements("request-0", "request-19"))
+			throughput := e.Get("request: throughput")
+			Ω(throughput.Type).Should(Equal(gmeasure.MeasurementTypeValue))
+			Ω(throughput.Units).Should(Equal("ops/sec"))
+			Ω(throughput.Values).Should(HaveLen(1))
+			Ω(throughput.Values[0]).Should(And(BeNumerically("<=", 400), BeNumerically(">", 100)))
+			Ω(throughput.Annotations[0]).Should(HavePrefix("20 samples in "))
+		})
+
+		It("panics if the SamplingConfig has an out-of-range TrimPercent", func() {
+			Expect(func() {
+				e.Sample(func(_ int) {}, gmeasure.SamplingConfig{N: 10, TrimPercent: 50})
+			}).To(PanicWith("SamplingConfig.TrimPercent must be at least 0 and less than 50, got 50"))
+		})
+
 		It("panics if the SamplingConfig does not specify a ceiling", func() {
 			Expect(func() {
 				e.Sample(func(_ int) {}, gmeasure.SamplingConfig{MinSamplingInterval: time.Second})
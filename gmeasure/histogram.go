@@ -0,0 +1,170 @@
+package gmeasure
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega/gmeasure/table"
+)
+
+// histogramBarWidth is the width of the bar that represents the fullest bucket when a Histogram is rendered
+const histogramBarWidth = 40
+
+/*
+HistogramBucket counts the data points of a Measurement that fall between Min (inclusive) and Max (exclusive - except
+for the last bucket of a Histogram, which includes its Max).
+
+For Duration Measurements Min and Max are durations converted to float64s, as Stats.FloatFor returns them.
+*/
+type HistogramBucket struct {
+	Min   float64
+	Max   float64
+	Count int
+}
+
+/*
+Histogram counts the data points of a Measurement in equal-width buckets spanning its smallest to its largest data point.
+Use Measurement.Histogram to create a Histogram.
+
+When using Ginkgo, you can register Histograms as Report Entries via AddReportEntry.  This will emit a table with a bar
+for each bucket when Ginkgo generates the report.
+*/
+type Histogram struct {
+	// Type is the StatsType - one of StatsTypeDuration or StatsTypeValue
+	Type StatsType
+
+	// ExperimentName is the name of the Experiment that recorded the Measurement from which this Histogram is derived
+	ExperimentName string
+
+	// MeasurementName is the name of the Measurement from which this Histogram is derived
+	MeasurementName string
+
+	// Units captures the Units of the Measurement from which this Histogram is derived
+	Units string
+
+	// Style captures the Style of the Measurement from which this Histogram is derived
+	Style string
+
+	// PrecisionBundle captures the precision to use when rendering the bounds of the buckets
+	PrecisionBundle PrecisionBundle
+
+	// Buckets are the buckets of the Histogram, in ascending order
+	Buckets []HistogramBucket
+}
+
+/*
+Histogram counts the data points of this Measurement in bucketCount equal-width buckets spanning its smallest to its
+largest data point.  If every data point is the same the Histogram has a single bucket.
+
+For example:
+
+	AddReportEntry("runtime histogram", experiment.Get("runtime").Histogram(10))
+
+Histogram panics if bucketCount is less than 1.
+*/
+func (m Measurement) Histogram(bucketCount int) Histogram {
+	if bucketCount < 1 {
+		panic(fmt.Sprintf("a histogram needs at least one bucket, got %d", bucketCount))
+	}
+	out := Histogram{
+		ExperimentName:  m.ExperimentName,
+		MeasurementName: m.Name,
+		Units:           m.Units,
+		Style:           m.Style,
+		PrecisionBundle: m.PrecisionBundle,
+	}
+	switch m.Type {
+	case MeasurementTypeValue:
+		out.Type = StatsTypeValue
+	case MeasurementTypeDuration:
+		out.Type = StatsTypeDuration
+	default:
+		return out
+	}
+
+	sorted := m.sortedFloats()
+	if len(sorted) == 0 {
+		return out
+	}
+	min, max := sorted[0], sorted[len(sorted)-1]
+	if min == max {
+		bucketCount = 1
+	}
+	width := (max - min) / float64(bucketCount)
+	out.Buckets = make([]HistogramBucket, bucketCount)
+	for idx := range out.Buckets {
+		out.Buckets[idx] = HistogramBucket{Min: min + width*float64(idx), Max: min + width*float64(idx+1)}
+	}
+	out.Buckets[bucketCount-1].Max = max
+	for _, v := range sorted {
+		idx := bucketCount - 1
+		if width > 0 {
+			idx = int(math.Min(math.Floor((v-min)/width), float64(bucketCount-1)))
+		}
+		out.Buckets[idx].Count++
+	}
+	return out
+}
+
+func (h Histogram) stringFor(bound float64) string {
+	switch h.Type {
+	case StatsTypeValue:
+		return fmt.Sprintf(h.PrecisionBundle.ValueFormat, bound)
+	case StatsTypeDuration:
+		return time.Duration(math.Round(bound)).Round(h.PrecisionBundle.Duration).String()
+	}
+	return ""
+}
+
+func (h Histogram) report(enableStyling bool) string {
+	out := fmt.Sprintf("%s - %s", h.ExperimentName, h.MeasurementName)
+	if h.Units != "" {
+		out += " [" + h.Units + "]"
+	}
+	if enableStyling && h.Style != "" {
+		out = h.Style + out + "{{/}}"
+	}
+	out += "\n"
+	if len(h.Buckets) == 0 {
+		return out + "No data points\n"
+	}
+
+	maxCount := 0
+	for _, bucket := range h.Buckets {
+		if bucket.Count > maxCount {
+			maxCount = bucket.Count
+		}
+	}
+	t := table.NewTable()
+	t.TableStyle.EnableTextStyling = enableStyling
+	t.AppendRow(table.R(table.C("Range", table.AlignTypeCenter), table.C("Count", table.AlignTypeCenter), table.C(""), table.Divider("="), "{{bold}}"))
+	for idx, bucket := range h.Buckets {
+		closing := ")"
+		if idx == len(h.Buckets)-1 {
+			closing = "]"
+		}
+		t.AppendRow(table.R(
+			table.C(fmt.Sprintf("[%s, %s%s", h.stringFor(bucket.Min), h.stringFor(bucket.Max), closing), table.AlignTypeRight),
+			table.C(fmt.Sprintf("%d", bucket.Count), table.AlignTypeRight),
+			table.C(strings.Repeat("#", bucket.Count*histogramBarWidth/maxCount), table.AlignTypeLeft),
+		))
+	}
+	return out + t.Render()
+}
+
+/*
+ColorableString generates a styled report that includes a row, with a bar, for each bucket of the Histogram.
+It is called automatically by Ginkgo's reporting infrastructure when the Histogram is registered as a ReportEntry via AddReportEntry.
+*/
+func (h Histogram) ColorableString() string {
+	return h.report(true)
+}
+
+/*
+String generates an unstyled report that includes a row, with a bar, for each bucket of the Histogram.
+*/
+func (h Histogram) String() string {
+	return h.report(false)
+}
@@ -0,0 +1,84 @@
+package gmeasure_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gmeasure"
+)
+
+var _ = Describe("Histogram", func() {
+	var e *gmeasure.Experiment
+
+	BeforeEach(func() {
+		e = gmeasure.NewExperiment("Test Experiment")
+	})
+
+	Describe("Value Measurements", func() {
+		BeforeEach(func() {
+			for _, v := range []float64{0, 1, 2, 2.5, 4, 7, 8, 10} {
+				e.RecordValue("sizes", v, gmeasure.Units("KiB"), gmeasure.Precision(1))
+			}
+		})
+
+		It("counts the data points in equal-width buckets, including the largest in the last bucket", func() {
+			h := e.Get("sizes").Histogram(4)
+			Ω(h.Type).Should(Equal(gmeasure.StatsTypeValue))
+			Ω(h.ExperimentName).Should(Equal("Test Experiment"))
+			Ω(h.MeasurementName).Should(Equal("sizes"))
+			Ω(h.Buckets).Should(Equal([]gmeasure.HistogramBucket{
+				{Min: 0, Max: 2.5, Count: 3},
+				{Min: 2.5, Max: 5, Count: 2},
+				{Min: 5, Max: 7.5, Count: 1},
+				{Min: 7.5, Max: 10, Count: 2},
+			}))
+		})
+
+		It("generates an unstyled report", func() {
+			Ω(e.Get("sizes").Histogram(2).String()).Should(Equal(strings.Join([]string{
+				"Test Experiment - sizes [KiB]",
+				"   Range    | Count |                                         ",
+				"==============================================================",
+				" [0.0, 5.0) |     5 | ########################################",
+				"--------------------------------------------------------------",
+				"[5.0, 10.0] |     3 | ########################                ",
+				"",
+			}, "\n")))
+		})
+
+		It("has a single bucket when every data point is the same", func() {
+			e.RecordValue("same", 3)
+			e.RecordValue("same", 3)
+			Ω(e.Get("same").Histogram(10).Buckets).Should(Equal([]gmeasure.HistogramBucket{{Min: 3, Max: 3, Count: 2}}))
+		})
+	})
+
+	Describe("Duration Measurements", func() {
+		It("represents the bounds of buckets as float64s and renders them as durations", func() {
+			for _, d := range []time.Duration{10, 20, 30, 90} {
+				e.RecordDuration("runtime", d*time.Millisecond, gmeasure.Style("{{red}}"))
+			}
+			h := e.Get("runtime").Histogram(2)
+			Ω(h.Type).Should(Equal(gmeasure.StatsTypeDuration))
+			Ω(h.Buckets).Should(Equal([]gmeasure.HistogramBucket{
+				{Min: float64(10 * time.Millisecond), Max: float64(50 * time.Millisecond), Count: 3},
+				{Min: float64(50 * time.Millisecond), Max: float64(90 * time.Millisecond), Count: 1},
+			}))
+			Ω(h.ColorableString()).Should(HavePrefix("{{red}}Test Experiment - runtime [duration]{{/}}\n"))
+			Ω(h.String()).Should(ContainSubstring("[10ms, 50ms) |     3 | ########################################"))
+			Ω(h.String()).Should(ContainSubstring("[50ms, 90ms] |     1 | ##########"))
+		})
+	})
+
+	It("has no buckets when there are no data points", func() {
+		h := gmeasure.Measurement{Type: gmeasure.MeasurementTypeValue, ExperimentName: "Test Experiment", Name: "empty"}.Histogram(3)
+		Ω(h.Buckets).Should(BeEmpty())
+		Ω(h.String()).Should(Equal("Test Experiment - empty\nNo data points\n"))
+	})
+
+	It("panics when asked for fewer than one bucket", func() {
+		Ω(func() { gmeasure.Measurement{}.Histogram(0) }).Should(PanicWith("a histogram needs at least one bucket, got 0"))
+	})
+})
@@ -0,0 +1,108 @@
+package gmeasure
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+StatFields maps Stats to what they are expected to be - a number, or a matcher.  See MatchStats.
+*/
+type StatFields map[Stat]interface{}
+
+/*
+MatchStats succeeds if actual - a Stats or a Measurement - satisfies every expectation in fields.  It lets an Experiment
+gate CI rather than only report numbers:
+
+	Expect(experiment.GetStats("runtime")).To(gmeasure.MatchStats(gmeasure.StatFields{
+		gmeasure.StatMedian: BeNumerically("<", 100*time.Millisecond),
+		gmeasure.StatMax:    BeNumerically("<", time.Second),
+	}))
+
+The stats of Duration Measurements are passed to the matchers as time.Durations, and the stats of Value Measurements
+as float64s.  Numbers are compared with BeNumerically("==", ...).  It is an error to pass Stats with no data points -
+for example the Stats GetStats returns for a Measurement that was never recorded.
+*/
+func MatchStats(fields StatFields) types.GomegaMatcher {
+	return &MatchStatsMatcher{
+		Fields: fields,
+	}
+}
+
+type MatchStatsMatcher struct {
+	Fields StatFields
+
+	// state
+	stats    Stats
+	failures []string
+}
+
+func (matcher *MatchStatsMatcher) Match(actual interface{}) (success bool, err error) {
+	switch x := actual.(type) {
+	case Stats:
+		matcher.stats = x
+	case Measurement:
+		matcher.stats = x.Stats()
+	default:
+		return false, fmt.Errorf("MatchStats matcher expects a gmeasure.Stats or a gmeasure.Measurement.  Got:\n%s", format.Object(actual, 1))
+	}
+	if matcher.stats.Type == StatsTypeInvalid || matcher.stats.N == 0 {
+		return false, fmt.Errorf("MatchStats matcher expects stats with data points, but %s has none", matcher.describe())
+	}
+
+	matcher.failures = []string{}
+	for _, stat := range sortedStats(matcher.Fields) {
+		expectedMatcher, isMatcher := matcher.Fields[stat].(types.GomegaMatcher)
+		if !isMatcher {
+			expectedMatcher = &matchers.BeNumericallyMatcher{Comparator: "==", CompareTo: []interface{}{matcher.Fields[stat]}}
+		}
+		value := matcher.valueFor(stat)
+		success, err := expectedMatcher.Match(value)
+		if err != nil {
+			return false, fmt.Errorf("MatchStats matcher could not match the %s of %s:\n%s", stat, matcher.describe(), format.IndentString(err.Error(), 1))
+		}
+		if !success {
+			matcher.failures = append(matcher.failures, fmt.Sprintf("%s:\n%s", stat, format.IndentString(expectedMatcher.FailureMessage(value), 1)))
+		}
+	}
+	return len(matcher.failures) == 0, nil
+}
+
+func (matcher *MatchStatsMatcher) FailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Expected the stats of %s (%s) to match, but:\n", matcher.describe(), matcher.stats)
+	return message + format.IndentString(strings.Join(matcher.failures, "\n"), 1)
+}
+
+func (matcher *MatchStatsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the stats of %s (%s) not to match, but they did", matcher.describe(), matcher.stats)
+}
+
+func (matcher *MatchStatsMatcher) valueFor(stat Stat) interface{} {
+	if matcher.stats.Type == StatsTypeDuration {
+		return matcher.stats.DurationFor(stat)
+	}
+	return matcher.stats.ValueFor(stat)
+}
+
+func (matcher *MatchStatsMatcher) describe() string {
+	if matcher.stats.ExperimentName == "" {
+		return fmt.Sprintf("measurement %q", matcher.stats.MeasurementName)
+	}
+	return fmt.Sprintf("measurement %q of experiment %q", matcher.stats.MeasurementName, matcher.stats.ExperimentName)
+}
+
+// sortedStats returns the Stats in fields in the order the Stat enum declares them, so that failures are reported
+// in a stable order
+func sortedStats(fields StatFields) []Stat {
+	stats := make([]Stat, 0, len(fields))
+	for stat := range fields {
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i] < stats[j] })
+	return stats
+}
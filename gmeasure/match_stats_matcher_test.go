@@ -0,0 +1,82 @@
+package gmeasure_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gmeasure"
+)
+
+var _ = Describe("MatchStats", func() {
+	var experiment *gmeasure.Experiment
+
+	BeforeEach(func() {
+		experiment = gmeasure.NewExperiment("My Test Experiment")
+		for _, d := range []time.Duration{10, 20, 30, 40, 100} {
+			experiment.RecordDuration("runtime", d*time.Millisecond)
+		}
+		for _, v := range []float64{1, 2, 3} {
+			experiment.RecordValue("length", v)
+		}
+	})
+
+	It("passes the stats of duration measurements to matchers as time.Durations", func() {
+		Ω(experiment.GetStats("runtime")).Should(gmeasure.MatchStats(gmeasure.StatFields{
+			gmeasure.StatMin:    10 * time.Millisecond,
+			gmeasure.StatMedian: BeNumerically("<", 50*time.Millisecond),
+			gmeasure.StatMax:    BeNumerically("<=", 100*time.Millisecond),
+			gmeasure.StatMean:   Equal(40 * time.Millisecond),
+		}))
+		Ω(experiment.GetStats("runtime")).ShouldNot(gmeasure.MatchStats(gmeasure.StatFields{
+			gmeasure.StatMedian: BeNumerically("<", 30*time.Millisecond),
+		}))
+	})
+
+	It("passes the stats of value measurements to matchers as float64s", func() {
+		Ω(experiment.GetStats("length")).Should(gmeasure.MatchStats(gmeasure.StatFields{
+			gmeasure.StatMean:   2,
+			gmeasure.StatMax:    Equal(3.0),
+			gmeasure.StatStdDev: BeNumerically("~", 0.816, 0.001),
+		}))
+		Ω(experiment.GetStats("length")).ShouldNot(gmeasure.MatchStats(gmeasure.StatFields{gmeasure.StatMin: 0}))
+	})
+
+	It("accepts measurements", func() {
+		Ω(experiment.Get("length")).Should(gmeasure.MatchStats(gmeasure.StatFields{gmeasure.StatMedian: 2}))
+	})
+
+	It("errors when there are no data points, or it is not passed stats", func() {
+		_, err := gmeasure.MatchStats(gmeasure.StatFields{}).Match(experiment.GetStats("missing"))
+		Ω(err).Should(MatchError(`MatchStats matcher expects stats with data points, but measurement "" has none`))
+		_, err = gmeasure.MatchStats(gmeasure.StatFields{}).Match(experiment)
+		Ω(err).Should(MatchError(ContainSubstring("MatchStats matcher expects a gmeasure.Stats or a gmeasure.Measurement.  Got:")))
+		_, err = gmeasure.MatchStats(gmeasure.StatFields{gmeasure.StatMax: "fast"}).Match(experiment.GetStats("runtime"))
+		Ω(err).Should(MatchError(HavePrefix(`MatchStats matcher could not match the Max of measurement "runtime" of experiment "My Test Experiment":`)))
+	})
+
+	It("reports every stat that does not match, in order", func() {
+		failures := InterceptGomegaFailures(func() {
+			Ω(experiment.GetStats("runtime")).Should(gmeasure.MatchStats(gmeasure.StatFields{
+				gmeasure.StatMax:    BeNumerically("<", 50*time.Millisecond),
+				gmeasure.StatMin:    10 * time.Millisecond,
+				gmeasure.StatMedian: BeNumerically("<", 20*time.Millisecond),
+			}))
+			Ω(experiment.GetStats("length")).ShouldNot(gmeasure.MatchStats(gmeasure.StatFields{gmeasure.StatMin: 1}))
+		})
+		Ω(failures).Should(Equal([]string{
+			`Expected the stats of measurement "runtime" of experiment "My Test Experiment" (10ms < [30ms] | <40ms> ±31.6ms < 100ms) to match, but:
+    Max:
+        Expected
+            <time.Duration>: 100000000
+        to be <
+            <time.Duration>: 50000000
+    Median:
+        Expected
+            <time.Duration>: 30000000
+        to be <
+            <time.Duration>: 20000000`,
+			`Expected the stats of measurement "length" of experiment "My Test Experiment" (1.000 < [2.000] | <2.000> ±0.816 < 3.000) not to match, but they did`,
+		}))
+	})
+})
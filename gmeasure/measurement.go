@@ -66,6 +66,10 @@ type Measurement struct {
 	// If Type is MeasurementTypeDuration or MeasurementTypeValue then Annotations will include string annotations for all recorded Durations or Values.
 	// If the user does not pass-in an Annotation() decoration for a particular value or duration, the corresponding entry in the Annotations slice will be the empty string ""
 	Annotations []string
+
+	// If TrimPercent is greater than zero, Stats() excludes that percentage of the smallest and of the largest data points when computing statistics.
+	// TrimPercent is set by the SampleX family of Experiment methods from SamplingConfig.TrimPercent.  All data points remain in Durations and Values.
+	TrimPercent float64
 }
 
 type Measurements []Measurement
@@ -158,6 +162,7 @@ func (m Measurement) Stats() Stats {
 		Units:           m.Units,
 		PrecisionBundle: m.PrecisionBundle,
 	}
+	m, out.Trimmed = m.trimmed()
 
 	switch m.Type {
 	case MeasurementTypeValue:
@@ -195,6 +200,11 @@ func (m Measurement) Stats() Stats {
 			out.ValueBundle[StatStdDev] += (v - out.ValueBundle[StatMean]) * (v - out.ValueBundle[StatMean])
 		}
 		out.ValueBundle[StatStdDev] = math.Sqrt(out.ValueBundle[StatStdDev] / float64(out.N))
+
+		sorted := m.sortedFloats()
+		for stat, p := range percentileStats {
+			out.ValueBundle[stat] = percentile(sorted, p)
+		}
 	case MeasurementTypeDuration:
 		out.Type = StatsTypeDuration
 		out.N = len(m.Durations)
@@ -229,7 +239,125 @@ func (m Measurement) Stats() Stats {
 			stdDev += float64(v-out.DurationBundle[StatMean]) * float64(v-out.DurationBundle[StatMean])
 		}
 		out.DurationBundle[StatStdDev] = time.Duration(math.Sqrt(stdDev / float64(out.N)))
+
+		sorted := m.sortedFloats()
+		for stat, p := range percentileStats {
+			out.DurationBundle[stat] = time.Duration(math.Round(percentile(sorted, p)))
+		}
+	}
+
+	return out
+}
+
+// trimmed returns a copy of this Measurement without the TrimPercent smallest and largest data points, along with the
+// number of data points it excluded.  The retained data points keep their order and annotations.
+func (m Measurement) trimmed() (Measurement, int) {
+	n := len(m.Values)
+	if m.Type == MeasurementTypeDuration {
+		n = len(m.Durations)
+	}
+	k := int(float64(n) * m.TrimPercent / 100)
+	if k <= 0 {
+		return m, 0
+	}
+	indices := make([]int, n)
+	for idx := range indices {
+		indices[idx] = idx
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		if m.Type == MeasurementTypeDuration {
+			return m.Durations[indices[i]] < m.Durations[indices[j]]
+		}
+		return m.Values[indices[i]] < m.Values[indices[j]]
+	})
+	keep := make([]bool, n)
+	for _, idx := range indices[k : n-k] {
+		keep[idx] = true
+	}
+
+	out := m
+	out.Durations, out.Values, out.Annotations = nil, nil, nil
+	for idx := 0; idx < n; idx++ {
+		if !keep[idx] {
+			continue
+		}
+		if m.Type == MeasurementTypeDuration {
+			out.Durations = append(out.Durations, m.Durations[idx])
+		} else {
+			out.Values = append(out.Values, m.Values[idx])
+		}
+		out.Annotations = append(out.Annotations, m.Annotations[idx])
+	}
+	return out, 2 * k
+}
+
+// percentileStats maps the percentile Stats to the percentiles they represent
+var percentileStats = map[Stat]float64{
+	StatP90:  90,
+	StatP99:  99,
+	StatP999: 99.9,
+}
+
+/*
+ValuePercentile returns the p-th percentile of the values recorded for this Measurement, where p is between 0 and 100.
+Percentiles are interpolated linearly between the closest data points, so ValuePercentile(50) is the median.
+You should only use this if the Measurement has Type MeasurementTypeValue.
+
+For example:
+
+	p95 := experiment.Get("queue depth").ValuePercentile(95)
+
+ValuePercentile panics if p is out of range, and returns 0 if no values have been recorded.
+*/
+func (m Measurement) ValuePercentile(p float64) float64 {
+	return percentile(m.sortedFloats(), validPercentile(p))
+}
+
+/*
+DurationPercentile returns the p-th percentile of the durations recorded for this Measurement, where p is between 0 and
+100.  Percentiles are interpolated linearly between the closest data points, so DurationPercentile(50) is the median.
+You should only use this if the Measurement has Type MeasurementTypeDuration.
+
+For example:
+
+	p999 := experiment.Get("runtime").DurationPercentile(99.9)
+
+DurationPercentile panics if p is out of range, and returns 0 if no durations have been recorded.
+*/
+func (m Measurement) DurationPercentile(p float64) time.Duration {
+	return time.Duration(math.Round(percentile(m.sortedFloats(), validPercentile(p))))
+}
+
+func validPercentile(p float64) float64 {
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		panic(fmt.Sprintf("percentiles must be between 0 and 100, got %v", p))
 	}
+	return p
+}
 
+// sortedFloats returns the data points of this Measurement in ascending order.  Durations are returned as float64s,
+// as Stats.FloatFor does.
+func (m Measurement) sortedFloats() []float64 {
+	var out []float64
+	switch m.Type {
+	case MeasurementTypeValue:
+		out = append(out, m.Values...)
+	case MeasurementTypeDuration:
+		out = make([]float64, len(m.Durations))
+		for idx, d := range m.Durations {
+			out[idx] = float64(d)
+		}
+	}
+	sort.Float64s(out)
 	return out
 }
+
+// percentile interpolates linearly between the data points in sorted that are closest to the p-th percentile
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p / 100 * float64(len(sorted)-1)
+	lower, upper := int(math.Floor(rank)), int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
@@ -147,6 +147,70 @@ var _ = Describe("Measurement", func() {
 				})
 			})
 		})
+
+		Describe("Computing percentiles", func() {
+			BeforeEach(func() {
+				for i := 100; i >= 0; i-- {
+					e.RecordValue("latency", float64(i))
+				}
+			})
+
+			It("includes P90, P99 and P99.9 in the stats", func() {
+				stats := e.GetStats("latency")
+				Ω(stats.ValueFor(gmeasure.StatP90)).Should(BeNumerically("~", 90.0))
+				Ω(stats.ValueFor(gmeasure.StatP99)).Should(BeNumerically("~", 99.0))
+				Ω(stats.ValueFor(gmeasure.StatP999)).Should(BeNumerically("~", 99.9))
+				Ω(stats.StringFor(gmeasure.StatP999)).Should(Equal("99.900"))
+			})
+
+			It("computes arbitrary percentiles, interpolating between data points", func() {
+				m := e.Get("latency")
+				Ω(m.ValuePercentile(0)).Should(Equal(0.0))
+				Ω(m.ValuePercentile(50)).Should(Equal(e.GetStats("latency").ValueFor(gmeasure.StatMedian)))
+				Ω(m.ValuePercentile(75)).Should(BeNumerically("~", 75.0))
+				Ω(m.ValuePercentile(100)).Should(Equal(100.0))
+
+				e.RecordValue("pair", 1)
+				e.RecordValue("pair", 2)
+				Ω(e.Get("pair").ValuePercentile(25)).Should(Equal(1.25))
+				Ω(gmeasure.Measurement{Type: gmeasure.MeasurementTypeValue}.ValuePercentile(50)).Should(BeZero())
+			})
+
+			It("panics when the percentile is out of range", func() {
+				Ω(func() { e.Get("latency").ValuePercentile(-1) }).Should(PanicWith("percentiles must be between 0 and 100, got -1"))
+				Ω(func() { e.Get("latency").ValuePercentile(100.1) }).Should(Panic())
+			})
+		})
+
+		Describe("Trimming outliers", func() {
+			It("excludes the smallest and largest values from the stats, keeping their annotations in step", func() {
+				m := gmeasure.Measurement{
+					Type:        gmeasure.MeasurementTypeValue,
+					Values:      []float64{50, 1, 2, 3, -50},
+					Annotations: []string{"high", "a", "b", "c", "low"},
+					TrimPercent: 20,
+				}
+				stats := m.Stats()
+				Ω(stats.N).Should(Equal(3))
+				Ω(stats.Trimmed).Should(Equal(2))
+				Ω(stats.ValueFor(gmeasure.StatMin)).Should(Equal(1.0))
+				Ω(stats.AnnotationBundle[gmeasure.StatMin]).Should(Equal("a"))
+				Ω(stats.ValueFor(gmeasure.StatMax)).Should(Equal(3.0))
+				Ω(stats.AnnotationBundle[gmeasure.StatMax]).Should(Equal("c"))
+				Ω(m.Values).Should(HaveLen(5))
+			})
+
+			It("does not trim when the percentage covers less than one data point", func() {
+				m := gmeasure.Measurement{
+					Type:        gmeasure.MeasurementTypeValue,
+					Values:      []float64{50, 1, 2, 3, -50},
+					Annotations: []string{"", "", "", "", ""},
+					TrimPercent: 10,
+				}
+				Ω(m.Stats().N).Should(Equal(5))
+				Ω(m.Stats().Trimmed).Should(BeZero())
+			})
+		})
 	})
 
 	Describe("Duration Measurement", func() {
@@ -252,5 +316,38 @@ var _ = Describe("Measurement", func() {
 				})
 			})
 		})
+
+		Describe("Computing percentiles", func() {
+			BeforeEach(func() {
+				for i := 1; i <= 1000; i++ {
+					e.RecordDuration("latency", time.Duration(i)*time.Millisecond)
+				}
+			})
+
+			It("includes P90, P99 and P99.9 in the stats", func() {
+				stats := e.GetStats("latency")
+				Ω(stats.DurationFor(gmeasure.StatP90)).Should(Equal(900100 * time.Microsecond))
+				Ω(stats.DurationFor(gmeasure.StatP99)).Should(Equal(990010 * time.Microsecond))
+				Ω(stats.DurationFor(gmeasure.StatP999)).Should(Equal(999001 * time.Microsecond))
+			})
+
+			It("can trim outliers from the stats", func() {
+				m := e.Get("latency")
+				m.TrimPercent = 1
+				stats := m.Stats()
+				Ω(stats.N).Should(Equal(980))
+				Ω(stats.Trimmed).Should(Equal(20))
+				Ω(stats.DurationFor(gmeasure.StatMin)).Should(Equal(11 * time.Millisecond))
+				Ω(stats.DurationFor(gmeasure.StatMax)).Should(Equal(990 * time.Millisecond))
+			})
+
+			It("computes arbitrary percentiles, interpolating between data points", func() {
+				m := e.Get("latency")
+				Ω(m.DurationPercentile(0)).Should(Equal(time.Millisecond))
+				Ω(m.DurationPercentile(50)).Should(Equal(e.GetStats("latency").DurationFor(gmeasure.StatMedian)))
+				Ω(m.DurationPercentile(99.99)).Should(Equal(999900100 * time.Nanosecond))
+				Ω(m.DurationPercentile(100)).Should(Equal(time.Second))
+			})
+		})
 	})
 })
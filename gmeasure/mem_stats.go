@@ -0,0 +1,76 @@
+package gmeasure
+
+import (
+	"runtime"
+	"time"
+)
+
+/*
+MemStatsRecorder is the type of the RecordMemStats decorator.
+*/
+type MemStatsRecorder struct{}
+
+/*
+The RecordMemStats decorator asks the methods that run a callback - MeasureDuration, MeasureValue, and the SampleX family - to also capture how
+the Go runtime's memory statistics change while the callback runs.  Each time the callback runs the changes are recorded on four additional Measurements:
+
+- "NAME: allocations" - the number of heap objects allocated (a Value Measurement with Units "allocs")
+- "NAME: bytes" - the number of heap bytes allocated (a Value Measurement with Units "B")
+- "NAME: GC cycles" - the number of completed garbage collection cycles (a Value Measurement with Units "cycles")
+- "NAME: GC pause" - the total time the garbage collector paused the program (a Duration Measurement)
+
+For example:
+
+	e := gmeasure.NewExperiment("My Experiment")
+	e.SampleDuration("parsing", func(_ int) {
+		parse(input)
+	}, gmeasure.SamplingConfig{N: 100}, gmeasure.RecordMemStats)
+	Expect(e.GetStats("parsing: allocations").ValueFor(gmeasure.StatMedian)).To(BeNumerically("<", 10))
+
+The memory statistics are read with runtime.ReadMemStats before and after the callback, outside the measured duration.  They cover the whole process,
+so they include allocations made by other goroutines - including the other workers when sampling with SamplingConfig.NumParallel.
+RecordMemStats is ignored by RecordDuration, RecordValue and RecordNote.
+*/
+var RecordMemStats = MemStatsRecorder{}
+
+type memStatsDelta struct {
+	enabled     bool
+	allocations uint64
+	bytes       uint64
+	gcCycles    uint32
+	gcPause     time.Duration
+}
+
+// measureMemStats reads the runtime's memory statistics, if enabled, and returns a function that reports how they have changed since
+func measureMemStats(enabled bool) func() memStatsDelta {
+	if !enabled {
+		return func() memStatsDelta { return memStatsDelta{} }
+	}
+	before := &runtime.MemStats{}
+	runtime.ReadMemStats(before)
+	return func() memStatsDelta {
+		after := &runtime.MemStats{}
+		runtime.ReadMemStats(after)
+		return memStatsDelta{
+			enabled:     true,
+			allocations: after.Mallocs - before.Mallocs,
+			bytes:       after.TotalAlloc - before.TotalAlloc,
+			gcCycles:    after.NumGC - before.NumGC,
+			gcPause:     time.Duration(after.PauseTotalNs - before.PauseTotalNs),
+		}
+	}
+}
+
+func (e *Experiment) recordMemStats(name string, delta memStatsDelta, decorations extractedDecorations) {
+	if !delta.enabled {
+		return
+	}
+	decorations.units = "allocs"
+	e.recordValue(name+": allocations", float64(delta.allocations), decorations)
+	decorations.units = "B"
+	e.recordValue(name+": bytes", float64(delta.bytes), decorations)
+	decorations.units = "cycles"
+	e.recordValue(name+": GC cycles", float64(delta.gcCycles), decorations)
+	decorations.precisionBundle.Duration = time.Microsecond
+	e.recordDuration(name+": GC pause", delta.gcPause, decorations)
+}
@@ -0,0 +1,81 @@
+package gmeasure_test
+
+import (
+	"runtime"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gmeasure"
+)
+
+var sink [][]byte
+
+var _ = Describe("RecordMemStats", func() {
+	var e *gmeasure.Experiment
+	BeforeEach(func() {
+		e = gmeasure.NewExperiment("Test Experiment")
+		DeferCleanup(func() { sink = nil })
+	})
+
+	allocate := func() {
+		for i := 0; i < 10; i++ {
+			sink = append(sink, make([]byte, 1024))
+		}
+	}
+
+	It("records memory statistics alongside measured durations", func() {
+		e.MeasureDuration("runtime", allocate, gmeasure.RecordMemStats, gmeasure.Annotation("first"))
+
+		allocations := e.Get("runtime: allocations")
+		Ω(allocations.Type).Should(Equal(gmeasure.MeasurementTypeValue))
+		Ω(allocations.Units).Should(Equal("allocs"))
+		Ω(allocations.Values).Should(ConsistOf(BeNumerically(">=", 10)))
+		Ω(allocations.Annotations).Should(Equal([]string{"first"}))
+
+		bytes := e.Get("runtime: bytes")
+		Ω(bytes.Units).Should(Equal("B"))
+		Ω(bytes.Values).Should(ConsistOf(BeNumerically(">=", 10*1024)))
+
+		Ω(e.Get("runtime: GC cycles").Units).Should(Equal("cycles"))
+		Ω(e.Get("runtime: GC cycles").Values).Should(HaveLen(1))
+		Ω(e.Get("runtime: GC pause").Type).Should(Equal(gmeasure.MeasurementTypeDuration))
+		Ω(e.Get("runtime: GC pause").Durations).Should(HaveLen(1))
+	})
+
+	It("records memory statistics for every sample, including garbage collections", func() {
+		e.SampleAnnotatedDuration("runtime", func(idx int) gmeasure.Annotation {
+			allocate()
+			runtime.GC()
+			return gmeasure.Annotation("sampled")
+		}, gmeasure.SamplingConfig{N: 3, Warmup: 2}, gmeasure.RecordMemStats)
+
+		Ω(e.Get("runtime: allocations").Values).Should(HaveLen(3))
+		Ω(e.Get("runtime: allocations").Annotations).Should(Equal([]string{"sampled", "sampled", "sampled"}))
+		Ω(e.Get("runtime: GC cycles").Values).Should(HaveEach(BeNumerically(">=", 1)))
+		Ω(e.Get("runtime: GC pause").Durations).Should(HaveEach(BeNumerically(">", time.Duration(0))))
+	})
+
+	It("records memory statistics alongside values", func() {
+		e.MeasureValue("length", func() float64 {
+			allocate()
+			return 3
+		}, gmeasure.Units("inches"), gmeasure.RecordMemStats)
+		e.SampleValue("length", func(_ int) float64 {
+			allocate()
+			return 4
+		}, gmeasure.SamplingConfig{N: 2}, gmeasure.RecordMemStats)
+
+		Ω(e.Get("length").Units).Should(Equal("inches"))
+		Ω(e.Get("length").Values).Should(Equal([]float64{3, 4, 4}))
+		Ω(e.Get("length: bytes").Values).Should(HaveEach(BeNumerically(">=", 10*1024)))
+	})
+
+	It("records nothing extra without the decorator, or when recording directly", func() {
+		e.MeasureDuration("runtime", allocate)
+		e.RecordDuration("recorded", time.Second, gmeasure.RecordMemStats)
+		e.RecordValue("recorded value", 1, gmeasure.RecordMemStats)
+
+		Ω(e.Measurements).Should(HaveLen(3))
+	})
+})
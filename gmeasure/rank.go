@@ -21,9 +21,15 @@ const (
 	HigherMinIsBetter
 	LowerMaxIsBetter
 	HigherMaxIsBetter
+	LowerP90IsBetter
+	HigherP90IsBetter
+	LowerP99IsBetter
+	HigherP99IsBetter
+	LowerP999IsBetter
+	HigherP999IsBetter
 )
 
-var rcEnumSupport = newEnumSupport(map[uint]string{uint(LowerMeanIsBetter): "Lower Mean is Better", uint(HigherMeanIsBetter): "Higher Mean is Better", uint(LowerMedianIsBetter): "Lower Median is Better", uint(HigherMedianIsBetter): "Higher Median is Better", uint(LowerMinIsBetter): "Lower Mins is Better", uint(HigherMinIsBetter): "Higher Min is Better", uint(LowerMaxIsBetter): "Lower Max is Better", uint(HigherMaxIsBetter): "Higher Max is Better"})
+var rcEnumSupport = newEnumSupport(map[uint]string{uint(LowerMeanIsBetter): "Lower Mean is Better", uint(HigherMeanIsBetter): "Higher Mean is Better", uint(LowerMedianIsBetter): "Lower Median is Better", uint(HigherMedianIsBetter): "Higher Median is Better", uint(LowerMinIsBetter): "Lower Mins is Better", uint(HigherMinIsBetter): "Higher Min is Better", uint(LowerMaxIsBetter): "Lower Max is Better", uint(HigherMaxIsBetter): "Higher Max is Better", uint(LowerP90IsBetter): "Lower P90 is Better", uint(HigherP90IsBetter): "Higher P90 is Better", uint(LowerP99IsBetter): "Lower P99 is Better", uint(HigherP99IsBetter): "Higher P99 is Better", uint(LowerP999IsBetter): "Lower P99.9 is Better", uint(HigherP999IsBetter): "Higher P99.9 is Better"})
 
 func (s RankingCriteria) String() string { return rcEnumSupport.String(uint(s)) }
 func (s *RankingCriteria) UnmarshalJSON(b []byte) error {
@@ -65,6 +71,18 @@ func RankStats(criteria RankingCriteria, stats ...Stats) Ranking {
 			return stats[i].FloatFor(StatMax) < stats[j].FloatFor(StatMax)
 		case HigherMaxIsBetter:
 			return stats[i].FloatFor(StatMax) > stats[j].FloatFor(StatMax)
+		case LowerP90IsBetter:
+			return stats[i].FloatFor(StatP90) < stats[j].FloatFor(StatP90)
+		case HigherP90IsBetter:
+			return stats[i].FloatFor(StatP90) > stats[j].FloatFor(StatP90)
+		case LowerP99IsBetter:
+			return stats[i].FloatFor(StatP99) < stats[j].FloatFor(StatP99)
+		case HigherP99IsBetter:
+			return stats[i].FloatFor(StatP99) > stats[j].FloatFor(StatP99)
+		case LowerP999IsBetter:
+			return stats[i].FloatFor(StatP999) < stats[j].FloatFor(StatP999)
+		case HigherP999IsBetter:
+			return stats[i].FloatFor(StatP999) > stats[j].FloatFor(StatP999)
 		}
 		return false
 	})
@@ -77,6 +95,20 @@ func RankStats(criteria RankingCriteria, stats ...Stats) Ranking {
 	return out
 }
 
+// percentileStat returns the percentile Stat the criteria ranks by, if it ranks by one.  The percentiles are not part of
+// the usual columns of a Ranking's table, so it gets a column of its own.
+func (c RankingCriteria) percentileStat() (Stat, bool) {
+	switch c {
+	case LowerP90IsBetter, HigherP90IsBetter:
+		return StatP90, true
+	case LowerP99IsBetter, HigherP99IsBetter:
+		return StatP99, true
+	case LowerP999IsBetter, HigherP999IsBetter:
+		return StatP999, true
+	}
+	return StatInvalid, false
+}
+
 /*
 Winner returns the Stats with the most optimal rank based on the specified ranking criteria.  For example, if the RankingCriteria is LowerMaxIsBetter then the Stats with the lowest value or duration for StatMax will be returned as the "winner"
 */
@@ -93,11 +125,16 @@ func (c Ranking) report(enableStyling bool) string {
 	}
 	t := table.NewTable()
 	t.TableStyle.EnableTextStyling = enableStyling
-	t.AppendRow(table.R(
+	header := table.R(
 		table.C("Experiment"), table.C("Name"), table.C("N"), table.C("Min"), table.C("Median"), table.C("Mean"), table.C("StdDev"), table.C("Max"),
 		table.Divider("="),
 		"{{bold}}",
-	))
+	)
+	percentileStat, rankedByPercentile := c.Criteria.percentileStat()
+	if rankedByPercentile {
+		header.AppendCell(table.C(percentileStat.String()))
+	}
+	t.AppendRow(header)
 
 	for idx, stats := range c.Stats {
 		name := stats.MeasurementName
@@ -115,6 +152,9 @@ func (c Ranking) report(enableStyling bool) string {
 		t.AppendRow(r)
 		r.AppendCell(table.C(experimentName), table.C(name))
 		r.AppendCell(stats.cells()...)
+		if rankedByPercentile {
+			r.AppendCell(table.C(stats.StringFor(percentileStat)))
+		}
 
 	}
 	out := fmt.Sprintf("Ranking Criteria: %s\n", c.Criteria)
@@ -93,6 +93,56 @@ var _ = Describe("Rank", func() {
 		})
 	})
 
+	Describe("Ranking by percentiles", func() {
+		makeStats := func(name string, p90 float64, p99 float64, p999 float64) gmeasure.Stats {
+			return gmeasure.Stats{
+				Type:            gmeasure.StatsTypeValue,
+				ExperimentName:  "Exp-" + name,
+				MeasurementName: name,
+				N:               100,
+				PrecisionBundle: gmeasure.Precision(2),
+				ValueBundle: map[gmeasure.Stat]float64{
+					gmeasure.StatP90:  p90,
+					gmeasure.StatP99:  p99,
+					gmeasure.StatP999: p999,
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			A = makeStats("A", 1, 2, 3)
+			B = makeStats("B", 2, 3, 1)
+			C = makeStats("C", 3, 1, 2)
+		})
+
+		DescribeTable("ranking by criteria",
+			func(criteria gmeasure.RankingCriteria, expectedOrder func() []gmeasure.Stats) {
+				ranking := gmeasure.RankStats(criteria, A, B, C)
+				Ω(ranking.Stats).Should(Equal(expectedOrder()))
+			},
+			Entry("entry", gmeasure.LowerP90IsBetter, func() []gmeasure.Stats { return []gmeasure.Stats{A, B, C} }),
+			Entry("entry", gmeasure.HigherP90IsBetter, func() []gmeasure.Stats { return []gmeasure.Stats{C, B, A} }),
+			Entry("entry", gmeasure.LowerP99IsBetter, func() []gmeasure.Stats { return []gmeasure.Stats{C, A, B} }),
+			Entry("entry", gmeasure.HigherP99IsBetter, func() []gmeasure.Stats { return []gmeasure.Stats{B, A, C} }),
+			Entry("entry", gmeasure.LowerP999IsBetter, func() []gmeasure.Stats { return []gmeasure.Stats{B, C, A} }),
+			Entry("entry", gmeasure.HigherP999IsBetter, func() []gmeasure.Stats { return []gmeasure.Stats{A, C, B} }),
+		)
+
+		It("adds a column for the percentile to reports", func() {
+			ranking := gmeasure.RankStats(gmeasure.LowerP99IsBetter, A, B)
+			Ω(ranking.String()).Should(Equal(strings.Join([]string{
+				"Ranking Criteria: Lower P99 is Better",
+				"Experiment | Name     | N   | Min  | Median | Mean | StdDev | Max  | P99 ",
+				"=========================================================================",
+				"Exp-A      | A        | 100 | 0.00 | 0.00   | 0.00 | 0.00   | 0.00 | 2.00",
+				"*Winner*   | *Winner* |     |      |        |      |        |      |     ",
+				"-------------------------------------------------------------------------",
+				"Exp-B      | B        | 100 | 0.00 | 0.00   | 0.00 | 0.00   | 0.00 | 3.00",
+				"",
+			}, "\n")))
+		})
+	})
+
 	Describe("Ranking Durations", func() {
 		makeStats := func(name string, min time.Duration, max time.Duration, mean time.Duration, median time.Duration) gmeasure.Stats {
 			return gmeasure.Stats{
@@ -19,9 +19,12 @@ const (
 	StatMean
 	StatMedian
 	StatStdDev
+	StatP90
+	StatP99
+	StatP999
 )
 
-var statEnumSupport = newEnumSupport(map[uint]string{uint(StatInvalid): "INVALID STAT", uint(StatMin): "Min", uint(StatMax): "Max", uint(StatMean): "Mean", uint(StatMedian): "Median", uint(StatStdDev): "StdDev"})
+var statEnumSupport = newEnumSupport(map[uint]string{uint(StatInvalid): "INVALID STAT", uint(StatMin): "Min", uint(StatMax): "Max", uint(StatMean): "Mean", uint(StatMedian): "Median", uint(StatStdDev): "StdDev", uint(StatP90): "P90", uint(StatP99): "P99", uint(StatP999): "P99.9"})
 
 func (s Stat) String() string { return statEnumSupport.String(uint(s)) }
 func (s *Stat) UnmarshalJSON(b []byte) error {
@@ -78,6 +81,9 @@ type Stats struct {
 	// N represents the total number of data points in the Meassurement from which this Stat is derived
 	N int
 
+	// Trimmed is the number of data points excluded from these statistics because the Measurement has a TrimPercent.  They are not included in N
+	Trimmed int
+
 	// If Type is StatTypeValue, ValueBundle will be populated with float64s representing this Stat's statistics
 	ValueBundle map[Stat]float64
 
@@ -397,6 +397,28 @@ func EventuallyWithOffset(offset int, actualOrCtx interface{}, args ...interface
 	return Default.EventuallyWithOffset(offset, actualOrCtx, args...)
 }
 
+/*
+EventuallyAny is like Eventually, but polls several sources and succeeds as soon as any one of them satisfies the matcher.
+Each source can be anything Eventually accepts - a value, a function, or a function that takes a Gomega.  For example, to
+wait for a log line on either of a session's output streams:
+
+	EventuallyAny(session.Out, session.Err).Should(gbytes.Say("listening"))
+
+or for a write to show up on either of two replicas:
+
+	EventuallyAny(primary.Get, replica.Get).WithArguments("key").Should(Equal("value"))
+
+When EventuallyAny times out, the failure message reports the last value polled from every source.  Timeouts, polling
+intervals, contexts, and arguments are configured with the chaining methods, e.g. EventuallyAny(...).WithTimeout(time.Second),
+and apply to every source.
+
+EventuallyAny is a method of WithT too, but not of the Gomega interface.
+*/
+func EventuallyAny(actuals ...interface{}) AsyncAssertion {
+	ensureDefaultGomegaIsConfigured()
+	return internalGomega(Default).EventuallyAny(actuals...)
+}
+
 /*
 Consistently, like Eventually, enables making assertions on asynchronous behavior.
 
@@ -435,6 +457,8 @@ You can send the StopTrying signal by either returning StopTrying("message") as
 
 You can also wrap StopTrying around an error with `StopTrying("message").Wrap(err)` and can attach additional objects via `StopTrying("message").Attach("description", object).  When rendered, the signal will include the wrapped error and any attached objects rendered using Gomega's default formatting.
 
+StopTrying("message").Suggest("action") records an action for whoever reads the failure to take.  It is rendered after the message, along with the number of times the assertion had polled when it was told to stop trying.  To give up after a fixed number of polls instead, use the StopTryingAfterAttempts method of AttemptLimitedAsyncAssertion.
+
 Here are a couple of examples.  This is how you might use StopTrying() as an error to signal that Eventually should stop:
 
 	playerIndex, numPlayers := 0, 11
@@ -471,6 +495,87 @@ PollingSignalError is the error returned by StopTrying() and TryAgainAfter()
 */
 type PollingSignalError = internal.PollingSignalError
 
+/*
+RegisterAsyncTimelineReporter registers a function that is called with a timeline of every Eventually and Consistently
+assertion made with the default Gomega, once the assertion completes.  The timeline records when each poll happened,
+a fingerprint of the polled value, and whether the matcher passed - enough to see how an assertion converged, not just
+how it finally failed.
+
+types.AsyncTimeline can be attached to a Ginkgo report as is:
+
+	RegisterAsyncTimelineReporter(func(timeline types.AsyncTimeline) {
+		AddReportEntry(timeline.AsyncType+" timeline", timeline, ReportEntryVisibilityFailureOrVerbose)
+	})
+
+Pass nil to stop reporting timelines.  Instances of WithT can set their AsyncTimelineReporter field directly.
+*/
+func RegisterAsyncTimelineReporter(reporter func(types.AsyncTimeline)) {
+	internalGomega(Default).AsyncTimelineReporter = reporter
+}
+
+/*
+RegisterFailureArtifactProvider registers a provider that the default Gomega calls whenever an assertion fails.  The
+provider is passed the failure message and location and can collect diagnostics - say, a snapshot of a debug endpoint.
+Descriptions of the artifacts it collects are appended to the failure message.  ghttp.NewEndpointSnapshotter is a
+ready-made provider:
+
+	DeferCleanup(RegisterFailureArtifactProvider(ghttp.NewEndpointSnapshotter(artifactDir, serverURL+"/healthz")))
+
+Providers are called in the order they were registered.  RegisterFailureArtifactProvider returns a function that
+unregisters the provider.  Instances of WithT can append to their FailureArtifactProviders field instead.
+*/
+func RegisterFailureArtifactProvider(provider types.FailureArtifactProvider) (unregister func()) {
+	g := internalGomega(Default)
+	registered := &registeredFailureArtifactProvider{provider}
+	g.FailureArtifactProviders = append(g.FailureArtifactProviders, registered)
+	return func() {
+		providers := []types.FailureArtifactProvider{}
+		for _, p := range g.FailureArtifactProviders {
+			if p != types.FailureArtifactProvider(registered) {
+				providers = append(providers, p)
+			}
+		}
+		g.FailureArtifactProviders = providers
+	}
+}
+
+// registeredFailureArtifactProvider gives each registration an identity, as providers themselves need not be comparable
+type registeredFailureArtifactProvider struct {
+	types.FailureArtifactProvider
+}
+
+/*
+RegisterAsyncActualAdapter registers an adapter that teaches the default Gomega's Eventually and Consistently to poll a
+new kind of actual.  With an adapter for, say, futures registered:
+
+	Eventually(future).Should(Equal("done"))
+
+polls future through the adapter rather than treating it as a value.  See types.AsyncActualAdapter for details.
+
+Adapters are consulted in the order they were registered and the first that adapts an actual is used.
+RegisterAsyncActualAdapter returns a function that unregisters the adapter.  Instances of WithT can append to their
+AsyncActualAdapters field instead.
+*/
+func RegisterAsyncActualAdapter(adapter types.AsyncActualAdapter) (unregister func()) {
+	g := internalGomega(Default)
+	registered := &registeredAsyncActualAdapter{adapter}
+	g.AsyncActualAdapters = append(g.AsyncActualAdapters, registered)
+	return func() {
+		adapters := []types.AsyncActualAdapter{}
+		for _, a := range g.AsyncActualAdapters {
+			if a != types.AsyncActualAdapter(registered) {
+				adapters = append(adapters, a)
+			}
+		}
+		g.AsyncActualAdapters = adapters
+	}
+}
+
+// registeredAsyncActualAdapter gives each registration an identity, as adapters themselves need not be comparable
+type registeredAsyncActualAdapter struct {
+	types.AsyncActualAdapter
+}
+
 // SetDefaultEventuallyTimeout sets the default timeout duration for Eventually. Eventually will repeatedly poll your condition until it succeeds, or until this timeout elapses.
 func SetDefaultEventuallyTimeout(t time.Duration) {
 	Default.SetDefaultEventuallyTimeout(t)
@@ -491,6 +596,90 @@ func SetDefaultConsistentlyPollingInterval(t time.Duration) {
 	Default.SetDefaultConsistentlyPollingInterval(t)
 }
 
+/*
+RegisterDuration registers a duration under a name with the default Gomega.  Asynchronous assertions can then refer
+to it with WithTimeoutNamed, so that a large suite can manage its timeout policies in one place:
+
+	var _ = BeforeSuite(func() {
+		RegisterDuration("cluster-converge", 5*time.Minute)
+	})
+
+	Eventually(cluster.Ready).(NamedTimeoutAsyncAssertion).WithTimeoutNamed("cluster-converge").Should(BeTrue())
+
+Failure messages name the policy that was exceeded.  Registering a name again replaces its duration.  Instances of
+WithT have a RegisterDuration method of their own.
+*/
+func RegisterDuration(name string, duration time.Duration) {
+	internalGomega(Default).RegisterDuration(name, duration)
+}
+
+/*
+SerializeAsyncAssertions makes the asynchronous assertions of the default Gomega take turns polling.  Eventually and
+Consistently assertions that start while serialization is on join a single scheduler that grants attempts round-robin,
+in the order the assertions started.  When several assertions run concurrently - say, from goroutines in one spec - their
+polls interleave the same way on every run, which helps when debugging ordering-sensitive race conditions:
+
+	SerializeAsyncAssertions(true)
+	DeferCleanup(SerializeAsyncAssertions, false)
+
+An assertion waits for its turn before each poll, so a slow poller holds up every other assertion.  Timeouts are still
+measured in wall-clock time and include time spent waiting for a turn.  Pass false to stop serializing assertions that
+start from then on.  Instances of WithT have a SerializeAsyncAssertions method of their own.
+*/
+func SerializeAsyncAssertions(serialize bool) {
+	internalGomega(Default).SerializeAsyncAssertions(serialize)
+}
+
+/*
+SetCompatibilityMode lets a suite written against an earlier version of Gomega opt out of behaviors that have since
+changed, so that it can adopt them one at a time:
+
+	var _ = BeforeSuite(func() {
+		SetCompatibilityMode(types.CompatibilityMode{LegacyFailureMessages: true})
+	})
+
+The mode applies to the default Gomega.  Instances of WithT have a Compatibility field of their own, so a suite can,
+say, try out new behaviors in a handful of tests first.  See types.CompatibilityMode for the behaviors that can be opted
+out of.
+*/
+func SetCompatibilityMode(mode types.CompatibilityMode) {
+	internalGomega(Default).Compatibility = mode
+}
+
+// SetTimeoutScale multiplies every Eventually timeout and Consistently duration - defaults and those passed to
+// Eventually, Consistently, WithTimeout, and Within alike - by scale.  Use it to give slow environments, like a loaded
+// CI machine, more time without editing every assertion.  Polling intervals and context deadlines are not scaled.
+//
+// Failure messages of asynchronous assertions report the scale.  Pass 1 to stop scaling.  The scale can also be set
+// with the GOMEGA_TIMEOUT_SCALE environment variable, which has a lower precedence than SetTimeoutScale.
+func SetTimeoutScale(scale float64) {
+	internalGomega(Default).SetTimeoutScale(scale)
+}
+
+/*
+WithinScope gives a stretch of a long, procedural spec a name.  Failures of the assertions made through the Gomega passed
+to f are prefixed with the path of scopes they occurred in, and the time spent in each so far:
+
+	WithinScope("bootstrapping cluster", func(g *WithT) {
+		g.Expect(cluster.Create()).To(Succeed())
+		g.WithinScope("installing CNI", func(g *WithT) {
+			g.Eventually(cni.Ready).Should(BeTrue())
+		})
+	})
+
+fails with a message that starts:
+
+	Scope: bootstrapping cluster (12.301s) → installing CNI (10.001s)
+
+The Gomega passed to f is derived from the default Gomega, as it is configured when WithinScope is called, and reports
+failures through its fail handler.  Assertions made through the default Gomega itself are not annotated.  Scopes nest
+by calling WithinScope on the Gomega passed to f, and instances of WithT have a WithinScope method of their own.
+*/
+func WithinScope(description string, f func(g *WithT)) {
+	ensureDefaultGomegaIsConfigured()
+	internalGomega(Default).WithinScope(description, f)
+}
+
 // AsyncAssertion is returned by Eventually and Consistently and polls the actual value passed into Eventually against
 // the matcher passed to the Should and ShouldNot methods.
 //
@@ -511,6 +700,17 @@ type AsyncAssertion = types.AsyncAssertion
 // GomegaAsyncAssertion is deprecated in favor of AsyncAssertion, which does not stutter.
 type GomegaAsyncAssertion = types.AsyncAssertion
 
+// The AsyncAssertions returned by Eventually and Consistently implement these optional interfaces too.  Type-assert
+// to them to reach the methods they add to AsyncAssertion:
+//
+//	Eventually(cluster.Ready).(NamedTimeoutAsyncAssertion).WithTimeoutNamed("cluster-converge").Should(BeTrue())
+type (
+	NamedTimeoutAsyncAssertion     = types.NamedTimeoutAsyncAssertion
+	SerializedAccessAsyncAssertion = types.SerializedAccessAsyncAssertion
+	AttemptLimitedAsyncAssertion   = types.AttemptLimitedAsyncAssertion
+	AsyncAssertionInspector        = types.AsyncAssertionInspector
+)
+
 // Assertion is returned by Ω and Expect and compares the actual value to the matcher
 // passed to the Should/ShouldNot and To/ToNot/NotTo methods.
 //
@@ -0,0 +1,223 @@
+/*
+Package gotel provides matchers over the spans recorded by OpenTelemetry's trace test exporters, so that specs for
+tracing instrumentation get failure messages that list the spans that were actually recorded:
+
+	exporter := tracetest.NewInMemoryExporter()
+	...
+	Expect(exporter.GetSpans()).To(gotel.HaveSpan("GET /users",
+		gotel.WithAttributes(attribute.String("http.method", "GET"), attribute.Int("http.status_code", 200)),
+		gotel.WithStatus(codes.Ok),
+		gotel.WithParent(gotel.HaveSpan("handle request")),
+	))
+
+The matchers accept a slice of spans - sdktrace.ReadOnlySpans, tracetest.SpanStubs or gotel.Spans - a single span, or
+a recorder with a GetSpans or Ended method, such as a *tracetest.InMemoryExporter or a *tracetest.SpanRecorder.
+Recorders are read every time the matcher runs, so Eventually polls them.
+
+gotel does not depend on the OpenTelemetry libraries: spans are read through the methods of sdktrace.ReadOnlySpan or
+the fields of tracetest.SpanStub.
+*/
+package gotel
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+// Span is the part of a recorded span the gotel matchers look at
+type Span struct {
+	Name              string
+	TraceID           string
+	SpanID            string
+	ParentSpanID      string // empty for root spans
+	Kind              string
+	Attributes        map[string]interface{}
+	StatusCode        string
+	StatusDescription string
+}
+
+// String describes the span on a single line
+func (s Span) String() string {
+	description := fmt.Sprintf("%q", s.Name)
+	if s.Kind != "" {
+		description += " [" + s.Kind + "]"
+	}
+	if s.StatusCode != "" {
+		description += " status=" + s.StatusCode
+		if s.StatusDescription != "" {
+			description += fmt.Sprintf(" %q", s.StatusDescription)
+		}
+	}
+	if len(s.Attributes) > 0 {
+		description += " " + renderAttributes(s.Attributes)
+	}
+	return description
+}
+
+func renderAttributes(attributes map[string]interface{}) string {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	rendered := make([]string, len(keys))
+	for i, key := range keys {
+		rendered[i] = fmt.Sprintf("%s=%s", key, renderAttribute(attributes[key]))
+	}
+	return "{" + strings.Join(rendered, ", ") + "}"
+}
+
+func renderAttribute(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// toSpans returns the spans actual holds or records
+func toSpans(matcherName string, actual interface{}) ([]Span, error) {
+	if actual == nil {
+		return nil, fmt.Errorf("%s matcher expects spans or a span recorder.  Got nil", matcherName)
+	}
+	value := reflect.ValueOf(actual)
+	for _, recorderMethod := range []string{"GetSpans", "Ended"} {
+		method := value.MethodByName(recorderMethod)
+		if method.IsValid() && method.Type().NumIn() == 0 && method.Type().NumOut() == 1 && method.Type().Out(0).Kind() == reflect.Slice {
+			value = method.Call(nil)[0]
+			break
+		}
+	}
+
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		spans := make([]Span, value.Len())
+		for i := range spans {
+			span, ok := toSpan(value.Index(i))
+			if !ok {
+				return nil, fmt.Errorf("%s matcher expects spans, but element %d is not one.  Got:\n%s", matcherName, i, format.Object(value.Index(i).Interface(), 1))
+			}
+			spans[i] = span
+		}
+		return spans, nil
+	}
+
+	span, ok := toSpan(value)
+	if !ok {
+		return nil, fmt.Errorf("%s matcher expects spans or a span recorder.  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+	return []Span{span}, nil
+}
+
+// toSpan reads a gotel.Span, the methods of an sdktrace.ReadOnlySpan, or the fields of a tracetest.SpanStub
+func toSpan(value reflect.Value) (Span, bool) {
+	for value.Kind() == reflect.Interface && !value.IsNil() {
+		value = value.Elem()
+	}
+	if span, ok := value.Interface().(Span); ok {
+		return span, true
+	}
+	if span, ok := value.Interface().(*Span); ok && span != nil {
+		return *span, true
+	}
+
+	read := func(name string) (reflect.Value, bool) {
+		if method := value.MethodByName(name); method.IsValid() && method.Type().NumIn() == 0 && method.Type().NumOut() == 1 {
+			return method.Call(nil)[0], true
+		}
+		fields := value
+		if fields.Kind() == reflect.Ptr && !fields.IsNil() {
+			fields = fields.Elem()
+		}
+		if fields.Kind() == reflect.Struct {
+			if field := fields.FieldByName(name); field.IsValid() {
+				return field, true
+			}
+		}
+		return reflect.Value{}, false
+	}
+
+	name, ok := read("Name")
+	if !ok || name.Kind() != reflect.String {
+		return Span{}, false
+	}
+	span := Span{Name: name.String(), Attributes: map[string]interface{}{}}
+	if spanContext, ok := read("SpanContext"); ok {
+		span.TraceID, span.SpanID, _ = readSpanContext(spanContext)
+	}
+	if parent, ok := read("Parent"); ok {
+		if _, parentID, valid := readSpanContext(parent); valid {
+			span.ParentSpanID = parentID
+		}
+	}
+	if kind, ok := read("SpanKind"); ok {
+		span.Kind = stringify(kind)
+	}
+	if attributes, ok := read("Attributes"); ok && attributes.Kind() == reflect.Slice {
+		for i := 0; i < attributes.Len(); i++ {
+			if key, value, ok := readAttribute(attributes.Index(i)); ok {
+				span.Attributes[key] = value
+			}
+		}
+	}
+	if status, ok := read("Status"); ok && status.Kind() == reflect.Struct {
+		if code := status.FieldByName("Code"); code.IsValid() {
+			span.StatusCode = stringify(code)
+		}
+		if description := status.FieldByName("Description"); description.IsValid() && description.Kind() == reflect.String {
+			span.StatusDescription = description.String()
+		}
+	}
+	return span, true
+}
+
+// readSpanContext returns the trace and span IDs of a trace.SpanContext, and whether it is valid
+func readSpanContext(spanContext reflect.Value) (traceID string, spanID string, valid bool) {
+	call := func(name string) (reflect.Value, bool) {
+		method := spanContext.MethodByName(name)
+		if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+			return reflect.Value{}, false
+		}
+		return method.Call(nil)[0], true
+	}
+	if id, ok := call("TraceID"); ok {
+		traceID = stringify(id)
+	}
+	if id, ok := call("SpanID"); ok {
+		spanID = stringify(id)
+	}
+	if isValid, ok := call("IsValid"); ok && isValid.Kind() == reflect.Bool {
+		return traceID, spanID, isValid.Bool()
+	}
+	return traceID, spanID, spanID != ""
+}
+
+// readAttribute reads an attribute.KeyValue, whose Value is unwrapped with AsInterface
+func readAttribute(keyValue reflect.Value) (string, interface{}, bool) {
+	if keyValue.Kind() == reflect.Ptr && !keyValue.IsNil() {
+		keyValue = keyValue.Elem()
+	}
+	if keyValue.Kind() != reflect.Struct {
+		return "", nil, false
+	}
+	key, value := keyValue.FieldByName("Key"), keyValue.FieldByName("Value")
+	if !key.IsValid() || key.Kind() != reflect.String || !value.IsValid() {
+		return "", nil, false
+	}
+	if asInterface := value.MethodByName("AsInterface"); asInterface.IsValid() && asInterface.Type().NumIn() == 0 && asInterface.Type().NumOut() == 1 {
+		return key.String(), asInterface.Call(nil)[0].Interface(), true
+	}
+	if value.CanInterface() {
+		return key.String(), value.Interface(), true
+	}
+	return "", nil, false
+}
+
+func stringify(value reflect.Value) string {
+	if stringer, ok := value.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return fmt.Sprintf("%v", value.Interface())
+}
@@ -0,0 +1,147 @@
+package gotel_test
+
+import (
+	"fmt"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gotel"
+)
+
+// The types below mirror the parts of OpenTelemetry's trace API and SDK that gotel reads by reflection
+
+type code uint32
+
+const (
+	codeUnset code = iota
+	codeError
+	codeOk
+)
+
+func (c code) String() string { return [...]string{"Unset", "Error", "Ok"}[c] }
+
+type status struct {
+	Code        code
+	Description string
+}
+
+type attributeValue struct{ value interface{} }
+
+func (v attributeValue) AsInterface() interface{} { return v.value }
+
+type keyValue struct {
+	Key   string
+	Value attributeValue
+}
+
+func attr(key string, value interface{}) keyValue { return keyValue{key, attributeValue{value}} }
+
+type spanID [2]byte
+
+func (id spanID) String() string { return fmt.Sprintf("%02x%02x", id[0], id[1]) }
+func (id spanID) IsValid() bool  { return id != spanID{} }
+
+type spanContext struct{ traceID, spanID spanID }
+
+func (c spanContext) TraceID() spanID { return c.traceID }
+func (c spanContext) SpanID() spanID  { return c.spanID }
+func (c spanContext) IsValid() bool   { return c.spanID.IsValid() }
+
+type spanKind int
+
+func (k spanKind) String() string { return [...]string{"unspecified", "internal", "server"}[k] }
+
+// readOnlySpan is read through its methods, like sdktrace.ReadOnlySpan
+type readOnlySpan struct{ stub spanStub }
+
+func (s readOnlySpan) Name() string             { return s.stub.Name }
+func (s readOnlySpan) SpanContext() spanContext { return s.stub.SpanContext }
+func (s readOnlySpan) Parent() spanContext      { return s.stub.Parent }
+func (s readOnlySpan) SpanKind() spanKind       { return s.stub.SpanKind }
+func (s readOnlySpan) Attributes() []keyValue   { return s.stub.Attributes }
+func (s readOnlySpan) Status() status           { return s.stub.Status }
+
+// spanStub is read through its fields, like tracetest.SpanStub
+type spanStub struct {
+	Name        string
+	SpanContext spanContext
+	Parent      spanContext
+	SpanKind    spanKind
+	Attributes  []keyValue
+	Status      status
+}
+
+type exporter struct {
+	lock  sync.Mutex
+	spans []spanStub
+}
+
+func (e *exporter) GetSpans() []spanStub {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return append([]spanStub{}, e.spans...)
+}
+
+func (e *exporter) record(span spanStub) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, span)
+}
+
+var trace = spanID{0xab, 0xcd}
+
+var requestSpan = spanStub{
+	Name:        "GET /users",
+	SpanContext: spanContext{trace, spanID{0, 1}},
+	SpanKind:    2,
+	Attributes:  []keyValue{attr("http.method", "GET"), attr("http.status_code", int64(200))},
+	Status:      status{Code: codeOk},
+}
+
+var querySpan = spanStub{
+	Name:        "SELECT users",
+	SpanContext: spanContext{trace, spanID{0, 2}},
+	Parent:      spanContext{trace, spanID{0, 1}},
+	SpanKind:    1,
+	Attributes:  []keyValue{attr("db.rows", int64(3))},
+	Status:      status{Code: codeError, Description: "timeout"},
+}
+
+var _ = Describe("Reading spans", func() {
+	It("should read spans through their methods or their fields", func() {
+		Expect([]readOnlySpan{{requestSpan}, {querySpan}}).Should(gotel.HaveSpan("SELECT users", gotel.WithParent("GET /users")))
+		Expect([]spanStub{requestSpan, querySpan}).Should(gotel.HaveSpan("SELECT users", gotel.WithParent("GET /users")))
+		Expect([]*spanStub{&requestSpan, &querySpan}).Should(gotel.HaveSpan("SELECT users", gotel.WithParent("GET /users")))
+		Expect([]interface{}{readOnlySpan{requestSpan}, querySpan}).Should(gotel.HaveSpan("SELECT users", gotel.WithParent("GET /users")))
+	})
+
+	It("should match a single span", func() {
+		Expect(readOnlySpan{requestSpan}).Should(gotel.HaveSpan("GET /users"))
+		Expect(gotel.Span{Name: "GET /users"}).Should(gotel.HaveSpan("GET /users"))
+	})
+
+	It("should read recorders every time it matches", func() {
+		e := &exporter{}
+		go func() {
+			defer GinkgoRecover()
+			e.record(requestSpan)
+		}()
+		Eventually(e).Should(gotel.HaveSpan("GET /users"))
+	})
+
+	It("should describe spans on a single line", func() {
+		matcher := gotel.HaveSpan("missing")
+		Expect(matcher.Match([]spanStub{querySpan})).Should(BeFalse())
+		Expect(matcher.FailureMessage(nil)).Should(HaveSuffix(`"SELECT users" [internal] status=Error "timeout" {db.rows=3}`))
+	})
+
+	It("should error when actual holds no spans", func() {
+		_, err := gotel.HaveSpan("GET /users").Match(nil)
+		Expect(err).Should(MatchError("HaveSpan matcher expects spans or a span recorder.  Got nil"))
+		_, err = gotel.HaveSpan("GET /users").Match(17)
+		Expect(err).Should(MatchError(ContainSubstring("HaveSpan matcher expects spans or a span recorder.  Got:")))
+		_, err = gotel.HaveSpan("GET /users").Match([]interface{}{requestSpan, 17})
+		Expect(err).Should(MatchError(ContainSubstring("HaveSpan matcher expects spans, but element 1 is not one.  Got:")))
+	})
+})
@@ -0,0 +1,289 @@
+package gotel
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+// SpanOption narrows down the spans HaveSpan looks for
+type SpanOption func(*HaveSpanMatcher)
+
+/*
+WithAttributes restricts HaveSpan to spans with the given attributes.  Spans may have other attributes too.  Attributes
+can be passed as attribute.KeyValues or as a map[string]interface{} whose values are values or matchers:
+
+	gotel.WithAttributes(attribute.String("http.method", "GET"), attribute.Int("http.status_code", 200))
+	gotel.WithAttributes(map[string]interface{}{"http.route": HavePrefix("/users"), "http.status_code": 200})
+
+Integers are compared as int64s and floats as float64s, as OpenTelemetry stores them.
+*/
+func WithAttributes(attributes ...interface{}) SpanOption {
+	return func(matcher *HaveSpanMatcher) {
+		if matcher.Attributes == nil {
+			matcher.Attributes = map[string]interface{}{}
+		}
+		for _, attribute := range attributes {
+			if m, ok := attribute.(map[string]interface{}); ok {
+				for key, value := range m {
+					matcher.Attributes[key] = value
+				}
+				continue
+			}
+			key, value, ok := readAttribute(reflect.ValueOf(attribute))
+			if !ok {
+				matcher.optionErr = fmt.Errorf("WithAttributes expects attribute.KeyValues or a map[string]interface{}.  Got:\n%s", format.Object(attribute, 1))
+				return
+			}
+			matcher.Attributes[key] = value
+		}
+	}
+}
+
+// WithStatus restricts HaveSpan to spans whose status code is expected.  expected can be a codes.Code, its name - "Unset",
+// "Error" or "Ok" - or a matcher, which is passed the name.
+func WithStatus(expected interface{}) SpanOption {
+	return func(matcher *HaveSpanMatcher) {
+		matcher.Status = expected
+	}
+}
+
+// WithParent restricts HaveSpan to spans whose parent was recorded too and satisfies expected.  expected can be the name
+// of the parent or a matcher, which is passed the parent as a gotel.Span - so HaveSpan can itself match the parent.
+func WithParent(expected interface{}) SpanOption {
+	return func(matcher *HaveSpanMatcher) {
+		matcher.Parent = expected
+	}
+}
+
+/*
+HaveSpan succeeds if actual has a span called name.  actual can be a slice of spans, a single span, or a span recorder.
+WithAttributes, WithStatus and WithParent narrow down the spans that count:
+
+	Expect(exporter.GetSpans()).To(gotel.HaveSpan("SELECT users", gotel.WithParent("GET /users")))
+	Eventually(exporter).Should(gotel.HaveSpan("process job", gotel.WithStatus(codes.Error)))
+
+When no span matches the failure message lists every recorded span, along with the reason each span called name did not
+match.
+*/
+func HaveSpan(name string, options ...SpanOption) types.GomegaMatcher {
+	matcher := &HaveSpanMatcher{
+		Name: name,
+	}
+	for _, option := range options {
+		option(matcher)
+	}
+	return matcher
+}
+
+type HaveSpanMatcher struct {
+	Name       string
+	Attributes map[string]interface{}
+	Status     interface{}
+	Parent     interface{}
+
+	// state
+	optionErr error
+	spans     []Span
+	reasons   []string
+	matched   *Span
+}
+
+func (matcher *HaveSpanMatcher) Match(actual interface{}) (success bool, err error) {
+	if matcher.optionErr != nil {
+		return false, matcher.optionErr
+	}
+	matcher.spans, err = toSpans("HaveSpan", actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.reasons, matcher.matched = make([]string, len(matcher.spans)), nil
+	for i, span := range matcher.spans {
+		matcher.reasons[i], err = matcher.mismatch(span)
+		if err != nil {
+			return false, err
+		}
+		if matcher.reasons[i] == "" {
+			matcher.matched = &matcher.spans[i]
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// mismatch returns why span is not the span the matcher is looking for, or "" if it is
+func (matcher *HaveSpanMatcher) mismatch(span Span) (string, error) {
+	if span.Name != matcher.Name {
+		return "its name is different", nil
+	}
+
+	keys := make([]string, 0, len(matcher.Attributes))
+	for key := range matcher.Attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		actualValue, ok := span.Attributes[key]
+		if !ok {
+			return fmt.Sprintf("it has no %s attribute", key), nil
+		}
+		expected := matcher.Attributes[key]
+		expectedMatcher, isMatcher := expected.(types.GomegaMatcher)
+		if !isMatcher {
+			expectedMatcher = &matchers.EqualMatcher{Expected: normalizeAttribute(expected)}
+		}
+		success, err := expectedMatcher.Match(actualValue)
+		if err != nil {
+			return "", fmt.Errorf("HaveSpan matcher could not match the %s attribute of span %q:\n%s", key, span.Name, format.IndentString(err.Error(), 1))
+		}
+		if !success {
+			if isMatcher {
+				return fmt.Sprintf("its %s attribute, %s, does not satisfy the matcher", key, renderAttribute(actualValue)), nil
+			}
+			return fmt.Sprintf("its %s attribute is %s, not %s", key, renderAttribute(actualValue), renderAttribute(expected)), nil
+		}
+	}
+
+	if matcher.Status != nil {
+		success, err := matchStatus(matcher.Status, span.StatusCode)
+		if err != nil {
+			return "", fmt.Errorf("HaveSpan matcher could not match the status of span %q:\n%s", span.Name, format.IndentString(err.Error(), 1))
+		}
+		if !success {
+			return fmt.Sprintf("its status is %s", span.StatusCode), nil
+		}
+	}
+
+	if matcher.Parent != nil {
+		if span.ParentSpanID == "" {
+			return "it has no parent", nil
+		}
+		parent, ok := matcher.findParent(span)
+		if !ok {
+			return "its parent was not recorded", nil
+		}
+		if name, isName := matcher.Parent.(string); isName {
+			if parent.Name != name {
+				return fmt.Sprintf("its parent is %q", parent.Name), nil
+			}
+		} else if parentMatcher, isMatcher := matcher.Parent.(types.GomegaMatcher); isMatcher {
+			success, err := parentMatcher.Match(parent)
+			if err != nil {
+				return "", fmt.Errorf("HaveSpan matcher could not match the parent of span %q:\n%s", span.Name, format.IndentString(err.Error(), 1))
+			}
+			if !success {
+				return fmt.Sprintf("its parent %q does not satisfy the matcher", parent.Name), nil
+			}
+		} else {
+			return "", fmt.Errorf("WithParent expects the name of a span or a matcher.  Got:\n%s", format.Object(matcher.Parent, 1))
+		}
+	}
+	return "", nil
+}
+
+func (matcher *HaveSpanMatcher) findParent(span Span) (Span, bool) {
+	for _, candidate := range matcher.spans {
+		if candidate.SpanID == span.ParentSpanID && (span.TraceID == "" || candidate.TraceID == span.TraceID) {
+			return candidate, true
+		}
+	}
+	return Span{}, false
+}
+
+func matchStatus(expected interface{}, code string) (bool, error) {
+	switch e := expected.(type) {
+	case types.GomegaMatcher:
+		return e.Match(code)
+	case string:
+		return e == code, nil
+	case fmt.Stringer:
+		return e.String() == code, nil
+	}
+	return false, fmt.Errorf("WithStatus expects a codes.Code, the name of one, or a matcher.  Got:\n%s", format.Object(expected, 1))
+}
+
+// normalizeAttribute converts numbers to the int64s and float64s OpenTelemetry stores them as
+func normalizeAttribute(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return int64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	}
+	return value
+}
+
+func (matcher *HaveSpanMatcher) FailureMessage(actual interface{}) (message string) {
+	if len(matcher.spans) == 0 {
+		return fmt.Sprintf("Expected a span %s, but no spans were recorded", matcher.description())
+	}
+	lines := make([]string, len(matcher.spans))
+	for i, span := range matcher.spans {
+		lines[i] = format.Indent + span.String()
+		if span.Name == matcher.Name {
+			lines[i] += ": " + matcher.reasons[i]
+		}
+	}
+	return fmt.Sprintf("Expected a span %s, but none of the %d recorded spans match:\n%s", matcher.description(), len(matcher.spans), strings.Join(lines, "\n"))
+}
+
+func (matcher *HaveSpanMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected no span %s, but found:\n%s%s", matcher.description(), format.Indent, matcher.matched)
+}
+
+// MatchMayChangeInTheFuture lets Eventually and Consistently keep reading span recorders, which record spans over time
+func (matcher *HaveSpanMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	if actual == nil {
+		return false
+	}
+	value := reflect.ValueOf(actual)
+	return value.MethodByName("GetSpans").IsValid() || value.MethodByName("Ended").IsValid()
+}
+
+func (matcher *HaveSpanMatcher) description() string {
+	description := fmt.Sprintf("named %q", matcher.Name)
+	if len(matcher.Attributes) > 0 {
+		rendered := map[string]interface{}{}
+		for key, value := range matcher.Attributes {
+			if _, isMatcher := value.(types.GomegaMatcher); isMatcher {
+				rendered[key] = rawAttribute(fmt.Sprintf("<%T>", value))
+			} else {
+				rendered[key] = value
+			}
+		}
+		description += " with attributes " + renderAttributes(rendered)
+	}
+	switch status := matcher.Status.(type) {
+	case nil:
+	case types.GomegaMatcher:
+		description += " with a status that satisfies the matcher"
+	case fmt.Stringer:
+		description += " with status " + status.String()
+	default:
+		description += fmt.Sprintf(" with status %v", status)
+	}
+	switch parent := matcher.Parent.(type) {
+	case nil:
+	case string:
+		description += fmt.Sprintf(" with parent %q", parent)
+	default:
+		description += " with a parent that satisfies the matcher"
+	}
+	return description
+}
+
+// rawAttribute is rendered as is, rather than quoted like a string attribute
+type rawAttribute string
+
+func (r rawAttribute) String() string {
+	return string(r)
+}
@@ -0,0 +1,110 @@
+package gotel_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gotel"
+)
+
+var _ = Describe("HaveSpan", func() {
+	var spans []spanStub
+
+	BeforeEach(func() {
+		spans = []spanStub{requestSpan, querySpan}
+	})
+
+	It("should find spans by name", func() {
+		Expect(spans).Should(gotel.HaveSpan("GET /users"))
+		Expect(spans).ShouldNot(gotel.HaveSpan("POST /users"))
+		Expect([]spanStub{}).ShouldNot(gotel.HaveSpan("GET /users"))
+	})
+
+	It("should narrow spans down by their attributes", func() {
+		Expect(spans).Should(gotel.HaveSpan("GET /users", gotel.WithAttributes(attr("http.method", "GET"))))
+		Expect(spans).Should(gotel.HaveSpan("GET /users", gotel.WithAttributes(attr("http.method", "GET"), attr("http.status_code", int64(200)))))
+		Expect(spans).Should(gotel.HaveSpan("GET /users", gotel.WithAttributes(map[string]interface{}{"http.status_code": 200})))
+		Expect(spans).Should(gotel.HaveSpan("GET /users", gotel.WithAttributes(map[string]interface{}{"http.status_code": BeNumerically("<", 300)})))
+		Expect(spans).ShouldNot(gotel.HaveSpan("GET /users", gotel.WithAttributes(attr("http.method", "POST"))))
+		Expect(spans).ShouldNot(gotel.HaveSpan("GET /users", gotel.WithAttributes(attr("http.route", "/users"))))
+		Expect(spans).ShouldNot(gotel.HaveSpan("SELECT users", gotel.WithAttributes(attr("http.method", "GET"))))
+	})
+
+	It("should narrow spans down by their status", func() {
+		Expect(spans).Should(gotel.HaveSpan("GET /users", gotel.WithStatus(codeOk)))
+		Expect(spans).Should(gotel.HaveSpan("SELECT users", gotel.WithStatus("Error")))
+		Expect(spans).Should(gotel.HaveSpan("SELECT users", gotel.WithStatus(Not(Equal("Ok")))))
+		Expect(spans).ShouldNot(gotel.HaveSpan("GET /users", gotel.WithStatus(codeError)))
+	})
+
+	It("should narrow spans down by their parent", func() {
+		Expect(spans).Should(gotel.HaveSpan("SELECT users", gotel.WithParent("GET /users")))
+		Expect(spans).Should(gotel.HaveSpan("SELECT users", gotel.WithParent(gotel.HaveSpan("GET /users", gotel.WithStatus(codeOk)))))
+		Expect(spans).Should(gotel.HaveSpan("SELECT users", gotel.WithParent(HaveField("Kind", "server"))))
+		Expect(spans).ShouldNot(gotel.HaveSpan("SELECT users", gotel.WithParent("POST /users")))
+		Expect(spans).ShouldNot(gotel.HaveSpan("GET /users", gotel.WithParent("GET /users")))
+		Expect(spans[1:]).ShouldNot(gotel.HaveSpan("SELECT users", gotel.WithParent("GET /users")))
+	})
+
+	It("should only look for parents in the same trace", func() {
+		otherTrace := requestSpan
+		otherTrace.SpanContext.traceID = spanID{0xff, 0xff}
+		Expect([]spanStub{otherTrace, querySpan}).ShouldNot(gotel.HaveSpan("SELECT users", gotel.WithParent("GET /users")))
+	})
+
+	It("should error when its options are invalid", func() {
+		_, err := gotel.HaveSpan("GET /users", gotel.WithAttributes("http.method")).Match(spans)
+		Expect(err).Should(MatchError(ContainSubstring("WithAttributes expects attribute.KeyValues or a map[string]interface{}.  Got:")))
+		_, err = gotel.HaveSpan("GET /users", gotel.WithStatus(1)).Match(spans)
+		Expect(err).Should(MatchError(ContainSubstring("WithStatus expects a codes.Code, the name of one, or a matcher.  Got:")))
+		_, err = gotel.HaveSpan("SELECT users", gotel.WithParent(1)).Match(spans)
+		Expect(err).Should(MatchError(ContainSubstring("WithParent expects the name of a span or a matcher.  Got:")))
+		_, err = gotel.HaveSpan("GET /users", gotel.WithAttributes(map[string]interface{}{"http.method": BeNumerically(">", 1)})).Match(spans)
+		Expect(err).Should(MatchError(ContainSubstring(`HaveSpan matcher could not match the http.method attribute of span "GET /users":`)))
+	})
+
+	Describe("failure messages", func() {
+		It("should list the recorded spans, and why those with the name did not match", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(append(spans, requestSpan)).Should(gotel.HaveSpan("GET /users",
+					gotel.WithAttributes(attr("http.status_code", 500), map[string]interface{}{"http.method": HavePrefix("G")}),
+				))
+			})
+			Expect(failures).Should(ConsistOf(`Expected a span named "GET /users" with attributes {http.method=<*matchers.HavePrefixMatcher>, http.status_code=500}, but none of the 3 recorded spans match:
+    "GET /users" [server] status=Ok {http.method="GET", http.status_code=200}: its http.status_code attribute is 200, not 500
+    "SELECT users" [internal] status=Error "timeout" {db.rows=3}
+    "GET /users" [server] status=Ok {http.method="GET", http.status_code=200}: its http.status_code attribute is 200, not 500`))
+		})
+
+		It("should explain status and parent mismatches", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(spans).Should(gotel.HaveSpan("SELECT users", gotel.WithStatus(codeOk)))
+				Expect(spans).Should(gotel.HaveSpan("SELECT users", gotel.WithParent(gotel.HaveSpan("GET /users", gotel.WithStatus(codeError)))))
+				Expect(spans).Should(gotel.HaveSpan("GET /users", gotel.WithParent("root")))
+				Expect(spans).Should(gotel.HaveSpan("GET /users", gotel.WithAttributes(map[string]interface{}{"http.status_code": BeNumerically(">", 400)})))
+			})
+			Expect(failures).Should(HaveLen(4))
+			Expect(failures[0]).Should(HavePrefix(`Expected a span named "SELECT users" with status Ok, but none of the 2 recorded spans match:`))
+			Expect(failures[0]).Should(HaveSuffix(`"SELECT users" [internal] status=Error "timeout" {db.rows=3}: its status is Error`))
+			Expect(failures[1]).Should(HavePrefix(`Expected a span named "SELECT users" with a parent that satisfies the matcher, but`))
+			Expect(failures[1]).Should(HaveSuffix(`: its parent "GET /users" does not satisfy the matcher`))
+			Expect(failures[2]).Should(ContainSubstring(`Expected a span named "GET /users" with parent "root", but`))
+			Expect(failures[2]).Should(ContainSubstring(`: it has no parent`))
+			Expect(failures[3]).Should(ContainSubstring(`: its http.status_code attribute, 200, does not satisfy the matcher`))
+		})
+
+		It("should say so when no spans were recorded", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect([]spanStub{}).Should(gotel.HaveSpan("GET /users", gotel.WithStatus(Equal("Ok"))))
+			})
+			Expect(failures).Should(ConsistOf(`Expected a span named "GET /users" with a status that satisfies the matcher, but no spans were recorded`))
+		})
+
+		It("should show the span that matched when negated", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(spans).ShouldNot(gotel.HaveSpan("SELECT users", gotel.WithParent("GET /users")))
+			})
+			Expect(failures).Should(ConsistOf(`Expected no span named "SELECT users" with parent "GET /users", but found:
+    "SELECT users" [internal] status=Error "timeout" {db.rows=3}`))
+		})
+	})
+})
@@ -0,0 +1,198 @@
+/*
+Package gports reserves free TCP ports for tests.
+
+Grabbing a free port by listening on port 0 and closing the listener is racy: another process - say, a parallel Ginkgo
+node running the same suite - can be handed the very same port before your server gets around to binding it.  gports
+avoids this by recording each reservation as a locked file in a directory shared by every process on the machine.  A
+port that has been reserved will not be handed out again until it is released, or the process that reserved it exits:
+
+	port, err := gports.Reserve()
+	Expect(err).ShouldNot(HaveOccurred())
+	DeferCleanup(gports.Release, port)
+
+	session, err := gexec.Start(exec.Command(serverPath, "--listen", gports.Address(port)), GinkgoWriter, GinkgoWriter)
+	Expect(err).ShouldNot(HaveOccurred())
+	Eventually(session).Should(gports.BeListeningOn(port))
+*/
+package gports
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+/*
+ReservationDir is the directory in which gports records reservations.  Every process that should not be handed
+the same port must use the same directory.  It defaults to a directory in os.TempDir().
+*/
+var ReservationDir = filepath.Join(os.TempDir(), "gomega-gports")
+
+const maxReservationAttempts = 100
+
+var (
+	lock     sync.Mutex
+	reserved = map[int]*os.File{}
+)
+
+/*
+Reserve returns a free TCP port on the loopback interface that no other process using gports has reserved.
+You should call Release (or ReleaseAll) when you no longer need the port.
+*/
+func Reserve() (int, error) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := os.MkdirAll(ReservationDir, 0777); err != nil {
+		return 0, fmt.Errorf("failed to create gports reservation directory: %w", err)
+	}
+
+	for attempt := 0; attempt < maxReservationAttempts; attempt++ {
+		port, err := freePort()
+		if err != nil {
+			return 0, err
+		}
+		if reserved[port] != nil {
+			continue
+		}
+		f, err := createReservation(port)
+		if err != nil {
+			return 0, err
+		}
+		if f != nil {
+			reserved[port] = f
+			return port, nil
+		}
+	}
+
+	return 0, fmt.Errorf("failed to reserve a free port after %d attempts", maxReservationAttempts)
+}
+
+/*
+ReserveN reserves n ports.  If any reservation fails, the ports that were reserved are released and an error is returned.
+*/
+func ReserveN(n int) ([]int, error) {
+	ports := []int{}
+	for i := 0; i < n; i++ {
+		port, err := Reserve()
+		if err != nil {
+			for _, port := range ports {
+				Release(port)
+			}
+			return nil, err
+		}
+		ports = append(ports, port)
+	}
+	return ports, nil
+}
+
+/*
+Release releases a port reserved by this process.  Releasing a port that this process did not reserve is an error.
+*/
+func Release(port int) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	f := reserved[port]
+	if f == nil {
+		return fmt.Errorf("port %d was not reserved by this process", port)
+	}
+	delete(reserved, port)
+	return removeReservation(port, f)
+}
+
+/*
+ReleaseAll releases every port reserved by this process.  It's a good idea to call it in an AfterSuite:
+
+	AfterSuite(func() {
+		Expect(gports.ReleaseAll()).Should(Succeed())
+	})
+*/
+func ReleaseAll() error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	var firstErr error
+	for port, f := range reserved {
+		delete(reserved, port)
+		if err := removeReservation(port, f); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+/*
+Address returns the loopback address for port, in a form suitable for passing to a server started with gexec:
+
+	gports.Address(8080) // "127.0.0.1:8080"
+*/
+func Address(port int) string {
+	return net.JoinHostPort("127.0.0.1", strconv.Itoa(port))
+}
+
+/*
+Listen listens on the loopback address for port.  The returned listener can be handed to a ghttp server:
+
+	listener, err := gports.Listen(port)
+	Expect(err).ShouldNot(HaveOccurred())
+	server := ghttp.NewUnstartedServer()
+	server.HTTPTestServer.Listener.Close()
+	server.HTTPTestServer.Listener = listener
+	server.Start()
+*/
+func Listen(port int) (net.Listener, error) {
+	return net.Listen("tcp", Address(port))
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free port: %w", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+func reservationPath(port int) string {
+	return filepath.Join(ReservationDir, fmt.Sprintf("%d.lock", port))
+}
+
+// createReservation locks the reservation file for port, recording the process that holds it.  It returns nil if
+// another process holds a reservation.
+func createReservation(port int) (*os.File, error) {
+	path := reservationPath(port)
+	f, ok, err := lockReservation(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve port %d: %w", port, err)
+	}
+	if !ok {
+		return nil, nil
+	}
+	if err := recordOwner(f); err != nil {
+		unlockReservation(path, f)
+		return nil, fmt.Errorf("failed to reserve port %d: %w", port, err)
+	}
+	return f, nil
+}
+
+// recordOwner replaces the contents of a reservation file - which may have been left behind by a process that exited
+// without releasing its reservation - with the pid of this process
+func recordOwner(f *os.File) error {
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	_, err := f.WriteAt([]byte(fmt.Sprintf("%d\n", os.Getpid())), 0)
+	return err
+}
+
+func removeReservation(port int, f *os.File) error {
+	err := unlockReservation(reservationPath(port), f)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to release port %d: %w", port, err)
+	}
+	return nil
+}
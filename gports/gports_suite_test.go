@@ -0,0 +1,13 @@
+package gports_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGports(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gports Suite")
+}
@@ -0,0 +1,107 @@
+package gports_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	"github.com/onsi/gomega/gports"
+)
+
+var _ = Describe("Reserving ports", func() {
+	BeforeEach(func() {
+		originalDir := gports.ReservationDir
+		gports.ReservationDir = GinkgoT().TempDir()
+		DeferCleanup(func() {
+			Expect(gports.ReleaseAll()).Should(Succeed())
+			gports.ReservationDir = originalDir
+		})
+	})
+
+	lockFile := func(port int) string {
+		return filepath.Join(gports.ReservationDir, strconv.Itoa(port)+".lock")
+	}
+
+	It("should reserve a free port and record the reservation", func() {
+		port, err := gports.Reserve()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(port).Should(BeNumerically(">", 0))
+		Expect(lockFile(port)).Should(BeAnExistingFile())
+
+		listener, err := gports.Listen(port)
+		Expect(err).ShouldNot(HaveOccurred())
+		listener.Close()
+	})
+
+	It("should never hand out the same port twice", func() {
+		ports, err := gports.ReserveN(20)
+		Expect(err).ShouldNot(HaveOccurred())
+		seen := map[int]bool{}
+		for _, port := range ports {
+			Expect(seen).ShouldNot(HaveKey(port))
+			seen[port] = true
+		}
+	})
+
+	It("should remove the reservation on release", func() {
+		port, err := gports.Reserve()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(gports.Release(port)).Should(Succeed())
+		Expect(lockFile(port)).ShouldNot(BeAnExistingFile())
+		Expect(gports.Release(port)).Should(MatchError(ContainSubstring("was not reserved by this process")))
+	})
+
+	It("should release every reservation on ReleaseAll", func() {
+		ports, err := gports.ReserveN(3)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(gports.ReleaseAll()).Should(Succeed())
+		for _, port := range ports {
+			Expect(lockFile(port)).ShouldNot(BeAnExistingFile())
+		}
+	})
+
+	It("should not delete reservations held by other processes", func() {
+		Expect(os.WriteFile(lockFile(1), []byte("12345\n"), 0666)).Should(Succeed())
+		Expect(gports.ReleaseAll()).Should(Succeed())
+		Expect(gports.Release(1)).ShouldNot(Succeed())
+		Expect(lockFile(1)).Should(BeAnExistingFile())
+	})
+
+	It("should format loopback addresses", func() {
+		Expect(gports.Address(8080)).Should(Equal("127.0.0.1:8080"))
+	})
+
+	It("should hand a listener to a ghttp server", func() {
+		port, err := gports.Reserve()
+		Expect(err).ShouldNot(HaveOccurred())
+
+		listener, err := gports.Listen(port)
+		Expect(err).ShouldNot(HaveOccurred())
+		server := ghttp.NewUnstartedServer()
+		server.HTTPTestServer.Listener.Close()
+		server.HTTPTestServer.Listener = listener
+		server.Start()
+		defer server.Close()
+		server.AppendHandlers(ghttp.RespondWith(http.StatusTeapot, ""))
+
+		Expect(server.Addr()).Should(Equal(gports.Address(port)))
+		resp, err := http.Get("http://" + gports.Address(port))
+		Expect(err).ShouldNot(HaveOccurred())
+		resp.Body.Close()
+		Expect(resp.StatusCode).Should(Equal(http.StatusTeapot))
+	})
+
+	It("should not be stopped by reservation files left behind by processes that exited", func() {
+		port, err := gports.Reserve()
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(gports.Release(port)).Should(Succeed())
+		Expect(os.WriteFile(lockFile(port), []byte("12345\n"), 0666)).Should(Succeed())
+
+		_, err = gports.ReserveN(5)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+})
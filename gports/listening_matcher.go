@@ -0,0 +1,72 @@
+package gports
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/onsi/gomega/format"
+)
+
+/*
+DialTimeout bounds each connection attempt made by the BeListeningOn matcher.
+*/
+var DialTimeout = 100 * time.Millisecond
+
+/*
+BeListeningOn succeeds if a TCP connection can be established to the loopback address for port.  It is meant to be
+used with Eventually to wait for a server to become ready:
+
+	Eventually(session).Should(gports.BeListeningOn(port))
+
+The actual value is not inspected except to abort early: if it is a gexec.Session (or any other gexec.Exiter) that has
+exited, the server will never start listening and Eventually gives up.  You can pass nil when there is no process to watch:
+
+	Eventually(nil).Should(gports.BeListeningOn(port))
+*/
+func BeListeningOn(port int) *listeningMatcher {
+	return &listeningMatcher{
+		port: port,
+	}
+}
+
+type listeningMatcher struct {
+	port    int
+	dialErr error
+}
+
+type exiter interface {
+	ExitCode() int
+}
+
+func (m *listeningMatcher) Match(actual interface{}) (success bool, err error) {
+	conn, err := net.DialTimeout("tcp", Address(m.port), DialTimeout)
+	m.dialErr = err
+	if err != nil {
+		return false, nil
+	}
+	conn.Close()
+	return true, nil
+}
+
+func (m *listeningMatcher) FailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Expected something to be listening on %s", Address(m.port))
+	if m.dialErr != nil {
+		message += fmt.Sprintf(", but dialing failed with:\n%s", format.IndentString(m.dialErr.Error(), 1))
+	}
+	if e, ok := actual.(exiter); ok && e.ExitCode() != -1 {
+		message += fmt.Sprintf("\nThe process exited with code %d", e.ExitCode())
+	}
+	return message
+}
+
+func (m *listeningMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected nothing to be listening on %s, but a connection was established", Address(m.port))
+}
+
+func (m *listeningMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	if e, ok := actual.(exiter); ok {
+		return e.ExitCode() == -1
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+//go:build !windows
+// +build !windows
+
+package gports_test
+
+import (
+	"net"
+	"os/exec"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/gports"
+)
+
+var _ = Describe("BeListeningOn", func() {
+	var port int
+
+	BeforeEach(func() {
+		var err error
+		port, err = gports.Reserve()
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(gports.Release, port)
+	})
+
+	It("should succeed once something is listening on the port", func() {
+		Expect(nil).ShouldNot(gports.BeListeningOn(port))
+
+		listeners := make(chan net.Listener, 1)
+		go func() {
+			defer GinkgoRecover()
+			time.Sleep(50 * time.Millisecond)
+			listener, err := net.Listen("tcp", gports.Address(port))
+			Expect(err).ShouldNot(HaveOccurred())
+			listeners <- listener
+		}()
+
+		Eventually(nil).Should(gports.BeListeningOn(port))
+		var listener net.Listener
+		Eventually(listeners).Should(Receive(&listener))
+		DeferCleanup(listener.Close)
+	})
+
+	It("should report the dial error", func() {
+		m := gports.BeListeningOn(port)
+		Expect(m.Match(nil)).Should(BeFalse())
+		Expect(m.FailureMessage(nil)).Should(HavePrefix("Expected something to be listening on " + gports.Address(port) + ", but dialing failed with:\n"))
+	})
+
+	It("should abort Eventually once the session has exited", func() {
+		session, err := gexec.Start(exec.Command("sh", "-c", "exit 3"), GinkgoWriter, GinkgoWriter)
+		Expect(err).ShouldNot(HaveOccurred())
+		session.Wait()
+
+		t := time.Now()
+		failures := InterceptGomegaFailures(func() {
+			Eventually(session, "10s").Should(gports.BeListeningOn(port))
+		})
+		Expect(time.Since(t)).Should(BeNumerically("<", time.Second))
+		Expect(failures).Should(HaveLen(1))
+		Expect(failures[0]).Should(ContainSubstring("The process exited with code 3"))
+	})
+})
@@ -0,0 +1,54 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package gports
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// lockReservation opens the reservation file at path and takes an exclusive lock on it.  It returns false if another
+// process holds the lock.  The lock lasts until the file is closed or the process exits, however it exits, so the
+// reservations of crashed processes are reclaimed straight away.
+func lockReservation(path string) (*os.File, bool, error) {
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0666)
+		if err != nil {
+			return nil, false, err
+		}
+		err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			f.Close()
+			return nil, false, nil
+		}
+		if err != nil {
+			f.Close()
+			return nil, false, err
+		}
+
+		// the reservation may have been released - and its file removed - between opening and locking the file, in
+		// which case the lock is on a file no other process will look at
+		locked, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, false, err
+		}
+		current, err := os.Stat(path)
+		if err == nil && os.SameFile(locked, current) {
+			return f, true, nil
+		}
+		f.Close()
+		if err != nil && !os.IsNotExist(err) {
+			return nil, false, err
+		}
+	}
+}
+
+// unlockReservation removes the reservation file at path while its lock is still held, so that no other process can
+// lock it in between, and then releases the lock
+func unlockReservation(path string, f *os.File) error {
+	err := os.Remove(path)
+	f.Close()
+	return err
+}
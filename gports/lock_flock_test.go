@@ -0,0 +1,39 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package gports_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gfs"
+	"github.com/onsi/gomega/gports"
+)
+
+var _ = Describe("Reservation locks", func() {
+	BeforeEach(func() {
+		originalDir := gports.ReservationDir
+		gports.ReservationDir = GinkgoT().TempDir()
+		DeferCleanup(func() {
+			Expect(gports.ReleaseAll()).Should(Succeed())
+			gports.ReservationDir = originalDir
+		})
+	})
+
+	It("should hold an exclusive lock on the reservation file, naming the process, until the port is released", func() {
+		port, err := gports.Reserve()
+		Expect(err).ShouldNot(HaveOccurred())
+		path := filepath.Join(gports.ReservationDir, strconv.Itoa(port)+".lock")
+		Expect(path).Should(gfs.BeExclusivelyLocked())
+		contents, err := os.ReadFile(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(strings.TrimSpace(string(contents))).Should(Equal(strconv.Itoa(os.Getpid())))
+
+		Expect(gports.Release(port)).Should(Succeed())
+		Expect(path).ShouldNot(BeAnExistingFile())
+	})
+})
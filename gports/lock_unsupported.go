@@ -0,0 +1,26 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package gports
+
+import (
+	"os"
+)
+
+// lockReservation creates the reservation file at path, which must not exist.  It returns false if it does.  Without
+// file locks the reservation is the file itself, so the reservations of crashed processes are never reclaimed.
+func lockReservation(path string) (*os.File, bool, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0666)
+	if os.IsExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return f, true, nil
+}
+
+// unlockReservation closes the reservation file at path before removing it, as some platforms can't remove open files
+func unlockReservation(path string, f *os.File) error {
+	f.Close()
+	return os.Remove(path)
+}
@@ -0,0 +1,44 @@
+package gprof
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/types"
+)
+
+/*
+AllocateLessThan succeeds if actual, a func(), allocates less than bytes per run on average.  The function is run
+DefaultAllocationRuns times, after a warm-up run; pass Runs to change that, and RecordIn to record the measurements in
+a gmeasure.Experiment:
+
+	Expect(func() { codec.Encode(message) }).To(gprof.AllocateLessThan(512))
+	Expect(func() { cache.Get(key) }).To(gprof.AllocateLessThan(1, gprof.Runs(1000)))
+*/
+func AllocateLessThan(bytes int64, options ...AllocationOption) types.GomegaMatcher {
+	return &AllocateLessThanMatcher{
+		Bytes:       bytes,
+		measurement: newAllocationMeasurement(options),
+	}
+}
+
+type AllocateLessThanMatcher struct {
+	Bytes int64
+
+	// state
+	measurement allocationMeasurement
+}
+
+func (matcher *AllocateLessThanMatcher) Match(actual interface{}) (success bool, err error) {
+	if err := matcher.measurement.measure("AllocateLessThan", actual); err != nil {
+		return false, err
+	}
+	return matcher.measurement.bytes < float64(matcher.Bytes), nil
+}
+
+func (matcher *AllocateLessThanMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the function to allocate less than %s per run, but it made %s", formatBytes(matcher.Bytes), matcher.measurement.describe())
+}
+
+func (matcher *AllocateLessThanMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the function to allocate at least %s per run, but it made %s", formatBytes(matcher.Bytes), matcher.measurement.describe())
+}
@@ -0,0 +1,25 @@
+package gprof_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gprof"
+)
+
+var _ = Describe("AllocateLessThan", func() {
+	It("succeeds when the function allocates less than the threshold per run", func() {
+		Expect(func() {}).To(AllocateLessThan(1))
+		Expect(allocateKiB).To(AllocateLessThan(4096))
+		Expect(allocateKiB).NotTo(AllocateLessThan(1024))
+	})
+
+	It("reports the allocations per run on failure", func() {
+		m := AllocateLessThan(1024, Runs(10))
+		Expect(m.Match(allocateKiB)).To(BeFalse())
+		Expect(m.FailureMessage(allocateKiB)).To(MatchRegexp(`^Expected the function to allocate less than 1\.0 KiB \(1024 B\) per run, but it made \d+\.\d\d allocations and \d+\.\d KiB \(\d+ B\) per run, on average over 10 runs$`))
+
+		m = AllocateLessThan(1, Runs(10))
+		Expect(m.Match(func() {})).To(BeTrue())
+		Expect(m.NegatedFailureMessage(func() {})).To(HavePrefix("Expected the function to allocate at least 1 B per run, but it made "))
+	})
+})
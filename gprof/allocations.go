@@ -0,0 +1,91 @@
+package gprof
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/gmeasure"
+)
+
+// DefaultAllocationRuns is the number of times the allocation matchers run a function to measure its allocations
+const DefaultAllocationRuns = 100
+
+// AllocationOption configures how AllocateLessThan and HaveAllocationsFewerThan measure allocations
+type AllocationOption func(*allocationMeasurement)
+
+// Runs sets the number of times the function is run.  Allocations are averaged over the runs.
+func Runs(n int) AllocationOption {
+	return func(m *allocationMeasurement) {
+		m.Runs = n
+	}
+}
+
+/*
+RecordIn records the allocations measured by the matcher in experiment, so that they show up in its report next to
+your other measurements.  The allocations per run are recorded as "<name>: allocations", and the bytes allocated per
+run as "<name>: bytes":
+
+	experiment := gmeasure.NewExperiment("parser")
+	AddReportEntry(experiment.Name, experiment)
+	Expect(parse).To(gprof.HaveAllocationsFewerThan(10, gprof.RecordIn(experiment, "parse")))
+*/
+func RecordIn(experiment *gmeasure.Experiment, name string) AllocationOption {
+	return func(m *allocationMeasurement) {
+		m.Experiment, m.Name = experiment, name
+	}
+}
+
+// allocationMeasurement measures the average allocations of a function, in the manner of testing.AllocsPerRun
+type allocationMeasurement struct {
+	Runs       int
+	Experiment *gmeasure.Experiment
+	Name       string
+
+	// state
+	allocs float64
+	bytes  float64
+}
+
+func newAllocationMeasurement(options []AllocationOption) allocationMeasurement {
+	m := allocationMeasurement{Runs: DefaultAllocationRuns}
+	for _, option := range options {
+		option(&m)
+	}
+	return m
+}
+
+// measure runs actual, which must be a func(), once to warm it up and then Runs times, and averages the allocations
+// and bytes allocated over those runs.  Like testing.AllocsPerRun it sets GOMAXPROCS to 1 while it measures, so that
+// other goroutines do not allocate concurrently.
+func (m *allocationMeasurement) measure(matcherName string, actual interface{}) error {
+	f, ok := actual.(func())
+	if !ok || f == nil {
+		return fmt.Errorf("%s matcher expects a func().  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+	if m.Runs < 1 {
+		return fmt.Errorf("%s matcher must run the function at least once, but was asked to run it %d times", matcherName, m.Runs)
+	}
+
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+	f()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	for i := 0; i < m.Runs; i++ {
+		f()
+	}
+	runtime.ReadMemStats(&after)
+
+	m.allocs = float64(after.Mallocs-before.Mallocs) / float64(m.Runs)
+	m.bytes = float64(after.TotalAlloc-before.TotalAlloc) / float64(m.Runs)
+	if m.Experiment != nil {
+		m.Experiment.RecordValue(m.Name+": allocations", m.allocs, gmeasure.Units("allocs"), gmeasure.Precision(2))
+		m.Experiment.RecordValue(m.Name+": bytes", m.bytes, gmeasure.Units("B"), gmeasure.Precision(0))
+	}
+	return nil
+}
+
+func (m *allocationMeasurement) describe() string {
+	return fmt.Sprintf("%.2f allocations and %s per run, on average over %d runs", m.allocs, formatBytes(int64(m.bytes)), m.Runs)
+}
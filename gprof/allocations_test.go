@@ -0,0 +1,50 @@
+package gprof_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gmeasure"
+	. "github.com/onsi/gomega/gprof"
+)
+
+// sink keeps allocations on the heap
+var sink []byte
+
+func allocateKiB() {
+	sink = make([]byte, 1024)
+}
+
+var _ = Describe("Measuring allocations", func() {
+	It("runs the function once to warm it up, and then the requested number of times", func() {
+		runs := 0
+		Expect(func() { runs++ }).To(HaveAllocationsFewerThan(1, Runs(10)))
+		Expect(runs).To(Equal(11))
+
+		runs = 0
+		Expect(func() { runs++ }).To(AllocateLessThan(1))
+		Expect(runs).To(Equal(DefaultAllocationRuns + 1))
+	})
+
+	It("records the allocations and bytes per run in an experiment", func() {
+		experiment := gmeasure.NewExperiment("allocations")
+		Expect(allocateKiB).To(HaveAllocationsFewerThan(10, RecordIn(experiment, "allocateKiB")))
+		Expect(allocateKiB).To(AllocateLessThan(4096, RecordIn(experiment, "allocateKiB")))
+
+		allocs := experiment.Get("allocateKiB: allocations")
+		Expect(allocs.Units).To(Equal("allocs"))
+		Expect(allocs.Values).To(HaveLen(2))
+		Expect(allocs.Values[0]).To(BeNumerically("~", 1, 0.5))
+		bytes := experiment.Get("allocateKiB: bytes")
+		Expect(bytes.Units).To(Equal("B"))
+		Expect(bytes.Values[0]).To(BeNumerically(">=", 1024))
+	})
+
+	It("errors when actual is not a func(), or the number of runs is invalid", func() {
+		_, err := HaveAllocationsFewerThan(1).Match(func() error { return nil })
+		Expect(err).To(MatchError(ContainSubstring("HaveAllocationsFewerThan matcher expects a func().  Got:")))
+		_, err = AllocateLessThan(1).Match((func())(nil))
+		Expect(err).To(MatchError(ContainSubstring("AllocateLessThan matcher expects a func().  Got:")))
+		_, err = AllocateLessThan(1, Runs(0)).Match(func() {})
+		Expect(err).To(MatchError("AllocateLessThan matcher must run the function at least once, but was asked to run it 0 times"))
+	})
+})
@@ -0,0 +1,170 @@
+/*
+Package gprof provides Gomega matchers over runtime/pprof profiles, so that memory-regression specs can assert
+directly on what a heap profile contains:
+
+	Expect(gprof.HeapProfile()).To(gprof.HaveHeapAllocBelow(64 << 20))
+	Expect(gprof.HeapProfile()).To(gprof.HaveNoFrameMatching(`mypkg\.\(\*Cache\)\.`, 1 << 20))
+
+The matchers accept a *profile.Profile, the raw bytes of a profile (gzipped or not), an io.Reader from which a
+profile can be read, or the path of a profile file - for example one written with "go test -memprofile".
+
+AllocateLessThan and HaveAllocationsFewerThan measure the allocations a function makes instead, as
+testing.AllocsPerRun does, so that specs can guard against allocation regressions:
+
+	Expect(func() { codec.Encode(message) }).To(gprof.HaveAllocationsFewerThan(3))
+
+Heap profiles are sampled: the runtime records roughly one allocation per runtime.MemProfileRate bytes and scales
+the samples up to estimate the total.  Leave some headroom in your thresholds, or lower runtime.MemProfileRate in
+specs that need precision.
+*/
+package gprof
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
+
+	"github.com/google/pprof/profile"
+	"github.com/onsi/gomega/format"
+)
+
+// inUseSpace is the sample type heap profiles use to report the bytes allocated and not yet freed
+const inUseSpace = "inuse_space"
+
+/*
+HeapProfile runs a garbage collection and returns a heap profile of the live runtime.  The garbage collection
+ensures that the profile reflects the current state of the heap and not the state as of the last collection.
+
+Use it directly with Expect, or pass it to Eventually to wait for memory to be released:
+
+	Eventually(gprof.HeapProfile).Should(gprof.HaveHeapAllocBelow(64 << 20))
+*/
+func HeapProfile() (*profile.Profile, error) {
+	runtime.GC()
+	buffer := &bytes.Buffer{}
+	if err := pprof.Lookup("heap").WriteTo(buffer, 0); err != nil {
+		return nil, err
+	}
+	return profile.Parse(buffer)
+}
+
+// toProfile loads the profile the matcher named matcherName was given as actual
+func toProfile(matcherName string, actual interface{}) (*profile.Profile, error) {
+	var p *profile.Profile
+	var err error
+	switch source := actual.(type) {
+	case *profile.Profile:
+		if source == nil {
+			return nil, fmt.Errorf("%s matcher expects a non-nil profile", matcherName)
+		}
+		return source, nil
+	case []byte:
+		p, err = profile.ParseData(source)
+	case io.Reader:
+		p, err = profile.Parse(source)
+	case string:
+		var data []byte
+		data, err = os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("%s matcher could not read the profile at %s:\n%s", matcherName, source, format.IndentString(err.Error(), 1))
+		}
+		p, err = profile.ParseData(data)
+	default:
+		return nil, fmt.Errorf("%s matcher expects a *profile.Profile, []byte, io.Reader, or path to a profile.  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s matcher could not parse the profile:\n%s", matcherName, format.IndentString(err.Error(), 1))
+	}
+	return p, nil
+}
+
+// inUseSpaceIndex returns the index of the in-use bytes in the values of p's samples
+func inUseSpaceIndex(matcherName string, p *profile.Profile) (int, error) {
+	sampleTypes := []string{}
+	for i, sampleType := range p.SampleType {
+		if sampleType.Type == inUseSpace {
+			return i, nil
+		}
+		sampleTypes = append(sampleTypes, sampleType.Type+"/"+sampleType.Unit)
+	}
+	return 0, fmt.Errorf("%s matcher expects a heap profile, but the profile has sample types %v", matcherName, sampleTypes)
+}
+
+// functionBytes is the number of in-use bytes attributed to a function
+type functionBytes struct {
+	function string
+	bytes    int64
+}
+
+// inUseByFunction totals, for each function accepted by include, the in-use bytes of the samples whose stack
+// contains it.  A sample counts once towards each function, however many times the function appears in its stack.
+// The totals are sorted largest first.
+func inUseByFunction(p *profile.Profile, index int, include func(function string) bool) []functionBytes {
+	totals := map[string]int64{}
+	for _, sample := range p.Sample {
+		seen := map[string]bool{}
+		for _, location := range sample.Location {
+			for _, line := range location.Line {
+				if line.Function == nil || seen[line.Function.Name] || !include(line.Function.Name) {
+					continue
+				}
+				seen[line.Function.Name] = true
+				totals[line.Function.Name] += sample.Value[index]
+			}
+		}
+	}
+	return sortFunctionBytes(totals)
+}
+
+// inUseByAllocationSite totals the in-use bytes allocated directly by each function, sorted largest first
+func inUseByAllocationSite(p *profile.Profile, index int) []functionBytes {
+	totals := map[string]int64{}
+	for _, sample := range p.Sample {
+		if len(sample.Location) == 0 || len(sample.Location[0].Line) == 0 || sample.Location[0].Line[0].Function == nil {
+			continue
+		}
+		totals[sample.Location[0].Line[0].Function.Name] += sample.Value[index]
+	}
+	return sortFunctionBytes(totals)
+}
+
+func sortFunctionBytes(totals map[string]int64) []functionBytes {
+	out := []functionBytes{}
+	for function, total := range totals {
+		out = append(out, functionBytes{function, total})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].bytes != out[j].bytes {
+			return out[i].bytes > out[j].bytes
+		}
+		return out[i].function < out[j].function
+	})
+	return out
+}
+
+func formatFunctionBytes(totals []functionBytes) string {
+	lines := []string{}
+	for _, total := range totals {
+		lines = append(lines, fmt.Sprintf("%s  %s", formatBytes(total.bytes), total.function))
+	}
+	return format.IndentString(strings.Join(lines, "\n"), 1)
+}
+
+// formatBytes renders a byte count in binary units, followed by the exact count
+func formatBytes(count int64) string {
+	const unit = 1024
+	if count < unit && count > -unit {
+		return fmt.Sprintf("%d B", count)
+	}
+	value, prefix := float64(count)/unit, 0
+	for value >= unit || value <= -unit {
+		value /= unit
+		prefix++
+	}
+	return fmt.Sprintf("%.1f %ciB (%d B)", value, "KMGTPE"[prefix], count)
+}
@@ -0,0 +1,48 @@
+package gprof_test
+
+import (
+	"testing"
+
+	"github.com/google/pprof/profile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGprof(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gprof Suite")
+}
+
+// heapSample describes a sample of a synthetic heap profile: its stack, leaf first, and its in-use bytes
+type heapSample struct {
+	stack []string
+	bytes int64
+}
+
+// syntheticHeapProfile builds a heap profile out of samples
+func syntheticHeapProfile(samples ...heapSample) *profile.Profile {
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "alloc_space", Unit: "bytes"},
+			{Type: "inuse_space", Unit: "bytes"},
+		},
+		PeriodType: &profile.ValueType{Type: "space", Unit: "bytes"},
+	}
+	functions := map[string]*profile.Function{}
+	for _, sample := range samples {
+		locations := []*profile.Location{}
+		for _, name := range sample.stack {
+			function, ok := functions[name]
+			if !ok {
+				function = &profile.Function{ID: uint64(len(functions) + 1), Name: name}
+				functions[name] = function
+				p.Function = append(p.Function, function)
+			}
+			location := &profile.Location{ID: uint64(len(p.Location) + 1), Line: []profile.Line{{Function: function}}}
+			p.Location = append(p.Location, location)
+			locations = append(locations, location)
+		}
+		p.Sample = append(p.Sample, &profile.Sample{Location: locations, Value: []int64{sample.bytes * 2, sample.bytes}})
+	}
+	return p
+}
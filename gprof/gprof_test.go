@@ -0,0 +1,83 @@
+package gprof_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/google/pprof/profile"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gprof"
+)
+
+var _ = Describe("Profile sources", func() {
+	var p *profile.Profile
+
+	BeforeEach(func() {
+		p = syntheticHeapProfile(heapSample{[]string{"main.alloc", "main.main"}, 1024})
+	})
+
+	It("accepts a *profile.Profile", func() {
+		Expect(p).To(HaveHeapAllocBelow(1025))
+		Expect(p).NotTo(HaveHeapAllocBelow(1024))
+	})
+
+	It("accepts the raw bytes of a profile, gzipped or not", func() {
+		compressed := &bytes.Buffer{}
+		Expect(p.Write(compressed)).To(Succeed())
+		Expect(compressed.Bytes()).To(HaveHeapAllocBelow(1025))
+
+		uncompressed := &bytes.Buffer{}
+		Expect(p.WriteUncompressed(uncompressed)).To(Succeed())
+		Expect(uncompressed.Bytes()).To(HaveHeapAllocBelow(1025))
+	})
+
+	It("accepts an io.Reader", func() {
+		buffer := &bytes.Buffer{}
+		Expect(p.Write(buffer)).To(Succeed())
+		Expect(buffer).To(HaveHeapAllocBelow(1025))
+	})
+
+	It("accepts the path to a profile file", func() {
+		buffer := &bytes.Buffer{}
+		Expect(p.Write(buffer)).To(Succeed())
+		path := filepath.Join(GinkgoT().TempDir(), "mem.prof")
+		Expect(os.WriteFile(path, buffer.Bytes(), 0644)).To(Succeed())
+		Expect(path).To(HaveHeapAllocBelow(1025))
+	})
+
+	It("errors for anything else", func() {
+		success, err := HaveHeapAllocBelow(1).Match(17)
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("HaveHeapAllocBelow matcher expects a *profile.Profile, []byte, io.Reader, or path to a profile.  Got:\n    <int>: 17")))
+
+		success, err = HaveHeapAllocBelow(1).Match((*profile.Profile)(nil))
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError("HaveHeapAllocBelow matcher expects a non-nil profile"))
+	})
+
+	It("errors when the profile can't be read or parsed", func() {
+		_, err := HaveHeapAllocBelow(1).Match(filepath.Join(GinkgoT().TempDir(), "missing.prof"))
+		Expect(err).To(MatchError(ContainSubstring("HaveHeapAllocBelow matcher could not read the profile at")))
+
+		_, err = HaveHeapAllocBelow(1).Match([]byte("not a profile"))
+		Expect(err).To(MatchError(ContainSubstring("HaveHeapAllocBelow matcher could not parse the profile:")))
+	})
+
+	It("errors when the profile is not a heap profile", func() {
+		cpu := &profile.Profile{SampleType: []*profile.ValueType{{Type: "samples", Unit: "count"}, {Type: "cpu", Unit: "nanoseconds"}}}
+		_, err := HaveHeapAllocBelow(1).Match(cpu)
+		Expect(err).To(MatchError("HaveHeapAllocBelow matcher expects a heap profile, but the profile has sample types [samples/count cpu/nanoseconds]"))
+	})
+
+	Describe("HeapProfile", func() {
+		It("returns a heap profile of the live runtime", func() {
+			p, err := HeapProfile()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.SampleType).To(ContainElement(HaveField("Type", "inuse_space")))
+			Expect(p).To(HaveHeapAllocBelow(1 << 40))
+			Expect(HeapProfile()).To(HaveNoFrameMatching(`^gprof_test\.doesNotExist$`, 0))
+		})
+	})
+})
@@ -0,0 +1,43 @@
+package gprof
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/types"
+)
+
+/*
+HaveAllocationsFewerThan succeeds if actual, a func(), makes fewer than n heap allocations per run on average.  The
+function is run DefaultAllocationRuns times, after a warm-up run; pass Runs to change that, and RecordIn to record the
+measurements in a gmeasure.Experiment:
+
+	Expect(func() { router.Match("/users/42") }).To(gprof.HaveAllocationsFewerThan(1))
+*/
+func HaveAllocationsFewerThan(n float64, options ...AllocationOption) types.GomegaMatcher {
+	return &HaveAllocationsFewerThanMatcher{
+		Allocations: n,
+		measurement: newAllocationMeasurement(options),
+	}
+}
+
+type HaveAllocationsFewerThanMatcher struct {
+	Allocations float64
+
+	// state
+	measurement allocationMeasurement
+}
+
+func (matcher *HaveAllocationsFewerThanMatcher) Match(actual interface{}) (success bool, err error) {
+	if err := matcher.measurement.measure("HaveAllocationsFewerThan", actual); err != nil {
+		return false, err
+	}
+	return matcher.measurement.allocs < matcher.Allocations, nil
+}
+
+func (matcher *HaveAllocationsFewerThanMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the function to make fewer than %g allocations per run, but it made %s", matcher.Allocations, matcher.measurement.describe())
+}
+
+func (matcher *HaveAllocationsFewerThanMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the function to make at least %g allocations per run, but it made %s", matcher.Allocations, matcher.measurement.describe())
+}
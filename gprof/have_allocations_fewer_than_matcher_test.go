@@ -0,0 +1,25 @@
+package gprof_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gprof"
+)
+
+var _ = Describe("HaveAllocationsFewerThan", func() {
+	It("succeeds when the function makes fewer allocations than the threshold per run", func() {
+		Expect(func() {}).To(HaveAllocationsFewerThan(1))
+		Expect(allocateKiB).To(HaveAllocationsFewerThan(3))
+		Expect(allocateKiB).NotTo(HaveAllocationsFewerThan(0.5))
+	})
+
+	It("reports the allocations per run on failure", func() {
+		m := HaveAllocationsFewerThan(0.5, Runs(10))
+		Expect(m.Match(allocateKiB)).To(BeFalse())
+		Expect(m.FailureMessage(allocateKiB)).To(MatchRegexp(`^Expected the function to make fewer than 0\.5 allocations per run, but it made \d+\.\d\d allocations and .+ per run, on average over 10 runs$`))
+
+		m = HaveAllocationsFewerThan(1, Runs(10))
+		Expect(m.Match(func() {})).To(BeTrue())
+		Expect(m.NegatedFailureMessage(func() {})).To(HavePrefix("Expected the function to make at least 1 allocations per run, but it made "))
+	})
+})
@@ -0,0 +1,63 @@
+package gprof
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/types"
+)
+
+// reportedAllocationSites is the number of allocation sites listed when a heap profile exceeds its budget
+const reportedAllocationSites = 5
+
+/*
+HaveHeapAllocBelow succeeds if actual is a heap profile in which fewer than bytes are in use - that is, allocated
+and not yet freed.  On failure it reports the functions that allocated the most in-use memory:
+
+	Expect(gprof.HeapProfile()).To(gprof.HaveHeapAllocBelow(64 << 20))
+*/
+func HaveHeapAllocBelow(bytes int64) types.GomegaMatcher {
+	return &HaveHeapAllocBelowMatcher{
+		Bytes: bytes,
+	}
+}
+
+type HaveHeapAllocBelowMatcher struct {
+	Bytes int64
+
+	// state
+	inUse int64
+	sites []functionBytes
+}
+
+func (matcher *HaveHeapAllocBelowMatcher) Match(actual interface{}) (success bool, err error) {
+	p, err := toProfile("HaveHeapAllocBelow", actual)
+	if err != nil {
+		return false, err
+	}
+	index, err := inUseSpaceIndex("HaveHeapAllocBelow", p)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.inUse = 0
+	for _, sample := range p.Sample {
+		matcher.inUse += sample.Value[index]
+	}
+	matcher.sites = inUseByAllocationSite(p, index)
+	if len(matcher.sites) > reportedAllocationSites {
+		matcher.sites = matcher.sites[:reportedAllocationSites]
+	}
+	return matcher.inUse < matcher.Bytes, nil
+}
+
+func (matcher *HaveHeapAllocBelowMatcher) FailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Expected the heap profile to have less than %s in use, but it has %s in use", formatBytes(matcher.Bytes), formatBytes(matcher.inUse))
+	if len(matcher.sites) > 0 {
+		message += "\nThe largest allocation sites are:\n" + formatFunctionBytes(matcher.sites)
+	}
+	return message
+}
+
+func (matcher *HaveHeapAllocBelowMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the heap profile to have at least %s in use, but it has %s in use", formatBytes(matcher.Bytes), formatBytes(matcher.inUse))
+}
@@ -0,0 +1,47 @@
+package gprof_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gprof"
+)
+
+var _ = Describe("HaveHeapAllocBelow", func() {
+	It("succeeds when the in-use bytes of all samples total less than the threshold", func() {
+		p := syntheticHeapProfile(
+			heapSample{[]string{"main.a", "main.main"}, 1000},
+			heapSample{[]string{"main.b", "main.main"}, 2000},
+		)
+		Expect(p).To(HaveHeapAllocBelow(3001))
+		Expect(p).NotTo(HaveHeapAllocBelow(3000))
+		Expect(syntheticHeapProfile()).To(HaveHeapAllocBelow(1))
+	})
+
+	It("reports the in-use bytes and the largest allocation sites on failure", func() {
+		p := syntheticHeapProfile(
+			heapSample{[]string{"main.a", "main.main"}, 1 << 20},
+			heapSample{[]string{"main.b", "main.main"}, 3 << 20},
+			heapSample{[]string{"main.a", "main.other"}, 1 << 20},
+			heapSample{[]string{"main.c"}, 100},
+			heapSample{[]string{"main.d"}, 99},
+			heapSample{[]string{"main.e"}, 98},
+			heapSample{[]string{"main.f"}, 97},
+		)
+		m := HaveHeapAllocBelow(4 << 20)
+		Expect(m.Match(p)).To(BeFalse())
+		Expect(m.FailureMessage(p)).To(Equal(`Expected the heap profile to have less than 4.0 MiB (4194304 B) in use, but it has 5.0 MiB (5243274 B) in use
+The largest allocation sites are:
+    3.0 MiB (3145728 B)  main.b
+    2.0 MiB (2097152 B)  main.a
+    100 B  main.c
+    99 B  main.d
+    98 B  main.e`))
+	})
+
+	It("reports the in-use bytes when negated", func() {
+		p := syntheticHeapProfile(heapSample{[]string{"main.a"}, 512})
+		m := HaveHeapAllocBelow(1024)
+		Expect(m.Match(p)).To(BeTrue())
+		Expect(m.NegatedFailureMessage(p)).To(Equal("Expected the heap profile to have at least 1.0 KiB (1024 B) in use, but it has 512 B in use"))
+	})
+})
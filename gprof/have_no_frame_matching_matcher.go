@@ -0,0 +1,70 @@
+package gprof
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/onsi/gomega/types"
+)
+
+/*
+HaveNoFrameMatching succeeds if actual is a heap profile in which no function whose name matches regexp retains
+more than aboveBytes of in-use memory.  The memory retained by a function is that of every sample with the function
+anywhere in its stack - so a match on a constructor covers everything allocated beneath it:
+
+	Expect(gprof.HeapProfile()).To(gprof.HaveNoFrameMatching(`mypkg\.\(\*Cache\)\.`, 1 << 20))
+
+Pass an aboveBytes of 0 to assert that matching functions retain no memory at all.  Function names are fully
+qualified, as in "github.com/me/mypkg.(*Cache).Put".
+*/
+func HaveNoFrameMatching(regexp string, aboveBytes int64) types.GomegaMatcher {
+	return &HaveNoFrameMatchingMatcher{
+		Regexp:     regexp,
+		AboveBytes: aboveBytes,
+	}
+}
+
+type HaveNoFrameMatchingMatcher struct {
+	Regexp     string
+	AboveBytes int64
+
+	// state
+	matching  []functionBytes
+	offending []functionBytes
+}
+
+func (matcher *HaveNoFrameMatchingMatcher) Match(actual interface{}) (success bool, err error) {
+	re, err := regexp.Compile(matcher.Regexp)
+	if err != nil {
+		return false, fmt.Errorf("HaveNoFrameMatching matcher failed to compile the regular expression:\n\t%s", err.Error())
+	}
+	p, err := toProfile("HaveNoFrameMatching", actual)
+	if err != nil {
+		return false, err
+	}
+	index, err := inUseSpaceIndex("HaveNoFrameMatching", p)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.matching = inUseByFunction(p, index, re.MatchString)
+	matcher.offending = []functionBytes{}
+	for _, total := range matcher.matching {
+		if total.bytes > matcher.AboveBytes {
+			matcher.offending = append(matcher.offending, total)
+		}
+	}
+	return len(matcher.offending) == 0, nil
+}
+
+func (matcher *HaveNoFrameMatchingMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected no function matching %q to retain more than %s, but these do:\n%s", matcher.Regexp, formatBytes(matcher.AboveBytes), formatFunctionBytes(matcher.offending))
+}
+
+func (matcher *HaveNoFrameMatchingMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Expected a function matching %q to retain more than %s", matcher.Regexp, formatBytes(matcher.AboveBytes))
+	if len(matcher.matching) == 0 {
+		return message + ", but no function in the profile matches"
+	}
+	return message + ", but the matching functions retain:\n" + formatFunctionBytes(matcher.matching)
+}
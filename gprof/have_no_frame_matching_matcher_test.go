@@ -0,0 +1,60 @@
+package gprof_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gprof"
+)
+
+var _ = Describe("HaveNoFrameMatching", func() {
+	var p interface{}
+
+	BeforeEach(func() {
+		p = syntheticHeapProfile(
+			heapSample{[]string{"bytes.makeSlice", "cache.(*Cache).Put", "main.main"}, 3000},
+			heapSample{[]string{"cache.(*Cache).grow", "cache.(*Cache).Put", "main.main"}, 2000},
+			heapSample{[]string{"cache.(*Cache).Put", "cache.(*Cache).Put", "main.main"}, 500},
+			heapSample{[]string{"main.other", "main.main"}, 100},
+		)
+	})
+
+	It("succeeds when no matching function retains more than the threshold", func() {
+		Expect(p).To(HaveNoFrameMatching(`^cache\.`, 5500))
+		Expect(p).NotTo(HaveNoFrameMatching(`^cache\.`, 5499))
+		Expect(p).To(HaveNoFrameMatching(`^nope\.`, 0))
+		Expect(p).To(HaveNoFrameMatching(`main\.other`, 100))
+		Expect(p).NotTo(HaveNoFrameMatching(`main\.other`, 99))
+	})
+
+	It("counts each sample once per function, however deep its recursion", func() {
+		Expect(p).To(HaveNoFrameMatching(`\(\*Cache\)\.Put$`, 5500))
+		Expect(p).NotTo(HaveNoFrameMatching(`\(\*Cache\)\.Put$`, 5499))
+		Expect(p).NotTo(HaveNoFrameMatching(`^main\.main$`, 5599))
+	})
+
+	It("reports the offending functions on failure", func() {
+		m := HaveNoFrameMatching(`^cache\.`, 1024)
+		Expect(m.Match(p)).To(BeFalse())
+		Expect(m.FailureMessage(p)).To(Equal(`Expected no function matching "^cache\\." to retain more than 1.0 KiB (1024 B), but these do:
+    5.4 KiB (5500 B)  cache.(*Cache).Put
+    2.0 KiB (2000 B)  cache.(*Cache).grow`))
+	})
+
+	It("reports the matching functions when negated", func() {
+		m := HaveNoFrameMatching(`^cache\.`, 1<<20)
+		Expect(m.Match(p)).To(BeTrue())
+		Expect(m.NegatedFailureMessage(p)).To(Equal(`Expected a function matching "^cache\\." to retain more than 1.0 MiB (1048576 B), but the matching functions retain:
+    5.4 KiB (5500 B)  cache.(*Cache).Put
+    2.0 KiB (2000 B)  cache.(*Cache).grow`))
+
+		m = HaveNoFrameMatching(`^nope\.`, 0)
+		Expect(m.Match(p)).To(BeTrue())
+		Expect(m.NegatedFailureMessage(p)).To(Equal(`Expected a function matching "^nope\\." to retain more than 0 B, but no function in the profile matches`))
+	})
+
+	It("errors when the regular expression is invalid", func() {
+		success, err := HaveNoFrameMatching(`(`, 0).Match(p)
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("HaveNoFrameMatching matcher failed to compile the regular expression")))
+	})
+})
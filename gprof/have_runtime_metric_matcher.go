@@ -0,0 +1,86 @@
+package gprof
+
+import (
+	"fmt"
+	"runtime/metrics"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+HaveRuntimeMetric succeeds if actual - a []metrics.Sample, typically polled from RuntimeMetrics - contains the named
+runtime/metrics sample and its value satisfies expected.  expected can be a value or a matcher; values are compared
+with Equal.
+
+Counters and gauges are passed to the matcher as uint64s or float64s, depending on the metric's kind, and histograms
+as *metrics.Float64Histogram.  Use HaveRuntimeMetric under Consistently to assert that a resource stays within a
+ceiling while the spec runs a workload:
+
+	go workload()
+	Consistently(gprof.RuntimeMetrics).WithTimeout(5 * time.Second).
+		Should(gprof.HaveRuntimeMetric("/memory/classes/heap/objects:bytes", BeNumerically("<", 256<<20)))
+
+The names of the supported metrics are listed in the runtime/metrics documentation.
+*/
+func HaveRuntimeMetric(name string, expected interface{}) types.GomegaMatcher {
+	return &HaveRuntimeMetricMatcher{
+		Name:     name,
+		Expected: expected,
+	}
+}
+
+type HaveRuntimeMetricMatcher struct {
+	Name     string
+	Expected interface{}
+
+	// state
+	value           interface{}
+	expectedMatcher types.GomegaMatcher
+}
+
+func (matcher *HaveRuntimeMetricMatcher) Match(actual interface{}) (success bool, err error) {
+	samples, ok := actual.([]metrics.Sample)
+	if !ok {
+		return false, fmt.Errorf("HaveRuntimeMetric matcher expects a []metrics.Sample - poll gprof.RuntimeMetrics to sample the live runtime.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	found := false
+	for _, sample := range samples {
+		if sample.Name != matcher.Name {
+			continue
+		}
+		found = true
+		switch sample.Value.Kind() {
+		case metrics.KindUint64:
+			matcher.value = sample.Value.Uint64()
+		case metrics.KindFloat64:
+			matcher.value = sample.Value.Float64()
+		case metrics.KindFloat64Histogram:
+			matcher.value = sample.Value.Float64Histogram()
+		default:
+			return false, fmt.Errorf("HaveRuntimeMetric matcher found no value for metric %s - it is not supported by this version of Go", matcher.Name)
+		}
+	}
+	if !found {
+		return false, fmt.Errorf("HaveRuntimeMetric matcher could not find metric %s among the samples", matcher.Name)
+	}
+
+	var isMatcher bool
+	matcher.expectedMatcher, isMatcher = matcher.Expected.(types.GomegaMatcher)
+	if !isMatcher {
+		matcher.expectedMatcher = &matchers.EqualMatcher{Expected: matcher.Expected}
+	}
+	return matcher.expectedMatcher.Match(matcher.value)
+}
+
+func (matcher *HaveRuntimeMetricMatcher) FailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Runtime metric %s failed to satisfy matcher.\n", matcher.Name)
+	return message + matcher.expectedMatcher.FailureMessage(matcher.value)
+}
+
+func (matcher *HaveRuntimeMetricMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Runtime metric %s satisfied matcher, but should not have.\n", matcher.Name)
+	return message + matcher.expectedMatcher.NegatedFailureMessage(matcher.value)
+}
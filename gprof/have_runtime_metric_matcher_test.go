@@ -0,0 +1,55 @@
+package gprof_test
+
+import (
+	"runtime/metrics"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gprof"
+)
+
+var _ = Describe("HaveRuntimeMetric", func() {
+	const goroutines = "/sched/goroutines:goroutines"
+
+	It("matches the value of the named metric", func() {
+		Expect(RuntimeMetrics()).To(HaveRuntimeMetric(goroutines, BeNumerically(">", 0)))
+		Expect(RuntimeMetrics(goroutines)).NotTo(HaveRuntimeMetric(goroutines, BeNumerically(">", 1<<40)))
+		Consistently(RuntimeMetrics).WithTimeout(50 * time.Millisecond).Should(HaveRuntimeMetric(goroutines, BeNumerically("<", 1<<40)))
+	})
+
+	It("compares values with Equal", func() {
+		samples := RuntimeMetrics(goroutines)
+		Expect(samples).To(HaveRuntimeMetric(goroutines, samples[0].Value.Uint64()))
+	})
+
+	It("passes histograms to the matcher", func() {
+		Expect(RuntimeMetrics("/gc/heap/allocs-by-size:bytes")).To(HaveRuntimeMetric("/gc/heap/allocs-by-size:bytes", HaveField("Buckets", Not(BeEmpty()))))
+	})
+
+	It("wraps the failure message of the matcher", func() {
+		samples := RuntimeMetrics(goroutines)
+		value := samples[0].Value.Uint64()
+
+		m := HaveRuntimeMetric(goroutines, BeZero())
+		Expect(m.Match(samples)).To(BeFalse())
+		Expect(m.FailureMessage(samples)).To(Equal("Runtime metric /sched/goroutines:goroutines failed to satisfy matcher.\n" + BeZero().FailureMessage(value)))
+
+		m = HaveRuntimeMetric(goroutines, value)
+		Expect(m.Match(samples)).To(BeTrue())
+		Expect(m.NegatedFailureMessage(samples)).To(Equal("Runtime metric /sched/goroutines:goroutines satisfied matcher, but should not have.\n" + Equal(value).NegatedFailureMessage(value)))
+	})
+
+	It("errors when the metric is missing or unsupported", func() {
+		_, err := HaveRuntimeMetric("/nope:bytes", 0).Match(RuntimeMetrics(goroutines))
+		Expect(err).To(MatchError("HaveRuntimeMetric matcher could not find metric /nope:bytes among the samples"))
+
+		_, err = HaveRuntimeMetric("/nope:bytes", 0).Match(RuntimeMetrics("/nope:bytes"))
+		Expect(err).To(MatchError("HaveRuntimeMetric matcher found no value for metric /nope:bytes - it is not supported by this version of Go"))
+	})
+
+	It("errors when actual is not a []metrics.Sample", func() {
+		_, err := HaveRuntimeMetric(goroutines, 0).Match(metrics.Sample{})
+		Expect(err).To(MatchError(ContainSubstring("HaveRuntimeMetric matcher expects a []metrics.Sample - poll gprof.RuntimeMetrics to sample the live runtime.  Got:")))
+	})
+})
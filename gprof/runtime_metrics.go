@@ -0,0 +1,23 @@
+package gprof
+
+import "runtime/metrics"
+
+/*
+RuntimeMetrics samples the named runtime/metrics - or all supported metrics if no names are given - from the live
+runtime.  Poll it with Eventually or Consistently and assert on the samples with HaveRuntimeMetric:
+
+	Consistently(gprof.RuntimeMetrics).Should(gprof.HaveRuntimeMetric("/sched/goroutines:goroutines", BeNumerically("<", 100)))
+*/
+func RuntimeMetrics(names ...string) []metrics.Sample {
+	if len(names) == 0 {
+		for _, description := range metrics.All() {
+			names = append(names, description.Name)
+		}
+	}
+	samples := make([]metrics.Sample, len(names))
+	for i, name := range names {
+		samples[i].Name = name
+	}
+	metrics.Read(samples)
+	return samples
+}
@@ -0,0 +1,24 @@
+package gprof_test
+
+import (
+	"runtime/metrics"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gprof"
+)
+
+var _ = Describe("RuntimeMetrics", func() {
+	It("samples every supported metric by default", func() {
+		samples := RuntimeMetrics()
+		Expect(samples).To(HaveLen(len(metrics.All())))
+		Expect(samples).To(ContainElement(HaveField("Name", "/sched/goroutines:goroutines")))
+	})
+
+	It("samples only the named metrics when given names", func() {
+		samples := RuntimeMetrics("/sched/goroutines:goroutines")
+		Expect(samples).To(HaveLen(1))
+		Expect(samples[0].Value.Kind()).To(Equal(metrics.KindUint64))
+		Expect(samples[0].Value.Uint64()).To(BeNumerically(">", 0))
+	})
+})
@@ -0,0 +1,229 @@
+package gprom
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+/*
+gather collects samples from a prometheus.Gatherer.  gprom does not import the Prometheus client libraries, so a
+Gatherer is anything with a
+
+	Gather() ([]*dto.MetricFamily, error)
+
+method, and the metric families are read through the getters protoc generates for them.
+*/
+func gather(actual interface{}) ([]Sample, bool, error) {
+	if actual == nil {
+		return nil, false, nil
+	}
+	gatherMethod := reflect.ValueOf(actual).MethodByName("Gather")
+	if !gatherMethod.IsValid() {
+		return nil, false, nil
+	}
+	gatherType := gatherMethod.Type()
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	if gatherType.NumIn() != 0 || gatherType.NumOut() != 2 || gatherType.Out(0).Kind() != reflect.Slice || gatherType.Out(1) != errorType {
+		return nil, false, nil
+	}
+
+	out := gatherMethod.Call(nil)
+	if !out[1].IsNil() {
+		return nil, true, out[1].Interface().(error)
+	}
+	samples := []Sample{}
+	families := out[0]
+	for i := 0; i < families.Len(); i++ {
+		familySamples, err := familyToSamples(families.Index(i))
+		if err != nil {
+			return nil, true, err
+		}
+		samples = append(samples, familySamples...)
+	}
+	return samples, true, nil
+}
+
+func familyToSamples(family reflect.Value) ([]Sample, error) {
+	name, err := getString(family, "GetName")
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := get(family, "GetMetric")
+	if err != nil {
+		return nil, err
+	}
+	samples := []Sample{}
+	for i := 0; i < metrics.Len(); i++ {
+		metric := metrics.Index(i)
+		labels, err := getLabels(metric)
+		if err != nil {
+			return nil, fmt.Errorf("metric %s: %s", name, err.Error())
+		}
+		metricSamples, err := metricToSamples(name, labels, metric)
+		if err != nil {
+			return nil, fmt.Errorf("metric %s: %s", name, err.Error())
+		}
+		samples = append(samples, metricSamples...)
+	}
+	return samples, nil
+}
+
+func metricToSamples(name string, labels map[string]string, metric reflect.Value) ([]Sample, error) {
+	for _, getter := range []string{"GetCounter", "GetGauge", "GetUntyped"} {
+		value, ok, err := getOptional(metric, getter)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			v, err := getFloat(value, "GetValue")
+			if err != nil {
+				return nil, err
+			}
+			return []Sample{{Name: name, Labels: labels, Value: v}}, nil
+		}
+	}
+
+	if summary, ok, err := getOptional(metric, "GetSummary"); err != nil {
+		return nil, err
+	} else if ok {
+		return distributionSamples(name, labels, summary, "GetQuantile", "quantile", "GetQuantile", "GetValue")
+	}
+
+	if histogram, ok, err := getOptional(metric, "GetHistogram"); err != nil {
+		return nil, err
+	} else if ok {
+		return distributionSamples(name, labels, histogram, "GetBucket", "le", "GetUpperBound", "GetCumulativeCount")
+	}
+
+	return nil, fmt.Errorf("has no counter, gauge, untyped, summary or histogram value")
+}
+
+// distributionSamples flattens a summary's quantiles or a histogram's buckets, along with their _sum and _count
+func distributionSamples(name string, labels map[string]string, distribution reflect.Value, elementsGetter string, label string, boundGetter string, valueGetter string) ([]Sample, error) {
+	samples := []Sample{}
+	elements, err := get(distribution, elementsGetter)
+	if err != nil {
+		return nil, err
+	}
+	count, err := getFloat(distribution, "GetSampleCount")
+	if err != nil {
+		return nil, err
+	}
+	sum, err := getFloat(distribution, "GetSampleSum")
+	if err != nil {
+		return nil, err
+	}
+
+	elementName := name
+	if label == "le" {
+		elementName = name + "_bucket"
+	}
+	sawInf := false
+	for i := 0; i < elements.Len(); i++ {
+		bound, err := getFloat(elements.Index(i), boundGetter)
+		if err != nil {
+			return nil, err
+		}
+		value, err := getFloat(elements.Index(i), valueGetter)
+		if err != nil {
+			return nil, err
+		}
+		sawInf = sawInf || math.IsInf(bound, 1)
+		samples = append(samples, Sample{Name: elementName, Labels: withLabel(labels, label, formatBound(bound)), Value: value})
+	}
+	// the text format always ends a histogram with a +Inf bucket, while gatherers leave it implied
+	if label == "le" && !sawInf {
+		samples = append(samples, Sample{Name: elementName, Labels: withLabel(labels, label, "+Inf"), Value: count})
+	}
+
+	return append(samples,
+		Sample{Name: name + "_sum", Labels: labels, Value: sum},
+		Sample{Name: name + "_count", Labels: labels, Value: count},
+	), nil
+}
+
+func getLabels(metric reflect.Value) (map[string]string, error) {
+	pairs, err := get(metric, "GetLabel")
+	if err != nil {
+		return nil, err
+	}
+	labels := map[string]string{}
+	for i := 0; i < pairs.Len(); i++ {
+		name, err := getString(pairs.Index(i), "GetName")
+		if err != nil {
+			return nil, err
+		}
+		value, err := getString(pairs.Index(i), "GetValue")
+		if err != nil {
+			return nil, err
+		}
+		labels[name] = value
+	}
+	return labels, nil
+}
+
+func withLabel(labels map[string]string, name string, value string) map[string]string {
+	copied := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		copied[k] = v
+	}
+	copied[name] = value
+	return copied
+}
+
+func formatBound(bound float64) string {
+	if math.IsInf(bound, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(bound, 'g', -1, 64)
+}
+
+// get calls the named, argument-less getter on v and returns its only result
+func get(v reflect.Value, getter string) (reflect.Value, error) {
+	method := v.MethodByName(getter)
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return reflect.Value{}, fmt.Errorf("%s has no %s method", v.Type(), getter)
+	}
+	return method.Call(nil)[0], nil
+}
+
+// getOptional calls a getter that returns a pointer, reporting whether the pointer is set
+func getOptional(v reflect.Value, getter string) (reflect.Value, bool, error) {
+	result, err := get(v, getter)
+	if err != nil {
+		return reflect.Value{}, false, err
+	}
+	if result.Kind() != reflect.Ptr {
+		return reflect.Value{}, false, fmt.Errorf("%s.%s does not return a pointer", v.Type(), getter)
+	}
+	return result, !result.IsNil(), nil
+}
+
+func getString(v reflect.Value, getter string) (string, error) {
+	result, err := get(v, getter)
+	if err != nil {
+		return "", err
+	}
+	if result.Kind() != reflect.String {
+		return "", fmt.Errorf("%s.%s does not return a string", v.Type(), getter)
+	}
+	return result.String(), nil
+}
+
+func getFloat(v reflect.Value, getter string) (float64, error) {
+	result, err := get(v, getter)
+	if err != nil {
+		return 0, err
+	}
+	switch result.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return result.Float(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(result.Uint()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(result.Int()), nil
+	}
+	return 0, fmt.Errorf("%s.%s does not return a number", v.Type(), getter)
+}
@@ -0,0 +1,139 @@
+package gprom_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gprom"
+)
+
+// The types below mirror the getters protoc generates for Prometheus' client_model, which gprom reads by reflection
+
+type fakeGatherer struct {
+	families []*metricFamily
+	err      error
+}
+
+func (g *fakeGatherer) Gather() ([]*metricFamily, error) { return g.families, g.err }
+
+type metricFamily struct {
+	name    string
+	metrics []*metric
+}
+
+func (f *metricFamily) GetName() string      { return f.name }
+func (f *metricFamily) GetMetric() []*metric { return f.metrics }
+
+type labelPair struct{ name, value string }
+
+func (p *labelPair) GetName() string  { return p.name }
+func (p *labelPair) GetValue() string { return p.value }
+
+type metric struct {
+	labels    []*labelPair
+	counter   *value
+	gauge     *value
+	untyped   *value
+	summary   *summary
+	histogram *histogram
+}
+
+func (m *metric) GetLabel() []*labelPair   { return m.labels }
+func (m *metric) GetCounter() *value       { return m.counter }
+func (m *metric) GetGauge() *value         { return m.gauge }
+func (m *metric) GetUntyped() *value       { return m.untyped }
+func (m *metric) GetSummary() *summary     { return m.summary }
+func (m *metric) GetHistogram() *histogram { return m.histogram }
+
+type value struct{ value float64 }
+
+func (v *value) GetValue() float64 { return v.value }
+
+type summary struct {
+	count     uint64
+	sum       float64
+	quantiles []*quantile
+}
+
+func (s *summary) GetSampleCount() uint64   { return s.count }
+func (s *summary) GetSampleSum() float64    { return s.sum }
+func (s *summary) GetQuantile() []*quantile { return s.quantiles }
+
+type quantile struct{ quantile, value float64 }
+
+func (q *quantile) GetQuantile() float64 { return q.quantile }
+func (q *quantile) GetValue() float64    { return q.value }
+
+type histogram struct {
+	count   uint64
+	sum     float64
+	buckets []*bucket
+}
+
+func (h *histogram) GetSampleCount() uint64 { return h.count }
+func (h *histogram) GetSampleSum() float64  { return h.sum }
+func (h *histogram) GetBucket() []*bucket   { return h.buckets }
+
+type bucket struct {
+	upperBound float64
+	count      uint64
+}
+
+func (b *bucket) GetUpperBound() float64     { return b.upperBound }
+func (b *bucket) GetCumulativeCount() uint64 { return b.count }
+
+func newFakeGatherer() *fakeGatherer {
+	return &fakeGatherer{families: []*metricFamily{
+		{name: "http_requests_total", metrics: []*metric{
+			{labels: []*labelPair{{"method", "GET"}, {"code", "200"}}, counter: &value{1027}},
+			{labels: []*labelPair{{"method", "POST"}, {"code", "500"}}, counter: &value{3}},
+		}},
+		{name: "queue_depth", metrics: []*metric{{gauge: &value{12.5}}}},
+		{name: "build_info", metrics: []*metric{{labels: []*labelPair{{"version", "1.2.3"}}, untyped: &value{1}}}},
+		{name: "rpc_duration_seconds", metrics: []*metric{{summary: &summary{count: 9, sum: 4.5, quantiles: []*quantile{{0.5, 0.25}, {0.99, 1.5}}}}}},
+		{name: "latency_seconds", metrics: []*metric{{histogram: &histogram{count: 7, sum: 1.75, buckets: []*bucket{{0.1, 4}, {1, 6}}}}}},
+	}}
+}
+
+var _ = Describe("Gathering from a prometheus.Gatherer", func() {
+	It("should flatten metric families into samples the way the text format does", func() {
+		Expect(newFakeGatherer()).Should(SatisfyAll(
+			gprom.HaveMetric("http_requests_total", gprom.WithLabels(map[string]string{"method": "GET", "code": "200"}), gprom.WithValue(1027)),
+			gprom.HaveMetric("http_requests_total", gprom.WithLabels(map[string]string{"code": "500"}), gprom.WithValue(3)),
+			gprom.HaveMetric("queue_depth", gprom.WithValue(12.5)),
+			gprom.HaveMetric("build_info", gprom.WithLabels(map[string]string{"version": "1.2.3"}), gprom.WithValue(1)),
+			gprom.HaveMetric("rpc_duration_seconds", gprom.WithLabels(map[string]string{"quantile": "0.99"}), gprom.WithValue(1.5)),
+			gprom.HaveMetric("rpc_duration_seconds_sum", gprom.WithValue(4.5)),
+			gprom.HaveMetric("rpc_duration_seconds_count", gprom.WithValue(9)),
+			gprom.HaveMetric("latency_seconds_bucket", gprom.WithLabels(map[string]string{"le": "0.1"}), gprom.WithValue(4)),
+			gprom.HaveMetric("latency_seconds_bucket", gprom.WithLabels(map[string]string{"le": "1"}), gprom.WithValue(6)),
+			gprom.HaveMetric("latency_seconds_sum", gprom.WithValue(1.75)),
+			gprom.HaveMetric("latency_seconds_count", gprom.WithValue(7)),
+		))
+	})
+
+	It("should add the +Inf bucket gatherers leave implied, but only once", func() {
+		gatherer := newFakeGatherer()
+		Expect(gatherer).Should(gprom.HaveMetric("latency_seconds_bucket", gprom.WithLabels(map[string]string{"le": "+Inf"}), gprom.WithValue(7)))
+
+		gatherer.families = []*metricFamily{{name: "latency_seconds", metrics: []*metric{{histogram: &histogram{count: 7, buckets: []*bucket{{math.Inf(1), 7}}}}}}}
+		Expect(gatherer).Should(gprom.HaveMetric("latency_seconds_bucket", gprom.WithValue(7)))
+		matcher := gprom.HaveMetric("latency_seconds_bucket", gprom.WithValue(8))
+		Expect(matcher.Match(gatherer)).Should(BeFalse())
+		Expect(strings.Count(matcher.FailureMessage(gatherer), "latency_seconds_bucket{")).Should(Equal(1))
+	})
+
+	It("should error when gathering fails", func() {
+		_, err := gprom.HaveMetric("up").Match(&fakeGatherer{err: errors.New("collector failed")})
+		Expect(err).Should(MatchError("HaveMetric matcher could not gather metrics:\n    collector failed"))
+	})
+
+	It("should error when a metric has no value", func() {
+		gatherer := &fakeGatherer{families: []*metricFamily{{name: "empty", metrics: []*metric{{}}}}}
+		_, err := gprom.HaveMetric("empty").Match(gatherer)
+		Expect(err).Should(MatchError("HaveMetric matcher could not gather metrics:\n    metric empty: has no counter, gauge, untyped, summary or histogram value"))
+	})
+})
@@ -0,0 +1,120 @@
+/*
+Package gprom provides matchers over Prometheus metrics, so that specs can assert on what a service exposes without
+grepping the text of its /metrics endpoint:
+
+	Expect(registry).To(gprom.HaveMetric("http_requests_total",
+		gprom.WithLabels(map[string]string{"method": "GET", "code": "200"}),
+		gprom.WithValue(BeNumerically(">", 0)),
+	))
+	Eventually(gprom.Endpoint(server.URL + "/metrics")).Should(gprom.HaveMetric("jobs_processed_total", gprom.WithValue(10)))
+
+The matchers accept metrics in the Prometheus text exposition format - as a string, a []byte, an *http.Response or an
+*httptest.ResponseRecorder - an Endpoint to scrape, or a prometheus.Gatherer such as a *prometheus.Registry.  gprom
+does not depend on the Prometheus client libraries: it recognizes a Gatherer by its Gather method.
+
+Metrics are flattened into samples as they are in the text format: a histogram called "latency_seconds" is made up of
+"latency_seconds_bucket" samples, with an "le" label, and "latency_seconds_sum" and "latency_seconds_count" samples.
+Summaries likewise have "quantile" labelled samples, "_sum" and "_count".
+*/
+package gprom
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/internal/gutil"
+)
+
+// Sample is a single sample of a metric
+type Sample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// String renders the sample as it would appear in the text exposition format, with its labels sorted
+func (s Sample) String() string {
+	return s.Name + renderLabels(s.Labels) + " " + strconv.FormatFloat(s.Value, 'g', -1, 64)
+}
+
+func renderLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	rendered := make([]string, len(names))
+	for i, name := range names {
+		rendered[i] = fmt.Sprintf("%s=%q", name, labels[name])
+	}
+	return "{" + strings.Join(rendered, ",") + "}"
+}
+
+/*
+Endpoint identifies a metrics endpoint.  The gprom matchers scrape it every time they run, so Eventually polls it:
+
+	Eventually(gprom.Endpoint("http://127.0.0.1:9090/metrics")).Should(gprom.HaveMetric("up", gprom.WithValue(1)))
+*/
+type Endpoint string
+
+// Client is the http.Client used to scrape Endpoints
+var Client = http.DefaultClient
+
+// toSamples returns the samples actual holds or identifies
+func toSamples(matcherName string, actual interface{}) ([]Sample, error) {
+	var text []byte
+	switch x := actual.(type) {
+	case string:
+		text = []byte(x)
+	case []byte:
+		text = x
+	case Endpoint:
+		resp, err := Client.Get(string(x))
+		if err != nil {
+			return nil, fmt.Errorf("%s matcher could not scrape %s:\n%s", matcherName, x, format.IndentString(err.Error(), 1))
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%s matcher could not scrape %s: it responded with %s", matcherName, x, resp.Status)
+		}
+		text, err = gutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("%s matcher could not scrape %s:\n%s", matcherName, x, format.IndentString(err.Error(), 1))
+		}
+	case *http.Response:
+		if x == nil || x.Body == nil {
+			return nil, fmt.Errorf("%s matcher expects an *http.Response with a body.  Got:\n%s", matcherName, format.Object(actual, 1))
+		}
+		defer x.Body.Close()
+		var err error
+		text, err = gutil.ReadAll(x.Body)
+		if err != nil {
+			return nil, fmt.Errorf("%s matcher could not read the response body:\n%s", matcherName, format.IndentString(err.Error(), 1))
+		}
+	case *httptest.ResponseRecorder:
+		text = x.Body.Bytes()
+	default:
+		samples, isGatherer, err := gather(actual)
+		if !isGatherer {
+			return nil, fmt.Errorf("%s matcher expects metrics in the text exposition format, an Endpoint, or a prometheus.Gatherer.  Got:\n%s", matcherName, format.Object(actual, 1))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s matcher could not gather metrics:\n%s", matcherName, format.IndentString(err.Error(), 1))
+		}
+		return samples, nil
+	}
+
+	samples, err := ParseText(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("%s matcher expects metrics in the text exposition format, but parsing them failed:\n%s", matcherName, format.IndentString(err.Error(), 1))
+	}
+	return samples, nil
+}
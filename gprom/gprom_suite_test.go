@@ -0,0 +1,13 @@
+package gprom_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGprom(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gprom Suite")
+}
@@ -0,0 +1,198 @@
+package gprom
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+// MetricOption narrows down the samples HaveMetric looks for
+type MetricOption func(*HaveMetricMatcher)
+
+// WithLabels restricts HaveMetric to samples that have all of the given labels.  Samples may have other labels too.
+func WithLabels(labels map[string]string) MetricOption {
+	return func(matcher *HaveMetricMatcher) {
+		if matcher.Labels == nil {
+			matcher.Labels = map[string]string{}
+		}
+		for name, value := range labels {
+			matcher.Labels[name] = value
+		}
+	}
+}
+
+// WithValue restricts HaveMetric to samples whose value satisfies expected.  Values are passed to expected as float64s.
+// expected can be a number or a matcher; numbers are compared with BeNumerically("==", ...) so their type does not matter.
+func WithValue(expected interface{}) MetricOption {
+	return func(matcher *HaveMetricMatcher) {
+		matcher.Value = expected
+	}
+}
+
+/*
+HaveMetric succeeds if actual has a sample of the metric called name.  actual can be metrics in the text exposition
+format, an Endpoint to scrape, or a prometheus.Gatherer.  WithLabels and WithValue narrow down the samples that count:
+
+	Expect(registry).To(gprom.HaveMetric("http_requests_total", gprom.WithLabels(map[string]string{"code": "500"})))
+	Expect(recorder).To(gprom.HaveMetric("queue_depth", gprom.WithValue(BeNumerically("<", 100))))
+	Expect(registry).NotTo(gprom.HaveMetric("panics_total", gprom.WithValue(BeNumerically(">", 0))))
+
+name is the name of the sample, so the buckets of a histogram called "latency_seconds" are "latency_seconds_bucket".
+*/
+func HaveMetric(name string, options ...MetricOption) types.GomegaMatcher {
+	matcher := &HaveMetricMatcher{
+		Name: name,
+	}
+	for _, option := range options {
+		option(matcher)
+	}
+	return matcher
+}
+
+type HaveMetricMatcher struct {
+	Name   string
+	Labels map[string]string
+	Value  interface{}
+
+	// state
+	cachedResponse *http.Response
+	cachedSamples  []Sample
+	samples        []Sample
+	named          []Sample
+	labelled       []Sample
+	matched        *Sample
+	valueMatcher   types.GomegaMatcher
+}
+
+func (matcher *HaveMetricMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.samples, err = matcher.readSamples(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.named, matcher.labelled, matcher.matched = []Sample{}, []Sample{}, nil
+	for _, sample := range matcher.samples {
+		if sample.Name != matcher.Name {
+			continue
+		}
+		matcher.named = append(matcher.named, sample)
+		if hasLabels(sample, matcher.Labels) {
+			matcher.labelled = append(matcher.labelled, sample)
+		}
+	}
+
+	if matcher.Value == nil {
+		if len(matcher.labelled) > 0 {
+			matcher.matched = &matcher.labelled[0]
+		}
+		return matcher.matched != nil, nil
+	}
+
+	var isMatcher bool
+	matcher.valueMatcher, isMatcher = matcher.Value.(types.GomegaMatcher)
+	if !isMatcher {
+		matcher.valueMatcher = &matchers.BeNumericallyMatcher{Comparator: "==", CompareTo: []interface{}{matcher.Value}}
+	}
+	for i, sample := range matcher.labelled {
+		success, err := matcher.valueMatcher.Match(sample.Value)
+		if err != nil {
+			return false, fmt.Errorf("HaveMetric matcher could not match the value of %s:\n%s", sample, format.IndentString(err.Error(), 1))
+		}
+		if success {
+			matcher.matched = &matcher.labelled[i]
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// readSamples reads the samples of actual.  The body of an *http.Response can only be read once, so its samples are cached.
+func (matcher *HaveMetricMatcher) readSamples(actual interface{}) ([]Sample, error) {
+	resp, isResponse := actual.(*http.Response)
+	if isResponse && resp != nil && resp == matcher.cachedResponse {
+		return matcher.cachedSamples, nil
+	}
+	samples, err := toSamples("HaveMetric", actual)
+	if err != nil {
+		return nil, err
+	}
+	if isResponse {
+		matcher.cachedResponse, matcher.cachedSamples = resp, samples
+	}
+	return samples, nil
+}
+
+func (matcher *HaveMetricMatcher) FailureMessage(actual interface{}) (message string) {
+	if len(matcher.named) == 0 {
+		return fmt.Sprintf("Expected metrics to have a sample of %s, but they have none.  The metrics they have are:\n%s", matcher.Name, formatLines(metricNames(matcher.samples)))
+	}
+	if len(matcher.labelled) == 0 {
+		return fmt.Sprintf("Expected metrics to have a sample of %s, but none of the samples of %s have those labels:\n%s", matcher.description(), matcher.Name, formatSamples(matcher.named))
+	}
+	message = fmt.Sprintf("Expected metrics to have a sample of %s whose value satisfies the matcher, but none do:\n%s\n", matcher.description(), formatSamples(matcher.labelled))
+	return message + matcher.valueMatcher.FailureMessage(matcher.labelled[0].Value)
+}
+
+func (matcher *HaveMetricMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if matcher.valueMatcher == nil {
+		return fmt.Sprintf("Expected metrics not to have a sample of %s, but they have:\n%s", matcher.description(), formatSamples([]Sample{*matcher.matched}))
+	}
+	message = fmt.Sprintf("Expected metrics not to have a sample of %s whose value satisfies the matcher, but they have:\n%s\n", matcher.description(), formatSamples([]Sample{*matcher.matched}))
+	return message + matcher.valueMatcher.NegatedFailureMessage(matcher.matched.Value)
+}
+
+// MatchMayChangeInTheFuture lets Eventually and Consistently keep scraping Endpoints and gathering from Gatherers, whose
+// metrics change over time
+func (matcher *HaveMetricMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	switch actual.(type) {
+	case string, []byte, *http.Response:
+		return false
+	}
+	return true
+}
+
+func (matcher *HaveMetricMatcher) description() string {
+	return matcher.Name + renderLabels(matcher.Labels)
+}
+
+func hasLabels(sample Sample, labels map[string]string) bool {
+	for name, value := range labels {
+		if actualValue, ok := sample.Labels[name]; !ok || actualValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+func metricNames(samples []Sample) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, sample := range samples {
+		if !seen[sample.Name] {
+			seen[sample.Name] = true
+			names = append(names, sample.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func formatSamples(samples []Sample) string {
+	lines := make([]string, len(samples))
+	for i, sample := range samples {
+		lines[i] = sample.String()
+	}
+	return formatLines(lines)
+}
+
+func formatLines(lines []string) string {
+	if len(lines) == 0 {
+		return format.Indent + "<none>"
+	}
+	return format.Indent + strings.Join(lines, "\n"+format.Indent)
+}
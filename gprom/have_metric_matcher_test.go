@@ -0,0 +1,131 @@
+package gprom_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gprom"
+)
+
+var _ = Describe("HaveMetric", func() {
+	get := map[string]string{"method": "GET"}
+
+	It("should find metrics by name", func() {
+		Expect(exposition).Should(gprom.HaveMetric("queue_depth"))
+		Expect([]byte(exposition)).Should(gprom.HaveMetric("http_requests_total"))
+		Expect(exposition).ShouldNot(gprom.HaveMetric("http_requests"))
+		Expect("").ShouldNot(gprom.HaveMetric("up"))
+	})
+
+	It("should narrow samples down by a subset of their labels", func() {
+		Expect(exposition).Should(gprom.HaveMetric("http_requests_total", gprom.WithLabels(get)))
+		Expect(exposition).Should(gprom.HaveMetric("http_requests_total", gprom.WithLabels(map[string]string{"method": "POST", "code": "500"})))
+		Expect(exposition).Should(gprom.HaveMetric("http_requests_total", gprom.WithLabels(get), gprom.WithLabels(map[string]string{"code": "200"})))
+		Expect(exposition).ShouldNot(gprom.HaveMetric("http_requests_total", gprom.WithLabels(map[string]string{"method": "DELETE"})))
+		Expect(exposition).ShouldNot(gprom.HaveMetric("queue_depth", gprom.WithLabels(map[string]string{"queue": "default"})))
+	})
+
+	It("should narrow samples down by their value", func() {
+		Expect(exposition).Should(gprom.HaveMetric("http_requests_total", gprom.WithLabels(get), gprom.WithValue(1027)))
+		Expect(exposition).Should(gprom.HaveMetric("http_requests_total", gprom.WithValue(uint(3))))
+		Expect(exposition).Should(gprom.HaveMetric("queue_depth", gprom.WithValue(BeNumerically("~", 12, 1))))
+		Expect(exposition).ShouldNot(gprom.HaveMetric("http_requests_total", gprom.WithLabels(get), gprom.WithValue(3)))
+		Expect(exposition).ShouldNot(gprom.HaveMetric("queue_depth", gprom.WithValue(BeNumerically(">", 100))))
+	})
+
+	It("should read the bodies of responses and recorders", func() {
+		resp := &http.Response{Body: io.NopCloser(strings.NewReader(exposition))}
+		matcher := gprom.HaveMetric("queue_depth", gprom.WithValue(12.5))
+		Expect(resp).Should(matcher)
+		Expect(resp).Should(matcher)
+
+		recorder := httptest.NewRecorder()
+		recorder.WriteString(exposition)
+		Expect(recorder).Should(gprom.HaveMetric("queue_depth", gprom.WithValue(12.5)))
+	})
+
+	It("should scrape Endpoints every time it matches", func() {
+		var scrapes int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt64(&scrapes, 1)
+			io.WriteString(w, "jobs_processed_total "+strings.Repeat("1", int(n))+"\n")
+		}))
+		DeferCleanup(server.Close)
+
+		Eventually(gprom.Endpoint(server.URL)).Should(gprom.HaveMetric("jobs_processed_total", gprom.WithValue(111)))
+		Expect(atomic.LoadInt64(&scrapes)).Should(BeNumerically(">=", 3))
+	})
+
+	It("should keep polling Endpoints and Gatherers, but not fixed metrics", func() {
+		matcher := gprom.HaveMetric("up").(*gprom.HaveMetricMatcher)
+		Expect(matcher.MatchMayChangeInTheFuture(exposition)).Should(BeFalse())
+		Expect(matcher.MatchMayChangeInTheFuture(gprom.Endpoint("http://127.0.0.1/metrics"))).Should(BeTrue())
+		Expect(matcher.MatchMayChangeInTheFuture(newFakeGatherer())).Should(BeTrue())
+	})
+
+	It("should error when actual does not hold metrics", func() {
+		_, err := gprom.HaveMetric("up").Match(17)
+		Expect(err).Should(MatchError(ContainSubstring("HaveMetric matcher expects metrics in the text exposition format, an Endpoint, or a prometheus.Gatherer.  Got:")))
+		_, err = gprom.HaveMetric("up").Match("up")
+		Expect(err).Should(MatchError("HaveMetric matcher expects metrics in the text exposition format, but parsing them failed:\n    line 1: \"up\" has no value"))
+		_, err = gprom.HaveMetric("up").Match((*http.Response)(nil))
+		Expect(err).Should(MatchError(ContainSubstring("HaveMetric matcher expects an *http.Response with a body.  Got:")))
+
+		server := httptest.NewServer(http.NotFoundHandler())
+		DeferCleanup(server.Close)
+		_, err = gprom.HaveMetric("up").Match(gprom.Endpoint(server.URL))
+		Expect(err).Should(MatchError("HaveMetric matcher could not scrape " + server.URL + ": it responded with 404 Not Found"))
+	})
+
+	It("should error when the value matcher errors", func() {
+		_, err := gprom.HaveMetric("queue_depth", gprom.WithValue("twelve")).Match(exposition)
+		Expect(err).Should(MatchError(ContainSubstring("HaveMetric matcher could not match the value of queue_depth 12.5:")))
+	})
+
+	Describe("failure messages", func() {
+		It("should list the metrics there are when there is no sample of the metric", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect("up 1\nbuild_info 1\nup 0\n").Should(gprom.HaveMetric("down"))
+			})
+			Expect(failures).Should(ConsistOf("Expected metrics to have a sample of down, but they have none.  The metrics they have are:\n    build_info\n    up"))
+		})
+
+		It("should list the samples of the metric when none have the labels", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(exposition).Should(gprom.HaveMetric("http_requests_total", gprom.WithLabels(map[string]string{"method": "DELETE"})))
+			})
+			Expect(failures).Should(ConsistOf(`Expected metrics to have a sample of http_requests_total{method="DELETE"}, but none of the samples of http_requests_total have those labels:
+    http_requests_total{code="200",method="GET"} 1027
+    http_requests_total{code="500",method="POST"} 3`))
+		})
+
+		It("should list the samples with the labels, and why the first failed, when none have the value", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(exposition).Should(gprom.HaveMetric("http_requests_total", gprom.WithLabels(get), gprom.WithValue(BeNumerically(">", 2000))))
+			})
+			Expect(failures).Should(HaveLen(1))
+			Expect(failures[0]).Should(HavePrefix(`Expected metrics to have a sample of http_requests_total{method="GET"} whose value satisfies the matcher, but none do:
+    http_requests_total{code="200",method="GET"} 1027
+Expected
+    <float64>: 1027
+to be >
+    <int>: 2000`))
+		})
+
+		It("should show the sample that matched when negated", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(exposition).ShouldNot(gprom.HaveMetric("http_requests_total", gprom.WithLabels(get)))
+				Expect(exposition).ShouldNot(gprom.HaveMetric("queue_depth", gprom.WithValue(12.5)))
+			})
+			Expect(failures).Should(HaveLen(2))
+			Expect(failures[0]).Should(Equal(`Expected metrics not to have a sample of http_requests_total{method="GET"}, but they have:
+    http_requests_total{code="200",method="GET"} 1027`))
+			Expect(failures[1]).Should(HavePrefix("Expected metrics not to have a sample of queue_depth whose value satisfies the matcher, but they have:\n    queue_depth 12.5\nExpected\n    <float64>: 12.5\nnot to be ==\n"))
+		})
+	})
+})
@@ -0,0 +1,143 @@
+package gprom
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+/*
+ParseText parses metrics in the Prometheus text exposition format into their samples.  Comments, including HELP and
+TYPE lines, and timestamps are ignored.
+*/
+func ParseText(text string) ([]Sample, error) {
+	samples := []Sample{}
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sample, err := parseSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", i+1, err.Error())
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
+
+func parseSample(line string) (Sample, error) {
+	sample := Sample{Labels: map[string]string{}}
+	nameEnd := strings.IndexAny(line, "{ \t")
+	if nameEnd == -1 {
+		return Sample{}, fmt.Errorf("%q has no value", line)
+	}
+	sample.Name = line[:nameEnd]
+	if !isMetricName(sample.Name) {
+		return Sample{}, fmt.Errorf("%q is not a valid metric name", sample.Name)
+	}
+
+	rest := line[nameEnd:]
+	if strings.HasPrefix(rest, "{") {
+		var err error
+		rest, err = parseLabels(rest[1:], sample.Labels)
+		if err != nil {
+			return Sample{}, err
+		}
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 || len(fields) > 2 {
+		return Sample{}, fmt.Errorf("expected a value, and optionally a timestamp, after %s", sample.Name)
+	}
+	value, err := parseValue(fields[0])
+	if err != nil {
+		return Sample{}, fmt.Errorf("%q is not a valid value", fields[0])
+	}
+	sample.Value = value
+	return sample, nil
+}
+
+// parseLabels parses the labels following a "{", and returns what follows the closing "}"
+func parseLabels(s string, labels map[string]string) (string, error) {
+	for {
+		s = strings.TrimLeft(s, " \t")
+		if strings.HasPrefix(s, "}") {
+			return s[1:], nil
+		}
+		eq := strings.IndexByte(s, '=')
+		if eq == -1 {
+			return "", fmt.Errorf("unterminated labels")
+		}
+		name := strings.TrimSpace(s[:eq])
+		if !isLabelName(name) {
+			return "", fmt.Errorf("%q is not a valid label name", name)
+		}
+		s = strings.TrimLeft(s[eq+1:], " \t")
+		if !strings.HasPrefix(s, `"`) {
+			return "", fmt.Errorf("the value of label %s is not quoted", name)
+		}
+		value := &strings.Builder{}
+		i := 1
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			value.WriteByte(s[i])
+		}
+		if i == len(s) {
+			return "", fmt.Errorf("the value of label %s is not terminated", name)
+		}
+		labels[name] = value.String()
+		s = strings.TrimLeft(s[i+1:], " \t")
+		if strings.HasPrefix(s, ",") {
+			s = s[1:]
+		} else if !strings.HasPrefix(s, "}") {
+			return "", fmt.Errorf("expected a ',' or '}' after label %s", name)
+		}
+	}
+}
+
+func parseValue(s string) (float64, error) {
+	switch s {
+	case "+Inf", "Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func isMetricName(name string) bool {
+	return isName(name, true)
+}
+
+func isLabelName(name string) bool {
+	return isName(name, false)
+}
+
+func isName(name string, allowColons bool) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r == ':' && allowColons:
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
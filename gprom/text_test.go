@@ -0,0 +1,62 @@
+package gprom_test
+
+import (
+	"math"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gprom"
+)
+
+const exposition = `# HELP http_requests_total The number of HTTP requests.
+# TYPE http_requests_total counter
+http_requests_total{method="GET",code="200"} 1027 1395066363000
+http_requests_total{method="POST",code="500"} 3
+# TYPE queue_depth gauge
+queue_depth 12.5
+
+# TYPE latency_seconds histogram
+latency_seconds_bucket{le="0.1"} 4
+latency_seconds_bucket{le="+Inf"} 7
+latency_seconds_sum 1.75
+latency_seconds_count 7
+escapes{path="C:\\dir\\",quote="say \"hi\"",newline="a\nb"} NaN
+infinite +Inf
+`
+
+var _ = Describe("ParseText", func() {
+	It("should parse the samples of the text exposition format", func() {
+		samples, err := gprom.ParseText(exposition)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(samples).Should(HaveLen(9))
+		Expect(samples[0]).Should(Equal(gprom.Sample{Name: "http_requests_total", Labels: map[string]string{"method": "GET", "code": "200"}, Value: 1027}))
+		Expect(samples[2]).Should(Equal(gprom.Sample{Name: "queue_depth", Labels: map[string]string{}, Value: 12.5}))
+		Expect(samples[4].Labels).Should(Equal(map[string]string{"le": "+Inf"}))
+		Expect(samples[7].Labels).Should(Equal(map[string]string{"path": `C:\dir\`, "quote": `say "hi"`, "newline": "a\nb"}))
+		Expect(math.IsNaN(samples[7].Value)).Should(BeTrue())
+		Expect(samples[8].Value).Should(Equal(math.Inf(1)))
+	})
+
+	It("should render samples in the text exposition format, with sorted labels", func() {
+		sample := gprom.Sample{Name: "http_requests_total", Labels: map[string]string{"method": "GET", "code": "200"}, Value: 1027}
+		Expect(sample.String()).Should(Equal(`http_requests_total{code="200",method="GET"} 1027`))
+		Expect(gprom.Sample{Name: "up", Value: 1}.String()).Should(Equal("up 1"))
+	})
+
+	It("should report malformed lines", func() {
+		_, err := gprom.ParseText("up 1\nup\n")
+		Expect(err).Should(MatchError(`line 2: "up" has no value`))
+		_, err = gprom.ParseText("1up 1")
+		Expect(err).Should(MatchError(`line 1: "1up" is not a valid metric name`))
+		_, err = gprom.ParseText(`up{job=unquoted} 1`)
+		Expect(err).Should(MatchError("line 1: the value of label job is not quoted"))
+		_, err = gprom.ParseText(`up{job="api" 1`)
+		Expect(err).Should(MatchError("line 1: expected a ',' or '}' after label job"))
+		_, err = gprom.ParseText(`up{job="api} 1`)
+		Expect(err).Should(MatchError("line 1: the value of label job is not terminated"))
+		_, err = gprom.ParseText("up one")
+		Expect(err).Should(MatchError(`line 1: "one" is not a valid value`))
+		_, err = gprom.ParseText("up 1 2 3")
+		Expect(err).Should(MatchError("line 1: expected a value, and optionally a timestamp, after up"))
+	})
+})
@@ -0,0 +1,65 @@
+package gregistry
+
+import (
+	"github.com/onsi/gomega"
+)
+
+// builtins are the Gomega matchers whose constructors take plain values.  Matchers that take functions, type
+// parameters, or pointers to receive into are left out.
+var builtins = []Registration{
+	{Name: "Equal", Description: "succeeds if actual is reflect.DeepEqual to the expected value", Factory: FactoryFor(gomega.Equal)},
+	{Name: "BeEquivalentTo", Description: "succeeds if actual, converted to the type of the expected value, equals it", Factory: FactoryFor(gomega.BeEquivalentTo)},
+	{Name: "BeIdenticalTo", Description: "succeeds if actual is == to the expected value", Factory: FactoryFor(gomega.BeIdenticalTo)},
+	{Name: "BeNil", Description: "succeeds if actual is nil", Factory: FactoryFor(gomega.BeNil)},
+	{Name: "BeTrue", Description: "succeeds if actual is true", Factory: FactoryFor(gomega.BeTrue)},
+	{Name: "BeFalse", Description: "succeeds if actual is false", Factory: FactoryFor(gomega.BeFalse)},
+	{Name: "BeZero", Description: "succeeds if actual is the zero value for its type", Factory: FactoryFor(gomega.BeZero)},
+	{Name: "BeNonZero", Description: "succeeds if actual is not the zero value for its type", Factory: FactoryFor(gomega.BeNonZero)},
+	{Name: "HaveOccurred", Description: "succeeds if actual is a non-nil error", Factory: FactoryFor(gomega.HaveOccurred)},
+	{Name: "Succeed", Description: "succeeds if actual is a nil error", Factory: FactoryFor(gomega.Succeed)},
+	{Name: "MatchError", Description: "succeeds if actual is an error matching the expected error, message, or matcher", Factory: FactoryFor(gomega.MatchError)},
+	{Name: "BeClosed", Description: "succeeds if actual is a closed channel", Factory: FactoryFor(gomega.BeClosed)},
+	{Name: "MatchRegexp", Description: "succeeds if actual matches the regular expression", Factory: FactoryFor(gomega.MatchRegexp)},
+	{Name: "ContainSubstring", Description: "succeeds if actual contains the substring", Factory: FactoryFor(gomega.ContainSubstring)},
+	{Name: "HavePrefix", Description: "succeeds if actual has the prefix", Factory: FactoryFor(gomega.HavePrefix)},
+	{Name: "HaveSuffix", Description: "succeeds if actual has the suffix", Factory: FactoryFor(gomega.HaveSuffix)},
+	{Name: "MatchJSON", Description: "succeeds if actual is JSON equivalent to the expected JSON", Factory: FactoryFor(gomega.MatchJSON)},
+	{Name: "MatchXML", Description: "succeeds if actual is XML equivalent to the expected XML", Factory: FactoryFor(gomega.MatchXML)},
+	{Name: "MatchYAML", Description: "succeeds if actual is YAML equivalent to the expected YAML", Factory: FactoryFor(gomega.MatchYAML)},
+	{Name: "BeEmpty", Description: "succeeds if actual is empty", Factory: FactoryFor(gomega.BeEmpty)},
+	{Name: "BeEmptyOrNil", Description: "succeeds if actual is nil or empty", Factory: FactoryFor(gomega.BeEmptyOrNil)},
+	{Name: "HaveLen", Description: "succeeds if actual has the given length", Factory: FactoryFor(gomega.HaveLen)},
+	{Name: "HaveCap", Description: "succeeds if actual has the given capacity", Factory: FactoryFor(gomega.HaveCap)},
+	{Name: "ContainElement", Description: "succeeds if actual contains an element matching the expected value", Factory: FactoryFor(gomega.ContainElement)},
+	{Name: "ContainElements", Description: "succeeds if actual contains elements matching each of the expected values", Factory: FactoryFor(gomega.ContainElements)},
+	{Name: "ConsistOf", Description: "succeeds if actual's elements match the expected values, in any order", Factory: FactoryFor(gomega.ConsistOf)},
+	{Name: "HaveExactElements", Description: "succeeds if actual's elements match the expected values, in order", Factory: FactoryFor(gomega.HaveExactElements)},
+	{Name: "HaveEach", Description: "succeeds if every element of actual matches the expected value", Factory: FactoryFor(gomega.HaveEach)},
+	{Name: "BeElementOf", Description: "succeeds if actual equals one of the expected values", Factory: FactoryFor(gomega.BeElementOf)},
+	{Name: "HaveKey", Description: "succeeds if actual is a map with a key matching the expected value", Factory: FactoryFor(gomega.HaveKey)},
+	{Name: "HaveKeyWithValue", Description: "succeeds if actual is a map with a matching key and value", Factory: FactoryFor(gomega.HaveKeyWithValue)},
+	{Name: "HaveField", Description: "succeeds if actual's field, at the given path, matches the expected value", Factory: FactoryFor(gomega.HaveField)},
+	{Name: "HaveExistingField", Description: "succeeds if actual has a field at the given path", Factory: FactoryFor(gomega.HaveExistingField)},
+	{Name: "HaveValue", Description: "succeeds if the value actual points to matches the matcher", Factory: FactoryFor(gomega.HaveValue)},
+	{Name: "BeNumerically", Description: "succeeds if actual compares to the expected number with the comparator", Factory: FactoryFor(gomega.BeNumerically)},
+	{Name: "BeTemporally", Description: "succeeds if actual compares to the expected time with the comparator", Factory: FactoryFor(gomega.BeTemporally)},
+	{Name: "BeAssignableToTypeOf", Description: "succeeds if actual is assignable to the type of the expected value", Factory: FactoryFor(gomega.BeAssignableToTypeOf)},
+	{Name: "Panic", Description: "succeeds if actual is a function that panics", Factory: FactoryFor(gomega.Panic)},
+	{Name: "PanicWith", Description: "succeeds if actual is a function that panics with a matching value", Factory: FactoryFor(gomega.PanicWith)},
+	{Name: "BeAnExistingFile", Description: "succeeds if a file exists at the path", Factory: FactoryFor(gomega.BeAnExistingFile)},
+	{Name: "BeARegularFile", Description: "succeeds if a regular file exists at the path", Factory: FactoryFor(gomega.BeARegularFile)},
+	{Name: "BeADirectory", Description: "succeeds if a directory exists at the path", Factory: FactoryFor(gomega.BeADirectory)},
+	{Name: "BeAnExistingCommand", Description: "succeeds if actual names an executable on the PATH", Factory: FactoryFor(gomega.BeAnExistingCommand)},
+	{Name: "HaveHTTPStatus", Description: "succeeds if the HTTP response has one of the expected statuses", Factory: FactoryFor(gomega.HaveHTTPStatus)},
+	{Name: "HaveHTTPHeaderWithValue", Description: "succeeds if the HTTP response has a matching header", Factory: FactoryFor(gomega.HaveHTTPHeaderWithValue)},
+	{Name: "HaveHTTPBody", Description: "succeeds if the HTTP response has a matching body", Factory: FactoryFor(gomega.HaveHTTPBody)},
+	{Name: "And", Description: "succeeds if actual satisfies all of the matchers", Factory: FactoryFor(gomega.And)},
+	{Name: "Or", Description: "succeeds if actual satisfies any of the matchers", Factory: FactoryFor(gomega.Or)},
+	{Name: "Not", Description: "succeeds if actual does not satisfy the matcher", Factory: FactoryFor(gomega.Not)},
+}
+
+func init() {
+	for _, registration := range builtins {
+		MustRegister(registration)
+	}
+}
@@ -0,0 +1,102 @@
+package gregistry
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/types"
+)
+
+var gomegaMatcherType = reflect.TypeOf((*types.GomegaMatcher)(nil)).Elem()
+
+/*
+FactoryFor turns constructor - any function that returns a types.GomegaMatcher, such as gomega.HaveLen - into a
+Factory.  The Factory checks that it is passed the right number of arguments and converts each to the type of the
+corresponding parameter: values assignable to the parameter are passed as is and numbers are converted to the
+parameter's numeric type, as long as no precision is lost.  This lets tools pass numbers decoded from JSON or YAML
+to matchers that expect an int.
+
+FactoryFor panics if constructor is not such a function.
+*/
+func FactoryFor(constructor interface{}) Factory {
+	fn := reflect.ValueOf(constructor)
+	fnType := fn.Type()
+	if fnType.Kind() != reflect.Func || fnType.NumOut() != 1 || !fnType.Out(0).Implements(gomegaMatcherType) {
+		panic(fmt.Sprintf("gregistry.FactoryFor expects a function that returns a types.GomegaMatcher.  Got %s", fnType))
+	}
+
+	return func(args ...interface{}) (types.GomegaMatcher, error) {
+		numIn := fnType.NumIn()
+		if fnType.IsVariadic() {
+			if len(args) < numIn-1 {
+				return nil, fmt.Errorf("expected at least %d arguments, got %d", numIn-1, len(args))
+			}
+		} else if len(args) != numIn {
+			return nil, fmt.Errorf("expected %d arguments, got %d", numIn, len(args))
+		}
+
+		in := make([]reflect.Value, len(args))
+		for i, arg := range args {
+			paramType := fnType.In(min(i, numIn-1))
+			if fnType.IsVariadic() && i >= numIn-1 {
+				paramType = paramType.Elem()
+			}
+			value, err := convertArgument(arg, paramType)
+			if err != nil {
+				return nil, fmt.Errorf("argument %d: %w", i, err)
+			}
+			in[i] = value
+		}
+		return fn.Call(in)[0].Interface().(types.GomegaMatcher), nil
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// convertArgument converts arg to t, allowing lossless conversions between numeric types
+func convertArgument(arg interface{}, t reflect.Type) (reflect.Value, error) {
+	if arg == nil {
+		switch t.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return reflect.Zero(t), nil
+		}
+		return reflect.Value{}, fmt.Errorf("cannot use nil as %s", t)
+	}
+
+	value := reflect.ValueOf(arg)
+	if value.Type().AssignableTo(t) {
+		return value, nil
+	}
+	if isNumeric(value.Kind()) && isNumeric(t.Kind()) {
+		converted := value.Convert(t)
+		if converted.Convert(value.Type()).Interface() == value.Interface() && !(isNegative(value) && isUnsigned(t.Kind())) {
+			return converted, nil
+		}
+		return reflect.Value{}, fmt.Errorf("cannot represent %v as %s", arg, t)
+	}
+	return reflect.Value{}, fmt.Errorf("cannot use %T as %s", arg, t)
+}
+
+func isNumeric(kind reflect.Kind) bool {
+	return reflect.Int <= kind && kind <= reflect.Float64
+}
+
+func isUnsigned(kind reflect.Kind) bool {
+	return reflect.Uint <= kind && kind <= reflect.Uintptr
+}
+
+// isNegative is needed because converting a negative number to an unsigned type and back round-trips
+func isNegative(value reflect.Value) bool {
+	switch {
+	case reflect.Int <= value.Kind() && value.Kind() <= reflect.Int64:
+		return value.Int() < 0
+	case value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64:
+		return value.Float() < 0
+	}
+	return false
+}
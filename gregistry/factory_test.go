@@ -0,0 +1,75 @@
+package gregistry_test
+
+import (
+	"math"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gregistry"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("FactoryFor", func() {
+	It("should pass arguments to the constructor", func() {
+		matcher, err := gregistry.FactoryFor(Equal)("hi")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect("hi").Should(matcher)
+	})
+
+	It("should support variadic constructors", func() {
+		factory := gregistry.FactoryFor(ContainSubstring)
+		matcher, err := factory("hi")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect("hi there").Should(matcher)
+
+		matcher, err = factory("%s there", "hi")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect("why, hi there").Should(matcher)
+
+		_, err = factory()
+		Expect(err).Should(MatchError("expected at least 1 arguments, got 0"))
+	})
+
+	It("should check the number of arguments", func() {
+		_, err := gregistry.FactoryFor(HaveLen)(1, 2)
+		Expect(err).Should(MatchError("expected 1 arguments, got 2"))
+	})
+
+	It("should convert numbers without losing precision", func() {
+		factory := gregistry.FactoryFor(HaveLen)
+		matcher, err := factory(2.0)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect("hi").Should(matcher)
+
+		matcher, err = factory(int64(2))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect("hi").Should(matcher)
+
+		_, err = factory(2.5)
+		Expect(err).Should(MatchError("argument 0: cannot represent 2.5 as int"))
+		_, err = factory(math.Inf(1))
+		Expect(err).Should(HaveOccurred())
+
+		_, err = gregistry.FactoryFor(func(n uint) types.GomegaMatcher { return HaveLen(int(n)) })(-1)
+		Expect(err).Should(MatchError("argument 0: cannot represent -1 as uint"))
+	})
+
+	It("should reject arguments of the wrong type", func() {
+		_, err := gregistry.FactoryFor(HaveLen)("two")
+		Expect(err).Should(MatchError("argument 0: cannot use string as int"))
+		_, err = gregistry.FactoryFor(HavePrefix)(nil)
+		Expect(err).Should(MatchError("argument 0: cannot use nil as string"))
+	})
+
+	It("should pass nil to parameters that accept it", func() {
+		matcher, err := gregistry.FactoryFor(Equal)(nil)
+		Expect(err).ShouldNot(HaveOccurred())
+		_, err = matcher.Match(nil)
+		Expect(err).Should(MatchError(ContainSubstring("Refusing to compare <nil> to <nil>")))
+	})
+
+	It("should panic if not passed a matcher constructor", func() {
+		Expect(func() { gregistry.FactoryFor("HaveLen") }).Should(PanicWith(ContainSubstring("gregistry.FactoryFor expects a function that returns a types.GomegaMatcher.  Got string")))
+		Expect(func() { gregistry.FactoryFor(func() int { return 0 }) }).Should(Panic())
+	})
+})
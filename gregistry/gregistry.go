@@ -0,0 +1,139 @@
+/*
+Package gregistry is a process-wide registry of named matcher factories.  It lets tools that build matchers from
+names - a parser for matchers written as strings, a command-line tool, a configuration-driven test runner - discover
+Gomega's matchers along with any organization-specific matchers linked into the binary.
+
+Packages that provide matchers register them in an init function, so importing the package (or loading it as a Go
+plugin) is enough to make them discoverable:
+
+	func init() {
+		gregistry.MustRegister(gregistry.Registration{
+			Name:        "acme.HaveTraceID",
+			Description: "succeeds if a request carries the given trace id",
+			Factory:     gregistry.FactoryFor(HaveTraceID),
+		})
+	}
+
+Tools then look matchers up by name:
+
+	matcher, err := gregistry.Build("acme.HaveTraceID", "abc123")
+
+Gomega's own matchers are registered under their names (e.g. "Equal", "HaveLen", "ContainSubstring").  Matchers
+provided by other packages should be registered under a dotted prefix to avoid collisions.
+*/
+package gregistry
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/onsi/gomega/types"
+)
+
+/*
+A Factory builds a matcher from a list of arguments.  It should return an error, rather than panic, if the arguments
+are not ones it can build a matcher from.  FactoryFor turns a matcher constructor into a Factory.
+*/
+type Factory func(args ...interface{}) (types.GomegaMatcher, error)
+
+/*
+A Registration describes a matcher in the registry.
+*/
+type Registration struct {
+	// Name identifies the matcher.  It must be a Go identifier, optionally preceded by dot-separated identifiers
+	// - e.g. "HaveLen" or "acme.HaveTraceID".
+	Name string
+	// Description is a one-line summary of the matcher, for tools that list the registry
+	Description string
+	// Factory builds the matcher
+	Factory Factory
+}
+
+var validName = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*\.)*[A-Za-z_][A-Za-z0-9_]*$`)
+
+var (
+	lock          = &sync.RWMutex{}
+	registrations = map[string]Registration{}
+)
+
+/*
+Register adds registration to the registry.  It returns an error if the registration's name is invalid or already
+registered, or if it has no Factory.
+*/
+func Register(registration Registration) error {
+	if !validName.MatchString(registration.Name) {
+		return fmt.Errorf("gregistry: %q is not a valid matcher name", registration.Name)
+	}
+	if registration.Factory == nil {
+		return fmt.Errorf("gregistry: matcher %s has no factory", registration.Name)
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	if _, ok := registrations[registration.Name]; ok {
+		return fmt.Errorf("gregistry: a matcher named %s is already registered", registration.Name)
+	}
+	registrations[registration.Name] = registration
+	return nil
+}
+
+/*
+MustRegister is like Register but panics if registration cannot be registered.  It is intended to be called from
+init functions, where a conflicting registration is a programming error.
+*/
+func MustRegister(registration Registration) {
+	if err := Register(registration); err != nil {
+		panic(err)
+	}
+}
+
+/*
+Unregister removes the matcher named name from the registry.  It does nothing if no such matcher is registered.
+*/
+func Unregister(name string) {
+	lock.Lock()
+	defer lock.Unlock()
+	delete(registrations, name)
+}
+
+/*
+Lookup returns the registration of the matcher named name.
+*/
+func Lookup(name string) (Registration, bool) {
+	lock.RLock()
+	defer lock.RUnlock()
+	registration, ok := registrations[name]
+	return registration, ok
+}
+
+/*
+Registrations returns every registration in the registry, ordered by name.
+*/
+func Registrations() []Registration {
+	lock.RLock()
+	defer lock.RUnlock()
+	out := make([]Registration, 0, len(registrations))
+	for _, registration := range registrations {
+		out = append(out, registration)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+/*
+Build builds the matcher named name from args.  It returns an error if no such matcher is registered or if its
+Factory cannot build a matcher from args.
+*/
+func Build(name string, args ...interface{}) (types.GomegaMatcher, error) {
+	registration, ok := Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("gregistry: no matcher named %s is registered", name)
+	}
+	matcher, err := registration.Factory(args...)
+	if err != nil {
+		return nil, fmt.Errorf("gregistry: could not build %s: %w", name, err)
+	}
+	return matcher, nil
+}
@@ -0,0 +1,13 @@
+package gregistry_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGregistry(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gregistry Suite")
+}
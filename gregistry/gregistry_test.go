@@ -0,0 +1,94 @@
+package gregistry_test
+
+import (
+	"sort"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gregistry"
+	"github.com/onsi/gomega/types"
+)
+
+func haveTraceID(id string) types.GomegaMatcher {
+	return HaveKeyWithValue("trace-id", id)
+}
+
+var _ = Describe("The matcher registry", func() {
+	registration := gregistry.Registration{
+		Name:        "acme.HaveTraceID",
+		Description: "succeeds if the headers carry the trace id",
+		Factory:     gregistry.FactoryFor(haveTraceID),
+	}
+
+	BeforeEach(func() {
+		Expect(gregistry.Register(registration)).Should(Succeed())
+		DeferCleanup(gregistry.Unregister, registration.Name)
+	})
+
+	It("should build registered matchers", func() {
+		matcher, err := gregistry.Build("acme.HaveTraceID", "abc123")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(map[string]string{"trace-id": "abc123"}).Should(matcher)
+		Expect(map[string]string{"trace-id": "def456"}).ShouldNot(matcher)
+	})
+
+	It("should look up registrations", func() {
+		found, ok := gregistry.Lookup("acme.HaveTraceID")
+		Expect(ok).Should(BeTrue())
+		Expect(found.Description).Should(Equal(registration.Description))
+
+		_, ok = gregistry.Lookup("acme.HaveSpanID")
+		Expect(ok).Should(BeFalse())
+	})
+
+	It("should list registrations by name", func() {
+		names := []string{}
+		for _, registration := range gregistry.Registrations() {
+			names = append(names, registration.Name)
+		}
+		Expect(names).Should(ContainElements("Equal", "HaveLen", "acme.HaveTraceID"))
+		Expect(sort.StringsAreSorted(names)).Should(BeTrue())
+	})
+
+	It("should remove unregistered matchers", func() {
+		gregistry.Unregister("acme.HaveTraceID")
+		_, err := gregistry.Build("acme.HaveTraceID", "abc123")
+		Expect(err).Should(MatchError("gregistry: no matcher named acme.HaveTraceID is registered"))
+	})
+
+	It("should reject duplicate and invalid registrations", func() {
+		Expect(gregistry.Register(registration)).Should(MatchError("gregistry: a matcher named acme.HaveTraceID is already registered"))
+		Expect(func() { gregistry.MustRegister(registration) }).Should(Panic())
+
+		Expect(gregistry.Register(gregistry.Registration{Name: "acme.Have Trace", Factory: registration.Factory})).Should(MatchError(`gregistry: "acme.Have Trace" is not a valid matcher name`))
+		Expect(gregistry.Register(gregistry.Registration{Name: "acme.", Factory: registration.Factory})).Should(HaveOccurred())
+		Expect(gregistry.Register(gregistry.Registration{Name: "acme.HaveSpanID"})).Should(MatchError("gregistry: matcher acme.HaveSpanID has no factory"))
+	})
+
+	It("should report factory errors", func() {
+		_, err := gregistry.Build("acme.HaveTraceID")
+		Expect(err).Should(MatchError("gregistry: could not build acme.HaveTraceID: expected 1 arguments, got 0"))
+	})
+
+	Describe("Gomega's matchers", func() {
+		It("should be registered", func() {
+			for _, name := range []string{"Equal", "BeNil", "HaveLen", "ContainSubstring", "ConsistOf", "And", "Or", "Not", "BeNumerically"} {
+				_, ok := gregistry.Lookup(name)
+				Expect(ok).Should(BeTrue(), name)
+			}
+		})
+
+		It("should compose", func() {
+			haveLen, err := gregistry.Build("HaveLen", 3.0)
+			Expect(err).ShouldNot(HaveOccurred())
+			prefix, err := gregistry.Build("HavePrefix", "ab")
+			Expect(err).ShouldNot(HaveOccurred())
+			and, err := gregistry.Build("And", haveLen, prefix)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect("abc").Should(and)
+			Expect("abcd").ShouldNot(and)
+			Expect("xyz").ShouldNot(and)
+		})
+	})
+})
@@ -0,0 +1,125 @@
+/*
+Package gsql provides matchers for asserting on what database/sql queries return, so that integration tests against
+a test database don't each reimplement scanning rows:
+
+	Expect(gsql.Query(db, "SELECT id FROM users WHERE org_id = $1", orgID)).To(gsql.HaveRowCount(3))
+	Eventually(gsql.Query(db, "SELECT name, active FROM users")).Should(gsql.ReturnRows(ContainElement(
+		MatchAllKeys(Keys{"name": Equal("alice"), "active": BeTrue()}),
+	)))
+
+The matchers accept a Query, which is run every time the matcher is - so Eventually polls the database - or an
+*sql.Rows, which is read once and cached by the matcher.
+*/
+package gsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+// Queryer runs queries.  *sql.DB, *sql.Tx and *sql.Conn are Queryers.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// QueryInDB identifies a query to run against a database.  Pass one to the gsql matchers to assert on what the
+// query returns.
+type QueryInDB struct {
+	DB    Queryer
+	Query string
+	Args  []interface{}
+}
+
+/*
+Query returns a QueryInDB that runs query, with args, against db:
+
+	Expect(gsql.Query(db, "SELECT * FROM jobs WHERE state = ?", "failed")).To(gsql.HaveRowCount(0))
+*/
+func Query(db Queryer, query string, args ...interface{}) QueryInDB {
+	return QueryInDB{DB: db, Query: query, Args: args}
+}
+
+// GomegaString keeps failure messages to the query and its arguments rather than the internals of the database handle
+func (q QueryInDB) GomegaString() string {
+	if len(q.Args) == 0 {
+		return fmt.Sprintf("%q", q.Query)
+	}
+	args := make([]string, len(q.Args))
+	for i, arg := range q.Args {
+		args[i] = fmt.Sprintf("%#v", arg)
+	}
+	return fmt.Sprintf("%q with arguments %s", q.Query, strings.Join(args, ", "))
+}
+
+/*
+Row is a row returned by a query, mapping column names to values.  Values are those returned by the database driver -
+typically int64, float64, bool, string, time.Time or nil - except that []byte values are converted to strings.
+*/
+type Row = map[string]interface{}
+
+// rowSource gives the gsql matchers uniform access to the rows returned by a QueryInDB or held in an *sql.Rows
+type rowSource struct {
+	query *QueryInDB
+	rows  *sql.Rows
+
+	// description identifies the rows in failure messages
+	description string
+}
+
+func toRowSource(matcherName string, actual interface{}) (rowSource, error) {
+	switch x := actual.(type) {
+	case QueryInDB:
+		if x.DB == nil {
+			return rowSource{}, fmt.Errorf("%s matcher expects a gsql.QueryInDB with a database.  Got nil", matcherName)
+		}
+		return rowSource{query: &x, description: "query " + x.GomegaString()}, nil
+	case *sql.Rows:
+		if x != nil {
+			return rowSource{rows: x, description: "the rows"}, nil
+		}
+	}
+	return rowSource{}, fmt.Errorf("%s matcher expects a gsql.QueryInDB or an *sql.Rows.  Got:\n%s", matcherName, format.Object(actual, 1))
+}
+
+// read runs the query, if there is one, and reads every row
+func (source rowSource) read() ([]Row, error) {
+	rows := source.rows
+	if source.query != nil {
+		var err error
+		rows, err = source.query.DB.QueryContext(context.Background(), source.query.Query, source.query.Args...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	result := []Row{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		row := Row{}
+		for i, column := range columns {
+			if b, ok := values[i].([]byte); ok {
+				row[column] = string(b)
+			} else {
+				row[column] = values[i]
+			}
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,13 @@
+package gsql_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGsql(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gsql Suite")
+}
@@ -0,0 +1,121 @@
+package gsql_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gsql"
+)
+
+// fakeResult is what the fake driver returns for a query
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+// fakeDatabase maps queries - with their arguments formatted by fmt.Sprint - to their results
+type fakeDatabase struct {
+	lock    sync.Mutex
+	results map[string]fakeResult
+}
+
+func (db *fakeDatabase) set(query string, result fakeResult) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+	db.results[query] = result
+}
+
+func (db *fakeDatabase) Connect(context.Context) (driver.Conn, error) { return fakeConn{db}, nil }
+func (db *fakeDatabase) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ db *fakeDatabase }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.db, query}, nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return nil, errors.New("transactions are not supported") }
+
+type fakeStmt struct {
+	db    *fakeDatabase
+	query string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errors.New("exec is not supported")
+}
+func (s fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	key := s.query
+	if len(args) > 0 {
+		key += fmt.Sprint(args)
+	}
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+	result, ok := s.db.results[key]
+	if !ok {
+		return nil, fmt.Errorf("no such table")
+	}
+	return &fakeRows{result: result}, nil
+}
+
+type fakeRows struct {
+	result fakeResult
+	next   int
+}
+
+func (r *fakeRows) Columns() []string { return r.result.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next == len(r.result.rows) {
+		return io.EOF
+	}
+	copy(dest, r.result.rows[r.next])
+	r.next++
+	return nil
+}
+
+func newFakeDB() (*sql.DB, *fakeDatabase) {
+	fake := &fakeDatabase{results: map[string]fakeResult{}}
+	return sql.OpenDB(fake), fake
+}
+
+var usersResult = fakeResult{
+	columns: []string{"id", "name", "role"},
+	rows: [][]driver.Value{
+		{int64(1), []byte("alice"), "admin"},
+		{int64(2), []byte("bob"), nil},
+	},
+}
+
+var _ = Describe("Query", func() {
+	It("should describe the query and its arguments", func() {
+		Expect(gsql.Query(nil, "SELECT * FROM users").GomegaString()).Should(Equal(`"SELECT * FROM users"`))
+		Expect(gsql.Query(nil, "SELECT * FROM users WHERE id = ? AND name = ?", 3, "bob").GomegaString()).Should(Equal(`"SELECT * FROM users WHERE id = ? AND name = ?" with arguments 3, "bob"`))
+	})
+
+	It("should run the query with its arguments", func() {
+		db, fake := newFakeDB()
+		defer db.Close()
+		fake.set("SELECT * FROM users WHERE id = ?[1]", fakeResult{columns: usersResult.columns, rows: usersResult.rows[:1]})
+		Expect(gsql.Query(db, "SELECT * FROM users WHERE id = ?", 1)).Should(gsql.ReturnRows(ConsistOf(
+			gsql.Row{"id": int64(1), "name": "alice", "role": "admin"},
+		)))
+	})
+
+	It("should run against transactions and connections", func() {
+		db, fake := newFakeDB()
+		defer db.Close()
+		fake.set("SELECT * FROM users", usersResult)
+		conn, err := db.Conn(context.Background())
+		Expect(err).ShouldNot(HaveOccurred())
+		defer conn.Close()
+		Expect(gsql.Query(conn, "SELECT * FROM users")).Should(gsql.HaveRowCount(2))
+	})
+})
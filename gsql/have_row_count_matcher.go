@@ -0,0 +1,86 @@
+package gsql
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+/*
+HaveRowCount succeeds if actual - a QueryInDB or an *sql.Rows - returns a number of rows that satisfies expected.
+The count is passed to expected as an int.  expected can be a number or a matcher; numbers are compared with
+BeNumerically("==", ...) so their type does not matter:
+
+	Expect(gsql.Query(db, "SELECT * FROM users")).To(gsql.HaveRowCount(3))
+	Eventually(gsql.Query(db, "SELECT * FROM jobs WHERE state = 'done'")).Should(gsql.HaveRowCount(BeNumerically(">=", 10)))
+*/
+func HaveRowCount(expected interface{}) types.GomegaMatcher {
+	return &HaveRowCountMatcher{
+		Expected: expected,
+	}
+}
+
+type HaveRowCountMatcher struct {
+	Expected interface{}
+
+	// state
+	source          rowSource
+	cache           rowCache
+	count           int
+	expectedMatcher types.GomegaMatcher
+}
+
+func (matcher *HaveRowCountMatcher) Match(actual interface{}) (success bool, err error) {
+	rows, err := readRows("HaveRowCount", actual, &matcher.source, &matcher.cache)
+	if err != nil {
+		return false, err
+	}
+	matcher.count = len(rows)
+
+	var isMatcher bool
+	matcher.expectedMatcher, isMatcher = matcher.Expected.(types.GomegaMatcher)
+	if !isMatcher {
+		matcher.expectedMatcher = &matchers.BeNumericallyMatcher{Comparator: "==", CompareTo: []interface{}{matcher.Expected}}
+	}
+	return matcher.expectedMatcher.Match(matcher.count)
+}
+
+func (matcher *HaveRowCountMatcher) FailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Row count of %s failed to satisfy matcher.\n", matcher.source.description)
+	return message + matcher.expectedMatcher.FailureMessage(matcher.count)
+}
+
+func (matcher *HaveRowCountMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Row count of %s satisfied matcher, but should not have.\n", matcher.source.description)
+	return message + matcher.expectedMatcher.NegatedFailureMessage(matcher.count)
+}
+
+// rowCache holds the rows read from an *sql.Rows, along with the *sql.Rows they were read from
+type rowCache struct {
+	source *sql.Rows
+	rows   []Row
+}
+
+// readRows reads the rows actual identifies.  The rows of an *sql.Rows can only be read once, so they are cached for
+// as long as the matcher is passed the same *sql.Rows.
+func readRows(matcherName string, actual interface{}, source *rowSource, cache *rowCache) ([]Row, error) {
+	var err error
+	*source, err = toRowSource(matcherName, actual)
+	if err != nil {
+		return nil, err
+	}
+	if source.rows != nil && source.rows == cache.source {
+		return cache.rows, nil
+	}
+	rows, err := source.read()
+	if err != nil {
+		return nil, fmt.Errorf("%s matcher could not read %s:\n%s", matcherName, source.description, format.IndentString(err.Error(), 1))
+	}
+	if source.rows != nil {
+		cache.source, cache.rows = source.rows, rows
+	}
+	return rows, nil
+}
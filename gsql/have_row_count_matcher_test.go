@@ -0,0 +1,77 @@
+package gsql_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gsql"
+)
+
+var _ = Describe("HaveRowCount", func() {
+	var db *sql.DB
+	var fake *fakeDatabase
+
+	BeforeEach(func() {
+		db, fake = newFakeDB()
+		DeferCleanup(db.Close)
+		fake.set("SELECT * FROM users", usersResult)
+		fake.set("SELECT * FROM jobs", fakeResult{columns: []string{"id"}})
+	})
+
+	It("should count the rows a query returns", func() {
+		Expect(gsql.Query(db, "SELECT * FROM users")).Should(gsql.HaveRowCount(2))
+		Expect(gsql.Query(db, "SELECT * FROM users")).Should(gsql.HaveRowCount(uint8(2)))
+		Expect(gsql.Query(db, "SELECT * FROM users")).Should(gsql.HaveRowCount(BeNumerically(">", 1)))
+		Expect(gsql.Query(db, "SELECT * FROM jobs")).Should(gsql.HaveRowCount(0))
+		Expect(gsql.Query(db, "SELECT * FROM users")).ShouldNot(gsql.HaveRowCount(3))
+	})
+
+	It("should run the query every time it matches", func() {
+		go func() {
+			defer GinkgoRecover()
+			fake.set("SELECT * FROM jobs", fakeResult{columns: []string{"id"}, rows: [][]driver.Value{{int64(1)}}})
+		}()
+		Eventually(gsql.Query(db, "SELECT * FROM jobs")).Should(gsql.HaveRowCount(1))
+	})
+
+	It("should count the rows of an *sql.Rows, and cache them", func() {
+		rows, err := db.QueryContext(context.Background(), "SELECT * FROM users")
+		Expect(err).ShouldNot(HaveOccurred())
+		matcher := gsql.HaveRowCount(2)
+		Expect(rows).Should(matcher)
+		Expect(rows).Should(matcher)
+	})
+
+	It("should only use the cached rows for the *sql.Rows they were read from", func() {
+		matcher := gsql.HaveRowCount(2)
+		users, err := db.QueryContext(context.Background(), "SELECT * FROM users")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(users).Should(matcher)
+		jobs, err := db.QueryContext(context.Background(), "SELECT * FROM jobs")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(jobs).ShouldNot(matcher)
+	})
+
+	It("should error when actual is not a query or rows, or the query fails", func() {
+		_, err := gsql.HaveRowCount(2).Match("SELECT * FROM users")
+		Expect(err).Should(MatchError(ContainSubstring("HaveRowCount matcher expects a gsql.QueryInDB or an *sql.Rows.  Got:")))
+		_, err = gsql.HaveRowCount(2).Match(gsql.Query(nil, "SELECT * FROM users"))
+		Expect(err).Should(MatchError("HaveRowCount matcher expects a gsql.QueryInDB with a database.  Got nil"))
+		_, err = gsql.HaveRowCount(2).Match(gsql.Query(db, "SELECT * FROM missing"))
+		Expect(err).Should(MatchError("HaveRowCount matcher could not read query \"SELECT * FROM missing\":\n    no such table"))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect(gsql.Query(db, "SELECT * FROM users")).Should(gsql.HaveRowCount(3))
+			Expect(gsql.Query(db, "SELECT * FROM users")).ShouldNot(gsql.HaveRowCount(2))
+		})
+		Expect(failures).Should(Equal([]string{
+			"Row count of query \"SELECT * FROM users\" failed to satisfy matcher.\nExpected\n    <int>: 2\nto be ==\n    <int>: 3",
+			"Row count of query \"SELECT * FROM users\" satisfied matcher, but should not have.\nExpected\n    <int>: 2\nnot to be ==\n    <int>: 2",
+		}))
+	})
+})
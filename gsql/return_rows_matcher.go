@@ -0,0 +1,59 @@
+package gsql
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/types"
+)
+
+/*
+ReturnRows succeeds if actual - a QueryInDB or an *sql.Rows - returns rows that satisfy matcher.  The rows are passed
+to matcher as a []Row, each mapping column names to values, so the collection matchers and gstruct's MatchKeys work as
+is:
+
+	Expect(gsql.Query(db, "SELECT name, role FROM users")).To(gsql.ReturnRows(ConsistOf(
+		gsql.Row{"name": "alice", "role": "admin"},
+		gsql.Row{"name": "bob", "role": "viewer"},
+	)))
+	Expect(gsql.Query(db, "SELECT * FROM users WHERE id = $1", id)).To(gsql.ReturnRows(ConsistOf(
+		MatchKeys(IgnoreExtras, Keys{"email": HaveSuffix("@example.com"), "age": BeNumerically(">=", 18)}),
+	)))
+
+Values are those returned by the database driver, except that []byte values are converted to strings.  Drivers
+typically return integers as int64s, so compare them with BeNumerically or BeEquivalentTo rather than Equal.
+*/
+func ReturnRows(matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &ReturnRowsMatcher{
+		Matcher: matcher,
+	}
+}
+
+type ReturnRowsMatcher struct {
+	Matcher types.GomegaMatcher
+
+	// state
+	source rowSource
+	cache  rowCache
+	rows   []Row
+}
+
+func (matcher *ReturnRowsMatcher) Match(actual interface{}) (success bool, err error) {
+	if matcher.Matcher == nil {
+		return false, fmt.Errorf("ReturnRows matcher expects a matcher for the rows.  Got nil")
+	}
+	matcher.rows, err = readRows("ReturnRows", actual, &matcher.source, &matcher.cache)
+	if err != nil {
+		return false, err
+	}
+	return matcher.Matcher.Match(matcher.rows)
+}
+
+func (matcher *ReturnRowsMatcher) FailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Rows returned by %s failed to satisfy matcher.\n", matcher.source.description)
+	return message + matcher.Matcher.FailureMessage(matcher.rows)
+}
+
+func (matcher *ReturnRowsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Rows returned by %s satisfied matcher, but should not have.\n", matcher.source.description)
+	return message + matcher.Matcher.NegatedFailureMessage(matcher.rows)
+}
@@ -0,0 +1,57 @@
+package gsql_test
+
+import (
+	"context"
+	"database/sql"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gsql"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("ReturnRows", func() {
+	var db *sql.DB
+
+	BeforeEach(func() {
+		var fake *fakeDatabase
+		db, fake = newFakeDB()
+		DeferCleanup(db.Close)
+		fake.set("SELECT * FROM users", usersResult)
+	})
+
+	It("should map rows to column names and values, converting []byte values to strings", func() {
+		Expect(gsql.Query(db, "SELECT * FROM users")).Should(gsql.ReturnRows(ConsistOf(
+			gsql.Row{"id": int64(2), "name": "bob", "role": nil},
+			gsql.Row{"id": int64(1), "name": "alice", "role": "admin"},
+		)))
+		Expect(gsql.Query(db, "SELECT * FROM users")).ShouldNot(gsql.ReturnRows(BeEmpty()))
+	})
+
+	It("should work with gstruct", func() {
+		Expect(gsql.Query(db, "SELECT * FROM users")).Should(gsql.ReturnRows(ContainElement(
+			MatchKeys(IgnoreExtras, Keys{"id": BeNumerically("==", 1), "role": Equal("admin")}),
+		)))
+	})
+
+	It("should read an *sql.Rows", func() {
+		rows, err := db.QueryContext(context.Background(), "SELECT * FROM users")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(rows).Should(gsql.ReturnRows(HaveLen(2)))
+	})
+
+	It("should error when it is not passed a matcher", func() {
+		_, err := gsql.ReturnRows(nil).Match(gsql.Query(db, "SELECT * FROM users"))
+		Expect(err).Should(MatchError("ReturnRows matcher expects a matcher for the rows.  Got nil"))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect(gsql.Query(db, "SELECT * FROM users")).Should(gsql.ReturnRows(HaveLen(3)))
+			Expect(gsql.Query(db, "SELECT * FROM users")).ShouldNot(gsql.ReturnRows(HaveLen(2)))
+		})
+		Expect(failures[0]).Should(HavePrefix("Rows returned by query \"SELECT * FROM users\" failed to satisfy matcher.\nExpected\n    <[]map[string]interface {} | len:2, cap:2>: ["))
+		Expect(failures[0]).Should(HaveSuffix("to have length 3"))
+		Expect(failures[1]).Should(HavePrefix("Rows returned by query \"SELECT * FROM users\" satisfied matcher, but should not have.\n"))
+	})
+})
@@ -0,0 +1,22 @@
+package gstruct
+
+import (
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+//HaveCookie succeeds if an *http.Response or *httptest.ResponseRecorder sets the named cookie (see
+//gomega.HaveHTTPCookie) and the *http.Cookie's attributes match the given fields.  Attributes that are not
+//mentioned are ignored.
+//  Expect(resp).To(HaveCookie("session", Fields{
+//      "Path":     Equal("/"),
+//      "Secure":   BeTrue(),
+//      "HttpOnly": BeTrue(),
+//      "SameSite": Equal(http.SameSiteStrictMode),
+//  }))
+func HaveCookie(name string, fields Fields) types.GomegaMatcher {
+	return &matchers.HaveHTTPCookieMatcher{
+		Name:    name,
+		Matcher: PointTo(MatchFields(IgnoreExtras, fields)),
+	}
+}
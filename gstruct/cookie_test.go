@@ -0,0 +1,48 @@
+package gstruct_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("HaveCookie", func() {
+	var resp *http.Response
+
+	BeforeEach(func() {
+		rec := httptest.NewRecorder()
+		http.SetCookie(rec, &http.Cookie{Name: "session", Value: "abc123", Path: "/", MaxAge: 3600, Secure: true, HttpOnly: true, SameSite: http.SameSiteLaxMode})
+		resp = rec.Result()
+	})
+
+	It("should match the given attributes and ignore the rest", func() {
+		Expect(resp).Should(HaveCookie("session", Fields{
+			"Value":    Equal("abc123"),
+			"Path":     Equal("/"),
+			"Secure":   BeTrue(),
+			"HttpOnly": BeTrue(),
+			"SameSite": Equal(http.SameSiteLaxMode),
+			"MaxAge":   Equal(3600),
+		}))
+	})
+
+	It("should report the attributes that failed", func() {
+		m := HaveCookie("session", Fields{
+			"Secure":   BeFalse(),
+			"SameSite": Equal(http.SameSiteStrictMode),
+		})
+		Expect(m.Match(resp)).Should(BeFalse())
+		message := m.FailureMessage(resp)
+		Expect(message).Should(HavePrefix("HTTP cookie \"session\":\n"))
+		Expect(message).Should(ContainSubstring(".Secure:"))
+		Expect(message).Should(ContainSubstring(".SameSite:"))
+		Expect(message).Should(HaveSuffix("The response sets these cookies:\n    session=abc123; Path=/; Max-Age=3600; HttpOnly; Secure; SameSite=Lax"))
+	})
+
+	It("should fail when the cookie is not set", func() {
+		Expect(resp).ShouldNot(HaveCookie("theme", Fields{}))
+	})
+})
@@ -3,14 +3,15 @@
 package gstruct
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 	"runtime/debug"
 	"strconv"
+	"strings"
 
 	"github.com/onsi/gomega/format"
 	errorsutil "github.com/onsi/gomega/gstruct/errors"
+	"github.com/onsi/gomega/internal/gutil"
 	"github.com/onsi/gomega/types"
 )
 
@@ -71,6 +72,8 @@ func MatchElements(identifier Identifier, options Options, elements Elements) ty
 		IgnoreExtras:    options&IgnoreExtras != 0,
 		IgnoreMissing:   options&IgnoreMissing != 0,
 		AllowDuplicates: options&AllowDuplicates != 0,
+		ReportFullPaths: options&ReportFullPaths != 0,
+		DerefPointers:   options&DerefPointers != 0,
 	}
 }
 
@@ -97,12 +100,46 @@ func MatchElementsWithIndex(identifier IdentifierWithIndex, options Options, ele
 		IgnoreExtras:    options&IgnoreExtras != 0,
 		IgnoreMissing:   options&IgnoreMissing != 0,
 		AllowDuplicates: options&AllowDuplicates != 0,
+		ReportFullPaths: options&ReportFullPaths != 0,
+		DerefPointers:   options&DerefPointers != 0,
 	}
 }
 
-// ElementsMatcher is a NestingMatcher that applies custom matchers to each element of a slice mapped
-// by the Identifier function.
-// TODO: Extend this to work with arrays & maps (map the key) as well.
+//MatchAllElementsWithError succeeds if every element of a slice matches the element matcher it maps to
+//through the id function, and every element matcher is matched.  The id function can return an error
+//for an element it can't identify, which fails the match.
+//    Expect(pods).To(MatchAllElementsWithError(IdentifyByFields("Namespace", "Name"), Elements{
+//        "default/web": MatchFields(IgnoreExtras, Fields{"Ready": BeTrue()}),
+//        "default/db":  MatchFields(IgnoreExtras, Fields{"Ready": BeTrue()}),
+//    }))
+func MatchAllElementsWithError(identifier IdentifierWithError, elements Elements) types.GomegaMatcher {
+	return &ElementsMatcher{
+		Identifier: identifier,
+		Elements:   elements,
+	}
+}
+
+//MatchElementsWithError succeeds if each element of a slice matches the element matcher it maps to
+//through the id function. It can ignore extra elements and/or missing elements.  The id function can
+//return an error for an element it can't identify, which fails the match.
+//    Expect(pods).To(MatchElementsWithError(IdentifyByFields("Namespace", "Name"), IgnoreExtras, Elements{
+//        "default/web": MatchFields(IgnoreExtras, Fields{"Ready": BeTrue()}),
+//    }))
+func MatchElementsWithError(identifier IdentifierWithError, options Options, elements Elements) types.GomegaMatcher {
+	return &ElementsMatcher{
+		Identifier:      identifier,
+		Elements:        elements,
+		IgnoreExtras:    options&IgnoreExtras != 0,
+		IgnoreMissing:   options&IgnoreMissing != 0,
+		AllowDuplicates: options&AllowDuplicates != 0,
+		ReportFullPaths: options&ReportFullPaths != 0,
+		DerefPointers:   options&DerefPointers != 0,
+	}
+}
+
+// ElementsMatcher is a NestingMatcher that applies custom matchers to each element of a slice or array mapped
+// by the Identifier function.  It also matches the values of maps, in key order: the Identifier is passed the
+// key of each value, and its index in key order.
 type ElementsMatcher struct {
 	// Matchers for each element.
 	Elements Elements
@@ -115,9 +152,16 @@ type ElementsMatcher struct {
 	IgnoreMissing bool
 	// Whether to key duplicates when matching IDs.
 	AllowDuplicates bool
+	// Whether to report every nested mismatch as a flat list with full paths.
+	ReportFullPaths bool
+	// Whether to accept a pointer to a slice, array, or map, and to dereference pointer elements before matching them.
+	DerefPointers bool
 
 	// State.
-	failures []error
+	failures          []error
+	legacy            []error
+	provenance        types.Provenance
+	trackedProvenance types.Provenance
 }
 
 // Element ID to matcher.
@@ -141,45 +185,151 @@ func (i IdentifierWithIndex) WithIndexAndElement(index int, element interface{})
 	return i(index, element)
 }
 
+// Function for identifying (mapping) elements that may fail to identify an element.
+type IdentifierWithError func(element interface{}) (string, error)
+
+// Calls the underlying function with the provided params, panicking if it returns an error.
+// ElementsMatcher calls IdentifyWithIndexAndElement instead, and fails the match.
+func (i IdentifierWithError) WithIndexAndElement(index int, element interface{}) string {
+	id, err := i(element)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Calls the underlying function with the provided params.
+// IdentifierWithError drops the index.
+func (i IdentifierWithError) IdentifyWithIndexAndElement(index int, element interface{}) (string, error) {
+	return i(element)
+}
+
 // Interface for identifing the element
 type Identify interface {
 	WithIndexAndElement(i int, element interface{}) string
 }
 
+// Interface for identifying the element, implemented by identifiers that may fail to identify an element
+type IdentifyWithError interface {
+	IdentifyWithIndexAndElement(i int, element interface{}) (string, error)
+}
+
+// KeyIdentity is a helper function for using a map's keys, formatted with %v, as
+// the keys in the element map
+func KeyIdentity(key interface{}) string {
+	return fmt.Sprintf("%v", key)
+}
+
 // IndexIdentity is a helper function for using an index as
 // the key in the element map
 func IndexIdentity(index int, _ interface{}) string {
 	return strconv.Itoa(index)
 }
 
+// CompositeIDSeparator separates the parts of the IDs built by CompositeID and IdentifyByFields
+const CompositeIDSeparator = "/"
+
+// CompositeID builds an element ID out of several parts, formatted with %v and joined with
+// CompositeIDSeparator.  It returns an error if a part contains the separator, as the ID would be ambiguous.
+//    id, err := CompositeID(pod.Namespace, pod.Name) // "default/web"
+func CompositeID(parts ...interface{}) (string, error) {
+	formatted := make([]string, len(parts))
+	for i, part := range parts {
+		formatted[i] = fmt.Sprintf("%v", part)
+		if strings.Contains(formatted[i], CompositeIDSeparator) {
+			return "", fmt.Errorf("cannot build an unambiguous ID as part %q contains %q", formatted[i], CompositeIDSeparator)
+		}
+	}
+	return strings.Join(formatted, CompositeIDSeparator), nil
+}
+
+// IdentifyByFields returns an identifier that identifies a struct, or a pointer to one, by the values of
+// the named fields, joined with CompositeID.  Fields of nested structs are named with dots.  The identifier
+// returns an error for elements that aren't structs, lack a field, or have a nil pointer along the way.
+//    Expect(pods).To(MatchElementsWithError(IdentifyByFields("Namespace", "Name"), IgnoreExtras, Elements{
+//        "default/web": MatchFields(IgnoreExtras, Fields{"Ready": BeTrue()}),
+//    }))
+func IdentifyByFields(fields ...string) IdentifierWithError {
+	return func(element interface{}) (string, error) {
+		parts := make([]interface{}, len(fields))
+		for i, field := range fields {
+			value, err := fieldByPath(element, field)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = value
+		}
+		return CompositeID(parts...)
+	}
+}
+
+func fieldByPath(element interface{}, path string) (interface{}, error) {
+	value := reflect.ValueOf(element)
+	for _, field := range strings.Split(path, ".") {
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return nil, fmt.Errorf("cannot read field %s of %T: encountered nil", path, element)
+			}
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("cannot read field %s of %T: not a struct", path, element)
+		}
+		value = value.FieldByName(field)
+		if !value.IsValid() {
+			return nil, fmt.Errorf("cannot read field %s of %T: no field named %s", path, element, field)
+		}
+		if !value.CanInterface() {
+			return nil, fmt.Errorf("cannot read field %s of %T: %s is unexported", path, element, field)
+		}
+	}
+	return value.Interface(), nil
+}
+
 func (m *ElementsMatcher) Match(actual interface{}) (success bool, err error) {
-	if reflect.TypeOf(actual).Kind() != reflect.Slice {
-		return false, fmt.Errorf("%v is type %T, expected slice", actual, actual)
+	// provenance only applies to the Match it was tracked for - a later, standalone, Match starts afresh
+	m.provenance, m.trackedProvenance = m.trackedProvenance, nil
+	if m.DerefPointers {
+		var failure error
+		if actual, failure = derefActual(actual); failure != nil {
+			m.failures = []error{failure}
+			m.legacy = m.failures
+			return false, nil
+		}
+	}
+	switch reflect.TypeOf(actual).Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+	default:
+		return false, fmt.Errorf("%v is type %T, expected slice, array, or map", actual, actual)
 	}
 
-	m.failures = m.matchElements(actual)
+	errs := m.matchElements(actual)
+	m.failures, m.legacy = errs.failures, errs.legacy
 	if len(m.failures) > 0 {
 		return false, nil
 	}
 	return true, nil
 }
 
-func (m *ElementsMatcher) matchElements(actual interface{}) (errs []error) {
+func (m *ElementsMatcher) matchElements(actual interface{}) (errs failureList) {
 	// Provide more useful error messages in the case of a panic.
 	defer func() {
 		if err := recover(); err != nil {
-			errs = append(errs, fmt.Errorf("panic checking %+v: %v\n%s", actual, err, debug.Stack()))
+			errs.add("", fmt.Errorf("panic checking %+v: %v\n%s", actual, err, debug.Stack()))
 		}
 	}()
 
-	val := reflect.ValueOf(actual)
 	elements := map[string]bool{}
-	for i := 0; i < val.Len(); i++ {
-		element := val.Index(i).Interface()
-		id := m.Identifier.WithIndexAndElement(i, element)
+	for i, entry := range elementEntries(reflect.ValueOf(actual)) {
+		element := entry.element
+		id, err := m.identify(i, entry.identifiedBy)
+		if err != nil {
+			errs.add("", fmt.Errorf("could not identify element %d: %v\n%s", i, err, format.Object(entry.identifiedBy, 1)))
+			continue
+		}
 		if elements[id] {
 			if !m.AllowDuplicates {
-				errs = append(errs, fmt.Errorf("found duplicate element ID %s", id))
+				errs.add("", fmt.Errorf("found duplicate element ID %s", id))
 				continue
 			}
 		}
@@ -188,44 +338,128 @@ func (m *ElementsMatcher) matchElements(actual interface{}) (errs []error) {
 		matcher, expected := m.Elements[id]
 		if !expected {
 			if !m.IgnoreExtras {
-				errs = append(errs, fmt.Errorf("unexpected element %s", id))
+				errs.add("", fmt.Errorf("unexpected element %s", id))
 			}
 			continue
 		}
 
+		if m.DerefPointers {
+			element = deref(element)
+		}
+
+		types.TrackProvenance(matcher, m.provenance.Append(entry.provenanceStep))
 		match, err := matcher.Match(element)
 		if match {
 			continue
 		}
 
 		if err == nil {
-			if nesting, ok := matcher.(errorsutil.NestingMatcher); ok {
-				err = errorsutil.AggregateError(nesting.Failures())
-			} else {
-				err = errors.New(matcher.FailureMessage(element))
-			}
+			err = failureOf(matcher, element)
 		}
-		errs = append(errs, errorsutil.Nest(fmt.Sprintf("[%s]", id), err))
+		errs.add(fmt.Sprintf("[%s]", id), err)
 	}
 
-	for id := range m.Elements {
+	for _, idValue := range gutil.SortedMapKeys(reflect.ValueOf(m.Elements)) {
+		id := idValue.String()
 		if !elements[id] && !m.IgnoreMissing {
-			errs = append(errs, fmt.Errorf("missing expected element %s", id))
+			errs.add("", fmt.Errorf("missing expected element %s", id))
 		}
 	}
 
 	return errs
 }
 
+// elementEntry is an element of a slice, array, or map to match, along with what its identifier is passed: the element
+// itself for slices and arrays, and its key for maps
+type elementEntry struct {
+	element        interface{}
+	identifiedBy   interface{}
+	provenanceStep string
+}
+
+// elementEntries returns the elements of a slice or array in order, and the entries of a map in key order
+func elementEntries(val reflect.Value) []elementEntry {
+	if val.Kind() == reflect.Map {
+		entries := []elementEntry{}
+		for _, key := range gutil.SortedMapKeys(val) {
+			entries = append(entries, elementEntry{
+				element:        val.MapIndex(key).Interface(),
+				identifiedBy:   key.Interface(),
+				provenanceStep: fmt.Sprintf("[%#v]", key.Interface()),
+			})
+		}
+		return entries
+	}
+	entries := make([]elementEntry, val.Len())
+	for i := range entries {
+		element := val.Index(i).Interface()
+		entries[i] = elementEntry{element: element, identifiedBy: element, provenanceStep: fmt.Sprintf("[%d]", i)}
+	}
+	return entries
+}
+
+// identify returns the ID of element, turning the errors and panics of the identifier into errors
+func (m *ElementsMatcher) identify(index int, element interface{}) (id string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("identifier panicked: %v", r)
+		}
+	}()
+	if identifier, ok := m.Identifier.(IdentifyWithError); ok {
+		return identifier.IdentifyWithIndexAndElement(index, element)
+	}
+	return m.Identifier.WithIndexAndElement(index, element), nil
+}
+
 func (m *ElementsMatcher) FailureMessage(actual interface{}) (message string) {
-	failure := errorsutil.AggregateError(m.failures)
+	return m.annotate(m.failureMessage(actual, m.failures))
+}
+
+func (m *ElementsMatcher) failureMessage(actual interface{}, failures []error) string {
+	if m.DerefPointers {
+		var failure error
+		if actual, failure = derefActual(actual); failure != nil {
+			return failure.Error()
+		}
+	}
+	if m.ReportFullPaths {
+		return format.Message(actual, fullPathReport("to match elements", failures))
+	}
+	failure := errorsutil.AggregateError(failures)
 	return format.Message(actual, fmt.Sprintf("to match elements: %v", failure))
 }
 
 func (m *ElementsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
-	return format.Message(actual, "not to match elements")
+	return m.annotate(format.Message(actual, "not to match elements"))
+}
+
+// LegacyFailureMessage makes ElementsMatcher a types.LegacyFailureMessager: it renders the legacy failures of the
+// matchers nested within it, without naming the provenance of actual
+func (m *ElementsMatcher) LegacyFailureMessage(actual interface{}, desiredMatch bool) string {
+	if !desiredMatch {
+		return format.Message(actual, "not to match elements")
+	}
+	return m.failureMessage(actual, m.legacy)
 }
 
 func (m *ElementsMatcher) Failures() []error {
 	return m.failures
 }
+
+func (m *ElementsMatcher) legacyFailures() []error {
+	return m.legacy
+}
+
+// TrackProvenance makes ElementsMatcher a types.ProvenanceTracker
+func (m *ElementsMatcher) TrackProvenance(provenance types.Provenance) {
+	m.trackedProvenance = provenance
+}
+
+// annotate names the provenance of actual when the matcher is nested in another that tracks it, as the paths in its
+// failure messages are relative to actual
+func (m *ElementsMatcher) annotate(message string) string {
+	if len(m.provenance) == 0 {
+		return message
+	}
+	return m.provenance.Annotate(message)
+}
@@ -1,6 +1,8 @@
 package gstruct_test
 
 import (
+	"strconv"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
@@ -169,6 +171,174 @@ var _ = Describe("Slice", func() {
 			Expect(nils).Should(m, "should handle nil slices")
 		})
 	})
+
+	It("should report full paths", func() {
+		m := MatchElements(id, ReportFullPaths, Elements{
+			"a": Equal("a"),
+			"b": PointTo(Equal("b")),
+		})
+		Expect(m.Match([]string{"a", "c"})).Should(BeFalse())
+		Expect(m.FailureMessage([]string{"a", "c"})).Should(HaveSuffix("to match elements, but found 2 mismatches:\n    unexpected element c\n    missing expected element b"))
+	})
+
+	Describe("identifiers that may fail", func() {
+		type Meta struct {
+			Namespace string
+		}
+		type Pod struct {
+			Meta  *Meta
+			Name  string
+			Ready bool
+		}
+		pods := []Pod{
+			{Meta: &Meta{"default"}, Name: "web", Ready: true},
+			{Meta: &Meta{"kube-system"}, Name: "dns", Ready: true},
+		}
+		byNamespaceAndName := IdentifyByFields("Meta.Namespace", "Name")
+
+		It("should identify elements by several fields", func() {
+			m := MatchAllElementsWithError(byNamespaceAndName, Elements{
+				"default/web":     MatchFields(IgnoreExtras, Fields{"Ready": BeTrue()}),
+				"kube-system/dns": MatchFields(IgnoreExtras, Fields{"Ready": BeTrue()}),
+			})
+			Expect(pods).Should(m)
+			Expect([]*Pod{&pods[0], &pods[1]}).Should(MatchAllElementsWithError(byNamespaceAndName, Elements{
+				"default/web":     Not(BeNil()),
+				"kube-system/dns": Not(BeNil()),
+			}), "should identify pointers to structs")
+
+			m = MatchElementsWithError(byNamespaceAndName, IgnoreExtras, Elements{
+				"default/web": MatchFields(IgnoreExtras, Fields{"Ready": BeFalse()}),
+			})
+			Expect(m.Match(pods)).Should(BeFalse())
+			Expect(m.FailureMessage(pods)).Should(ContainSubstring("[default/web]"))
+		})
+
+		It("should fail the match for elements that can't be identified", func() {
+			m := MatchElementsWithError(byNamespaceAndName, IgnoreExtras|IgnoreMissing, Elements{})
+			unidentifiable := []Pod{pods[0], {Name: "orphan"}}
+			Expect(m.Match(unidentifiable)).Should(BeFalse())
+			Expect(m.FailureMessage(unidentifiable)).Should(ContainSubstring("could not identify element 1: cannot read field Meta.Namespace of gstruct_test.Pod: encountered nil\n"))
+
+			Expect(m.Match([]string{"a"})).Should(BeFalse())
+			Expect(m.FailureMessage([]string{"a"})).Should(ContainSubstring("could not identify element 0: cannot read field Meta.Namespace of string: not a struct"))
+
+			m = MatchElementsWithError(IdentifyByFields("UID"), IgnoreExtras, Elements{})
+			Expect(m.Match(pods)).Should(BeFalse())
+			Expect(m.FailureMessage(pods)).Should(ContainSubstring("could not identify element 0: cannot read field UID of gstruct_test.Pod: no field named UID"))
+		})
+
+		It("should refuse to build ambiguous IDs", func() {
+			m := MatchElementsWithError(IdentifyByFields("Name", "Meta.Namespace"), IgnoreExtras, Elements{})
+			ambiguous := []Pod{{Meta: &Meta{"a"}, Name: "b/c"}}
+			Expect(m.Match(ambiguous)).Should(BeFalse())
+			Expect(m.FailureMessage(ambiguous)).Should(ContainSubstring(`could not identify element 0: cannot build an unambiguous ID as part "b/c" contains "/"`))
+		})
+
+		It("should fail the match for identifiers that panic", func() {
+			m := MatchElements(id, IgnoreExtras, Elements{})
+			Expect(m.Match([]interface{}{"a", 1})).Should(BeFalse())
+			Expect(m.FailureMessage([]interface{}{"a", 1})).Should(ContainSubstring("could not identify element 1: identifier panicked: interface conversion"))
+		})
+
+		It("should build composite IDs", func() {
+			Expect(CompositeID("default", "web", 3)).Should(Equal("default/web/3"))
+			_, err := CompositeID("a/b")
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("matching maps, arrays, and nested collections", func() {
+		It("should match the values of a map, identified by their keys", func() {
+			ports := map[string]int{"http": 80, "https": 443}
+			m := MatchAllElements(KeyIdentity, Elements{
+				"http":  Equal(80),
+				"https": Equal(443),
+			})
+			Expect(ports).Should(m)
+			Expect(map[string]int{"http": 80}).ShouldNot(m, "should fail with missing keys")
+			Expect(map[string]int{"http": 80, "https": 443, "ssh": 22}).ShouldNot(m, "should fail with extra keys")
+
+			m = MatchElements(KeyIdentity, IgnoreExtras, Elements{
+				"https": Equal(8443),
+			})
+			Expect(m.Match(ports)).Should(BeFalse())
+			Expect(m.FailureMessage(ports)).Should(ContainSubstring("[https]:\n\tExpected\n\t    <int>: 443\n\tto equal\n\t    <int>: 8443"))
+		})
+
+		It("should pass identifiers with an index the position of the key in key order", func() {
+			m := MatchAllElementsWithIndex(IndexIdentity, Elements{
+				"0": Equal("one"),
+				"1": Equal("two"),
+			})
+			Expect(map[int]string{2: "two", 1: "one"}).Should(m)
+		})
+
+		It("should match arrays", func() {
+			m := MatchAllElementsWithIndex(IndexIdentity, Elements{
+				"0": Equal("a"),
+				"1": Equal("b"),
+			})
+			Expect([2]string{"a", "b"}).Should(m)
+			Expect([2]string{"a", "c"}).ShouldNot(m)
+		})
+
+		It("should match slices of slices and maps of slices", func() {
+			grid := [][]string{{"a", "b"}, {"c"}}
+			m := MatchAllElementsWithIndex(IndexIdentity, Elements{
+				"0": MatchAllElementsWithIndex(IndexIdentity, Elements{"0": Equal("a"), "1": Equal("b")}),
+				"1": MatchAllElementsWithIndex(IndexIdentity, Elements{"0": Equal("d")}),
+			})
+			Expect(m.Match(grid)).Should(BeFalse())
+			Expect(m.(*ElementsMatcher).Failures()).Should(HaveLen(1))
+			Expect(m.(*ElementsMatcher).Failures()[0].Error()).Should(Equal("[1][0]:\n\tExpected\n\t    <string>: c\n\tto equal\n\t    <string>: d"))
+
+			groups := map[string][]string{"admins": {"alice"}, "users": {"bob", "carol"}}
+			Expect(groups).Should(MatchAllElements(KeyIdentity, Elements{
+				"admins": MatchAllElements(id, Elements{"alice": Not(BeEmpty())}),
+				"users":  MatchAllElements(id, Elements{"bob": Not(BeEmpty()), "carol": Not(BeEmpty())}),
+			}))
+		})
+
+		It("should error for other types", func() {
+			_, err := MatchAllElements(id, Elements{}).Match("abc")
+			Expect(err).Should(MatchError("abc is type string, expected slice, array, or map"))
+		})
+	})
+
+	Describe("dereferencing pointers", func() {
+		a, b := "a", "b"
+		elements := []*string{&a, &b, nil}
+		idFn := func(index int, _ interface{}) string { return strconv.Itoa(index) }
+
+		It("should accept a pointer and dereference its elements", func() {
+			m := MatchElementsWithIndex(idFn, DerefPointers, Elements{
+				"0": Equal("a"),
+				"1": Equal("b"),
+				"2": BeNil(),
+			})
+			Expect(elements).Should(m)
+			Expect(&elements).Should(m)
+
+			m = MatchElementsWithIndex(idFn, IgnoreExtras, Elements{
+				"0": Equal("a"),
+			})
+			Expect(m.Match(elements)).Should(BeFalse(), "should not dereference without the option")
+		})
+
+		It("should dereference the values of maps", func() {
+			m := MatchElements(KeyIdentity, DerefPointers, Elements{
+				"x": Equal("a"),
+			})
+			Expect(map[string]*string{"x": &a}).Should(m)
+		})
+
+		It("should fail on a nil pointer", func() {
+			m := MatchElementsWithIndex(idFn, DerefPointers, Elements{})
+			Expect(m.Match((*[]*string)(nil))).Should(BeFalse())
+			Expect(m.FailureMessage((*[]*string)(nil))).Should(Equal("Expected\n    <*[]*string | 0x0>: nil\nnot to be <nil>"))
+		})
+	})
 })
 
 func id(element interface{}) string {
@@ -70,3 +70,28 @@ func (err AggregateError) Error() string {
 	result += "]"
 	return result
 }
+
+// Flatten returns the leaves of errs - the errors that are neither NestedErrors nor AggregateErrors - each nested
+// under its full path.  Leaves that are not nested have an empty Path.
+func Flatten(errs []error) []*NestedError {
+	leaves := []*NestedError{}
+	for _, err := range errs {
+		leaves = append(leaves, flatten("", err)...)
+	}
+	return leaves
+}
+
+func flatten(path string, err error) []*NestedError {
+	switch e := err.(type) {
+	case AggregateError:
+		leaves := []*NestedError{}
+		for _, nested := range e {
+			leaves = append(leaves, flatten(path, nested)...)
+		}
+		return leaves
+	case *NestedError:
+		return flatten(path+e.Path, e.Err)
+	default:
+		return []*NestedError{{Path: path, Err: err}}
+	}
+}
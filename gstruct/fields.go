@@ -8,9 +8,10 @@ import (
 	"reflect"
 	"runtime/debug"
 	"strings"
+	"unsafe"
 
 	"github.com/onsi/gomega/format"
-	errorsutil "github.com/onsi/gomega/gstruct/errors"
+	"github.com/onsi/gomega/internal/gutil"
 	"github.com/onsi/gomega/types"
 )
 
@@ -61,12 +62,32 @@ func MatchAllFields(fields Fields) types.GomegaMatcher {
 //    }))
 func MatchFields(options Options, fields Fields) types.GomegaMatcher {
 	return &FieldsMatcher{
-		Fields:        fields,
-		IgnoreExtras:  options&IgnoreExtras != 0,
-		IgnoreMissing: options&IgnoreMissing != 0,
+		Fields:                fields,
+		IgnoreExtras:          options&IgnoreExtras != 0,
+		IgnoreMissing:         options&IgnoreMissing != 0,
+		ReportFullPaths:       options&ReportFullPaths != 0,
+		PromoteEmbeddedFields: options&PromoteEmbeddedFields != 0,
+		DerefPointers:         options&DerefPointers != 0,
 	}
 }
 
+//MatchFieldsAllowingUnexported is MatchFields for structs whose unexported fields need checking too.
+//allowed lists a value of each struct type whose unexported fields may be read - including the types
+//of embedded structs when their fields are promoted.
+//    type server struct {
+//      Name string
+//      port int
+//    }
+//
+//    Expect(server{"api", 8080}).To(MatchFieldsAllowingUnexported(IgnoreExtras, []interface{}{server{}}, Fields{
+//      "port": Equal(8080),
+//    }))
+func MatchFieldsAllowingUnexported(options Options, allowed []interface{}, fields Fields) types.GomegaMatcher {
+	matcher := MatchFields(options, fields).(*FieldsMatcher)
+	matcher.AllowUnexported = allowed
+	return matcher
+}
+
 type FieldsMatcher struct {
 	// Matchers for each field.
 	Fields Fields
@@ -75,32 +96,60 @@ type FieldsMatcher struct {
 	IgnoreExtras bool
 	// Whether to ignore missing elements or consider it an error.
 	IgnoreMissing bool
+	// Whether to report every nested mismatch as a flat list with full paths.
+	ReportFullPaths bool
+	// Whether to match the fields of embedded structs as if they were fields of the struct itself.
+	PromoteEmbeddedFields bool
+	// Whether to accept a pointer to a struct, and to dereference pointer fields before matching them.
+	DerefPointers bool
+	// Values of the struct types whose unexported fields may be read.
+	AllowUnexported []interface{}
 
 	// State.
-	failures []error
+	failures          []error
+	legacy            []error
+	provenance        types.Provenance
+	trackedProvenance types.Provenance
 }
 
 // Field name to matcher.
 type Fields map[string]types.GomegaMatcher
 
 func (m *FieldsMatcher) Match(actual interface{}) (success bool, err error) {
+	// provenance only applies to the Match it was tracked for - a later, standalone, Match starts afresh
+	m.provenance, m.trackedProvenance = m.trackedProvenance, nil
+	if m.DerefPointers {
+		var failure error
+		if actual, failure = derefActual(actual); failure != nil {
+			m.failures = []error{failure}
+			m.legacy = m.failures
+			return false, nil
+		}
+	}
 	if reflect.TypeOf(actual).Kind() != reflect.Struct {
 		return false, fmt.Errorf("%v is type %T, expected struct", actual, actual)
 	}
 
-	m.failures = m.matchFields(actual)
+	errs := m.matchFields(actual)
+	m.failures, m.legacy = errs.failures, errs.legacy
 	if len(m.failures) > 0 {
 		return false, nil
 	}
 	return true, nil
 }
 
-func (m *FieldsMatcher) matchFields(actual interface{}) (errs []error) {
+func (m *FieldsMatcher) matchFields(actual interface{}) (errs failureList) {
 	val := reflect.ValueOf(actual)
 	typ := val.Type()
+	if len(m.AllowUnexported) > 0 {
+		// unexported fields can only be read through their address
+		addressable := reflect.New(typ).Elem()
+		addressable.Set(val)
+		val = addressable
+	}
 	fields := map[string]bool{}
-	for i := 0; i < val.NumField(); i++ {
-		fieldName := typ.Field(i).Name
+	for _, structField := range m.structFields(typ) {
+		fieldName := structField.Name
 		fields[fieldName] = true
 
 		err := func() (err error) {
@@ -114,52 +163,181 @@ func (m *FieldsMatcher) matchFields(actual interface{}) (errs []error) {
 
 			matcher, expected := m.Fields[fieldName]
 			if !expected {
-				if !m.IgnoreExtras {
+				if !m.IgnoreExtras && !m.isTransparent(structField) {
 					return fmt.Errorf("unexpected field %s: %+v", fieldName, actual)
 				}
 				return nil
 			}
 
-			field := val.Field(i).Interface()
+			field, err := m.fieldValue(val, structField)
+			if err != nil {
+				return err
+			}
+			if m.DerefPointers {
+				field = deref(field)
+			}
 
+			types.TrackProvenance(matcher, m.provenance.Append("."+fieldName))
 			match, err := matcher.Match(field)
 			if err != nil {
 				return err
 			} else if !match {
-				if nesting, ok := matcher.(errorsutil.NestingMatcher); ok {
-					return errorsutil.AggregateError(nesting.Failures())
-				}
-				return errors.New(matcher.FailureMessage(field))
+				return failureOf(matcher, field)
 			}
 			return nil
 		}()
 		if err != nil {
-			errs = append(errs, errorsutil.Nest("."+fieldName, err))
+			errs.add("."+fieldName, err)
 		}
 	}
 
-	for field := range m.Fields {
+	for _, fieldValue := range gutil.SortedMapKeys(reflect.ValueOf(m.Fields)) {
+		field := fieldValue.String()
 		if !fields[field] && !m.IgnoreMissing {
-			errs = append(errs, fmt.Errorf("missing expected field %s", field))
+			errs.add("", fmt.Errorf("missing expected field %s", field))
 		}
 	}
 
 	return errs
 }
 
+// structFields returns the fields of typ to match: its own fields or, when promoting embedded fields, every field
+// visible from typ - as reflect.VisibleFields, fields shadowed by a shallower field of the same name are left out
+func (m *FieldsMatcher) structFields(typ reflect.Type) []reflect.StructField {
+	if m.PromoteEmbeddedFields {
+		return reflect.VisibleFields(typ)
+	}
+	fields := make([]reflect.StructField, typ.NumField())
+	for i := range fields {
+		fields[i] = typ.Field(i)
+	}
+	return fields
+}
+
+// isTransparent reports whether field is an embedded struct whose fields are promoted, and so need not be matched itself
+func (m *FieldsMatcher) isTransparent(field reflect.StructField) bool {
+	if !m.PromoteEmbeddedFields || !field.Anonymous {
+		return false
+	}
+	typ := field.Type
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct
+}
+
+// fieldValue reads field from val, following embedded pointers to promoted fields and reading unexported fields of
+// the allowed types
+func (m *FieldsMatcher) fieldValue(val reflect.Value, field reflect.StructField) (interface{}, error) {
+	embedded := []string{}
+	for _, index := range field.Index[:len(field.Index)-1] {
+		embedded = append(embedded, val.Type().Field(index).Name)
+		val = val.Field(index)
+		if val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				return nil, fmt.Errorf("field %s is promoted through nil embedded pointer %s", field.Name, strings.Join(embedded, "."))
+			}
+			val = val.Elem()
+		}
+	}
+	declaringType := val.Type()
+	val = val.Field(field.Index[len(field.Index)-1])
+	if val.CanInterface() {
+		return val.Interface(), nil
+	}
+	if !m.allowsUnexported(declaringType) || !val.CanAddr() {
+		return nil, fmt.Errorf("cannot read unexported field %s of %s; allow its unexported fields with MatchFieldsAllowingUnexported", field.Name, declaringType)
+	}
+	return reflect.NewAt(val.Type(), unsafe.Pointer(val.UnsafeAddr())).Elem().Interface(), nil
+}
+
+// deref follows value through any pointers to what they point to, stopping at a nil pointer
+func deref(value interface{}) interface{} {
+	val := reflect.ValueOf(value)
+	if val.Kind() != reflect.Ptr {
+		return value
+	}
+	for val.Kind() == reflect.Ptr && !val.IsNil() {
+		val = val.Elem()
+	}
+	return val.Interface()
+}
+
+// derefActual dereferences the actual of a matcher passed DerefPointers.  A nil pointer can't be matched, so it is
+// returned along with the failure it causes.
+func derefActual(actual interface{}) (interface{}, error) {
+	val := reflect.ValueOf(actual)
+	if val.Kind() != reflect.Ptr {
+		return actual, nil
+	}
+	if val.IsNil() {
+		return actual, errors.New(format.Message(actual, "not to be <nil>"))
+	}
+	return deref(actual), nil
+}
+
+func (m *FieldsMatcher) allowsUnexported(typ reflect.Type) bool {
+	for _, allowed := range m.AllowUnexported {
+		if reflect.TypeOf(allowed) == typ {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *FieldsMatcher) FailureMessage(actual interface{}) (message string) {
-	failures := make([]string, len(m.failures))
-	for i := range m.failures {
-		failures[i] = m.failures[i].Error()
+	return m.annotate(m.failureMessage(actual, m.failures))
+}
+
+func (m *FieldsMatcher) failureMessage(actual interface{}, failures []error) string {
+	if m.DerefPointers {
+		var failure error
+		if actual, failure = derefActual(actual); failure != nil {
+			return failure.Error()
+		}
+	}
+	if m.ReportFullPaths {
+		return format.Message(reflect.TypeOf(actual).Name(), fullPathReport("to match fields", failures))
+	}
+	messages := make([]string, len(failures))
+	for i := range failures {
+		messages[i] = failures[i].Error()
 	}
 	return format.Message(reflect.TypeOf(actual).Name(),
-		fmt.Sprintf("to match fields: {\n%v\n}\n", strings.Join(failures, "\n")))
+		fmt.Sprintf("to match fields: {\n%v\n}\n", strings.Join(messages, "\n")))
 }
 
 func (m *FieldsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
-	return format.Message(actual, "not to match fields")
+	return m.annotate(format.Message(actual, "not to match fields"))
+}
+
+// LegacyFailureMessage makes FieldsMatcher a types.LegacyFailureMessager: it renders the legacy failures of the
+// matchers nested within it, without naming the provenance of actual
+func (m *FieldsMatcher) LegacyFailureMessage(actual interface{}, desiredMatch bool) string {
+	if !desiredMatch {
+		return format.Message(actual, "not to match fields")
+	}
+	return m.failureMessage(actual, m.legacy)
 }
 
 func (m *FieldsMatcher) Failures() []error {
 	return m.failures
 }
+
+func (m *FieldsMatcher) legacyFailures() []error {
+	return m.legacy
+}
+
+// TrackProvenance makes FieldsMatcher a types.ProvenanceTracker
+func (m *FieldsMatcher) TrackProvenance(provenance types.Provenance) {
+	m.trackedProvenance = provenance
+}
+
+// annotate names the provenance of actual when the matcher is nested in another that tracks it, as the paths in its
+// failure messages are relative to actual
+func (m *FieldsMatcher) annotate(message string) string {
+	if len(m.provenance) == 0 {
+		return message
+	}
+	return m.provenance.Annotate(message)
+}
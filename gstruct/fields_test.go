@@ -1,9 +1,12 @@
 package gstruct_test
 
 import (
+	"strings"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
 )
 
 var _ = Describe("Struct", func() {
@@ -98,4 +101,338 @@ var _ = Describe("Struct", func() {
 			".C:\n	unexpected field C: {A:b C:c}",
 		))
 	})
+
+	Describe("reporting full paths", func() {
+		type Container struct {
+			Name  string
+			Image string
+		}
+		type PodSpec struct {
+			Containers []Container
+		}
+		type Template struct {
+			Spec PodSpec
+		}
+		type Spec struct {
+			Replicas int
+			Template *Template
+		}
+		type Deployment struct {
+			Name string
+			Spec Spec
+		}
+
+		deployment := Deployment{
+			Name: "web",
+			Spec: Spec{
+				Replicas: 2,
+				Template: &Template{Spec: PodSpec{Containers: []Container{{Name: "nginx", Image: "nginx:1.0"}}}},
+			},
+		}
+
+		matcher := func(options Options) types.GomegaMatcher {
+			return MatchFields(options, Fields{
+				"Name": Equal("web"),
+				"Spec": MatchAllFields(Fields{
+					"Replicas": Equal(3),
+					"Template": PointTo(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"Containers": MatchAllElementsWithIndex(IndexIdentity, Elements{
+								"0": MatchAllFields(Fields{
+									"Name":  Equal("nginx"),
+									"Image": Equal("nginx:1.1"),
+								}),
+							}),
+						}),
+					})),
+					"Paused": BeFalse(),
+				}),
+			})
+		}
+
+		It("should report every mismatched leaf with its full path", func() {
+			m := matcher(ReportFullPaths)
+			Expect(m.Match(deployment)).Should(BeFalse())
+			Expect(m.FailureMessage(deployment)).Should(Equal(`Expected
+    <string>: Deployment
+to match fields, but found 3 mismatches:
+    .Spec.Replicas:
+        Expected
+            <int>: 2
+        to equal
+            <int>: 3
+    .Spec.Template.Spec.Containers[0].Image:
+        Expected
+            <string>: nginx:1.0
+        to equal
+            <string>: nginx:1.1
+    .Spec: missing expected field Paused`))
+		})
+
+		It("should report PointTo with the failure message of the matcher it points to without the option", func() {
+			m := matcher(0)
+			Expect(m.Match(deployment)).Should(BeFalse())
+			Expect(m.FailureMessage(deployment)).Should(ContainSubstring(".Spec.Template:\n\tProvenance: actual.Spec.Template\n\tExpected\n\t    <string>: Template\n\tto match fields: {\n\t.Spec.Containers[0].Image:\n"))
+		})
+
+		It("should report a single mismatch", func() {
+			m := MatchFields(IgnoreExtras|ReportFullPaths, Fields{"Name": Equal("api")})
+			Expect(m.Match(deployment)).Should(BeFalse())
+			Expect(m.FailureMessage(deployment)).Should(HaveSuffix("to match fields, but found 1 mismatch:\n    .Name:\n        Expected\n            <string>: web\n        to equal\n            <string>: api"))
+		})
+	})
+
+	Describe("promoting embedded fields", func() {
+		type Meta struct {
+			Name    string
+			Version int
+		}
+		type Status struct {
+			Ready bool
+		}
+		type Service struct {
+			Meta
+			*Status
+			Version string
+			Port    int
+		}
+		service := Service{Meta: Meta{Name: "api", Version: 1}, Status: &Status{Ready: true}, Version: "v2", Port: 80}
+
+		It("should match promoted fields as fields of the struct", func() {
+			m := MatchFields(PromoteEmbeddedFields, Fields{
+				"Name":    Equal("api"),
+				"Ready":   BeTrue(),
+				"Version": Equal("v2"),
+				"Port":    Equal(80),
+			})
+			Expect(service).Should(m, "should not require the embedded structs to be matched")
+
+			m = MatchAllFields(Fields{
+				"Name":    Equal("api"),
+				"Ready":   BeTrue(),
+				"Version": Equal("v2"),
+				"Port":    Equal(80),
+			})
+			Expect(service).ShouldNot(m, "should not promote fields without the option")
+		})
+
+		It("should still allow the embedded structs to be matched", func() {
+			m := MatchFields(PromoteEmbeddedFields|IgnoreExtras, Fields{
+				"Meta": Equal(Meta{Name: "api", Version: 1}),
+			})
+			Expect(service).Should(m)
+		})
+
+		It("should leave out shadowed fields", func() {
+			m := MatchFields(PromoteEmbeddedFields|IgnoreExtras, Fields{
+				"Version": Equal(1),
+			})
+			Expect(m.Match(service)).Should(BeFalse())
+			Expect(m.FailureMessage(service)).Should(ContainSubstring("<string>: v2"))
+		})
+
+		It("should report fields promoted through a nil pointer", func() {
+			m := MatchFields(PromoteEmbeddedFields|IgnoreExtras, Fields{
+				"Ready": BeTrue(),
+			})
+			Expect(m.Match(Service{})).Should(BeFalse())
+			Expect(m.FailureMessage(Service{})).Should(ContainSubstring(".Ready:\n\tfield Ready is promoted through nil embedded pointer Status"))
+
+			m = MatchFields(PromoteEmbeddedFields|IgnoreExtras, Fields{
+				"Port": Equal(0),
+			})
+			Expect(Service{}).Should(m, "should only fail if a matcher needs the field")
+		})
+	})
+
+	Describe("matching unexported fields", func() {
+		type base struct {
+			id int
+		}
+		type Server struct {
+			base
+			Name string
+			port int
+		}
+		server := Server{base: base{id: 7}, Name: "api", port: 8080}
+
+		It("should read the unexported fields of allowed types", func() {
+			m := MatchFieldsAllowingUnexported(0, []interface{}{Server{}}, Fields{
+				"base": Equal(base{id: 7}),
+				"Name": Equal("api"),
+				"port": Equal(8080),
+			})
+			Expect(server).Should(m)
+			Expect(Server{port: 9090}).ShouldNot(m)
+		})
+
+		It("should read unexported promoted fields when the embedded type is allowed", func() {
+			m := MatchFieldsAllowingUnexported(PromoteEmbeddedFields|IgnoreExtras, []interface{}{base{}}, Fields{
+				"id":   Equal(7),
+				"Name": Equal("api"),
+			})
+			Expect(server).Should(m)
+		})
+
+		It("should refuse to read the unexported fields of other types", func() {
+			m := MatchFieldsAllowingUnexported(PromoteEmbeddedFields|IgnoreExtras, []interface{}{Server{}}, Fields{
+				"id": Equal(7),
+			})
+			Expect(m.Match(server)).Should(BeFalse())
+			Expect(m.FailureMessage(server)).Should(ContainSubstring(".id:\n\tcannot read unexported field id of gstruct_test.base; allow its unexported fields with MatchFieldsAllowingUnexported"))
+
+			m = MatchFields(IgnoreExtras, Fields{
+				"port": Equal(8080),
+			})
+			Expect(m.Match(server)).Should(BeFalse())
+			Expect(m.FailureMessage(server)).Should(ContainSubstring(".port:\n\tcannot read unexported field port of gstruct_test.Server"))
+		})
+	})
+
+	Describe("dereferencing pointers", func() {
+		type Spec struct {
+			Replicas *int32
+			Paused   *bool
+		}
+		type Deployment struct {
+			Name string
+			Spec *Spec
+		}
+		replicas := int32(3)
+		deployment := &Deployment{Name: "api", Spec: &Spec{Replicas: &replicas}}
+
+		It("should dereference the actual and its pointer fields", func() {
+			m := MatchFields(DerefPointers, Fields{
+				"Name": Equal("api"),
+				"Spec": MatchFields(DerefPointers, Fields{
+					"Replicas": Equal(int32(3)),
+					"Paused":   BeNil(),
+				}),
+			})
+			Expect(deployment).Should(m)
+			Expect(*deployment).Should(m)
+
+			m = MatchFields(IgnoreExtras, Fields{
+				"Spec": MatchFields(IgnoreExtras, Fields{}),
+			})
+			Expect(m.Match(*deployment)).Should(BeFalse(), "should not dereference without the option")
+			Expect(m.FailureMessage(*deployment)).Should(ContainSubstring("expected struct"))
+		})
+
+		It("should report mismatches in dereferenced fields", func() {
+			m := MatchFields(DerefPointers|IgnoreExtras, Fields{
+				"Spec": MatchFields(DerefPointers|IgnoreExtras, Fields{
+					"Replicas": Equal(int32(5)),
+				}),
+			})
+			Expect(m.Match(deployment)).Should(BeFalse())
+			message := m.FailureMessage(deployment)
+			Expect(message).Should(ContainSubstring("Expected\n    <string>: Deployment\nto match fields:"))
+			Expect(message).Should(ContainSubstring(".Spec.Replicas:\n\tExpected\n\t    <int32>: 3\n\tto equal\n\t    <int32>: 5"))
+		})
+
+		It("should fail on nil pointers", func() {
+			m := MatchFields(DerefPointers|IgnoreExtras, Fields{
+				"Spec": MatchFields(DerefPointers|IgnoreExtras, Fields{}),
+			})
+			Expect(m.Match(&Deployment{})).Should(BeFalse())
+			Expect(m.FailureMessage(&Deployment{})).Should(ContainSubstring(".Spec:\n\tExpected\n\t    <*gstruct_test.Spec | 0x0>: nil\n\tnot to be <nil>"))
+
+			Expect(m.Match((*Deployment)(nil))).Should(BeFalse())
+			Expect(m.FailureMessage((*Deployment)(nil))).Should(Equal("Expected\n    <*gstruct_test.Deployment | 0x0>: nil\nnot to be <nil>"))
+		})
+	})
+
+	Describe("provenance", func() {
+		type Container struct {
+			Image string
+		}
+		type PodSpec struct {
+			Containers []Container
+			Labels     map[string]string
+		}
+		type Pod struct {
+			Spec *PodSpec
+		}
+		pod := Pod{Spec: &PodSpec{
+			Containers: []Container{{Image: "NGINX:1.0"}},
+			Labels:     map[string]string{"app": "web"},
+		}}
+
+		It("should pass the path to nested matchers that track provenance", func() {
+			m := MatchFields(IgnoreExtras, Fields{
+				"Spec": PointTo(MatchFields(IgnoreExtras, Fields{
+					"Containers": MatchAllElementsWithIndex(IndexIdentity, Elements{
+						"0": MatchFields(IgnoreExtras, Fields{
+							"Image": WithTransform(strings.ToLower, Equal("nginx:1.1")),
+						}),
+					}),
+				})),
+			})
+			Expect(m.Match(pod)).Should(BeFalse())
+			Expect(m.FailureMessage(pod)).Should(ContainSubstring("Provenance: actual.Spec.Containers[0].Image → transformed by ToLower\n"))
+		})
+
+		It("should not report a stale provenance when a nested matcher is reused on its own", func() {
+			inner := MatchKeys(IgnoreExtras, Keys{"app": Equal("api")})
+			Expect(HaveField("Spec.Labels", inner).Match(pod)).Should(BeFalse())
+			Expect(inner.FailureMessage(pod.Spec.Labels)).Should(ContainSubstring("Provenance: actual.Spec.Labels\n"))
+			Expect(inner.Match(pod.Spec.Labels)).Should(BeFalse())
+			Expect(inner.FailureMessage(pod.Spec.Labels)).ShouldNot(ContainSubstring("Provenance"))
+
+			pointer := PointTo(MatchFields(IgnoreExtras, Fields{"Labels": HaveLen(2)}))
+			Expect(HaveField("Spec", pointer).Match(pod)).Should(BeFalse())
+			Expect(pointer.Match(pod.Spec)).Should(BeFalse())
+			Expect(pointer.FailureMessage(pod.Spec)).ShouldNot(ContainSubstring("Provenance"))
+		})
+
+		It("should name the provenance of actual when nested in a matcher that tracks it", func() {
+			m := HaveField("Spec", PointTo(MatchFields(IgnoreExtras, Fields{
+				"Labels": MatchKeys(IgnoreExtras, Keys{
+					"app": Equal("api"),
+				}),
+			})))
+			Expect(m.Match(pod)).Should(BeFalse())
+			Expect(m.FailureMessage(pod)).Should(HavePrefix("Value for field 'Spec' failed to satisfy matcher.\nProvenance: actual.Spec\nExpected\n"))
+
+			m = HaveField("Spec.Labels", MatchKeys(IgnoreExtras, Keys{
+				"app": HaveLen(1),
+			}))
+			Expect(m.Match(pod)).Should(BeFalse())
+			Expect(m.FailureMessage(pod)).Should(ContainSubstring("Provenance: actual.Spec.Labels\n"))
+		})
+	})
+
+	Describe("legacy failure messages", func() {
+		type Container struct {
+			Image string
+		}
+		type PodSpec struct {
+			Containers []Container
+		}
+		type Pod struct {
+			Spec *PodSpec
+		}
+		pod := Pod{Spec: &PodSpec{
+			Containers: []Container{{Image: "NGINX:1.0"}},
+		}}
+
+		It("should render the legacy failures of nested matchers, without provenance", func() {
+			m := MatchFields(IgnoreExtras, Fields{
+				"Spec": PointTo(MatchFields(IgnoreExtras, Fields{
+					"Containers": MatchAllElementsWithIndex(IndexIdentity, Elements{
+						"0": MatchFields(IgnoreExtras, Fields{
+							"Image": WithTransform(strings.ToLower, Or(Equal("nginx:1.1"), Equal("nginx:1.2"))),
+						}),
+					}),
+				})),
+			})
+			Expect(m.Match(pod)).Should(BeFalse())
+			Expect(m.FailureMessage(pod)).Should(ContainSubstring(".Spec:\n\tProvenance: actual.Spec\n\tExpected\n\t    <string>: PodSpec\n\tto match fields: {\n\t.Containers[0].Image:\n\t\tProvenance: actual.Spec.Containers[0].Image → transformed by ToLower\n\t\tExpected at least one of the following, but none held:"))
+
+			legacy := types.LegacyFailureMessageFor(m, pod, true)
+			Expect(legacy).ShouldNot(ContainSubstring("Provenance"))
+			Expect(legacy).Should(ContainSubstring(".Spec:\n\tExpected\n\t    <string>: PodSpec\n\tto match fields: {\n\t.Containers[0].Image:\n\t\tExpected\n\t\t    <string>: nginx:1.0\n\t\tTo satisfy at least one of these matchers: ["))
+		})
+	})
 })
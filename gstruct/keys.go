@@ -3,14 +3,15 @@
 package gstruct
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"runtime/debug"
+	"sort"
 	"strings"
 
 	"github.com/onsi/gomega/format"
-	errorsutil "github.com/onsi/gomega/gstruct/errors"
+	"github.com/onsi/gomega/internal/gutil"
 	"github.com/onsi/gomega/types"
 )
 
@@ -22,9 +23,11 @@ func MatchAllKeys(keys Keys) types.GomegaMatcher {
 
 func MatchKeys(options Options, keys Keys) types.GomegaMatcher {
 	return &KeysMatcher{
-		Keys:          keys,
-		IgnoreExtras:  options&IgnoreExtras != 0,
-		IgnoreMissing: options&IgnoreMissing != 0,
+		Keys:            keys,
+		IgnoreExtras:    options&IgnoreExtras != 0,
+		IgnoreMissing:   options&IgnoreMissing != 0,
+		ReportFullPaths: options&ReportFullPaths != 0,
+		DerefPointers:   options&DerefPointers != 0,
 	}
 }
 
@@ -36,29 +39,51 @@ type KeysMatcher struct {
 	IgnoreExtras bool
 	// Whether to ignore missing keys or consider it an error.
 	IgnoreMissing bool
+	// Whether to report every nested mismatch as a flat list with full paths.
+	ReportFullPaths bool
+	// Whether to accept a pointer to a map, and to dereference pointer values before matching them.
+	DerefPointers bool
 
 	// State.
-	failures []error
+	failures          []error
+	legacy            []error
+	provenance        types.Provenance
+	trackedProvenance types.Provenance
 }
 
+// Key to matcher.  A key that is a *regexp.Regexp or a types.GomegaMatcher stands for every key of the map it matches
+// and applies its matcher to each of their values.  Keys matched by such a pattern aren't extra keys, and a pattern
+// that matches no key is missing.  A key that is matched exactly is not matched against the patterns.
 type Keys map[interface{}]types.GomegaMatcher
 
 func (m *KeysMatcher) Match(actual interface{}) (success bool, err error) {
+	// provenance only applies to the Match it was tracked for - a later, standalone, Match starts afresh
+	m.provenance, m.trackedProvenance = m.trackedProvenance, nil
+	if m.DerefPointers {
+		var failure error
+		if actual, failure = derefActual(actual); failure != nil {
+			m.failures = []error{failure}
+			m.legacy = m.failures
+			return false, nil
+		}
+	}
 	if reflect.TypeOf(actual).Kind() != reflect.Map {
 		return false, fmt.Errorf("%v is type %T, expected map", actual, actual)
 	}
 
-	m.failures = m.matchKeys(actual)
+	errs := m.matchKeys(actual)
+	m.failures, m.legacy = errs.failures, errs.legacy
 	if len(m.failures) > 0 {
 		return false, nil
 	}
 	return true, nil
 }
 
-func (m *KeysMatcher) matchKeys(actual interface{}) (errs []error) {
+func (m *KeysMatcher) matchKeys(actual interface{}) (errs failureList) {
 	actualValue := reflect.ValueOf(actual)
+	patterns := m.keyPatterns()
 	keys := map[interface{}]bool{}
-	for _, keyValue := range actualValue.MapKeys() {
+	for _, keyValue := range gutil.SortedMapKeys(actualValue) {
 		key := keyValue.Interface()
 		keys[key] = true
 
@@ -71,8 +96,22 @@ func (m *KeysMatcher) matchKeys(actual interface{}) (errs []error) {
 				}
 			}()
 
-			matcher, ok := m.Keys[key]
-			if !ok {
+			matchers := []types.GomegaMatcher{}
+			if matcher, ok := m.Keys[key]; ok && !isKeyPattern(key) {
+				matchers = append(matchers, matcher)
+			} else {
+				for _, pattern := range patterns {
+					matched, err := pattern.matches(key)
+					if err != nil {
+						return err
+					}
+					if matched {
+						pattern.matched = true
+						matchers = append(matchers, pattern.matcher)
+					}
+				}
+			}
+			if len(matchers) == 0 {
 				if !m.IgnoreExtras {
 					return fmt.Errorf("unexpected key %s: %+v", key, actual)
 				}
@@ -80,47 +119,147 @@ func (m *KeysMatcher) matchKeys(actual interface{}) (errs []error) {
 			}
 
 			valInterface := actualValue.MapIndex(keyValue).Interface()
-
-			match, err := matcher.Match(valInterface)
-			if err != nil {
-				return err
+			if m.DerefPointers {
+				valInterface = deref(valInterface)
 			}
-
-			if !match {
-				if nesting, ok := matcher.(errorsutil.NestingMatcher); ok {
-					return errorsutil.AggregateError(nesting.Failures())
+			for _, matcher := range matchers {
+				if err := m.matchValue(matcher, key, valInterface); err != nil {
+					return err
 				}
-				return errors.New(matcher.FailureMessage(valInterface))
 			}
 			return nil
 		}()
 		if err != nil {
-			errs = append(errs, errorsutil.Nest(fmt.Sprintf(".%#v", key), err))
+			errs.add(fmt.Sprintf(".%#v", key), err)
 		}
 	}
 
-	for key := range m.Keys {
-		if !keys[key] && !m.IgnoreMissing {
-			errs = append(errs, fmt.Errorf("missing expected key %s", key))
+	for _, keyValue := range gutil.SortedMapKeys(reflect.ValueOf(m.Keys)) {
+		key := keyValue.Interface()
+		if !isKeyPattern(key) && !keys[key] && !m.IgnoreMissing {
+			errs.add("", fmt.Errorf("missing expected key %s", key))
+		}
+	}
+	for _, pattern := range patterns {
+		if !pattern.matched && !m.IgnoreMissing {
+			errs.add("", fmt.Errorf("missing expected keys matching %s", pattern.description))
 		}
 	}
 
 	return errs
 }
 
+func (m *KeysMatcher) matchValue(matcher types.GomegaMatcher, key interface{}, value interface{}) error {
+	types.TrackProvenance(matcher, m.provenance.Append(fmt.Sprintf("[%#v]", key)))
+	match, err := matcher.Match(value)
+	if err != nil {
+		return err
+	}
+
+	if !match {
+		return failureOf(matcher, value)
+	}
+	return nil
+}
+
+// keyPattern is a key of Keys that stands for every key it matches - a regular expression, or a matcher
+type keyPattern struct {
+	pattern     interface{}
+	matcher     types.GomegaMatcher
+	description string
+
+	matched bool
+}
+
+func isKeyPattern(key interface{}) bool {
+	switch key.(type) {
+	case *regexp.Regexp, types.GomegaMatcher:
+		return true
+	}
+	return false
+}
+
+// keyPatterns returns the patterns among m's keys, ordered by description
+func (m *KeysMatcher) keyPatterns() []*keyPattern {
+	patterns := []*keyPattern{}
+	for key, matcher := range m.Keys {
+		switch key := key.(type) {
+		case *regexp.Regexp:
+			patterns = append(patterns, &keyPattern{pattern: key, matcher: matcher, description: "/" + key.String() + "/"})
+		case types.GomegaMatcher:
+			patterns = append(patterns, &keyPattern{pattern: key, matcher: matcher, description: fmt.Sprintf("%#v", reflect.Indirect(reflect.ValueOf(key)).Interface())})
+		}
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		return patterns[i].description < patterns[j].description
+	})
+	return patterns
+}
+
+// matches reports whether key belongs to the pattern.  Regular expressions are matched against keys formatted with %v.
+func (p *keyPattern) matches(key interface{}) (bool, error) {
+	switch pattern := p.pattern.(type) {
+	case *regexp.Regexp:
+		return pattern.MatchString(fmt.Sprint(key)), nil
+	case types.GomegaMatcher:
+		return pattern.Match(key)
+	}
+	return false, nil
+}
+
 func (m *KeysMatcher) FailureMessage(actual interface{}) (message string) {
-	failures := make([]string, len(m.failures))
-	for i := range m.failures {
-		failures[i] = m.failures[i].Error()
+	return m.annotate(m.failureMessage(actual, m.failures))
+}
+
+func (m *KeysMatcher) failureMessage(actual interface{}, failures []error) string {
+	if m.DerefPointers {
+		var failure error
+		if actual, failure = derefActual(actual); failure != nil {
+			return failure.Error()
+		}
+	}
+	if m.ReportFullPaths {
+		return format.Message(reflect.TypeOf(actual).Name(), fullPathReport("to match keys", failures))
+	}
+	messages := make([]string, len(failures))
+	for i := range failures {
+		messages[i] = failures[i].Error()
 	}
 	return format.Message(reflect.TypeOf(actual).Name(),
-		fmt.Sprintf("to match keys: {\n%v\n}\n", strings.Join(failures, "\n")))
+		fmt.Sprintf("to match keys: {\n%v\n}\n", strings.Join(messages, "\n")))
 }
 
 func (m *KeysMatcher) NegatedFailureMessage(actual interface{}) (message string) {
-	return format.Message(actual, "not to match keys")
+	return m.annotate(format.Message(actual, "not to match keys"))
+}
+
+// LegacyFailureMessage makes KeysMatcher a types.LegacyFailureMessager: it renders the legacy failures of the
+// matchers nested within it, without naming the provenance of actual
+func (m *KeysMatcher) LegacyFailureMessage(actual interface{}, desiredMatch bool) string {
+	if !desiredMatch {
+		return format.Message(actual, "not to match keys")
+	}
+	return m.failureMessage(actual, m.legacy)
 }
 
 func (m *KeysMatcher) Failures() []error {
 	return m.failures
 }
+
+func (m *KeysMatcher) legacyFailures() []error {
+	return m.legacy
+}
+
+// TrackProvenance makes KeysMatcher a types.ProvenanceTracker
+func (m *KeysMatcher) TrackProvenance(provenance types.Provenance) {
+	m.trackedProvenance = provenance
+}
+
+// annotate names the provenance of actual when the matcher is nested in another that tracks it, as the paths in its
+// failure messages are relative to actual
+func (m *KeysMatcher) annotate(message string) string {
+	if len(m.provenance) == 0 {
+		return message
+	}
+	return m.provenance.Annotate(message)
+}
@@ -1,6 +1,8 @@
 package gstruct_test
 
 import (
+	"regexp"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
@@ -98,4 +100,128 @@ var _ = Describe("Map", func() {
 			".\"C\":\n	unexpected key C: map[",
 		))
 	})
+
+	It("should report failures in a deterministic order", func() {
+		m := MatchAllKeys(Keys{
+			"E": Equal("e"),
+			"D": Equal("d"),
+			"A": Equal("a"),
+		})
+
+		actual := map[string]string{"A": "b", "C": "c", "B": "b"}
+		m.Match(actual)
+		message := m.FailureMessage(actual)
+		Expect(message).Should(MatchRegexp(`(?s)\."A":.*\."B":.*\."C":.*missing expected key D\nmissing expected key E`))
+		for i := 0; i < 10; i++ {
+			m.Match(actual)
+			Expect(m.FailureMessage(actual)).Should(Equal(message))
+		}
+	})
+
+	Describe("matching keys by pattern", func() {
+		labels := map[string]string{
+			"app":          "web",
+			"metrics_cpu":  "12",
+			"metrics_mem":  "512",
+			"team":         "infra",
+			"metrics_disk": "7",
+		}
+
+		It("should apply a regular expression key's matcher to every key it matches", func() {
+			m := MatchAllKeys(Keys{
+				"app":                               Equal("web"),
+				"team":                              Equal("infra"),
+				regexp.MustCompile(`^metrics_\w+$`): MatchRegexp(`^\d+$`),
+			})
+			Expect(labels).Should(m)
+
+			labels := map[string]string{"app": "web", "team": "infra", "metrics_cpu": "high"}
+			Expect(m.Match(labels)).Should(BeFalse())
+			Expect(m.FailureMessage(labels)).Should(ContainSubstring(".\"metrics_cpu\":\n\tExpected\n\t    <string>: high\n\tto match regular expression"))
+		})
+
+		It("should apply a matcher key's matcher to every key it matches", func() {
+			m := MatchKeys(IgnoreExtras, Keys{
+				HavePrefix("metrics_"): Not(BeEmpty()),
+			})
+			Expect(labels).Should(m)
+			Expect(map[string]string{"metrics_cpu": ""}).ShouldNot(m)
+		})
+
+		It("should treat the keys a pattern matches as expected", func() {
+			m := MatchKeys(0, Keys{
+				"app":                  Equal("web"),
+				HavePrefix("metrics_"): Not(BeEmpty()),
+			})
+			Expect(m.Match(labels)).Should(BeFalse())
+			Expect(m.(*KeysMatcher).Failures()).Should(HaveLen(1))
+			Expect(m.FailureMessage(labels)).Should(ContainSubstring("unexpected key team"))
+		})
+
+		It("should not match exact keys against the patterns", func() {
+			m := MatchAllKeys(Keys{
+				"app":                  Equal("web"),
+				"team":                 Equal("infra"),
+				"metrics_cpu":          Equal("12"),
+				MatchRegexp(`metrics`): Not(Equal("12")),
+			})
+			Expect(labels).Should(m)
+		})
+
+		It("should report patterns that match no key as missing", func() {
+			m := MatchKeys(IgnoreExtras, Keys{
+				regexp.MustCompile(`^trace_`): BeEmpty(),
+			})
+			Expect(m.Match(labels)).Should(BeFalse())
+			Expect(m.FailureMessage(labels)).Should(ContainSubstring("missing expected keys matching /^trace_/"))
+
+			m = MatchKeys(IgnoreExtras|IgnoreMissing, Keys{
+				regexp.MustCompile(`^trace_`): BeEmpty(),
+			})
+			Expect(labels).Should(m)
+		})
+
+		It("should apply every pattern a key matches", func() {
+			m := MatchKeys(IgnoreExtras, Keys{
+				HavePrefix("metrics_"): Not(BeEmpty()),
+				HaveSuffix("_mem"):     Equal("1024"),
+			})
+			Expect(m.Match(labels)).Should(BeFalse())
+			Expect(m.FailureMessage(labels)).Should(ContainSubstring(".\"metrics_mem\":\n\tExpected\n\t    <string>: 512\n\tto equal\n\t    <string>: 1024"))
+		})
+
+		It("should report errors matching keys", func() {
+			m := MatchKeys(IgnoreExtras, Keys{
+				BeNumerically(">", 0): Equal("web"),
+			})
+			Expect(m.Match(labels)).Should(BeFalse())
+			Expect(m.FailureMessage(labels)).Should(ContainSubstring(".\"app\":\n\tExpected a number.  Got:\n\t    <string>: app"))
+		})
+	})
+
+	Describe("dereferencing pointers", func() {
+		web, db := "web", "db"
+		labels := map[string]*string{"app": &web, "backend": &db, "tier": nil}
+
+		It("should accept a pointer and dereference its values", func() {
+			m := MatchKeys(DerefPointers, Keys{
+				"app":     Equal("web"),
+				"backend": Equal("db"),
+				"tier":    BeNil(),
+			})
+			Expect(labels).Should(m)
+			Expect(&labels).Should(m)
+
+			m = MatchKeys(IgnoreExtras, Keys{
+				"app": Equal("web"),
+			})
+			Expect(m.Match(labels)).Should(BeFalse(), "should not dereference without the option")
+		})
+
+		It("should fail on a nil pointer", func() {
+			m := MatchKeys(DerefPointers, Keys{})
+			Expect(m.Match((*map[string]*string)(nil))).Should(BeFalse())
+			Expect(m.FailureMessage((*map[string]*string)(nil))).Should(Equal("Expected\n    <*map[string]*string | 0x0>: nil\nnot to be <nil>"))
+		})
+	})
 })
@@ -3,10 +3,12 @@
 package gstruct
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 
 	"github.com/onsi/gomega/format"
+	errorsutil "github.com/onsi/gomega/gstruct/errors"
 	"github.com/onsi/gomega/types"
 )
 
@@ -24,10 +26,18 @@ type PointerMatcher struct {
 	Matcher types.GomegaMatcher
 
 	// Failure message.
-	failure string
+	failure       string
+	legacyFailure string
+	failures      []error
+
+	provenance             types.Provenance
+	trackedProvenance      types.Provenance
+	nestedTracksProvenance bool
 }
 
 func (m *PointerMatcher) Match(actual interface{}) (bool, error) {
+	// provenance only applies to the Match it was tracked for - a later, standalone, Match starts afresh
+	m.provenance, m.trackedProvenance = m.trackedProvenance, nil
 	val := reflect.ValueOf(actual)
 
 	// return error if actual type is not a pointer
@@ -37,22 +47,68 @@ func (m *PointerMatcher) Match(actual interface{}) (bool, error) {
 
 	if !val.IsValid() || val.IsNil() {
 		m.failure = format.Message(actual, "not to be <nil>")
+		m.legacyFailure = m.failure
+		m.failures = []error{errors.New(m.failure)}
 		return false, nil
 	}
 
 	// Forward the value.
 	elem := val.Elem().Interface()
+	m.nestedTracksProvenance = types.TrackProvenance(m.Matcher, m.provenance)
 	match, err := m.Matcher.Match(elem)
 	if !match {
 		m.failure = m.Matcher.FailureMessage(elem)
+		m.legacyFailure = types.LegacyFailureMessageFor(m.Matcher, elem, true)
+		m.failures = []error{errors.New(m.failure)}
+		switch nesting := m.Matcher.(type) {
+		case errorsutil.NestingMatcher:
+			m.failures = nesting.Failures()
+		case fullPathNestingMatcher:
+			m.failures = nesting.fullPathFailures()
+		}
 	}
 	return match, err
 }
 
 func (m *PointerMatcher) FailureMessage(_ interface{}) (message string) {
-	return m.failure
+	return m.annotate(m.failure)
+}
+
+// fullPathFailures lets ReportFullPaths list the failures of a nesting matcher PointerMatcher points to with their full
+// paths.  PointerMatcher isn't a NestingMatcher, so that it is otherwise reported with the failure message of the
+// matcher it points to, as it always has been.
+func (m *PointerMatcher) fullPathFailures() []error {
+	return m.failures
 }
 
 func (m *PointerMatcher) NegatedFailureMessage(actual interface{}) (message string) {
-	return m.Matcher.NegatedFailureMessage(actual)
+	return m.annotate(m.Matcher.NegatedFailureMessage(actual))
+}
+
+// LegacyFailureMessage makes PointerMatcher a types.LegacyFailureMessager: it renders the legacy message of the matcher
+// it points to, without naming the provenance of actual
+func (m *PointerMatcher) LegacyFailureMessage(actual interface{}, desiredMatch bool) string {
+	if desiredMatch {
+		return m.legacyFailure
+	}
+	return types.LegacyFailureMessageFor(m.Matcher, actual, false)
+}
+
+// legacyFailures does not nest the failures of the matcher PointerMatcher points to, as they weren't before
+// PointerMatcher was a NestingMatcher
+func (m *PointerMatcher) legacyFailures() []error {
+	return []error{errors.New(m.legacyFailure)}
+}
+
+// TrackProvenance makes PointerMatcher a types.ProvenanceTracker.  Pointers are followed transparently, so the
+// provenance is passed on to the matcher as is.
+func (m *PointerMatcher) TrackProvenance(provenance types.Provenance) {
+	m.trackedProvenance = provenance
+}
+
+func (m *PointerMatcher) annotate(message string) string {
+	if len(m.provenance) == 0 || m.nestedTracksProvenance {
+		return message
+	}
+	return m.provenance.Annotate(message)
 }
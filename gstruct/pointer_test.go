@@ -30,4 +30,28 @@ var _ = Describe("PointTo", func() {
 		Expect(ipp).Should(PointTo(PointTo(Equal(1))))
 		Expect(ipp).ShouldNot(PointTo(PointTo(Equal(2))))
 	})
+
+	It("should report the failure message of the matcher it points to when nested", func() {
+		type Inner struct{ A string }
+		type Outer struct{ P *Inner }
+		m := MatchAllFields(Fields{"P": PointTo(MatchAllFields(Fields{"A": Equal("b")}))})
+		Expect(m.Match(Outer{P: &Inner{A: "a"}})).Should(BeFalse())
+		Expect(m.FailureMessage(Outer{P: &Inner{A: "a"}})).Should(Equal("Expected\n    <string>: Outer\nto match fields: {\n.P:\n\tProvenance: actual.P\n\tExpected\n\t    <string>: Inner\n\tto match fields: {\n\t.A:\n\t\tExpected\n\t\t    <string>: a\n\t\tto equal\n\t\t    <string>: b\n\t}\n\t\n}\n"))
+	})
+
+	It("should pass on the failures of nested matchers to be reported with full paths", func() {
+		type Inner struct{ A string }
+		type Outer struct{ P *Inner }
+		inner := &Inner{A: "a"}
+		m := MatchFields(ReportFullPaths, Fields{"P": PointTo(MatchAllFields(Fields{"A": Equal("b")}))})
+		Expect(m.Match(Outer{P: inner})).Should(BeFalse())
+		Expect(m.FailureMessage(Outer{P: inner})).Should(HaveSuffix("to match fields, but found 1 mismatch:\n    .P.A:\n        Expected\n            <string>: a\n        to equal\n            <string>: b"))
+
+		Expect(m.Match(Outer{})).Should(BeFalse())
+		Expect(m.FailureMessage(Outer{})).Should(HaveSuffix("to match fields, but found 1 mismatch:\n    .P:\n        Expected\n            <*gstruct_test.Inner | 0x0>: nil\n        not to be <nil>"))
+
+		m = MatchFields(ReportFullPaths, Fields{"P": PointTo(PointTo(MatchAllFields(Fields{"A": Equal("b")})))})
+		Expect(m.Match(struct{ P **Inner }{P: &inner})).Should(BeFalse())
+		Expect(m.FailureMessage(struct{ P **Inner }{P: &inner})).Should(ContainSubstring("\n    .P.A:\n"))
+	})
 })
@@ -0,0 +1,134 @@
+package gstruct
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	errorsutil "github.com/onsi/gomega/gstruct/errors"
+	"github.com/onsi/gomega/types"
+)
+
+// fullPathReport renders failures - and the failures nested within them - as a flat list of mismatches, each
+// labelled with its full path
+func fullPathReport(expectation string, failures []error) string {
+	expanded := make([]error, len(failures))
+	for i, failure := range failures {
+		expanded[i] = expand(failure)
+	}
+	leaves := errorsutil.Flatten(expanded)
+	mismatches := "mismatches"
+	if len(leaves) == 1 {
+		mismatches = "mismatch"
+	}
+
+	lines := []string{fmt.Sprintf("%s, but found %d %s:", expectation, len(leaves), mismatches)}
+	for _, leaf := range leaves {
+		message := leaf.Err.Error()
+		switch {
+		case leaf.Path == "":
+		case strings.Contains(message, "\n"):
+			message = fmt.Sprintf("%s:\n%s", leaf.Path, format.IndentString(message, 1))
+		default:
+			message = fmt.Sprintf("%s: %s", leaf.Path, message)
+		}
+		lines = append(lines, format.IndentString(message, 1))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fullPathNestingMatcher is implemented by matchers, such as PointTo, that render the failure message of the matcher
+// nested within them as it is, but whose nested failures are listed with their full paths by ReportFullPaths
+type fullPathNestingMatcher interface {
+	fullPathFailures() []error
+}
+
+// collapsedFailure is the failure of a fullPathNestingMatcher.  It reads as the matcher's failure message, but
+// fullPathReport expands it into the failures nested within.
+type collapsedFailure struct {
+	message string
+	nested  []error
+}
+
+func (f *collapsedFailure) Error() string {
+	return f.message
+}
+
+// expand replaces the collapsedFailures within err with the failures nested in them
+func expand(err error) error {
+	switch err := err.(type) {
+	case errorsutil.AggregateError:
+		expanded := errorsutil.AggregateError{}
+		for _, nested := range err {
+			expanded = append(expanded, expand(nested))
+		}
+		return expanded
+	case *errorsutil.NestedError:
+		return &errorsutil.NestedError{Path: err.Path, Err: expand(err.Err)}
+	case *collapsedFailure:
+		return expand(errorsutil.AggregateError(err.nested))
+	}
+	return err
+}
+
+// failureList collects the failures of a gstruct matcher twice: as they are rendered now, and as they were rendered
+// before failure messages were reworked - for Gomegas with types.CompatibilityMode's LegacyFailureMessages set
+type failureList struct {
+	failures []error
+	legacy   []error
+}
+
+// add adds err, nested under path unless path is empty.  Errors returned by failureOf are split into their two
+// renderings.
+func (l *failureList) add(path string, err error) {
+	failure, legacy := err, err
+	if f, ok := err.(matcherFailure); ok {
+		failure, legacy = f.failure, f.legacy
+	}
+	if path != "" {
+		failure, legacy = errorsutil.Nest(path, failure), errorsutil.Nest(path, legacy)
+	}
+	l.failures = append(l.failures, failure)
+	l.legacy = append(l.legacy, legacy)
+}
+
+// legacyNestingMatcher is implemented by the gstruct matchers, which keep the legacy failures of the matchers nested
+// within them alongside their failures
+type legacyNestingMatcher interface {
+	legacyFailures() []error
+}
+
+// matcherFailure is the failure of a nested matcher, along with its legacy rendering
+type matcherFailure struct {
+	failure error
+	legacy  error
+}
+
+func (f matcherFailure) Error() string {
+	return f.failure.Error()
+}
+
+// failureOf returns the failure of matcher, which failed to match actual
+func failureOf(matcher types.GomegaMatcher, actual interface{}) error {
+	f := matcherFailure{}
+	switch nesting := matcher.(type) {
+	case errorsutil.NestingMatcher:
+		f.failure = errorsutil.AggregateError(nesting.Failures())
+	case fullPathNestingMatcher:
+		f.failure = &collapsedFailure{message: matcher.FailureMessage(actual), nested: nesting.fullPathFailures()}
+	default:
+		f.failure = errors.New(matcher.FailureMessage(actual))
+	}
+	switch legacy := matcher.(type) {
+	case legacyNestingMatcher:
+		f.legacy = errorsutil.AggregateError(legacy.legacyFailures())
+	case errorsutil.NestingMatcher:
+		f.legacy = f.failure
+	case types.LegacyFailureMessager:
+		f.legacy = errors.New(legacy.LegacyFailureMessage(actual, true))
+	default:
+		f.legacy = f.failure
+	}
+	return f
+}
@@ -0,0 +1,19 @@
+package gstruct
+
+import (
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+//HaveStats computes statistics over a slice of numbers or time.Durations (see gomega.HaveStatistics) and
+//matches the resulting matchers.Statistics struct against the given fields.  Statistics that are not
+//mentioned are ignored.
+//  Expect(latencies).To(HaveStats(Fields{
+//      "Mean": BeNumerically("<", 5*time.Millisecond),
+//      "P99":  BeNumerically("<", 20*time.Millisecond),
+//  }))
+func HaveStats(fields Fields) types.GomegaMatcher {
+	return &matchers.HaveStatisticsMatcher{
+		Matcher: MatchFields(IgnoreExtras, fields),
+	}
+}
@@ -0,0 +1,36 @@
+package gstruct_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("HaveStats", func() {
+	latencies := []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond, 30 * time.Millisecond}
+
+	It("should match the given statistics and ignore the rest", func() {
+		Expect(latencies).Should(HaveStats(Fields{
+			"Mean": BeNumerically("<", 10*time.Millisecond),
+			"Min":  BeNumerically("==", time.Millisecond),
+		}))
+	})
+
+	It("should report the statistics that failed", func() {
+		m := HaveStats(Fields{
+			"Mean": BeNumerically("<", 5*time.Millisecond),
+			"P99":  BeNumerically("<", 20*time.Millisecond),
+		})
+		Expect(m.Match(latencies)).Should(BeFalse())
+		message := m.FailureMessage(latencies)
+		Expect(message).Should(HavePrefix("Statistics for 4 samples failed to satisfy matcher.\n"))
+		Expect(message).Should(ContainSubstring(".Mean:"))
+		Expect(message).Should(ContainSubstring(".P99:"))
+	})
+
+	It("should fail on unknown statistics", func() {
+		Expect(latencies).ShouldNot(HaveStats(Fields{"P42": BeZero()}))
+	})
+})
@@ -12,4 +12,15 @@ const (
 	//considered by the indentifier function. All members that map to a given key must still match successfully
 	//with the matcher that is provided for that key.
 	AllowDuplicates
+	//ReportFullPaths tells the matcher to report every mismatch among the matchers nested within it - through
+	//MatchFields, MatchElements, MatchKeys and PointTo - as a single flat list, each with its full path
+	//(e.g. .Spec.Template.Spec.Containers[0].Image), rather than as nested, indented fragments.
+	ReportFullPaths
+	//PromoteEmbeddedFields tells MatchFields to match the fields of embedded structs as if they were fields of the
+	//struct itself, following Go's rules for promoted fields.  The embedded structs need not be matched themselves.
+	PromoteEmbeddedFields
+	//DerefPointers tells MatchFields, MatchElements and MatchKeys to accept a pointer to the struct, slice, array or
+	//map they match, and to dereference the pointer fields, elements or values before applying their matchers - so
+	//neither need wrapping in PointTo.  Nil pointers among them are passed on as they are.
+	DerefPointers
 )
@@ -92,18 +92,13 @@ func (assertion *Assertion) match(matcher types.GomegaMatcher, desiredMatch bool
 	assertion.g.THelper()
 	if err != nil {
 		description := assertion.buildDescription(optionalDescription...)
-		assertion.g.Fail(description+err.Error(), 2+assertion.offset)
+		assertion.g.Fail(assertion.g.withFailureArtifacts(format.AccessibleString(description+err.Error()), 2+assertion.offset), 2+assertion.offset)
 		return false
 	}
 	if matches != desiredMatch {
-		var message string
-		if desiredMatch {
-			message = matcher.FailureMessage(actualInput)
-		} else {
-			message = matcher.NegatedFailureMessage(actualInput)
-		}
+		message := assertion.g.failureMessageFor(matcher, actualInput, desiredMatch)
 		description := assertion.buildDescription(optionalDescription...)
-		assertion.g.Fail(description+message, 2+assertion.offset)
+		assertion.g.Fail(assertion.g.withFailureArtifacts(format.AccessibleString(description+message), 2+assertion.offset), 2+assertion.offset)
 		return false
 	}
 
@@ -121,7 +116,7 @@ func (assertion *Assertion) vetActuals(optionalDescription ...interface{}) bool
 
 	description := assertion.buildDescription(optionalDescription...)
 	assertion.g.THelper()
-	assertion.g.Fail(description+message, 2+assertion.offset)
+	assertion.g.Fail(assertion.g.withFailureArtifacts(format.AccessibleString(description+message), 2+assertion.offset), 2+assertion.offset)
 	return false
 }
 
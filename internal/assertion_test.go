@@ -3,9 +3,12 @@ package internal_test
 import (
 	"errors"
 	"reflect"
+	"runtime"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
 )
 
 var _ = Describe("Making Synchronous Assertions", func() {
@@ -223,4 +226,106 @@ var _ = Describe("Making Synchronous Assertions", func() {
 
 	})
 
+	When("format.AccessibleOutput is enabled", func() {
+		BeforeEach(func() {
+			format.AccessibleOutput = true
+			DeferCleanup(func() {
+				format.AccessibleOutput = false
+			})
+		})
+
+		It("escapes non-ASCII characters in the failure message and description", func() {
+			ig := NewInstrumentedGomega()
+			ig.G.Expect("ünïcode").To(Equal("unicode"), "ça va?")
+			Expect(ig.FailureMessage).To(Equal(`\u00e7a va?
+ACTUAL:
+    <string>: \u00fcn\u00efcode
+EXPECTATION: to equal
+EXPECTED:
+    <string>: unicode
+DIFF: first mismatch at byte offset 0
+    actual:   "\u00fcn\u00efc..."
+    expected: "unicod..."
+               ^`))
+		})
+	})
+
+	Describe("failure artifact providers", func() {
+		var ig *InstrumentedGomega
+		var failures []types.FailureMetadata
+
+		recordingProvider := func(artifacts []string, err error) types.FailureArtifactProvider {
+			return types.FailureArtifactProviderFunc(func(failure types.FailureMetadata) ([]string, error) {
+				failures = append(failures, failure)
+				return artifacts, err
+			})
+		}
+
+		BeforeEach(func() {
+			ig = NewInstrumentedGomega()
+			failures = []types.FailureMetadata{}
+		})
+
+		It("does not call the providers when the assertion passes", func() {
+			ig.G.FailureArtifactProviders = append(ig.G.FailureArtifactProviders, recordingProvider(nil, nil))
+			ig.G.Expect(true).To(BeTrue())
+			Expect(failures).To(BeEmpty())
+		})
+
+		It("calls the providers with the failure message and location, and appends the artifacts to the message", func() {
+			ig.G.FailureArtifactProviders = append(ig.G.FailureArtifactProviders,
+				recordingProvider([]string{"/tmp/a.txt"}, nil),
+				recordingProvider([]string{"/tmp/b.txt", "/tmp/c.txt"}, nil),
+			)
+
+			_, thisFile, anchorLine, _ := runtime.Caller(0)
+			ig.G.Expect(true).To(BeFalse())
+
+			Expect(failures).To(HaveLen(2))
+			Expect(failures[0].Message).To(Equal("Expected\n    <bool>: true\nto be false"))
+			Expect(failures[0].File).To(Equal(thisFile))
+			Expect(failures[0].Line).To(Equal(anchorLine + 1))
+			Expect(failures[0].Time).NotTo(BeZero())
+			Expect(failures[1]).To(Equal(failures[0]))
+
+			Expect(ig.FailureMessage).To(Equal("Expected\n    <bool>: true\nto be false\n\nFailure artifacts:\n    /tmp/a.txt\n    /tmp/b.txt\n    /tmp/c.txt"))
+		})
+
+		It("reports errors returned by the providers alongside any artifacts they did collect", func() {
+			ig.G.FailureArtifactProviders = append(ig.G.FailureArtifactProviders,
+				recordingProvider([]string{"/tmp/a.txt"}, errors.New("boom")),
+				recordingProvider(nil, errors.New("bam")),
+			)
+			ig.G.Expect(true).To(BeFalse())
+			Expect(ig.FailureMessage).To(Equal("Expected\n    <bool>: true\nto be false\n\nFailure artifacts:\n    /tmp/a.txt\n\nFailed to collect failure artifacts:\n    boom\n    bam"))
+		})
+
+		It("is called when the matcher errors", func() {
+			ig.G.FailureArtifactProviders = append(ig.G.FailureArtifactProviders, recordingProvider([]string{"/tmp/a.txt"}, nil))
+			ig.G.Expect(3).To(BeTrue())
+			Expect(failures).To(HaveLen(1))
+			Expect(ig.FailureMessage).To(HaveSuffix("\n\nFailure artifacts:\n    /tmp/a.txt"))
+		})
+
+		It("is called when extra values fail to be nil", func() {
+			ig.G.FailureArtifactProviders = append(ig.G.FailureArtifactProviders, recordingProvider([]string{"/tmp/a.txt"}, nil))
+			ig.G.Expect(3, errors.New("boom")).To(Equal(3))
+			Expect(failures).To(HaveLen(1))
+			Expect(failures[0].Message).To(ContainSubstring("boom"))
+			Expect(ig.FailureMessage).To(HaveSuffix("\n\nFailure artifacts:\n    /tmp/a.txt"))
+		})
+
+		It("is called when an asynchronous assertion fails", func() {
+			ig.G.FailureArtifactProviders = append(ig.G.FailureArtifactProviders, recordingProvider([]string{"/tmp/a.txt"}, nil))
+
+			_, thisFile, anchorLine, _ := runtime.Caller(0)
+			ig.G.Eventually(true, "10ms", "1ms").Should(BeFalse())
+
+			Expect(failures).To(HaveLen(1))
+			Expect(failures[0].Message).To(HavePrefix("Timed out after"))
+			Expect(failures[0].File).To(Equal(thisFile))
+			Expect(failures[0].Line).To(Equal(anchorLine + 1))
+			Expect(ig.FailureMessage).To(HaveSuffix("\n\nFailure artifacts:\n    /tmp/a.txt"))
+		})
+	})
 })
@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"context"
+
+	"github.com/onsi/gomega/types"
+)
+
+// asyncActualAdapterFor returns the first of g's AsyncActualAdapters that adapts actual, or nil if none do
+func (g *Gomega) asyncActualAdapterFor(actual interface{}) types.AsyncActualAdapter {
+	for _, adapter := range g.AsyncActualAdapters {
+		if adapter.Adapts(actual) {
+			return adapter
+		}
+	}
+	return nil
+}
+
+// buildAdaptedPoller returns a poller that asks adapter for actual's current value.  Like polled functions, adapters
+// can signal StopTrying and TryAgainAfter by panicking.
+func (assertion *AsyncAssertion) buildAdaptedPoller(adapter types.AsyncActualAdapter, actual interface{}) func() (interface{}, error) {
+	ctx := assertion.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return func() (value interface{}, err error) {
+		defer func() {
+			if e := recover(); e != nil {
+				if _, isAsyncError := AsPollingSignalError(e); isAsyncError {
+					err = e.(error)
+				} else {
+					panic(e)
+				}
+			}
+		}()
+		return adapter.Poll(ctx, actual)
+	}
+}
@@ -0,0 +1,165 @@
+package internal_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/internal"
+	"github.com/onsi/gomega/types"
+)
+
+// fakeFuture is a value that is resolved, once, at some point in the future
+type fakeFuture struct {
+	lock     sync.Mutex
+	value    string
+	err      error
+	resolved bool
+}
+
+func (f *fakeFuture) resolve(value string, err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.value, f.err, f.resolved = value, err, true
+}
+
+// fakeFutureAdapter polls *fakeFutures, recording the contexts it is passed
+type fakeFutureAdapter struct {
+	contexts []context.Context
+}
+
+func (a *fakeFutureAdapter) Adapts(actual interface{}) bool {
+	_, ok := actual.(*fakeFuture)
+	return ok
+}
+
+func (a *fakeFutureAdapter) Poll(ctx context.Context, actual interface{}) (interface{}, error) {
+	a.contexts = append(a.contexts, ctx)
+	f := actual.(*fakeFuture)
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if !f.resolved {
+		return "pending", nil
+	}
+	return f.value, f.err
+}
+
+func (a *fakeFutureAdapter) MayChange(actual interface{}, value interface{}) bool {
+	f := actual.(*fakeFuture)
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return !f.resolved
+}
+
+var _ = Describe("AsyncActualAdapters", func() {
+	var ig *InstrumentedGomega
+	var adapter *fakeFutureAdapter
+	var future *fakeFuture
+
+	BeforeEach(func() {
+		ig = NewInstrumentedGomega()
+		adapter = &fakeFutureAdapter{}
+		ig.G.AsyncActualAdapters = []types.AsyncActualAdapter{adapter}
+		future = &fakeFuture{}
+	})
+
+	It("polls adapted actuals through the adapter", func() {
+		time.AfterFunc(30*time.Millisecond, func() { future.resolve("done", nil) })
+		Ω(ig.G.Eventually(future).WithTimeout(time.Second).WithPolling(5 * time.Millisecond).Should(Equal("done"))).Should(BeTrue())
+		Ω(ig.FailureMessage).Should(BeZero())
+		Ω(len(adapter.contexts)).Should(BeNumerically(">", 1))
+	})
+
+	It("reports the last polled value when it times out", func() {
+		ig.G.Eventually(future).WithTimeout(30 * time.Millisecond).WithPolling(5 * time.Millisecond).Should(Equal("done"))
+		Ω(ig.FailureMessage).Should(ContainSubstring("Timed out after"))
+		Ω(ig.FailureMessage).Should(ContainSubstring("<string>: pending"))
+	})
+
+	It("passes the assertion's context to the adapter", func() {
+		ctx := context.WithValue(context.Background(), "key", "value")
+		future.resolve("done", nil)
+		ig.G.Eventually(ctx, future).Should(Equal("done"))
+		Ω(adapter.contexts).Should(ConsistOf(ctx))
+
+		ig.G.Eventually(future).Should(Equal("done"))
+		Ω(adapter.contexts[1]).Should(Equal(context.Background()))
+	})
+
+	It("reports errors returned by the adapter", func() {
+		future.resolve("", errors.New("boom"))
+		ig.G.Eventually(future).WithTimeout(30 * time.Millisecond).WithPolling(5 * time.Millisecond).Should(Equal("done"))
+		Ω(ig.FailureMessage).Should(ContainSubstring("returned the following error:\nboom"))
+	})
+
+	It("supports polling signals returned by, or panicked in, the adapter", func() {
+		ig.G.AsyncActualAdapters = []types.AsyncActualAdapter{stopTryingAdapter{}}
+		ig.G.Eventually(future).WithTimeout(time.Second).Should(Equal("done"))
+		Ω(ig.FailureMessage).Should(ContainSubstring("Told to stop trying"))
+		Ω(ig.FailureMessage).Should(ContainSubstring("the future was cancelled"))
+	})
+
+	Describe("stopping early", func() {
+		It("bails out of Eventually when the value can no longer change and the matcher fails", func() {
+			future.resolve("failed", nil)
+			t := time.Now()
+			ig.G.Eventually(future).WithTimeout(time.Second).Should(Equal("done"))
+			Ω(time.Since(t)).Should(BeNumerically("<", 100*time.Millisecond))
+			Ω(ig.FailureMessage).Should(ContainSubstring("No future change is possible.  Bailing out early"))
+		})
+
+		It("ends Consistently early when the value can no longer change", func() {
+			future.resolve("done", nil)
+			t := time.Now()
+			Ω(ig.G.Consistently(future).WithTimeout(time.Second).Should(Equal("done"))).Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("<", 100*time.Millisecond))
+		})
+
+		It("keeps polling while the value may change", func() {
+			t := time.Now()
+			Ω(ig.G.Consistently(future).WithTimeout(50 * time.Millisecond).WithPolling(5 * time.Millisecond).Should(Equal("pending"))).Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically(">=", 50*time.Millisecond))
+		})
+	})
+
+	It("uses the first adapter that adapts the actual", func() {
+		ig.G.AsyncActualAdapters = []types.AsyncActualAdapter{stopTryingAdapter{}, adapter}
+		future.resolve("done", nil)
+		ig.G.Eventually(future).Should(Equal("done"))
+		Ω(ig.FailureMessage).Should(ContainSubstring("Told to stop trying"))
+		Ω(adapter.contexts).Should(BeEmpty())
+	})
+
+	It("leaves actuals that no adapter adapts alone", func() {
+		Ω(ig.G.Eventually("done").Should(Equal("done"))).Should(BeTrue())
+		Ω(ig.G.Eventually(func() string { return "done" }).Should(Equal("done"))).Should(BeTrue())
+		Ω(adapter.contexts).Should(BeEmpty())
+	})
+
+	It("adapts the sources passed to EventuallyAny", func() {
+		other := &fakeFuture{}
+		time.AfterFunc(30*time.Millisecond, func() { other.resolve("done", nil) })
+		Ω(ig.G.EventuallyAny(future, other).WithTimeout(time.Second).WithPolling(5 * time.Millisecond).Should(Equal("done"))).Should(BeTrue())
+		Ω(ig.FailureMessage).Should(BeZero())
+	})
+})
+
+// stopTryingAdapter adapts *fakeFutures by telling Eventually to stop trying
+type stopTryingAdapter struct{}
+
+func (stopTryingAdapter) Adapts(actual interface{}) bool {
+	_, ok := actual.(*fakeFuture)
+	return ok
+}
+
+func (stopTryingAdapter) Poll(ctx context.Context, actual interface{}) (interface{}, error) {
+	internal.StopTrying("the future was cancelled").Now()
+	return nil, nil
+}
+
+func (stopTryingAdapter) MayChange(actual interface{}, value interface{}) bool {
+	return true
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"reflect"
 	"runtime"
+	"strings"
 	"sync"
 	"time"
 
@@ -70,12 +72,17 @@ type AsyncAssertion struct {
 
 	actualIsFunc  bool
 	actual        interface{}
+	adapter       types.AsyncActualAdapter
 	argsToForward []interface{}
 
 	timeoutInterval    time.Duration
+	timeoutName        string
 	pollingInterval    time.Duration
 	mustPassRepeatedly int
+	maxAttempts        int
+	hasMaxAttempts     bool
 	ctx                context.Context
+	accessLock         sync.Locker
 	offset             int
 	g                  *Gomega
 }
@@ -92,13 +99,25 @@ func NewAsyncAssertion(asyncType AsyncAssertionType, actualInput interface{}, g
 	}
 
 	out.actual = actualInput
-	if actualInput != nil && reflect.TypeOf(actualInput).Kind() == reflect.Func {
-		out.actualIsFunc = true
+	if actuals, ok := actualInput.(anyOfActuals); ok {
+		for _, actual := range actuals {
+			out.actualIsFunc = out.actualIsFunc || isFunc(actual) || isCursorSource(actual) || g.asyncActualAdapterFor(actual) != nil
+		}
+	} else {
+		out.actualIsFunc = isFunc(actualInput) || isCursorSource(actualInput)
+		out.adapter = g.asyncActualAdapterFor(actualInput)
 	}
 
 	return out
 }
 
+var (
+	_ types.NamedTimeoutAsyncAssertion     = &AsyncAssertion{}
+	_ types.SerializedAccessAsyncAssertion = &AsyncAssertion{}
+	_ types.AttemptLimitedAsyncAssertion   = &AsyncAssertion{}
+	_ types.AsyncAssertionInspector        = &AsyncAssertion{}
+)
+
 func (assertion *AsyncAssertion) WithOffset(offset int) types.AsyncAssertion {
 	assertion.offset = offset
 	return assertion
@@ -106,6 +125,12 @@ func (assertion *AsyncAssertion) WithOffset(offset int) types.AsyncAssertion {
 
 func (assertion *AsyncAssertion) WithTimeout(interval time.Duration) types.AsyncAssertion {
 	assertion.timeoutInterval = interval
+	assertion.timeoutName = ""
+	return assertion
+}
+
+func (assertion *AsyncAssertion) WithTimeoutNamed(name string) types.AsyncAssertion {
+	assertion.timeoutName = name
 	return assertion
 }
 
@@ -116,6 +141,7 @@ func (assertion *AsyncAssertion) WithPolling(interval time.Duration) types.Async
 
 func (assertion *AsyncAssertion) Within(timeout time.Duration) types.AsyncAssertion {
 	assertion.timeoutInterval = timeout
+	assertion.timeoutName = ""
 	return assertion
 }
 
@@ -134,11 +160,62 @@ func (assertion *AsyncAssertion) WithArguments(argsToForward ...interface{}) typ
 	return assertion
 }
 
+func (assertion *AsyncAssertion) WithSerializedAccess(lock sync.Locker) types.AsyncAssertion {
+	assertion.accessLock = lock
+	return assertion
+}
+
 func (assertion *AsyncAssertion) MustPassRepeatedly(count int) types.AsyncAssertion {
 	assertion.mustPassRepeatedly = count
 	return assertion
 }
 
+func (assertion *AsyncAssertion) StopTryingAfterAttempts(attempts int) types.AsyncAssertion {
+	assertion.maxAttempts = attempts
+	assertion.hasMaxAttempts = true
+	return assertion
+}
+
+func (assertion *AsyncAssertion) Timeout() (time.Duration, bool) {
+	if assertion.timeoutName != "" {
+		return assertion.g.NamedDuration(assertion.timeoutName)
+	}
+	if assertion.timeoutInterval >= 0 {
+		return assertion.timeoutInterval, true
+	}
+	if assertion.asyncType == AsyncAssertionTypeConsistently {
+		return assertion.g.DurationBundle.ConsistentlyDuration, true
+	}
+	if assertion.ctx != nil {
+		return 0, false
+	}
+	return assertion.g.DurationBundle.EventuallyTimeout, true
+}
+
+func (assertion *AsyncAssertion) PollingInterval() time.Duration {
+	if assertion.pollingInterval >= 0 {
+		return assertion.pollingInterval
+	}
+	if assertion.asyncType == AsyncAssertionTypeConsistently {
+		return assertion.g.DurationBundle.ConsistentlyPollingInterval
+	}
+	return assertion.g.DurationBundle.EventuallyPollingInterval
+}
+
+func (assertion *AsyncAssertion) Context() context.Context {
+	return assertion.ctx
+}
+
+func (assertion *AsyncAssertion) Arguments() []interface{} {
+	return append([]interface{}{}, assertion.argsToForward...)
+}
+
+func (assertion *AsyncAssertion) Clone() types.AsyncAssertion {
+	clone := *assertion
+	clone.argsToForward = assertion.Arguments()
+	return &clone
+}
+
 func (assertion *AsyncAssertion) Should(matcher types.GomegaMatcher, optionalDescription ...interface{}) bool {
 	assertion.g.THelper()
 	vetOptionalDescription("Asynchronous assertion", optionalDescription...)
@@ -238,11 +315,24 @@ You can learn more at https://onsi.github.io/gomega/#eventually
 }
 
 func (assertion *AsyncAssertion) buildActualPoller() (func() (interface{}, error), error) {
-	if !assertion.actualIsFunc {
-		return func() (interface{}, error) { return assertion.actual, nil }, nil
+	if actuals, ok := assertion.actual.(anyOfActuals); ok {
+		return assertion.buildAnyOfActualsPoller(actuals)
 	}
-	actualValue := reflect.ValueOf(assertion.actual)
-	actualType := reflect.TypeOf(assertion.actual)
+	return assertion.buildPollerFor(assertion.actual)
+}
+
+func (assertion *AsyncAssertion) buildPollerFor(actual interface{}) (func() (interface{}, error), error) {
+	if source, ok := actual.(types.CursorSource); ok {
+		return buildCursorPoller(source), nil
+	}
+	if adapter := assertion.g.asyncActualAdapterFor(actual); adapter != nil {
+		return assertion.buildAdaptedPoller(adapter, actual), nil
+	}
+	if !isFunc(actual) {
+		return func() (interface{}, error) { return actual, nil }, nil
+	}
+	actualValue := reflect.ValueOf(actual)
+	actualType := reflect.TypeOf(actual)
 	numIn, numOut, isVariadic := actualType.NumIn(), actualType.NumOut(), actualType.IsVariadic()
 
 	if numIn == 0 && numOut == 0 {
@@ -328,33 +418,24 @@ func (assertion *AsyncAssertion) buildActualPoller() (func() (interface{}, error
 }
 
 func (assertion *AsyncAssertion) afterTimeout() <-chan time.Time {
-	if assertion.timeoutInterval >= 0 {
-		return time.After(assertion.timeoutInterval)
+	timeout, ok := assertion.Timeout()
+	if !ok {
+		return nil
 	}
-
-	if assertion.asyncType == AsyncAssertionTypeConsistently {
-		return time.After(assertion.g.DurationBundle.ConsistentlyDuration)
-	} else {
-		if assertion.ctx == nil {
-			return time.After(assertion.g.DurationBundle.EventuallyTimeout)
-		} else {
-			return nil
-		}
+	if assertion.g.Compatibility.LegacyTimeouts {
+		return time.After(timeout)
 	}
+	return time.After(assertion.g.DurationBundle.scaleTimeout(timeout))
 }
 
 func (assertion *AsyncAssertion) afterPolling() <-chan time.Time {
-	if assertion.pollingInterval >= 0 {
-		return time.After(assertion.pollingInterval)
-	}
-	if assertion.asyncType == AsyncAssertionTypeConsistently {
-		return time.After(assertion.g.DurationBundle.ConsistentlyPollingInterval)
-	} else {
-		return time.After(assertion.g.DurationBundle.EventuallyPollingInterval)
-	}
+	return time.After(assertion.PollingInterval())
 }
 
 func (assertion *AsyncAssertion) matcherSaysStopTrying(matcher types.GomegaMatcher, value interface{}) bool {
+	if assertion.adapter != nil {
+		return !assertion.adapter.MayChange(assertion.actual, value)
+	}
 	if assertion.actualIsFunc || types.MatchMayChangeInTheFuture(matcher, value) {
 		return false
 	}
@@ -377,7 +458,24 @@ func (assertion *AsyncAssertion) pollMatcher(matcher types.GomegaMatcher, value
 	return
 }
 
-func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch bool, optionalDescription ...interface{}) bool {
+func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch bool, optionalDescription ...interface{}) (succeeded bool) {
+	if actuals, ok := assertion.actual.(anyOfActuals); ok {
+		matcher = &anyOfActualsMatcher{g: assertion.g, matcher: matcher, desiredMatch: desiredMatch, numActuals: len(actuals)}
+		desiredMatch = true
+	}
+
+	assertion.g.THelper()
+	if assertion.timeoutName != "" {
+		if _, ok := assertion.g.NamedDuration(assertion.timeoutName); !ok {
+			assertion.g.Fail(fmt.Sprintf("%s was given the timeout %q, but no duration is registered with that name.  Register one with RegisterDuration.", assertion.asyncType, assertion.timeoutName), 2+assertion.offset)
+			return false
+		}
+	}
+	if assertion.hasMaxAttempts && assertion.maxAttempts < 1 {
+		assertion.g.Fail(fmt.Sprintf("Invalid use of StopTryingAfterAttempts with %s: parameter can't be < 1", assertion.asyncType), 2+assertion.offset)
+		return false
+	}
+
 	timer := time.Now()
 	timeout := assertion.afterTimeout()
 	lock := sync.Mutex{}
@@ -386,8 +484,7 @@ func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch
 	var actual, lastValidActual interface{}
 	var actualErr, matcherErr error
 	var oracleMatcherSaysStop bool
-
-	assertion.g.THelper()
+	attempts := 0
 
 	pollActual, buildActualPollerErr := assertion.buildActualPoller()
 	if buildActualPollerErr != nil {
@@ -395,18 +492,93 @@ func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch
 		return false
 	}
 
-	actual, actualErr = pollActual()
-	if actualErr == nil {
-		lastValidActual = actual
-		hasLastValidActual = true
-		oracleMatcherSaysStop = assertion.matcherSaysStopTrying(matcher, actual)
-		matches, matcherErr = assertion.pollMatcher(matcher, actual)
+	var timeline *types.AsyncTimeline
+	if assertion.g.AsyncTimelineReporter != nil {
+		timeline = &types.AsyncTimeline{AsyncType: assertion.asyncType.String(), Start: timer}
+		defer func() {
+			timeline.End = time.Now()
+			timeline.Succeeded = succeeded
+			timeline.Description = strings.TrimSuffix(assertion.buildDescription(optionalDescription...), "\n")
+			assertion.g.AsyncTimelineReporter(*timeline)
+		}()
+	}
+	recordAttempt := func() {
+		if timeline != nil {
+			recordAsyncAttempt(timeline, actual, actualErr, matcherErr, actualErr == nil && matcherErr == nil && matches == desiredMatch)
+		}
+	}
+
+	var contextDone <-chan struct{}
+	if assertion.ctx != nil {
+		contextDone = assertion.ctx.Done()
+	}
+
+	participant := assertion.g.joinAsyncScheduler()
+	defer participant.leave()
+	holdingAccess, waitedOnAccess := false, false
+	defer func() {
+		if holdingAccess {
+			assertion.accessLock.Unlock()
+		}
+	}()
+	// awaitTurn waits until the async scheduler lets this assertion poll - immediately, if assertions are not serialized -
+	// and then for the lock passed to WithSerializedAccess, if any
+	awaitTurn := func() asyncTurnOutcome {
+		if participant != nil {
+			select {
+			case <-participant.requestTurn():
+			case <-contextDone:
+				participant.cancelTurn()
+				return asyncTurnContextCancelled
+			case <-timeout:
+				participant.cancelTurn()
+				return asyncTurnTimedOut
+			}
+		}
+		if assertion.accessLock != nil {
+			outcome := acquireAccess(assertion.accessLock, contextDone, timeout)
+			if outcome != asyncTurnGranted {
+				participant.releaseTurn()
+				waitedOnAccess = true
+				return outcome
+			}
+			holdingAccess = true
+		}
+		return asyncTurnGranted
+	}
+	// releaseTurn lets other assertions, and other code using the lock passed to WithSerializedAccess, proceed
+	releaseTurn := func() {
+		if holdingAccess {
+			holdingAccess = false
+			assertion.accessLock.Unlock()
+		}
+		participant.releaseTurn()
+	}
+
+	firstTurn := awaitTurn()
+	if firstTurn == asyncTurnGranted {
+		actual, actualErr = pollActual()
+		attempts++
+		if actualErr == nil {
+			lastValidActual = actual
+			hasLastValidActual = true
+			oracleMatcherSaysStop = assertion.matcherSaysStopTrying(matcher, actual)
+			matches, matcherErr = assertion.pollMatcher(matcher, actual)
+		}
+		releaseTurn()
+		recordAttempt()
 	}
 
 	renderError := func(preamble string, err error) string {
 		message := ""
 		if pollingSignalErr, ok := AsPollingSignalError(err); ok {
 			message = err.Error()
+			if pollingSignalErr.IsStopTrying() && pollingSignalErr.Attempts() > 1 {
+				message += fmt.Sprintf("\nAttempts: %d", pollingSignalErr.Attempts())
+			}
+			if pollingSignalErr.SuggestedAction() != "" {
+				message += "\nSuggested action: " + pollingSignalErr.SuggestedAction()
+			}
 			for _, attachment := range pollingSignalErr.Attachments {
 				message += fmt.Sprintf("\n%s:\n", attachment.Description)
 				message += format.Object(attachment.Object, 1)
@@ -423,14 +595,14 @@ func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch
 		defer lock.Unlock()
 		message := ""
 
-		if actualErr == nil {
+		if firstTurn != asyncTurnGranted && waitedOnAccess {
+			message += fmt.Sprintf("%s never got to poll: the lock passed to WithSerializedAccess was not released in time.  Make sure the code making the assertion isn't holding it.\n", assertion.asyncType)
+		} else if firstTurn != asyncTurnGranted {
+			message += fmt.Sprintf("%s never got a turn to poll: asynchronous assertions are serialized and the assertions ahead of it did not finish their turns in time\n", assertion.asyncType)
+		} else if actualErr == nil {
 			if matcherErr == nil {
 				if desiredMatch != matches {
-					if desiredMatch {
-						message += matcher.FailureMessage(actual)
-					} else {
-						message += matcher.NegatedFailureMessage(actual)
-					}
+					message += assertion.g.failureMessageFor(matcher, actual, desiredMatch)
 				} else {
 					if assertion.asyncType == AsyncAssertionTypeConsistently {
 						message += "There is no failure as the matcher passed to Consistently has not yet failed"
@@ -460,11 +632,7 @@ func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch
 					message += renderError(" the matcher returned the following error:", e)
 				} else {
 					message += " the matcher was not satisfied:\n"
-					if desiredMatch {
-						message += matcher.FailureMessage(lastValidActual)
-					} else {
-						message += matcher.NegatedFailureMessage(lastValidActual)
-					}
+					message += assertion.g.failureMessageFor(matcher, lastValidActual, desiredMatch)
 				}
 			}
 		}
@@ -475,18 +643,48 @@ func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch
 
 	fail := func(preamble string) {
 		assertion.g.THelper()
-		assertion.g.Fail(fmt.Sprintf("%s after %.3fs.\n%s", preamble, time.Since(timer).Seconds(), messageGenerator()), 3+assertion.offset)
+		elapsed := fmt.Sprintf("%.3fs", time.Since(timer).Seconds())
+		notes := []string{}
+		if assertion.timeoutName != "" {
+			namedTimeout, _ := assertion.g.NamedDuration(assertion.timeoutName)
+			notes = append(notes, fmt.Sprintf("timeout %q is %s", assertion.timeoutName, namedTimeout))
+		}
+		if scale := assertion.g.DurationBundle.TimeoutScale; scale > 0 && scale != 1 && !assertion.g.Compatibility.LegacyTimeouts {
+			notes = append(notes, fmt.Sprintf("timeouts scaled by %gx", scale))
+		}
+		if len(notes) > 0 {
+			elapsed += " (" + strings.Join(notes, ", ") + ")"
+		}
+		message := format.AccessibleString(fmt.Sprintf("%s after %s.\n%s", preamble, elapsed, messageGenerator()))
+		assertion.g.Fail(assertion.g.withFailureArtifacts(message, 3+assertion.offset), 3+assertion.offset)
 	}
 
-	var contextDone <-chan struct{}
 	if assertion.ctx != nil {
-		contextDone = assertion.ctx.Done()
 		if v, ok := assertion.ctx.Value("GINKGO_SPEC_CONTEXT").(contextWithAttachProgressReporter); ok {
 			detach := v.AttachProgressReporter(messageGenerator)
 			defer detach()
 		}
 	}
 
+	// timeoutFailure returns the preamble to fail with once the timeout elapses, or "" if the assertion succeeded
+	timeoutFailure := func(isTryAgainAfterError bool) string {
+		if assertion.asyncType == AsyncAssertionTypeEventually {
+			return "Timed out"
+		} else if isTryAgainAfterError {
+			return "Timed out while waiting on TryAgainAfter"
+		}
+		return ""
+	}
+
+	switch firstTurn {
+	case asyncTurnContextCancelled:
+		fail("Context was cancelled")
+		return false
+	case asyncTurnTimedOut:
+		fail("Timed out")
+		return false
+	}
+
 	// Used to count the number of times in a row a step passed
 	passedRepeatedlyCount := 0
 	for {
@@ -496,6 +694,9 @@ func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch
 		for _, err := range []error{actualErr, matcherErr} {
 			if pollingSignalErr, ok := AsPollingSignalError(err); ok {
 				if pollingSignalErr.IsStopTrying() {
+					lock.Lock()
+					pollingSignalErr.attempts = attempts
+					lock.Unlock()
 					fail("Told to stop trying")
 					return false
 				}
@@ -522,6 +723,15 @@ func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch
 			passedRepeatedlyCount = 0
 		}
 
+		if assertion.hasMaxAttempts && attempts >= assertion.maxAttempts {
+			if assertion.asyncType == AsyncAssertionTypeEventually {
+				fail(fmt.Sprintf("Used up its %d attempts", assertion.maxAttempts))
+				return false
+			} else {
+				return true
+			}
+		}
+
 		if oracleMatcherSaysStop {
 			if assertion.asyncType == AsyncAssertionTypeEventually {
 				fail("No future change is possible.  Bailing out early")
@@ -537,9 +747,21 @@ func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch
 
 		select {
 		case <-nextPoll:
+			switch awaitTurn() {
+			case asyncTurnContextCancelled:
+				fail("Context was cancelled")
+				return false
+			case asyncTurnTimedOut:
+				if preamble := timeoutFailure(isTryAgainAfterError); preamble != "" {
+					fail(preamble)
+					return false
+				}
+				return true
+			}
 			a, e := pollActual()
 			lock.Lock()
 			actual, actualErr = a, e
+			attempts++
 			lock.Unlock()
 			if actualErr == nil {
 				lock.Lock()
@@ -552,20 +774,71 @@ func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch
 				matches, matcherErr = m, e
 				lock.Unlock()
 			}
+			releaseTurn()
+			recordAttempt()
 		case <-contextDone:
 			fail("Context was cancelled")
 			return false
 		case <-timeout:
-			if assertion.asyncType == AsyncAssertionTypeEventually {
-				fail("Timed out")
+			if preamble := timeoutFailure(isTryAgainAfterError); preamble != "" {
+				fail(preamble)
 				return false
-			} else {
-				if isTryAgainAfterError {
-					fail("Timed out while waiting on TryAgainAfter")
-					return false
-				}
-				return true
 			}
+			return true
+		}
+	}
+}
+
+// acquireAccess locks lock, giving up if the context is done or the assertion times out first
+func acquireAccess(lock sync.Locker, contextDone <-chan struct{}, timeout <-chan time.Time) asyncTurnOutcome {
+	if tryLocker, ok := lock.(interface{ TryLock() bool }); ok && tryLocker.TryLock() {
+		return asyncTurnGranted
+	}
+	acquired := make(chan struct{})
+	go func() {
+		lock.Lock()
+		close(acquired)
+	}()
+	abandon := func() {
+		go func() {
+			<-acquired
+			lock.Unlock()
+		}()
+	}
+	select {
+	case <-acquired:
+		return asyncTurnGranted
+	case <-contextDone:
+		abandon()
+		return asyncTurnContextCancelled
+	case <-timeout:
+		abandon()
+		return asyncTurnTimedOut
+	}
+}
+
+// recordAsyncAttempt adds a poll to the timeline, extending the last attempt if the poll produced the same result
+func recordAsyncAttempt(timeline *types.AsyncTimeline, actual interface{}, actualErr error, matcherErr error, passed bool) {
+	now := time.Now()
+	attempt := types.AsyncAttempt{First: now, Last: now, Polls: 1, Passed: passed}
+	if actualErr != nil {
+		attempt.Error = actualErr.Error()
+	} else if matcherErr != nil {
+		attempt.Error = matcherErr.Error()
+	}
+	if actualErr == nil {
+		hash := fnv.New32a()
+		hash.Write([]byte(format.Object(actual, 0)))
+		attempt.Fingerprint = fmt.Sprintf("%08x", hash.Sum32())
+	}
+
+	if n := len(timeline.Attempts); n > 0 {
+		last := &timeline.Attempts[n-1]
+		if last.Fingerprint == attempt.Fingerprint && last.Passed == attempt.Passed && last.Error == attempt.Error {
+			last.Last = now
+			last.Polls++
+			return
 		}
 	}
+	timeline.Attempts = append(timeline.Attempts, attempt)
 }
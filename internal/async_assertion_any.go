@@ -0,0 +1,127 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// anyOfActuals holds the sources passed to EventuallyAny.  Each is polled like the actual passed to Eventually.
+type anyOfActuals []interface{}
+
+// polledActual is the result of polling one of the sources passed to EventuallyAny
+type polledActual struct {
+	value interface{}
+	err   error
+}
+
+func isFunc(actual interface{}) bool {
+	return actual != nil && reflect.TypeOf(actual).Kind() == reflect.Func
+}
+
+// buildAnyOfActualsPoller returns a poller that polls every source and returns their results as a []polledActual.
+// Errors returned by individual sources are reported alongside their results, unless they are polling signals
+// (e.g. StopTrying) - those apply to the assertion as a whole.
+func (assertion *AsyncAssertion) buildAnyOfActualsPoller(actuals anyOfActuals) (func() (interface{}, error), error) {
+	if len(actuals) == 0 {
+		return nil, fmt.Errorf("EventuallyAny requires at least one source to poll")
+	}
+
+	pollers := make([]func() (interface{}, error), len(actuals))
+	for i, actual := range actuals {
+		poller, err := assertion.buildPollerFor(actual)
+		if err != nil {
+			return nil, fmt.Errorf("Source %d passed to EventuallyAny is invalid:\n%s", i, err.Error())
+		}
+		pollers[i] = poller
+	}
+
+	return func() (interface{}, error) {
+		results := make([]polledActual, len(pollers))
+		for i, poller := range pollers {
+			value, err := poller()
+			if _, ok := AsPollingSignalError(err); ok {
+				return results, err
+			}
+			results[i] = polledActual{value: value, err: err}
+		}
+		return results, nil
+	}, nil
+}
+
+// anyOfActualsMatcher succeeds if matcher's result agrees with desiredMatch for any one of the polled sources
+type anyOfActualsMatcher struct {
+	g            *Gomega
+	matcher      types.GomegaMatcher
+	desiredMatch bool
+	numActuals   int
+
+	// state
+	matches    []bool
+	matcherErr []error
+}
+
+func (m *anyOfActualsMatcher) Match(actual interface{}) (success bool, err error) {
+	results := actual.([]polledActual)
+	m.matches = make([]bool, len(results))
+	m.matcherErr = make([]error, len(results))
+
+	for i, result := range results {
+		if result.err != nil {
+			continue
+		}
+		m.matches[i], m.matcherErr[i] = m.matcher.Match(result.value)
+		if _, ok := AsPollingSignalError(m.matcherErr[i]); ok {
+			return false, m.matcherErr[i]
+		}
+		if m.matcherErr[i] == nil && m.matches[i] == m.desiredMatch {
+			success = true
+		}
+	}
+	return success, nil
+}
+
+func (m *anyOfActualsMatcher) FailureMessage(actual interface{}) (message string) {
+	if m.desiredMatch {
+		message = fmt.Sprintf("None of the %d sources passed to EventuallyAny satisfied the matcher.\n", m.numActuals)
+	} else {
+		message = fmt.Sprintf("All %d sources passed to EventuallyAny satisfied the matcher, but at least one should not have.\n", m.numActuals)
+	}
+	return message + m.describeSources(actual)
+}
+
+func (m *anyOfActualsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return "A source passed to EventuallyAny satisfied the matcher.\n" + m.describeSources(actual)
+}
+
+func (m *anyOfActualsMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	results, ok := actual.([]polledActual)
+	if !ok {
+		return true
+	}
+	for _, result := range results {
+		if result.err != nil || types.MatchMayChangeInTheFuture(m.matcher, result.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// describeSources reports the last value polled from each source, and how it fared
+func (m *anyOfActualsMatcher) describeSources(actual interface{}) string {
+	descriptions := []string{}
+	for i, result := range actual.([]polledActual) {
+		switch {
+		case result.err != nil:
+			descriptions = append(descriptions, fmt.Sprintf("Source %d returned an error:\n%s", i, format.IndentString(result.err.Error(), 1)))
+		case i < len(m.matcherErr) && m.matcherErr[i] != nil:
+			descriptions = append(descriptions, fmt.Sprintf("Source %d caused the matcher to error:\n%s", i, format.IndentString(m.matcherErr[i].Error(), 1)))
+		default:
+			descriptions = append(descriptions, fmt.Sprintf("Source %d:\n%s", i, format.IndentString(m.g.failureMessageFor(m.matcher, result.value, m.desiredMatch), 1)))
+		}
+	}
+	return strings.Join(descriptions, "\n")
+}
@@ -6,10 +6,12 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
 	"golang.org/x/net/context"
 )
 
@@ -1724,4 +1726,390 @@ sprocket:
 		})
 
 	})
+
+	When("using StopTryingAfterAttempts", func() {
+		It("errors when passed a count < 1", func() {
+			ig.G.Eventually(func(g Gomega) {}).(AttemptLimitedAsyncAssertion).StopTryingAfterAttempts(0).Should(Succeed())
+			Ω(ig.FailureMessage).Should(Equal("Invalid use of StopTryingAfterAttempts with Eventually: parameter can't be < 1"))
+			Ω(ig.FailureSkip).Should(Equal([]int{2}))
+		})
+
+		It("fails an Eventually that has not succeeded once its attempts are used up, without waiting for the timeout", func() {
+			counter := 0
+			t := time.Now()
+			ig.G.Eventually(func() int {
+				counter++
+				return counter
+			}).WithTimeout(time.Second).WithPolling(10 * time.Millisecond).(AttemptLimitedAsyncAssertion).StopTryingAfterAttempts(3).Should(Equal(5))
+			Ω(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+			Ω(counter).Should(Equal(3))
+			Ω(ig.FailureMessage).Should(HavePrefix("Used up its 3 attempts after"))
+			Ω(ig.FailureMessage).Should(ContainSubstring("<int>: 3\nto equal\n    <int>: 5"))
+			Ω(ig.FailureSkip).Should(Equal([]int{3}))
+		})
+
+		It("lets an Eventually succeed on its last attempt", func() {
+			counter := 0
+			ig.G.Eventually(func() int {
+				counter++
+				return counter
+			}).WithPolling(10 * time.Millisecond).(AttemptLimitedAsyncAssertion).StopTryingAfterAttempts(3).Should(Equal(3))
+			Ω(ig.FailureMessage).Should(BeZero())
+		})
+
+		It("counts every poll towards MustPassRepeatedly's budget", func() {
+			counter := 0
+			ig.G.Eventually(func() int {
+				counter++
+				return counter % 2
+			}).WithPolling(10 * time.Millisecond).MustPassRepeatedly(2).(AttemptLimitedAsyncAssertion).StopTryingAfterAttempts(4).Should(Equal(1))
+			Ω(counter).Should(Equal(4))
+			Ω(ig.FailureMessage).Should(HavePrefix("Used up its 4 attempts after"))
+		})
+
+		It("lets a Consistently succeed once its attempts have passed", func() {
+			counter := 0
+			t := time.Now()
+			ig.G.Consistently(func() int {
+				counter++
+				return 1
+			}).WithTimeout(time.Second).WithPolling(10 * time.Millisecond).(AttemptLimitedAsyncAssertion).StopTryingAfterAttempts(3).Should(Equal(1))
+			Ω(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+			Ω(counter).Should(Equal(3))
+			Ω(ig.FailureMessage).Should(BeZero())
+		})
+	})
+
+	Describe("reporting on StopTrying signals", func() {
+		It("reports the number of attempts made and the suggested action", func() {
+			counter := 0
+			ig.G.Eventually(func() (int, error) {
+				counter++
+				if counter == 3 {
+					return 0, StopTrying("the cluster is gone").Wrap(errors.New("404")).Suggest("check that the cluster was not deleted by another suite")
+				}
+				return counter, nil
+			}).WithPolling(10 * time.Millisecond).Should(Equal(5))
+			Ω(ig.FailureMessage).Should(HavePrefix("Told to stop trying after"))
+			Ω(ig.FailureMessage).Should(ContainSubstring("the cluster is gone: 404\nAttempts: 3\nSuggested action: check that the cluster was not deleted by another suite"))
+		})
+
+		It("reports the suggested action of a TryAgainAfter signal", func() {
+			ig.G.Eventually(func() (int, error) {
+				return 0, TryAgainAfter(time.Second).Suggest("wait for the rate limit to reset")
+			}).WithTimeout(50 * time.Millisecond).Should(Equal(5))
+			Ω(ig.FailureMessage).Should(ContainSubstring("told to try again after 1s\nSuggested action: wait for the rate limit to reset"))
+			Ω(ig.FailureMessage).ShouldNot(ContainSubstring("Attempts:"))
+		})
+	})
+
+	Describe("inspecting and cloning assertions", func() {
+		BeforeEach(func() {
+			ig.G.SetDefaultEventuallyTimeout(time.Second)
+			ig.G.SetDefaultEventuallyPollingInterval(10 * time.Millisecond)
+			ig.G.SetDefaultConsistentlyDuration(2 * time.Second)
+			ig.G.SetDefaultConsistentlyPollingInterval(20 * time.Millisecond)
+		})
+
+		It("reports the default timeouts and polling intervals", func() {
+			timeout, ok := ig.G.Eventually(MATCH).(AsyncAssertionInspector).Timeout()
+			Ω(ok).Should(BeTrue())
+			Ω(timeout).Should(Equal(time.Second))
+			Ω(ig.G.Eventually(MATCH).(AsyncAssertionInspector).PollingInterval()).Should(Equal(10 * time.Millisecond))
+
+			timeout, ok = ig.G.Consistently(MATCH).(AsyncAssertionInspector).Timeout()
+			Ω(ok).Should(BeTrue())
+			Ω(timeout).Should(Equal(2 * time.Second))
+			Ω(ig.G.Consistently(MATCH).(AsyncAssertionInspector).PollingInterval()).Should(Equal(20 * time.Millisecond))
+		})
+
+		It("reports configured timeouts and polling intervals", func() {
+			a := ig.G.Eventually(MATCH, "3s", "30ms").(AsyncAssertionInspector)
+			timeout, _ := a.Timeout()
+			Ω(timeout).Should(Equal(3 * time.Second))
+			Ω(a.PollingInterval()).Should(Equal(30 * time.Millisecond))
+
+			a.WithTimeout(4 * time.Second).WithPolling(40 * time.Millisecond)
+			timeout, _ = a.Timeout()
+			Ω(timeout).Should(Equal(4 * time.Second))
+			Ω(a.PollingInterval()).Should(Equal(40 * time.Millisecond))
+
+			ig.G.RegisterDuration("named", 5*time.Second)
+			timeout, ok := a.(NamedTimeoutAsyncAssertion).WithTimeoutNamed("named").(AsyncAssertionInspector).Timeout()
+			Ω(ok).Should(BeTrue())
+			Ω(timeout).Should(Equal(5 * time.Second))
+
+			_, ok = a.(NamedTimeoutAsyncAssertion).WithTimeoutNamed("unregistered").(AsyncAssertionInspector).Timeout()
+			Ω(ok).Should(BeFalse())
+		})
+
+		It("reports timeouts before they are scaled", func() {
+			ig.G.SetTimeoutScale(3)
+			timeout, _ := ig.G.Eventually(MATCH).WithTimeout(time.Second).(AsyncAssertionInspector).Timeout()
+			Ω(timeout).Should(Equal(time.Second))
+		})
+
+		It("reports that an Eventually with a context and no explicit timeout has no timeout", func() {
+			ctx := context.Background()
+			a := ig.G.Eventually(ctx, MATCH).(AsyncAssertionInspector)
+			_, ok := a.Timeout()
+			Ω(ok).Should(BeFalse())
+			Ω(a.Context()).Should(Equal(ctx))
+
+			_, ok = a.WithTimeout(time.Second).(AsyncAssertionInspector).Timeout()
+			Ω(ok).Should(BeTrue())
+		})
+
+		It("reports the context and arguments", func() {
+			a := ig.G.Eventually(func(a, b int) int { return a + b }).WithArguments(1, 2).(AsyncAssertionInspector)
+			Ω(a.Context()).Should(BeNil())
+			Ω(a.Arguments()).Should(Equal([]interface{}{1, 2}))
+
+			ctx := context.Background()
+			a.WithContext(ctx).WithArguments(3, 4)
+			Ω(a.Context()).Should(Equal(ctx))
+			Ω(a.Arguments()).Should(Equal([]interface{}{3, 4}))
+
+			a.Arguments()[0] = 5
+			Ω(a.Arguments()).Should(Equal([]interface{}{3, 4}))
+		})
+
+		It("returns clones that can be configured without affecting the original", func() {
+			original := ig.G.Eventually(func(a int) int { return a }).WithArguments(1).WithTimeout(time.Second).(AsyncAssertionInspector)
+			clone := original.Clone().WithArguments(2).WithTimeout(time.Minute).WithPolling(time.Millisecond).(AsyncAssertionInspector)
+
+			timeout, _ := original.Timeout()
+			Ω(timeout).Should(Equal(time.Second))
+			Ω(original.PollingInterval()).Should(Equal(10 * time.Millisecond))
+			Ω(original.Arguments()).Should(Equal([]interface{}{1}))
+
+			timeout, _ = clone.Timeout()
+			Ω(timeout).Should(Equal(time.Minute))
+			Ω(clone.PollingInterval()).Should(Equal(time.Millisecond))
+			Ω(clone.Arguments()).Should(Equal([]interface{}{2}))
+
+			clone.Should(Equal(2))
+			Ω(ig.FailureMessage).Should(BeZero())
+			original.WithTimeout(50 * time.Millisecond).Should(Equal(2))
+			Ω(ig.FailureMessage).Should(ContainSubstring("Timed out after"))
+		})
+	})
+
+	Describe("serializing access to the actual", func() {
+		var mu *sync.Mutex
+		var fake map[string]int
+
+		BeforeEach(func() {
+			mu = &sync.Mutex{}
+			fake = map[string]int{}
+		})
+
+		It("holds the lock while polling and matching", func() {
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for i := 0; i < 20; i++ {
+					mu.Lock()
+					fake[fmt.Sprintf("key-%d", i)] = i
+					mu.Unlock()
+					time.Sleep(time.Millisecond)
+				}
+			}()
+
+			heldWhilePolling := true
+			ig.G.Eventually(func() map[string]int {
+				if mu.TryLock() {
+					heldWhilePolling = false
+					mu.Unlock()
+				}
+				return fake
+			}, time.Second, time.Millisecond).(SerializedAccessAsyncAssertion).WithSerializedAccess(mu).Should(HaveLen(20))
+			<-done
+
+			Ω(ig.FailureMessage).Should(BeZero())
+			Ω(heldWhilePolling).Should(BeTrue())
+			Ω(mu.TryLock()).Should(BeTrue(), "the lock should be released once the assertion completes")
+		})
+
+		It("waits for the lock to be released", func() {
+			mu.Lock()
+			released := make(chan struct{})
+			go func(mu *sync.Mutex, fake map[string]int) {
+				defer close(released)
+				time.Sleep(50 * time.Millisecond)
+				fake["ready"] = 1
+				mu.Unlock()
+			}(mu, fake)
+			ig.G.Consistently(func() int { return len(fake) }, 100*time.Millisecond, time.Millisecond).(SerializedAccessAsyncAssertion).WithSerializedAccess(mu).ShouldNot(Equal(0))
+			<-released
+			Ω(ig.FailureMessage).Should(BeZero())
+		})
+
+		It("fails, rather than deadlocking, when the lock is never released", func() {
+			mu.Lock()
+			ig.G.Eventually(func() int { return len(fake) }, 50*time.Millisecond, time.Millisecond).(SerializedAccessAsyncAssertion).WithSerializedAccess(mu).Should(Equal(1))
+			Ω(ig.FailureMessage).Should(HavePrefix("Timed out after"))
+			Ω(ig.FailureMessage).Should(ContainSubstring("Eventually never got to poll: the lock passed to WithSerializedAccess was not released in time.  Make sure the code making the assertion isn't holding it."))
+
+			mu.Unlock()
+			Eventually(mu.TryLock).Should(BeTrue(), "the abandoned attempt to acquire the lock should release it")
+		})
+
+		It("is copied by Clone", func() {
+			mu.Lock()
+			ig.G.Eventually(func() int { return 1 }, 50*time.Millisecond, time.Millisecond).(SerializedAccessAsyncAssertion).WithSerializedAccess(mu).(AsyncAssertionInspector).Clone().Should(Equal(1))
+			Ω(ig.FailureMessage).Should(ContainSubstring("the lock passed to WithSerializedAccess was not released in time"))
+			mu.Unlock()
+		})
+	})
+
+	Describe("EventuallyAny", func() {
+		It("succeeds as soon as any source satisfies the matcher", func() {
+			counterA, counterB := 0, 0
+			ig.G.EventuallyAny(func() string {
+				counterA++
+				return NO_MATCH
+			}, func() string {
+				counterB++
+				if counterB > 3 {
+					return MATCH
+				}
+				return NO_MATCH
+			}).Should(SpecMatch())
+			Ω(counterA).Should(Equal(4))
+			Ω(counterB).Should(Equal(4))
+			Ω(ig.FailureMessage).Should(BeZero())
+		})
+
+		It("accepts values as well as functions", func() {
+			ig.G.EventuallyAny(NO_MATCH, MATCH).Should(SpecMatch())
+			Ω(ig.FailureMessage).Should(BeZero())
+		})
+
+		It("reports the last value from every source when it times out", func() {
+			ig.G.EventuallyAny(func() string { return "A" }, func() string { return "B" }).WithTimeout(50 * time.Millisecond).WithPolling(10 * time.Millisecond).Should(Equal("C"))
+			Ω(ig.FailureMessage).Should(ContainSubstring("Timed out after"))
+			Ω(ig.FailureMessage).Should(ContainSubstring("None of the 2 sources passed to EventuallyAny satisfied the matcher.\nSource 0:\n    Expected\n        <string>: A\n    to equal\n        <string>: C\nSource 1:\n    Expected\n        <string>: B\n    to equal\n        <string>: C"))
+		})
+
+		It("bails out early when no source can change", func() {
+			ig.G.EventuallyAny("A", "B").WithTimeout(time.Hour).Should(QuickMatcherWithOracle(
+				func(_ any) (bool, error) { return false, nil },
+				func(_ any) bool { return false },
+			))
+			Ω(ig.FailureMessage).Should(ContainSubstring("No future change is possible.  Bailing out early"))
+			Ω(ig.FailureMessage).Should(ContainSubstring("Source 0:\n    QM failure message: A\nSource 1:\n    QM failure message: B"))
+		})
+
+		It("reports errors returned by individual sources, and keeps polling the others", func() {
+			counter := 0
+			ig.G.EventuallyAny(func() (string, error) {
+				return "", errors.New("boom")
+			}, func() string {
+				counter++
+				return "A"
+			}).WithTimeout(50 * time.Millisecond).WithPolling(10 * time.Millisecond).Should(Equal("B"))
+			Ω(counter).Should(BeNumerically(">", 2))
+			Ω(ig.FailureMessage).Should(ContainSubstring("Source 0 returned an error:\n    boom\nSource 1:\n    Expected\n        <string>: A"))
+		})
+
+		It("succeeds with ShouldNot when any source fails to satisfy the matcher", func() {
+			ig.G.EventuallyAny(func() string { return "A" }, func() string { return "B" }).ShouldNot(Equal("A"))
+			Ω(ig.FailureMessage).Should(BeZero())
+
+			ig.G.EventuallyAny(func() string { return "A" }, func() string { return "A" }).WithTimeout(50 * time.Millisecond).ShouldNot(Equal("A"))
+			Ω(ig.FailureMessage).Should(ContainSubstring("All 2 sources passed to EventuallyAny satisfied the matcher, but at least one should not have.\nSource 0:\n    Expected\n        <string>: A\n    not to equal"))
+		})
+
+		It("forwards arguments to every source", func() {
+			ig.G.EventuallyAny(func(s string) string { return "A" + s }, func(s string) string { return "B" + s }).WithArguments("!").Should(Equal("B!"))
+			Ω(ig.FailureMessage).Should(BeZero())
+		})
+
+		It("stops when any source says to stop trying", func() {
+			ig.G.EventuallyAny(func() (string, error) {
+				return "", StopTrying("bam")
+			}, func() string { return "A" }).WithTimeout(time.Hour).Should(Equal("B"))
+			Ω(ig.FailureMessage).Should(ContainSubstring("Told to stop trying"))
+			Ω(ig.FailureMessage).Should(ContainSubstring("bam"))
+		})
+
+		It("fails when passed no sources or an invalid source", func() {
+			ig.G.EventuallyAny().Should(Equal("A"))
+			Ω(ig.FailureMessage).Should(Equal("EventuallyAny requires at least one source to poll"))
+
+			ig.G.EventuallyAny("A", func() {}).Should(Equal("A"))
+			Ω(ig.FailureMessage).Should(HavePrefix("Source 1 passed to EventuallyAny is invalid:\nThe function passed to Eventually had an invalid signature of func()"))
+		})
+	})
+
+	Describe("reporting async timelines", func() {
+		var timelines []types.AsyncTimeline
+
+		BeforeEach(func() {
+			timelines = nil
+			ig.G.AsyncTimelineReporter = func(timeline types.AsyncTimeline) {
+				timelines = append(timelines, timeline)
+			}
+		})
+
+		It("reports each poll, collapsing consecutive polls with the same result", func() {
+			counter := 0
+			ig.G.Eventually(func() (string, error) {
+				counter++
+				switch {
+				case counter <= 3:
+					return NO_MATCH, nil
+				case counter == 4:
+					return "", errors.New("boom")
+				default:
+					return MATCH, nil
+				}
+			}).WithPolling(time.Millisecond).Should(SpecMatch(), "waiting for %s", "a match")
+
+			Ω(timelines).Should(HaveLen(1))
+			timeline := timelines[0]
+			Ω(timeline.AsyncType).Should(Equal("Eventually"))
+			Ω(timeline.Description).Should(Equal("waiting for a match"))
+			Ω(timeline.Succeeded).Should(BeTrue())
+			Ω(timeline.End).Should(BeTemporally(">=", timeline.Start))
+
+			Ω(timeline.Attempts).Should(HaveLen(3))
+			Ω(timeline.Attempts[0].Polls).Should(Equal(3))
+			Ω(timeline.Attempts[0].Passed).Should(BeFalse())
+			Ω(timeline.Attempts[0].Fingerprint).Should(HaveLen(8))
+			Ω(timeline.Attempts[0].Last).Should(BeTemporally(">", timeline.Attempts[0].First))
+			Ω(timeline.Attempts[1]).Should(And(
+				HaveField("Polls", 1),
+				HaveField("Error", "boom"),
+				HaveField("Fingerprint", ""),
+			))
+			Ω(timeline.Attempts[2].Passed).Should(BeTrue())
+			Ω(timeline.Attempts[2].Fingerprint).ShouldNot(Equal(timeline.Attempts[0].Fingerprint))
+
+			Ω(timeline.String()).Should(HavePrefix("Eventually succeeded after 5 polls over "))
+			Ω(timeline.String()).Should(ContainSubstring("3 polls, value " + timeline.Attempts[0].Fingerprint + " did not pass"))
+			Ω(timeline.String()).Should(ContainSubstring("1 polls, errored: boom"))
+		})
+
+		It("reports failed assertions", func() {
+			ig.G.Consistently(func() string { return NO_MATCH }).WithTimeout(50 * time.Millisecond).WithPolling(10 * time.Millisecond).ShouldNot(SpecMatch())
+			Ω(ig.FailureMessage).Should(BeZero())
+			ig.G.Eventually(func() string { return NO_MATCH }).WithTimeout(50 * time.Millisecond).WithPolling(10 * time.Millisecond).Should(SpecMatch())
+			Ω(ig.FailureMessage).ShouldNot(BeZero())
+
+			Ω(timelines).Should(HaveLen(2))
+			Ω(timelines[0].AsyncType).Should(Equal("Consistently"))
+			Ω(timelines[0].Succeeded).Should(BeTrue())
+			Ω(timelines[1].Succeeded).Should(BeFalse())
+			Ω(timelines[1].Attempts).Should(HaveLen(1))
+			Ω(timelines[1].Attempts[0].Polls).Should(BeNumerically(">", 2))
+		})
+
+		It("does not record anything when no reporter is registered", func() {
+			ig.G.AsyncTimelineReporter = nil
+			ig.G.Eventually(MATCH).Should(SpecMatch())
+			Ω(timelines).Should(BeEmpty())
+		})
+	})
 })
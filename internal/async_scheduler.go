@@ -0,0 +1,143 @@
+package internal
+
+import "sync"
+
+/*
+asyncScheduler serializes the polling attempts of every asynchronous assertion made through a Gomega.  Assertions
+join the scheduler in the order they start and take turns in that order, round-robin: an assertion that is still
+waiting out its polling interval holds up the assertions after it, so that the interleaving of attempts depends only
+on the order in which the assertions started.
+*/
+type asyncScheduler struct {
+	lock         sync.Mutex
+	participants []*asyncParticipant
+	next         int
+	holder       *asyncParticipant
+}
+
+// asyncParticipant is an assertion's membership in an asyncScheduler.  A nil *asyncParticipant - used when
+// assertions are not serialized - is always granted its turn immediately.
+type asyncParticipant struct {
+	scheduler *asyncScheduler
+	waiting   chan struct{}
+}
+
+// asyncTurnOutcome is how an assertion's wait for its turn to poll ended
+type asyncTurnOutcome int
+
+const (
+	asyncTurnGranted asyncTurnOutcome = iota
+	asyncTurnContextCancelled
+	asyncTurnTimedOut
+)
+
+func newAsyncScheduler() *asyncScheduler {
+	return &asyncScheduler{}
+}
+
+func (s *asyncScheduler) join() *asyncParticipant {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	p := &asyncParticipant{scheduler: s}
+	s.participants = append(s.participants, p)
+	return p
+}
+
+// dispatch grants the next participant its turn, if it is waiting for one and no one else is taking theirs.  It
+// must be called with the lock held.
+func (s *asyncScheduler) dispatch() {
+	if s.holder != nil || len(s.participants) == 0 {
+		return
+	}
+	p := s.participants[s.next]
+	if p.waiting == nil {
+		return
+	}
+	s.holder = p
+	close(p.waiting)
+	p.waiting = nil
+}
+
+func (s *asyncScheduler) indexOf(p *asyncParticipant) int {
+	for i, participant := range s.participants {
+		if participant == p {
+			return i
+		}
+	}
+	return -1
+}
+
+// requestTurn returns a channel that is closed when it is p's turn to poll.  p must call releaseTurn when its
+// attempt is done, or cancelTurn if it stops waiting.
+func (p *asyncParticipant) requestTurn() <-chan struct{} {
+	if p == nil {
+		granted := make(chan struct{})
+		close(granted)
+		return granted
+	}
+	s := p.scheduler
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	waiting := make(chan struct{})
+	p.waiting = waiting
+	s.dispatch()
+	return waiting
+}
+
+// releaseTurn ends p's turn and passes the next turn to the participant after it
+func (p *asyncParticipant) releaseTurn() {
+	if p == nil {
+		return
+	}
+	s := p.scheduler
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	p.release()
+}
+
+func (p *asyncParticipant) release() {
+	s := p.scheduler
+	if s.holder != p {
+		return
+	}
+	s.holder = nil
+	s.next = (s.indexOf(p) + 1) % len(s.participants)
+	s.dispatch()
+}
+
+// cancelTurn stops waiting for a turn requested with requestTurn.  If the turn was granted in the meantime, it is
+// released.
+func (p *asyncParticipant) cancelTurn() {
+	if p == nil {
+		return
+	}
+	s := p.scheduler
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	p.waiting = nil
+	p.release()
+}
+
+// leave removes p from the scheduler once its assertion is done
+func (p *asyncParticipant) leave() {
+	if p == nil {
+		return
+	}
+	s := p.scheduler
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	p.waiting = nil
+	p.release()
+	index := s.indexOf(p)
+	if index == -1 {
+		return
+	}
+	s.participants = append(s.participants[:index], s.participants[index+1:]...)
+	if index < s.next {
+		s.next--
+	}
+	if s.next >= len(s.participants) {
+		s.next = 0
+	}
+	s.dispatch()
+}
@@ -0,0 +1,101 @@
+package internal_test
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Serializing asynchronous assertions", func() {
+	var ig *InstrumentedGomega
+	var lock sync.Mutex
+	var polls []string
+
+	BeforeEach(func() {
+		ig = NewInstrumentedGomega()
+		ig.G.SerializeAsyncAssertions(true)
+		polls = []string{}
+	})
+
+	// poller returns a function for Eventually that records its polls and returns how many times it has been polled
+	poller := func(name string, firstPoll func()) func() int {
+		count := 0
+		return func() int {
+			lock.Lock()
+			polls = append(polls, name)
+			lock.Unlock()
+			count++
+			if count == 1 && firstPoll != nil {
+				firstPoll()
+			}
+			return count
+		}
+	}
+
+	It("makes assertions take turns polling, in the order they started", func() {
+		aPolling, releaseA := make(chan struct{}), make(chan struct{})
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			ig.G.Eventually(poller("A", func() {
+				close(aPolling)
+				<-releaseA
+			}), time.Second, time.Millisecond).Should(Equal(4))
+		}()
+		<-aPolling
+		go func() {
+			defer wg.Done()
+			ig.G.Eventually(poller("B", nil), time.Second, 10*time.Millisecond).Should(Equal(3))
+		}()
+		// give B time to join the scheduler while A holds the turn
+		time.Sleep(50 * time.Millisecond)
+		close(releaseA)
+		wg.Wait()
+
+		Expect(ig.FailureMessage).Should(BeZero())
+		Expect(polls).Should(Equal([]string{"A", "B", "A", "B", "A", "B", "A"}))
+	})
+
+	It("times out assertions that never get a turn", func() {
+		aPolling, releaseA := make(chan struct{}), make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			ig.G.Eventually(poller("A", func() {
+				close(aPolling)
+				<-releaseA
+			}), time.Second, time.Millisecond).Should(Equal(1))
+		}()
+		<-aPolling
+
+		ig.G.Eventually(poller("B", nil), 50*time.Millisecond, time.Millisecond).Should(Equal(1))
+		Expect(ig.FailureMessage).Should(ContainSubstring("Timed out after"))
+		Expect(ig.FailureMessage).Should(ContainSubstring("Eventually never got a turn to poll: asynchronous assertions are serialized"))
+		Expect(polls).Should(Equal([]string{"A"}))
+
+		close(releaseA)
+		<-done
+	})
+
+	It("stops serializing when turned off", func() {
+		ig.G.SerializeAsyncAssertions(false)
+		aPolling, releaseA := make(chan struct{}), make(chan struct{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			ig.G.Eventually(poller("A", func() {
+				close(aPolling)
+				<-releaseA
+			}), time.Second, time.Millisecond).Should(Equal(1))
+		}()
+		<-aPolling
+
+		ig.G.Eventually(poller("B", nil), 50*time.Millisecond, time.Millisecond).Should(Equal(2))
+		Expect(ig.FailureMessage).Should(BeZero())
+		close(releaseA)
+		<-done
+	})
+})
@@ -0,0 +1,12 @@
+package internal
+
+import "github.com/onsi/gomega/types"
+
+// failureMessageFor returns matcher's failure message, or its legacy failure message if g's compatibility mode asks
+// for one
+func (g *Gomega) failureMessageFor(matcher types.GomegaMatcher, actual interface{}, desiredMatch bool) string {
+	if g.Compatibility.LegacyFailureMessages {
+		return types.LegacyFailureMessageFor(matcher, actual, desiredMatch)
+	}
+	return types.FailureMessageFor(matcher, actual, desiredMatch)
+}
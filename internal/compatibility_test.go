@@ -0,0 +1,73 @@
+package internal_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("Compatibility modes", func() {
+	var ig *InstrumentedGomega
+
+	BeforeEach(func() {
+		ig = NewInstrumentedGomega()
+	})
+
+	Describe("LegacyFailureMessages", func() {
+		It("renders legacy failure messages for matchers that have them", func() {
+			ig.G.Expect("hi").To(Or(HaveLen(1), Equal("bye")))
+			Ω(ig.FailureMessage).Should(HavePrefix("Expected at least one of the following, but none held:"))
+
+			ig.G.Compatibility = types.CompatibilityMode{LegacyFailureMessages: true}
+			ig.G.Expect("hi").To(Or(HaveLen(1), Equal("bye")))
+			Ω(ig.FailureMessage).Should(HavePrefix("Expected\n    <string>: hi\nTo satisfy at least one of these matchers: ["))
+
+			ig.G.Expect("hi").NotTo(And(HaveLen(2), Equal("hi")))
+			Ω(ig.FailureMessage).Should(HavePrefix("Expected\n    <string>: hi\nTo not satisfy all of these matchers: ["))
+		})
+
+		It("renders legacy failure messages through the matchers that wrap them", func() {
+			ig.G.Compatibility = types.CompatibilityMode{LegacyFailureMessages: true}
+			ig.G.Expect(1).NotTo(WithTransform(func(i int) int { return i + 1 }, And(BeNumerically(">", 1), Equal(2))))
+			Ω(ig.FailureMessage).Should(HavePrefix("Expected\n    <int>: 2\nTo not satisfy all of these matchers: ["))
+
+			ig.G.Expect(struct{ A int }{1}).To(HaveField("A", WithTransform(func(i int) int { return i + 1 }, BeZero())))
+			Ω(ig.FailureMessage).Should(Equal("Value for field 'A' failed to satisfy matcher.\nExpected\n    <int>: 2\nto be zero-valued"))
+		})
+
+		It("renders the current failure messages of matchers that don't", func() {
+			ig.G.Compatibility = types.CompatibilityMode{LegacyFailureMessages: true}
+			ig.G.Expect("hi").To(Equal("bye"))
+			Ω(ig.FailureMessage).Should(Equal("Expected\n    <string>: hi\nto equal\n    <string>: bye"))
+		})
+
+		It("applies to asynchronous assertions", func() {
+			ig.G.Compatibility = types.CompatibilityMode{LegacyFailureMessages: true}
+			ig.G.Eventually("hi").WithTimeout(10 * time.Millisecond).Should(Not(Not(Or(HaveLen(1), Equal("bye")))))
+			Ω(ig.FailureMessage).Should(ContainSubstring("To satisfy at least one of these matchers: ["))
+		})
+
+		It("applies to the sources polled by EventuallyAny", func() {
+			ig.G.Compatibility = types.CompatibilityMode{LegacyFailureMessages: true}
+			ig.G.EventuallyAny("hi", "hello").WithTimeout(10 * time.Millisecond).Should(Or(HaveLen(1), Equal("bye")))
+			Ω(ig.FailureMessage).Should(ContainSubstring("Source 0:\n    Expected\n        <string>: hi\n    To satisfy at least one of these matchers: ["))
+			Ω(ig.FailureMessage).Should(ContainSubstring("Source 1:\n    Expected\n        <string>: hello\n    To satisfy at least one of these matchers: ["))
+
+			ig.G.EventuallyAny("hi", "hello").WithTimeout(10 * time.Millisecond).ShouldNot(And(HavePrefix("h"), ContainSubstring("h")))
+			Ω(ig.FailureMessage).Should(ContainSubstring("Source 0:\n    Expected\n        <string>: hi\n    To not satisfy all of these matchers: ["))
+		})
+	})
+
+	Describe("LegacyTimeouts", func() {
+		It("ignores the timeout scale", func() {
+			ig.G.SetTimeoutScale(20)
+			ig.G.Compatibility = types.CompatibilityMode{LegacyTimeouts: true}
+			t := time.Now()
+			ig.G.Eventually(NO_MATCH).WithTimeout(50 * time.Millisecond).Should(SpecMatch())
+			Ω(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+			Ω(ig.FailureMessage).ShouldNot(ContainSubstring("timeouts scaled"))
+		})
+	})
+})
@@ -0,0 +1,30 @@
+package internal
+
+import "github.com/onsi/gomega/types"
+
+func isCursorSource(actual interface{}) bool {
+	_, ok := actual.(types.CursorSource)
+	return ok
+}
+
+// buildCursorPoller returns a poller that reads source from where the previous successful poll left off.  Like polled
+// functions, sources can signal StopTrying and TryAgainAfter by panicking.
+func buildCursorPoller(source types.CursorSource) func() (interface{}, error) {
+	var cursor interface{}
+	return func() (values interface{}, err error) {
+		defer func() {
+			if e := recover(); e != nil {
+				if _, isAsyncError := AsPollingSignalError(e); isAsyncError {
+					values, err = nil, e.(error)
+				} else {
+					panic(e)
+				}
+			}
+		}()
+		values, newCursor, err := source.ReadFrom(cursor)
+		if err == nil {
+			cursor = newCursor
+		}
+		return values, err
+	}
+}
@@ -0,0 +1,97 @@
+package internal_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/internal"
+	"github.com/onsi/gomega/types"
+)
+
+// fakeLog is a CursorSource of lines, read from the offset of the first line yet to be read
+type fakeLog struct {
+	lock    sync.Mutex
+	lines   []string
+	cursors []interface{}
+	err     error
+}
+
+func (l *fakeLog) append(lines ...string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.lines = append(l.lines, lines...)
+}
+
+func (l *fakeLog) ReadFrom(cursor interface{}) (interface{}, interface{}, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.cursors = append(l.cursors, cursor)
+	if l.err != nil {
+		return nil, nil, l.err
+	}
+	offset, _ := cursor.(int)
+	return append([]string{}, l.lines[offset:]...), len(l.lines), nil
+}
+
+var _ = Describe("CursorSources", func() {
+	var ig *InstrumentedGomega
+	var log *fakeLog
+
+	BeforeEach(func() {
+		ig = NewInstrumentedGomega()
+		log = &fakeLog{}
+	})
+
+	It("reads from where the previous attempt left off, matching only the new values", func() {
+		log.append("starting")
+		time.AfterFunc(30*time.Millisecond, func() { log.append("listening", "ready") })
+		Ω(ig.G.Eventually(log).WithTimeout(time.Second).WithPolling(5 * time.Millisecond).Should(ContainElement("ready"))).Should(BeTrue())
+		Ω(ig.FailureMessage).Should(BeZero())
+		Ω(log.cursors[0]).Should(BeNil())
+		Ω(log.cursors[1:]).Should(HaveEach(BeNumerically(">", 0)))
+		Ω(log.cursors[len(log.cursors)-1]).Should(Equal(1))
+	})
+
+	It("starts each assertion from the beginning", func() {
+		log.append("ready")
+		Ω(ig.G.Eventually(log).Should(ContainElement("ready"))).Should(BeTrue())
+		Ω(ig.G.Eventually(log).Should(ContainElement("ready"))).Should(BeTrue())
+		Ω(log.cursors).Should(Equal([]interface{}{nil, nil}))
+	})
+
+	It("keeps polling with Consistently", func() {
+		log.append("ok")
+		time.AfterFunc(30*time.Millisecond, func() { log.append("panic: boom") })
+		Ω(ig.G.Consistently(log).WithTimeout(time.Second).WithPolling(5 * time.Millisecond).ShouldNot(ContainElement(HavePrefix("panic")))).Should(BeFalse())
+		Ω(ig.FailureMessage).Should(ContainSubstring("panic: boom"))
+	})
+
+	It("reports errors and leaves the cursor where it was", func() {
+		log.append("starting")
+		Ω(ig.G.Eventually(log).WithTimeout(30 * time.Millisecond).WithPolling(5 * time.Millisecond).Should(ContainElement("ready"))).Should(BeFalse())
+		log.err = errors.New("boom")
+		log.cursors = nil
+		ig.G.Eventually(log).WithTimeout(30 * time.Millisecond).WithPolling(5 * time.Millisecond).Should(ContainElement("ready"))
+		Ω(ig.FailureMessage).Should(ContainSubstring("returned the following error:\nboom"))
+		Ω(log.cursors).Should(HaveEach(BeNil()))
+	})
+
+	It("supports polling signals returned by, or panicked in, the source", func() {
+		source := types.CursorSourceFunc(func(cursor interface{}) (interface{}, interface{}, error) {
+			internal.StopTrying("the log was rotated").Now()
+			return nil, nil, nil
+		})
+		ig.G.Eventually(source).WithTimeout(time.Second).Should(ContainElement("ready"))
+		Ω(ig.FailureMessage).Should(ContainSubstring("Told to stop trying"))
+		Ω(ig.FailureMessage).Should(ContainSubstring("the log was rotated"))
+	})
+
+	It("reads the sources passed to EventuallyAny", func() {
+		other := &fakeLog{}
+		time.AfterFunc(30*time.Millisecond, func() { other.append("ready") })
+		Ω(ig.G.EventuallyAny(log, other).WithTimeout(time.Second).WithPolling(5 * time.Millisecond).Should(ContainElement("ready"))).Should(BeTrue())
+	})
+})
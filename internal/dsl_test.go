@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/internal"
+	"github.com/onsi/gomega/types"
 )
 
 func getGlobalDurationBundle() internal.DurationBundle {
@@ -19,6 +20,7 @@ func setGlobalDurationBundle(bundle internal.DurationBundle) {
 	SetDefaultEventuallyPollingInterval(bundle.EventuallyPollingInterval)
 	SetDefaultConsistentlyDuration(bundle.ConsistentlyDuration)
 	SetDefaultConsistentlyPollingInterval(bundle.ConsistentlyPollingInterval)
+	SetTimeoutScale(bundle.TimeoutScale)
 }
 
 var _ = Describe("Gomega DSL", func() {
@@ -55,6 +57,7 @@ var _ = Describe("Gomega DSL", func() {
 				EventuallyPollingInterval:   2 * time.Minute,
 				ConsistentlyDuration:        3 * time.Minute,
 				ConsistentlyPollingInterval: 4 * time.Minute,
+				TimeoutScale:                2.5,
 			}
 			setGlobalDurationBundle(bundle)
 
@@ -201,11 +204,104 @@ var _ = Describe("Gomega DSL", func() {
 			SetDefaultEventuallyPollingInterval(bundle.EventuallyPollingInterval)
 			SetDefaultConsistentlyDuration(bundle.ConsistentlyDuration)
 			SetDefaultConsistentlyPollingInterval(bundle.ConsistentlyPollingInterval)
+			SetTimeoutScale(bundle.TimeoutScale)
 
 			Ω(Default.(*internal.Gomega).DurationBundle).Should(Equal(bundle))
 		})
 	})
 
+	Describe("RegisterDuration", func() {
+		It("registers named durations on the Default gomega", func() {
+			RegisterDuration("dsl-test", 3*time.Minute)
+			duration, ok := Default.(*internal.Gomega).NamedDuration("dsl-test")
+			Ω(ok).Should(BeTrue())
+			Ω(duration).Should(Equal(3 * time.Minute))
+		})
+	})
+
+	Describe("RegisterAsyncTimelineReporter", func() {
+		It("reports timelines of async assertions made with the Default gomega", func() {
+			DeferCleanup(func() { RegisterAsyncTimelineReporter(nil) })
+			timelines := []types.AsyncTimeline{}
+			RegisterAsyncTimelineReporter(func(timeline types.AsyncTimeline) {
+				timelines = append(timelines, timeline)
+			})
+
+			Eventually(func() bool { return true }).Should(BeTrue())
+			Ω(timelines).Should(HaveLen(1))
+			Ω(timelines[0].Succeeded).Should(BeTrue())
+
+			RegisterAsyncTimelineReporter(nil)
+			Eventually(func() bool { return true }).Should(BeTrue())
+			Ω(timelines).Should(HaveLen(1))
+		})
+	})
+
+	Describe("RegisterFailureArtifactProvider", func() {
+		It("registers providers with the Default gomega, and returns a function that unregisters them", func() {
+			calls := []string{}
+			provider := func(name string) types.FailureArtifactProvider {
+				return types.FailureArtifactProviderFunc(func(failure types.FailureMetadata) ([]string, error) {
+					calls = append(calls, name)
+					return []string{name + ".txt"}, nil
+				})
+			}
+			failureMessage := ""
+			RegisterFailHandler(func(message string, skip ...int) {
+				failureMessage = message
+			})
+
+			unregisterA := RegisterFailureArtifactProvider(provider("a"))
+			unregisterB := RegisterFailureArtifactProvider(provider("b"))
+			DeferCleanup(func() {
+				unregisterA()
+				unregisterB()
+			})
+
+			Expect(true).To(BeFalse())
+			Ω(calls).Should(Equal([]string{"a", "b"}))
+			Ω(failureMessage).Should(HaveSuffix("Failure artifacts:\n    a.txt\n    b.txt"))
+
+			unregisterA()
+			Expect(true).To(BeFalse())
+			Ω(calls).Should(Equal([]string{"a", "b", "b"}))
+			Ω(failureMessage).Should(HaveSuffix("Failure artifacts:\n    b.txt"))
+
+			unregisterB()
+			Expect(true).To(BeFalse())
+			Ω(calls).Should(Equal([]string{"a", "b", "b"}))
+			Ω(failureMessage).Should(Equal("Expected\n    <bool>: true\nto be false"))
+		})
+	})
+
+	Describe("RegisterAsyncActualAdapter", func() {
+		It("registers adapters with the Default gomega, and returns a function that unregisters them", func() {
+			adapter := &fakeFutureAdapter{}
+			unregister := RegisterAsyncActualAdapter(adapter)
+			DeferCleanup(unregister)
+
+			future := &fakeFuture{}
+			future.resolve("done", nil)
+			Eventually(future).Should(Equal("done"))
+			Ω(adapter.contexts).Should(HaveLen(1))
+
+			unregister()
+			Ω(Default.(*internal.Gomega).AsyncActualAdapters).Should(BeEmpty())
+			Eventually(future).Should(Equal(future))
+			Ω(adapter.contexts).Should(HaveLen(1))
+		})
+	})
+
+	Describe("WithinScope", func() {
+		It("scopes the failures of the Default gomega", func() {
+			Expect(InterceptGomegaFailures(func() {
+				WithinScope("dsl-test", func(g *WithT) {
+					g.Expect(3).To(Equal(2))
+				})
+			})).To(ConsistOf(MatchRegexp(`^Scope: dsl-test \(\d+\.\d{3}s\)\nExpected`)))
+		})
+	})
+
 	Describe("Offsets", func() {
 		AfterEach(func() {
 			RegisterFailHandler(Fail)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strconv"
 	"time"
 )
 
@@ -12,6 +13,9 @@ type DurationBundle struct {
 	EventuallyPollingInterval   time.Duration
 	ConsistentlyDuration        time.Duration
 	ConsistentlyPollingInterval time.Duration
+
+	// TimeoutScale multiplies the timeouts of Eventually and the durations of Consistently.  Zero means unscaled.
+	TimeoutScale float64
 }
 
 const (
@@ -20,6 +24,8 @@ const (
 
 	ConsistentlyDurationEnvVarName        = "GOMEGA_DEFAULT_CONSISTENTLY_DURATION"
 	ConsistentlyPollingIntervalEnvVarName = "GOMEGA_DEFAULT_CONSISTENTLY_POLLING_INTERVAL"
+
+	TimeoutScaleEnvVarName = "GOMEGA_TIMEOUT_SCALE"
 )
 
 func FetchDefaultDurationBundle() DurationBundle {
@@ -29,6 +35,8 @@ func FetchDefaultDurationBundle() DurationBundle {
 
 		ConsistentlyDuration:        durationFromEnv(ConsistentlyDurationEnvVarName, 100*time.Millisecond),
 		ConsistentlyPollingInterval: durationFromEnv(ConsistentlyPollingIntervalEnvVarName, 10*time.Millisecond),
+
+		TimeoutScale: scaleFromEnv(TimeoutScaleEnvVarName),
 	}
 }
 
@@ -44,6 +52,26 @@ func durationFromEnv(key string, defaultDuration time.Duration) time.Duration {
 	return duration
 }
 
+func scaleFromEnv(key string) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return 0
+	}
+	scale, err := strconv.ParseFloat(value, 64)
+	if err != nil || scale <= 0 {
+		panic(fmt.Sprintf("Expected a positive number when using %s!  Got %q", key, value))
+	}
+	return scale
+}
+
+// scaleTimeout applies the TimeoutScale to a timeout
+func (bundle DurationBundle) scaleTimeout(timeout time.Duration) time.Duration {
+	if bundle.TimeoutScale <= 0 || bundle.TimeoutScale == 1 {
+		return timeout
+	}
+	return time.Duration(float64(timeout) * bundle.TimeoutScale)
+}
+
 func toDuration(input interface{}) (time.Duration, error) {
 	duration, ok := input.(time.Duration)
 	if ok {
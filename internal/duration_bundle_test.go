@@ -16,7 +16,7 @@ var _ = Describe("DurationBundle and Duration Support", func() {
 		var originalValues map[string]string
 
 		BeforeEach(func() {
-			envVars = []string{internal.EventuallyTimeoutEnvVarName, internal.EventuallyPollingIntervalEnvVarName, internal.ConsistentlyDurationEnvVarName, internal.ConsistentlyPollingIntervalEnvVarName}
+			envVars = []string{internal.EventuallyTimeoutEnvVarName, internal.EventuallyPollingIntervalEnvVarName, internal.ConsistentlyDurationEnvVarName, internal.ConsistentlyPollingIntervalEnvVarName, internal.TimeoutScaleEnvVarName}
 			originalValues = map[string]string{}
 
 			for _, envVar := range envVars {
@@ -43,6 +43,7 @@ var _ = Describe("DurationBundle and Duration Support", func() {
 				Ω(bundle.EventuallyPollingInterval).Should(Equal(10 * time.Millisecond))
 				Ω(bundle.ConsistentlyDuration).Should(Equal(100 * time.Millisecond))
 				Ω(bundle.ConsistentlyPollingInterval).Should(Equal(10 * time.Millisecond))
+				Ω(bundle.TimeoutScale).Should(BeZero())
 			})
 		})
 
@@ -52,6 +53,7 @@ var _ = Describe("DurationBundle and Duration Support", func() {
 				os.Setenv(internal.EventuallyPollingIntervalEnvVarName, "2s")
 				os.Setenv(internal.ConsistentlyDurationEnvVarName, "1h")
 				os.Setenv(internal.ConsistentlyPollingIntervalEnvVarName, "3ms")
+				os.Setenv(internal.TimeoutScaleEnvVarName, "2.5")
 			})
 
 			It("returns an appropriate bundle", func() {
@@ -60,6 +62,7 @@ var _ = Describe("DurationBundle and Duration Support", func() {
 				Ω(bundle.EventuallyPollingInterval).Should(Equal(2 * time.Second))
 				Ω(bundle.ConsistentlyDuration).Should(Equal(time.Hour))
 				Ω(bundle.ConsistentlyPollingInterval).Should(Equal(3 * time.Millisecond))
+				Ω(bundle.TimeoutScale).Should(Equal(2.5))
 			})
 		})
 
@@ -74,6 +77,20 @@ var _ = Describe("DurationBundle and Duration Support", func() {
 				}).Should(PanicWith(`Expected a duration when using GOMEGA_DEFAULT_EVENTUALLY_TIMEOUT!  Parse error time: invalid duration "chicken nuggets"`))
 			})
 		})
+
+		Context("with an invalid timeout scale set", func() {
+			It("panics", func() {
+				os.Setenv(internal.TimeoutScaleEnvVarName, "lots")
+				Ω(func() {
+					internal.FetchDefaultDurationBundle()
+				}).Should(PanicWith(`Expected a positive number when using GOMEGA_TIMEOUT_SCALE!  Got "lots"`))
+
+				os.Setenv(internal.TimeoutScaleEnvVarName, "-1")
+				Ω(func() {
+					internal.FetchDefaultDurationBundle()
+				}).Should(PanicWith(`Expected a positive number when using GOMEGA_TIMEOUT_SCALE!  Got "-1"`))
+			})
+		})
 	})
 
 	Describe("specifying default durations on a Gomega instance", func() {
@@ -108,6 +125,122 @@ var _ = Describe("DurationBundle and Duration Support", func() {
 		})
 	})
 
+	Describe("scaling timeouts", func() {
+		var ig *InstrumentedGomega
+
+		BeforeEach(func() {
+			ig = NewInstrumentedGomega()
+			ig.G.SetTimeoutScale(3)
+		})
+
+		It("scales the default timeout of Eventually and duration of Consistently", func() {
+			ig.G.SetDefaultEventuallyTimeout(20 * time.Millisecond)
+			ig.G.SetDefaultConsistentlyDuration(20 * time.Millisecond)
+
+			t := time.Now()
+			ig.G.Consistently(true).Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("~", 60*time.Millisecond, 25*time.Millisecond))
+
+			t = time.Now()
+			ig.G.Eventually(func() bool { return false }).Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("~", 60*time.Millisecond, 25*time.Millisecond))
+		})
+
+		It("scales explicit timeouts", func() {
+			t := time.Now()
+			ig.G.Consistently(true, "20ms").Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("~", 60*time.Millisecond, 25*time.Millisecond))
+
+			t = time.Now()
+			ig.G.Eventually(func() bool { return false }).WithTimeout(20 * time.Millisecond).Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("~", 60*time.Millisecond, 25*time.Millisecond))
+
+			t = time.Now()
+			ig.G.Eventually(func() bool { return false }).Within(20 * time.Millisecond).Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("~", 60*time.Millisecond, 25*time.Millisecond))
+		})
+
+		It("reports the scale in failure messages", func() {
+			ig.G.Eventually(func() bool { return false }).WithTimeout(10 * time.Millisecond).Should(BeTrue())
+			Ω(ig.FailureMessage).Should(MatchRegexp(`^Timed out after 0\.0\d\ds \(timeouts scaled by 3x\)\.\n`))
+
+			ig.G.SetTimeoutScale(1.5)
+			ig.G.Consistently(true, "10ms").Should(BeFalse())
+			Ω(ig.FailureMessage).Should(MatchRegexp(`^Failed after 0\.0\d\ds \(timeouts scaled by 1\.5x\)\.\n`))
+		})
+
+		It("does not scale timeouts, or mention the scale, when set to 1", func() {
+			ig.G.SetTimeoutScale(1)
+			t := time.Now()
+			ig.G.Eventually(func() bool { return false }).WithTimeout(20 * time.Millisecond).Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("~", 20*time.Millisecond, 15*time.Millisecond))
+			Ω(ig.FailureMessage).Should(MatchRegexp(`^Timed out after 0\.0\d\ds\.\n`))
+		})
+	})
+
+	Describe("named timeouts", func() {
+		var ig *InstrumentedGomega
+
+		BeforeEach(func() {
+			ig = NewInstrumentedGomega()
+			ig.G.RegisterDuration("short", 20*time.Millisecond)
+		})
+
+		It("uses the registered duration as the timeout", func() {
+			t := time.Now()
+			ig.G.Eventually(func() bool { return false }).(NamedTimeoutAsyncAssertion).WithTimeoutNamed("short").Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("~", 20*time.Millisecond, 15*time.Millisecond))
+			Ω(ig.FailureMessage).Should(MatchRegexp(`^Timed out after 0\.0\d\ds \(timeout "short" is 20ms\)\.\n`))
+
+			t = time.Now()
+			ig.G.Consistently(true).(NamedTimeoutAsyncAssertion).WithTimeoutNamed("short").Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("~", 20*time.Millisecond, 15*time.Millisecond))
+		})
+
+		It("picks up durations registered after the assertion is made", func() {
+			eventually := ig.G.Eventually(func() bool { return false }).(NamedTimeoutAsyncAssertion).WithTimeoutNamed("long")
+			ig.G.RegisterDuration("long", 40*time.Millisecond)
+			t := time.Now()
+			eventually.Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("~", 40*time.Millisecond, 15*time.Millisecond))
+			Ω(ig.FailureMessage).Should(ContainSubstring(`(timeout "long" is 40ms)`))
+		})
+
+		It("lets later registrations replace earlier ones", func() {
+			ig.G.RegisterDuration("short", 10*time.Millisecond)
+			d, ok := ig.G.NamedDuration("short")
+			Ω(ok).Should(BeTrue())
+			Ω(d).Should(Equal(10 * time.Millisecond))
+		})
+
+		It("is overridden by a later WithTimeout or Within", func() {
+			t := time.Now()
+			ig.G.Eventually(func() bool { return false }).(NamedTimeoutAsyncAssertion).WithTimeoutNamed("short").WithTimeout(50 * time.Millisecond).Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("~", 50*time.Millisecond, 15*time.Millisecond))
+			Ω(ig.FailureMessage).ShouldNot(ContainSubstring("short"))
+
+			t = time.Now()
+			ig.G.Eventually(func() bool { return false }).(NamedTimeoutAsyncAssertion).WithTimeoutNamed("short").Within(50 * time.Millisecond).Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("~", 50*time.Millisecond, 15*time.Millisecond))
+		})
+
+		It("is scaled by the timeout scale", func() {
+			ig.G.SetTimeoutScale(3)
+			t := time.Now()
+			ig.G.Eventually(func() bool { return false }).(NamedTimeoutAsyncAssertion).WithTimeoutNamed("short").Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("~", 60*time.Millisecond, 25*time.Millisecond))
+			Ω(ig.FailureMessage).Should(MatchRegexp(`^Timed out after 0\.0\d\ds \(timeout "short" is 20ms, timeouts scaled by 3x\)\.\n`))
+		})
+
+		It("fails immediately when no duration is registered with the name", func() {
+			t := time.Now()
+			ig.G.Eventually(func() bool { return true }).(NamedTimeoutAsyncAssertion).WithTimeoutNamed("unknown").Should(BeTrue())
+			Ω(time.Since(t)).Should(BeNumerically("<", 10*time.Millisecond))
+			Ω(ig.FailureMessage).Should(Equal(`Eventually was given the timeout "unknown", but no duration is registered with that name.  Register one with RegisterDuration.`))
+			Ω(ig.FailureSkip).Should(Equal([]int{2}))
+		})
+	})
+
 	Describe("specifying durations", func() {
 		It("supports passing in a duration", func() {
 			t := time.Now()
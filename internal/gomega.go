@@ -2,8 +2,12 @@ package internal
 
 import (
 	"context"
+	"runtime"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/onsi/gomega/format"
 	"github.com/onsi/gomega/types"
 )
 
@@ -11,6 +15,29 @@ type Gomega struct {
 	Fail           types.GomegaFailHandler
 	THelper        func()
 	DurationBundle DurationBundle
+
+	// AsyncTimelineReporter, if set, is called with a timeline of every Eventually and Consistently once it completes
+	AsyncTimelineReporter func(types.AsyncTimeline)
+
+	// FailureArtifactProviders are called, in order, whenever an assertion fails
+	FailureArtifactProviders []types.FailureArtifactProvider
+
+	// AsyncActualAdapters teach Eventually and Consistently to poll new kinds of actual.  The first that adapts an
+	// actual is used.
+	AsyncActualAdapters []types.AsyncActualAdapter
+
+	// Compatibility opts out of behaviors that have changed since earlier versions of Gomega
+	Compatibility types.CompatibilityMode
+
+	namedDurations     map[string]time.Duration
+	namedDurationsLock sync.RWMutex
+
+	asyncScheduler     *asyncScheduler
+	asyncSchedulerLock sync.Mutex
+
+	// unscoped is the Gomega a Gomega passed to WithinScope was derived from, and scopes the scopes it is in
+	unscoped *Gomega
+	scopes   []scope
 }
 
 func NewGomega(bundle DurationBundle) *Gomega {
@@ -60,6 +87,10 @@ func (g *Gomega) EventuallyWithOffset(offset int, actualOrCtx interface{}, args
 	return g.makeAsyncAssertion(AsyncAssertionTypeEventually, offset, actualOrCtx, args...)
 }
 
+func (g *Gomega) EventuallyAny(actuals ...interface{}) types.AsyncAssertion {
+	return NewAsyncAssertion(AsyncAssertionTypeEventually, anyOfActuals(actuals), g, -time.Duration(1), -time.Duration(1), 1, nil, 0)
+}
+
 func (g *Gomega) Consistently(actualOrCtx interface{}, args ...interface{}) types.AsyncAssertion {
 	return g.makeAsyncAssertion(AsyncAssertionTypeConsistently, 0, actualOrCtx, args...)
 }
@@ -127,3 +158,89 @@ func (g *Gomega) SetDefaultConsistentlyDuration(t time.Duration) {
 func (g *Gomega) SetDefaultConsistentlyPollingInterval(t time.Duration) {
 	g.DurationBundle.ConsistentlyPollingInterval = t
 }
+
+func (g *Gomega) SetTimeoutScale(scale float64) {
+	g.DurationBundle.TimeoutScale = scale
+}
+
+// RegisterDuration registers a duration under name, for use with WithTimeoutNamed
+func (g *Gomega) RegisterDuration(name string, duration time.Duration) {
+	if g.unscoped != nil {
+		g.unscoped.RegisterDuration(name, duration)
+		return
+	}
+	g.namedDurationsLock.Lock()
+	defer g.namedDurationsLock.Unlock()
+	if g.namedDurations == nil {
+		g.namedDurations = map[string]time.Duration{}
+	}
+	g.namedDurations[name] = duration
+}
+
+// NamedDuration returns the duration registered under name
+func (g *Gomega) NamedDuration(name string) (time.Duration, bool) {
+	if g.unscoped != nil {
+		return g.unscoped.NamedDuration(name)
+	}
+	g.namedDurationsLock.RLock()
+	defer g.namedDurationsLock.RUnlock()
+	duration, ok := g.namedDurations[name]
+	return duration, ok
+}
+
+// SerializeAsyncAssertions makes asynchronous assertions that start from now on take turns polling, round-robin, in
+// the order they started
+func (g *Gomega) SerializeAsyncAssertions(serialize bool) {
+	if g.unscoped != nil {
+		g.unscoped.SerializeAsyncAssertions(serialize)
+		return
+	}
+	g.asyncSchedulerLock.Lock()
+	defer g.asyncSchedulerLock.Unlock()
+	if !serialize {
+		g.asyncScheduler = nil
+	} else if g.asyncScheduler == nil {
+		g.asyncScheduler = newAsyncScheduler()
+	}
+}
+
+// joinAsyncScheduler returns a new participant in the async scheduler, or nil if assertions are not serialized
+func (g *Gomega) joinAsyncScheduler() *asyncParticipant {
+	if g.unscoped != nil {
+		return g.unscoped.joinAsyncScheduler()
+	}
+	g.asyncSchedulerLock.Lock()
+	defer g.asyncSchedulerLock.Unlock()
+	if g.asyncScheduler == nil {
+		return nil
+	}
+	return g.asyncScheduler.join()
+}
+
+// withFailureArtifacts runs the FailureArtifactProviders and appends the artifacts they collected to the failure message.
+// skip has the same meaning as the skip passed to the fail handler.
+func (g *Gomega) withFailureArtifacts(message string, skip int) string {
+	if len(g.FailureArtifactProviders) == 0 {
+		return message
+	}
+
+	_, file, line, _ := runtime.Caller(skip + 1)
+	failure := types.FailureMetadata{Message: message, File: file, Line: line, Time: time.Now()}
+
+	artifacts, errs := []string{}, []string{}
+	for _, provider := range g.FailureArtifactProviders {
+		collected, err := provider.CollectFailureArtifacts(failure)
+		artifacts = append(artifacts, collected...)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(artifacts) > 0 {
+		message += "\n\nFailure artifacts:\n" + format.IndentString(strings.Join(artifacts, "\n"), 1)
+	}
+	if len(errs) > 0 {
+		message += "\n\nFailed to collect failure artifacts:\n" + format.IndentString(strings.Join(errs, "\n"), 1)
+	}
+	return message
+}
@@ -0,0 +1,52 @@
+package gutil
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// SortedMapKeys returns the keys of the passed in map in a deterministic order.
+//
+// Keys of the same numeric, string or boolean type are sorted by value.  All other keys (and keys of
+// differing types stored in an interface-keyed map) are sorted by type name and then by their
+// %#v representation.  SortedMapKeys is used wherever Gomega iterates over a map to build output
+// so that failure messages are stable from run to run.
+func SortedMapKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		return lessValue(keys[i], keys[j])
+	})
+	return keys
+}
+
+func lessValue(a, b reflect.Value) bool {
+	for a.Kind() == reflect.Interface && !a.IsNil() {
+		a = a.Elem()
+	}
+	for b.Kind() == reflect.Interface && !b.IsNil() {
+		b = b.Elem()
+	}
+
+	if a.Type() != b.Type() {
+		if a.Type().String() != b.Type().String() {
+			return a.Type().String() < b.Type().String()
+		}
+		return fmt.Sprintf("%#v", a) < fmt.Sprintf("%#v", b)
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return a.String() < b.String()
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	default:
+		return fmt.Sprintf("%#v", a) < fmt.Sprintf("%#v", b)
+	}
+}
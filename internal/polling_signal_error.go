@@ -17,6 +17,7 @@ type PollingSignalError interface {
 	error
 	Wrap(err error) PollingSignalError
 	Attach(description string, obj any) PollingSignalError
+	Suggest(action string) PollingSignalError
 	Now()
 }
 
@@ -45,6 +46,8 @@ type PollingSignalErrorImpl struct {
 	wrappedErr             error
 	pollingSignalErrorType PollingSignalErrorType
 	duration               time.Duration
+	suggestedAction        string
+	attempts               int
 	Attachments            []PollingSignalErrorAttachment
 }
 
@@ -58,6 +61,21 @@ func (s *PollingSignalErrorImpl) Attach(description string, obj any) PollingSign
 	return s
 }
 
+func (s *PollingSignalErrorImpl) Suggest(action string) PollingSignalError {
+	s.suggestedAction = action
+	return s
+}
+
+// SuggestedAction returns the action suggested with Suggest, if any
+func (s *PollingSignalErrorImpl) SuggestedAction() string {
+	return s.suggestedAction
+}
+
+// Attempts returns the number of times Eventually or Consistently had polled when they were told to stop trying
+func (s *PollingSignalErrorImpl) Attempts() int {
+	return s.attempts
+}
+
 func (s *PollingSignalErrorImpl) Error() string {
 	if s.wrappedErr == nil {
 		return s.message
@@ -39,6 +39,15 @@ var _ = Describe("PollingSignalError", func() {
 			})
 		})
 
+		Describe("suggesting an action", func() {
+			It("records the suggested action, without changing the error", func() {
+				st := StopTrying("Welp!").Suggest("check the logs").(*internal.PollingSignalErrorImpl)
+				Ω(st.SuggestedAction()).Should(Equal("check the logs"))
+				Ω(st.Error()).Should(Equal("Welp!"))
+				Ω(st.Attempts()).Should(BeZero())
+			})
+		})
+
 		Describe("when invoking Now()", func() {
 			It("should panic with itself", func() {
 				st := StopTrying("bam").(*internal.PollingSignalErrorImpl)
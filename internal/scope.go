@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega/format"
+)
+
+type scope struct {
+	description string
+	start       time.Time
+}
+
+// WithinScope calls f with a Gomega derived from g that prepends the path of scopes f runs in, and the time spent in
+// each, to the message of any failure of its assertions.  g itself is left untouched, so failures of its own assertions
+// are not annotated - even while f runs.
+func (g *Gomega) WithinScope(description string, f func(g *Gomega)) {
+	f(g.withScope(scope{description: description, start: time.Now()}))
+}
+
+// withScope returns a Gomega configured as g is now, whose failures are annotated with g's scopes and s.  Scoped
+// Gomegas report their failures through the fail handler of the unscoped Gomega they were derived from, and share its
+// named durations and async scheduler.
+func (g *Gomega) withScope(s scope) *Gomega {
+	unscoped := g
+	if g.unscoped != nil {
+		unscoped = g.unscoped
+	}
+	scoped := &Gomega{
+		THelper:                  g.THelper,
+		DurationBundle:           g.DurationBundle,
+		AsyncTimelineReporter:    g.AsyncTimelineReporter,
+		FailureArtifactProviders: g.FailureArtifactProviders,
+		AsyncActualAdapters:      g.AsyncActualAdapters,
+		Compatibility:            g.Compatibility,
+		unscoped:                 unscoped,
+		scopes:                   append(append([]scope{}, g.scopes...), s),
+	}
+	scoped.Fail = func(message string, callerSkip ...int) {
+		unscoped.THelper()
+		skip := 0
+		if len(callerSkip) > 0 {
+			skip = callerSkip[0]
+		}
+		unscoped.Fail(scoped.scopeAnnotation()+message, skip+1)
+	}
+	return scoped
+}
+
+// scopeAnnotation renders the scopes g is in.  Failure messages have been made accessible by the time they are
+// annotated, so the annotation is made accessible here.
+func (g *Gomega) scopeAnnotation() string {
+	steps := make([]string, len(g.scopes))
+	for i, s := range g.scopes {
+		steps[i] = fmt.Sprintf("%s (%.3fs)", s.description, time.Since(s.start).Seconds())
+	}
+	separator := " → "
+	if format.AccessibleOutput {
+		separator = " -> "
+	}
+	return format.AccessibleString("Scope: "+strings.Join(steps, separator)) + "\n"
+}
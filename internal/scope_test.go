@@ -0,0 +1,138 @@
+package internal_test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/internal"
+)
+
+var _ = Describe("WithinScope", func() {
+	var ig *InstrumentedGomega
+
+	BeforeEach(func() {
+		ig = NewInstrumentedGomega()
+	})
+
+	It("prefixes failures with the scope and the time spent in it", func() {
+		ig.G.WithinScope("bootstrapping cluster", func(g *internal.Gomega) {
+			time.Sleep(20 * time.Millisecond)
+			g.Expect(1).To(Equal(2))
+		})
+		Ω(ig.FailureMessage).Should(MatchRegexp(`^Scope: bootstrapping cluster \(0\.0[2-9]\ds\)\nExpected\n    <int>: 1\nto equal`))
+	})
+
+	It("renders the path of nested scopes", func() {
+		ig.G.WithinScope("bootstrapping cluster", func(g *internal.Gomega) {
+			g.WithinScope("installing CNI", func(g *internal.Gomega) {
+				g.Expect(errors.New("boom")).NotTo(HaveOccurred())
+			})
+		})
+		Ω(ig.FailureMessage).Should(MatchRegexp(`^Scope: bootstrapping cluster \(\d+\.\d{3}s\) → installing CNI \(\d+\.\d{3}s\)\nUnexpected error`))
+	})
+
+	It("renders plain ASCII when format.AccessibleOutput is enabled", func() {
+		format.AccessibleOutput = true
+		DeferCleanup(func() {
+			format.AccessibleOutput = false
+		})
+		ig.G.WithinScope("démarrage", func(g *internal.Gomega) {
+			g.WithinScope("inner", func(g *internal.Gomega) {
+				g.Expect(1).To(Equal(2))
+			})
+		})
+		Ω(ig.FailureMessage).Should(MatchRegexp(`^Scope: d\\u00e9marrage \(\d+\.\d{3}s\) -> inner \(\d+\.\d{3}s\)\nACTUAL:`))
+	})
+
+	It("annotates asynchronous assertions", func() {
+		ig.G.WithinScope("waiting", func(g *internal.Gomega) {
+			g.Eventually(func() int { return 1 }).WithTimeout(10 * time.Millisecond).Should(Equal(2))
+		})
+		Ω(ig.FailureMessage).Should(HavePrefix("Scope: waiting ("))
+		Ω(ig.FailureMessage).Should(ContainSubstring("Timed out after"))
+	})
+
+	It("only annotates failures within the scope", func() {
+		ig.G.WithinScope("outer", func(g *internal.Gomega) {
+			g.WithinScope("inner", func(g *internal.Gomega) {})
+			g.Expect(1).To(Equal(2))
+		})
+		Ω(ig.FailureMessage).Should(MatchRegexp(`^Scope: outer \(\d+\.\d{3}s\)\nExpected`))
+
+		ig.G.Expect(1).To(Equal(2))
+		Ω(ig.FailureMessage).Should(HavePrefix("Expected"))
+	})
+
+	It("accounts for its own frame in the skip it passes on", func() {
+		ig.G.Expect(1).To(Equal(2))
+		unscopedSkip := ig.FailureSkip[0]
+		ig.G.WithinScope("scope", func(g *internal.Gomega) {
+			g.Expect(1).To(Equal(2))
+		})
+		Ω(ig.FailureSkip).Should(Equal([]int{unscopedSkip + 1}))
+		Ω(ig.RegisteredHelpers).Should(ContainElement(HavePrefix("(*Gomega).withScope")))
+	})
+
+	It("does not annotate the failures of the Gomega it was derived from", func() {
+		ig.G.WithinScope("scope", func(g *internal.Gomega) {
+			ig.G.Expect(1).To(Equal(2))
+			Ω(ig.FailureMessage).Should(HavePrefix("Expected"))
+		})
+		Ω(func() {
+			ig.G.WithinScope("scope", func(g *internal.Gomega) { panic("boom") })
+		}).Should(PanicWith("boom"))
+		ig.G.Expect(1).To(Equal(2))
+		Ω(ig.FailureMessage).Should(HavePrefix("Expected"))
+	})
+
+	It("reports failures through the fail handler the Gomega it was derived from has at the time", func() {
+		var intercepted string
+		ig.G.WithinScope("scope", func(g *internal.Gomega) {
+			ig.G.Fail = func(message string, _ ...int) { intercepted = message }
+			g.Expect(1).To(Equal(2))
+		})
+		Ω(intercepted).Should(HavePrefix("Scope: scope ("))
+	})
+
+	It("keeps the scopes of concurrent scopes apart", func() {
+		var lock sync.Mutex
+		messages := []string{}
+		ig.G.Fail = func(message string, _ ...int) {
+			lock.Lock()
+			defer lock.Unlock()
+			messages = append(messages, message)
+		}
+		ig.G.THelper = func() {}
+		wg := &sync.WaitGroup{}
+		for _, description := range []string{"first", "second"} {
+			description := description
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				ig.G.WithinScope(description, func(g *internal.Gomega) {
+					time.Sleep(10 * time.Millisecond)
+					g.Expect(1).To(Equal(2))
+				})
+			}()
+		}
+		wg.Wait()
+		Ω(messages).Should(ConsistOf(
+			MatchRegexp(`^Scope: first \(\d+\.\d{3}s\)\nExpected`),
+			MatchRegexp(`^Scope: second \(\d+\.\d{3}s\)\nExpected`),
+		))
+	})
+
+	It("shares the named durations of the Gomega it was derived from", func() {
+		ig.G.RegisterDuration("short", 10*time.Millisecond)
+		ig.G.WithinScope("scope", func(g *internal.Gomega) {
+			duration, ok := g.NamedDuration("short")
+			Ω(ok).Should(BeTrue())
+			Ω(duration).Should(Equal(10 * time.Millisecond))
+		})
+	})
+})
@@ -1,6 +1,9 @@
 package gomega
 
 import (
+	"crypto/x509"
+	"io"
+	"reflect"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
@@ -83,10 +86,27 @@ func HaveOccurred() types.GomegaMatcher {
 // It is a mistake to use Succeed with a function that has multiple return values.  Gomega's Ω and Expect
 // functions automatically trigger failure if any return values after the first return value are non-zero/non-nil.
 // This means that Ω(MultiReturnFunc()).ShouldNot(Succeed()) can never pass.
+//
+// When actual is an error that wraps others, the failure message includes the error chain, and the stack trace of the
+// deepest error in the chain with a StackTrace method (as errors created by github.com/pkg/errors have).
 func Succeed() types.GomegaMatcher {
 	return &matchers.SucceedMatcher{}
 }
 
+// SucceedWith passes if actual is a nil error, just like Succeed.  It also describes the error actual should be when it
+// isn't nil - with a matcher, an error, or a string, as with MatchError - so that ShouldNot can assert on the specific
+// failure:
+//
+//	Eventually(client.Fetch).WithArguments("deleted-key").ShouldNot(SucceedWith(MatchError(ErrNotFound)))
+//
+// When actual is an error that does not satisfy the description SucceedWith errors, so neither Should nor ShouldNot
+// pass - and Eventually keeps polling.
+func SucceedWith(expectedError interface{}) types.GomegaMatcher {
+	return &matchers.SucceedMatcher{
+		Error: expectedError,
+	}
+}
+
 // MatchError succeeds if actual is a non-nil error that matches the passed in string/error.
 //
 // These are valid use-cases:
@@ -101,6 +121,92 @@ func MatchError(expected interface{}) types.GomegaMatcher {
 	}
 }
 
+// HaveErrorInChain succeeds if actual is a non-nil error whose chain - actual followed by each error it wraps, as
+// unwrapped by errors.Unwrap - contains target.  target can be an error, which is checked with errors.Is and
+// reflect.DeepEqual; a string, which must equal an error's message; or a matcher, which is passed each error in turn:
+//
+//	Expect(err).Should(HaveErrorInChain(fs.ErrNotExist))
+//	Expect(err).Should(HaveErrorInChain(BeAssignableToTypeOf(&fs.PathError{})))
+//
+// On failure the whole chain is printed, one error per line.
+func HaveErrorInChain(target interface{}) types.GomegaMatcher {
+	return &matchers.HaveErrorInChainMatcher{Target: target}
+}
+
+// HaveErrorChainDepth succeeds if actual is a non-nil error that wraps exactly depth errors.  An error that wraps
+// nothing has depth 0:
+//
+//	Expect(fmt.Errorf("loading config: %w", err)).Should(HaveErrorChainDepth(1))
+//
+// On failure the whole chain is printed, one error per line.
+func HaveErrorChainDepth(depth int) types.GomegaMatcher {
+	return &matchers.HaveErrorChainDepthMatcher{Depth: depth}
+}
+
+// MatchErrorAt succeeds if actual is a non-nil error and the error at depth in its chain matches expected.  Depth 0
+// is actual itself, depth 1 the error it wraps, and so on.  expected can be an error, which must be equal to the
+// error at depth; a string, which must equal its message; or a matcher, which is passed the error at depth:
+//
+//	Expect(err).Should(MatchErrorAt(1, MatchError(ContainSubstring("connection refused"))))
+//	Expect(err).Should(MatchErrorAt(2, BeAssignableToTypeOf(&net.OpError{})))
+//
+// On failure the whole chain is printed, one error per line.
+func MatchErrorAt(depth int, expected interface{}) types.GomegaMatcher {
+	return &matchers.MatchErrorAtMatcher{Depth: depth, Expected: expected}
+}
+
+// BeTimeoutError succeeds if actual is a non-nil error and the first error in its chain with a Timeout() bool method -
+// net.Error, os.ErrDeadlineExceeded and context.DeadlineExceeded all have one - reports that it is a timeout:
+//
+//	_, err := conn.Read(buf)
+//	Expect(err).Should(BeTimeoutError())
+//
+// On failure the whole chain is printed, one error per line.
+func BeTimeoutError() types.GomegaMatcher {
+	return &matchers.BeTimeoutErrorMatcher{}
+}
+
+// BeTemporaryError succeeds if actual is a non-nil error and the first error in its chain with a Temporary() bool
+// method - such as a net.Error - reports that it is temporary.
+//
+// On failure the whole chain is printed, one error per line.
+func BeTemporaryError() types.GomegaMatcher {
+	return &matchers.BeTemporaryErrorMatcher{}
+}
+
+// HaveOccurredWithin succeeds if actual is a non-nil error and the first error in its chain with a Timestamp()
+// time.Time method is timestamped no more than duration ago:
+//
+//	Expect(lastErr).Should(HaveOccurredWithin(time.Minute))
+//
+// On failure the whole chain is printed, one error per line.
+func HaveOccurredWithin(duration time.Duration) types.GomegaMatcher {
+	return &matchers.HaveOccurredWithinMatcher{Duration: duration}
+}
+
+// HaveGRPCStatus succeeds if actual has the gRPC status code - such as codes.NotFound - that status.FromError would
+// extract from it: nil has code OK, an error with a GRPCStatus method (or that wraps one) has its status' code, and
+// any other error has code Unknown.
+//
+// Optionally, HaveGRPCStatus can also assert on the status message - with a string, or a matcher - and on the
+// status details, with a matcher that is passed the slice returned by the status' Details method:
+//
+//	Expect(err).Should(HaveGRPCStatus(codes.NotFound))
+//	Expect(err).Should(HaveGRPCStatus(codes.InvalidArgument, ContainSubstring("email"), ContainElement(BeAssignableToTypeOf(&errdetails.BadRequest{}))))
+//
+// On failure the full status is printed.  Gomega does not depend on google.golang.org/grpc - the status is read by
+// calling its methods.
+func HaveGRPCStatus(code interface{}, optionalMessageAndDetails ...interface{}) types.GomegaMatcher {
+	matcher := &matchers.HaveGRPCStatusMatcher{Code: code}
+	if len(optionalMessageAndDetails) > 0 {
+		matcher.Message = optionalMessageAndDetails[0]
+	}
+	if len(optionalMessageAndDetails) > 1 {
+		matcher.Details = optionalMessageAndDetails[1]
+	}
+	return matcher
+}
+
 // BeClosed succeeds if actual is a closed channel.
 // It is an error to pass a non-channel to BeClosed, it is also an error to pass nil
 //
@@ -116,6 +222,81 @@ func BeClosed() types.GomegaMatcher {
 	return &matchers.BeClosedMatcher{}
 }
 
+// BeDone succeeds if actual is a context.Context that is done - i.e. whose Done() channel is closed.
+// When the context is done but should not be, the failure message reports ctx.Err().
+//
+// Use it with Eventually to wait for a context to be cancelled or to time out:
+//
+//	Eventually(ctx).Should(BeDone())
+func BeDone() types.GomegaMatcher {
+	return &matchers.BeDoneMatcher{}
+}
+
+// BeUnlockedMutex succeeds if actual is a *sync.Mutex or *sync.RWMutex - or anything else with TryLock and Unlock
+// methods - that can be locked right now.  BeUnlockedMutex locks and immediately unlocks actual to find out, so it is
+// best-effort: another goroutine may lock actual as soon as the matcher returns.  A *sync.RWMutex held by readers
+// is locked as far as BeUnlockedMutex is concerned.
+//
+//	Eventually(&cache.mu).Should(BeUnlockedMutex())
+func BeUnlockedMutex() types.GomegaMatcher {
+	return &matchers.BeUnlockedMutexMatcher{}
+}
+
+// HaveChannelLen succeeds if the number of values buffered in actual, which must be a channel, satisfies expected.
+// expected can be a number or a matcher; numbers are compared with BeNumerically("==", ...) so their type does not
+// matter.  Unlike Expect(len(ch)), HaveChannelLen reads the length every time it is polled:
+//
+//	Eventually(queue).Should(HaveChannelLen(BeNumerically(">=", 10)))
+func HaveChannelLen(expected interface{}) types.GomegaMatcher {
+	return &matchers.HaveChannelLenMatcher{
+		Expected: expected,
+	}
+}
+
+// HaveChannelCap succeeds if the buffer capacity of actual, which must be a channel, satisfies expected.  expected can
+// be a number or a matcher; numbers are compared with BeNumerically("==", ...) so their type does not matter.
+//
+//	Expect(queue).Should(HaveChannelCap(BeNumerically(">", 0)))
+func HaveChannelCap(expected interface{}) types.GomegaMatcher {
+	return &matchers.HaveChannelCapMatcher{
+		Expected: expected,
+	}
+}
+
+// HaveContextValue succeeds if actual is a context.Context whose value for key satisfies expected.  expected can be
+// a value, which is compared with Equal, or a matcher.  A context that has no value for key - i.e. whose Value(key)
+// returns nil - never matches.
+//
+// Use it to test middleware that decorates contexts:
+//
+//	Expect(req.Context()).Should(HaveContextValue(userKey{}, HaveField("Name", "alice")))
+func HaveContextValue(key interface{}, expected interface{}) types.GomegaMatcher {
+	return &matchers.HaveContextValueMatcher{
+		Key:      key,
+		Expected: expected,
+	}
+}
+
+// HaveDeadlineWithin succeeds if actual is a context.Context with a deadline that is at most d from now.  Deadlines
+// that have already passed are within d.  A context without a deadline never matches.
+//
+//	Expect(ctx).Should(HaveDeadlineWithin(5 * time.Second))
+func HaveDeadlineWithin(d time.Duration) types.GomegaMatcher {
+	return &matchers.HaveDeadlineWithinMatcher{
+		Duration: d,
+	}
+}
+
+// BeClosedReader succeeds if actual is an io.Reader - such as a net.Conn or an *os.File - that has been closed.
+//
+// BeClosedReader performs a zero-length read on actual, which does not consume any data but fails with
+// net.ErrClosed, os.ErrClosed or io.ErrClosedPipe if actual has been closed.  It is an error for the read to fail
+// in any other way.  Note that zero-length reads on the connections returned by net.Pipe, and on io.Pipe readers,
+// block until the other end writes - don't use BeClosedReader with those.
+func BeClosedReader() types.GomegaMatcher {
+	return &matchers.BeClosedReaderMatcher{}
+}
+
 // Receive succeeds if there is a value to be received on actual.
 // Actual must be a channel (and cannot be a send-only channel) -- anything else is an error.
 //
@@ -155,14 +336,55 @@ func BeClosed() types.GomegaMatcher {
 //	Eventually(thingChan).Should(Receive(&myThing))
 //	Expect(myThing.Sprocket).Should(Equal("foo"))
 //	Expect(myThing.IsValid()).Should(BeTrue())
+//
+// You can pass a matcher after the pointer - the value is then only assigned once it satisfies the matcher:
+//
+//	Eventually(thingChan).Should(Receive(&myThing, HaveField("Sprocket", "foo")))
+//
+// If the pointer points to a slice of the channel's element type, Receive instead appends every value it can receive
+// without blocking to the slice, and matches the slice.  So, to wait for three values:
+//
+//	var things []thing
+//	Eventually(thingChan).Should(Receive(&things, HaveLen(3)))
 func Receive(args ...interface{}) types.GomegaMatcher {
 	var arg interface{}
 	if len(args) > 0 {
 		arg = args[0]
 	}
+	var matcher types.GomegaMatcher
+	if len(args) > 1 {
+		var ok bool
+		matcher, ok = args[1].(types.GomegaMatcher)
+		if len(args) > 2 || !ok {
+			panic("Receive takes at most two arguments: a pointer and a matcher")
+		}
+	}
 
 	return &matchers.ReceiveMatcher{
-		Arg: arg,
+		Arg:     arg,
+		Matcher: matcher,
+	}
+}
+
+// ReceiveN succeeds once n values have been received from actual, which must be a channel (and cannot be a send-only
+// channel).  Like Receive, ReceiveN never blocks - but the values received by each attempt of an Eventually count
+// towards n, so it is typically used to wait for n values:
+//
+//	Eventually(c).Should(ReceiveN(3))
+//
+// If passed a matcher, ReceiveN applies it to the slice of the n values received:
+//
+//	Eventually(c).Should(ReceiveN(3, ConsistOf("a", "b", "c")))
+//
+// ReceiveN remembers the values it has received, so use a new ReceiveN for each assertion.
+func ReceiveN(n int, optionalMatcher ...types.GomegaMatcher) types.GomegaMatcher {
+	var matcher types.GomegaMatcher
+	if len(optionalMatcher) > 0 {
+		matcher = optionalMatcher[0]
+	}
+	return &matchers.ReceiveNMatcher{
+		Count:   n,
+		Matcher: matcher,
 	}
 }
 
@@ -194,6 +416,23 @@ func MatchRegexp(regexp string, args ...interface{}) types.GomegaMatcher {
 	}
 }
 
+// MatchRegexpWithCaptures succeeds if actual is a string or stringer that matches the passed-in regexp
+// and the values captured by the regexp's named groups satisfy the passed-in matchers.  Captures maps
+// group names to matchers, so gstruct.Keys can be used to build it:
+//
+//	Expect(line).Should(MatchRegexpWithCaptures(`id=(?P<id>\d+) status=(?P<status>\w+)`, Keys{
+//		"id":     Equal("42"),
+//		"status": BeElementOf("ok", "degraded"),
+//	}))
+//
+// Only the first match in actual is considered.  Groups without a matcher are not checked.
+func MatchRegexpWithCaptures(regexp string, captures map[interface{}]types.GomegaMatcher) types.GomegaMatcher {
+	return &matchers.MatchRegexpWithCapturesMatcher{
+		Regexp:   regexp,
+		Captures: captures,
+	}
+}
+
 // ContainSubstring succeeds if actual is a string or stringer that contains the
 // passed-in substring.  Optional arguments can be provided to construct the substring
 // via fmt.Sprintf().
@@ -204,6 +443,19 @@ func ContainSubstring(substr string, args ...interface{}) types.GomegaMatcher {
 	}
 }
 
+// ContainSubstringTimes succeeds if actual is a string or stringer that contains the passed-in
+// substring a number of times that satisfies count.  Occurrences are counted without overlap, as
+// with strings.Count.  count can be a number or a matcher:
+//
+//	Expect(log).Should(ContainSubstringTimes("WARN", 1))
+//	Expect(log).Should(ContainSubstringTimes("retrying", BeNumerically("<=", 3)))
+func ContainSubstringTimes(substr string, count interface{}) types.GomegaMatcher {
+	return &matchers.ContainSubstringTimesMatcher{
+		Substr: substr,
+		Count:  count,
+	}
+}
+
 // HavePrefix succeeds if actual is a string or stringer that contains the
 // passed-in string as a prefix.  Optional arguments can be provided to construct
 // via fmt.Sprintf().
@@ -224,6 +476,17 @@ func HaveSuffix(suffix string, args ...interface{}) types.GomegaMatcher {
 	}
 }
 
+// EqualLines succeeds if actual is a string or stringer equal to expected.  Unlike Equal, EqualLines
+// reports a failure as a unified diff of the two texts, which makes multi-line comparisons - against
+// golden files, for example - much easier to read:
+//
+//	Expect(rendered).Should(EqualLines(string(golden)))
+func EqualLines(expected string) types.GomegaMatcher {
+	return &matchers.EqualLinesMatcher{
+		Expected: expected,
+	}
+}
+
 // MatchJSON succeeds if actual is a string or stringer of JSON that matches
 // the expected JSON.  The JSONs are decoded and the resulting objects are compared via
 // reflect.DeepEqual so things like key-ordering and whitespace shouldn't matter.
@@ -251,11 +514,91 @@ func MatchYAML(yaml interface{}) types.GomegaMatcher {
 	}
 }
 
+// MatchGoldenFile succeeds if actual matches the contents of the golden file at path.  Strings, []byte and
+// stringers are compared as is; any other value is serialized to indented JSON first.  Mismatches are reported
+// as a unified diff against the golden file.
+//
+// When the UPDATE_GOLDEN environment variable is set, MatchGoldenFile writes actual to the golden file
+// (creating it and its directory if necessary) and succeeds:
+//
+//	Expect(renderReport(data)).To(MatchGoldenFile("testdata/report.golden"))
+//
+//	$ UPDATE_GOLDEN=1 go test ./...
+func MatchGoldenFile(path string) types.GomegaMatcher {
+	return &matchers.MatchGoldenFileMatcher{
+		Path: path,
+	}
+}
+
+// MatchAfterApplyingPatch succeeds if actual - a patch - applied to base yields a document that satisfies the
+// passed-in matcher.  It lets you test code that produces patches by what they do rather than by their raw bytes:
+//
+//	Expect(patch).To(MatchAfterApplyingPatch(`{"replicas": 1, "paused": true}`, `{"replicas": 3, "paused": true}`))
+//	Expect(patch).To(MatchAfterApplyingPatch(deployment, ContainSubstring(`"replicas": 3`)))
+//
+// A patch that is a JSON array is applied as an RFC 6902 JSON Patch.  Any other patch is applied as an RFC 7386
+// JSON Merge Patch, honoring the $patch, $retainKeys and $deleteFromPrimitiveList directives of Kubernetes'
+// strategic merge patches.  Lists are replaced wholesale, as Gomega can't know their merge keys.
+//
+// base and actual can be strings, stringers, or []byte of JSON, or any other value to marshal as JSON.  The patched
+// document is passed to the matcher as a string of indented JSON.  If the matcher is not a matcher it is compared
+// against the patched document with MatchJSON.  MatchAfterApplyingPatch errors if the patch cannot be applied.
+func MatchAfterApplyingPatch(base interface{}, matcher interface{}) types.GomegaMatcher {
+	return &matchers.MatchAfterApplyingPatchMatcher{
+		Base:     base,
+		Expected: matcher,
+	}
+}
+
+// BeAValidJSONPatchFor succeeds if actual is a patch that applies cleanly to base: every path a JSON Patch
+// operation refers to exists, and every "test" operation passes.  Patches are interpreted as by MatchAfterApplyingPatch.
+//
+//	Expect(patch).To(BeAValidJSONPatchFor(deployment))
+func BeAValidJSONPatchFor(base interface{}) types.GomegaMatcher {
+	return &matchers.BeAValidJSONPatchForMatcher{
+		Base: base,
+	}
+}
+
+// HaveChecksum succeeds if actual - a string, stringer, []byte, or io.Reader - has the passed-in hex-encoded
+// checksum.  Supported algorithms are "md5", "sha1", "sha256", "sha512", and "crc32" (IEEE).  Failure messages
+// report the checksums and the size of the content rather than the content itself, which makes HaveChecksum
+// well-suited to verifying downloads and build artifacts:
+//
+//	Expect(os.ReadFile("dist/app.tar.gz")).Should(HaveChecksum("sha256", "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"))
+//
+// io.Readers are read to the end.
+func HaveChecksum(algorithm string, checksum string) types.GomegaMatcher {
+	return &matchers.HaveChecksumMatcher{
+		Algorithm: algorithm,
+		Checksum:  checksum,
+	}
+}
+
+// HashEqual succeeds if actual and expected - each a string, stringer, []byte, or io.Reader - have the same
+// content.  The contents are compared by their SHA-256 checksums and, unlike Equal, the failure message reports
+// checksums and sizes rather than the (potentially very large) contents themselves:
+//
+//	Expect(downloaded).Should(HashEqual(original))
+//
+// io.Readers are read to the end.
+func HashEqual(expected interface{}) types.GomegaMatcher {
+	return &matchers.HashEqualMatcher{
+		Expected: expected,
+	}
+}
+
 // BeEmpty succeeds if actual is empty.  Actual must be of type string, array, map, chan, or slice.
 func BeEmpty() types.GomegaMatcher {
 	return &matchers.BeEmptyMatcher{}
 }
 
+// BeEmptyOrNil succeeds if actual is nil or empty.  Unlike BeEmpty, it accepts a nil pointer or a nil interface.
+// Otherwise actual must be of type string, array, map, chan, or slice.
+func BeEmptyOrNil() types.GomegaMatcher {
+	return &matchers.BeEmptyOrNilMatcher{}
+}
+
 // HaveLen succeeds if actual has the passed-in length.  Actual must be of type string, array, map, chan, or slice.
 func HaveLen(count int) types.GomegaMatcher {
 	return &matchers.HaveLenMatcher{
@@ -275,6 +618,14 @@ func BeZero() types.GomegaMatcher {
 	return &matchers.BeZeroMatcher{}
 }
 
+// BeNonZero succeeds if actual is neither nil nor the zero value for its type.  It is the opposite of BeZero.
+// When it is negated and fails on a struct, the failure message names the first non-zero field:
+//
+//	Expect(config).ShouldNot(BeNonZero())
+func BeNonZero() types.GomegaMatcher {
+	return &matchers.BeNonZeroMatcher{}
+}
+
 // ContainElement succeeds if actual contains the passed in element. By default
 // ContainElement() uses Equal() to perform the match, however a matcher can be
 // passed in instead:
@@ -300,7 +651,8 @@ func ContainElement(element interface{}, result ...interface{}) types.GomegaMatc
 }
 
 // BeElementOf succeeds if actual is contained in the passed in elements.
-// BeElementOf() always uses Equal() to perform the match.
+// Elements that are matchers are used to match actual directly, all other elements are compared with actual
+// using Equal().
 // When the passed in elements are comprised of a single element that is either an Array or Slice, BeElementOf() behaves
 // as the reverse of ContainElement() that operates with Equal() to perform the match.
 //
@@ -311,6 +663,13 @@ func ContainElement(element interface{}, result ...interface{}) types.GomegaMatc
 //
 //	Expect(2).Should(BeElementOf(1, 2))
 //
+// When the single element is a function that takes no arguments and returns an array or slice (and, optionally, an
+// error) BeElementOf() calls it every time it matches.  This lets Eventually poll a collection that changes over time:
+//
+//	Eventually(newID).Should(BeElementOf(client.ListIDs))
+//
+// When actual is a string or a number and is not an element, the failure message suggests the nearest elements.
+//
 // Actual must be typed.
 func BeElementOf(elements ...interface{}) types.GomegaMatcher {
 	return &matchers.BeElementOfMatcher{
@@ -318,16 +677,46 @@ func BeElementOf(elements ...interface{}) types.GomegaMatcher {
 	}
 }
 
+// BeOneOf succeeds if actual matches at least one of the passed in candidates.
+// Candidates that are matchers are used to match actual directly, all other candidates are compared
+// with actual using Equal():
+//
+//	Expect(status).Should(BeOneOf("pending", "running", HavePrefix("succeeded")))
+//
+// Unlike BeElementOf, BeOneOf does not unpack a single slice or array candidate - use BeElementOf when
+// the candidates are already in a collection.
+func BeOneOf(candidates ...interface{}) types.GomegaMatcher {
+	return &matchers.BeOneOfMatcher{
+		Elements: candidates,
+	}
+}
+
 // BeKeyOf succeeds if actual is contained in the keys of the passed in map.
-// BeKeyOf() always uses Equal() to perform the match between actual and the map keys.
+// Keys that are matchers are used to match actual directly, all other keys are compared with actual using Equal().
+// As with BeElementOf, failure messages suggest the nearest keys.
 //
 //	Expect("foo").Should(BeKeyOf(map[string]bool{"foo": true, "bar": false}))
+//
+// As with BeElementOf, the map can be provided by a function that takes no arguments and returns a map (and,
+// optionally, an error); it is called every time BeKeyOf matches.
 func BeKeyOf(element interface{}) types.GomegaMatcher {
 	return &matchers.BeKeyOfMatcher{
 		Map: element,
 	}
 }
 
+// BeValueOf succeeds if actual is contained in the values of the passed in map.
+// Values that are matchers are used to match actual directly, all other values are compared with actual using Equal().
+//
+//	Expect("red").Should(BeValueOf(map[string]string{"apple": "red", "banana": "yellow"}))
+//
+// As with BeKeyOf, the map can be provided by a function, and failure messages suggest the nearest values.
+func BeValueOf(m interface{}) types.GomegaMatcher {
+	return &matchers.BeValueOfMatcher{
+		Map: m,
+	}
+}
+
 // ConsistOf succeeds if actual contains precisely the elements passed into the matcher.  The ordering of the elements does not matter.
 // By default ConsistOf() uses Equal() to match the elements, however custom matchers can be passed in instead.  Here are some examples:
 //
@@ -392,6 +781,46 @@ func HaveEach(element interface{}) types.GomegaMatcher {
 	}
 }
 
+// HaveEachWithIndex succeeds if every element of actual satisfies the matcher that matcherFor returns for its index:
+//
+//	Expect([]int{0, 2, 4}).Should(HaveEachWithIndex(func(i int) types.GomegaMatcher { return Equal(i * 2) }))
+//
+// When it fails, the failure messages of every element that did not match are reported.
+// Actual must be a non-empty array or slice.
+func HaveEachWithIndex(matcherFor func(index int) types.GomegaMatcher) types.GomegaMatcher {
+	return &matchers.HaveEachWithIndexMatcher{
+		MatcherFor: matcherFor,
+	}
+}
+
+// HaveAtLeast succeeds if at least count elements of actual match the passed in element.
+// By default HaveAtLeast() uses Equal() to perform the match, however a matcher can be passed in instead:
+//
+//	Expect([]string{"Foo", "FooBar", "Bar"}).Should(HaveAtLeast(2, ContainSubstring("Foo")))
+//
+// When it fails, the number of elements that matched and the elements that did not are reported.
+// Actual must be an array, slice or map.  For maps, HaveAtLeast counts the map's values.
+func HaveAtLeast(count int, element interface{}) types.GomegaMatcher {
+	return &matchers.HaveAtLeastMatcher{
+		Count:   count,
+		Element: element,
+	}
+}
+
+// HaveAtMost succeeds if no more than count elements of actual match the passed in element.
+// By default HaveAtMost() uses Equal() to perform the match, however a matcher can be passed in instead:
+//
+//	Expect([]string{"Foo", "FooBar", "Bar"}).Should(HaveAtMost(1, ContainSubstring("Bar")))
+//
+// When it fails, the number of elements that matched and the elements that matched are reported.
+// Actual must be an array, slice or map.  For maps, HaveAtMost counts the map's values.
+func HaveAtMost(count int, element interface{}) types.GomegaMatcher {
+	return &matchers.HaveAtMostMatcher{
+		Count:   count,
+		Element: element,
+	}
+}
+
 // HaveKey succeeds if actual is a map with the passed in key.
 // By default HaveKey uses Equal() to perform the match, however a
 // matcher can be passed in instead:
@@ -416,6 +845,24 @@ func HaveKeyWithValue(key interface{}, value interface{}) types.GomegaMatcher {
 	}
 }
 
+// HaveNestedKeyWithValue succeeds if actual is a map and the value found by following path through
+// nested maps and slices matches the passed in value.  This is particularly handy for asserting on the
+// map[string]interface{} structures produced by json.Unmarshal.
+//
+// The path can be a dotted string or a slice of keys.  Segments that traverse slices must be indices.
+// By default HaveNestedKeyWithValue uses Equal() to perform the match, however a matcher can be passed in instead:
+//
+//	Expect(decoded).Should(HaveNestedKeyWithValue("spec.containers.0.image", "nginx"))
+//	Expect(decoded).Should(HaveNestedKeyWithValue([]string{"metadata", "app.kubernetes.io/name"}, HavePrefix("web")))
+//
+// If a segment of the path cannot be found the failure message reports which segment was missing.
+func HaveNestedKeyWithValue(path interface{}, value interface{}) types.GomegaMatcher {
+	return &matchers.HaveNestedKeyWithValueMatcher{
+		Path:  path,
+		Value: value,
+	}
+}
+
 // HaveField succeeds if actual is a struct and the value at the passed in field
 // matches the passed in matcher.  By default HaveField used Equal() to perform the match,
 // however a matcher can be passed in in stead.
@@ -496,6 +943,37 @@ func BeNumerically(comparator string, compareTo ...interface{}) types.GomegaMatc
 	}
 }
 
+// BeNumericallyCloseTo succeeds if actual is a number that is within the passed in tolerance of expected.
+// Actual and expected can be any numeric type.  The failure message always reports the actual difference.
+//
+// By default the tolerance is an absolute number.  Use RelativeTolerance() or ULPTolerance() to compare
+// floating point values using relative error or units in the last place instead:
+//
+//	Expect(3.14159).Should(BeNumericallyCloseTo(math.Pi, 1e-5))
+//	Expect(avogadro).Should(BeNumericallyCloseTo(6.02214076e23, RelativeTolerance(1e-9)))
+//	Expect(math.Sqrt(2) * math.Sqrt(2)).Should(BeNumericallyCloseTo(2.0, ULPTolerance(1)))
+//
+// NaN is never close to anything.
+func BeNumericallyCloseTo(expected interface{}, tolerance interface{}) types.GomegaMatcher {
+	return &matchers.BeNumericallyCloseToMatcher{
+		Expected:  expected,
+		Tolerance: tolerance,
+	}
+}
+
+// RelativeTolerance is used with BeNumericallyCloseTo to specify a tolerance relative to the larger of the magnitudes
+// of actual and expected.  RelativeTolerance(1e-6) allows actual and expected to differ by one part in a million.
+func RelativeTolerance(fraction float64) matchers.RelativeToleranceFraction {
+	return matchers.RelativeToleranceFraction(fraction)
+}
+
+// ULPTolerance is used with BeNumericallyCloseTo to specify a tolerance in units in the last place: the number of
+// representable floating point values that may lie between actual and expected.  When actual is a float32 the
+// distance is measured between float32 values.
+func ULPTolerance(ulps uint64) matchers.ULPToleranceCount {
+	return matchers.ULPToleranceCount(ulps)
+}
+
 // BeTemporally compares time.Time's like BeNumerically
 // Actual and expected must be time.Time. The comparators are the same as for BeNumerically
 //
@@ -509,6 +987,177 @@ func BeTemporally(comparator string, compareTo time.Time, threshold ...time.Dura
 	}
 }
 
+// BeTemporallyCloseTo succeeds if actual is a time.Time within window of expected.  Unlike BeTemporally,
+// the failure message reports how far apart the two times were.
+//
+// BeTemporallyCloseTo compares wall clock instants: monotonic clock readings are ignored and the locations
+// of the two times make no difference.  This makes BeTemporallyCloseTo(t, 0) a handy way to assert that
+// two times represent the same instant when Equal is too strict:
+//
+//	Expect(record.CreatedAt).Should(BeTemporallyCloseTo(time.Now(), time.Second))
+//	Expect(parsed).Should(BeTemporallyCloseTo(original.UTC(), 0))
+func BeTemporallyCloseTo(expected time.Time, window time.Duration) types.GomegaMatcher {
+	return &matchers.BeTemporallyCloseToMatcher{
+		Expected: expected,
+		Window:   window,
+	}
+}
+
+// BeBetweenTimes succeeds if actual is a time.Time that falls between start and end, inclusive.
+// Like BeTemporallyCloseTo, it compares wall clock instants and ignores monotonic clock readings and locations.
+//
+//	before := time.Now()
+//	record := Create()
+//	Expect(record.CreatedAt).Should(BeBetweenTimes(before, time.Now()))
+func BeBetweenTimes(start, end time.Time) types.GomegaMatcher {
+	return &matchers.BeBetweenTimesMatcher{
+		Start: start,
+		End:   end,
+	}
+}
+
+// BeInLocation succeeds if actual is a time.Time whose Location has the same name as the passed-in location.
+// It is useful for asserting that an API returns UTC times, or preserves the location of the times it is given:
+//
+//	Expect(record.CreatedAt).Should(BeInLocation(time.UTC))
+//
+// The failure message reports both the expected and the actual location.
+func BeInLocation(location *time.Location) types.GomegaMatcher {
+	return &matchers.BeInLocationMatcher{
+		Location: location,
+	}
+}
+
+// HaveTimezone succeeds if actual is a time.Time whose time zone at that instant has the passed-in abbreviated
+// name (as returned by time.Time.Zone).  You can optionally pass an offset from UTC that the zone must also have:
+//
+//	Expect(t).Should(HaveTimezone("UTC"))
+//	Expect(t).Should(HaveTimezone("EST", -5*time.Hour))
+//
+// The failure message reports both the expected and the actual zone.
+func HaveTimezone(name string, offset ...time.Duration) types.GomegaMatcher {
+	return &matchers.HaveTimezoneMatcher{
+		Name:   name,
+		Offset: offset,
+	}
+}
+
+// BeDurationApproximately succeeds if actual is a time.Duration within tolerance of expected.  Both actual and
+// expected may be given as time.Durations or as duration strings that time.ParseDuration understands.  The tolerance
+// may be a time.Duration, a duration string, or a percentage of expected:
+//
+//	Expect(latency).Should(BeDurationApproximately("1.5s", "10%"))
+//	Expect(latency).Should(BeDurationApproximately(time.Second, "50ms"))
+//
+// The failure message reports how much longer or shorter than expected actual was.
+func BeDurationApproximately(expected interface{}, tolerance interface{}) types.GomegaMatcher {
+	return &matchers.BeDurationApproximatelyMatcher{
+		Expected:  expected,
+		Tolerance: tolerance,
+	}
+}
+
+// HaveStatistics computes basic statistics (count, min, max, mean, median, standard deviation, and
+// the 90th, 95th and 99th percentiles) over actual - an array or slice of numbers or time.Durations -
+// and succeeds if the resulting matchers.Statistics struct satisfies the passed-in matcher:
+//
+//	Expect(latencies).Should(HaveStatistics(And(
+//		HaveField("Mean", BeNumerically("<", 5*time.Millisecond)),
+//		HaveField("P99", BeNumerically("<", 20*time.Millisecond)),
+//	)))
+//
+// gstruct.HaveStats offers a more compact syntax built on gstruct.Fields.
+func HaveStatistics(matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &matchers.HaveStatisticsMatcher{
+		Matcher: matcher,
+	}
+}
+
+// BeMonotonicallyIncreasing succeeds if actual is an array or slice whose elements never decrease.
+// Elements may be numbers (including time.Durations) or time.Times.  Empty and single-element
+// collections are trivially monotonic.  On failure the first violating pair is reported.
+//
+//	Expect([]int{1, 2, 2, 3}).Should(BeMonotonicallyIncreasing())
+//	Expect(timestamps).Should(BeMonotonicallyIncreasing())
+func BeMonotonicallyIncreasing() types.GomegaMatcher {
+	return &matchers.BeMonotonicMatcher{}
+}
+
+// BeMonotonicallyDecreasing succeeds if actual is an array or slice whose elements never increase.
+// See BeMonotonicallyIncreasing for details.
+func BeMonotonicallyDecreasing() types.GomegaMatcher {
+	return &matchers.BeMonotonicMatcher{Decreasing: true}
+}
+
+// BeStrictlyIncreasing succeeds if actual is an array or slice in which each element is
+// greater than the one before it.  See BeMonotonicallyIncreasing for details.
+//
+//	Expect([]int{1, 2, 3}).Should(BeStrictlyIncreasing())
+//	Expect([]int{1, 2, 2}).ShouldNot(BeStrictlyIncreasing())
+func BeStrictlyIncreasing() types.GomegaMatcher {
+	return &matchers.BeMonotonicMatcher{Strict: true}
+}
+
+// BeStrictlyDecreasing succeeds if actual is an array or slice in which each element is
+// less than the one before it.  See BeMonotonicallyIncreasing for details.
+func BeStrictlyDecreasing() types.GomegaMatcher {
+	return &matchers.BeMonotonicMatcher{Decreasing: true, Strict: true}
+}
+
+// ImplementInterface succeeds if the type of actual implements the interface I.  It fails if actual is nil.
+// On failure it lists the methods of I that actual is missing:
+//
+//	Expect(reader).Should(ImplementInterface[io.Closer]())
+//
+// ImplementInterface returns an error if I is not an interface type.
+func ImplementInterface[I any]() types.GomegaMatcher {
+	return &matchers.ImplementInterfaceMatcher{
+		Interface: reflect.TypeOf((*I)(nil)).Elem(),
+	}
+}
+
+// BeOfType succeeds if the dynamic type of actual is exactly T:
+//
+//	Expect(err).Should(BeOfType[*fs.PathError]())
+//
+// BeOfType returns an error if T is an interface type - use ImplementInterface instead.
+func BeOfType[T any]() types.GomegaMatcher {
+	return &matchers.BeOfTypeMatcher{
+		Type: reflect.TypeOf((*T)(nil)).Elem(),
+	}
+}
+
+// HaveMethod succeeds if the type of actual - or actual itself, if it is a reflect.Type - has an exported method
+// called name.  An optional signature constrains the method's type, without its receiver.  The signature can be
+// a string, a matcher that is passed the signature as a string, or a function (or the reflect.Type of one) whose type
+// the method must have:
+//
+//	Expect(plugin).Should(HaveMethod("Init"))
+//	Expect(plugin).Should(HaveMethod("Close", "func() error"))
+//	Expect(plugin).Should(HaveMethod("Serve", HavePrefix("func(context.Context")))
+//	Expect(plugin).Should(HaveMethod("Handle", (func(string) error)(nil)))
+func HaveMethod(name string, signature ...interface{}) types.GomegaMatcher {
+	return &matchers.HaveMethodMatcher{
+		Name:      name,
+		Signature: signature,
+	}
+}
+
+// HaveStructTag succeeds if actual - a struct, a pointer to a struct, or the reflect.Type of either - has a field
+// whose tag has the given key.  field can be a dot-separated path to a field of a nested struct.  An optional value
+// constrains the tag's value.  It can be a string, which the value must equal, or a matcher that is passed the value:
+//
+//	Expect(User{}).Should(HaveStructTag("Name", "json", "name,omitempty"))
+//	Expect(User{}).Should(HaveStructTag("Address.Zip", "json", HavePrefix("zip")))
+//	Expect(User{}).ShouldNot(HaveStructTag("Password", "json"))
+func HaveStructTag(field string, key string, value ...interface{}) types.GomegaMatcher {
+	return &matchers.HaveStructTagMatcher{
+		Field: field,
+		Key:   key,
+		Value: value,
+	}
+}
+
 // BeAssignableToTypeOf succeeds if actual is assignable to the type of expected.
 // It will return an error when one of the values is nil.
 //
@@ -535,16 +1184,41 @@ func Panic() types.GomegaMatcher {
 // matcher can be passed in instead:
 //
 //	Expect(fn).Should(PanicWith(MatchRegexp(`.+Foo$`)))
+//
+// To match panics by type - such as the runtime.Error raised by a write to a nil map - pass a pointer to an interface:
+//
+//	Expect(fn).Should(PanicWith(new(runtime.Error)))
 func PanicWith(expected interface{}) types.GomegaMatcher {
 	return &matchers.PanicMatcher{Expected: expected}
 }
 
+// PanicWithStack behaves like PanicWith but also captures the stack trace of the panicking goroutine and includes it
+// in failure messages.  Pass nil to accept any panic:
+//
+//	Expect(fn).ShouldNot(PanicWithStack(nil))
+func PanicWithStack(expected interface{}) types.GomegaMatcher {
+	return &matchers.PanicMatcher{Expected: expected, CaptureStack: true}
+}
+
 // BeAnExistingFile succeeds if a file exists.
 // Actual must be a string representing the abs path to the file being checked.
 func BeAnExistingFile() types.GomegaMatcher {
 	return &matchers.BeAnExistingFileMatcher{}
 }
 
+// BeAnExistingCommand succeeds if a command can be run.
+// Actual must be a string: either the name of a command, which must be an executable file in one of the directories
+// on the PATH, or the path of an executable file.  Commands are looked up with exec.LookPath:
+//
+//	BeforeEach(func() {
+//		Expect("docker").To(BeAnExistingCommand(), "these specs require docker")
+//	})
+//
+// On failure the matcher reports why the command could not be found.
+func BeAnExistingCommand() types.GomegaMatcher {
+	return &matchers.BeAnExistingCommandMatcher{}
+}
+
 // BeARegularFile succeeds if a file exists and is a regular file.
 // Actual must be a string representing the abs path to the file being checked.
 func BeARegularFile() types.GomegaMatcher {
@@ -579,6 +1253,21 @@ func HaveHTTPHeaderWithValue(header string, value interface{}) types.GomegaMatch
 	}
 }
 
+// HaveHTTPCookie succeeds if actual - an *http.Response or *httptest.ResponseRecorder - sets the named cookie.
+// If passed a matcher, HaveHTTPCookie also matches the *http.Cookie against it:
+//
+//	Expect(resp).Should(HaveHTTPCookie("session", HaveField("HttpOnly", BeTrue())))
+//
+// On failure, all the cookies the response sets are listed.  gstruct.HaveCookie offers a more compact syntax for
+// asserting on the cookie's attributes, built on gstruct.Fields.
+func HaveHTTPCookie(name string, optionalMatcher ...types.GomegaMatcher) types.GomegaMatcher {
+	matcher := &matchers.HaveHTTPCookieMatcher{Name: name}
+	if len(optionalMatcher) > 0 {
+		matcher.Matcher = optionalMatcher[0]
+	}
+	return matcher
+}
+
 // HaveHTTPBody matches if the body matches.
 // Actual must be either a *http.Response or *httptest.ResponseRecorder.
 // Expected must be either a string, []byte, or other matcher
@@ -586,6 +1275,247 @@ func HaveHTTPBody(expected interface{}) types.GomegaMatcher {
 	return &matchers.HaveHTTPBodyMatcher{Expected: expected}
 }
 
+// MatchURL succeeds if actual - a string, url.URL or *url.URL - is the same URL as expected.  Rather than comparing
+// strings, MatchURL compares the URLs component by component: the scheme and host are compared case-insensitively and
+// without default ports, the path and fragment are compared percent-decoded, and query parameters may come in any
+// order (though a parameter's values must come in the same order):
+//
+//	Expect("HTTPS://Example.com:443/a%20b?y=2&x=1").Should(MatchURL("https://example.com/a b?x=1&y=2"))
+//
+// On failure every component that differs is listed.
+func MatchURL(expected string) types.GomegaMatcher {
+	return &matchers.MatchURLMatcher{Expected: expected}
+}
+
+// HaveURLField succeeds if a component of actual - a string, url.URL or *url.URL - matches expected.  field is one of
+// Scheme, User, Host, Hostname, Port, Path, Query or Fragment.  Query is the url.Values parsed from the query string,
+// all the others are strings (the Path and Fragment percent-decoded).  expected can be a value, compared with Equal,
+// or a matcher:
+//
+//	Expect(redirect).Should(HaveURLField("Path", HavePrefix("/login")))
+//	Expect(redirect).Should(HaveURLField("Query", HaveKeyWithValue("next", ConsistOf("/settings"))))
+func HaveURLField(field string, expected interface{}) types.GomegaMatcher {
+	return &matchers.HaveURLFieldMatcher{Field: field, Expected: expected}
+}
+
+// BeAValidIP succeeds if actual is a valid IPv4 or IPv6 address.  Actual must be a string, net.IP, *net.IPAddr or
+// netip.Addr.
+func BeAValidIP() types.GomegaMatcher {
+	return &matchers.BeAValidIPMatcher{}
+}
+
+// BeInCIDR succeeds if actual is an IP address in the passed in CIDR block.  Actual must be a valid IP address, as a
+// string, net.IP, *net.IPAddr or netip.Addr.  IPv4-mapped IPv6 addresses match IPv4 blocks:
+//
+//	Expect(pod.Status.PodIP).Should(BeInCIDR("10.0.0.0/8"))
+func BeInCIDR(cidr string) types.GomegaMatcher {
+	return &matchers.BeInCIDRMatcher{CIDR: cidr}
+}
+
+// BeAValidPort succeeds if actual is an integer, or a string holding an integer, between 1 and 65535.
+func BeAValidPort() types.GomegaMatcher {
+	return &matchers.BeAValidPortMatcher{}
+}
+
+// ResolveTo succeeds if actual is a host name that resolves to at least one address matching expected.  The
+// addresses are strings - as returned by net.Resolver.LookupHost - so expected can be a string, compared with Equal,
+// or a matcher such as BeInCIDR:
+//
+//	Eventually("api.internal.example.com").Should(ResolveTo(BeInCIDR("10.0.0.0/8")))
+//
+// A host that fails to resolve does not resolve to anything, so Eventually keeps polling and ShouldNot passes.
+func ResolveTo(expected interface{}) types.GomegaMatcher {
+	return &matchers.ResolveToMatcher{Expected: expected}
+}
+
+// HaveSubjectCN succeeds if the subject common name of a certificate matches expected, which can be a string or a
+// matcher.  Actual must be an *x509.Certificate or a tls.ConnectionState, whose leaf peer certificate is checked:
+//
+//	Expect(resp.TLS).To(HaveSubjectCN("api.example.com"))
+func HaveSubjectCN(expected interface{}) types.GomegaMatcher {
+	return &matchers.HaveSubjectCNMatcher{Expected: expected}
+}
+
+// BeValidAt succeeds if a certificate is valid at time t - that is, if t lies between its NotBefore and NotAfter.
+// Actual must be an *x509.Certificate or a tls.ConnectionState, whose leaf peer certificate is checked:
+//
+//	Expect(cert).To(BeValidAt(time.Now().Add(30 * 24 * time.Hour)), "the certificate expires within 30 days")
+func BeValidAt(t time.Time) types.GomegaMatcher {
+	return &matchers.BeValidAtMatcher{Time: t}
+}
+
+// BeSignedBy succeeds if a certificate chains up to one of the certificates in roots.  Actual must be an
+// *x509.Certificate or a tls.ConnectionState, whose leaf peer certificate is checked using the other certificates the
+// peer presented as intermediates.
+//
+// BeSignedBy only asserts on who signed the certificate: it ignores key usages, and verifies the chain as of the time
+// the certificate became valid (its NotBefore) rather than now.  So the certificate may have expired since, but the
+// intermediates and the root must have been valid when it was issued.
+func BeSignedBy(roots *x509.CertPool) types.GomegaMatcher {
+	return &matchers.BeSignedByMatcher{Roots: roots}
+}
+
+// HaveDNSNames succeeds if the DNS subject alternative names of a certificate match expected, which can be a
+// []string or a matcher.  Actual must be an *x509.Certificate or a tls.ConnectionState, whose leaf peer certificate is
+// checked:
+//
+//	Expect(cert).To(HaveDNSNames(ConsistOf("example.com", "www.example.com")))
+func HaveDNSNames(expected interface{}) types.GomegaMatcher {
+	return &matchers.HaveDNSNamesMatcher{Expected: expected}
+}
+
+// JWTClaims maps the names of JWT claims to the values - or matchers - BeAValidJWT expects them to have
+type JWTClaims map[string]interface{}
+
+// BeAValidJWT succeeds if actual - a string or []byte - is a JSON Web Token, in compact serialization, whose signature
+// is verified by key and which has neither expired (exp) nor is not yet valid (nbf).  key is either:
+//   - a []byte secret for HS256/384/512 tokens, an *rsa.PublicKey for RS256/384/512 and PS256/384/512 tokens, an
+//     *ecdsa.PublicKey for ES256/384/512 tokens or an ed25519.PublicKey for EdDSA tokens (private keys work too)
+//   - a func(header map[string]interface{}) (interface{}, error) that returns the key for the token's header, for
+//     example by its kid
+//
+// BeAValidJWT can also be passed JWTClaims.  Each claim must then be present and match its value or matcher.  Values
+// are compared with the claim as JSON decodes it, so numbers are compared as float64s.  The exp, nbf and iat claims are
+// decoded into time.Times, so they can be compared with a time.Time or matched with BeTemporally:
+//
+//	Expect(token).To(BeAValidJWT(publicKey, JWTClaims{
+//		"sub": "user-42",
+//		"aud": ContainElement("api"),
+//		"exp": BeTemporally("~", time.Now().Add(time.Hour), time.Minute),
+//	}))
+//
+// On failure BeAValidJWT reports why the token is not valid and its decoded claims.
+func BeAValidJWT(key interface{}, claims ...JWTClaims) types.GomegaMatcher {
+	matcher := &matchers.BeAValidJWTMatcher{Key: key}
+	if len(claims) > 1 {
+		panic("BeAValidJWT accepts at most one JWTClaims")
+	}
+	if len(claims) == 1 {
+		matcher.Claims = claims[0]
+	}
+	return matcher
+}
+
+// BeSemVer succeeds if actual is a string holding a semantic version, as specified by https://semver.org, optionally
+// prefixed with a "v" - such as "1.4.3", "v2.0.0-rc.1" or "1.0.0+build.5".
+func BeSemVer() types.GomegaMatcher {
+	return &matchers.BeSemVerMatcher{}
+}
+
+// BeSemVerConstraint succeeds if actual is a string holding a semantic version that satisfies constraint:
+//
+//	Expect(version).To(BeSemVerConstraint(">= 1.2.0, < 2"))
+//
+// A constraint is made up of comparisons separated by "," - all of which must be satisfied - and "||" - either side of
+// which must be.  The operators are =, !=, >, >=, <, <=, ~ (patch-level changes: "~1.2.3" is ">= 1.2.3, < 1.3.0") and
+// ^ (changes that keep the left-most non-zero number: "^1.2.3" is ">= 1.2.3, < 2.0.0").  A version without an operator
+// must be equal.  Versions in a constraint can omit their minor and patch numbers, in which case they stand for
+// every version that starts with them: "= 1.2" is satisfied by "1.2.7", and "> 1.2" is not.
+//
+// Versions are ordered by semantic version precedence, so "1.3.0-rc.1" satisfies ">= 1.2.0" but not ">= 1.3.0".
+func BeSemVerConstraint(constraint string) types.GomegaMatcher {
+	return &matchers.BeSemVerConstraintMatcher{Constraint: constraint}
+}
+
+// BeNewerVersionThan succeeds if actual is a string holding a semantic version that takes precedence over version:
+//
+//	Expect("1.10.0").To(BeNewerVersionThan("1.9.2"))
+//	Expect("2.0.0").To(BeNewerVersionThan("2.0.0-rc.2"))
+//
+// Build metadata is ignored, so "1.4.3+build.2" is not newer than "1.4.3".
+func BeNewerVersionThan(version string) types.GomegaMatcher {
+	return &matchers.BeNewerVersionThanMatcher{Version: version}
+}
+
+// BeAUUID succeeds if actual is a UUID: either a string or []byte in the canonical 8-4-4-4-12 hexadecimal digit form,
+// such as "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", or a [16]byte - including array types such as uuid.UUID.
+// BeAUUID can be passed the versions the UUID may have, in which case it must also have the RFC 4122 variant:
+//
+//	Expect(order.ID).To(BeAUUID(4))
+//	Expect(event.ID).To(BeAUUID(4, 7))
+//
+// On failure BeAUUID reports which part of actual is malformed.
+func BeAUUID(versions ...int) types.GomegaMatcher {
+	return &matchers.BeAUUIDMatcher{Versions: versions}
+}
+
+// BeAULID succeeds if actual is a string or []byte holding a ULID: 26 case-insensitive Crockford base32 characters,
+// such as "01ARZ3NDEKTSV4RRFFQ69G5FAV".  On failure BeAULID reports which part of actual is malformed.
+func BeAULID() types.GomegaMatcher {
+	return &matchers.BeAULIDMatcher{}
+}
+
+// BeBase64Encoding succeeds if actual - a string or []byte - is base64 encoded.  Standard and URL-safe base64 are both
+// accepted, with or without padding.  If passed a matcher, BeBase64Encoding also matches the decoded []byte against it:
+//
+//	Expect(secret.Data["token"]).To(BeBase64Encoding(Equal([]byte("hunter2"))))
+//	Expect(payload).To(BeBase64Encoding(MatchJSON(`{"id": 42}`)))
+func BeBase64Encoding(optionalMatcher ...types.GomegaMatcher) types.GomegaMatcher {
+	matcher := &matchers.BeBase64EncodingMatcher{}
+	if len(optionalMatcher) > 0 {
+		matcher.Matcher = optionalMatcher[0]
+	}
+	return matcher
+}
+
+// BeHexEncoding succeeds if actual - a string or []byte - is hex encoded, in either case.  If passed a matcher,
+// BeHexEncoding also matches the decoded []byte against it:
+//
+//	Expect(digest).To(BeHexEncoding(HaveLen(32)))
+func BeHexEncoding(optionalMatcher ...types.GomegaMatcher) types.GomegaMatcher {
+	matcher := &matchers.BeHexEncodingMatcher{}
+	if len(optionalMatcher) > 0 {
+		matcher.Matcher = optionalMatcher[0]
+	}
+	return matcher
+}
+
+// BeGzippedContaining succeeds if actual is gzip compressed content whose decompressed []byte matches matcher.
+// Actual must be a []byte, string, io.Reader, *http.Response or *httptest.ResponseRecorder - the content of readers and
+// response bodies is read, and cached by the matcher:
+//
+//	Expect(resp).To(BeGzippedContaining(MatchJSON(`{"status": "ok"}`)))
+func BeGzippedContaining(matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &matchers.BeCompressedContainingMatcher{Format: "gzip", Matcher: matcher}
+}
+
+// BeDeflatedContaining succeeds if actual is deflate compressed content whose decompressed []byte matches matcher.
+// As with the HTTP deflate content encoding the content can be zlib wrapped or raw.  Actual can be anything that
+// BeGzippedContaining accepts.
+func BeDeflatedContaining(matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &matchers.BeCompressedContainingMatcher{Format: "deflate", Matcher: matcher}
+}
+
+// BeCompressedContaining succeeds if actual is compressed content whose decompressed []byte matches matcher, for
+// compression formats that Gomega does not support itself.  decompress wraps a reader of the compressed content in
+// a reader of the decompressed content and format names the format in failure messages.  For example, with
+// github.com/klauspost/compress/zstd:
+//
+//	decompressZstd := func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) }
+//	Expect(artifact).To(BeCompressedContaining("zstd", decompressZstd, ContainSubstring("manifest")))
+//
+// Actual can be anything that BeGzippedContaining accepts.
+func BeCompressedContaining(format string, decompress func(io.Reader) (io.Reader, error), matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &matchers.BeCompressedContainingMatcher{Format: format, Decompress: decompress, Matcher: matcher}
+}
+
+// HaveEnvironmentVariable succeeds if an environment sets the variable called name.
+// Actual must be an *exec.Cmd - whose Env is checked, or the current process's environment if Env is nil - or
+// a []string of key=value pairs such as the one returned by os.Environ().
+// An optional value can be a string, or another matcher that the variable's value must satisfy:
+//
+//	Expect(cmd).To(HaveEnvironmentVariable("HOME"))
+//	Expect(cmd).To(HaveEnvironmentVariable("GOFLAGS", "-mod=mod"))
+//	Expect(os.Environ()).To(HaveEnvironmentVariable("PATH", ContainSubstring("/usr/local/bin")))
+//
+// When a variable is set more than once the last value wins, as it does when an *exec.Cmd starts.
+func HaveEnvironmentVariable(name string, value ...interface{}) types.GomegaMatcher {
+	return &matchers.HaveEnvironmentVariableMatcher{
+		Name:  name,
+		Value: value,
+	}
+}
+
 // And succeeds only if all of the given matchers succeed.
 // The matchers are tried in order, and will fail-fast if one doesn't succeed.
 //
@@ -620,6 +1550,17 @@ func SatisfyAny(matchers ...types.GomegaMatcher) types.GomegaMatcher {
 	return Or(matchers...)
 }
 
+// Named labels matcher with a description of the clause it represents.  It succeeds and fails with matcher, but its
+// failure messages name the clause - so that when a large And() or SatisfyAll() fails it is clear which clause did:
+//
+//	Expect(deployment).To(SatisfyAll(
+//		Named("has ready replicas", HaveField("Status.ReadyReplicas", BeNumerically(">", 0))),
+//		Named("runs the new image", HaveField("Spec.Image", Equal(image))),
+//	))
+func Named(name string, matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &matchers.NamedMatcher{Name: name, Matcher: matcher}
+}
+
 // Not negates the given matcher; it succeeds if the given matcher fails.
 //
 //	Expect(1).To(Not(Equal(2))
@@ -645,6 +1586,21 @@ func WithTransform(transform interface{}, matcher types.GomegaMatcher) types.Gom
 	return matchers.NewWithTransformMatcher(transform, matcher)
 }
 
+// WithTransforms passes the actual value through a pipeline of transforms and matches the result against the matcher,
+// which must come last.  Each transform takes the value returned by the one before it and must satisfy the same rules
+// as WithTransform's.  This reads in the order the transforms are applied, unlike nested WithTransform calls:
+//
+//	Expect(resp).To(WithTransforms(readBody, parseOrder, getTotal, BeNumerically(">", 100)))
+//
+// is equivalent to
+//
+//	Expect(resp).To(WithTransform(readBody, WithTransform(parseOrder, WithTransform(getTotal, BeNumerically(">", 100)))))
+//
+// WithTransforms panics if the output of a transform can never be passed to the next one.
+func WithTransforms(transformsAndMatcher ...interface{}) types.GomegaMatcher {
+	return matchers.NewWithTransformsMatcher(transformsAndMatcher...)
+}
+
 // Satisfy matches the actual value against the `predicate` function.
 // The given predicate must be a function of one paramter that returns bool.
 //
@@ -27,11 +27,30 @@ func (m *AndMatcher) Match(actual interface{}) (success bool, err error) {
 }
 
 func (m *AndMatcher) FailureMessage(actual interface{}) (message string) {
-	return m.firstFailedMatcher.FailureMessage(actual)
+	return types.FailureMessageFor(m.firstFailedMatcher, actual, true)
 }
 
 func (m *AndMatcher) NegatedFailureMessage(actual interface{}) (message string) {
-	// not the most beautiful list of matchers, but not bad either...
+	// every matcher matched - and not(A and B) is (not A) or (not B)
+	switch len(m.Matchers) {
+	case 0:
+		return format.Message(actual, "to fail at least one matcher, but And was passed none")
+	case 1:
+		return types.FailureMessageFor(m.Matchers[0], actual, false)
+	}
+	outcomes := make([]branchOutcome, len(m.Matchers))
+	for i := range outcomes {
+		outcomes[i] = branchMatched
+	}
+	summary := fmt.Sprintf("Expected at least one of And's %d matchers not to match, but all of them did:", len(m.Matchers))
+	return branchesFailureMessage(summary, m.Matchers, outcomes, actual)
+}
+
+// LegacyFailureMessage makes AndMatcher a types.LegacyFailureMessager
+func (m *AndMatcher) LegacyFailureMessage(actual interface{}, desiredMatch bool) string {
+	if desiredMatch {
+		return types.LegacyFailureMessageFor(m.firstFailedMatcher, actual, true)
+	}
 	return format.Message(actual, fmt.Sprintf("To not satisfy all of these matchers: %s", m.Matchers))
 }
 
@@ -57,9 +57,48 @@ var _ = Describe("AndMatcher", func() {
 
 		When("match succeeds, but expected it to fail", func() {
 			It("gives a descriptive message", func() {
-				verifyFailureMessage(Not(And(true1, true2)), input,
-					`To not satisfy all of these matchers: [%!s(*matchers.HaveLenMatcher=&{2}) %!s(*matchers.EqualMatcher=&{hi})]`)
+				m := Not(And(true1, true2))
+				Expect(m.Match(input)).To(BeFalse())
+				Expect(m.FailureMessage(input)).To(Equal("Expected at least one of And's 2 matchers not to match, but all of them did:\n" +
+					"  matcher 1 matched:\n    Expected\n        <string>: hi\n    not to have length 2\n" +
+					"  matcher 2 matched:\n    Expected\n        <string>: hi\n    not to equal\n        <string>: hi"))
 			})
+
+			It("names the clauses that matched", func() {
+				m := Not(And(Named("is short", true1), true2))
+				Expect(m.Match(input)).To(BeFalse())
+				Expect(m.FailureMessage(input)).To(ContainSubstring("  matcher 1 matched:\n    Clause \"is short\" satisfied matcher, but should not have.\n"))
+			})
+
+			It("reports a single matcher's message as is", func() {
+				verifyFailureMessage(Not(And(true1)), input, "not to have length 2")
+			})
+
+			It("explains that And was passed no matchers", func() {
+				verifyFailureMessage(Not(And()), input, "to fail at least one matcher, but And was passed none")
+			})
+		})
+
+		When("negated more than once", func() {
+			It("keeps the polarity of the message", func() {
+				verifyFailureMessage(Not(Not(And(true1, false2))), input, "to equal\n    <string>: hip")
+				verifyFailureMessage(Not(Not(Not(And(true1)))), input, "not to have length 2")
+				verifyFailureMessage(And(Not(Not(false1))), input, "to have length 1")
+			})
+		})
+	})
+
+	Context("legacy failure messages", func() {
+		It("describes all of its matchers when it should have failed", func() {
+			m := And(true1, true2)
+			Expect(m.Match(input)).To(BeTrue())
+			Expect(m.(*AndMatcher).LegacyFailureMessage(input, false)).To(HavePrefix("Expected\n    <string>: hi\nTo not satisfy all of these matchers: [%!s(*matchers.HaveLenMatcher="))
+		})
+
+		It("reports the legacy message of the first matcher that failed", func() {
+			m := And(true1, Or(false1, false2))
+			Expect(m.Match(input)).To(BeFalse())
+			Expect(m.(*AndMatcher).LegacyFailureMessage(input, true)).To(HavePrefix("Expected\n    <string>: hi\nTo satisfy at least one of these matchers: ["))
 		})
 	})
 
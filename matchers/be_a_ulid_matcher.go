@@ -0,0 +1,50 @@
+package matchers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+// crockfordBase32 is the alphabet ULIDs are encoded with.  It leaves out I, L, O and U.
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+type BeAULIDMatcher struct {
+	// state
+	problem string
+}
+
+func (matcher *BeAULIDMatcher) Match(actual interface{}) (success bool, err error) {
+	s, ok := toString(actual)
+	if !ok {
+		return false, fmt.Errorf("BeAULID matcher expects a string or []byte.  Got:\n%s", format.Object(actual, 1))
+	}
+	matcher.problem = ulidFormatProblem(s)
+	return matcher.problem == "", nil
+}
+
+func (matcher *BeAULIDMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to be a ULID, but "+matcher.problem)
+}
+
+func (matcher *BeAULIDMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to be a ULID")
+}
+
+// ulidFormatProblem describes what is wrong with s as a ULID - 26 case-insensitive Crockford base32 characters, of
+// which the first 10 hold a 48-bit timestamp - or returns "" if nothing is
+func ulidFormatProblem(s string) string {
+	if len(s) != 26 {
+		return fmt.Sprintf("it is %d characters long, expected 26", len(s))
+	}
+	for i := 0; i < len(s); i++ {
+		if !strings.ContainsRune(crockfordBase32, rune(s[i])) && !strings.ContainsRune(strings.ToLower(crockfordBase32), rune(s[i])) {
+			return fmt.Sprintf("character %d is %q, expected a Crockford base32 digit", i+1, s[i])
+		}
+	}
+	if s[0] > '7' {
+		return fmt.Sprintf("its timestamp overflows 48 bits: character 1 is %q, expected 0-7", s[0])
+	}
+	return ""
+}
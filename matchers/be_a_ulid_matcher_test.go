@@ -0,0 +1,43 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeAULID", func() {
+	It("should succeed for ULIDs", func() {
+		Expect("01ARZ3NDEKTSV4RRFFQ69G5FAV").Should(BeAULID())
+		Expect("01arz3ndektsv4rrffq69g5fav").Should(BeAULID())
+		Expect("7ZZZZZZZZZZZZZZZZZZZZZZZZZ").Should(BeAULID())
+		Expect([]byte("01ARZ3NDEKTSV4RRFFQ69G5FAV")).Should(BeAULID())
+	})
+
+	It("should fail for malformed ULIDs", func() {
+		Expect("").ShouldNot(BeAULID())
+		Expect("01ARZ3NDEKTSV4RRFFQ69G5FA").ShouldNot(BeAULID())
+		Expect("01ARZ3NDEKTUV4RRFFQ69G5FAV").ShouldNot(BeAULID())
+		Expect("01ARZ3NDEKTSV4RRFFQ69G5FA-").ShouldNot(BeAULID())
+		Expect("81ARZ3NDEKTSV4RRFFQ69G5FAV").ShouldNot(BeAULID())
+	})
+
+	It("should error when actual is not a string", func() {
+		_, err := BeAULID().Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("BeAULID matcher expects a string or []byte.  Got:")))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect("01ARZ3NDEKTSV4RRFFQ69G5FA").Should(BeAULID())
+			Expect("01ARZ3NDEKTUV4RRFFQ69G5FAV").Should(BeAULID())
+			Expect("81ARZ3NDEKTSV4RRFFQ69G5FAV").Should(BeAULID())
+			Expect("01ARZ3NDEKTSV4RRFFQ69G5FAV").ShouldNot(BeAULID())
+		})
+		Expect(failures).Should(Equal([]string{
+			"Expected\n    <string>: 01ARZ3NDEKTSV4RRFFQ69G5FA\nto be a ULID, but it is 25 characters long, expected 26",
+			"Expected\n    <string>: 01ARZ3NDEKTUV4RRFFQ69G5FAV\nto be a ULID, but character 12 is 'U', expected a Crockford base32 digit",
+			"Expected\n    <string>: 81ARZ3NDEKTSV4RRFFQ69G5FAV\nto be a ULID, but its timestamp overflows 48 bits: character 1 is '8', expected 0-7",
+			"Expected\n    <string>: 01ARZ3NDEKTSV4RRFFQ69G5FAV\nnot to be a ULID",
+		}))
+	})
+})
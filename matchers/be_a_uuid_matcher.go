@@ -0,0 +1,117 @@
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeAUUIDMatcher struct {
+	// Versions, if any, are the UUID versions actual may have.  UUIDs of a given version must also have the RFC 4122
+	// variant.
+	Versions []int
+
+	// state
+	problem string
+}
+
+func (matcher *BeAUUIDMatcher) Match(actual interface{}) (success bool, err error) {
+	var version, variant byte
+	if s, ok := toString(actual); ok {
+		matcher.problem = uuidFormatProblem(s)
+		if matcher.problem != "" {
+			return false, nil
+		}
+		version, variant = fromHexDigit(s[14]), fromHexDigit(s[19])
+	} else if bytes, ok := uuidBytes(actual); ok {
+		version, variant = bytes[6]>>4, bytes[8]>>4
+	} else {
+		return false, fmt.Errorf("BeAUUID matcher expects a string, []byte or [16]byte.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	matcher.problem = ""
+	if len(matcher.Versions) == 0 {
+		return true, nil
+	}
+	if variant&0xc != 0x8 {
+		matcher.problem = fmt.Sprintf("its variant is not RFC 4122: its variant digit is %x, expected one of 8, 9, a or b", variant)
+		return false, nil
+	}
+	for _, v := range matcher.Versions {
+		if int(version) == v {
+			return true, nil
+		}
+	}
+	matcher.problem = fmt.Sprintf("it is a version %d UUID", version)
+	return false, nil
+}
+
+func (matcher *BeAUUIDMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("to be a %s, but %s", matcher.description(), matcher.problem))
+}
+
+func (matcher *BeAUUIDMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("not to be a %s", matcher.description()))
+}
+
+func (matcher *BeAUUIDMatcher) description() string {
+	if len(matcher.Versions) == 0 {
+		return "UUID"
+	}
+	versions := make([]string, len(matcher.Versions))
+	for i, v := range matcher.Versions {
+		versions[i] = fmt.Sprint(v)
+	}
+	return fmt.Sprintf("version %s UUID", strings.Join(versions, " or "))
+}
+
+// uuidFormatProblem describes what is wrong with s as a UUID in its canonical, 8-4-4-4-12 hexadecimal digit form, or
+// returns "" if nothing is
+func uuidFormatProblem(s string) string {
+	if len(s) != 36 {
+		return fmt.Sprintf("it is %d characters long, expected 36", len(s))
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return fmt.Sprintf("character %d is %q, expected '-'", i+1, s[i])
+			}
+		default:
+			if !isHexDigit(s[i]) {
+				return fmt.Sprintf("character %d is %q, expected a hexadecimal digit", i+1, s[i])
+			}
+		}
+	}
+	return ""
+}
+
+// uuidBytes returns actual's bytes if it is a [16]byte, or an array type based on one such as uuid.UUID
+func uuidBytes(actual interface{}) ([16]byte, bool) {
+	value := reflect.ValueOf(actual)
+	if !value.IsValid() || value.Kind() != reflect.Array || value.Len() != 16 || value.Type().Elem().Kind() != reflect.Uint8 {
+		return [16]byte{}, false
+	}
+	var bytes [16]byte
+	for i := range bytes {
+		bytes[i] = byte(value.Index(i).Uint())
+	}
+	return bytes, true
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func fromHexDigit(c byte) byte {
+	switch {
+	case c >= 'a':
+		return c - 'a' + 10
+	case c >= 'A':
+		return c - 'A' + 10
+	default:
+		return c - '0'
+	}
+}
@@ -0,0 +1,71 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type fakeUUID [16]byte
+
+var _ = Describe("BeAUUID", func() {
+	const v1 = "f81d4fae-7dec-11d0-a765-00a0c91e6bf6"
+	const v4 = "9B2C5D7E-3F4A-4B8C-9D0E-1F2A3B4C5D6E"
+	const v7 = "018f1b2c-3d4e-7f60-8a1b-2c3d4e5f6a7b"
+
+	It("should succeed for UUIDs in canonical form", func() {
+		Expect(v1).Should(BeAUUID())
+		Expect(v4).Should(BeAUUID())
+		Expect([]byte(v7)).Should(BeAUUID())
+		Expect("00000000-0000-0000-0000-000000000000").Should(BeAUUID())
+	})
+
+	It("should fail for malformed UUIDs", func() {
+		Expect("").ShouldNot(BeAUUID())
+		Expect("f81d4fae7dec11d0a76500a0c91e6bf6").ShouldNot(BeAUUID())
+		Expect("{f81d4fae-7dec-11d0-a765-00a0c91e6bf6}").ShouldNot(BeAUUID())
+		Expect("f81d4fae_7dec-11d0-a765-00a0c91e6bf6").ShouldNot(BeAUUID())
+		Expect("f81d4fae-7dec-11d0-a765-00a0c91e6bfg").ShouldNot(BeAUUID())
+	})
+
+	It("should check the version and variant when passed versions", func() {
+		Expect(v1).Should(BeAUUID(1))
+		Expect(v4).Should(BeAUUID(4))
+		Expect(v7).Should(BeAUUID(4, 7))
+		Expect(v1).ShouldNot(BeAUUID(4))
+		Expect("9b2c5d7e-3f4a-4b8c-cd0e-1f2a3b4c5d6e").ShouldNot(BeAUUID(4))
+		Expect("00000000-0000-0000-0000-000000000000").ShouldNot(BeAUUID(4))
+	})
+
+	It("should accept [16]byte arrays", func() {
+		id := fakeUUID{0x9b, 0x2c, 0x5d, 0x7e, 0x3f, 0x4a, 0x4b, 0x8c, 0x9d, 0x0e, 0x1f, 0x2a, 0x3b, 0x4c, 0x5d, 0x6e}
+		Expect(id).Should(BeAUUID())
+		Expect(id).Should(BeAUUID(4))
+		Expect([16]byte(id)).ShouldNot(BeAUUID(7))
+	})
+
+	It("should error when actual is not a string or [16]byte", func() {
+		_, err := BeAUUID().Match([15]byte{})
+		Expect(err).Should(MatchError(ContainSubstring("BeAUUID matcher expects a string, []byte or [16]byte.  Got:")))
+		_, err = BeAUUID().Match(nil)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect("f81d4fae7dec11d0a76500a0c91e6bf6").Should(BeAUUID())
+			Expect("f81d4fae_7dec-11d0-a765-00a0c91e6bf6").Should(BeAUUID())
+			Expect("f81d4fae-7dec-11d0-a765-00a0c91e6bfg").Should(BeAUUID())
+			Expect(v1).Should(BeAUUID(4, 7))
+			Expect("9b2c5d7e-3f4a-4b8c-cd0e-1f2a3b4c5d6e").Should(BeAUUID(4))
+			Expect(v4).ShouldNot(BeAUUID(4))
+		})
+		Expect(failures).Should(Equal([]string{
+			"Expected\n    <string>: f81d4fae7dec11d0a76500a0c91e6bf6\nto be a UUID, but it is 32 characters long, expected 36",
+			"Expected\n    <string>: f81d4fae_7dec-11d0-a765-00a0c91e6bf6\nto be a UUID, but character 9 is '_', expected '-'",
+			"Expected\n    <string>: f81d4fae-7dec-11d0-a765-00a0c91e6bfg\nto be a UUID, but character 36 is 'g', expected a hexadecimal digit",
+			"Expected\n    <string>: " + v1 + "\nto be a version 4 or 7 UUID, but it is a version 1 UUID",
+			"Expected\n    <string>: 9b2c5d7e-3f4a-4b8c-cd0e-1f2a3b4c5d6e\nto be a version 4 UUID, but its variant is not RFC 4122: its variant digit is c, expected one of 8, 9, a or b",
+			"Expected\n    <string>: " + v4 + "\nnot to be a version 4 UUID",
+		}))
+	})
+})
@@ -0,0 +1,26 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeAValidIPMatcher struct {
+}
+
+func (matcher *BeAValidIPMatcher) Match(actual interface{}) (success bool, err error) {
+	_, ok, err := toAddr("BeAValidIP", actual)
+	if !ok {
+		return false, fmt.Errorf("BeAValidIP matcher expects a string, net.IP, *net.IPAddr or netip.Addr.  Got:\n%s", format.Object(actual, 1))
+	}
+	return err == nil, nil
+}
+
+func (matcher *BeAValidIPMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to be a valid IP address")
+}
+
+func (matcher *BeAValidIPMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to be a valid IP address")
+}
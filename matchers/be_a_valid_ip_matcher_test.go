@@ -0,0 +1,38 @@
+package matchers_test
+
+import (
+	"net"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("BeAValidIP", func() {
+	It("should succeed for valid IP addresses", func() {
+		Expect("10.1.2.3").Should(BeAValidIP())
+		Expect("fd00::1").Should(BeAValidIP())
+		Expect(net.ParseIP("192.168.0.1")).Should(BeAValidIP())
+		Expect(&net.IPAddr{IP: net.ParseIP("::1")}).Should(BeAValidIP())
+		Expect(netip.MustParseAddr("127.0.0.1")).Should(BeAValidIP())
+	})
+
+	It("should fail for invalid IP addresses", func() {
+		Expect("10.1.2").ShouldNot(BeAValidIP())
+		Expect("example.com").ShouldNot(BeAValidIP())
+		Expect(net.IP{1, 2, 3}).ShouldNot(BeAValidIP())
+		Expect(netip.Addr{}).ShouldNot(BeAValidIP())
+	})
+
+	It("should error for other types", func() {
+		success, err := (&BeAValidIPMatcher{}).Match(3)
+		Expect(success).Should(BeFalse())
+		Expect(err).Should(MatchError(ContainSubstring("BeAValidIP matcher expects a string, net.IP, *net.IPAddr or netip.Addr.")))
+	})
+
+	It("should build failure messages", func() {
+		Expect(BeAValidIP().FailureMessage("10.1.2")).Should(Equal("Expected\n    <string>: 10.1.2\nto be a valid IP address"))
+		Expect(BeAValidIP().NegatedFailureMessage("10.1.2.3")).Should(Equal("Expected\n    <string>: 10.1.2.3\nnot to be a valid IP address"))
+	})
+})
@@ -0,0 +1,35 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeAValidJSONPatchForMatcher struct {
+	Base interface{}
+
+	// state
+	applyErr error
+}
+
+func (matcher *BeAValidJSONPatchForMatcher) Match(actual interface{}) (success bool, err error) {
+	base, err := decodeJSONDocument("BeAValidJSONPatchFor", "the base", matcher.Base)
+	if err != nil {
+		return false, err
+	}
+	patch, err := decodeJSONDocument("BeAValidJSONPatchFor", "a patch", actual)
+	if err != nil {
+		return false, err
+	}
+	_, matcher.applyErr = applyPatch(base, patch)
+	return matcher.applyErr == nil, nil
+}
+
+func (matcher *BeAValidJSONPatchForMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("%s\nbut it could not be applied: %s", format.Message(actual, "to be a valid patch for", matcher.Base), matcher.applyErr)
+}
+
+func (matcher *BeAValidJSONPatchForMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to be a valid patch for", matcher.Base)
+}
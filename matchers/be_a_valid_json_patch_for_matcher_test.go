@@ -0,0 +1,41 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeAValidJSONPatchFor", func() {
+	base := `{"name": "web", "ports": [80]}`
+
+	It("should succeed when the patch applies cleanly", func() {
+		Expect(`[{"op": "add", "path": "/ports/1", "value": 443}, {"op": "test", "path": "/name", "value": "web"}]`).Should(BeAValidJSONPatchFor(base))
+		Expect(`{"name": "api"}`).Should(BeAValidJSONPatchFor(base))
+	})
+
+	It("should fail when the patch cannot be applied", func() {
+		Expect(`[{"op": "replace", "path": "/missing", "value": 1}]`).ShouldNot(BeAValidJSONPatchFor(base))
+		Expect(`[{"op": "add", "path": "/ports/5", "value": 443}]`).ShouldNot(BeAValidJSONPatchFor(base))
+		Expect(`[{"op": "add", "path": "/ports/01", "value": 443}]`).ShouldNot(BeAValidJSONPatchFor(base))
+		Expect(`[{"op": "test", "path": "/name", "value": "api"}]`).ShouldNot(BeAValidJSONPatchFor(base))
+		Expect(`[{"op": "move", "from": "/ports", "path": "/ports/0"}]`).ShouldNot(BeAValidJSONPatchFor(base))
+		Expect(`[{"op": "remove", "path": ""}]`).ShouldNot(BeAValidJSONPatchFor(base))
+		Expect(`[{"op": "frobnicate", "path": "/name"}]`).ShouldNot(BeAValidJSONPatchFor(base))
+		Expect(`[{"op": "add", "path": "name", "value": 1}]`).ShouldNot(BeAValidJSONPatchFor(base))
+		Expect(`[{"op": "add", "path": "/name"}]`).ShouldNot(BeAValidJSONPatchFor(base))
+		Expect(`{"$patch": "shuffle"}`).ShouldNot(BeAValidJSONPatchFor(base))
+	})
+
+	It("should explain why the patch is invalid", func() {
+		patch := `[{"op": "test", "path": "/name", "value": "web"}, {"op": "remove", "path": "/ports/1"}]`
+		m := BeAValidJSONPatchFor(base)
+		Expect(m.Match(patch)).Should(BeFalse())
+		Expect(m.FailureMessage(patch)).Should(HaveSuffix(`but it could not be applied: operation 1: /ports/1: invalid index "1" into an array of length 1`))
+		Expect(m.NegatedFailureMessage(patch)).Should(ContainSubstring("not to be a valid patch for"))
+	})
+
+	It("should error when the patch is not JSON", func() {
+		_, err := BeAValidJSONPatchFor(base).Match(`[{"op":`)
+		Expect(err).Should(MatchError(ContainSubstring("BeAValidJSONPatchFor matcher expects a patch to be valid JSON")))
+	})
+})
@@ -0,0 +1,170 @@
+package matchers
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega/format"
+)
+
+// jwtNumericDateClaims are the registered claims that hold a time, which BeAValidJWT decodes into a time.Time
+var jwtNumericDateClaims = map[string]bool{"exp": true, "nbf": true, "iat": true}
+
+type BeAValidJWTMatcher struct {
+	// Key verifies the token's signature.  It is either the key itself or a
+	// func(header map[string]interface{}) (interface{}, error) that looks up the key for the token's header.
+	Key interface{}
+	// Claims maps the names of claims to the values - or matchers - they are expected to have
+	Claims map[string]interface{}
+	// Time is the time exp and nbf are checked against, time.Now() if zero
+	Time time.Time
+
+	// state
+	claims   map[string]interface{}
+	invalid  string
+	failures []string
+}
+
+func (matcher *BeAValidJWTMatcher) Match(actual interface{}) (success bool, err error) {
+	token, ok := toString(actual)
+	if !ok {
+		return false, fmt.Errorf("BeAValidJWT matcher expects a string or []byte.  Got:\n%s", format.Object(actual, 1))
+	}
+	if matcher.Key == nil {
+		return false, fmt.Errorf("BeAValidJWT matcher expects a key, got nil")
+	}
+
+	matcher.claims, matcher.invalid, matcher.failures = nil, "", nil
+	decoded, err := decodeJWT(token)
+	if err != nil {
+		matcher.invalid = err.Error()
+		return false, nil
+	}
+	matcher.claims = decoded.claims
+	key := matcher.Key
+	if keyFunc, isKeyFunc := key.(func(map[string]interface{}) (interface{}, error)); isKeyFunc {
+		key, err = keyFunc(decoded.header)
+		if err != nil {
+			matcher.invalid = fmt.Sprintf("looking up its key failed: %s", err)
+			return false, nil
+		}
+	}
+	if err := decoded.verify(key); err != nil {
+		matcher.invalid = err.Error()
+		return false, nil
+	}
+
+	claims, err := decodeJWTClaims(decoded.claims)
+	if err != nil {
+		matcher.invalid = err.Error()
+		return false, nil
+	}
+	now := matcher.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	if exp, ok := claims["exp"].(time.Time); ok && !now.Before(exp) {
+		matcher.invalid = fmt.Sprintf("it expired at %s", exp.Format(time.RFC3339))
+		return false, nil
+	}
+	if nbf, ok := claims["nbf"].(time.Time); ok && now.Before(nbf) {
+		matcher.invalid = fmt.Sprintf("it is not valid before %s", nbf.Format(time.RFC3339))
+		return false, nil
+	}
+
+	names := make([]string, 0, len(matcher.Claims))
+	for name := range matcher.Claims {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		claim, present := claims[name]
+		if !present {
+			matcher.failures = append(matcher.failures, fmt.Sprintf("claim %q is missing", name))
+			continue
+		}
+		claimMatcher, err := jwtClaimMatcher(name, matcher.Claims[name])
+		if err != nil {
+			return false, err
+		}
+		success, err := claimMatcher.Match(claim)
+		if err != nil {
+			return false, fmt.Errorf("claim %q:\n%s", name, format.IndentString(err.Error(), 1))
+		}
+		if !success {
+			matcher.failures = append(matcher.failures, fmt.Sprintf("claim %q:\n%s", name, format.IndentString(claimMatcher.FailureMessage(claim), 1)))
+		}
+	}
+	return len(matcher.failures) == 0, nil
+}
+
+func (matcher *BeAValidJWTMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.invalid != "" {
+		message = format.Message(actual, "to be a valid JWT, but "+matcher.invalid)
+	} else {
+		message = format.Message(actual, "to be a valid JWT with matching claims, but:\n"+format.IndentString(strings.Join(matcher.failures, "\n"), 1))
+	}
+	return message + matcher.decodedClaims()
+}
+
+func (matcher *BeAValidJWTMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if len(matcher.Claims) == 0 {
+		return format.Message(actual, "not to be a valid JWT") + matcher.decodedClaims()
+	}
+	return format.Message(actual, "not to be a valid JWT with matching claims") + matcher.decodedClaims()
+}
+
+func (matcher *BeAValidJWTMatcher) decodedClaims() string {
+	if matcher.claims == nil {
+		return ""
+	}
+	claims, err := json.MarshalIndent(matcher.claims, "", "    ")
+	if err != nil {
+		return ""
+	}
+	return "\nDecoded claims:\n" + format.IndentString(string(claims), 1)
+}
+
+// decodeJWTClaims converts the NumericDate claims exp, nbf and iat into time.Times
+func decodeJWTClaims(raw map[string]interface{}) (map[string]interface{}, error) {
+	claims := make(map[string]interface{}, len(raw))
+	for name, value := range raw {
+		if jwtNumericDateClaims[name] {
+			seconds, ok := value.(float64)
+			if !ok {
+				return nil, fmt.Errorf("its %q claim is not a NumericDate: %s", name, format.Object(value, 0))
+			}
+			whole := int64(seconds)
+			value = time.Unix(whole, int64((seconds-float64(whole))*float64(time.Second))).UTC()
+		}
+		claims[name] = value
+	}
+	return claims, nil
+}
+
+// jwtClaimMatcher returns expected if it is a matcher.  Otherwise the claim is compared with expected as JSON would
+// decode it - so numbers are float64s and structs are maps - or, for NumericDate claims, with expected as a time.
+func jwtClaimMatcher(name string, expected interface{}) (omegaMatcher, error) {
+	if m, ok := expected.(omegaMatcher); ok {
+		return m, nil
+	}
+	if jwtNumericDateClaims[name] {
+		t, ok := expected.(time.Time)
+		if !ok {
+			return nil, fmt.Errorf("BeAValidJWT matcher expects the %q claim to be compared with a time.Time or a matcher.  Got:\n%s", name, format.Object(expected, 1))
+		}
+		return &BeTemporallyMatcher{Comparator: "==", CompareTo: t}, nil
+	}
+	data, err := json.Marshal(expected)
+	if err != nil {
+		return nil, fmt.Errorf("BeAValidJWT matcher expects the %q claim to be compared with a value that can be encoded as JSON.  Encoding failed with:\n%s", name, format.IndentString(err.Error(), 1))
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return &EqualMatcher{Expected: decoded}, nil
+}
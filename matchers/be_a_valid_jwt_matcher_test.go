@@ -0,0 +1,207 @@
+package matchers_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+func encodeJWTSegment(v interface{}) string {
+	data, err := json.Marshal(v)
+	Expect(err).ShouldNot(HaveOccurred())
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// signJWT returns a token for claims signed with key using alg - one of HS256, RS256, PS256, ES256 and EdDSA
+func signJWT(alg string, key interface{}, header map[string]interface{}, claims map[string]interface{}) string {
+	fullHeader := map[string]interface{}{"alg": alg, "typ": "JWT"}
+	for k, v := range header {
+		fullHeader[k] = v
+	}
+	signingInput := encodeJWTSegment(fullHeader) + "." + encodeJWTSegment(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	var signature []byte
+	var err error
+	switch alg {
+	case "HS256":
+		mac := hmac.New(sha256.New, key.([]byte))
+		mac.Write([]byte(signingInput))
+		signature = mac.Sum(nil)
+	case "RS256":
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key.(*rsa.PrivateKey), crypto.SHA256, digest[:])
+	case "PS256":
+		signature, err = rsa.SignPSS(rand.Reader, key.(*rsa.PrivateKey), crypto.SHA256, digest[:], nil)
+	case "ES256":
+		r, s, signErr := ecdsa.Sign(rand.Reader, key.(*ecdsa.PrivateKey), digest[:])
+		err = signErr
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	case "EdDSA":
+		signature = ed25519.Sign(key.(ed25519.PrivateKey), []byte(signingInput))
+	}
+	Expect(err).ShouldNot(HaveOccurred())
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+var _ = Describe("BeAValidJWT", func() {
+	secret := []byte("s3cr3t")
+	now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+	claims := map[string]interface{}{
+		"sub":   "user-42",
+		"aud":   []string{"api", "web"},
+		"admin": true,
+		"level": 3,
+		"iat":   now.Add(-time.Minute).Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}
+
+	Context("verifying signatures", func() {
+		It("should verify each supported algorithm", func() {
+			rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ShouldNot(HaveOccurred())
+			ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ShouldNot(HaveOccurred())
+			edPublicKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).ShouldNot(HaveOccurred())
+
+			Expect(signJWT("HS256", secret, nil, claims)).Should(&BeAValidJWTMatcher{Key: secret, Time: now})
+			Expect(signJWT("RS256", rsaKey, nil, claims)).Should(&BeAValidJWTMatcher{Key: &rsaKey.PublicKey, Time: now})
+			Expect(signJWT("PS256", rsaKey, nil, claims)).Should(&BeAValidJWTMatcher{Key: &rsaKey.PublicKey, Time: now})
+			Expect(signJWT("ES256", ecKey, nil, claims)).Should(&BeAValidJWTMatcher{Key: &ecKey.PublicKey, Time: now})
+			Expect(signJWT("EdDSA", edKey, nil, claims)).Should(&BeAValidJWTMatcher{Key: edPublicKey, Time: now})
+
+			By("accepting private keys in place of public keys")
+			Expect(signJWT("ES256", ecKey, nil, claims)).Should(&BeAValidJWTMatcher{Key: ecKey, Time: now})
+		})
+
+		It("should not match tokens signed with another key", func() {
+			token := signJWT("HS256", secret, nil, claims)
+			Expect(token).ShouldNot(&BeAValidJWTMatcher{Key: []byte("other"), Time: now})
+			Expect([]byte(token)).Should(&BeAValidJWTMatcher{Key: secret, Time: now})
+		})
+
+		It("should not match tokens whose algorithm does not suit the key", func() {
+			ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(signJWT("HS256", secret, nil, claims)).ShouldNot(&BeAValidJWTMatcher{Key: &ecKey.PublicKey, Time: now})
+		})
+
+		It("should not match unsigned or malformed tokens", func() {
+			unsigned := encodeJWTSegment(map[string]string{"alg": "none"}) + "." + encodeJWTSegment(claims) + "."
+			Expect(unsigned).ShouldNot(&BeAValidJWTMatcher{Key: secret, Time: now})
+			Expect("not-a-token").ShouldNot(&BeAValidJWTMatcher{Key: secret, Time: now})
+			Expect("a.b.c").ShouldNot(&BeAValidJWTMatcher{Key: secret, Time: now})
+		})
+
+		It("should look up keys with a key func", func() {
+			keys := map[string][]byte{"one": []byte("first"), "two": []byte("second")}
+			keyFunc := func(header map[string]interface{}) (interface{}, error) {
+				key, ok := keys[header["kid"].(string)]
+				if !ok {
+					return nil, errors.New("unknown kid")
+				}
+				return key, nil
+			}
+			Expect(signJWT("HS256", keys["two"], map[string]interface{}{"kid": "two"}, claims)).Should(&BeAValidJWTMatcher{Key: keyFunc, Time: now})
+			Expect(signJWT("HS256", keys["two"], map[string]interface{}{"kid": "one"}, claims)).ShouldNot(&BeAValidJWTMatcher{Key: keyFunc, Time: now})
+			Expect(signJWT("HS256", keys["two"], map[string]interface{}{"kid": "three"}, claims)).ShouldNot(&BeAValidJWTMatcher{Key: keyFunc, Time: now})
+		})
+	})
+
+	Context("checking validity times", func() {
+		It("should not match expired tokens or tokens that are not valid yet", func() {
+			token := signJWT("HS256", secret, nil, map[string]interface{}{"nbf": now.Unix(), "exp": now.Add(time.Hour).Unix()})
+			Expect(token).Should(&BeAValidJWTMatcher{Key: secret, Time: now})
+			Expect(token).ShouldNot(&BeAValidJWTMatcher{Key: secret, Time: now.Add(-time.Second)})
+			Expect(token).ShouldNot(&BeAValidJWTMatcher{Key: secret, Time: now.Add(time.Hour)})
+		})
+
+		It("should default to the current time", func() {
+			Expect(signJWT("HS256", secret, nil, map[string]interface{}{"exp": time.Now().Add(time.Hour).Unix()})).Should(BeAValidJWT(secret))
+			Expect(signJWT("HS256", secret, nil, claims)).ShouldNot(BeAValidJWT(secret))
+		})
+	})
+
+	Context("matching claims", func() {
+		token := signJWT("HS256", secret, nil, claims)
+
+		It("should compare claims with values as JSON decodes them", func() {
+			Expect(token).Should(&BeAValidJWTMatcher{Key: secret, Time: now, Claims: map[string]interface{}{
+				"sub":   "user-42",
+				"aud":   []string{"api", "web"},
+				"admin": true,
+				"level": 3,
+			}})
+			Expect(token).ShouldNot(&BeAValidJWTMatcher{Key: secret, Time: now, Claims: map[string]interface{}{"level": 4}})
+		})
+
+		It("should match claims with matchers", func() {
+			Expect(token).Should(&BeAValidJWTMatcher{Key: secret, Time: now, Claims: map[string]interface{}{
+				"sub": HavePrefix("user-"),
+				"aud": ContainElement("api"),
+			}})
+		})
+
+		It("should decode NumericDate claims into times", func() {
+			Expect(token).Should(&BeAValidJWTMatcher{Key: secret, Time: now, Claims: map[string]interface{}{
+				"iat": now.Add(-time.Minute),
+				"exp": BeTemporally("~", now.Add(time.Hour), time.Second),
+			}})
+		})
+
+		It("should not match missing claims", func() {
+			Expect(token).ShouldNot(&BeAValidJWTMatcher{Key: secret, Time: now, Claims: map[string]interface{}{"email": BeEmpty()}})
+		})
+
+		It("should error when a NumericDate claim is compared with something other than a time", func() {
+			_, err := (&BeAValidJWTMatcher{Key: secret, Time: now, Claims: map[string]interface{}{"exp": 3}}).Match(token)
+			Expect(err).Should(MatchError(ContainSubstring(`BeAValidJWT matcher expects the "exp" claim to be compared with a time.Time or a matcher.`)))
+		})
+	})
+
+	It("should error when passed something other than a string or when not passed a key", func() {
+		_, err := BeAValidJWT(secret).Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("BeAValidJWT matcher expects a string or []byte.  Got:")))
+		_, err = BeAValidJWT(nil).Match("a.b.c")
+		Expect(err).Should(MatchError("BeAValidJWT matcher expects a key, got nil"))
+	})
+
+	It("should panic when passed more than one JWTClaims", func() {
+		Expect(func() { BeAValidJWT(secret, JWTClaims{}, JWTClaims{}) }).Should(Panic())
+	})
+
+	It("should build failure messages", func() {
+		token := signJWT("HS256", secret, nil, map[string]interface{}{"sub": "user-42", "exp": now.Add(time.Hour).Unix()})
+		failures := InterceptGomegaFailures(func() {
+			Expect(token).Should(&BeAValidJWTMatcher{Key: []byte("other"), Time: now})
+			Expect(token).Should(&BeAValidJWTMatcher{Key: secret, Time: now.Add(2 * time.Hour)})
+			Expect(token).Should(&BeAValidJWTMatcher{Key: secret, Time: now, Claims: map[string]interface{}{"sub": "user-7", "email": BeEmpty()}})
+			Expect(token).ShouldNot(&BeAValidJWTMatcher{Key: secret, Time: now})
+			Expect("a.b").Should(&BeAValidJWTMatcher{Key: secret, Time: now})
+		})
+		decodedClaims := "\nDecoded claims:\n    {\n        \"exp\": 1717246800,\n        \"sub\": \"user-42\"\n    }"
+		prefix := "Expected\n    <string>: " + token + "\n"
+		Expect(failures).Should(Equal([]string{
+			prefix + "to be a valid JWT, but its signature does not match" + decodedClaims,
+			prefix + "to be a valid JWT, but it expired at 2024-06-01T13:00:00Z" + decodedClaims,
+			prefix + "to be a valid JWT with matching claims, but:\n    claim \"email\" is missing\n    claim \"sub\":\n        Expected\n            <string>: user-42\n        to equal\n            <string>: user-7" + decodedClaims,
+			prefix + "not to be a valid JWT" + decodedClaims,
+			"Expected\n    <string>: a.b\nto be a valid JWT, but it has 2 dot-separated parts, expected 3",
+		}))
+		Expect(strings.Count(failures[0], "Decoded claims")).Should(Equal(1))
+	})
+})
@@ -0,0 +1,41 @@
+package matchers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeAValidPortMatcher struct {
+}
+
+func (matcher *BeAValidPortMatcher) Match(actual interface{}) (success bool, err error) {
+	var port int64
+	switch {
+	case actual == nil:
+		return false, fmt.Errorf("BeAValidPort matcher expects an integer or a string.  Got:\n%s", format.Object(actual, 1))
+	case isInteger(actual) || isUnsignedInteger(actual):
+		if isUnsignedInteger(actual) && toUnsignedInteger(actual) > 65535 {
+			return false, nil
+		}
+		port = toInteger(actual)
+	case isString(actual):
+		s, _ := toString(actual)
+		port, err = strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return false, nil
+		}
+	default:
+		return false, fmt.Errorf("BeAValidPort matcher expects an integer or a string.  Got:\n%s", format.Object(actual, 1))
+	}
+	return 1 <= port && port <= 65535, nil
+}
+
+func (matcher *BeAValidPortMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to be a valid port (1-65535)")
+}
+
+func (matcher *BeAValidPortMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to be a valid port (1-65535)")
+}
@@ -0,0 +1,38 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("BeAValidPort", func() {
+	It("should succeed for integers between 1 and 65535", func() {
+		Expect(1).Should(BeAValidPort())
+		Expect(uint16(8080)).Should(BeAValidPort())
+		Expect(65535).Should(BeAValidPort())
+		Expect(0).ShouldNot(BeAValidPort())
+		Expect(-80).ShouldNot(BeAValidPort())
+		Expect(65536).ShouldNot(BeAValidPort())
+		Expect(uint64(1 << 63)).ShouldNot(BeAValidPort())
+	})
+
+	It("should succeed for strings holding such integers", func() {
+		Expect("443").Should(BeAValidPort())
+		Expect("0").ShouldNot(BeAValidPort())
+		Expect("http").ShouldNot(BeAValidPort())
+		Expect("").ShouldNot(BeAValidPort())
+	})
+
+	It("should error for other types", func() {
+		_, err := (&BeAValidPortMatcher{}).Match(nil)
+		Expect(err).Should(MatchError(ContainSubstring("BeAValidPort matcher expects an integer or a string.")))
+		_, err = (&BeAValidPortMatcher{}).Match(80.0)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should build failure messages", func() {
+		Expect(BeAValidPort().FailureMessage(0)).Should(Equal("Expected\n    <int>: 0\nto be a valid port (1-65535)"))
+		Expect(BeAValidPort().NegatedFailureMessage(80)).Should(Equal("Expected\n    <int>: 80\nnot to be a valid port (1-65535)"))
+	})
+})
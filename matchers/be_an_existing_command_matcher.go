@@ -0,0 +1,42 @@
+package matchers
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeAnExistingCommandMatcher struct {
+	// state
+	path        string
+	lookPathErr error
+}
+
+func (matcher *BeAnExistingCommandMatcher) Match(actual interface{}) (success bool, err error) {
+	command, ok := actual.(string)
+	if !ok || command == "" {
+		return false, fmt.Errorf("BeAnExistingCommand matcher expects the name or path of a command.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	matcher.path, matcher.lookPathErr = exec.LookPath(command)
+	return matcher.lookPathErr == nil, nil
+}
+
+func (matcher *BeAnExistingCommandMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("to be %s, but:\n%s", describeCommand(actual), format.IndentString(matcher.lookPathErr.Error(), 1)))
+}
+
+func (matcher *BeAnExistingCommandMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("not to be %s, but it is:\n%s", describeCommand(actual), format.IndentString(matcher.path, 1)))
+}
+
+// describeCommand distinguishes commands looked up on the PATH from paths to commands, which exec.LookPath checks
+// directly
+func describeCommand(actual interface{}) string {
+	if command := actual.(string); filepath.Base(command) != command {
+		return "an executable file"
+	}
+	return "an executable on the PATH"
+}
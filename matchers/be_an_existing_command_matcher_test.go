@@ -0,0 +1,86 @@
+package matchers_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/internal/gutil"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("BeAnExistingCommand", func() {
+	var binDir, executable, notExecutable string
+
+	BeforeEach(func() {
+		var err error
+		binDir, err = gutil.MkdirTemp("", "gomega-test-bin")
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(os.RemoveAll, binDir)
+
+		executable = filepath.Join(binDir, "gomega-test-tool")
+		Expect(os.WriteFile(executable, []byte("#!/bin/sh\n"), 0755)).Should(Succeed())
+		notExecutable = filepath.Join(binDir, "gomega-test-data")
+		Expect(os.WriteFile(notExecutable, []byte("data"), 0644)).Should(Succeed())
+
+		originalPath := os.Getenv("PATH")
+		os.Setenv("PATH", binDir+string(os.PathListSeparator)+originalPath)
+		DeferCleanup(os.Setenv, "PATH", originalPath)
+	})
+
+	When("passed the name of a command", func() {
+		It("succeeds if an executable with that name is on the PATH", func() {
+			Expect("gomega-test-tool").Should(BeAnExistingCommand())
+			Expect("gomega-test-dne").ShouldNot(BeAnExistingCommand())
+			Expect("gomega-test-data").ShouldNot(BeAnExistingCommand())
+		})
+	})
+
+	When("passed the path of a command", func() {
+		It("succeeds if the path is an executable file", func() {
+			Expect(executable).Should(BeAnExistingCommand())
+			Expect(notExecutable).ShouldNot(BeAnExistingCommand())
+			Expect(filepath.Join(binDir, "gomega-test-dne")).ShouldNot(BeAnExistingCommand())
+			Expect(binDir).ShouldNot(BeAnExistingCommand())
+		})
+	})
+
+	When("passed something else", func() {
+		It("should error", func() {
+			success, err := (&BeAnExistingCommandMatcher{}).Match(nil)
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError(ContainSubstring("BeAnExistingCommand matcher expects the name or path of a command.")))
+
+			success, err = (&BeAnExistingCommandMatcher{}).Match("")
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("failure messages", func() {
+		It("explains why a command on the PATH couldn't be found", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect("gomega-test-dne").Should(BeAnExistingCommand())
+			})
+			Expect(failures).Should(ConsistOf("Expected\n    <string>: gomega-test-dne\nto be an executable on the PATH, but:\n    exec: \"gomega-test-dne\": executable file not found in $PATH"))
+		})
+
+		It("explains why a path isn't a command", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(notExecutable).Should(BeAnExistingCommand())
+			})
+			Expect(failures).Should(ConsistOf(And(
+				HavePrefix("Expected\n    <string>: %s\nto be an executable file, but:\n    exec: ", notExecutable),
+				ContainSubstring("permission denied"),
+			)))
+		})
+
+		It("reports where the command was found", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect("gomega-test-tool").ShouldNot(BeAnExistingCommand())
+			})
+			Expect(failures).Should(ConsistOf("Expected\n    <string>: gomega-test-tool\nnot to be an executable on the PATH, but it is:\n    " + executable))
+		})
+	})
+})
@@ -0,0 +1,41 @@
+package matchers
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+)
+
+type BeBase64EncodingMatcher struct {
+	// Matcher, if not nil, is matched against the decoded []byte
+	Matcher types.GomegaMatcher
+
+	// state
+	content *encodedContent
+}
+
+func (matcher *BeBase64EncodingMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.content = &encodedContent{matcherName: "BeBase64Encoding", encoding: "base64", decode: decodeBase64, matcher: matcher.Matcher}
+	return matcher.content.match(actual)
+}
+
+func (matcher *BeBase64EncodingMatcher) FailureMessage(actual interface{}) (message string) {
+	return matcher.content.failureMessage(actual, true)
+}
+
+func (matcher *BeBase64EncodingMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return matcher.content.failureMessage(actual, false)
+}
+
+// decodeBase64 decodes standard or URL-safe base64, with or without padding
+func decodeBase64(s string) ([]byte, error) {
+	encoding := base64.StdEncoding
+	if strings.ContainsAny(s, "-_") {
+		encoding = base64.URLEncoding
+	}
+	if len(s)%4 != 0 && !strings.HasSuffix(s, "=") {
+		encoding = encoding.WithPadding(base64.NoPadding)
+	}
+	return encoding.DecodeString(s)
+}
@@ -0,0 +1,50 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeBase64Encoding", func() {
+	It("should succeed for base64 encoded content", func() {
+		Expect("aHVudGVyMg==").Should(BeBase64Encoding())
+		Expect([]byte("aHVudGVyMg==")).Should(BeBase64Encoding())
+		Expect("").Should(BeBase64Encoding())
+		Expect("not base64!").ShouldNot(BeBase64Encoding())
+	})
+
+	It("should accept URL-safe and unpadded base64", func() {
+		Expect("-_8=").Should(BeBase64Encoding(Equal([]byte{0xfb, 0xff})))
+		Expect("+/8=").Should(BeBase64Encoding(Equal([]byte{0xfb, 0xff})))
+		Expect("-_8").Should(BeBase64Encoding(Equal([]byte{0xfb, 0xff})))
+		Expect("aHVudGVyMg").Should(BeBase64Encoding(Equal([]byte("hunter2"))))
+	})
+
+	It("should match the decoded content", func() {
+		Expect("aHVudGVyMg==").Should(BeBase64Encoding(Equal([]byte("hunter2"))))
+		Expect("eyJpZCI6IDQyfQ==").Should(BeBase64Encoding(MatchJSON(`{"id":42}`)))
+		Expect("aHVudGVyMg==").ShouldNot(BeBase64Encoding(ContainSubstring("password")))
+	})
+
+	It("should error when actual is not a string, or when the matcher errors", func() {
+		_, err := BeBase64Encoding().Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("BeBase64Encoding matcher expects a string or []byte.  Got:")))
+		_, err = BeBase64Encoding(BeTrue()).Match("aHVudGVyMg==")
+		Expect(err).Should(MatchError(HavePrefix("Content decoded from base64:\n    Expected a boolean.")))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect("aHVu!GVyMg==").Should(BeBase64Encoding())
+			Expect("aHVudGVyMg==").Should(BeBase64Encoding(ContainSubstring("password")))
+			Expect("aHVudGVyMg==").ShouldNot(BeBase64Encoding(ContainSubstring("hunter")))
+			Expect("aHVudGVyMg==").ShouldNot(BeBase64Encoding())
+		})
+		Expect(failures).Should(Equal([]string{
+			"Expected\n    <string>: aHVu!GVyMg==\nto be base64 encoded, but decoding it failed with:\n    illegal base64 data at input byte 4",
+			"Content decoded from base64:\n    Expected\n        <[]uint8 | len:7, cap:9>: hunter2\n    to contain substring\n        <string>: password",
+			"Content decoded from base64:\n    Expected\n        <[]uint8 | len:7, cap:9>: hunter2\n    not to contain substring\n        <string>: hunter",
+			"Expected\n    <string>: aHVudGVyMg==\nnot to be base64 encoded",
+		}))
+	})
+})
@@ -0,0 +1,45 @@
+package matchers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeBetweenTimesMatcher struct {
+	Start time.Time
+	End   time.Time
+
+	// state
+	outside string
+}
+
+func (matcher *BeBetweenTimesMatcher) Match(actual interface{}) (success bool, err error) {
+	actualTime, ok := actual.(time.Time)
+	if !ok {
+		return false, fmt.Errorf("BeBetweenTimes matcher expects a time.Time.  Got:\n%s", format.Object(actual, 1))
+	}
+	if wallClockDifference(matcher.End, matcher.Start) < 0 {
+		return false, fmt.Errorf("BeBetweenTimes matcher requires the start to not be after the end.  Got:\n%s\nand\n%s", format.Object(matcher.Start, 1), format.Object(matcher.End, 1))
+	}
+
+	matcher.outside = ""
+	if difference := wallClockDifference(actualTime, matcher.Start); difference < 0 {
+		matcher.outside = describeWallClockDifference(difference, "the start")
+		return false, nil
+	}
+	if difference := wallClockDifference(actualTime, matcher.End); difference > 0 {
+		matcher.outside = describeWallClockDifference(difference, "the end")
+		return false, nil
+	}
+	return true, nil
+}
+
+func (matcher *BeBetweenTimesMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to be between", matcher.Start) + "\nand\n" + format.Object(matcher.End, 1) + "\n" + matcher.outside
+}
+
+func (matcher *BeBetweenTimesMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to be between", matcher.Start) + "\nand\n" + format.Object(matcher.End, 1)
+}
@@ -0,0 +1,52 @@
+package matchers_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeBetweenTimes", func() {
+	var start, end time.Time
+
+	BeforeEach(func() {
+		start = time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+		end = start.Add(time.Hour)
+	})
+
+	It("should succeed when actual is between start and end, inclusive", func() {
+		Expect(start).Should(BeBetweenTimes(start, end))
+		Expect(start.Add(time.Minute)).Should(BeBetweenTimes(start, end))
+		Expect(end).Should(BeBetweenTimes(start, end))
+		Expect(start.Add(-1)).ShouldNot(BeBetweenTimes(start, end))
+		Expect(end.Add(1)).ShouldNot(BeBetweenTimes(start, end))
+	})
+
+	It("should ignore locations and monotonic clock readings", func() {
+		before := time.Now()
+		now := time.Now()
+		after := time.Now()
+		Expect(now.Round(0).In(time.FixedZone("X", -5*60*60))).Should(BeBetweenTimes(before, after))
+	})
+
+	It("should report how far outside the range actual was", func() {
+		m := BeBetweenTimes(start, end)
+		Expect(m.Match(start.Add(-time.Minute))).Should(BeFalse())
+		Expect(m.FailureMessage(start.Add(-time.Minute))).Should(Equal("Expected\n    <time.Time>: 2023-03-01T11:59:00Z\nto be between\n    <time.Time>: 2023-03-01T12:00:00Z\nand\n    <time.Time>: 2023-03-01T13:00:00Z\nit was 1m0s before the start"))
+
+		Expect(m.Match(end.Add(time.Second))).Should(BeFalse())
+		Expect(m.FailureMessage(end.Add(time.Second))).Should(HaveSuffix("it was 1s after the end"))
+
+		Expect(m.Match(start)).Should(BeTrue())
+		Expect(m.NegatedFailureMessage(start)).Should(Equal("Expected\n    <time.Time>: 2023-03-01T12:00:00Z\nnot to be between\n    <time.Time>: 2023-03-01T12:00:00Z\nand\n    <time.Time>: 2023-03-01T13:00:00Z"))
+	})
+
+	It("should error on invalid input", func() {
+		_, err := BeBetweenTimes(start, end).Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("expects a time.Time")))
+
+		_, err = BeBetweenTimes(end, start).Match(start)
+		Expect(err).Should(MatchError(ContainSubstring("start to not be after the end")))
+	})
+})
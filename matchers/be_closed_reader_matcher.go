@@ -0,0 +1,48 @@
+package matchers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeClosedReaderMatcher struct {
+	// state
+	closedWith error
+}
+
+func (matcher *BeClosedReaderMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.closedWith = nil
+	reader, ok := actual.(io.Reader)
+	if !ok || isNil(actual) {
+		return false, fmt.Errorf("BeClosedReader matcher expects an io.Reader such as a net.Conn or an *os.File.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	// a zero-length read does not consume anything, but is refused by readers that have been closed
+	_, err = reader.Read([]byte{})
+	if err == nil {
+		return false, nil
+	}
+	if errors.Is(err, net.ErrClosed) || errors.Is(err, os.ErrClosed) || errors.Is(err, io.ErrClosedPipe) {
+		matcher.closedWith = err
+		return true, nil
+	}
+	return false, fmt.Errorf("BeClosedReader matcher could not tell whether the reader is closed, reading from it failed with:\n%s", format.Object(err, 1))
+}
+
+func (matcher *BeClosedReaderMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to be closed")
+}
+
+func (matcher *BeClosedReaderMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("to be open, but reading from it failed with:\n%s", format.IndentString(matcher.closedWith.Error(), 1)))
+}
+
+// MatchMayChangeInTheFuture lets Eventually and Consistently stop early: a reader that is closed stays closed
+func (matcher *BeClosedReaderMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	return matcher.closedWith == nil
+}
@@ -0,0 +1,80 @@
+package matchers_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("BeClosedReader", func() {
+	It("should succeed for closed files", func() {
+		f, err := os.Create(filepath.Join(GinkgoT().TempDir(), "file"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(f).ShouldNot(BeClosedReader())
+		Expect(f.Close()).Should(Succeed())
+		Expect(f).Should(BeClosedReader())
+	})
+
+	It("should succeed for closed network connections, without consuming data", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer listener.Close()
+
+		client, err := net.Dial("tcp", listener.Addr().String())
+		Expect(err).ShouldNot(HaveOccurred())
+		server, err := listener.Accept()
+		Expect(err).ShouldNot(HaveOccurred())
+		defer server.Close()
+
+		_, err = server.Write([]byte("hi"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(client).ShouldNot(BeClosedReader())
+		buf := make([]byte, 2)
+		_, err = client.Read(buf)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(string(buf)).Should(Equal("hi"))
+
+		Expect(client.Close()).Should(Succeed())
+		Expect(client).Should(BeClosedReader())
+	})
+
+	It("reports why it considers the reader closed", func() {
+		f, err := os.Create(filepath.Join(GinkgoT().TempDir(), "file"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(f.Close()).Should(Succeed())
+		failuresMessages := InterceptGomegaFailures(func() {
+			Expect(f).ShouldNot(BeClosedReader())
+		})
+		Expect(failuresMessages).Should(ConsistOf(MatchRegexp(`to be open, but reading from it failed with:\n    read .+: file already closed$`)))
+	})
+
+	It("tells Eventually and Consistently that closed readers stay closed", func() {
+		f, err := os.Create(filepath.Join(GinkgoT().TempDir(), "file"))
+		Expect(err).ShouldNot(HaveOccurred())
+		m := &BeClosedReaderMatcher{}
+		Expect(m.Match(f)).Should(BeFalse())
+		Expect(m.MatchMayChangeInTheFuture(f)).Should(BeTrue())
+		Expect(f.Close()).Should(Succeed())
+		Expect(m.Match(f)).Should(BeTrue())
+		Expect(m.MatchMayChangeInTheFuture(f)).Should(BeFalse())
+	})
+
+	It("should error when reading fails for another reason", func() {
+		success, err := (&BeClosedReaderMatcher{}).Match(erroringReader{})
+		Expect(success).Should(BeFalse())
+		Expect(err).Should(MatchError(ContainSubstring("BeClosedReader matcher could not tell whether the reader is closed")))
+	})
+
+	It("should error when passed something that isn't a reader", func() {
+		success, err := (&BeClosedReaderMatcher{}).Match(nil)
+		Expect(success).Should(BeFalse())
+		Expect(err).Should(MatchError("BeClosedReader matcher expects an io.Reader such as a net.Conn or an *os.File.  Got:\n    <nil>: nil"))
+
+		_, err = (&BeClosedReaderMatcher{}).Match("foo")
+		Expect(err).Should(HaveOccurred())
+	})
+})
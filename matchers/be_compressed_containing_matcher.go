@@ -0,0 +1,144 @@
+package matchers
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/internal/gutil"
+	"github.com/onsi/gomega/types"
+)
+
+type BeCompressedContainingMatcher struct {
+	// Format names the compression format.  Decompress can be nil for the formats BeCompressedContaining supports
+	// itself: "gzip" and "deflate".
+	Format     string
+	Decompress func(io.Reader) (io.Reader, error)
+	Matcher    types.GomegaMatcher
+
+	// state
+	cachedSource  interface{}
+	cachedContent []byte
+	decompressed  []byte
+	decompressErr error
+}
+
+func (matcher *BeCompressedContainingMatcher) Match(actual interface{}) (success bool, err error) {
+	decompress := matcher.Decompress
+	if decompress == nil {
+		decompress = builtinDecompressors[matcher.Format]
+	}
+	if decompress == nil {
+		return false, fmt.Errorf("BeCompressedContaining matcher expects a function that decompresses %s content, got nil", matcher.Format)
+	}
+	if matcher.Matcher == nil {
+		return false, fmt.Errorf("BeCompressedContaining matcher expects a matcher for the decompressed content, got nil")
+	}
+	content, err := matcher.content(actual)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.decompressed, matcher.decompressErr = nil, nil
+	reader, err := decompress(bytes.NewReader(content))
+	if err == nil {
+		matcher.decompressed, err = gutil.ReadAll(reader)
+		if closer, ok := reader.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+	if err != nil {
+		matcher.decompressErr = err
+		return false, nil
+	}
+
+	success, err = matcher.Matcher.Match(matcher.decompressed)
+	if err != nil {
+		return false, fmt.Errorf("Content decompressed from %s:\n%s", matcher.Format, format.IndentString(err.Error(), 1))
+	}
+	return success, nil
+}
+
+func (matcher *BeCompressedContainingMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.decompressErr != nil {
+		return fmt.Sprintf("Expected %s compressed content, but decompressing it failed with:\n%s", matcher.Format, format.IndentString(matcher.decompressErr.Error(), 1))
+	}
+	return fmt.Sprintf("Content decompressed from %s:\n%s", matcher.Format, format.IndentString(matcher.Matcher.FailureMessage(matcher.decompressed), 1))
+}
+
+func (matcher *BeCompressedContainingMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Content decompressed from %s:\n%s", matcher.Format, format.IndentString(matcher.Matcher.NegatedFailureMessage(matcher.decompressed), 1))
+}
+
+// content returns the compressed content.  Content read from a reader or a response body can only be read once, so it
+// is cached for as long as the matcher is passed the same reader or response.
+func (matcher *BeCompressedContainingMatcher) content(actual interface{}) ([]byte, error) {
+	switch a := actual.(type) {
+	case []byte:
+		return a, nil
+	case string:
+		return []byte(a), nil
+	}
+	if matcher.cachedSource != nil && actual == matcher.cachedSource {
+		return matcher.cachedContent, nil
+	}
+
+	var reader io.Reader
+	switch a := actual.(type) {
+	case *http.Response:
+		if a != nil && a.Body != nil {
+			defer a.Body.Close()
+			reader = a.Body
+		}
+	case *httptest.ResponseRecorder:
+		if a != nil {
+			reader = a.Body
+		}
+	case io.Reader:
+		reader = a
+	}
+	if reader == nil {
+		return nil, fmt.Errorf("BeCompressedContaining matcher expects a []byte, string, io.Reader, *http.Response or *httptest.ResponseRecorder.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	content, err := gutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("BeCompressedContaining matcher failed to read the compressed content:\n%s", format.IndentString(err.Error(), 1))
+	}
+	// only pointers are cached: they can be compared by identity
+	if reflect.ValueOf(actual).Kind() == reflect.Ptr {
+		matcher.cachedSource, matcher.cachedContent = actual, content
+	}
+	return content, nil
+}
+
+var builtinDecompressors = map[string]func(io.Reader) (io.Reader, error){
+	"gzip":    decompressGzip,
+	"deflate": decompressDeflate,
+}
+
+func decompressGzip(r io.Reader) (io.Reader, error) {
+	return gzip.NewReader(r)
+}
+
+// decompressDeflate decompresses deflate content, as used by the HTTP deflate content encoding: zlib wrapped, or raw if
+// it has no zlib header
+func decompressDeflate(r io.Reader) (io.Reader, error) {
+	content, err := gutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := zlib.NewReader(bytes.NewReader(content))
+	if errors.Is(err, zlib.ErrHeader) {
+		return flate.NewReader(bytes.NewReader(content)), nil
+	}
+	return reader, err
+}
@@ -0,0 +1,121 @@
+package matchers_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/lzw"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+func compress(newWriter func(io.Writer) io.WriteCloser, content string) []byte {
+	buffer := &bytes.Buffer{}
+	writer := newWriter(buffer)
+	_, err := writer.Write([]byte(content))
+	Expect(err).ShouldNot(HaveOccurred())
+	Expect(writer.Close()).Should(Succeed())
+	return buffer.Bytes()
+}
+
+func gzipped(content string) []byte {
+	return compress(func(w io.Writer) io.WriteCloser { return gzip.NewWriter(w) }, content)
+}
+
+var _ = Describe("BeCompressedContaining", func() {
+	Context("with gzip", func() {
+		It("should match the decompressed content", func() {
+			Expect(gzipped("hello world")).Should(BeGzippedContaining(Equal([]byte("hello world"))))
+			Expect(gzipped("hello world")).Should(BeGzippedContaining(ContainSubstring("world")))
+			Expect(string(gzipped("hello world"))).Should(BeGzippedContaining(ContainSubstring("world")))
+			Expect(gzipped("hello world")).ShouldNot(BeGzippedContaining(ContainSubstring("moon")))
+		})
+
+		It("should fail for content that is not gzipped", func() {
+			Expect([]byte("hello world")).ShouldNot(BeGzippedContaining(ContainSubstring("world")))
+			Expect(gzipped("hello world")[:10]).ShouldNot(BeGzippedContaining(ContainSubstring("world")))
+		})
+	})
+
+	Context("with deflate", func() {
+		It("should accept zlib wrapped and raw content", func() {
+			zlibbed := compress(func(w io.Writer) io.WriteCloser { return zlib.NewWriter(w) }, "hello world")
+			raw := compress(func(w io.Writer) io.WriteCloser {
+				writer, _ := flate.NewWriter(w, flate.DefaultCompression)
+				return writer
+			}, "hello world")
+
+			Expect(zlibbed).Should(BeDeflatedContaining(Equal([]byte("hello world"))))
+			Expect(raw).Should(BeDeflatedContaining(Equal([]byte("hello world"))))
+			Expect(gzipped("hello world")).ShouldNot(BeDeflatedContaining(Equal([]byte("hello world"))))
+		})
+	})
+
+	Context("with a custom decompressor", func() {
+		It("should decompress with it", func() {
+			decompressLZW := func(r io.Reader) (io.Reader, error) { return lzw.NewReader(r, lzw.LSB, 8), nil }
+			compressed := compress(func(w io.Writer) io.WriteCloser { return lzw.NewWriter(w, lzw.LSB, 8) }, "hello world")
+			Expect(compressed).Should(BeCompressedContaining("lzw", decompressLZW, Equal([]byte("hello world"))))
+		})
+
+		It("should error when it is nil for an unsupported format", func() {
+			_, err := BeCompressedContaining("zstd", nil, BeEmpty()).Match([]byte{})
+			Expect(err).Should(MatchError("BeCompressedContaining matcher expects a function that decompresses zstd content, got nil"))
+		})
+	})
+
+	Context("with readers and responses", func() {
+		It("should read the content and cache it", func() {
+			matcher := BeGzippedContaining(ContainSubstring("world"))
+			reader := bytes.NewReader(gzipped("hello world"))
+			Expect(reader).Should(matcher)
+			Expect(reader).Should(matcher)
+		})
+
+		It("should only use the cached content for the reader it was read from", func() {
+			matcher := BeGzippedContaining(ContainSubstring("world"))
+			Expect(bytes.NewReader(gzipped("hello world"))).Should(matcher)
+			Expect(bytes.NewReader(gzipped("hello moon"))).ShouldNot(matcher)
+			Expect(gzipped("hello moon")).ShouldNot(matcher)
+			Expect(bytes.NewReader(gzipped("hello world"))).Should(matcher)
+		})
+
+		It("should read response bodies", func() {
+			recorder := httptest.NewRecorder()
+			recorder.Body.Write(gzipped("hello world"))
+			Expect(recorder).Should(BeGzippedContaining(ContainSubstring("world")))
+
+			resp := &http.Response{Body: io.NopCloser(bytes.NewReader(gzipped("hello world")))}
+			Expect(resp).Should(BeGzippedContaining(ContainSubstring("world")))
+		})
+	})
+
+	It("should error when actual is of the wrong type or the matcher is nil", func() {
+		_, err := BeGzippedContaining(BeEmpty()).Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("BeCompressedContaining matcher expects a []byte, string, io.Reader, *http.Response or *httptest.ResponseRecorder.  Got:")))
+		_, err = BeGzippedContaining(nil).Match(gzipped(""))
+		Expect(err).Should(MatchError("BeCompressedContaining matcher expects a matcher for the decompressed content, got nil"))
+		_, err = BeGzippedContaining(BeTrue()).Match(gzipped(""))
+		Expect(err).Should(MatchError(HavePrefix("Content decompressed from gzip:\n    Expected a boolean.")))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect(strings.NewReader("hello world")).Should(BeGzippedContaining(ContainSubstring("world")))
+			Expect(gzipped("hello world")).Should(&BeCompressedContainingMatcher{Format: "gzip", Matcher: ContainSubstring("moon")})
+			Expect(gzipped("hello world")).ShouldNot(BeGzippedContaining(ContainSubstring("world")))
+		})
+		Expect(failures).Should(Equal([]string{
+			"Expected gzip compressed content, but decompressing it failed with:\n    gzip: invalid header",
+			"Content decompressed from gzip:\n    Expected\n        <[]uint8 | len:11, cap:512>: hello world\n    to contain substring\n        <string>: moon",
+			"Content decompressed from gzip:\n    Expected\n        <[]uint8 | len:11, cap:512>: hello world\n    not to contain substring\n        <string>: world",
+		}))
+	})
+})
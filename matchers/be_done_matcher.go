@@ -0,0 +1,42 @@
+package matchers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeDoneMatcher struct {
+}
+
+func (matcher *BeDoneMatcher) Match(actual interface{}) (success bool, err error) {
+	ctx, ok := actual.(context.Context)
+	if !ok || isNil(actual) {
+		return false, fmt.Errorf("BeDone matcher expects a context.Context.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	select {
+	case <-ctx.Done():
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+func (matcher *BeDoneMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to be done")
+}
+
+func (matcher *BeDoneMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("not to be done, but it was done with:\n%s", format.IndentString(actual.(context.Context).Err().Error(), 1)))
+}
+
+// MatchMayChangeInTheFuture lets Eventually and Consistently stop early: a context that is done stays done
+func (matcher *BeDoneMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	ctx, ok := actual.(context.Context)
+	if !ok || isNil(actual) {
+		return true
+	}
+	return ctx.Err() == nil
+}
@@ -0,0 +1,51 @@
+package matchers_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("BeDone", func() {
+	It("should succeed when the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		Expect(ctx).ShouldNot(BeDone())
+		cancel()
+		Expect(ctx).Should(BeDone())
+	})
+
+	It("can be awaited with Eventually", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		Eventually(ctx).Should(BeDone())
+	})
+
+	It("reports ctx.Err() when the context should not be done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		failuresMessages := InterceptGomegaFailures(func() {
+			Expect(ctx).ShouldNot(BeDone())
+		})
+		Expect(failuresMessages).Should(ConsistOf(HaveSuffix("not to be done, but it was done with:\n    context canceled")))
+	})
+
+	It("tells Eventually and Consistently that done contexts stay done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		m := &BeDoneMatcher{}
+		Expect(m.MatchMayChangeInTheFuture(ctx)).Should(BeTrue())
+		cancel()
+		Expect(m.MatchMayChangeInTheFuture(ctx)).Should(BeFalse())
+	})
+
+	It("should error when passed something that isn't a context", func() {
+		success, err := (&BeDoneMatcher{}).Match(nil)
+		Expect(success).Should(BeFalse())
+		Expect(err).Should(MatchError("BeDone matcher expects a context.Context.  Got:\n    <nil>: nil"))
+
+		_, err = (&BeDoneMatcher{}).Match(make(chan struct{}))
+		Expect(err).Should(HaveOccurred())
+	})
+})
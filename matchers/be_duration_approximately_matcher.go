@@ -0,0 +1,108 @@
+package matchers
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeDurationApproximatelyMatcher struct {
+	Expected  interface{}
+	Tolerance interface{}
+
+	// state
+	actual     time.Duration
+	expected   time.Duration
+	window     time.Duration
+	percentage string
+}
+
+func (matcher *BeDurationApproximatelyMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.actual, err = toDuration("BeDurationApproximately", actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.expected, err = toDuration("BeDurationApproximately", matcher.Expected)
+	if err != nil {
+		return false, err
+	}
+	err = matcher.parseTolerance()
+	if err != nil {
+		return false, err
+	}
+
+	difference := matcher.actual - matcher.expected
+	return -matcher.window <= difference && difference <= matcher.window, nil
+}
+
+func (matcher *BeDurationApproximatelyMatcher) FailureMessage(actual interface{}) (message string) {
+	return matcher.message("to be within")
+}
+
+func (matcher *BeDurationApproximatelyMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return matcher.message("not to be within")
+}
+
+func (matcher *BeDurationApproximatelyMatcher) message(relation string) string {
+	window := matcher.window.String()
+	if matcher.percentage != "" {
+		window = fmt.Sprintf("%s (%s)", matcher.percentage, matcher.window)
+	}
+
+	var difference string
+	switch {
+	case matcher.actual > matcher.expected:
+		difference = fmt.Sprintf("it was %s longer", matcher.actual-matcher.expected)
+	case matcher.actual < matcher.expected:
+		difference = fmt.Sprintf("it was %s shorter", matcher.expected-matcher.actual)
+	default:
+		difference = "it was exactly the expected duration"
+	}
+
+	return fmt.Sprintf("Expected\n%s<time.Duration>: %s\n%s %s of\n%s<time.Duration>: %s\n%s",
+		format.Indent, matcher.actual, relation, window, format.Indent, matcher.expected, difference)
+}
+
+// parseTolerance accepts a time.Duration, a duration string like "50ms", or a percentage of the expected duration like "10%"
+func (matcher *BeDurationApproximatelyMatcher) parseTolerance() error {
+	matcher.percentage = ""
+	if s, ok := matcher.Tolerance.(string); ok && strings.HasSuffix(s, "%") {
+		percent, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, "%")), 64)
+		if err != nil || percent < 0 || math.IsInf(percent, 0) || math.IsNaN(percent) {
+			return fmt.Errorf("BeDurationApproximately matcher expects a non-negative percentage tolerance.  Got: %q", s)
+		}
+		matcher.percentage = s
+		matcher.window = time.Duration(math.Abs(float64(matcher.expected)) * percent / 100)
+		return nil
+	}
+
+	window, err := toDuration("BeDurationApproximately", matcher.Tolerance)
+	if err != nil {
+		return err
+	}
+	if window < 0 {
+		return fmt.Errorf("BeDurationApproximately matcher requires a non-negative tolerance.  Got: %s", window)
+	}
+	matcher.window = window
+	return nil
+}
+
+// toDuration accepts a time.Duration or a string that time.ParseDuration understands
+func toDuration(matcherName string, value interface{}) (time.Duration, error) {
+	switch x := value.(type) {
+	case time.Duration:
+		return x, nil
+	case string:
+		duration, err := time.ParseDuration(x)
+		if err != nil {
+			return 0, fmt.Errorf("%s matcher could not parse the duration %q: %s", matcherName, x, err.Error())
+		}
+		return duration, nil
+	default:
+		return 0, fmt.Errorf("%s matcher expects a time.Duration or a duration string.  Got:\n%s", matcherName, format.Object(value, 1))
+	}
+}
@@ -0,0 +1,60 @@
+package matchers_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeDurationApproximately", func() {
+	It("should accept percentage tolerances", func() {
+		Expect(1600 * time.Millisecond).Should(BeDurationApproximately("1.5s", "10%"))
+		Expect(1650 * time.Millisecond).Should(BeDurationApproximately("1.5s", "10%"))
+		Expect(1350 * time.Millisecond).Should(BeDurationApproximately(1500*time.Millisecond, "10%"))
+		Expect(1651 * time.Millisecond).ShouldNot(BeDurationApproximately("1.5s", "10%"))
+		Expect(1349 * time.Millisecond).ShouldNot(BeDurationApproximately("1.5s", "10%"))
+		Expect(time.Second).Should(BeDurationApproximately("1s", "0%"))
+	})
+
+	It("should accept duration tolerances", func() {
+		Expect(time.Second).Should(BeDurationApproximately("1.05s", "50ms"))
+		Expect(time.Second).Should(BeDurationApproximately(950*time.Millisecond, 50*time.Millisecond))
+		Expect(time.Second).ShouldNot(BeDurationApproximately("1.06s", "50ms"))
+	})
+
+	It("should accept duration strings as actual", func() {
+		Expect("1.2s").Should(BeDurationApproximately("1s", "20%"))
+		Expect("1.3s").ShouldNot(BeDurationApproximately("1s", "20%"))
+	})
+
+	It("should report how far off actual was", func() {
+		m := BeDurationApproximately("1.5s", "10%")
+		Expect(m.Match(1800 * time.Millisecond)).Should(BeFalse())
+		Expect(m.FailureMessage(1800 * time.Millisecond)).Should(Equal("Expected\n    <time.Duration>: 1.8s\nto be within 10% (150ms) of\n    <time.Duration>: 1.5s\nit was 300ms longer"))
+
+		m = BeDurationApproximately(time.Second, "50ms")
+		Expect(m.Match(900 * time.Millisecond)).Should(BeFalse())
+		Expect(m.FailureMessage(900 * time.Millisecond)).Should(HaveSuffix("to be within 50ms of\n    <time.Duration>: 1s\nit was 100ms shorter"))
+
+		Expect(m.Match(time.Second)).Should(BeTrue())
+		Expect(m.NegatedFailureMessage(time.Second)).Should(Equal("Expected\n    <time.Duration>: 1s\nnot to be within 50ms of\n    <time.Duration>: 1s\nit was exactly the expected duration"))
+	})
+
+	It("should error on invalid input", func() {
+		_, err := BeDurationApproximately("1s", "10%").Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("expects a time.Duration or a duration string")))
+
+		_, err = BeDurationApproximately("soon", "10%").Match(time.Second)
+		Expect(err).Should(MatchError(ContainSubstring(`could not parse the duration "soon"`)))
+
+		_, err = BeDurationApproximately("1s", "lots%").Match(time.Second)
+		Expect(err).Should(MatchError(ContainSubstring("non-negative percentage tolerance")))
+
+		_, err = BeDurationApproximately("1s", "-10%").Match(time.Second)
+		Expect(err).Should(MatchError(ContainSubstring("non-negative percentage tolerance")))
+
+		_, err = BeDurationApproximately("1s", "-10ms").Match(time.Second)
+		Expect(err).Should(MatchError(ContainSubstring("non-negative tolerance")))
+	})
+})
@@ -11,6 +11,9 @@ import (
 
 type BeElementOfMatcher struct {
 	Elements []interface{}
+
+	// state
+	provided []interface{}
 }
 
 func (matcher *BeElementOfMatcher) Match(actual interface{}) (success bool, err error) {
@@ -18,26 +21,47 @@ func (matcher *BeElementOfMatcher) Match(actual interface{}) (success bool, err
 		return false, fmt.Errorf("BeElement matcher expects actual to be typed")
 	}
 
-	var lastError error
-	for _, m := range flatten(matcher.Elements) {
-		matcher := &EqualMatcher{Expected: m}
-		success, err := matcher.Match(actual)
+	elements := flatten(matcher.Elements)
+	if matcher.hasProvider() {
+		collection, err := provideCollection("BeElementOf", matcher.Elements[0])
 		if err != nil {
-			lastError = err
-			continue
+			return false, err
 		}
-		if success {
-			return true, nil
+		if !isArrayOrSlice(collection) {
+			return false, fmt.Errorf("BeElementOf matcher expects its provider to return an array or slice.  Got:\n%s", format.Object(collection, 1))
 		}
+		elements = valuesOf(collection)
+		matcher.provided = elements
 	}
 
-	return false, lastError
+	return matchesAnyCandidate(actual, elements)
 }
 
 func (matcher *BeElementOfMatcher) FailureMessage(actual interface{}) (message string) {
-	return format.Message(actual, "to be an element of", presentable(matcher.Elements))
+	if matcher.hasProvider() {
+		return matcher.LegacyFailureMessage(actual, true) + nearestCandidatesMessage(actual, matcher.provided)
+	}
+	return matcher.LegacyFailureMessage(actual, true) + nearestCandidatesMessage(actual, flatten(matcher.Elements))
 }
 
 func (matcher *BeElementOfMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if matcher.hasProvider() {
+		return providedCollectionMessage(actual, "not to be an element of", "elements", matcher.provided, matcher.Elements[0])
+	}
 	return format.Message(actual, "not to be an element of", presentable(matcher.Elements))
 }
+
+// LegacyFailureMessage makes BeElementOfMatcher a types.LegacyFailureMessager: it does not suggest the nearest elements
+func (matcher *BeElementOfMatcher) LegacyFailureMessage(actual interface{}, desiredMatch bool) string {
+	if !desiredMatch {
+		return matcher.NegatedFailureMessage(actual)
+	}
+	if matcher.hasProvider() {
+		return providedCollectionMessage(actual, "to be an element of", "elements", matcher.provided, matcher.Elements[0])
+	}
+	return format.Message(actual, "to be an element of", presentable(matcher.Elements))
+}
+
+func (matcher *BeElementOfMatcher) hasProvider() bool {
+	return len(matcher.Elements) == 1 && isCollectionProvider(matcher.Elements[0])
+}
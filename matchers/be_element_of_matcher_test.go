@@ -1,9 +1,13 @@
 package matchers_test
 
 import (
+	"errors"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
 )
 
 var _ = Describe("BeElementOf", func() {
@@ -63,4 +67,94 @@ var _ = Describe("BeElementOf", func() {
 		actual := BeElementOf(1, 2).NegatedFailureMessage(123)
 		Expect(actual).To(Equal("Expected\n    <int>: 123\nnot to be an element of\n    <[]int | len:2, cap:2>: [1, 2]"))
 	})
+
+	Context("when passed a provider function", func() {
+		It("calls it for the elements each time it matches", func() {
+			ids := []int{1, 2}
+			listIDs := func() []int { return ids }
+			m := BeElementOf(listIDs)
+			Expect(3).ShouldNot(m)
+			ids = append(ids, 3)
+			Expect(3).Should(m)
+		})
+
+		It("can be awaited with Eventually", func() {
+			calls := 0
+			provider := func() ([]string, error) {
+				calls++
+				if calls < 3 {
+					return []string{"a"}, nil
+				}
+				return []string{"a", "b"}, nil
+			}
+			Eventually("b").WithPolling(time.Millisecond).Should(BeElementOf(provider))
+			Expect(calls).Should(Equal(3))
+		})
+
+		It("errors when the provider does", func() {
+			success, err := BeElementOf(func() ([]int, error) { return nil, errors.New("bam") }).Match(1)
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError(ContainSubstring("returned an error: bam")))
+		})
+
+		It("errors when the provider does not provide an array or slice", func() {
+			_, err := BeElementOf(func() int { return 1 }).Match(1)
+			Expect(err).Should(MatchError(ContainSubstring("BeElementOf matcher expects its provider to return an array or slice")))
+
+			_, err = BeElementOf(func(n int) []int { return nil }).Match(1)
+			Expect(err).Should(MatchError(ContainSubstring("BeElementOf matcher expects a provider function that takes no arguments")))
+		})
+
+		It("reports the size of the collection and a sample of its elements", func() {
+			m := BeElementOf(listTwelveIDs)
+			Expect(m.Match(42)).Should(BeFalse())
+			Expect(m.FailureMessage(42)).Should(Equal("Expected\n    <int>: 42\nto be an element of the 12 elements last provided by listTwelveIDs, the first 10 of which are\n    <[]int | len:10, cap:10>: [0, 1, 2, 3, 4, 5, 6, 7, 8, 9]\nThe nearest candidates were\n    <[]int | len:3, cap:3>: [11, 10, 9]"))
+
+			m = BeElementOf(func() []int { return []int{1, 2} })
+			Expect(m.Match(1)).Should(BeTrue())
+			Expect(m.NegatedFailureMessage(1)).Should(Equal("Expected\n    <int>: 1\nnot to be an element of the 2 elements last provided by func() []int\n    <[]int | len:2, cap:2>: [1, 2]"))
+		})
+	})
+	Context("when passed matchers", func() {
+		It("matches actual against them", func() {
+			Expect("eu-west-1").Should(BeElementOf([]interface{}{"us-east-1", HavePrefix("eu-")}))
+			Expect("ap-south-1").ShouldNot(BeElementOf("us-east-1", HavePrefix("eu-")))
+		})
+
+		It("errors only when no element matches", func() {
+			Expect(3).Should(BeElementOf(BeEmpty(), 3))
+			success, err := BeElementOf(BeEmpty()).Match(3)
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	It("suggests the nearest string elements", func() {
+		actual := BeElementOf("staging", "production", "development", "sandbox").FailureMessage("prodution")
+		Expect(actual).Should(HaveSuffix("The nearest candidates were\n    <[]string | len:3, cap:3>: [\"production\", \"sandbox\", \"staging\"]"))
+	})
+
+	It("does not suggest elements it cannot rank", func() {
+		actual := BeElementOf(HavePrefix("a"), true).FailureMessage("b")
+		Expect(actual).ShouldNot(ContainSubstring("nearest"))
+
+		actual = BeElementOf(uintptr(1), uintptr(2)).FailureMessage(uintptr(3))
+		Expect(actual).ShouldNot(ContainSubstring("nearest"))
+		actual = BeElementOf(1, 5, 9, 20, uintptr(2)).FailureMessage(3)
+		Expect(actual).Should(HaveSuffix("The nearest candidates were\n    <[]int | len:3, cap:3>: [1, 5, 9]"))
+	})
+
+	It("does not suggest elements when there are no more than it would suggest", func() {
+		actual := BeElementOf("staging", "production", "development").FailureMessage("prodution")
+		Expect(actual).ShouldNot(ContainSubstring("nearest"))
+	})
+
+	It("does not suggest elements in its legacy failure message", func() {
+		actual := types.LegacyFailureMessageFor(BeElementOf("staging", "production", "development", "sandbox"), "prodution", true)
+		Expect(actual).Should(Equal("Expected\n    <string>: prodution\nto be an element of\n    <[]string | len:4, cap:4>: [\"staging\", \"production\", \"development\", \"sandbox\"]"))
+	})
 })
+
+func listTwelveIDs() []int {
+	return []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+}
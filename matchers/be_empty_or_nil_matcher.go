@@ -0,0 +1,33 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeEmptyOrNilMatcher struct {
+}
+
+func (matcher *BeEmptyOrNilMatcher) Match(actual interface{}) (success bool, err error) {
+	if isNil(actual) {
+		return true, nil
+	}
+	length, ok := lengthOf(actual)
+	if !ok {
+		return false, fmt.Errorf("BeEmptyOrNil matcher expects nil or a string/array/map/channel/slice.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	return length == 0, nil
+}
+
+func (matcher *BeEmptyOrNilMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to be empty or nil")
+}
+
+func (matcher *BeEmptyOrNilMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if isNil(actual) {
+		return format.Message(actual, "not to be empty or nil, but it is nil")
+	}
+	return format.Message(actual, "not to be empty or nil, but it is empty")
+}
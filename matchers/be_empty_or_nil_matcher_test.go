@@ -0,0 +1,49 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("BeEmptyOrNil", func() {
+	When("passed a supported type", func() {
+		It("should do the right thing", func() {
+			Expect(nil).Should(BeEmptyOrNil())
+			Expect((*int)(nil)).Should(BeEmptyOrNil())
+			Expect(error(nil)).Should(BeEmptyOrNil())
+
+			Expect([]string(nil)).Should(BeEmptyOrNil())
+			Expect([]string{}).Should(BeEmptyOrNil())
+			Expect([]string{"foo"}).ShouldNot(BeEmptyOrNil())
+
+			Expect(map[string]int(nil)).Should(BeEmptyOrNil())
+			Expect(map[string]int{}).Should(BeEmptyOrNil())
+			Expect(map[string]int{"a": 1}).ShouldNot(BeEmptyOrNil())
+
+			Expect("").Should(BeEmptyOrNil())
+			Expect("foo").ShouldNot(BeEmptyOrNil())
+		})
+	})
+
+	When("passed an unsupported type", func() {
+		It("should error", func() {
+			success, err := (&BeEmptyOrNilMatcher{}).Match(0)
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError(ContainSubstring("BeEmptyOrNil matcher expects nil or a string/array/map/channel/slice.")))
+
+			n := 3
+			success, err = (&BeEmptyOrNilMatcher{}).Match(&n)
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("failure messages", func() {
+		It("should say whether actual was nil or empty", func() {
+			Expect(BeEmptyOrNil().FailureMessage("foo")).To(Equal("Expected\n    <string>: foo\nto be empty or nil"))
+			Expect(BeEmptyOrNil().NegatedFailureMessage([]int(nil))).To(Equal("Expected\n    <[]int | len:0, cap:0>: nil\nnot to be empty or nil, but it is nil"))
+			Expect(BeEmptyOrNil().NegatedFailureMessage([]int{})).To(Equal("Expected\n    <[]int | len:0, cap:0>: []\nnot to be empty or nil, but it is empty"))
+		})
+	})
+})
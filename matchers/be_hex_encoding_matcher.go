@@ -0,0 +1,28 @@
+package matchers
+
+import (
+	"encoding/hex"
+
+	"github.com/onsi/gomega/types"
+)
+
+type BeHexEncodingMatcher struct {
+	// Matcher, if not nil, is matched against the decoded []byte
+	Matcher types.GomegaMatcher
+
+	// state
+	content *encodedContent
+}
+
+func (matcher *BeHexEncodingMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.content = &encodedContent{matcherName: "BeHexEncoding", encoding: "hex", decode: hex.DecodeString, matcher: matcher.Matcher}
+	return matcher.content.match(actual)
+}
+
+func (matcher *BeHexEncodingMatcher) FailureMessage(actual interface{}) (message string) {
+	return matcher.content.failureMessage(actual, true)
+}
+
+func (matcher *BeHexEncodingMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return matcher.content.failureMessage(actual, false)
+}
@@ -0,0 +1,39 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeHexEncoding", func() {
+	It("should succeed for hex encoded content in either case", func() {
+		Expect("68756e74657232").Should(BeHexEncoding())
+		Expect("CAFEBABE").Should(BeHexEncoding(Equal([]byte{0xca, 0xfe, 0xba, 0xbe})))
+		Expect([]byte("cafebabe")).Should(BeHexEncoding(HaveLen(4)))
+		Expect("").Should(BeHexEncoding())
+	})
+
+	It("should fail for content that is not hex encoded", func() {
+		Expect("cafebab").ShouldNot(BeHexEncoding())
+		Expect("0xcafebabe").ShouldNot(BeHexEncoding())
+		Expect("68756e74657232").ShouldNot(BeHexEncoding(HaveLen(32)))
+	})
+
+	It("should error when actual is not a string", func() {
+		_, err := BeHexEncoding().Match(0xcafe)
+		Expect(err).Should(MatchError(ContainSubstring("BeHexEncoding matcher expects a string or []byte.  Got:")))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect("cafebab").Should(BeHexEncoding())
+			Expect("cafebabg").Should(BeHexEncoding())
+			Expect("68756e74657232").Should(BeHexEncoding(HaveLen(32)))
+		})
+		Expect(failures).Should(Equal([]string{
+			"Expected\n    <string>: cafebab\nto be hex encoded, but decoding it failed with:\n    encoding/hex: odd length hex string",
+			"Expected\n    <string>: cafebabg\nto be hex encoded, but decoding it failed with:\n    encoding/hex: invalid byte: U+0067 'g'",
+			"Content decoded from hex:\n    Expected\n        <[]uint8 | len:7, cap:7>: hunter2\n    to have length 32",
+		}))
+	})
+})
@@ -0,0 +1,35 @@
+package matchers
+
+import (
+	"fmt"
+	"net/netip"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeInCIDRMatcher struct {
+	CIDR string
+}
+
+func (matcher *BeInCIDRMatcher) Match(actual interface{}) (success bool, err error) {
+	prefix, err := netip.ParsePrefix(matcher.CIDR)
+	if err != nil {
+		return false, fmt.Errorf("BeInCIDR matcher expects a CIDR such as 10.0.0.0/8.  Got:\n%s", format.Object(matcher.CIDR, 1))
+	}
+	addr, ok, err := toAddr("BeInCIDR", actual)
+	if !ok {
+		return false, fmt.Errorf("BeInCIDR matcher expects a string, net.IP, *net.IPAddr or netip.Addr.  Got:\n%s", format.Object(actual, 1))
+	}
+	if err != nil {
+		return false, err
+	}
+	return prefix.Masked().Contains(addr), nil
+}
+
+func (matcher *BeInCIDRMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to be in CIDR", matcher.CIDR)
+}
+
+func (matcher *BeInCIDRMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to be in CIDR", matcher.CIDR)
+}
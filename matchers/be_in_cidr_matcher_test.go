@@ -0,0 +1,45 @@
+package matchers_test
+
+import (
+	"net"
+	"net/netip"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("BeInCIDR", func() {
+	It("should succeed for addresses in the block", func() {
+		Expect("10.1.2.3").Should(BeInCIDR("10.0.0.0/8"))
+		Expect("11.1.2.3").ShouldNot(BeInCIDR("10.0.0.0/8"))
+		Expect(net.ParseIP("192.168.1.7")).Should(BeInCIDR("192.168.1.0/24"))
+		Expect(netip.MustParseAddr("fd00::1")).Should(BeInCIDR("fd00::/8"))
+		Expect("fe80::1").ShouldNot(BeInCIDR("fd00::/8"))
+	})
+
+	It("should match IPv4-mapped IPv6 addresses against IPv4 blocks", func() {
+		Expect("::ffff:10.1.2.3").Should(BeInCIDR("10.0.0.0/8"))
+		Expect(net.ParseIP("10.1.2.3").To16()).Should(BeInCIDR("10.0.0.0/8"))
+	})
+
+	It("should accept blocks with host bits set", func() {
+		Expect("10.1.2.3").Should(BeInCIDR("10.1.2.1/24"))
+	})
+
+	It("should error for invalid addresses and blocks", func() {
+		_, err := (&BeInCIDRMatcher{CIDR: "10.0.0.0/8"}).Match("10.1.2")
+		Expect(err).Should(MatchError(ContainSubstring("BeInCIDR matcher expects a valid IP address.")))
+
+		_, err = (&BeInCIDRMatcher{CIDR: "10.0.0.0/8"}).Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("BeInCIDR matcher expects a string, net.IP, *net.IPAddr or netip.Addr.")))
+
+		_, err = (&BeInCIDRMatcher{CIDR: "10.0.0.0"}).Match("10.1.2.3")
+		Expect(err).Should(MatchError(ContainSubstring("BeInCIDR matcher expects a CIDR such as 10.0.0.0/8.")))
+	})
+
+	It("should build failure messages", func() {
+		Expect(BeInCIDR("10.0.0.0/8").FailureMessage("11.1.2.3")).Should(Equal("Expected\n    <string>: 11.1.2.3\nto be in CIDR\n    <string>: 10.0.0.0/8"))
+		Expect(BeInCIDR("10.0.0.0/8").NegatedFailureMessage("10.1.2.3")).Should(Equal("Expected\n    <string>: 10.1.2.3\nnot to be in CIDR\n    <string>: 10.0.0.0/8"))
+	})
+})
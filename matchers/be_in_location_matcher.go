@@ -0,0 +1,36 @@
+package matchers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeInLocationMatcher struct {
+	Location *time.Location
+
+	// state
+	actualLocation string
+}
+
+func (matcher *BeInLocationMatcher) Match(actual interface{}) (success bool, err error) {
+	actualTime, ok := actual.(time.Time)
+	if !ok {
+		return false, fmt.Errorf("BeInLocation matcher expects a time.Time.  Got:\n%s", format.Object(actual, 1))
+	}
+	if matcher.Location == nil {
+		return false, fmt.Errorf("BeInLocation matcher requires a non-nil *time.Location")
+	}
+
+	matcher.actualLocation = actualTime.Location().String()
+	return matcher.actualLocation == matcher.Location.String(), nil
+}
+
+func (matcher *BeInLocationMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to be in location", matcher.Location.String()) + fmt.Sprintf("\nbut it is in location\n%s", format.Object(matcher.actualLocation, 1))
+}
+
+func (matcher *BeInLocationMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to be in location", matcher.Location.String())
+}
@@ -0,0 +1,40 @@
+package matchers_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeInLocation", func() {
+	var t0 time.Time
+	var tokyo *time.Location
+
+	BeforeEach(func() {
+		t0 = time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+		tokyo = time.FixedZone("Asia/Tokyo", 9*60*60)
+	})
+
+	It("should compare location names", func() {
+		Expect(t0).Should(BeInLocation(time.UTC))
+		Expect(t0.In(tokyo)).Should(BeInLocation(tokyo))
+		Expect(t0.In(tokyo)).ShouldNot(BeInLocation(time.UTC))
+		Expect(t0).ShouldNot(BeInLocation(time.Local))
+	})
+
+	It("should report both locations", func() {
+		m := BeInLocation(time.UTC)
+		Expect(m.Match(t0.In(tokyo))).Should(BeFalse())
+		Expect(m.FailureMessage(t0.In(tokyo))).Should(Equal("Expected\n    <time.Time>: 2023-03-01T21:00:00+09:00\nto be in location\n    <string>: UTC\nbut it is in location\n    <string>: Asia/Tokyo"))
+		Expect(m.NegatedFailureMessage(t0)).Should(Equal("Expected\n    <time.Time>: 2023-03-01T12:00:00Z\nnot to be in location\n    <string>: UTC"))
+	})
+
+	It("should error on invalid input", func() {
+		_, err := BeInLocation(time.UTC).Match("now")
+		Expect(err).Should(MatchError(ContainSubstring("expects a time.Time")))
+
+		_, err = BeInLocation(nil).Match(t0)
+		Expect(err).Should(MatchError(ContainSubstring("non-nil *time.Location")))
+	})
+})
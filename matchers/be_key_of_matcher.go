@@ -5,14 +5,29 @@ import (
 	"reflect"
 
 	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/internal/gutil"
 )
 
 type BeKeyOfMatcher struct {
 	Map interface{}
+
+	// state
+	provided interface{}
 }
 
 func (matcher *BeKeyOfMatcher) Match(actual interface{}) (success bool, err error) {
-	if !isMap(matcher.Map) {
+	m := matcher.Map
+	if isCollectionProvider(m) {
+		m, err = provideCollection("BeKeyOf", matcher.Map)
+		if err != nil {
+			return false, err
+		}
+		if !isMap(m) {
+			return false, fmt.Errorf("BeKeyOf matcher expects its provider to return a map.  Got:\n%s", format.Object(m, 1))
+		}
+		matcher.provided = m
+	}
+	if !isMap(m) {
 		return false, fmt.Errorf("BeKeyOf matcher needs expected to be a map type")
 	}
 
@@ -20,26 +35,42 @@ func (matcher *BeKeyOfMatcher) Match(actual interface{}) (success bool, err erro
 		return false, fmt.Errorf("BeKeyOf matcher expects actual to be typed")
 	}
 
-	var lastError error
-	for _, key := range reflect.ValueOf(matcher.Map).MapKeys() {
-		matcher := &EqualMatcher{Expected: key.Interface()}
-		success, err := matcher.Match(actual)
-		if err != nil {
-			lastError = err
-			continue
-		}
-		if success {
-			return true, nil
-		}
-	}
-
-	return false, lastError
+	return matchesAnyCandidate(actual, keysOf(m))
 }
 
 func (matcher *BeKeyOfMatcher) FailureMessage(actual interface{}) (message string) {
-	return format.Message(actual, "to be a key of", presentable(valuesOf(matcher.Map)))
+	if isCollectionProvider(matcher.Map) {
+		return matcher.LegacyFailureMessage(actual, true) + nearestCandidatesMessage(actual, keysOf(matcher.provided))
+	}
+	return matcher.LegacyFailureMessage(actual, true) + nearestCandidatesMessage(actual, keysOf(matcher.Map))
 }
 
 func (matcher *BeKeyOfMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if isCollectionProvider(matcher.Map) {
+		return providedCollectionMessage(actual, "not to be a key of", "keys", keysOf(matcher.provided), matcher.Map)
+	}
 	return format.Message(actual, "not to be a key of", presentable(valuesOf(matcher.Map)))
 }
+
+// LegacyFailureMessage makes BeKeyOfMatcher a types.LegacyFailureMessager: it does not suggest the nearest keys
+func (matcher *BeKeyOfMatcher) LegacyFailureMessage(actual interface{}, desiredMatch bool) string {
+	if !desiredMatch {
+		return matcher.NegatedFailureMessage(actual)
+	}
+	if isCollectionProvider(matcher.Map) {
+		return providedCollectionMessage(actual, "to be a key of", "keys", keysOf(matcher.provided), matcher.Map)
+	}
+	return format.Message(actual, "to be a key of", presentable(valuesOf(matcher.Map)))
+}
+
+// keysOf returns the keys of the map m, sorted
+func keysOf(m interface{}) []interface{} {
+	keys := []interface{}{}
+	if m == nil {
+		return keys
+	}
+	for _, key := range gutil.SortedMapKeys(reflect.ValueOf(m)) {
+		keys = append(keys, key.Interface())
+	}
+	return keys
+}
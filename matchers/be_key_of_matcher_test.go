@@ -4,6 +4,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
 )
 
 var _ = Describe("BeKeyOf", func() {
@@ -52,4 +53,35 @@ var _ = Describe("BeKeyOf", func() {
 		Expect(actual).To(MatchRegexp("Expected\n    <int>: 42\nnot to be a key of\n    <\\[\\]bool | len:2, cap:2>: \\[(true, false)|(false, true)\\]"))
 	})
 
+	Context("when passed a provider function", func() {
+		It("calls it for the map each time it matches", func() {
+			sessions := map[string]int{"alice": 1}
+			m := BeKeyOf(func() map[string]int { return sessions })
+			Expect("bob").ShouldNot(m)
+			sessions["bob"] = 2
+			Expect("bob").Should(m)
+		})
+
+		It("errors when the provider does not provide a map", func() {
+			_, err := BeKeyOf(func() []string { return nil }).Match("bob")
+			Expect(err).Should(MatchError(ContainSubstring("BeKeyOf matcher expects its provider to return a map")))
+		})
+
+		It("reports the number of keys and a sample of them", func() {
+			m := BeKeyOf(func() (map[string]int, error) { return map[string]int{"bob": 2, "alice": 1}, nil })
+			Expect(m.Match("carol")).Should(BeFalse())
+			Expect(m.FailureMessage("carol")).Should(Equal("Expected\n    <string>: carol\nto be a key of the 2 keys last provided by func() (map[string]int, error)\n    <[]string | len:2, cap:2>: [\"alice\", \"bob\"]"))
+		})
+	})
+	It("matches actual against keys that are matchers", func() {
+		routes := map[interface{}]string{"/": "index", MatchRegexp(`^/users/\d+$`): "user"}
+		Expect("/users/42").Should(BeKeyOf(routes))
+		Expect("/users/bob").ShouldNot(BeKeyOf(routes))
+	})
+
+	It("suggests the nearest keys, except in its legacy failure message", func() {
+		m := map[string]int{"alice": 1, "bob": 2, "carol": 3, "dave": 4}
+		Expect(BeKeyOf(m).FailureMessage("carl")).Should(HaveSuffix("\nThe nearest candidates were\n    <[]string | len:3, cap:3>: [\"carol\", \"dave\", \"bob\"]"))
+		Expect(types.LegacyFailureMessageFor(BeKeyOf(m), "carl", true)).ShouldNot(ContainSubstring("nearest"))
+	})
 })
@@ -0,0 +1,163 @@
+package matchers
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeMonotonicMatcher struct {
+	Decreasing bool
+	Strict     bool
+
+	// state
+	violationIndex int
+	previous       interface{}
+	current        interface{}
+}
+
+func (matcher *BeMonotonicMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.violationIndex = -1
+	if !isArrayOrSlice(actual) {
+		return false, fmt.Errorf("%s matcher expects an array or slice.  Got:\n%s", matcher.name(), format.Object(actual, 1))
+	}
+
+	value := reflect.ValueOf(actual)
+	for i := 1; i < value.Len(); i++ {
+		previous, current := value.Index(i-1).Interface(), value.Index(i).Interface()
+		ordered, err := matcher.ordered(previous, current)
+		if err != nil {
+			return false, fmt.Errorf("%s matcher cannot compare elements %d and %d: %s", matcher.name(), i-1, i, err.Error())
+		}
+		if !ordered {
+			matcher.violationIndex, matcher.previous, matcher.current = i, previous, current
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (matcher *BeMonotonicMatcher) FailureMessage(actual interface{}) (message string) {
+	message = format.Message(actual, "to be "+matcher.description())
+	if matcher.violationIndex > 0 {
+		message += fmt.Sprintf("\nThe first violation is element %d:\n%s\nwhich is not %s element %d:\n%s",
+			matcher.violationIndex, format.Object(matcher.current, 1),
+			matcher.relation(), matcher.violationIndex-1, format.Object(matcher.previous, 1))
+	}
+	return message
+}
+
+func (matcher *BeMonotonicMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to be "+matcher.description())
+}
+
+// ordered reports whether current may follow previous
+func (matcher *BeMonotonicMatcher) ordered(previous, current interface{}) (bool, error) {
+	comparison, comparable, err := compareOrderedValues(previous, current)
+	if err != nil || !comparable {
+		return false, err
+	}
+	if matcher.Decreasing {
+		comparison = -comparison
+	}
+	if matcher.Strict {
+		return comparison < 0, nil
+	}
+	return comparison <= 0, nil
+}
+
+func (matcher *BeMonotonicMatcher) name() string {
+	switch {
+	case matcher.Strict && matcher.Decreasing:
+		return "BeStrictlyDecreasing"
+	case matcher.Strict:
+		return "BeStrictlyIncreasing"
+	case matcher.Decreasing:
+		return "BeMonotonicallyDecreasing"
+	default:
+		return "BeMonotonicallyIncreasing"
+	}
+}
+
+func (matcher *BeMonotonicMatcher) description() string {
+	switch {
+	case matcher.Strict && matcher.Decreasing:
+		return "strictly decreasing"
+	case matcher.Strict:
+		return "strictly increasing"
+	case matcher.Decreasing:
+		return "monotonically decreasing"
+	default:
+		return "monotonically increasing"
+	}
+}
+
+func (matcher *BeMonotonicMatcher) relation() string {
+	switch {
+	case matcher.Strict && matcher.Decreasing:
+		return "less than"
+	case matcher.Strict:
+		return "greater than"
+	case matcher.Decreasing:
+		return "less than or equal to"
+	default:
+		return "greater than or equal to"
+	}
+}
+
+// compareOrderedValues returns -1, 0, or 1 if a is less than, equal to, or greater than b.
+// It supports numbers (including time.Durations) and time.Times.  Values involving NaN
+// are not comparable and never satisfy an ordering.
+func compareOrderedValues(a, b interface{}) (comparison int, comparable bool, err error) {
+	if aTime, ok := a.(time.Time); ok {
+		bTime, ok := b.(time.Time)
+		if !ok {
+			return 0, false, fmt.Errorf("expected a time.Time.  Got:\n%s", format.Object(b, 1))
+		}
+		switch {
+		case aTime.Before(bTime):
+			return -1, true, nil
+		case aTime.After(bTime):
+			return 1, true, nil
+		default:
+			return 0, true, nil
+		}
+	}
+
+	if !isNumber(a) {
+		return 0, false, fmt.Errorf("expected a number or time.Time.  Got:\n%s", format.Object(a, 1))
+	}
+	if !isNumber(b) {
+		return 0, false, fmt.Errorf("expected a number.  Got:\n%s", format.Object(b, 1))
+	}
+
+	switch {
+	case isInteger(a) && isInteger(b):
+		aInt, bInt := toInteger(a), toInteger(b)
+		return boolToComparison(aInt < bInt, aInt > bInt), true, nil
+	case isUnsignedInteger(a) && isUnsignedInteger(b):
+		aUint, bUint := toUnsignedInteger(a), toUnsignedInteger(b)
+		return boolToComparison(aUint < bUint, aUint > bUint), true, nil
+	default:
+		aFloat, bFloat := toFloat(a), toFloat(b)
+		if math.IsNaN(aFloat) || math.IsNaN(bFloat) {
+			return 0, false, nil
+		}
+		return boolToComparison(aFloat < bFloat, aFloat > bFloat), true, nil
+	}
+}
+
+func boolToComparison(less, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	default:
+		return 0
+	}
+}
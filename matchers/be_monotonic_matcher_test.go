@@ -0,0 +1,91 @@
+package matchers_test
+
+import (
+	"math"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeMonotonic", func() {
+	Context("with numbers", func() {
+		It("should distinguish weak from strict orderings", func() {
+			Expect([]int{1, 2, 2, 3}).Should(BeMonotonicallyIncreasing())
+			Expect([]int{1, 2, 2, 3}).ShouldNot(BeStrictlyIncreasing())
+			Expect([]int{1, 2, 3}).Should(BeStrictlyIncreasing())
+			Expect([]int{1, 3, 2}).ShouldNot(BeMonotonicallyIncreasing())
+
+			Expect([]float64{3, 2.5, 2.5, -1}).Should(BeMonotonicallyDecreasing())
+			Expect([]float64{3, 2.5, 2.5, -1}).ShouldNot(BeStrictlyDecreasing())
+			Expect([3]uint8{9, 4, 1}).Should(BeStrictlyDecreasing())
+			Expect([]int{1, 2}).ShouldNot(BeMonotonicallyDecreasing())
+		})
+
+		It("should compare mixed numeric types", func() {
+			Expect([]interface{}{1, uint8(2), 2.5, int64(3)}).Should(BeStrictlyIncreasing())
+			Expect([]interface{}{int64(math.MaxInt64 - 1), int64(math.MaxInt64)}).Should(BeStrictlyIncreasing())
+		})
+
+		It("should treat empty and single-element collections as monotonic", func() {
+			Expect([]int{}).Should(BeStrictlyIncreasing())
+			Expect([]int{7}).Should(BeStrictlyDecreasing())
+		})
+
+		It("should never consider NaN ordered", func() {
+			Expect([]float64{1, math.NaN(), 2}).ShouldNot(BeMonotonicallyIncreasing())
+			Expect([]float64{2, math.NaN(), 1}).ShouldNot(BeMonotonicallyDecreasing())
+		})
+	})
+
+	Context("with times and durations", func() {
+		It("should order them", func() {
+			t := time.Now()
+			Expect([]time.Time{t, t, t.Add(time.Second)}).Should(BeMonotonicallyIncreasing())
+			Expect([]time.Time{t, t.Add(-time.Second)}).Should(BeStrictlyDecreasing())
+			Expect([]time.Time{t, t.Add(-time.Second)}).ShouldNot(BeMonotonicallyIncreasing())
+			Expect([]time.Duration{time.Millisecond, time.Second}).Should(BeStrictlyIncreasing())
+		})
+	})
+
+	Describe("failure messages", func() {
+		It("should report the first violating pair", func() {
+			m := BeMonotonicallyIncreasing()
+			Expect(m.Match([]int{1, 3, 2, 0})).Should(BeFalse())
+			Expect(m.FailureMessage([]int{1, 3, 2, 0})).Should(Equal(`Expected
+    <[]int | len:4, cap:4>: [1, 3, 2, 0]
+to be monotonically increasing
+The first violation is element 2:
+    <int>: 2
+which is not greater than or equal to element 1:
+    <int>: 3`))
+		})
+
+		It("should describe strict decreasing orderings", func() {
+			m := BeStrictlyDecreasing()
+			Expect(m.Match([]int{3, 3})).Should(BeFalse())
+			Expect(m.FailureMessage([]int{3, 3})).Should(ContainSubstring("to be strictly decreasing\nThe first violation is element 1:\n    <int>: 3\nwhich is not less than element 0:"))
+		})
+
+		It("should have a negated failure message", func() {
+			m := BeMonotonicallyDecreasing()
+			Expect(m.Match([]int{3, 2})).Should(BeTrue())
+			Expect(m.NegatedFailureMessage([]int{3, 2})).Should(Equal("Expected\n    <[]int | len:2, cap:2>: [3, 2]\nnot to be monotonically decreasing"))
+		})
+	})
+
+	Context("when passed invalid input", func() {
+		It("should error", func() {
+			success, err := BeMonotonicallyIncreasing().Match(3)
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError(ContainSubstring("BeMonotonicallyIncreasing matcher expects an array or slice")))
+
+			success, err = BeStrictlyIncreasing().Match([]interface{}{1, "two"})
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError(ContainSubstring("BeStrictlyIncreasing matcher cannot compare elements 0 and 1")))
+
+			_, err = BeMonotonicallyIncreasing().Match([]interface{}{time.Now(), 3})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,31 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeNewerVersionThanMatcher struct {
+	Version string
+}
+
+func (matcher *BeNewerVersionThanMatcher) Match(actual interface{}) (success bool, err error) {
+	version, err := parseSemVer(matcher.Version)
+	if err != nil {
+		return false, fmt.Errorf("BeNewerVersionThan matcher expects to be passed a semantic version, but %s", err)
+	}
+	actualVersion, err := toSemVer("BeNewerVersionThan", actual)
+	if err != nil {
+		return false, err
+	}
+	return actualVersion.compare(version) > 0, nil
+}
+
+func (matcher *BeNewerVersionThanMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to be a newer version than", matcher.Version)
+}
+
+func (matcher *BeNewerVersionThanMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to be a newer version than", matcher.Version)
+}
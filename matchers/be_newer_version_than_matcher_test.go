@@ -0,0 +1,41 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeNewerVersionThan", func() {
+	It("should compare versions by precedence", func() {
+		Expect("1.4.4").Should(BeNewerVersionThan("1.4.3"))
+		Expect("1.10.0").Should(BeNewerVersionThan("1.9.2"))
+		Expect("v2.0.0").Should(BeNewerVersionThan("1.99.99"))
+		Expect("2.0.0").Should(BeNewerVersionThan("2.0.0-rc.2"))
+		Expect("2.0.0-rc.10").Should(BeNewerVersionThan("2.0.0-rc.2"))
+		Expect("2.0.0-rc.1").Should(BeNewerVersionThan("2.0.0-rc"))
+		Expect("2.0.0-rc").Should(BeNewerVersionThan("2.0.0-1"))
+
+		Expect("1.4.3").ShouldNot(BeNewerVersionThan("1.4.3"))
+		Expect("1.4.3+build.2").ShouldNot(BeNewerVersionThan("1.4.3+build.1"))
+		Expect("1.4.2").ShouldNot(BeNewerVersionThan("1.4.3"))
+		Expect("2.0.0-rc.2").ShouldNot(BeNewerVersionThan("2.0.0"))
+	})
+
+	It("should error when either version is not a semantic version", func() {
+		_, err := BeNewerVersionThan("1.4").Match("1.4.3")
+		Expect(err).Should(MatchError(`BeNewerVersionThan matcher expects to be passed a semantic version, but "1.4" does not have major, minor and patch versions`))
+		_, err = BeNewerVersionThan("1.4.3").Match("latest")
+		Expect(err).Should(MatchError(`BeNewerVersionThan matcher expects a semantic version, but "latest" has an invalid version number "latest"`))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect("1.4.2").Should(BeNewerVersionThan("1.4.3"))
+			Expect("1.4.4").ShouldNot(BeNewerVersionThan("1.4.3"))
+		})
+		Expect(failures).Should(Equal([]string{
+			"Expected\n    <string>: 1.4.2\nto be a newer version than\n    <string>: 1.4.3",
+			"Expected\n    <string>: 1.4.4\nnot to be a newer version than\n    <string>: 1.4.3",
+		}))
+	})
+})
@@ -0,0 +1,21 @@
+package matchers
+
+import (
+	"github.com/onsi/gomega/format"
+)
+
+type BeNonZeroMatcher struct {
+}
+
+func (matcher *BeNonZeroMatcher) Match(actual interface{}) (success bool, err error) {
+	isZero, err := (&BeZeroMatcher{}).Match(actual)
+	return !isZero, err
+}
+
+func (matcher *BeNonZeroMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to be zero-valued")
+}
+
+func (matcher *BeNonZeroMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return zeroValuedFailureMessage(actual)
+}
@@ -0,0 +1,35 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeNonZero", func() {
+	It("succeeds for values that are not zero-valued", func() {
+		Expect(nil).ShouldNot(BeNonZero())
+
+		Expect("").ShouldNot(BeNonZero())
+		Expect(" ").Should(BeNonZero())
+
+		Expect(0).ShouldNot(BeNonZero())
+		Expect(1).Should(BeNonZero())
+
+		Expect([]int(nil)).ShouldNot(BeNonZero())
+		Expect([]int{}).Should(BeNonZero())
+
+		Expect(myCustomType{}).ShouldNot(BeNonZero())
+		Expect(myCustomType{s: "a"}).Should(BeNonZero())
+	})
+
+	It("builds failure message", func() {
+		actual := BeNonZero().FailureMessage(0)
+		Expect(actual).To(Equal("Expected\n    <int>: 0\nnot to be zero-valued"))
+	})
+
+	It("names the first non-zero field in the negated failure message", func() {
+		type point struct{ X, Y int }
+		actual := BeNonZero().NegatedFailureMessage(point{Y: 3})
+		Expect(actual).To(HaveSuffix("\nto be zero-valued, but field Y is non-zero:\n    <int>: 3"))
+	})
+})
@@ -0,0 +1,124 @@
+package matchers
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+)
+
+// RelativeToleranceFraction is a tolerance expressed as a fraction of the larger of the magnitudes of actual and expected.
+type RelativeToleranceFraction float64
+
+// ULPToleranceCount is a tolerance expressed as a number of units in the last place - i.e. the number of representable
+// floating point values that may lie between actual and expected.
+type ULPToleranceCount uint64
+
+type BeNumericallyCloseToMatcher struct {
+	Expected  interface{}
+	Tolerance interface{}
+
+	// state
+	delta string
+}
+
+func (matcher *BeNumericallyCloseToMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.delta = ""
+	if !isNumber(actual) {
+		return false, fmt.Errorf("BeNumericallyCloseTo matcher expects a number.  Got:\n%s", format.Object(actual, 1))
+	}
+	if !isNumber(matcher.Expected) {
+		return false, fmt.Errorf("BeNumericallyCloseTo matcher expects a number to compare to.  Got:\n%s", format.Object(matcher.Expected, 1))
+	}
+
+	a, e := toFloat(actual), toFloat(matcher.Expected)
+	if math.IsNaN(a) || math.IsNaN(e) {
+		matcher.delta = "NaN is never close to anything"
+		return false, nil
+	}
+
+	switch tolerance := matcher.Tolerance.(type) {
+	case RelativeToleranceFraction:
+		if tolerance < 0 || math.IsNaN(float64(tolerance)) {
+			return false, fmt.Errorf("BeNumericallyCloseTo matcher requires a non-negative relative tolerance.  Got: %v", tolerance)
+		}
+		relativeDifference := relativeDifference(a, e)
+		matcher.delta = fmt.Sprintf("the relative difference was %v", relativeDifference)
+		return relativeDifference <= float64(tolerance), nil
+	case ULPToleranceCount:
+		distance := ulpDistance(a, e, reflect.TypeOf(actual).Kind() == reflect.Float32)
+		matcher.delta = fmt.Sprintf("the distance was %d ULPs", distance)
+		return distance <= uint64(tolerance), nil
+	default:
+		if !isNumber(tolerance) {
+			return false, fmt.Errorf("BeNumericallyCloseTo matcher expects the tolerance to be a number, a RelativeTolerance(), or a ULPTolerance().  Got:\n%s", format.Object(tolerance, 1))
+		}
+		t := toFloat(tolerance)
+		if t < 0 || math.IsNaN(t) {
+			return false, fmt.Errorf("BeNumericallyCloseTo matcher requires a non-negative tolerance.  Got: %v", tolerance)
+		}
+		absoluteDifference := math.Abs(a - e)
+		matcher.delta = fmt.Sprintf("the absolute difference was %v", absoluteDifference)
+		return absoluteDifference <= t, nil
+	}
+}
+
+func (matcher *BeNumericallyCloseToMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("to be within %s of", matcher.toleranceDescription()), matcher.Expected) + "\n" + matcher.delta
+}
+
+func (matcher *BeNumericallyCloseToMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("not to be within %s of", matcher.toleranceDescription()), matcher.Expected) + "\n" + matcher.delta
+}
+
+func (matcher *BeNumericallyCloseToMatcher) toleranceDescription() string {
+	switch tolerance := matcher.Tolerance.(type) {
+	case RelativeToleranceFraction:
+		return fmt.Sprintf("a relative tolerance of %v", float64(tolerance))
+	case ULPToleranceCount:
+		return fmt.Sprintf("%d ULPs", uint64(tolerance))
+	default:
+		return fmt.Sprintf("%v", tolerance)
+	}
+}
+
+func relativeDifference(a, e float64) float64 {
+	if a == e {
+		return 0
+	}
+	return math.Abs(a-e) / math.Max(math.Abs(a), math.Abs(e))
+}
+
+// ulpDistance returns the number of representable floating point values between a and e.  When asFloat32 is set
+// the distance is measured between float32 representations.
+func ulpDistance(a, e float64, asFloat32 bool) uint64 {
+	var oa, oe int64
+	if asFloat32 {
+		oa, oe = orderedFloat32Bits(float32(a)), orderedFloat32Bits(float32(e))
+	} else {
+		oa, oe = orderedFloat64Bits(a), orderedFloat64Bits(e)
+	}
+	if oa < oe {
+		oa, oe = oe, oa
+	}
+	return uint64(oa) - uint64(oe)
+}
+
+// orderedFloat64Bits maps a float64 onto an int64 such that adjacent floats map onto adjacent integers
+// (and +0 and -0 both map onto 0)
+func orderedFloat64Bits(f float64) int64 {
+	bits := math.Float64bits(f)
+	if bits&(1<<63) != 0 {
+		return -int64(bits &^ (1 << 63))
+	}
+	return int64(bits)
+}
+
+func orderedFloat32Bits(f float32) int64 {
+	bits := math.Float32bits(f)
+	if bits&(1<<31) != 0 {
+		return -int64(bits &^ (1 << 31))
+	}
+	return int64(bits)
+}
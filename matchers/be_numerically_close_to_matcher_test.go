@@ -0,0 +1,102 @@
+package matchers_test
+
+import (
+	"math"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("BeNumericallyCloseTo", func() {
+	Context("with an absolute tolerance", func() {
+		It("should compare numbers of any type", func() {
+			Expect(3.14159).Should(BeNumericallyCloseTo(math.Pi, 1e-5))
+			Expect(3.14159).ShouldNot(BeNumericallyCloseTo(math.Pi, 1e-6))
+			Expect(10).Should(BeNumericallyCloseTo(12, 2))
+			Expect(uint8(10)).ShouldNot(BeNumericallyCloseTo(int64(13), 2))
+			Expect(float32(1.5)).Should(BeNumericallyCloseTo(2, 0.5))
+		})
+
+		It("should report the absolute difference", func() {
+			m := BeNumericallyCloseTo(10, 2)
+			Expect(m.Match(14)).Should(BeFalse())
+			Expect(m.FailureMessage(14)).Should(Equal("Expected\n    <int>: 14\nto be within 2 of\n    <int>: 10\nthe absolute difference was 4"))
+		})
+	})
+
+	Context("with a relative tolerance", func() {
+		It("should compare relative to the larger magnitude", func() {
+			Expect(6.02214076e23).Should(BeNumericallyCloseTo(6.0221407e23, RelativeTolerance(1e-7)))
+			Expect(6.02214076e23).ShouldNot(BeNumericallyCloseTo(6.0221407e23, RelativeTolerance(1e-9)))
+			Expect(100).Should(BeNumericallyCloseTo(110, RelativeTolerance(0.1)))
+			Expect(100).ShouldNot(BeNumericallyCloseTo(112, RelativeTolerance(0.1)))
+			Expect(0.0).Should(BeNumericallyCloseTo(0, RelativeTolerance(0)))
+		})
+
+		It("should report the relative difference", func() {
+			m := BeNumericallyCloseTo(100, RelativeTolerance(0.1))
+			Expect(m.Match(125)).Should(BeFalse())
+			Expect(m.FailureMessage(125)).Should(Equal("Expected\n    <int>: 125\nto be within a relative tolerance of 0.1 of\n    <int>: 100\nthe relative difference was 0.2"))
+		})
+	})
+
+	Context("with a ULP tolerance", func() {
+		var a, b = 0.1, 0.2
+
+		It("should count the representable values between actual and expected", func() {
+			Expect(1.0).Should(BeNumericallyCloseTo(1.0, ULPTolerance(0)))
+			Expect(math.Nextafter(1.0, 2)).Should(BeNumericallyCloseTo(1.0, ULPTolerance(1)))
+			Expect(math.Nextafter(math.Nextafter(1.0, 2), 2)).ShouldNot(BeNumericallyCloseTo(1.0, ULPTolerance(1)))
+			Expect(a + b).Should(BeNumericallyCloseTo(0.3, ULPTolerance(1)))
+			Expect(a + b).ShouldNot(BeNumericallyCloseTo(0.3, ULPTolerance(0)))
+		})
+
+		It("should straddle zero", func() {
+			Expect(math.Copysign(0, -1)).Should(BeNumericallyCloseTo(0.0, ULPTolerance(0)))
+			Expect(-math.SmallestNonzeroFloat64).Should(BeNumericallyCloseTo(math.SmallestNonzeroFloat64, ULPTolerance(2)))
+			Expect(-math.SmallestNonzeroFloat64).ShouldNot(BeNumericallyCloseTo(math.SmallestNonzeroFloat64, ULPTolerance(1)))
+		})
+
+		It("should measure float32 actuals in float32 space", func() {
+			Expect(math.Nextafter32(1.0, 2)).Should(BeNumericallyCloseTo(1.0, ULPTolerance(1)))
+			Expect(float64(math.Nextafter32(1.0, 2))).ShouldNot(BeNumericallyCloseTo(1.0, ULPTolerance(1)))
+		})
+
+		It("should report the distance", func() {
+			m := BeNumericallyCloseTo(0.3, ULPTolerance(0))
+			Expect(m.Match(a + b)).Should(BeFalse())
+			Expect(m.FailureMessage(a + b)).Should(HaveSuffix("to be within 0 ULPs of\n    <float64>: 0.3\nthe distance was 1 ULPs"))
+		})
+	})
+
+	It("should never consider NaN close", func() {
+		Expect(math.NaN()).ShouldNot(BeNumericallyCloseTo(math.NaN(), 1))
+		Expect(1.0).ShouldNot(BeNumericallyCloseTo(math.NaN(), ULPTolerance(math.MaxUint64)))
+	})
+
+	It("should report the difference in the negated failure message", func() {
+		m := BeNumericallyCloseTo(10, 2)
+		Expect(m.Match(11)).Should(BeTrue())
+		Expect(m.NegatedFailureMessage(11)).Should(Equal("Expected\n    <int>: 11\nnot to be within 2 of\n    <int>: 10\nthe absolute difference was 1"))
+	})
+
+	Context("when passed invalid arguments", func() {
+		It("should error", func() {
+			_, err := (&BeNumericallyCloseToMatcher{Expected: 1, Tolerance: 1}).Match("one")
+			Expect(err).Should(HaveOccurred())
+
+			_, err = (&BeNumericallyCloseToMatcher{Expected: "one", Tolerance: 1}).Match(1)
+			Expect(err).Should(HaveOccurred())
+
+			_, err = (&BeNumericallyCloseToMatcher{Expected: 1, Tolerance: "one"}).Match(1)
+			Expect(err).Should(HaveOccurred())
+
+			_, err = (&BeNumericallyCloseToMatcher{Expected: 1, Tolerance: -1}).Match(1)
+			Expect(err).Should(HaveOccurred())
+
+			_, err = (&BeNumericallyCloseToMatcher{Expected: 1, Tolerance: RelativeToleranceFraction(-0.1)}).Match(1)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
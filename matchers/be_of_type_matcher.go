@@ -0,0 +1,30 @@
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeOfTypeMatcher struct {
+	Type reflect.Type
+}
+
+func (matcher *BeOfTypeMatcher) Match(actual interface{}) (success bool, err error) {
+	if matcher.Type == nil {
+		return false, fmt.Errorf("BeOfType matcher expects a type")
+	}
+	if matcher.Type.Kind() == reflect.Interface {
+		return false, fmt.Errorf("BeOfType matcher expects a concrete type, but %s is an interface.  Use ImplementInterface instead.", matcher.Type)
+	}
+	return reflect.TypeOf(actual) == matcher.Type, nil
+}
+
+func (matcher *BeOfTypeMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("to be of type %s", matcher.Type))
+}
+
+func (matcher *BeOfTypeMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("not to be of type %s", matcher.Type))
+}
@@ -0,0 +1,56 @@
+package matchers_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+type myInt int
+
+var _ = Describe("BeOfType", func() {
+	It("succeeds if actual's dynamic type is exactly the type", func() {
+		Expect(3).Should(BeOfType[int]())
+		Expect("3").Should(BeOfType[string]())
+		Expect(&fs.PathError{}).Should(BeOfType[*fs.PathError]())
+
+		Expect(myInt(3)).ShouldNot(BeOfType[int]())
+		Expect(3).ShouldNot(BeOfType[myInt]())
+		Expect(fs.PathError{}).ShouldNot(BeOfType[*fs.PathError]())
+		Expect(nil).ShouldNot(BeOfType[*fs.PathError]())
+	})
+
+	It("checks the dynamic type of interface values", func() {
+		var err error = &fs.PathError{}
+		Expect(err).Should(BeOfType[*fs.PathError]())
+		Expect(fmt.Errorf("wrapped: %w", err)).ShouldNot(BeOfType[*fs.PathError]())
+	})
+
+	It("errors when the type is an interface", func() {
+		success, err := BeOfType[io.Reader]().Match(errors.New("boom"))
+		Expect(success).Should(BeFalse())
+		Expect(err).Should(MatchError("BeOfType matcher expects a concrete type, but io.Reader is an interface.  Use ImplementInterface instead."))
+
+		_, err = (&BeOfTypeMatcher{}).Match(3)
+		Expect(err).Should(MatchError("BeOfType matcher expects a type"))
+	})
+
+	Describe("failure messages", func() {
+		It("reports the expected type", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(myInt(3)).Should(BeOfType[int]())
+			})
+			Expect(failures).Should(ConsistOf("Expected\n    <matchers_test.myInt>: 3\nto be of type int"))
+
+			failures = InterceptGomegaFailures(func() {
+				Expect(3).ShouldNot(BeOfType[int]())
+			})
+			Expect(failures).Should(ConsistOf("Expected\n    <int>: 3\nnot to be of type int"))
+		})
+	})
+})
@@ -0,0 +1,36 @@
+package matchers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeOneOfMatcher struct {
+	Elements []interface{}
+}
+
+func (matcher *BeOneOfMatcher) Match(actual interface{}) (success bool, err error) {
+	if len(matcher.Elements) == 0 {
+		return false, fmt.Errorf("BeOneOf matcher requires at least one candidate")
+	}
+
+	return matchesAnyCandidate(actual, matcher.Elements)
+}
+
+func (matcher *BeOneOfMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to be one of") + "\n" + matcher.formattedCandidates()
+}
+
+func (matcher *BeOneOfMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to be one of") + "\n" + matcher.formattedCandidates()
+}
+
+func (matcher *BeOneOfMatcher) formattedCandidates() string {
+	candidates := make([]string, len(matcher.Elements))
+	for i, element := range matcher.Elements {
+		candidates[i] = format.Object(element, 1)
+	}
+	return strings.Join(candidates, "\n")
+}
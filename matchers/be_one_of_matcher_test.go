@@ -0,0 +1,68 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("BeOneOf", func() {
+	When("passed values", func() {
+		It("should succeed if actual equals one of them", func() {
+			Expect(2).Should(BeOneOf(1, 2, 3))
+			Expect(4).ShouldNot(BeOneOf(1, 2, 3))
+
+			Expect("abc").Should(BeOneOf("abc"))
+			Expect("abc").ShouldNot(BeOneOf("def", "ghi"))
+		})
+
+		It("should be strict about types", func() {
+			Expect(2).ShouldNot(BeOneOf(int64(2), 2.0))
+		})
+
+		It("should not unpack slices", func() {
+			Expect([]int{1, 2}).Should(BeOneOf([]int{1, 2}, []int{3}))
+			Expect(1).ShouldNot(BeOneOf([]int{1, 2}))
+		})
+	})
+
+	When("passed matchers", func() {
+		It("should succeed if actual satisfies one of them", func() {
+			Expect("foobar").Should(BeOneOf(HavePrefix("bar"), HaveSuffix("bar")))
+			Expect("foobar").ShouldNot(BeOneOf(HavePrefix("bar"), HaveLen(3)))
+		})
+
+		It("should allow mixing values and matchers", func() {
+			Expect(5).Should(BeOneOf(1, 2, BeNumerically(">", 4)))
+			Expect(3).ShouldNot(BeOneOf(1, 2, BeNumerically(">", 4)))
+		})
+	})
+
+	When("a candidate errors", func() {
+		It("should succeed if another candidate matches", func() {
+			Expect(3).Should(BeOneOf(BeEmpty(), 3))
+		})
+
+		It("should return the error if no candidate matches", func() {
+			success, err := (&BeOneOfMatcher{Elements: []interface{}{BeEmpty(), 2}}).Match(3)
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError(ContainSubstring("BeEmpty matcher expects")))
+		})
+	})
+
+	It("should error when passed no candidates", func() {
+		success, err := (&BeOneOfMatcher{}).Match(3)
+		Expect(success).Should(BeFalse())
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("lists every candidate in the failure message", func() {
+		actual := BeOneOf(1, 2, "three").FailureMessage(4)
+		Expect(actual).To(Equal("Expected\n    <int>: 4\nto be one of\n    <int>: 1\n    <int>: 2\n    <string>: three"))
+	})
+
+	It("lists every candidate in the negated failure message", func() {
+		actual := BeOneOf(1, 2).NegatedFailureMessage(2)
+		Expect(actual).To(Equal("Expected\n    <int>: 2\nnot to be one of\n    <int>: 1\n    <int>: 2"))
+	})
+})
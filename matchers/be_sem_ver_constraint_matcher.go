@@ -0,0 +1,36 @@
+package matchers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeSemVerConstraintMatcher struct {
+	Constraint string
+
+	// state
+	unsatisfied []string
+}
+
+func (matcher *BeSemVerConstraintMatcher) Match(actual interface{}) (success bool, err error) {
+	constraint, err := parseSemVerConstraint(matcher.Constraint)
+	if err != nil {
+		return false, fmt.Errorf("BeSemVerConstraint matcher expects a valid version constraint, but %s", err)
+	}
+	version, err := toSemVer("BeSemVerConstraint", actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.unsatisfied = constraint.unsatisfied(version)
+	return matcher.unsatisfied == nil, nil
+}
+
+func (matcher *BeSemVerConstraintMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to satisfy the version constraint", matcher.Constraint) + fmt.Sprintf("\nbut it does not satisfy %s", strings.Join(matcher.unsatisfied, " or "))
+}
+
+func (matcher *BeSemVerConstraintMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to satisfy the version constraint", matcher.Constraint)
+}
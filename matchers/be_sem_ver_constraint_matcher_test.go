@@ -0,0 +1,65 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeSemVerConstraint", func() {
+	DescribeTable("evaluating constraints",
+		func(constraint string, satisfying []string, notSatisfying []string) {
+			for _, version := range satisfying {
+				Expect(version).Should(BeSemVerConstraint(constraint))
+			}
+			for _, version := range notSatisfying {
+				Expect(version).ShouldNot(BeSemVerConstraint(constraint))
+			}
+		},
+		Entry("equal", "1.2.3", []string{"1.2.3", "v1.2.3", "1.2.3+build.1"}, []string{"1.2.4", "1.2.3-rc.1"}),
+		Entry("explicitly equal", "= 1.2.3", []string{"1.2.3"}, []string{"1.2.4"}),
+		Entry("partially equal", "=1.2", []string{"1.2.0", "1.2.7"}, []string{"1.3.0", "1.1.9"}),
+		Entry("not equal", "!= 1.2", []string{"1.3.0", "1.1.9"}, []string{"1.2.0", "1.2.7"}),
+		Entry("greater", "> 1.2.3", []string{"1.2.4", "2.0.0"}, []string{"1.2.3", "1.2.3-rc.1"}),
+		Entry("partially greater", "> 1.2", []string{"1.3.0"}, []string{"1.2.9"}),
+		Entry("greater or equal", ">= 1.2", []string{"1.2.0", "1.3.0"}, []string{"1.1.9", "1.2.0-rc.1"}),
+		Entry("less", "< 2", []string{"1.99.99", "2.0.0-rc.1"}, []string{"2.0.0"}),
+		Entry("less or equal", "<= 1.2.3", []string{"1.2.3", "1.0.0"}, []string{"1.2.4"}),
+		Entry("partially less or equal", "<= 1.2", []string{"1.2.99"}, []string{"1.3.0"}),
+		Entry("tilde", "~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.2.2", "1.3.0"}),
+		Entry("tilde with a major version", "~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}),
+		Entry("caret", "^1.2.3", []string{"1.2.3", "1.9.0"}, []string{"1.2.2", "2.0.0"}),
+		Entry("caret with a zero major version", "^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}),
+		Entry("caret with zero major and minor versions", "^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}),
+		Entry("caret with a partial zero version", "^0", []string{"0.9.9"}, []string{"1.0.0"}),
+		Entry("conjunctions", ">= 1.2.0, < 2", []string{"1.2.0", "1.9.9"}, []string{"1.1.0", "2.0.0"}),
+		Entry("disjunctions", "< 1 || >= 2.1, != 2.2.0", []string{"0.9.0", "2.1.0", "2.2.1"}, []string{"1.0.0", "2.0.9", "2.2.0"}),
+		Entry("pre-release precedence", ">= 1.0.0-alpha.2", []string{"1.0.0-alpha.10", "1.0.0-beta", "1.0.0"}, []string{"1.0.0-alpha.1", "1.0.0-alpha"}),
+	)
+
+	It("should error when the constraint is invalid", func() {
+		_, err := BeSemVerConstraint(">= 1.2.0,").Match("1.2.0")
+		Expect(err).Should(MatchError("BeSemVerConstraint matcher expects a valid version constraint, but it has an empty comparison"))
+		_, err = BeSemVerConstraint(">> 1.2.0").Match("1.2.0")
+		Expect(err).Should(MatchError(`BeSemVerConstraint matcher expects a valid version constraint, but ">> 1.2.0" is not a valid comparison: "> 1.2.0" has an invalid version number "> 1"`))
+	})
+
+	It("should error when actual is not a semantic version", func() {
+		_, err := BeSemVerConstraint(">= 1.2.0").Match("1.2")
+		Expect(err).Should(MatchError(`BeSemVerConstraint matcher expects a semantic version, but "1.2" does not have major, minor and patch versions`))
+		_, err = BeSemVerConstraint(">= 1.2.0").Match(1.2)
+		Expect(err).Should(MatchError(ContainSubstring("BeSemVerConstraint matcher expects a string or []byte.  Got:")))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect("2.1.0").Should(BeSemVerConstraint(">= 1.2.0, < 2"))
+			Expect("1.5.0").Should(BeSemVerConstraint("< 1 || >= 2"))
+			Expect("1.5.0").ShouldNot(BeSemVerConstraint(">= 1.2.0, < 2"))
+		})
+		Expect(failures).Should(Equal([]string{
+			"Expected\n    <string>: 2.1.0\nto satisfy the version constraint\n    <string>: >= 1.2.0, < 2\nbut it does not satisfy < 2",
+			"Expected\n    <string>: 1.5.0\nto satisfy the version constraint\n    <string>: < 1 || >= 2\nbut it does not satisfy < 1 or >= 2",
+			"Expected\n    <string>: 1.5.0\nnot to satisfy the version constraint\n    <string>: >= 1.2.0, < 2",
+		}))
+	})
+})
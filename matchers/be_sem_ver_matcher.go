@@ -0,0 +1,42 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeSemVerMatcher struct {
+	// state
+	parseErr error
+}
+
+func (matcher *BeSemVerMatcher) Match(actual interface{}) (success bool, err error) {
+	version, ok := toString(actual)
+	if !ok {
+		return false, fmt.Errorf("BeSemVer matcher expects a string or []byte.  Got:\n%s", format.Object(actual, 1))
+	}
+	_, matcher.parseErr = parseSemVer(version)
+	return matcher.parseErr == nil, nil
+}
+
+func (matcher *BeSemVerMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to be a semantic version, but "+matcher.parseErr.Error())
+}
+
+func (matcher *BeSemVerMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to be a semantic version")
+}
+
+// toSemVer parses actual, which must be a string or []byte holding a semantic version
+func toSemVer(matcherName string, actual interface{}) (semVer, error) {
+	version, ok := toString(actual)
+	if !ok {
+		return semVer{}, fmt.Errorf("%s matcher expects a string or []byte.  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+	v, err := parseSemVer(version)
+	if err != nil {
+		return semVer{}, fmt.Errorf("%s matcher expects a semantic version, but %s", matcherName, err)
+	}
+	return v, nil
+}
@@ -0,0 +1,49 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeSemVer", func() {
+	It("should succeed for semantic versions", func() {
+		Expect("1.4.3").Should(BeSemVer())
+		Expect("v1.4.3").Should(BeSemVer())
+		Expect("0.0.0").Should(BeSemVer())
+		Expect("2.0.0-rc.1").Should(BeSemVer())
+		Expect("1.0.0-alpha-beta.0.x-7").Should(BeSemVer())
+		Expect("1.0.0+build.5").Should(BeSemVer())
+		Expect("1.0.0-rc.1+sha.5114f85").Should(BeSemVer())
+		Expect([]byte("1.4.3")).Should(BeSemVer())
+	})
+
+	It("should fail for anything else", func() {
+		Expect("").ShouldNot(BeSemVer())
+		Expect("1.4").ShouldNot(BeSemVer())
+		Expect("1.4.3.2").ShouldNot(BeSemVer())
+		Expect("01.4.3").ShouldNot(BeSemVer())
+		Expect("1.4.x").ShouldNot(BeSemVer())
+		Expect("1.4.3-").ShouldNot(BeSemVer())
+		Expect("1.4.3-rc..1").ShouldNot(BeSemVer())
+		Expect("1.4.3-rc.01").ShouldNot(BeSemVer())
+		Expect("1.4.3+build_5").ShouldNot(BeSemVer())
+	})
+
+	It("should error when actual is not a string", func() {
+		_, err := BeSemVer().Match(1)
+		Expect(err).Should(MatchError(ContainSubstring("BeSemVer matcher expects a string or []byte.  Got:")))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect("1.4").Should(BeSemVer())
+			Expect("1.4.3-rc.01").Should(BeSemVer())
+			Expect("1.4.3").ShouldNot(BeSemVer())
+		})
+		Expect(failures).Should(Equal([]string{
+			"Expected\n    <string>: 1.4\nto be a semantic version, but \"1.4\" does not have major, minor and patch versions",
+			"Expected\n    <string>: 1.4.3-rc.01\nto be a semantic version, but \"1.4.3-rc.01\" has an invalid pre-release version",
+			"Expected\n    <string>: 1.4.3\nnot to be a semantic version",
+		}))
+	})
+})
@@ -0,0 +1,57 @@
+package matchers
+
+import (
+	"crypto/x509"
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeSignedByMatcher struct {
+	Roots *x509.CertPool
+
+	// state
+	certificate *x509.Certificate
+	chain       []*x509.Certificate
+	verifyErr   error
+}
+
+func (matcher *BeSignedByMatcher) Match(actual interface{}) (success bool, err error) {
+	if matcher.Roots == nil {
+		return false, fmt.Errorf("BeSignedBy matcher expects a pool of root certificates, got nil")
+	}
+	var intermediates []*x509.Certificate
+	matcher.certificate, intermediates, err = toCertificate("BeSignedBy", actual)
+	if err != nil {
+		return false, err
+	}
+
+	intermediatePool := x509.NewCertPool()
+	for _, intermediate := range intermediates {
+		intermediatePool.AddCert(intermediate)
+	}
+	// BeSignedBy asserts on who signed the certificate, not on whether it is valid now or for a particular use - see
+	// BeValidAt.  So the chain is verified as of the time the certificate became valid: every certificate in the chain
+	// must have been valid then, as they are when a certificate is properly issued.
+	chains, err := matcher.certificate.Verify(x509.VerifyOptions{
+		Roots:         matcher.Roots,
+		Intermediates: intermediatePool,
+		CurrentTime:   matcher.certificate.NotBefore,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	matcher.verifyErr = err
+	if err != nil {
+		return false, nil
+	}
+	matcher.chain = chains[0]
+	return true, nil
+}
+
+func (matcher *BeSignedByMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected certificate %s to be signed by one of the passed in roots, but verifying it failed with:\n%s", describeCertificate(matcher.certificate), format.IndentString(matcher.verifyErr.Error(), 1))
+}
+
+func (matcher *BeSignedByMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	root := matcher.chain[len(matcher.chain)-1]
+	return fmt.Sprintf("Expected certificate %s not to be signed by one of the passed in roots, but it chains to %s", describeCertificate(matcher.certificate), describeCertificate(root))
+}
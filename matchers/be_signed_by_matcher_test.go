@@ -0,0 +1,69 @@
+package matchers_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeSignedBy", func() {
+	var root, otherRoot, intermediate, leaf testCertificate
+	var roots, otherRoots *x509.CertPool
+
+	BeforeEach(func() {
+		root = newTestCertificate(1, "Test Root CA", nil, nil)
+		otherRoot = newTestCertificate(2, "Other Root CA", nil, nil)
+		intermediate = newTestCertificate(3, "Test Intermediate CA", nil, &root)
+		leaf = newTestCertificate(4, "api.example.com", []string{"api.example.com"}, &intermediate)
+
+		roots = x509.NewCertPool()
+		roots.AddCert(root.Certificate)
+		otherRoots = x509.NewCertPool()
+		otherRoots.AddCert(otherRoot.Certificate)
+	})
+
+	It("should succeed when the certificate chains to one of the roots", func() {
+		Expect(intermediate.Certificate).Should(BeSignedBy(roots))
+		Expect(intermediate.Certificate).ShouldNot(BeSignedBy(otherRoots))
+	})
+
+	It("should use the peer's other certificates as intermediates", func() {
+		Expect(leaf.Certificate).ShouldNot(BeSignedBy(roots))
+		state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf.Certificate, intermediate.Certificate}}
+		Expect(state).Should(BeSignedBy(roots))
+		Expect(state).ShouldNot(BeSignedBy(otherRoots))
+	})
+
+	It("should verify the chain as of the time the certificate became valid", func() {
+		// the test certificates have all expired, which BeSignedBy ignores
+		Expect(intermediate.Certificate).Should(BeSignedBy(roots))
+
+		original := certificateValidity
+		DeferCleanup(func() { certificateValidity = original })
+		certificateValidity.notBefore = original.notBefore.Add(24 * time.Hour)
+		laterRoot := newTestCertificate(5, "Later Root CA", nil, nil)
+		laterRoots := x509.NewCertPool()
+		laterRoots.AddCert(laterRoot.Certificate)
+		certificateValidity = original
+		issuedEarlier := newTestCertificate(6, "Test Intermediate CA", nil, &laterRoot)
+		Expect(issuedEarlier.Certificate).ShouldNot(BeSignedBy(laterRoots))
+	})
+
+	It("should error when not passed roots", func() {
+		_, err := BeSignedBy(nil).Match(root.Certificate)
+		Expect(err).Should(MatchError("BeSignedBy matcher expects a pool of root certificates, got nil"))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect(intermediate.Certificate).Should(BeSignedBy(otherRoots))
+			Expect(intermediate.Certificate).ShouldNot(BeSignedBy(roots))
+		})
+		Expect(failures).Should(HaveLen(2))
+		Expect(failures[0]).Should(HavePrefix("Expected certificate \"CN=Test Intermediate CA\" (serial 3) to be signed by one of the passed in roots, but verifying it failed with:\n    x509: certificate signed by unknown authority"))
+		Expect(failures[1]).Should(Equal("Expected certificate \"CN=Test Intermediate CA\" (serial 3) not to be signed by one of the passed in roots, but it chains to \"CN=Test Root CA\" (serial 1)"))
+	})
+})
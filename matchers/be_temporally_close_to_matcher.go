@@ -0,0 +1,53 @@
+package matchers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeTemporallyCloseToMatcher struct {
+	Expected time.Time
+	Window   time.Duration
+
+	// state
+	difference time.Duration
+}
+
+func (matcher *BeTemporallyCloseToMatcher) Match(actual interface{}) (success bool, err error) {
+	actualTime, ok := actual.(time.Time)
+	if !ok {
+		return false, fmt.Errorf("BeTemporallyCloseTo matcher expects a time.Time.  Got:\n%s", format.Object(actual, 1))
+	}
+	if matcher.Window < 0 {
+		return false, fmt.Errorf("BeTemporallyCloseTo matcher requires a non-negative window.  Got: %s", matcher.Window)
+	}
+
+	matcher.difference = wallClockDifference(actualTime, matcher.Expected)
+	return -matcher.Window <= matcher.difference && matcher.difference <= matcher.Window, nil
+}
+
+func (matcher *BeTemporallyCloseToMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("to be within %s of", matcher.Window), matcher.Expected) + "\n" + describeWallClockDifference(matcher.difference, "the expected time")
+}
+
+func (matcher *BeTemporallyCloseToMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("not to be within %s of", matcher.Window), matcher.Expected) + "\n" + describeWallClockDifference(matcher.difference, "the expected time")
+}
+
+// wallClockDifference returns a - b, ignoring monotonic clock readings.  Locations never affect the result.
+func wallClockDifference(a, b time.Time) time.Duration {
+	return a.Round(0).Sub(b.Round(0))
+}
+
+func describeWallClockDifference(difference time.Duration, reference string) string {
+	switch {
+	case difference > 0:
+		return fmt.Sprintf("it was %s after %s", difference, reference)
+	case difference < 0:
+		return fmt.Sprintf("it was %s before %s", -difference, reference)
+	default:
+		return fmt.Sprintf("it was the same instant as %s", reference)
+	}
+}
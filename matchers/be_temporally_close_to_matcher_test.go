@@ -0,0 +1,54 @@
+package matchers_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeTemporallyCloseTo", func() {
+	var t0 time.Time
+
+	BeforeEach(func() {
+		t0 = time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	It("should succeed when actual is within the window", func() {
+		Expect(t0.Add(time.Second)).Should(BeTemporallyCloseTo(t0, time.Second))
+		Expect(t0.Add(-time.Second)).Should(BeTemporallyCloseTo(t0, time.Second))
+		Expect(t0.Add(time.Second + 1)).ShouldNot(BeTemporallyCloseTo(t0, time.Second))
+	})
+
+	It("should ignore locations", func() {
+		tokyo := time.FixedZone("JST", 9*60*60)
+		Expect(t0.In(tokyo)).Should(BeTemporallyCloseTo(t0, 0))
+		Expect(t0.In(tokyo)).ShouldNot(Equal(t0))
+	})
+
+	It("should ignore monotonic clock readings", func() {
+		now := time.Now()
+		Expect(now.Round(0)).Should(BeTemporallyCloseTo(now, 0))
+		Expect(now.Round(0)).ShouldNot(Equal(now))
+	})
+
+	It("should report how far apart the times were", func() {
+		m := BeTemporallyCloseTo(t0, time.Second)
+		Expect(m.Match(t0.Add(1500 * time.Millisecond))).Should(BeFalse())
+		Expect(m.FailureMessage(t0.Add(1500 * time.Millisecond))).Should(HaveSuffix("to be within 1s of\n    <time.Time>: 2023-03-01T12:00:00Z\nit was 1.5s after the expected time"))
+
+		Expect(m.Match(t0.Add(-2 * time.Second))).Should(BeFalse())
+		Expect(m.FailureMessage(t0.Add(-2 * time.Second))).Should(HaveSuffix("it was 2s before the expected time"))
+
+		Expect(m.Match(t0)).Should(BeTrue())
+		Expect(m.NegatedFailureMessage(t0)).Should(HaveSuffix("not to be within 1s of\n    <time.Time>: 2023-03-01T12:00:00Z\nit was the same instant as the expected time"))
+	})
+
+	It("should error on invalid input", func() {
+		_, err := BeTemporallyCloseTo(t0, time.Second).Match("now")
+		Expect(err).Should(MatchError(ContainSubstring("expects a time.Time")))
+
+		_, err = BeTemporallyCloseTo(t0, -time.Second).Match(t0)
+		Expect(err).Should(MatchError(ContainSubstring("non-negative window")))
+	})
+})
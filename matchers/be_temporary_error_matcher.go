@@ -0,0 +1,37 @@
+package matchers
+
+import "fmt"
+
+// temporaryError is implemented by errors - such as net.Error - that can tell whether the failure is temporary
+type temporaryError interface {
+	Temporary() bool
+}
+
+type BeTemporaryErrorMatcher struct {
+	// state
+	chain []error
+	depth int
+}
+
+func (matcher *BeTemporaryErrorMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.chain, err = toErrorChain("BeTemporaryError", actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.depth = findInErrorChain(matcher.chain, func(err error) bool {
+		_, ok := err.(temporaryError)
+		return ok
+	})
+	return matcher.depth >= 0 && matcher.chain[matcher.depth].(temporaryError).Temporary(), nil
+}
+
+func (matcher *BeTemporaryErrorMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.depth < 0 {
+		return fmt.Sprintf("Expected an error in the chain to report that it is temporary, but none has a Temporary() method\n%s", formatErrorChain(matcher.chain))
+	}
+	return fmt.Sprintf("Expected an error in the chain to report that it is temporary, but [%d] %T reports that it is not\n%s", matcher.depth, matcher.chain[matcher.depth], formatErrorChain(matcher.chain))
+}
+
+func (matcher *BeTemporaryErrorMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected no error in the chain to report that it is temporary, but [%d] %T does\n%s", matcher.depth, matcher.chain[matcher.depth], formatErrorChain(matcher.chain))
+}
@@ -0,0 +1,53 @@
+package matchers_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("BeTemporaryError", func() {
+	It("succeeds if the first error with a Temporary method reports that it is temporary", func() {
+		Expect(&net.DNSError{IsTemporary: true}).Should(BeTemporaryError())
+		Expect(fmt.Errorf("resolving: %w", &net.DNSError{IsTemporary: true})).Should(BeTemporaryError())
+		Expect(&net.DNSError{IsTemporary: false}).ShouldNot(BeTemporaryError())
+		Expect(errors.New("boom")).ShouldNot(BeTemporaryError())
+	})
+
+	It("only consults the first error with a Temporary method", func() {
+		err := &net.OpError{Op: "dial", Err: &net.DNSError{IsTemporary: true}}
+		Expect(fmt.Errorf("outer: %w", err)).Should(BeTemporaryError())
+	})
+
+	It("errors when ACTUAL is not an error", func() {
+		_, err := (&BeTemporaryErrorMatcher{}).Match(nil)
+		Expect(err).Should(MatchError("BeTemporaryError matcher expects an error, got nil"))
+	})
+
+	Describe("failure messages", func() {
+		It("reports when no error has a Temporary method", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(errors.New("boom")).Should(BeTemporaryError())
+			})
+			Expect(failures).Should(ConsistOf("Expected an error in the chain to report that it is temporary, but none has a Temporary() method\nError chain:\n    [0] *errors.errorString: boom"))
+		})
+
+		It("reports the error that says it is not temporary", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(&net.DNSError{Err: "no such host"}).Should(BeTemporaryError())
+			})
+			Expect(failures).Should(ConsistOf(HavePrefix("Expected an error in the chain to report that it is temporary, but [0] *net.DNSError reports that it is not\n")))
+		})
+
+		It("reports the error that says it is temporary", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(&net.DNSError{Err: "try again", IsTemporary: true}).ShouldNot(BeTemporaryError())
+			})
+			Expect(failures).Should(ConsistOf(HavePrefix("Expected no error in the chain to report that it is temporary, but [0] *net.DNSError does\n")))
+		})
+	})
+})
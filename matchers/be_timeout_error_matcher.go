@@ -0,0 +1,38 @@
+package matchers
+
+import "fmt"
+
+// timeoutError is implemented by errors - such as net.Error, os.ErrDeadlineExceeded and context.DeadlineExceeded -
+// that can tell whether the failure is a timeout
+type timeoutError interface {
+	Timeout() bool
+}
+
+type BeTimeoutErrorMatcher struct {
+	// state
+	chain []error
+	depth int
+}
+
+func (matcher *BeTimeoutErrorMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.chain, err = toErrorChain("BeTimeoutError", actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.depth = findInErrorChain(matcher.chain, func(err error) bool {
+		_, ok := err.(timeoutError)
+		return ok
+	})
+	return matcher.depth >= 0 && matcher.chain[matcher.depth].(timeoutError).Timeout(), nil
+}
+
+func (matcher *BeTimeoutErrorMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.depth < 0 {
+		return fmt.Sprintf("Expected an error in the chain to report that it is a timeout, but none has a Timeout() method\n%s", formatErrorChain(matcher.chain))
+	}
+	return fmt.Sprintf("Expected an error in the chain to report that it is a timeout, but [%d] %T reports that it is not\n%s", matcher.depth, matcher.chain[matcher.depth], formatErrorChain(matcher.chain))
+}
+
+func (matcher *BeTimeoutErrorMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected no error in the chain to report that it is a timeout, but [%d] %T does\n%s", matcher.depth, matcher.chain[matcher.depth], formatErrorChain(matcher.chain))
+}
@@ -0,0 +1,68 @@
+package matchers_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("BeTimeoutError", func() {
+	It("succeeds if the first error with a Timeout method reports a timeout", func() {
+		Expect(os.ErrDeadlineExceeded).Should(BeTimeoutError())
+		Expect(context.DeadlineExceeded).Should(BeTimeoutError())
+		Expect(fmt.Errorf("fetching: %w", context.DeadlineExceeded)).Should(BeTimeoutError())
+		Expect(&net.DNSError{IsTimeout: false}).ShouldNot(BeTimeoutError())
+		Expect(context.Canceled).ShouldNot(BeTimeoutError())
+	})
+
+	It("works with errors returned by network connections", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ShouldNot(HaveOccurred())
+		defer listener.Close()
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		Expect(err).ShouldNot(HaveOccurred())
+		defer conn.Close()
+
+		Expect(conn.SetReadDeadline(time.Now().Add(time.Millisecond))).Should(Succeed())
+		_, err = conn.Read(make([]byte, 1))
+		Expect(err).Should(BeTimeoutError())
+	})
+
+	It("errors when ACTUAL is not an error", func() {
+		_, err := (&BeTimeoutErrorMatcher{}).Match(nil)
+		Expect(err).Should(MatchError("BeTimeoutError matcher expects an error, got nil"))
+
+		_, err = (&BeTimeoutErrorMatcher{}).Match("timeout")
+		Expect(err).Should(MatchError(ContainSubstring("BeTimeoutError matcher expects an error.  Got:")))
+	})
+
+	Describe("failure messages", func() {
+		It("reports when no error has a Timeout method", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(fmt.Errorf("outer: %w", errors.New("boom"))).Should(BeTimeoutError())
+			})
+			Expect(failures).Should(ConsistOf("Expected an error in the chain to report that it is a timeout, but none has a Timeout() method\nError chain:\n    [0] *fmt.wrapError: outer: boom\n    [1] *errors.errorString: boom"))
+		})
+
+		It("reports the error that says it is not a timeout", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(fmt.Errorf("outer: %w", &net.DNSError{Err: "no such host", Name: "example"})).Should(BeTimeoutError())
+			})
+			Expect(failures).Should(ConsistOf(HavePrefix("Expected an error in the chain to report that it is a timeout, but [1] *net.DNSError reports that it is not\nError chain:\n")))
+		})
+
+		It("reports the error that says it is a timeout", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(os.ErrDeadlineExceeded).ShouldNot(BeTimeoutError())
+			})
+			Expect(failures).Should(ConsistOf(HavePrefix("Expected no error in the chain to report that it is a timeout, but [0] *poll.DeadlineExceededError does\n")))
+		})
+	})
+})
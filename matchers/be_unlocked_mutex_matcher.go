@@ -0,0 +1,37 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+// tryLocker is implemented by *sync.Mutex and *sync.RWMutex
+type tryLocker interface {
+	TryLock() bool
+	Unlock()
+}
+
+type BeUnlockedMutexMatcher struct {
+}
+
+func (matcher *BeUnlockedMutexMatcher) Match(actual interface{}) (success bool, err error) {
+	mutex, ok := actual.(tryLocker)
+	if !ok || isNil(actual) {
+		return false, fmt.Errorf("BeUnlockedMutex matcher expects a *sync.Mutex, a *sync.RWMutex, or anything else with TryLock and Unlock methods.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	if !mutex.TryLock() {
+		return false, nil
+	}
+	mutex.Unlock()
+	return true, nil
+}
+
+func (matcher *BeUnlockedMutexMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected %T to be unlocked, but it is locked", actual)
+}
+
+func (matcher *BeUnlockedMutexMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected %T to be locked, but it is unlocked", actual)
+}
@@ -0,0 +1,58 @@
+package matchers_test
+
+import (
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("BeUnlockedMutex", func() {
+	It("should succeed when the mutex can be locked, and leave it unlocked", func() {
+		mutex := &sync.Mutex{}
+		Expect(mutex).Should(BeUnlockedMutex())
+		Expect(mutex.TryLock()).Should(BeTrue())
+		Expect(mutex).ShouldNot(BeUnlockedMutex())
+		mutex.Unlock()
+		Expect(mutex).Should(BeUnlockedMutex())
+	})
+
+	It("should treat read locks on an RWMutex as locked", func() {
+		mutex := &sync.RWMutex{}
+		Expect(mutex).Should(BeUnlockedMutex())
+		mutex.RLock()
+		Expect(mutex).ShouldNot(BeUnlockedMutex())
+		mutex.RUnlock()
+		Expect(mutex).Should(BeUnlockedMutex())
+	})
+
+	It("can be awaited with Eventually", func() {
+		mutex := &sync.Mutex{}
+		mutex.Lock()
+		go mutex.Unlock()
+		Eventually(mutex).Should(BeUnlockedMutex())
+	})
+
+	It("should error when actual is not a mutex", func() {
+		_, err := (&BeUnlockedMutexMatcher{}).Match(nil)
+		Expect(err).Should(MatchError("BeUnlockedMutex matcher expects a *sync.Mutex, a *sync.RWMutex, or anything else with TryLock and Unlock methods.  Got:\n    <nil>: nil"))
+		_, err = (&BeUnlockedMutexMatcher{}).Match((*sync.Mutex)(nil))
+		Expect(err).Should(HaveOccurred())
+		_, err = (&BeUnlockedMutexMatcher{}).Match(sync.WaitGroup{})
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should build failure messages", func() {
+		mutex := &sync.Mutex{}
+		failures := InterceptGomegaFailures(func() {
+			Expect(mutex).ShouldNot(BeUnlockedMutex())
+			mutex.Lock()
+			Expect(mutex).Should(BeUnlockedMutex())
+		})
+		Expect(failures).Should(Equal([]string{
+			"Expected *sync.Mutex to be locked, but it is unlocked",
+			"Expected *sync.Mutex to be unlocked, but it is locked",
+		}))
+	})
+})
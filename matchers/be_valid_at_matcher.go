@@ -0,0 +1,34 @@
+package matchers
+
+import (
+	"crypto/x509"
+	"fmt"
+	"time"
+)
+
+type BeValidAtMatcher struct {
+	Time time.Time
+
+	// state
+	certificate *x509.Certificate
+}
+
+func (matcher *BeValidAtMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.certificate, _, err = toCertificate("BeValidAt", actual)
+	if err != nil {
+		return false, err
+	}
+	return !matcher.Time.Before(matcher.certificate.NotBefore) && !matcher.Time.After(matcher.certificate.NotAfter), nil
+}
+
+func (matcher *BeValidAtMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected certificate %s to be valid at %s, but it is only %s", describeCertificate(matcher.certificate), matcher.Time.Format(time.RFC3339), matcher.validity())
+}
+
+func (matcher *BeValidAtMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected certificate %s not to be valid at %s, but it is %s", describeCertificate(matcher.certificate), matcher.Time.Format(time.RFC3339), matcher.validity())
+}
+
+func (matcher *BeValidAtMatcher) validity() string {
+	return fmt.Sprintf("valid from %s to %s", matcher.certificate.NotBefore.Format(time.RFC3339), matcher.certificate.NotAfter.Format(time.RFC3339))
+}
@@ -0,0 +1,34 @@
+package matchers_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BeValidAt", func() {
+	leaf := newTestCertificate(7, "api.example.com", []string{"api.example.com"}, nil)
+
+	It("should succeed for times within the validity period", func() {
+		Expect(leaf.Certificate).Should(BeValidAt(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)))
+		Expect(leaf.Certificate).Should(BeValidAt(leaf.NotBefore))
+		Expect(leaf.Certificate).Should(BeValidAt(leaf.NotAfter))
+	})
+
+	It("should fail for times outside the validity period", func() {
+		Expect(leaf.Certificate).ShouldNot(BeValidAt(leaf.NotBefore.Add(-time.Second)))
+		Expect(leaf.Certificate).ShouldNot(BeValidAt(leaf.NotAfter.Add(time.Second)))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect(leaf.Certificate).Should(BeValidAt(time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)))
+			Expect(leaf.Certificate).ShouldNot(BeValidAt(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)))
+		})
+		Expect(failures).Should(Equal([]string{
+			"Expected certificate \"CN=api.example.com\" (serial 7) to be valid at 2025-06-01T00:00:00Z, but it is only valid from 2024-01-01T00:00:00Z to 2025-01-01T00:00:00Z",
+			"Expected certificate \"CN=api.example.com\" (serial 7) not to be valid at 2024-06-01T00:00:00Z, but it is valid from 2024-01-01T00:00:00Z to 2025-01-01T00:00:00Z",
+		}))
+	})
+})
@@ -0,0 +1,52 @@
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+)
+
+type BeValueOfMatcher struct {
+	Map interface{}
+
+	// state
+	provided interface{}
+}
+
+func (matcher *BeValueOfMatcher) Match(actual interface{}) (success bool, err error) {
+	m := matcher.Map
+	if isCollectionProvider(m) {
+		m, err = provideCollection("BeValueOf", matcher.Map)
+		if err != nil {
+			return false, err
+		}
+		if !isMap(m) {
+			return false, fmt.Errorf("BeValueOf matcher expects its provider to return a map.  Got:\n%s", format.Object(m, 1))
+		}
+		matcher.provided = m
+	}
+	if !isMap(m) {
+		return false, fmt.Errorf("BeValueOf matcher needs expected to be a map type")
+	}
+
+	if reflect.TypeOf(actual) == nil {
+		return false, fmt.Errorf("BeValueOf matcher expects actual to be typed")
+	}
+
+	return matchesAnyCandidate(actual, valuesOf(m))
+}
+
+func (matcher *BeValueOfMatcher) FailureMessage(actual interface{}) (message string) {
+	if isCollectionProvider(matcher.Map) {
+		return providedCollectionMessage(actual, "to be a value of", "values", valuesOf(matcher.provided), matcher.Map) + nearestCandidatesMessage(actual, valuesOf(matcher.provided))
+	}
+	return format.Message(actual, "to be a value of", matcher.Map) + nearestCandidatesMessage(actual, valuesOf(matcher.Map))
+}
+
+func (matcher *BeValueOfMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if isCollectionProvider(matcher.Map) {
+		return providedCollectionMessage(actual, "not to be a value of", "values", valuesOf(matcher.provided), matcher.Map)
+	}
+	return format.Message(actual, "not to be a value of", matcher.Map)
+}
@@ -0,0 +1,47 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("BeValueOf", func() {
+	It("should succeed if actual is one of the map's values", func() {
+		colors := map[string]string{"apple": "red", "banana": "yellow"}
+		Expect("red").Should(BeValueOf(colors))
+		Expect("green").ShouldNot(BeValueOf(colors))
+		Expect("apple").ShouldNot(BeValueOf(colors))
+		Expect(1).ShouldNot(BeValueOf(map[string]int{}))
+	})
+
+	It("matches actual against values that are matchers", func() {
+		Expect("error: disk full").Should(BeValueOf(map[string]interface{}{"ok": "ok", "failed": HavePrefix("error: ")}))
+	})
+
+	It("calls a provider function each time it matches", func() {
+		colors := map[string]string{"apple": "red"}
+		m := BeValueOf(func() map[string]string { return colors })
+		Expect("yellow").ShouldNot(m)
+		colors["banana"] = "yellow"
+		Expect("yellow").Should(m)
+	})
+
+	It("should error when not passed a map", func() {
+		success, err := (&BeValueOfMatcher{Map: []string{"red"}}).Match("red")
+		Expect(success).Should(BeFalse())
+		Expect(err).Should(MatchError("BeValueOf matcher needs expected to be a map type"))
+
+		_, err = BeValueOf(func() []string { return nil }).Match("red")
+		Expect(err).Should(MatchError(ContainSubstring("BeValueOf matcher expects its provider to return a map")))
+
+		_, err = BeValueOf(map[string]string{}).Match(nil)
+		Expect(err).Should(MatchError("BeValueOf matcher expects actual to be typed"))
+	})
+
+	It("builds failure messages that suggest the nearest values", func() {
+		m := map[string]int{"a": 1, "b": 10, "c": 100, "d": 1000}
+		Expect(BeValueOf(m).FailureMessage(12)).Should(Equal("Expected\n    <int>: 12\nto be a value of\n    <map[string]int | len:4>: {\"a\": 1, \"b\": 10, \"c\": 100, \"d\": 1000}\nThe nearest candidates were\n    <[]int | len:3, cap:3>: [10, 1, 100]"))
+		Expect(BeValueOf(m).NegatedFailureMessage(10)).Should(Equal("Expected\n    <int>: 10\nnot to be a value of\n    <map[string]int | len:4>: {\"a\": 1, \"b\": 10, \"c\": 100, \"d\": 1000}"))
+	})
+})
@@ -1,6 +1,7 @@
 package matchers
 
 import (
+	"fmt"
 	"reflect"
 
 	"github.com/onsi/gomega/format"
@@ -20,9 +21,57 @@ func (matcher *BeZeroMatcher) Match(actual interface{}) (success bool, err error
 }
 
 func (matcher *BeZeroMatcher) FailureMessage(actual interface{}) (message string) {
-	return format.Message(actual, "to be zero-valued")
+	return zeroValuedFailureMessage(actual)
 }
 
 func (matcher *BeZeroMatcher) NegatedFailureMessage(actual interface{}) (message string) {
 	return format.Message(actual, "not to be zero-valued")
 }
+
+// LegacyFailureMessage makes BeZeroMatcher a types.LegacyFailureMessager: it does not name the first non-zero field
+func (matcher *BeZeroMatcher) LegacyFailureMessage(actual interface{}, desiredMatch bool) string {
+	if desiredMatch {
+		return format.Message(actual, "to be zero-valued")
+	}
+	return matcher.NegatedFailureMessage(actual)
+}
+
+// zeroValuedFailureMessage explains that actual is not zero-valued, naming its first non-zero field when actual is a
+// struct or an array
+func zeroValuedFailureMessage(actual interface{}) string {
+	path, value, ok := firstNonZeroField(reflect.ValueOf(actual), "")
+	if !ok || path == "" {
+		return format.Message(actual, "to be zero-valued")
+	}
+	if !value.CanInterface() {
+		return format.Message(actual, fmt.Sprintf("to be zero-valued, but field %s is non-zero", path))
+	}
+	return format.Message(actual, fmt.Sprintf("to be zero-valued, but field %s is non-zero:", path), value.Interface())
+}
+
+// firstNonZeroField descends into structs and arrays to find the first non-zero value within v, returning its path
+// relative to v
+func firstNonZeroField(v reflect.Value, path string) (string, reflect.Value, bool) {
+	if !v.IsValid() || v.IsZero() {
+		return "", reflect.Value{}, false
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			fieldPath := v.Type().Field(i).Name
+			if path != "" {
+				fieldPath = path + "." + fieldPath
+			}
+			if fieldPath, value, ok := firstNonZeroField(v.Field(i), fieldPath); ok {
+				return fieldPath, value, true
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if elementPath, value, ok := firstNonZeroField(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); ok {
+				return elementPath, value, true
+			}
+		}
+	}
+	return path, v, true
+}
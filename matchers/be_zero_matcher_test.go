@@ -3,6 +3,7 @@ package matchers_test
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
 )
 
 var _ = Describe("BeZero", func() {
@@ -33,6 +34,44 @@ var _ = Describe("BeZero", func() {
 		Expect(actual).To(Equal("Expected\n    <int>: 123\nto be zero-valued"))
 	})
 
+	Describe("failure messages for structs", func() {
+		type server struct {
+			Host string
+			Port int
+		}
+		type config struct {
+			Name    string
+			Server  server
+			Weights [3]int
+			secret  string
+		}
+
+		It("names the first non-zero field", func() {
+			actual := BeZero().FailureMessage(config{Server: server{Port: 8080}})
+			Expect(actual).To(HaveSuffix("\nto be zero-valued, but field Server.Port is non-zero:\n    <int>: 8080"))
+		})
+
+		It("names elements of arrays", func() {
+			actual := BeZero().FailureMessage(config{Weights: [3]int{0, 0, 2}})
+			Expect(actual).To(HaveSuffix("\nto be zero-valued, but field Weights[2] is non-zero:\n    <int>: 2"))
+		})
+
+		It("does not render the value of unexported fields", func() {
+			actual := BeZero().FailureMessage(config{secret: "shh"})
+			Expect(actual).To(HaveSuffix("\nto be zero-valued, but field secret is non-zero"))
+		})
+
+		It("falls back to the plain message for values without fields", func() {
+			actual := BeZero().FailureMessage(&config{})
+			Expect(actual).To(HaveSuffix("\nto be zero-valued"))
+		})
+
+		It("does not name the field in the legacy failure message", func() {
+			actual := types.LegacyFailureMessageFor(BeZero(), config{Server: server{Port: 8080}}, true)
+			Expect(actual).To(HaveSuffix("\nto be zero-valued"))
+		})
+	})
+
 	It("builds negated failure message", func() {
 		actual := BeZero().NegatedFailureMessage(123)
 		Expect(actual).To(Equal("Expected\n    <int>: 123\nnot to be zero-valued"))
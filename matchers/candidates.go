@@ -0,0 +1,112 @@
+package matchers
+
+import (
+	"math"
+	"sort"
+
+	"github.com/onsi/gomega/format"
+)
+
+// nearestCandidatesSize caps the number of nearest candidates shown in failure messages
+const nearestCandidatesSize = 3
+
+// matchesCandidate tells whether actual is one of the candidates BeElementOf, BeKeyOf and BeValueOf look among: a
+// candidate that is a matcher must be satisfied by actual, any other candidate must equal it
+func matchesCandidate(actual interface{}, candidate interface{}) (bool, error) {
+	if candidateMatcher, ok := candidate.(omegaMatcher); ok {
+		return candidateMatcher.Match(actual)
+	}
+	return (&EqualMatcher{Expected: candidate}).Match(actual)
+}
+
+// matchesAnyCandidate tells whether actual matches any of candidates.  Errors are only returned when no candidate
+// matches.
+func matchesAnyCandidate(actual interface{}, candidates []interface{}) (bool, error) {
+	var lastError error
+	for _, candidate := range candidates {
+		success, err := matchesCandidate(actual, candidate)
+		if err != nil {
+			lastError = err
+			continue
+		}
+		if success {
+			return true, nil
+		}
+	}
+	return false, lastError
+}
+
+// nearestCandidatesMessage suggests the candidates closest to actual - by edit distance for strings and by absolute
+// difference for numbers.  Other candidates, including matchers, can't be ranked and are never suggested.  Nothing is
+// suggested unless there are more rankable candidates than would be suggested: the failure message lists them already.
+func nearestCandidatesMessage(actual interface{}, candidates []interface{}) string {
+	type rankedCandidate struct {
+		candidate interface{}
+		distance  float64
+	}
+	ranked := []rankedCandidate{}
+	for _, candidate := range candidates {
+		if distance, ok := candidateDistance(actual, candidate); ok {
+			ranked = append(ranked, rankedCandidate{candidate, distance})
+		}
+	}
+	if len(ranked) <= nearestCandidatesSize {
+		return ""
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].distance < ranked[j].distance })
+	ranked = ranked[:nearestCandidatesSize]
+	nearest := make([]interface{}, len(ranked))
+	for i := range ranked {
+		nearest[i] = ranked[i].candidate
+	}
+	return "\nThe nearest candidates were\n" + format.Object(presentable(nearest), 1)
+}
+
+func candidateDistance(actual interface{}, candidate interface{}) (float64, bool) {
+	if isString(actual) && isString(candidate) {
+		a, _ := toString(actual)
+		c, _ := toString(candidate)
+		return float64(editDistance(a, c)), true
+	}
+	if isRankableNumber(actual) && isRankableNumber(candidate) {
+		return math.Abs(toFloat(actual) - toFloat(candidate)), true
+	}
+	return 0, false
+}
+
+// isRankableNumber tells whether a is a number toFloat can convert - any number but a uintptr
+func isRankableNumber(a interface{}) bool {
+	return isInteger(a) || isUnsignedInteger(a) || isFloat(a)
+}
+
+// editDistance is the Levenshtein distance between a and b, counted in runes
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			substitution := previous[j-1]
+			if ra[i-1] != rb[j-1] {
+				substitution++
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, substitution)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
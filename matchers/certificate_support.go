@@ -0,0 +1,37 @@
+package matchers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+// toCertificate returns the certificate actual describes: an *x509.Certificate, or the leaf certificate the peer
+// presented in a tls.ConnectionState.  intermediates holds the other certificates the peer presented, if any.
+func toCertificate(matcherName string, actual interface{}) (certificate *x509.Certificate, intermediates []*x509.Certificate, err error) {
+	switch a := actual.(type) {
+	case *x509.Certificate:
+		if a != nil {
+			return a, nil, nil
+		}
+	case x509.Certificate:
+		return &a, nil, nil
+	case *tls.ConnectionState:
+		if a != nil {
+			return toCertificate(matcherName, *a)
+		}
+	case tls.ConnectionState:
+		if len(a.PeerCertificates) == 0 {
+			return nil, nil, fmt.Errorf("%s matcher expects the TLS connection's peer to have presented a certificate, but it presented none", matcherName)
+		}
+		return a.PeerCertificates[0], a.PeerCertificates[1:], nil
+	}
+	return nil, nil, fmt.Errorf("%s matcher expects an *x509.Certificate or a tls.ConnectionState.  Got:\n%s", matcherName, format.Object(actual, 1))
+}
+
+// describeCertificate identifies a certificate in failure messages, which would be swamped by its formatted fields
+func describeCertificate(certificate *x509.Certificate) string {
+	return fmt.Sprintf("%q (serial %s)", certificate.Subject.String(), certificate.SerialNumber)
+}
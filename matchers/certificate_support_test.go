@@ -0,0 +1,72 @@
+package matchers_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var certificateValidity = struct{ notBefore, notAfter time.Time }{
+	notBefore: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	notAfter:  time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+}
+
+type testCertificate struct {
+	*x509.Certificate
+	key *ecdsa.PrivateKey
+}
+
+// newTestCertificate issues a certificate for commonName, signed by issuer or self-signed if issuer is nil
+func newTestCertificate(serial int64, commonName string, dnsNames []string, issuer *testCertificate) testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ShouldNot(HaveOccurred())
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              dnsNames,
+		NotBefore:             certificateValidity.notBefore,
+		NotAfter:              certificateValidity.notAfter,
+		IsCA:                  issuer == nil || len(dnsNames) == 0,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	parent, parentKey := template, key
+	if issuer != nil {
+		parent, parentKey = issuer.Certificate, issuer.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	Expect(err).ShouldNot(HaveOccurred())
+	certificate, err := x509.ParseCertificate(der)
+	Expect(err).ShouldNot(HaveOccurred())
+	return testCertificate{Certificate: certificate, key: key}
+}
+
+var _ = Describe("certificate matchers", func() {
+	leaf := newTestCertificate(1, "api.example.com", []string{"api.example.com"}, nil)
+
+	It("should accept certificates and TLS connection states", func() {
+		Expect(leaf.Certificate).Should(HaveSubjectCN("api.example.com"))
+		Expect(*leaf.Certificate).Should(HaveSubjectCN("api.example.com"))
+		Expect(tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf.Certificate}}).Should(HaveSubjectCN("api.example.com"))
+		Expect(&tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf.Certificate}}).Should(HaveSubjectCN("api.example.com"))
+	})
+
+	It("should error when there is no certificate", func() {
+		_, err := HaveSubjectCN("api.example.com").Match(tls.ConnectionState{})
+		Expect(err).Should(MatchError("HaveSubjectCN matcher expects the TLS connection's peer to have presented a certificate, but it presented none"))
+
+		_, err = HaveSubjectCN("api.example.com").Match((*x509.Certificate)(nil))
+		Expect(err).Should(MatchError(ContainSubstring("HaveSubjectCN matcher expects an *x509.Certificate or a tls.ConnectionState.  Got:")))
+
+		_, err = HaveSubjectCN("api.example.com").Match("api.example.com")
+		Expect(err).Should(MatchError(ContainSubstring("HaveSubjectCN matcher expects an *x509.Certificate or a tls.ConnectionState.  Got:")))
+	})
+})
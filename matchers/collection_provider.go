@@ -0,0 +1,46 @@
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+)
+
+// collectionSampleSize caps the number of elements of a provided collection shown in failure messages
+const collectionSampleSize = 10
+
+// isCollectionProvider reports whether a is a function that BeElementOf and BeKeyOf should call for their collection
+func isCollectionProvider(a interface{}) bool {
+	return a != nil && reflect.TypeOf(a).Kind() == reflect.Func
+}
+
+// provideCollection calls provider - a function that takes no arguments and returns a collection and, optionally, an
+// error - afresh each time a matcher matches, so that Eventually can await membership of a changing collection
+func provideCollection(matcherName string, provider interface{}) (interface{}, error) {
+	t := reflect.TypeOf(provider)
+	if t.NumIn() != 0 || t.NumOut() < 1 || t.NumOut() > 2 || (t.NumOut() == 2 && !t.Out(1).Implements(errorT)) {
+		return nil, fmt.Errorf("%s matcher expects a provider function that takes no arguments and returns a collection and, optionally, an error.  Got:\n%s", matcherName, format.Object(provider, 1))
+	}
+	out := reflect.ValueOf(provider).Call(nil)
+	if len(out) == 2 && !out[1].IsNil() {
+		return nil, fmt.Errorf("%s matcher's provider %s returned an error: %w", matcherName, funcName(provider), out[1].Interface().(error))
+	}
+	return out[0].Interface(), nil
+}
+
+// providedCollectionMessage renders a failure message naming the provider of a collection, its size when it was last
+// provided, and a sample of its members
+func providedCollectionMessage(actual interface{}, relation string, noun string, members []interface{}, provider interface{}) string {
+	message := fmt.Sprintf("%s the %d %s last provided by %s", relation, len(members), noun, funcName(provider))
+	sample := members
+	if len(members) > collectionSampleSize {
+		sample = members[:collectionSampleSize]
+		message += fmt.Sprintf(", the first %d of which are", collectionSampleSize)
+	}
+	// presentable unpacks a lone slice, so wrap a lone member to keep it whole
+	if len(sample) == 1 && isArrayOrSlice(sample[0]) {
+		return format.Message(actual, message, sample)
+	}
+	return format.Message(actual, message, presentable(sample))
+}
@@ -108,6 +108,17 @@ var _ = Describe("ConsistOf", func() {
 			})
 		})
 
+		When("actual is a map", func() {
+			It("prints the extra elements in key order", func() {
+				failures := InterceptGomegaFailures(func() {
+					Expect(map[string]int{"e": 5, "b": 2, "d": 4, "a": 1, "c": 3}).Should(ConsistOf(3))
+				})
+
+				expected := "the extra elements were\n.*\\[1, 2, 4, 5\\]"
+				Expect(failures).To(ConsistOf(MatchRegexp(expected)))
+			})
+		})
+
 		When("expected was specified as an array", func() {
 			It("flattens the array in the expectation message", func() {
 				failures := InterceptGomegaFailures(func() {
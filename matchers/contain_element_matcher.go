@@ -8,6 +8,7 @@ import (
 	"reflect"
 
 	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/internal/gutil"
 )
 
 type ContainElementMatcher struct {
@@ -75,7 +76,7 @@ func (matcher *ContainElementMatcher) Match(actual interface{}) (success bool, e
 	var foundAt func(int)
 
 	if isMap(actual) {
-		keys := value.MapKeys()
+		keys := gutil.SortedMapKeys(value)
 		valueAt = func(i int) interface{} {
 			return value.MapIndex(keys[i]).Interface()
 		}
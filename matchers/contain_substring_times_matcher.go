@@ -0,0 +1,52 @@
+package matchers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+type ContainSubstringTimesMatcher struct {
+	Substr string
+	Count  interface{}
+
+	// state
+	occurrences  int
+	countMatcher omegaMatcher
+}
+
+func (matcher *ContainSubstringTimesMatcher) Match(actual interface{}) (success bool, err error) {
+	actualString, ok := toString(actual)
+	if !ok {
+		return false, fmt.Errorf("ContainSubstringTimes matcher requires a string or stringer.  Got:\n%s", format.Object(actual, 1))
+	}
+	if matcher.Substr == "" {
+		return false, fmt.Errorf("ContainSubstringTimes matcher requires a non-empty substring")
+	}
+
+	var isMatcher bool
+	matcher.countMatcher, isMatcher = matcher.Count.(omegaMatcher)
+	if !isMatcher {
+		matcher.countMatcher = &EqualMatcher{Expected: matcher.Count}
+	}
+
+	matcher.occurrences = strings.Count(actualString, matcher.Substr)
+	success, err = matcher.countMatcher.Match(matcher.occurrences)
+	if err != nil {
+		return false, fmt.Errorf("ContainSubstringTimes's count matcher failed with:\n%s%s", format.Indent, err.Error())
+	}
+	return success, nil
+}
+
+func (matcher *ContainSubstringTimesMatcher) FailureMessage(actual interface{}) (message string) {
+	message = format.Message(actual, "to contain substring", matcher.Substr)
+	message += fmt.Sprintf("\nbut the number of occurrences (%d) failed to satisfy matcher.\n", matcher.occurrences)
+	return message + matcher.countMatcher.FailureMessage(matcher.occurrences)
+}
+
+func (matcher *ContainSubstringTimesMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	message = format.Message(actual, "not to contain substring", matcher.Substr)
+	message += fmt.Sprintf("\na number of times satisfying matcher, but the number of occurrences (%d) satisfied it.\n", matcher.occurrences)
+	return message + matcher.countMatcher.NegatedFailureMessage(matcher.occurrences)
+}
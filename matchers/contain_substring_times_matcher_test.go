@@ -0,0 +1,44 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainSubstringTimes", func() {
+	const log = "INFO starting\nWARN disk low\nINFO ready\nWARN disk very low\n"
+
+	It("should count non-overlapping occurrences", func() {
+		Expect(log).Should(ContainSubstringTimes("WARN", 2))
+		Expect(log).Should(ContainSubstringTimes("ERROR", 0))
+		Expect(log).ShouldNot(ContainSubstringTimes("WARN", 1))
+		Expect("aaaa").Should(ContainSubstringTimes("aa", 2))
+		Expect([]byte(log)).Should(ContainSubstringTimes("INFO", 2))
+		Expect(&myStringer{a: log}).Should(ContainSubstringTimes("disk", 2))
+	})
+
+	It("should accept a matcher for the count", func() {
+		Expect(log).Should(ContainSubstringTimes("INFO", BeNumerically(">=", 1)))
+		Expect(log).ShouldNot(ContainSubstringTimes("WARN", BeNumerically("<", 2)))
+	})
+
+	It("should report the number of occurrences", func() {
+		m := ContainSubstringTimes("WARN", 1)
+		Expect(m.Match("WARN WARN")).Should(BeFalse())
+		Expect(m.FailureMessage("WARN WARN")).Should(Equal("Expected\n    <string>: WARN WARN\nto contain substring\n    <string>: WARN\nbut the number of occurrences (2) failed to satisfy matcher.\nExpected\n    <int>: 2\nto equal\n    <int>: 1"))
+
+		Expect(m.Match("WARN")).Should(BeTrue())
+		Expect(m.NegatedFailureMessage("WARN")).Should(Equal("Expected\n    <string>: WARN\nnot to contain substring\n    <string>: WARN\na number of times satisfying matcher, but the number of occurrences (1) satisfied it.\nExpected\n    <int>: 1\nnot to equal\n    <int>: 1"))
+	})
+
+	It("should error on invalid input", func() {
+		_, err := ContainSubstringTimes("WARN", 1).Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("requires a string or stringer")))
+
+		_, err = ContainSubstringTimes("", 1).Match("WARN")
+		Expect(err).Should(MatchError(ContainSubstring("non-empty substring")))
+
+		_, err = ContainSubstringTimes("WARN", HaveLen(1)).Match("WARN")
+		Expect(err).Should(MatchError(ContainSubstring("count matcher failed with")))
+	})
+})
@@ -0,0 +1,50 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// encodedContent decodes actual - a string or []byte - and matches the decoded bytes with an optional matcher.  It
+// implements BeBase64Encoding and BeHexEncoding.
+type encodedContent struct {
+	matcherName string
+	encoding    string
+	decode      func(string) ([]byte, error)
+	matcher     types.GomegaMatcher
+
+	// state
+	decoded   []byte
+	decodeErr error
+}
+
+func (content *encodedContent) match(actual interface{}) (success bool, err error) {
+	s, ok := toString(actual)
+	if !ok {
+		return false, fmt.Errorf("%s matcher expects a string or []byte.  Got:\n%s", content.matcherName, format.Object(actual, 1))
+	}
+	content.decoded, content.decodeErr = content.decode(s)
+	if content.decodeErr != nil {
+		return false, nil
+	}
+	if content.matcher == nil {
+		return true, nil
+	}
+	success, err = content.matcher.Match(content.decoded)
+	if err != nil {
+		return false, fmt.Errorf("Content decoded from %s:\n%s", content.encoding, format.IndentString(err.Error(), 1))
+	}
+	return success, nil
+}
+
+func (content *encodedContent) failureMessage(actual interface{}, desiredMatch bool) string {
+	if content.decodeErr != nil {
+		return format.Message(actual, fmt.Sprintf("to be %s encoded, but decoding it failed with:\n%s", content.encoding, format.IndentString(content.decodeErr.Error(), 1)))
+	}
+	if content.matcher == nil {
+		return format.Message(actual, fmt.Sprintf("not to be %s encoded", content.encoding))
+	}
+	return fmt.Sprintf("Content decoded from %s:\n%s", content.encoding, format.IndentString(types.FailureMessageFor(content.matcher, content.decoded, desiredMatch), 1))
+}
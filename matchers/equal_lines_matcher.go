@@ -0,0 +1,28 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type EqualLinesMatcher struct {
+	Expected string
+}
+
+func (matcher *EqualLinesMatcher) Match(actual interface{}) (success bool, err error) {
+	actualString, ok := toString(actual)
+	if !ok {
+		return false, fmt.Errorf("EqualLines matcher requires a string or stringer.  Got:\n%s", format.Object(actual, 1))
+	}
+	return actualString == matcher.Expected, nil
+}
+
+func (matcher *EqualLinesMatcher) FailureMessage(actual interface{}) (message string) {
+	actualString, _ := toString(actual)
+	return "Expected the lines to be equal, but they differ:\n" + format.IndentString(unifiedDiff("expected", "actual", matcher.Expected, actualString), 1)
+}
+
+func (matcher *EqualLinesMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to equal", matcher.Expected)
+}
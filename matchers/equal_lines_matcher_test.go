@@ -0,0 +1,104 @@
+package matchers_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EqualLines", func() {
+	numberedLines := func(from, to int) string {
+		lines := []string{}
+		for i := from; i <= to; i++ {
+			lines = append(lines, fmt.Sprintf("line %d\n", i))
+		}
+		return strings.Join(lines, "")
+	}
+
+	It("should succeed when the strings are equal", func() {
+		Expect("a\nb\n").Should(EqualLines("a\nb\n"))
+		Expect([]byte("a\nb\n")).Should(EqualLines("a\nb\n"))
+		Expect(&myStringer{a: "a\nb"}).Should(EqualLines("a\nb"))
+		Expect("a\nb\n").ShouldNot(EqualLines("a\nc\n"))
+		Expect("a\nb").ShouldNot(EqualLines("a\nb\n"))
+	})
+
+	It("should report a unified diff", func() {
+		expected := "alpha\nbeta\ngamma\n"
+		actual := "alpha\nBETA\ngamma\ndelta\n"
+		m := EqualLines(expected)
+		Expect(m.Match(actual)).Should(BeFalse())
+		Expect(m.FailureMessage(actual)).Should(Equal(`Expected the lines to be equal, but they differ:
+    --- expected
+    +++ actual
+    @@ -1,3 +1,4 @@
+     alpha
+    -beta
+    +BETA
+     gamma
+    +delta`))
+	})
+
+	It("should only show context around the changes, splitting distant changes into separate hunks", func() {
+		expected := numberedLines(1, 20)
+		actual := strings.Replace(strings.Replace(expected, "line 2\n", "line two\n", 1), "line 18\n", "", 1)
+		m := EqualLines(expected)
+		Expect(m.Match(actual)).Should(BeFalse())
+		Expect(m.FailureMessage(actual)).Should(Equal(`Expected the lines to be equal, but they differ:
+    --- expected
+    +++ actual
+    @@ -1,5 +1,5 @@
+     line 1
+    -line 2
+    +line two
+     line 3
+     line 4
+     line 5
+    @@ -15,6 +15,5 @@
+     line 15
+     line 16
+     line 17
+    -line 18
+     line 19
+     line 20`))
+	})
+
+	It("should merge changes that are close together into one hunk", func() {
+		expected := numberedLines(1, 12)
+		actual := strings.Replace(strings.Replace(expected, "line 3\n", "", 1), "line 10\n", "", 1)
+		m := EqualLines(expected)
+		Expect(m.Match(actual)).Should(BeFalse())
+		Expect(m.FailureMessage(actual)).Should(ContainSubstring("@@ -1,12 +1,10 @@"))
+		Expect(strings.Count(m.FailureMessage(actual), "@@ -")).Should(Equal(1))
+	})
+
+	It("should point out a missing newline at the end", func() {
+		m := EqualLines("a\nb\n")
+		Expect(m.Match("a\nb")).Should(BeFalse())
+		Expect(m.FailureMessage("a\nb")).Should(Equal("Expected the lines to be equal, but they differ:\n    --- expected\n    +++ actual\n    @@ -1,2 +1,2 @@\n     a\n    -b\n    +b\n    \\ No newline at end of file"))
+	})
+
+	It("should handle empty strings", func() {
+		m := EqualLines("")
+		Expect(m.Match("a\n")).Should(BeFalse())
+		Expect(m.FailureMessage("a\n")).Should(HaveSuffix("@@ -0,0 +1 @@\n    +a"))
+	})
+
+	It("should still produce a correct diff for very large changes", func() {
+		expected := numberedLines(1, 3000)
+		actual := numberedLines(3001, 6000)
+		m := EqualLines(expected)
+		Expect(m.Match(actual)).Should(BeFalse())
+		message := m.FailureMessage(actual)
+		Expect(message).Should(ContainSubstring("@@ -1,3000 +1,3000 @@"))
+		Expect(strings.Count(message, "\n    -line")).Should(Equal(3000))
+		Expect(strings.Count(message, "\n    +line")).Should(Equal(3000))
+	})
+
+	It("should error when actual is not a string", func() {
+		_, err := EqualLines("a").Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("requires a string or stringer")))
+	})
+})
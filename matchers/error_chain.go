@@ -0,0 +1,60 @@
+package matchers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+// toErrorChain returns actual followed by each error it wraps, as unwrapped by errors.Unwrap
+func toErrorChain(matcherName string, actual interface{}) ([]error, error) {
+	if isNil(actual) {
+		return nil, fmt.Errorf("%s matcher expects an error, got nil", matcherName)
+	}
+	err, ok := actual.(error)
+	if !ok {
+		return nil, fmt.Errorf("%s matcher expects an error.  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+
+	chain := []error{}
+	for ; err != nil; err = errors.Unwrap(err) {
+		chain = append(chain, err)
+	}
+	return chain, nil
+}
+
+// formatErrorChain renders one line per error in chain, with its depth and type
+func formatErrorChain(chain []error) string {
+	lines := []string{"Error chain:"}
+	for depth, err := range chain {
+		lines = append(lines, fmt.Sprintf("%s[%d] %T: %s", format.Indent, depth, err, err.Error()))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// toErrorChainElementMatcher returns a matcher for a single error in a chain.  Errors are compared with equality
+// and strings with the error's message; matchers are passed the error itself.
+func toErrorChainElementMatcher(matcherName string, expected interface{}) (omegaMatcher, error) {
+	switch e := expected.(type) {
+	case omegaMatcher:
+		return e, nil
+	case error:
+		return &EqualMatcher{Expected: e}, nil
+	case string:
+		return &MatchErrorMatcher{Expected: e}, nil
+	default:
+		return nil, fmt.Errorf("%s matcher must be passed an error, a string, or a matcher.  Got:\n%s", matcherName, format.Object(expected, 1))
+	}
+}
+
+// findInErrorChain returns the depth of the first error in chain that satisfies has, or -1 if none does
+func findInErrorChain(chain []error, has func(error) bool) int {
+	for depth, err := range chain {
+		if has(err) {
+			return depth
+		}
+	}
+	return -1
+}
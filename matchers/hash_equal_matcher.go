@@ -0,0 +1,41 @@
+package matchers
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type HashEqualMatcher struct {
+	Expected interface{}
+
+	// state
+	expectedChecksum, actualChecksum string
+	expectedSize, actualSize         int64
+	expectedHashed                   bool
+}
+
+func (matcher *HashEqualMatcher) Match(actual interface{}) (success bool, err error) {
+	// an io.Reader can only be read once, so the expected content is hashed once and remembered
+	if !matcher.expectedHashed {
+		matcher.expectedChecksum, matcher.expectedSize, err = checksum("HashEqual", sha256.New, matcher.Expected)
+		if err != nil {
+			return false, err
+		}
+		matcher.expectedHashed = true
+	}
+	matcher.actualChecksum, matcher.actualSize, err = checksum("HashEqual", sha256.New, actual)
+	if err != nil {
+		return false, err
+	}
+	return matcher.actualChecksum == matcher.expectedChecksum, nil
+}
+
+func (matcher *HashEqualMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected content\n%s%d bytes with sha256 checksum %s\nto equal content\n%s%d bytes with sha256 checksum %s", format.Indent, matcher.actualSize, matcher.actualChecksum, format.Indent, matcher.expectedSize, matcher.expectedChecksum)
+}
+
+func (matcher *HashEqualMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected content\n%s%d bytes with sha256 checksum %s\nnot to equal content with the same checksum", format.Indent, matcher.actualSize, matcher.actualChecksum)
+}
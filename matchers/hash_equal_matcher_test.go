@@ -0,0 +1,36 @@
+package matchers_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HashEqual", func() {
+	It("should succeed when the contents are the same", func() {
+		Expect("test").Should(HashEqual([]byte("test")))
+		Expect(strings.NewReader("test")).Should(HashEqual(bytes.NewBufferString("test")))
+		Expect("test").ShouldNot(HashEqual("tests"))
+	})
+
+	It("should read an expected io.Reader only once", func() {
+		m := HashEqual(strings.NewReader("test"))
+		Expect(m.Match("test")).Should(BeTrue())
+		Expect(m.Match("test")).Should(BeTrue())
+	})
+
+	It("should report checksums and sizes rather than content", func() {
+		m := HashEqual("tests")
+		Expect(m.Match("test")).Should(BeFalse())
+		Expect(m.FailureMessage("test")).Should(Equal("Expected content\n    4 bytes with sha256 checksum 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08\nto equal content\n    5 bytes with sha256 checksum 59830ebc3a4184110566bf1a290d08473dfdcbd492ce498b14cd1a5e2fa2e441"))
+	})
+
+	It("should error when either value is unsupported", func() {
+		_, err := HashEqual(17).Match("test")
+		Expect(err).Should(MatchError(ContainSubstring("HashEqual matcher requires a string, stringer, []byte, or io.Reader")))
+		_, err = HashEqual("test").Match(17)
+		Expect(err).Should(MatchError(ContainSubstring("HashEqual matcher requires a string, stringer, []byte, or io.Reader")))
+	})
+})
@@ -0,0 +1,36 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+type HaveChannelCapMatcher struct {
+	Expected interface{}
+
+	// state
+	capacity        int
+	expectedMatcher types.GomegaMatcher
+}
+
+func (matcher *HaveChannelCapMatcher) Match(actual interface{}) (success bool, err error) {
+	channel, err := toChannelValue("HaveChannelCap", actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.capacity = channel.Cap()
+	matcher.expectedMatcher = channelSizeMatcher(matcher.Expected)
+	return matcher.expectedMatcher.Match(matcher.capacity)
+}
+
+func (matcher *HaveChannelCapMatcher) FailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Buffer capacity of %s failed to satisfy matcher.\n", format.Object(actual, 0))
+	return message + matcher.expectedMatcher.FailureMessage(matcher.capacity)
+}
+
+func (matcher *HaveChannelCapMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Buffer capacity of %s satisfied matcher, but should not have.\n", format.Object(actual, 0))
+	return message + matcher.expectedMatcher.NegatedFailureMessage(matcher.capacity)
+}
@@ -0,0 +1,37 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("HaveChannelCap", func() {
+	It("should match the buffer capacity of the channel", func() {
+		c := make(chan int, 3)
+		Expect(c).Should(HaveChannelCap(3))
+		Expect(c).Should(HaveChannelCap(int64(3)))
+		Expect(c).Should(HaveChannelCap(BeNumerically(">", 0)))
+		Expect((chan<- int)(c)).Should(HaveChannelCap(3))
+		Expect(c).ShouldNot(HaveChannelCap(2))
+		Expect(make(chan int)).Should(HaveChannelCap(0))
+	})
+
+	It("should error when actual is not a channel", func() {
+		_, err := (&HaveChannelCapMatcher{Expected: 0}).Match(nil)
+		Expect(err).Should(MatchError("HaveChannelCap matcher expects a channel.  Got:\n    <nil>: nil"))
+		_, err = (&HaveChannelCapMatcher{Expected: 0}).Match(make([]int, 0, 3))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should build failure messages", func() {
+		c := make(chan int, 3)
+		failures := InterceptGomegaFailures(func() {
+			Expect(c).Should(HaveChannelCap(2))
+			Expect(c).ShouldNot(HaveChannelCap(3))
+		})
+		Expect(failures).Should(HaveLen(2))
+		Expect(failures[0]).Should(MatchRegexp(`^Buffer capacity of <chan int \| len:0, cap:3>: 0x[0-9a-f]+ failed to satisfy matcher.\nExpected\n    <int>: 3\nto be ==\n    <int>: 2$`))
+		Expect(failures[1]).Should(MatchRegexp(`^Buffer capacity of <chan int \| len:0, cap:3>: 0x[0-9a-f]+ satisfied matcher, but should not have.\nExpected\n    <int>: 3\nnot to be ==\n    <int>: 3$`))
+	})
+})
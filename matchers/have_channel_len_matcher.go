@@ -0,0 +1,54 @@
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+type HaveChannelLenMatcher struct {
+	Expected interface{}
+
+	// state
+	length          int
+	expectedMatcher types.GomegaMatcher
+}
+
+func (matcher *HaveChannelLenMatcher) Match(actual interface{}) (success bool, err error) {
+	channel, err := toChannelValue("HaveChannelLen", actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.length = channel.Len()
+	matcher.expectedMatcher = channelSizeMatcher(matcher.Expected)
+	return matcher.expectedMatcher.Match(matcher.length)
+}
+
+func (matcher *HaveChannelLenMatcher) FailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Number of values buffered in %s failed to satisfy matcher.\n", format.Object(actual, 0))
+	return message + matcher.expectedMatcher.FailureMessage(matcher.length)
+}
+
+func (matcher *HaveChannelLenMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Number of values buffered in %s satisfied matcher, but should not have.\n", format.Object(actual, 0))
+	return message + matcher.expectedMatcher.NegatedFailureMessage(matcher.length)
+}
+
+// toChannelValue returns actual, which must be a channel, as a reflect.Value
+func toChannelValue(matcherName string, actual interface{}) (reflect.Value, error) {
+	if isNil(actual) || reflect.TypeOf(actual).Kind() != reflect.Chan {
+		return reflect.Value{}, fmt.Errorf("%s matcher expects a channel.  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+	return reflect.ValueOf(actual), nil
+}
+
+// channelSizeMatcher returns expected if it is a matcher, and otherwise compares with it numerically so that the type
+// of the number does not matter
+func channelSizeMatcher(expected interface{}) types.GomegaMatcher {
+	if expectedMatcher, ok := expected.(types.GomegaMatcher); ok {
+		return expectedMatcher
+	}
+	return &BeNumericallyMatcher{Comparator: "==", CompareTo: []interface{}{expected}}
+}
@@ -0,0 +1,52 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("HaveChannelLen", func() {
+	It("should match the number of values buffered in the channel", func() {
+		c := make(chan int, 3)
+		Expect(c).Should(HaveChannelLen(0))
+		c <- 1
+		c <- 2
+		Expect(c).Should(HaveChannelLen(2))
+		Expect(c).Should(HaveChannelLen(uint8(2)))
+		Expect(c).Should(HaveChannelLen(BeNumerically(">", 1)))
+		Expect((<-chan int)(c)).Should(HaveChannelLen(2))
+		Expect(c).ShouldNot(HaveChannelLen(3))
+		Expect(make(chan int)).Should(HaveChannelLen(0))
+	})
+
+	It("should read the length every time it is polled", func() {
+		c := make(chan int, 3)
+		go func() {
+			c <- 1
+			c <- 2
+		}()
+		Eventually(c).Should(HaveChannelLen(2))
+	})
+
+	It("should error when actual is not a channel", func() {
+		_, err := (&HaveChannelLenMatcher{Expected: 0}).Match(nil)
+		Expect(err).Should(MatchError("HaveChannelLen matcher expects a channel.  Got:\n    <nil>: nil"))
+		_, err = (&HaveChannelLenMatcher{Expected: 0}).Match([]int{})
+		Expect(err).Should(HaveOccurred())
+		_, err = (&HaveChannelLenMatcher{Expected: 0}).Match((chan int)(nil))
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should build failure messages", func() {
+		c := make(chan int, 3)
+		c <- 1
+		failures := InterceptGomegaFailures(func() {
+			Expect(c).Should(HaveChannelLen(2))
+			Expect(c).ShouldNot(HaveChannelLen(1))
+		})
+		Expect(failures).Should(HaveLen(2))
+		Expect(failures[0]).Should(MatchRegexp(`^Number of values buffered in <chan int \| len:1, cap:3>: 0x[0-9a-f]+ failed to satisfy matcher.\nExpected\n    <int>: 1\nto be ==\n    <int>: 2$`))
+		Expect(failures[1]).Should(MatchRegexp(`^Number of values buffered in <chan int \| len:1, cap:3>: 0x[0-9a-f]+ satisfied matcher, but should not have.\nExpected\n    <int>: 1\nnot to be ==\n    <int>: 1$`))
+	})
+})
@@ -0,0 +1,84 @@
+package matchers
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+// checksumAlgorithms are the algorithms supported by HaveChecksum
+var checksumAlgorithms = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+	"crc32":  func() hash.Hash { return crc32.NewIEEE() },
+}
+
+type HaveChecksumMatcher struct {
+	Algorithm string
+	Checksum  string
+
+	// state
+	actualChecksum string
+	actualSize     int64
+}
+
+func (matcher *HaveChecksumMatcher) Match(actual interface{}) (success bool, err error) {
+	newHash, ok := checksumAlgorithms[strings.ToLower(matcher.Algorithm)]
+	if !ok {
+		return false, fmt.Errorf("HaveChecksum matcher does not support the %q algorithm.  Supported algorithms are %s", matcher.Algorithm, strings.Join(supportedChecksumAlgorithms(), ", "))
+	}
+	matcher.actualChecksum, matcher.actualSize, err = checksum("HaveChecksum", newHash, actual)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(matcher.actualChecksum, matcher.Checksum), nil
+}
+
+func (matcher *HaveChecksumMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected content (%d bytes) to have %s checksum\n%s%s\nbut it has\n%s%s", matcher.actualSize, matcher.Algorithm, format.Indent, matcher.Checksum, format.Indent, matcher.actualChecksum)
+}
+
+func (matcher *HaveChecksumMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected content (%d bytes) not to have %s checksum\n%s%s", matcher.actualSize, matcher.Algorithm, format.Indent, matcher.Checksum)
+}
+
+func supportedChecksumAlgorithms() []string {
+	algorithms := []string{}
+	for algorithm := range checksumAlgorithms {
+		algorithms = append(algorithms, algorithm)
+	}
+	sort.Strings(algorithms)
+	return algorithms
+}
+
+// checksum hashes a string, stringer, []byte, or the remaining contents of an io.Reader, returning the hex-encoded
+// digest and the number of bytes hashed
+func checksum(matcherName string, newHash func() hash.Hash, content interface{}) (string, int64, error) {
+	h := newHash()
+	var size int64
+	if contentString, ok := toString(content); ok {
+		h.Write([]byte(contentString))
+		size = int64(len(contentString))
+	} else if reader, ok := content.(io.Reader); ok {
+		var err error
+		size, err = io.Copy(h, reader)
+		if err != nil {
+			return "", 0, fmt.Errorf("%s matcher failed to read from the io.Reader:\n%s", matcherName, format.IndentString(err.Error(), 1))
+		}
+	} else {
+		return "", 0, fmt.Errorf("%s matcher requires a string, stringer, []byte, or io.Reader.  Got:\n%s", matcherName, format.Object(content, 1))
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
@@ -0,0 +1,73 @@
+package matchers_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+type erroringReader struct{}
+
+func (erroringReader) Read(p []byte) (int, error) {
+	return 0, errors.New("boom")
+}
+
+var _ = Describe("HaveChecksum", func() {
+	const sha256OfTest = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+	It("should support several algorithms", func() {
+		Expect("test").Should(HaveChecksum("md5", "098f6bcd4621d373cade4e832627b4f6"))
+		Expect("test").Should(HaveChecksum("sha1", "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3"))
+		Expect("test").Should(HaveChecksum("sha256", sha256OfTest))
+		Expect("test").Should(HaveChecksum("sha512", "ee26b0dd4af7e749aa1a8ee3c10ae9923f618980772e473f8819a5d4940e0db27ac185f8a0e1d5f84f88bc887fd67b143732c304cc5fa9ad8e6f57f50028a8ff"))
+		Expect("test").Should(HaveChecksum("crc32", "d87f7e0c"))
+		Expect("test").ShouldNot(HaveChecksum("crc32", "00000000"))
+	})
+
+	It("should ignore the case of the algorithm and checksum", func() {
+		Expect("test").Should(HaveChecksum("SHA256", strings.ToUpper(sha256OfTest)))
+	})
+
+	It("should accept strings, stringers, []byte and io.Readers", func() {
+		Expect([]byte("test")).Should(HaveChecksum("sha256", sha256OfTest))
+		Expect(&myStringer{a: "test"}).Should(HaveChecksum("sha256", sha256OfTest))
+		Expect(strings.NewReader("test")).Should(HaveChecksum("sha256", sha256OfTest))
+	})
+
+	It("should report checksums and sizes rather than content", func() {
+		m := HaveChecksum("sha256", "abc123")
+		Expect(m.Match(strings.NewReader("test"))).Should(BeFalse())
+		Expect(m.FailureMessage(nil)).Should(Equal("Expected content (4 bytes) to have sha256 checksum\n    abc123\nbut it has\n    " + sha256OfTest))
+
+		m = HaveChecksum("sha256", sha256OfTest)
+		Expect(m.Match("test")).Should(BeTrue())
+		Expect(m.NegatedFailureMessage(nil)).Should(Equal("Expected content (4 bytes) not to have sha256 checksum\n    " + sha256OfTest))
+	})
+
+	Context("when passed an unsupported algorithm", func() {
+		It("should error", func() {
+			success, err := (&HaveChecksumMatcher{Algorithm: "sha3", Checksum: "abc"}).Match("test")
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError(`HaveChecksum matcher does not support the "sha3" algorithm.  Supported algorithms are crc32, md5, sha1, sha256, sha512`))
+		})
+	})
+
+	Context("when passed an unsupported actual", func() {
+		It("should error", func() {
+			success, err := (&HaveChecksumMatcher{Algorithm: "md5", Checksum: "abc"}).Match(17)
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError(ContainSubstring("HaveChecksum matcher requires a string, stringer, []byte, or io.Reader.  Got:\n    <int>: 17")))
+		})
+	})
+
+	Context("when the io.Reader errors", func() {
+		It("should error", func() {
+			success, err := (&HaveChecksumMatcher{Algorithm: "md5", Checksum: "abc"}).Match(erroringReader{})
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError("HaveChecksum matcher failed to read from the io.Reader:\n    boom"))
+		})
+	})
+})
@@ -0,0 +1,56 @@
+package matchers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+type HaveContextValueMatcher struct {
+	Key      interface{}
+	Expected interface{}
+
+	// state
+	value           interface{}
+	found           bool
+	expectedMatcher types.GomegaMatcher
+}
+
+func (matcher *HaveContextValueMatcher) Match(actual interface{}) (success bool, err error) {
+	ctx, ok := actual.(context.Context)
+	if !ok || isNil(actual) {
+		return false, fmt.Errorf("HaveContextValue matcher expects a context.Context.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	matcher.value = ctx.Value(matcher.Key)
+	matcher.found = matcher.value != nil
+	if !matcher.found {
+		return false, nil
+	}
+
+	var isMatcher bool
+	matcher.expectedMatcher, isMatcher = matcher.Expected.(types.GomegaMatcher)
+	if !isMatcher {
+		matcher.expectedMatcher = &EqualMatcher{Expected: matcher.Expected}
+	}
+	success, err = matcher.expectedMatcher.Match(matcher.value)
+	if err != nil {
+		return false, fmt.Errorf("HaveContextValue matcher could not match the value for key %s:\n%s", format.Object(matcher.Key, 0), format.IndentString(err.Error(), 1))
+	}
+	return success, nil
+}
+
+func (matcher *HaveContextValueMatcher) FailureMessage(actual interface{}) (message string) {
+	if !matcher.found {
+		return format.Message(actual, fmt.Sprintf("to have a value for key %s, but it has none", format.Object(matcher.Key, 0)))
+	}
+	message = fmt.Sprintf("Value for context key %s:\n", format.Object(matcher.Key, 0))
+	return message + format.IndentString(matcher.expectedMatcher.FailureMessage(matcher.value), 1)
+}
+
+func (matcher *HaveContextValueMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Value for context key %s:\n", format.Object(matcher.Key, 0))
+	return message + format.IndentString(matcher.expectedMatcher.NegatedFailureMessage(matcher.value), 1)
+}
@@ -0,0 +1,59 @@
+package matchers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+type contextKey string
+
+var _ = Describe("HaveContextValue", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.WithValue(context.Background(), contextKey("user"), "alice")
+		ctx = context.WithValue(ctx, contextKey("attempt"), 3)
+	})
+
+	It("should compare the value for the key with Equal", func() {
+		Expect(ctx).Should(HaveContextValue(contextKey("user"), "alice"))
+		Expect(ctx).Should(HaveContextValue(contextKey("attempt"), 3))
+		Expect(ctx).ShouldNot(HaveContextValue(contextKey("user"), "bob"))
+		Expect(ctx).ShouldNot(HaveContextValue("user", "alice"))
+	})
+
+	It("should pass the value for the key to a matcher", func() {
+		Expect(ctx).Should(HaveContextValue(contextKey("user"), HavePrefix("al")))
+		Expect(ctx).Should(HaveContextValue(contextKey("attempt"), BeNumerically(">", 2)))
+		Expect(ctx).ShouldNot(HaveContextValue(contextKey("attempt"), BeNumerically(">", 3)))
+	})
+
+	It("should not match when the context has no value for the key", func() {
+		Expect(ctx).ShouldNot(HaveContextValue(contextKey("missing"), BeNil()))
+		Expect(context.Background()).ShouldNot(HaveContextValue(contextKey("user"), "alice"))
+	})
+
+	It("should error when actual is not a context, or the matcher errors", func() {
+		_, err := (&HaveContextValueMatcher{Key: "user", Expected: "alice"}).Match(nil)
+		Expect(err).Should(MatchError("HaveContextValue matcher expects a context.Context.  Got:\n    <nil>: nil"))
+		_, err = (&HaveContextValueMatcher{Key: "user", Expected: "alice"}).Match("alice")
+		Expect(err).Should(HaveOccurred())
+		_, err = (&HaveContextValueMatcher{Key: contextKey("user"), Expected: BeNumerically(">", 1)}).Match(ctx)
+		Expect(err).Should(MatchError(HavePrefix("HaveContextValue matcher could not match the value for key <matchers_test.contextKey>: \"user\":\n")))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect(ctx).Should(HaveContextValue(contextKey("missing"), "alice"))
+			Expect(ctx).Should(HaveContextValue(contextKey("user"), "bob"))
+			Expect(ctx).ShouldNot(HaveContextValue(contextKey("user"), "alice"))
+		})
+		Expect(failures).Should(HaveLen(3))
+		Expect(failures[0]).Should(HaveSuffix("to have a value for key <matchers_test.contextKey>: \"missing\", but it has none"))
+		Expect(failures[1]).Should(Equal("Value for context key <matchers_test.contextKey>: \"user\":\n    Expected\n        <string>: alice\n    to equal\n        <string>: bob"))
+		Expect(failures[2]).Should(Equal("Value for context key <matchers_test.contextKey>: \"user\":\n    Expected\n        <string>: alice\n    not to equal\n        <string>: alice"))
+	})
+})
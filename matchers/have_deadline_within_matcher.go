@@ -0,0 +1,50 @@
+package matchers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/onsi/gomega/format"
+)
+
+type HaveDeadlineWithinMatcher struct {
+	Duration time.Duration
+
+	// state
+	deadline    time.Time
+	hasDeadline bool
+	remaining   time.Duration
+}
+
+func (matcher *HaveDeadlineWithinMatcher) Match(actual interface{}) (success bool, err error) {
+	ctx, ok := actual.(context.Context)
+	if !ok || isNil(actual) {
+		return false, fmt.Errorf("HaveDeadlineWithin matcher expects a context.Context.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	matcher.deadline, matcher.hasDeadline = ctx.Deadline()
+	if !matcher.hasDeadline {
+		return false, nil
+	}
+	matcher.remaining = time.Until(matcher.deadline)
+	return matcher.remaining <= matcher.Duration, nil
+}
+
+func (matcher *HaveDeadlineWithinMatcher) FailureMessage(actual interface{}) (message string) {
+	if !matcher.hasDeadline {
+		return format.Message(actual, fmt.Sprintf("to have a deadline within %s, but it has no deadline", matcher.Duration))
+	}
+	return format.Message(actual, fmt.Sprintf("to have a deadline within %s, but %s", matcher.Duration, matcher.describeDeadline()))
+}
+
+func (matcher *HaveDeadlineWithinMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("not to have a deadline within %s, but %s", matcher.Duration, matcher.describeDeadline()))
+}
+
+func (matcher *HaveDeadlineWithinMatcher) describeDeadline() string {
+	if matcher.remaining < 0 {
+		return fmt.Sprintf("its deadline passed %s ago, at %s", -matcher.remaining, matcher.deadline.Format(time.RFC3339Nano))
+	}
+	return fmt.Sprintf("its deadline is in %s, at %s", matcher.remaining, matcher.deadline.Format(time.RFC3339Nano))
+}
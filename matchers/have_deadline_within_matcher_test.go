@@ -0,0 +1,53 @@
+package matchers_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("HaveDeadlineWithin", func() {
+	It("should succeed when the deadline is at most the duration from now", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		Expect(ctx).Should(HaveDeadlineWithin(time.Minute))
+		Expect(ctx).Should(HaveDeadlineWithin(time.Hour))
+		Expect(ctx).ShouldNot(HaveDeadlineWithin(30 * time.Second))
+	})
+
+	It("should succeed when the deadline has passed", func() {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		defer cancel()
+		Expect(ctx).Should(HaveDeadlineWithin(0))
+	})
+
+	It("should not match a context without a deadline", func() {
+		Expect(context.Background()).ShouldNot(HaveDeadlineWithin(time.Hour))
+	})
+
+	It("should error when actual is not a context", func() {
+		_, err := (&HaveDeadlineWithinMatcher{Duration: time.Second}).Match(nil)
+		Expect(err).Should(MatchError("HaveDeadlineWithin matcher expects a context.Context.  Got:\n    <nil>: nil"))
+		_, err = (&HaveDeadlineWithinMatcher{Duration: time.Second}).Match(time.Now())
+		Expect(err).Should(HaveOccurred())
+	})
+
+	It("should build failure messages", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		defer cancel()
+		expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Minute))
+		defer cancelExpired()
+		failures := InterceptGomegaFailures(func() {
+			Expect(context.Background()).Should(HaveDeadlineWithin(time.Second))
+			Expect(ctx).Should(HaveDeadlineWithin(time.Second))
+			Expect(expired).ShouldNot(HaveDeadlineWithin(time.Second))
+		})
+		Expect(failures).Should(HaveLen(3))
+		Expect(failures[0]).Should(HaveSuffix("to have a deadline within 1s, but it has no deadline"))
+		Expect(failures[1]).Should(MatchRegexp(`to have a deadline within 1s, but its deadline is in 59m59\.\d+s, at \S+$`))
+		Expect(failures[2]).Should(MatchRegexp(`not to have a deadline within 1s, but its deadline passed 1m0\.\d+s ago, at \S+$`))
+	})
+})
@@ -0,0 +1,40 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type HaveDNSNamesMatcher struct {
+	Expected interface{}
+
+	// state
+	expectedMatcher omegaMatcher
+	dnsNames        []string
+}
+
+func (matcher *HaveDNSNamesMatcher) Match(actual interface{}) (success bool, err error) {
+	certificate, _, err := toCertificate("HaveDNSNames", actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.dnsNames = certificate.DNSNames
+	if matcher.dnsNames == nil {
+		matcher.dnsNames = []string{}
+	}
+	var isMatcher bool
+	matcher.expectedMatcher, isMatcher = matcher.Expected.(omegaMatcher)
+	if !isMatcher {
+		matcher.expectedMatcher = &EqualMatcher{Expected: matcher.Expected}
+	}
+	return matcher.expectedMatcher.Match(matcher.dnsNames)
+}
+
+func (matcher *HaveDNSNamesMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Certificate DNS names:\n%s", format.IndentString(matcher.expectedMatcher.FailureMessage(matcher.dnsNames), 1))
+}
+
+func (matcher *HaveDNSNamesMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Certificate DNS names:\n%s", format.IndentString(matcher.expectedMatcher.NegatedFailureMessage(matcher.dnsNames), 1))
+}
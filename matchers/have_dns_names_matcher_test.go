@@ -0,0 +1,31 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveDNSNames", func() {
+	leaf := newTestCertificate(1, "example.com", []string{"example.com", "www.example.com"}, nil)
+	ca := newTestCertificate(2, "Test CA", nil, nil)
+
+	It("should match the DNS names against a value or a matcher", func() {
+		Expect(leaf.Certificate).Should(HaveDNSNames([]string{"example.com", "www.example.com"}))
+		Expect(leaf.Certificate).Should(HaveDNSNames(ConsistOf("www.example.com", "example.com")))
+		Expect(leaf.Certificate).ShouldNot(HaveDNSNames(ContainElement("api.example.com")))
+	})
+
+	It("should treat a certificate without DNS names as having none", func() {
+		Expect(ca.Certificate).Should(HaveDNSNames(BeEmpty()))
+		Expect(ca.Certificate).Should(HaveDNSNames([]string{}))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect(leaf.Certificate).Should(HaveDNSNames(ContainElement("api.example.com")))
+		})
+		Expect(failures).Should(Equal([]string{
+			"Certificate DNS names:\n    Expected\n        <[]string | len:2, cap:2>: [\"example.com\", \"www.example.com\"]\n    to contain element matching\n        <string>: api.example.com",
+		}))
+	})
+})
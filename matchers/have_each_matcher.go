@@ -5,6 +5,7 @@ import (
 	"reflect"
 
 	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/internal/gutil"
 )
 
 type HaveEachMatcher struct {
@@ -30,7 +31,7 @@ func (matcher *HaveEachMatcher) Match(actual interface{}) (success bool, err err
 
 	var valueAt func(int) interface{}
 	if isMap(actual) {
-		keys := value.MapKeys()
+		keys := gutil.SortedMapKeys(value)
 		valueAt = func(i int) interface{} {
 			return value.MapIndex(keys[i]).Interface()
 		}
@@ -0,0 +1,62 @@
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+type HaveEachWithIndexMatcher struct {
+	MatcherFor func(index int) types.GomegaMatcher
+
+	// state
+	mismatchFailures []mismatchFailure
+}
+
+func (matcher *HaveEachWithIndexMatcher) Match(actual interface{}) (success bool, err error) {
+	if !isArrayOrSlice(actual) {
+		return false, fmt.Errorf("HaveEachWithIndex matcher expects an array/slice.  Got:\n%s", format.Object(actual, 1))
+	}
+	value := reflect.ValueOf(actual)
+	if value.Len() == 0 {
+		return false, fmt.Errorf("HaveEachWithIndex matcher expects a non-empty array/slice.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	matcher.mismatchFailures = nil
+	for i := 0; i < value.Len(); i++ {
+		elemMatcher := matcher.MatcherFor(i)
+		if elemMatcher == nil {
+			return false, fmt.Errorf("HaveEachWithIndex matcher's function returned a nil matcher for index %d", i)
+		}
+		element := value.Index(i).Interface()
+		success, err := elemMatcher.Match(element)
+		if err != nil {
+			return false, err
+		}
+		if !success {
+			matcher.mismatchFailures = append(matcher.mismatchFailures, mismatchFailure{
+				index:   i,
+				failure: elemMatcher.FailureMessage(element),
+			})
+		}
+	}
+
+	return len(matcher.mismatchFailures) == 0, nil
+}
+
+func (matcher *HaveEachWithIndexMatcher) FailureMessage(actual interface{}) (message string) {
+	message = format.Message(actual, "to have each element satisfy the matcher for its index")
+	if len(matcher.mismatchFailures) != 0 {
+		message = fmt.Sprintf("%s\nthe mismatch indexes were:", message)
+	}
+	for _, mismatch := range matcher.mismatchFailures {
+		message = fmt.Sprintf("%s\n%d: %s", message, mismatch.index, mismatch.failure)
+	}
+	return
+}
+
+func (matcher *HaveEachWithIndexMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to have each element satisfy the matcher for its index")
+}
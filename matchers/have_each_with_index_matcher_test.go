@@ -0,0 +1,65 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("HaveEachWithIndex", func() {
+	double := func(i int) types.GomegaMatcher { return Equal(i * 2) }
+
+	When("passed an array or slice", func() {
+		It("should do the right thing", func() {
+			Expect([]int{0, 2, 4}).Should(HaveEachWithIndex(double))
+			Expect([3]int{0, 2, 4}).Should(HaveEachWithIndex(double))
+			Expect([]int{0, 2, 5}).ShouldNot(HaveEachWithIndex(double))
+			Expect([]string{"a0", "b1"}).Should(HaveEachWithIndex(func(i int) types.GomegaMatcher {
+				return HaveSuffix(string(rune('0' + i)))
+			}))
+		})
+
+		It("reports every element that did not match", func() {
+			m := HaveEachWithIndex(double)
+			Expect(m.Match([]int{1, 2, 5})).Should(BeFalse())
+			Expect(m.FailureMessage([]int{1, 2, 5})).Should(Equal(`Expected
+    <[]int | len:3, cap:3>: [1, 2, 5]
+to have each element satisfy the matcher for its index
+the mismatch indexes were:
+0: Expected
+    <int>: 1
+to equal
+    <int>: 0
+2: Expected
+    <int>: 5
+to equal
+    <int>: 4`))
+			Expect(m.NegatedFailureMessage([]int{0, 2})).Should(Equal("Expected\n    <[]int | len:2, cap:2>: [0, 2]\nnot to have each element satisfy the matcher for its index"))
+		})
+	})
+
+	When("passed something that isn't an array or slice", func() {
+		It("should error", func() {
+			for _, actual := range []interface{}{map[int]int{0: 0}, []int{}, nil, 3} {
+				success, err := (&HaveEachWithIndexMatcher{MatcherFor: double}).Match(actual)
+				Expect(success).Should(BeFalse())
+				Expect(err).Should(HaveOccurred())
+			}
+		})
+	})
+
+	When("the function returns a nil matcher", func() {
+		It("should error", func() {
+			_, err := HaveEachWithIndex(func(int) types.GomegaMatcher { return nil }).Match([]int{1})
+			Expect(err).Should(MatchError("HaveEachWithIndex matcher's function returned a nil matcher for index 0"))
+		})
+	})
+
+	When("an element matcher errors", func() {
+		It("should error", func() {
+			_, err := HaveEachWithIndex(func(int) types.GomegaMatcher { return BeTrue() }).Match([]int{1})
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
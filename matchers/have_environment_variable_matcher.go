@@ -0,0 +1,125 @@
+package matchers
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+type HaveEnvironmentVariableMatcher struct {
+	Name  string
+	Value []interface{}
+
+	// state
+	found bool
+	value string
+}
+
+func (matcher *HaveEnvironmentVariableMatcher) Match(actual interface{}) (success bool, err error) {
+	env, err := matcher.environment(actual)
+	if err != nil {
+		return false, err
+	}
+	valueMatcher, err := matcher.getSubMatcher()
+	if err != nil {
+		return false, err
+	}
+
+	matcher.value, matcher.found = lookupEnv(env, matcher.Name)
+	if !matcher.found {
+		return false, nil
+	}
+	if valueMatcher == nil {
+		return true, nil
+	}
+	return valueMatcher.Match(matcher.value)
+}
+
+func (matcher *HaveEnvironmentVariableMatcher) FailureMessage(actual interface{}) (message string) {
+	if !matcher.found {
+		// only the names of the variables are listed, as their values may well be secrets
+		env, _ := matcher.environment(actual)
+		return fmt.Sprintf("Expected the environment to contain the variable %q, but it does not.  It has the variables:\n%s", matcher.Name, format.Object(environmentNames(env), 1))
+	}
+	valueMatcher, _ := matcher.getSubMatcher()
+	return fmt.Sprintf("Environment variable %q:\n%s", matcher.Name, format.IndentString(valueMatcher.FailureMessage(matcher.value), 1))
+}
+
+func (matcher *HaveEnvironmentVariableMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	valueMatcher, _ := matcher.getSubMatcher()
+	if valueMatcher == nil {
+		// only the length of the value is reported, as it may well be a secret
+		return fmt.Sprintf("Expected the environment not to contain the variable %q, but it is set to a %d-byte value.", matcher.Name, len(matcher.value))
+	}
+	return fmt.Sprintf("Environment variable %q:\n%s", matcher.Name, format.IndentString(valueMatcher.NegatedFailureMessage(matcher.value), 1))
+}
+
+// getSubMatcher returns the matcher for the variable's value, or nil if the variable need only be set
+func (matcher *HaveEnvironmentVariableMatcher) getSubMatcher() (types.GomegaMatcher, error) {
+	switch len(matcher.Value) {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		return nil, fmt.Errorf("HaveEnvironmentVariable matcher expects at most one value.  Got:\n%s", format.Object(matcher.Value, 1))
+	}
+	switch v := matcher.Value[0].(type) {
+	case string:
+		return &EqualMatcher{Expected: v}, nil
+	case types.GomegaMatcher:
+		return v, nil
+	default:
+		return nil, fmt.Errorf("HaveEnvironmentVariable matcher must be passed a string or a GomegaMatcher.  Got:\n%s", format.Object(v, 1))
+	}
+}
+
+// environment returns the environment actual describes.  Like exec.Cmd itself, a command with a nil Env is taken to
+// inherit the environment of the current process.
+func (matcher *HaveEnvironmentVariableMatcher) environment(actual interface{}) ([]string, error) {
+	switch a := actual.(type) {
+	case *exec.Cmd:
+		if a == nil {
+			return nil, fmt.Errorf("HaveEnvironmentVariable matcher expects a non-nil *exec.Cmd")
+		}
+		if a.Env == nil {
+			return os.Environ(), nil
+		}
+		return a.Env, nil
+	case []string:
+		return a, nil
+	default:
+		return nil, fmt.Errorf("HaveEnvironmentVariable matcher expects an *exec.Cmd or a []string of key=value pairs.  Got:\n%s", format.Object(actual, 1))
+	}
+}
+
+// environmentNames returns the sorted names of the variables in env
+func environmentNames(env []string) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, entry := range env {
+		name, _, _ := strings.Cut(entry, "=")
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupEnv finds the value of name in env.  When a name appears more than once the last value wins, as it does
+// when exec.Cmd starts a process.
+func lookupEnv(env []string, name string) (value string, found bool) {
+	for _, entry := range env {
+		key, entryValue, ok := strings.Cut(entry, "=")
+		if ok && key == name {
+			value, found = entryValue, true
+		}
+	}
+	return value, found
+}
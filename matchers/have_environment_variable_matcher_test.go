@@ -0,0 +1,118 @@
+package matchers_test
+
+import (
+	"os"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("HaveEnvironmentVariable", func() {
+	var cmd *exec.Cmd
+
+	BeforeEach(func() {
+		cmd = exec.Command("true")
+		cmd.Env = []string{"HOME=/home/gopher", "GOFLAGS=-mod=mod", "EMPTY="}
+	})
+
+	It("succeeds if the variable is set", func() {
+		Expect(cmd).To(HaveEnvironmentVariable("HOME"))
+		Expect(cmd).To(HaveEnvironmentVariable("EMPTY"))
+		Expect(cmd).NotTo(HaveEnvironmentVariable("PATH"))
+		Expect(cmd).NotTo(HaveEnvironmentVariable("home"))
+	})
+
+	It("compares string values for equality", func() {
+		Expect(cmd).To(HaveEnvironmentVariable("GOFLAGS", "-mod=mod"))
+		Expect(cmd).To(HaveEnvironmentVariable("EMPTY", ""))
+		Expect(cmd).NotTo(HaveEnvironmentVariable("GOFLAGS", "-mod=vendor"))
+		Expect(cmd).NotTo(HaveEnvironmentVariable("PATH", ""))
+	})
+
+	It("matches values against matchers", func() {
+		Expect(cmd).To(HaveEnvironmentVariable("HOME", HavePrefix("/home/")))
+		Expect(cmd).NotTo(HaveEnvironmentVariable("HOME", HavePrefix("/root")))
+	})
+
+	It("uses the last value of a variable that is set more than once", func() {
+		cmd.Env = append(cmd.Env, "HOME=/root")
+		Expect(cmd).To(HaveEnvironmentVariable("HOME", "/root"))
+		Expect(cmd).NotTo(HaveEnvironmentVariable("HOME", "/home/gopher"))
+	})
+
+	It("ignores entries that aren't key=value pairs", func() {
+		Expect([]string{"JUNK"}).NotTo(HaveEnvironmentVariable("JUNK"))
+	})
+
+	When("the command's Env is nil", func() {
+		It("checks the environment of the current process, which the command inherits", func() {
+			os.Setenv("GOMEGA_HAVE_ENVIRONMENT_VARIABLE", "inherited")
+			DeferCleanup(os.Unsetenv, "GOMEGA_HAVE_ENVIRONMENT_VARIABLE")
+
+			cmd.Env = nil
+			Expect(cmd).To(HaveEnvironmentVariable("GOMEGA_HAVE_ENVIRONMENT_VARIABLE", "inherited"))
+		})
+	})
+
+	When("ACTUAL is a []string", func() {
+		It("treats it as a list of key=value pairs", func() {
+			Expect([]string{"A=1", "B=2"}).To(HaveEnvironmentVariable("B", "2"))
+			Expect([]string{}).NotTo(HaveEnvironmentVariable("B"))
+		})
+	})
+
+	It("errors when ACTUAL is not an environment", func() {
+		success, err := (&HaveEnvironmentVariableMatcher{Name: "HOME"}).Match(map[string]string{"HOME": "/"})
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError(ContainSubstring("HaveEnvironmentVariable matcher expects an *exec.Cmd or a []string of key=value pairs.")))
+
+		var nilCmd *exec.Cmd
+		_, err = (&HaveEnvironmentVariableMatcher{Name: "HOME"}).Match(nilCmd)
+		Expect(err).To(MatchError("HaveEnvironmentVariable matcher expects a non-nil *exec.Cmd"))
+	})
+
+	It("errors when passed an invalid value", func() {
+		_, err := HaveEnvironmentVariable("HOME", 3).Match(cmd)
+		Expect(err).To(MatchError(ContainSubstring("HaveEnvironmentVariable matcher must be passed a string or a GomegaMatcher.")))
+
+		_, err = HaveEnvironmentVariable("HOME", "a", "b").Match(cmd)
+		Expect(err).To(MatchError(ContainSubstring("HaveEnvironmentVariable matcher expects at most one value.")))
+	})
+
+	Describe("failure messages", func() {
+		It("lists the names of the variables, but not their values, when the variable is missing", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect([]string{"TOKEN=secret", "A=1", "A=2"}).To(HaveEnvironmentVariable("B"))
+			})
+			Expect(failures).To(ConsistOf("Expected the environment to contain the variable \"B\", but it does not.  It has the variables:\n    <[]string | len:2, cap:2>: [\"A\", \"TOKEN\"]"))
+
+			failures = InterceptGomegaFailures(func() {
+				Expect([]string{"A=1"}).To(HaveEnvironmentVariable("B", "2"))
+			})
+			Expect(failures).To(ConsistOf(HavePrefix("Expected the environment to contain the variable \"B\", but it does not.")))
+		})
+
+		It("reports the value matcher's failure when the value doesn't match", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(cmd).To(HaveEnvironmentVariable("GOFLAGS", "-mod=vendor"))
+			})
+			Expect(failures).To(ConsistOf("Environment variable \"GOFLAGS\":\n    Expected\n        <string>: -mod=mod\n    to equal\n        <string>: -mod=vendor"))
+		})
+
+		It("reports the length, but not the value, of a variable that should not be set", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(cmd).NotTo(HaveEnvironmentVariable("HOME"))
+			})
+			Expect(failures).To(ConsistOf("Expected the environment not to contain the variable \"HOME\", but it is set to a 12-byte value."))
+		})
+
+		It("reports the value matcher's negated failure when the value matches", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(cmd).NotTo(HaveEnvironmentVariable("HOME", HavePrefix("/home/")))
+			})
+			Expect(failures).To(ConsistOf("Environment variable \"HOME\":\n    Expected\n        <string>: /home/gopher\n    not to have prefix\n        <string>: /home/"))
+		})
+	})
+})
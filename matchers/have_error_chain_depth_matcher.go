@@ -0,0 +1,26 @@
+package matchers
+
+import "fmt"
+
+type HaveErrorChainDepthMatcher struct {
+	Depth int
+
+	// state
+	chain []error
+}
+
+func (matcher *HaveErrorChainDepthMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.chain, err = toErrorChain("HaveErrorChainDepth", actual)
+	if err != nil {
+		return false, err
+	}
+	return len(matcher.chain)-1 == matcher.Depth, nil
+}
+
+func (matcher *HaveErrorChainDepthMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the error chain to have depth %d, but it has depth %d\n%s", matcher.Depth, len(matcher.chain)-1, formatErrorChain(matcher.chain))
+}
+
+func (matcher *HaveErrorChainDepthMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the error chain not to have depth %d\n%s", matcher.Depth, formatErrorChain(matcher.chain))
+}
@@ -0,0 +1,50 @@
+package matchers_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("HaveErrorChainDepth", func() {
+	It("succeeds if the error wraps exactly depth errors", func() {
+		err := errors.New("boom")
+		Expect(err).Should(HaveErrorChainDepth(0))
+		Expect(err).ShouldNot(HaveErrorChainDepth(1))
+
+		err = fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", err))
+		Expect(err).Should(HaveErrorChainDepth(2))
+		Expect(err).ShouldNot(HaveErrorChainDepth(1))
+		Expect(err).ShouldNot(HaveErrorChainDepth(3))
+	})
+
+	It("does not follow errors formatted with %v", func() {
+		Expect(fmt.Errorf("outer: %v", errors.New("boom"))).Should(HaveErrorChainDepth(0))
+	})
+
+	It("errors when ACTUAL is not an error", func() {
+		_, err := (&HaveErrorChainDepthMatcher{Depth: 0}).Match(nil)
+		Expect(err).Should(MatchError("HaveErrorChainDepth matcher expects an error, got nil"))
+
+		_, err = (&HaveErrorChainDepthMatcher{Depth: 0}).Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("HaveErrorChainDepth matcher expects an error.  Got:")))
+	})
+
+	Describe("failure messages", func() {
+		It("reports the actual depth and prints the chain", func() {
+			err := fmt.Errorf("outer: %w", errors.New("boom"))
+			failures := InterceptGomegaFailures(func() {
+				Expect(err).Should(HaveErrorChainDepth(2))
+			})
+			Expect(failures).Should(ConsistOf("Expected the error chain to have depth 2, but it has depth 1\nError chain:\n    [0] *fmt.wrapError: outer: boom\n    [1] *errors.errorString: boom"))
+
+			failures = InterceptGomegaFailures(func() {
+				Expect(err).ShouldNot(HaveErrorChainDepth(1))
+			})
+			Expect(failures).Should(ConsistOf("Expected the error chain not to have depth 1\nError chain:\n    [0] *fmt.wrapError: outer: boom\n    [1] *errors.errorString: boom"))
+		})
+	})
+})
@@ -0,0 +1,59 @@
+package matchers
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+)
+
+type HaveErrorInChainMatcher struct {
+	Target interface{}
+
+	// state
+	chain []error
+	depth int
+}
+
+func (matcher *HaveErrorInChainMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.chain, err = toErrorChain("HaveErrorInChain", actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.depth = -1
+
+	if target, ok := matcher.Target.(error); ok {
+		// errors.Is holds for every error above the one that matches target, so the deepest depth is the match
+		for depth, element := range matcher.chain {
+			if errors.Is(element, target) || reflect.DeepEqual(element, target) {
+				matcher.depth = depth
+			}
+		}
+		return matcher.depth >= 0, nil
+	}
+
+	elementMatcher, err := toErrorChainElementMatcher("HaveErrorInChain", matcher.Target)
+	if err != nil {
+		return false, err
+	}
+	for depth, element := range matcher.chain {
+		success, err := elementMatcher.Match(element)
+		if err != nil {
+			return false, err
+		}
+		if success {
+			matcher.depth = depth
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (matcher *HaveErrorInChainMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the error chain to contain\n%s\n%s", format.Object(matcher.Target, 1), formatErrorChain(matcher.chain))
+}
+
+func (matcher *HaveErrorInChainMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the error chain not to contain\n%s\nbut it does, at depth %d\n%s", format.Object(matcher.Target, 1), matcher.depth, formatErrorChain(matcher.chain))
+}
@@ -0,0 +1,117 @@
+package matchers_test
+
+import (
+	"fmt"
+	"io/fs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+type multiWrapError []error
+
+func (e multiWrapError) Error() string {
+	return "multiple errors"
+}
+
+func (e multiWrapError) Unwrap() []error {
+	return e
+}
+
+type chainTestError struct {
+	code int
+}
+
+func (e chainTestError) Error() string {
+	return fmt.Sprintf("code %d", e.code)
+}
+
+var _ = Describe("HaveErrorInChain", func() {
+	var pathErr *fs.PathError
+	var err error
+
+	BeforeEach(func() {
+		pathErr = &fs.PathError{Op: "open", Path: "config.yml", Err: fs.ErrNotExist}
+		err = fmt.Errorf("loading config: %w", pathErr)
+	})
+
+	When("passed an error", func() {
+		It("succeeds if an error in the chain is the target, according to errors.Is", func() {
+			Expect(err).Should(HaveErrorInChain(fs.ErrNotExist))
+			Expect(err).Should(HaveErrorInChain(pathErr))
+			Expect(err).Should(HaveErrorInChain(err))
+			Expect(err).ShouldNot(HaveErrorInChain(fs.ErrPermission))
+		})
+
+		It("succeeds if an error in the chain is deeply equal to the target", func() {
+			Expect(fmt.Errorf("wrapped: %w", chainTestError{3})).Should(HaveErrorInChain(chainTestError{3}))
+			Expect(fmt.Errorf("wrapped: %w", chainTestError{3})).ShouldNot(HaveErrorInChain(chainTestError{4}))
+
+			Expect(err).Should(HaveErrorInChain(&fs.PathError{Op: "open", Path: "config.yml", Err: fs.ErrNotExist}))
+		})
+	})
+
+	When("passed a string", func() {
+		It("succeeds if the message of an error in the chain equals the string", func() {
+			Expect(err).Should(HaveErrorInChain("open config.yml: file does not exist"))
+			Expect(err).Should(HaveErrorInChain("file does not exist"))
+			Expect(err).ShouldNot(HaveErrorInChain("config.yml"))
+		})
+	})
+
+	When("passed a matcher", func() {
+		It("succeeds if an error in the chain satisfies the matcher", func() {
+			Expect(err).Should(HaveErrorInChain(BeAssignableToTypeOf(&fs.PathError{})))
+			Expect(err).Should(HaveErrorInChain(MatchError(HavePrefix("open"))))
+			Expect(err).ShouldNot(HaveErrorInChain(BeAssignableToTypeOf(chainTestError{})))
+		})
+
+		It("errors if the matcher errors", func() {
+			success, matchErr := (&HaveErrorInChainMatcher{Target: HaveLen(1)}).Match(err)
+			Expect(success).Should(BeFalse())
+			Expect(matchErr).Should(HaveOccurred())
+		})
+	})
+
+	It("errors when ACTUAL is not an error", func() {
+		_, matchErr := (&HaveErrorInChainMatcher{Target: fs.ErrNotExist}).Match(nil)
+		Expect(matchErr).Should(MatchError("HaveErrorInChain matcher expects an error, got nil"))
+
+		_, matchErr = (&HaveErrorInChainMatcher{Target: fs.ErrNotExist}).Match("an error")
+		Expect(matchErr).Should(MatchError(ContainSubstring("HaveErrorInChain matcher expects an error.  Got:")))
+	})
+
+	It("errors when passed an invalid target", func() {
+		_, matchErr := (&HaveErrorInChainMatcher{Target: 3}).Match(err)
+		Expect(matchErr).Should(MatchError(ContainSubstring("HaveErrorInChain matcher must be passed an error, a string, or a matcher.  Got:")))
+	})
+
+	Describe("failure messages", func() {
+		It("prints the whole chain", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(err).Should(HaveErrorInChain(fs.ErrPermission))
+			})
+			Expect(failures).Should(ConsistOf(HaveSuffix("Error chain:\n    [0] *fmt.wrapError: loading config: open config.yml: file does not exist\n    [1] *fs.PathError: open config.yml: file does not exist\n    [2] *errors.errorString: file does not exist")))
+			Expect(failures[0]).Should(HavePrefix("Expected the error chain to contain\n"))
+		})
+
+		It("reports the depth at which the target was found", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(err).ShouldNot(HaveErrorInChain(fs.ErrNotExist))
+			})
+			Expect(failures).Should(ConsistOf(ContainSubstring("but it does, at depth 2\nError chain:\n")))
+
+			failures = InterceptGomegaFailures(func() {
+				Expect(err).ShouldNot(HaveErrorInChain(BeAssignableToTypeOf(&fs.PathError{})))
+			})
+			Expect(failures).Should(ConsistOf(ContainSubstring("but it does, at depth 1\nError chain:\n")))
+		})
+	})
+
+	It("stops at errors that wrap more than one error", func() {
+		joined := fmt.Errorf("outer: %w", multiWrapError{fs.ErrNotExist, fs.ErrPermission})
+		Expect(joined).ShouldNot(HaveErrorInChain(BeAssignableToTypeOf(fs.ErrPermission)))
+		Expect(joined).Should(HaveErrorChainDepth(1))
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
 )
 
 // missingFieldError represents a missing field extraction error that
@@ -65,11 +66,16 @@ type HaveFieldMatcher struct {
 	Field    string
 	Expected interface{}
 
-	extractedField  interface{}
-	expectedMatcher omegaMatcher
+	extractedField         interface{}
+	expectedMatcher        omegaMatcher
+	provenance             types.Provenance
+	trackedProvenance      types.Provenance
+	nestedTracksProvenance bool
 }
 
 func (matcher *HaveFieldMatcher) Match(actual interface{}) (success bool, err error) {
+	// provenance only applies to the Match it was tracked for - a later, standalone, Match starts afresh
+	matcher.provenance, matcher.trackedProvenance = matcher.trackedProvenance, nil
 	matcher.extractedField, err = extractField(actual, matcher.Field, "HaveField")
 	if err != nil {
 		return false, err
@@ -81,6 +87,7 @@ func (matcher *HaveFieldMatcher) Match(actual interface{}) (success bool, err er
 		matcher.expectedMatcher = &EqualMatcher{Expected: matcher.Expected}
 	}
 
+	matcher.nestedTracksProvenance = types.TrackProvenance(matcher.expectedMatcher, matcher.fieldProvenance())
 	return matcher.expectedMatcher.Match(matcher.extractedField)
 }
 
@@ -88,12 +95,43 @@ func (matcher *HaveFieldMatcher) FailureMessage(actual interface{}) (message str
 	message = fmt.Sprintf("Value for field '%s' failed to satisfy matcher.\n", matcher.Field)
 	message += matcher.expectedMatcher.FailureMessage(matcher.extractedField)
 
-	return message
+	return matcher.annotate(message)
 }
 
 func (matcher *HaveFieldMatcher) NegatedFailureMessage(actual interface{}) (message string) {
 	message = fmt.Sprintf("Value for field '%s' satisfied matcher, but should not have.\n", matcher.Field)
 	message += matcher.expectedMatcher.NegatedFailureMessage(matcher.extractedField)
 
-	return message
+	return matcher.annotate(message)
+}
+
+// LegacyFailureMessage makes HaveFieldMatcher a types.LegacyFailureMessager: it renders the legacy message of the
+// field's matcher, without naming the field's provenance
+func (matcher *HaveFieldMatcher) LegacyFailureMessage(actual interface{}, desiredMatch bool) (message string) {
+	if desiredMatch {
+		message = fmt.Sprintf("Value for field '%s' failed to satisfy matcher.\n", matcher.Field)
+	} else {
+		message = fmt.Sprintf("Value for field '%s' satisfied matcher, but should not have.\n", matcher.Field)
+	}
+	return message + types.LegacyFailureMessageFor(matcher.expectedMatcher, matcher.extractedField, desiredMatch)
+}
+
+// TrackProvenance makes HaveFieldMatcher a types.ProvenanceTracker
+func (matcher *HaveFieldMatcher) TrackProvenance(provenance types.Provenance) {
+	matcher.trackedProvenance = provenance
+}
+
+func (matcher *HaveFieldMatcher) fieldProvenance() types.Provenance {
+	steps := []string{}
+	for _, field := range strings.Split(matcher.Field, ".") {
+		steps = append(steps, "."+field)
+	}
+	return matcher.provenance.Append(steps...)
+}
+
+func (matcher *HaveFieldMatcher) annotate(message string) string {
+	if len(matcher.provenance) == 0 || matcher.nestedTracksProvenance {
+		return message
+	}
+	return matcher.fieldProvenance().Annotate(message)
 }
@@ -2,10 +2,12 @@ package matchers_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
 )
 
 type Book struct {
@@ -180,4 +182,40 @@ var _ = Describe("HaveField", func() {
 		})
 	})
 
+	Describe("provenance", func() {
+		It("annotates failures nested in another HaveField with the path from the actual", func() {
+			matcher := HaveField("Author", HaveField("FirstName", WithTransform(strings.ToLower, Equal("hugo"))))
+			Ω(matcher.Match(book)).Should(BeFalse())
+			Ω(matcher.FailureMessage(book)).Should(Equal("Value for field 'Author' failed to satisfy matcher.\nValue for field 'FirstName' failed to satisfy matcher.\nProvenance: actual.Author.FirstName → transformed by ToLower\nExpected\n    <string>: victor\nto equal\n    <string>: hugo"))
+		})
+
+		It("annotates the innermost HaveField", func() {
+			matcher := HaveField("Sequel", HaveField("Title", "Les Miserables 3"))
+			Ω(matcher.Match(book)).Should(BeFalse())
+			Ω(matcher.FailureMessage(book)).Should(HavePrefix("Value for field 'Sequel' failed to satisfy matcher.\nProvenance: actual.Sequel.Title\nValue for field 'Title' failed to satisfy matcher.\n"))
+
+			matcher = HaveField("Sequel", HaveField("Title", "Les Miserables 2"))
+			Ω(matcher.Match(book)).Should(BeTrue())
+			Ω(matcher.NegatedFailureMessage(book)).Should(ContainSubstring("\nProvenance: actual.Sequel.Title\nValue for field 'Title' satisfied matcher, but should not have.\n"))
+		})
+
+		It("does not carry the provenance over when a nested matcher is reused on its own", func() {
+			inner := HaveField("FirstName", WithTransform(strings.ToLower, Equal("hugo")))
+			Ω(HaveField("Author", inner).Match(book)).Should(BeFalse())
+			Ω(inner.Match(book.Author)).Should(BeFalse())
+			Ω(inner.FailureMessage(book.Author)).Should(Equal("Value for field 'FirstName' failed to satisfy matcher.\nProvenance: actual.FirstName → transformed by ToLower\nExpected\n    <string>: victor\nto equal\n    <string>: hugo"))
+		})
+
+		It("does not annotate failures that aren't nested", func() {
+			matcher := HaveField("Author.FirstName", "Hugo")
+			Ω(matcher.Match(book)).Should(BeFalse())
+			Ω(matcher.FailureMessage(book)).ShouldNot(ContainSubstring("Provenance"))
+		})
+
+		It("does not annotate legacy failure messages, and renders the legacy messages of the matchers nested within", func() {
+			matcher := HaveField("Author", HaveField("FirstName", WithTransform(strings.ToLower, Or(Equal("hugo"), Equal("jean")))))
+			Ω(matcher.Match(book)).Should(BeFalse())
+			Ω(types.LegacyFailureMessageFor(matcher, book, true)).Should(HavePrefix("Value for field 'Author' failed to satisfy matcher.\nValue for field 'FirstName' failed to satisfy matcher.\nExpected\n    <string>: victor\nTo satisfy at least one of these matchers: ["))
+		})
+	})
 })
@@ -0,0 +1,169 @@
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+)
+
+// grpcCodeNames are the names of the canonical gRPC status codes, as defined by google.golang.org/grpc/codes
+var grpcCodeNames = []string{"OK", "Canceled", "Unknown", "InvalidArgument", "DeadlineExceeded", "NotFound", "AlreadyExists", "PermissionDenied", "ResourceExhausted", "FailedPrecondition", "Aborted", "OutOfRange", "Unimplemented", "Internal", "Unavailable", "DataLoss", "Unauthenticated"}
+
+const grpcCodeUnknown = 2
+
+type HaveGRPCStatusMatcher struct {
+	Code    interface{}
+	Message interface{}
+	Details interface{}
+
+	// state
+	status  grpcStatus
+	failure string
+}
+
+// grpcStatus is the status HaveGRPCStatus extracts from an error.  Gomega does not depend on google.golang.org/grpc,
+// so it is read by calling the methods of the *status.Status returned by the error's GRPCStatus method.
+type grpcStatus struct {
+	code    uint64
+	message string
+	details []interface{}
+	proto   string
+}
+
+func (matcher *HaveGRPCStatusMatcher) Match(actual interface{}) (success bool, err error) {
+	expectedCode, ok := toGRPCCode(matcher.Code)
+	if !ok {
+		return false, fmt.Errorf("HaveGRPCStatus matcher expects a status code, such as codes.NotFound.  Got:\n%s", format.Object(matcher.Code, 1))
+	}
+	matcher.status, err = grpcStatusOf(actual)
+	if err != nil {
+		return false, err
+	}
+
+	if matcher.status.code != expectedCode {
+		matcher.failure = fmt.Sprintf("Expected a gRPC status with code %s, but got code %s", grpcCodeName(expectedCode), grpcCodeName(matcher.status.code))
+		return false, nil
+	}
+
+	if matcher.Message != nil {
+		messageMatcher, ok := matcher.Message.(omegaMatcher)
+		if !ok {
+			messageMatcher = &EqualMatcher{Expected: matcher.Message}
+		}
+		success, err := messageMatcher.Match(matcher.status.message)
+		if err != nil {
+			return false, fmt.Errorf("HaveGRPCStatus matcher's message matcher failed with:\n%s", format.IndentString(err.Error(), 1))
+		}
+		if !success {
+			matcher.failure = fmt.Sprintf("Expected the gRPC status message to match, but:\n%s", format.IndentString(messageMatcher.FailureMessage(matcher.status.message), 1))
+			return false, nil
+		}
+	}
+
+	if matcher.Details != nil {
+		detailsMatcher, ok := matcher.Details.(omegaMatcher)
+		if !ok {
+			return false, fmt.Errorf("HaveGRPCStatus matcher expects a matcher for the status details.  Got:\n%s", format.Object(matcher.Details, 1))
+		}
+		success, err := detailsMatcher.Match(matcher.status.details)
+		if err != nil {
+			return false, fmt.Errorf("HaveGRPCStatus matcher's details matcher failed with:\n%s", format.IndentString(err.Error(), 1))
+		}
+		if !success {
+			matcher.failure = fmt.Sprintf("Expected the gRPC status details to match, but:\n%s", format.IndentString(detailsMatcher.FailureMessage(matcher.status.details), 1))
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (matcher *HaveGRPCStatusMatcher) FailureMessage(actual interface{}) (message string) {
+	return matcher.failure + "\n" + matcher.renderedStatus()
+}
+
+func (matcher *HaveGRPCStatusMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	code, _ := toGRPCCode(matcher.Code)
+	return fmt.Sprintf("Expected a gRPC status other than code %s, but got one\n%s", grpcCodeName(code), matcher.renderedStatus())
+}
+
+func (matcher *HaveGRPCStatusMatcher) renderedStatus() string {
+	if matcher.status.proto == "" {
+		return fmt.Sprintf("Status:\n%s", format.IndentString(fmt.Sprintf("code: %s, message: %q", grpcCodeName(matcher.status.code), matcher.status.message), 1))
+	}
+	return fmt.Sprintf("Status:\n%s", format.IndentString(matcher.status.proto, 1))
+}
+
+// grpcStatusOf extracts the status of actual as status.FromError does: nil is OK, an error - or an error it wraps -
+// with a GRPCStatus method has that status, and any other error is Unknown with the error's message
+func grpcStatusOf(actual interface{}) (grpcStatus, error) {
+	if isNil(actual) {
+		return grpcStatus{}, nil
+	}
+	chain, err := toErrorChain("HaveGRPCStatus", actual)
+	if err != nil {
+		return grpcStatus{}, err
+	}
+	for _, err := range chain {
+		method := reflect.ValueOf(err).MethodByName("GRPCStatus")
+		if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+			continue
+		}
+		s := method.Call(nil)[0]
+		if isNil(s.Interface()) {
+			continue
+		}
+		return readGRPCStatus(s)
+	}
+	return grpcStatus{code: grpcCodeUnknown, message: chain[0].Error()}, nil
+}
+
+func readGRPCStatus(s reflect.Value) (grpcStatus, error) {
+	call := func(name string) (interface{}, bool) {
+		method := s.MethodByName(name)
+		if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+			return nil, false
+		}
+		return method.Call(nil)[0].Interface(), true
+	}
+
+	status := grpcStatus{}
+	code, ok := call("Code")
+	if ok {
+		status.code, ok = toGRPCCode(code)
+	}
+	if !ok {
+		return status, fmt.Errorf("HaveGRPCStatus matcher expects GRPCStatus() to return a status with a Code() method.  Got:\n%s", format.Object(s.Interface(), 1))
+	}
+	if message, ok := call("Message"); ok {
+		status.message, _ = message.(string)
+	}
+	if details, ok := call("Details"); ok {
+		status.details, _ = details.([]interface{})
+	}
+	if proto, ok := call("Proto"); ok && !isNil(proto) {
+		status.proto = fmt.Sprint(proto)
+	}
+	return status, nil
+}
+
+func toGRPCCode(code interface{}) (uint64, bool) {
+	if code == nil {
+		return 0, false
+	}
+	if isUnsignedInteger(code) {
+		return toUnsignedInteger(code), true
+	}
+	if isInteger(code) && toInteger(code) >= 0 {
+		return uint64(toInteger(code)), true
+	}
+	return 0, false
+}
+
+func grpcCodeName(code uint64) string {
+	if code < uint64(len(grpcCodeNames)) {
+		return grpcCodeNames[code]
+	}
+	return fmt.Sprintf("Code(%d)", code)
+}
@@ -0,0 +1,114 @@
+package matchers_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+// fakeCode, fakeStatus and fakeStatusError mirror the API of google.golang.org/grpc's codes.Code, *status.Status and
+// status errors
+type fakeCode uint32
+
+const (
+	fakeOK              fakeCode = 0
+	fakeUnknown         fakeCode = 2
+	fakeInvalidArgument fakeCode = 3
+	fakeNotFound        fakeCode = 5
+)
+
+type fakeStatusProto struct {
+	code    fakeCode
+	message string
+}
+
+func (p *fakeStatusProto) String() string {
+	return fmt.Sprintf("code:%d message:%q", p.code, p.message)
+}
+
+type fakeStatus struct {
+	code    fakeCode
+	message string
+	details []interface{}
+}
+
+func (s *fakeStatus) Code() fakeCode          { return s.code }
+func (s *fakeStatus) Message() string         { return s.message }
+func (s *fakeStatus) Details() []interface{}  { return s.details }
+func (s *fakeStatus) Proto() *fakeStatusProto { return &fakeStatusProto{s.code, s.message} }
+
+type fakeStatusError struct {
+	status *fakeStatus
+}
+
+func (e fakeStatusError) Error() string {
+	return fmt.Sprintf("rpc error: code = %d desc = %s", e.status.code, e.status.message)
+}
+func (e fakeStatusError) GRPCStatus() *fakeStatus { return e.status }
+
+func statusError(code fakeCode, message string, details ...interface{}) error {
+	return fakeStatusError{&fakeStatus{code: code, message: message, details: details}}
+}
+
+var _ = Describe("HaveGRPCStatus", func() {
+	It("matches the status code", func() {
+		Expect(statusError(fakeNotFound, "no such user")).Should(HaveGRPCStatus(fakeNotFound))
+		Expect(statusError(fakeNotFound, "no such user")).ShouldNot(HaveGRPCStatus(fakeInvalidArgument))
+	})
+
+	It("extracts the status as status.FromError does", func() {
+		Expect(nil).Should(HaveGRPCStatus(fakeOK))
+		Expect(fmt.Errorf("creating user: %w", statusError(fakeNotFound, ""))).Should(HaveGRPCStatus(fakeNotFound))
+		Expect(errors.New("boom")).Should(HaveGRPCStatus(fakeUnknown, "boom"))
+	})
+
+	It("optionally matches the message and details", func() {
+		err := statusError(fakeInvalidArgument, "invalid email", "field: email")
+		Expect(err).Should(HaveGRPCStatus(fakeInvalidArgument, "invalid email"))
+		Expect(err).Should(HaveGRPCStatus(fakeInvalidArgument, ContainSubstring("email"), ConsistOf("field: email")))
+		Expect(err).ShouldNot(HaveGRPCStatus(fakeInvalidArgument, "invalid name"))
+		Expect(err).ShouldNot(HaveGRPCStatus(fakeInvalidArgument, ContainSubstring("email"), BeEmpty()))
+	})
+
+	Describe("failure messages", func() {
+		It("explains what did not match and prints the status proto", func() {
+			err := statusError(fakeNotFound, "no such user", "user: bob")
+			failures := InterceptGomegaFailures(func() {
+				Expect(err).Should(HaveGRPCStatus(fakeInvalidArgument))
+				Expect(err).Should(HaveGRPCStatus(fakeNotFound, "no such group"))
+				Expect(err).Should(HaveGRPCStatus(fakeNotFound, "no such user", BeEmpty()))
+				Expect(err).ShouldNot(HaveGRPCStatus(fakeNotFound))
+			})
+			Expect(failures).Should(HaveLen(4))
+			Expect(failures[0]).Should(Equal("Expected a gRPC status with code InvalidArgument, but got code NotFound\nStatus:\n    code:5 message:\"no such user\""))
+			Expect(failures[1]).Should(HavePrefix("Expected the gRPC status message to match, but:\n    Expected\n        <string>: no such user\n    to equal\n        <string>: no such group"))
+			Expect(failures[2]).Should(HavePrefix("Expected the gRPC status details to match, but:\n    Expected\n        <[]interface {} | len:1, cap:1>: [<string>\"user: bob\"]\n    to be empty"))
+			Expect(failures[3]).Should(Equal("Expected a gRPC status other than code NotFound, but got one\nStatus:\n    code:5 message:\"no such user\""))
+		})
+
+		It("describes statuses of errors that don't have one", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(errors.New("boom")).Should(HaveGRPCStatus(fakeNotFound))
+			})
+			Expect(failures).Should(ConsistOf("Expected a gRPC status with code NotFound, but got code Unknown\nStatus:\n    code: Unknown, message: \"boom\""))
+		})
+	})
+
+	It("errors when not passed a status code", func() {
+		_, err := (&HaveGRPCStatusMatcher{Code: "NotFound"}).Match(nil)
+		Expect(err).Should(MatchError(ContainSubstring("HaveGRPCStatus matcher expects a status code, such as codes.NotFound.")))
+	})
+
+	It("errors when actual is not an error", func() {
+		_, err := (&HaveGRPCStatusMatcher{Code: fakeOK}).Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("HaveGRPCStatus matcher expects an error.")))
+	})
+
+	It("errors when the details matcher is not a matcher", func() {
+		_, err := HaveGRPCStatus(fakeNotFound, "", "details").Match(statusError(fakeNotFound, ""))
+		Expect(err).Should(MatchError(ContainSubstring("HaveGRPCStatus matcher expects a matcher for the status details.")))
+	})
+})
@@ -0,0 +1,74 @@
+package matchers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+type HaveHTTPCookieMatcher struct {
+	Name    string
+	Matcher types.GomegaMatcher
+
+	// state
+	cookies []*http.Cookie
+	cookie  *http.Cookie
+}
+
+func (matcher *HaveHTTPCookieMatcher) Match(actual interface{}) (success bool, err error) {
+	switch r := actual.(type) {
+	case *http.Response:
+		matcher.cookies = r.Cookies()
+	case *httptest.ResponseRecorder:
+		matcher.cookies = r.Result().Cookies()
+	default:
+		return false, fmt.Errorf("HaveHTTPCookie matcher expects *http.Response or *httptest.ResponseRecorder. Got:\n%s", format.Object(actual, 1))
+	}
+
+	matcher.cookie = nil
+	for _, cookie := range matcher.cookies {
+		if cookie.Name == matcher.Name {
+			matcher.cookie = cookie
+			break
+		}
+	}
+	if matcher.cookie == nil {
+		return false, nil
+	}
+	if matcher.Matcher == nil {
+		return true, nil
+	}
+	return matcher.Matcher.Match(matcher.cookie)
+}
+
+func (matcher *HaveHTTPCookieMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.cookie == nil {
+		return fmt.Sprintf("Expected the HTTP response to set cookie %q, but it did not\n%s", matcher.Name, matcher.cookiesSet())
+	}
+	diff := format.IndentString(matcher.Matcher.FailureMessage(matcher.cookie), 1)
+	return fmt.Sprintf("HTTP cookie %q:\n%s\n%s", matcher.Name, diff, matcher.cookiesSet())
+}
+
+func (matcher *HaveHTTPCookieMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if matcher.Matcher == nil {
+		return fmt.Sprintf("Expected the HTTP response not to set cookie %q, but it did\n%s", matcher.Name, matcher.cookiesSet())
+	}
+	diff := format.IndentString(matcher.Matcher.NegatedFailureMessage(matcher.cookie), 1)
+	return fmt.Sprintf("HTTP cookie %q:\n%s\n%s", matcher.Name, diff, matcher.cookiesSet())
+}
+
+// cookiesSet lists every cookie the response sets, as they appear in its Set-Cookie headers
+func (matcher *HaveHTTPCookieMatcher) cookiesSet() string {
+	if len(matcher.cookies) == 0 {
+		return "The response sets no cookies"
+	}
+	lines := []string{"The response sets these cookies:"}
+	for _, cookie := range matcher.cookies {
+		lines = append(lines, format.Indent+cookie.String())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,74 @@
+package matchers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveHTTPCookie", func() {
+	var resp *http.Response
+
+	BeforeEach(func() {
+		rec := httptest.NewRecorder()
+		http.SetCookie(rec, &http.Cookie{Name: "session", Value: "abc123", Path: "/", Secure: true, HttpOnly: true, SameSite: http.SameSiteStrictMode})
+		http.SetCookie(rec, &http.Cookie{Name: "theme", Value: "dark", MaxAge: 60})
+		resp = rec.Result()
+	})
+
+	It("can match the presence of a cookie", func() {
+		Expect(resp).To(HaveHTTPCookie("session"))
+		Expect(resp).NotTo(HaveHTTPCookie("missing"))
+	})
+
+	It("can match the cookie against a matcher", func() {
+		Expect(resp).To(HaveHTTPCookie("session", HaveField("HttpOnly", BeTrue())))
+		Expect(resp).To(HaveHTTPCookie("theme", HaveField("MaxAge", 60)))
+		Expect(resp).NotTo(HaveHTTPCookie("theme", HaveField("Secure", BeTrue())))
+		Expect(resp).NotTo(HaveHTTPCookie("missing", HaveField("Secure", BeFalse())))
+	})
+
+	It("can match a response recorder", func() {
+		rec := httptest.NewRecorder()
+		http.SetCookie(rec, &http.Cookie{Name: "session", Value: "abc123"})
+		Expect(rec).To(HaveHTTPCookie("session", HaveField("Value", "abc123")))
+	})
+
+	It("errors when actual is not a response", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect("not a response").To(HaveHTTPCookie("session"))
+		})
+		Expect(failures).To(ConsistOf(HavePrefix("HaveHTTPCookie matcher expects *http.Response or *httptest.ResponseRecorder. Got:\n")))
+	})
+
+	Describe("failure messages", func() {
+		It("lists the cookies set when the cookie is missing", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(resp).To(HaveHTTPCookie("missing"))
+			})
+			Expect(failures).To(ConsistOf("Expected the HTTP response to set cookie \"missing\", but it did not\nThe response sets these cookies:\n    session=abc123; Path=/; HttpOnly; Secure; SameSite=Strict\n    theme=dark; Max-Age=60"))
+		})
+
+		It("reports when the response sets no cookies", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(&http.Response{Header: http.Header{}}).To(HaveHTTPCookie("session"))
+			})
+			Expect(failures).To(ConsistOf("Expected the HTTP response to set cookie \"session\", but it did not\nThe response sets no cookies"))
+		})
+
+		It("reports the matcher's failure", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(resp).To(HaveHTTPCookie("theme", HaveField("Value", "light")))
+				Expect(resp).NotTo(HaveHTTPCookie("theme", HaveField("Value", "dark")))
+				Expect(resp).NotTo(HaveHTTPCookie("theme"))
+			})
+			Expect(failures).To(HaveLen(3))
+			Expect(failures[0]).To(HavePrefix("HTTP cookie \"theme\":\n    Value for field 'Value' failed to satisfy matcher.\n"))
+			Expect(failures[0]).To(HaveSuffix("\nThe response sets these cookies:\n    session=abc123; Path=/; HttpOnly; Secure; SameSite=Strict\n    theme=dark; Max-Age=60"))
+			Expect(failures[1]).To(HavePrefix("HTTP cookie \"theme\":\n"))
+			Expect(failures[2]).To(HavePrefix("Expected the HTTP response not to set cookie \"theme\", but it did\n"))
+		})
+	})
+})
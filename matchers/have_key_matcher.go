@@ -7,6 +7,7 @@ import (
 	"reflect"
 
 	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/internal/gutil"
 )
 
 type HaveKeyMatcher struct {
@@ -23,7 +24,7 @@ func (matcher *HaveKeyMatcher) Match(actual interface{}) (success bool, err erro
 		keyMatcher = &EqualMatcher{Expected: matcher.Key}
 	}
 
-	keys := reflect.ValueOf(actual).MapKeys()
+	keys := gutil.SortedMapKeys(reflect.ValueOf(actual))
 	for i := 0; i < len(keys); i++ {
 		success, err := keyMatcher.Match(keys[i].Interface())
 		if err != nil {
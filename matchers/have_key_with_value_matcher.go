@@ -7,6 +7,7 @@ import (
 	"reflect"
 
 	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/internal/gutil"
 )
 
 type HaveKeyWithValueMatcher struct {
@@ -29,7 +30,7 @@ func (matcher *HaveKeyWithValueMatcher) Match(actual interface{}) (success bool,
 		valueMatcher = &EqualMatcher{Expected: matcher.Value}
 	}
 
-	keys := reflect.ValueOf(actual).MapKeys()
+	keys := gutil.SortedMapKeys(reflect.ValueOf(actual))
 	for i := 0; i < len(keys); i++ {
 		success, err := keyMatcher.Match(keys[i].Interface())
 		if err != nil {
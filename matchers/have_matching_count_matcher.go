@@ -0,0 +1,112 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+// matchingCount counts the elements of an array, slice or map - a map's values, in the order of its sorted keys - that
+// satisfy a matcher
+type matchingCount struct {
+	total       int
+	matching    []interface{}
+	nonMatching []interface{}
+}
+
+func countMatching(matcherName string, count int, element interface{}, actual interface{}) (matchingCount, error) {
+	if count < 0 {
+		return matchingCount{}, fmt.Errorf("%s matcher expects a non-negative count.  Got:\n%s", matcherName, format.Object(count, 1))
+	}
+	if !isArrayOrSlice(actual) && !isMap(actual) {
+		return matchingCount{}, fmt.Errorf("%s matcher expects an array/slice/map.  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+
+	elemMatcher, elementIsMatcher := element.(omegaMatcher)
+	if !elementIsMatcher {
+		elemMatcher = &EqualMatcher{Expected: element}
+	}
+
+	counted := matchingCount{}
+	for _, value := range valuesOf(actual) {
+		success, err := elemMatcher.Match(value)
+		if err != nil {
+			return matchingCount{}, err
+		}
+		if success {
+			counted.matching = append(counted.matching, value)
+		} else {
+			counted.nonMatching = append(counted.nonMatching, value)
+		}
+		counted.total++
+	}
+	return counted, nil
+}
+
+func (counted matchingCount) message(actual interface{}, relation string, count int, element interface{}, listMatching bool) string {
+	message := format.Message(actual, fmt.Sprintf("%s %d %s matching", relation, count, pluralize("element", count)), element)
+	message = fmt.Sprintf("%s\nbut %d of %d did", message, len(counted.matching), counted.total)
+	listed, which := counted.nonMatching, "did not match"
+	if listMatching {
+		listed, which = counted.matching, "matched"
+	}
+	if len(listed) > 0 {
+		message = fmt.Sprintf("%s\nthe elements that %s were\n%s", message, which, format.Object(presentable(listed), 1))
+	}
+	return message
+}
+
+func pluralize(noun string, count int) string {
+	if count == 1 {
+		return noun
+	}
+	return noun + "s"
+}
+
+type HaveAtLeastMatcher struct {
+	Count   int
+	Element interface{}
+
+	// state
+	counted matchingCount
+}
+
+func (matcher *HaveAtLeastMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.counted, err = countMatching("HaveAtLeast", matcher.Count, matcher.Element, actual)
+	if err != nil {
+		return false, err
+	}
+	return len(matcher.counted.matching) >= matcher.Count, nil
+}
+
+func (matcher *HaveAtLeastMatcher) FailureMessage(actual interface{}) (message string) {
+	return matcher.counted.message(actual, "to have at least", matcher.Count, matcher.Element, false)
+}
+
+func (matcher *HaveAtLeastMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return matcher.counted.message(actual, "not to have at least", matcher.Count, matcher.Element, true)
+}
+
+type HaveAtMostMatcher struct {
+	Count   int
+	Element interface{}
+
+	// state
+	counted matchingCount
+}
+
+func (matcher *HaveAtMostMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.counted, err = countMatching("HaveAtMost", matcher.Count, matcher.Element, actual)
+	if err != nil {
+		return false, err
+	}
+	return len(matcher.counted.matching) <= matcher.Count, nil
+}
+
+func (matcher *HaveAtMostMatcher) FailureMessage(actual interface{}) (message string) {
+	return matcher.counted.message(actual, "to have at most", matcher.Count, matcher.Element, true)
+}
+
+func (matcher *HaveAtMostMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return matcher.counted.message(actual, "not to have at most", matcher.Count, matcher.Element, false)
+}
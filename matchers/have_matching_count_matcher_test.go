@@ -0,0 +1,77 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveAtLeast and HaveAtMost", func() {
+	words := []string{"Foo", "FooBar", "Bar"}
+
+	Describe("HaveAtLeast", func() {
+		It("succeeds if enough elements match", func() {
+			Expect(words).Should(HaveAtLeast(2, ContainSubstring("Foo")))
+			Expect(words).Should(HaveAtLeast(0, ContainSubstring("Baz")))
+			Expect(words).ShouldNot(HaveAtLeast(3, ContainSubstring("Foo")))
+			Expect([3]int{1, 2, 1}).Should(HaveAtLeast(2, 1))
+			Expect(map[string]int{"a": 1, "b": 2, "c": 1}).Should(HaveAtLeast(2, 1))
+			Expect([]int{}).ShouldNot(HaveAtLeast(1, 1))
+		})
+
+		It("reports the count and the elements that did not match", func() {
+			m := HaveAtLeast(3, ContainSubstring("Foo"))
+			Expect(m.Match(words)).Should(BeFalse())
+			Expect(m.FailureMessage(words)).Should(ContainSubstring("to have at least 3 elements matching\n"))
+			Expect(m.FailureMessage(words)).Should(HaveSuffix(`{Substr: "Foo", Args: nil}
+but 2 of 3 did
+the elements that did not match were
+    <[]string | len:1, cap:1>: ["Bar"]`))
+
+			m = HaveAtLeast(1, "Foo")
+			Expect(m.Match(words)).Should(BeTrue())
+			Expect(m.NegatedFailureMessage(words)).Should(HaveSuffix(`not to have at least 1 element matching
+    <string>: Foo
+but 1 of 3 did
+the elements that matched were
+    <[]string | len:1, cap:1>: ["Foo"]`))
+		})
+	})
+
+	Describe("HaveAtMost", func() {
+		It("succeeds if few enough elements match", func() {
+			Expect(words).Should(HaveAtMost(1, ContainSubstring("Baz")))
+			Expect(words).Should(HaveAtMost(2, ContainSubstring("Bar")))
+			Expect(words).ShouldNot(HaveAtMost(1, ContainSubstring("Bar")))
+			Expect(map[string]int{"a": 1, "b": 2}).Should(HaveAtMost(1, 1))
+			Expect([]int{}).Should(HaveAtMost(0, 1))
+		})
+
+		It("reports the count and the elements that matched", func() {
+			m := HaveAtMost(0, "Bar")
+			Expect(m.Match(words)).Should(BeFalse())
+			Expect(m.FailureMessage(words)).Should(HaveSuffix(`to have at most 0 elements matching
+    <string>: Bar
+but 1 of 3 did
+the elements that matched were
+    <[]string | len:1, cap:1>: ["Bar"]`))
+
+			Expect(m.Match([]string{"Foo"})).Should(BeTrue())
+			Expect(m.NegatedFailureMessage([]string{"Foo"})).Should(HaveSuffix(`not to have at most 0 elements matching
+    <string>: Bar
+but 0 of 1 did
+the elements that did not match were
+    <[]string | len:1, cap:1>: ["Foo"]`))
+		})
+	})
+
+	It("errors when misused", func() {
+		_, err := HaveAtLeast(-1, 1).Match([]int{1})
+		Expect(err).Should(MatchError(ContainSubstring("HaveAtLeast matcher expects a non-negative count")))
+
+		_, err = HaveAtMost(1, 1).Match("not a collection")
+		Expect(err).Should(MatchError(ContainSubstring("HaveAtMost matcher expects an array/slice/map")))
+
+		_, err = HaveAtLeast(1, BeTrue()).Match([]int{1})
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,94 @@
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+type HaveMethodMatcher struct {
+	Name      string
+	Signature []interface{}
+
+	// state
+	actualType       reflect.Type
+	signature        reflect.Type
+	signatureMatcher types.GomegaMatcher
+	pointerHasMethod bool
+}
+
+func (matcher *HaveMethodMatcher) Match(actual interface{}) (success bool, err error) {
+	switch a := actual.(type) {
+	case nil:
+		return false, fmt.Errorf("HaveMethod matcher expects a non-nil value or a reflect.Type.  Got nil")
+	case reflect.Type:
+		matcher.actualType = a
+	default:
+		matcher.actualType = reflect.TypeOf(actual)
+	}
+	matcher.signatureMatcher, err = matcher.getSignatureMatcher()
+	if err != nil {
+		return false, err
+	}
+
+	matcher.signature = nil
+	method, ok := matcher.actualType.MethodByName(matcher.Name)
+	if !ok {
+		if matcher.actualType.Kind() != reflect.Ptr && matcher.actualType.Kind() != reflect.Interface {
+			_, matcher.pointerHasMethod = reflect.PointerTo(matcher.actualType).MethodByName(matcher.Name)
+		}
+		return false, nil
+	}
+	matcher.signature = methodSignature(matcher.actualType, method)
+	if matcher.signatureMatcher == nil {
+		return true, nil
+	}
+	return matcher.signatureMatcher.Match(matcher.signature.String())
+}
+
+// getSignatureMatcher returns the matcher for the method's signature, rendered as a string, or nil if any signature
+// will do
+func (matcher *HaveMethodMatcher) getSignatureMatcher() (types.GomegaMatcher, error) {
+	switch len(matcher.Signature) {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		return nil, fmt.Errorf("HaveMethod matcher expects at most one signature.  Got:\n%s", format.Object(matcher.Signature, 1))
+	}
+	switch s := matcher.Signature[0].(type) {
+	case types.GomegaMatcher:
+		return s, nil
+	case string:
+		return &EqualMatcher{Expected: s}, nil
+	case reflect.Type:
+		if s.Kind() == reflect.Func {
+			return &EqualMatcher{Expected: s.String()}, nil
+		}
+	default:
+		if s != nil && reflect.TypeOf(s).Kind() == reflect.Func {
+			return &EqualMatcher{Expected: reflect.TypeOf(s).String()}, nil
+		}
+	}
+	return nil, fmt.Errorf("HaveMethod matcher expects the signature to be a string, a matcher, a function, or a reflect.Type of a function.  Got:\n%s", format.Object(matcher.Signature[0], 1))
+}
+
+func (matcher *HaveMethodMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.signature == nil {
+		message = fmt.Sprintf("Expected %s to have a method named %s, but it does not", matcher.actualType, matcher.Name)
+		if matcher.pointerHasMethod {
+			message += fmt.Sprintf("\n%s does - did you mean to pass a pointer?", reflect.PointerTo(matcher.actualType))
+		}
+		return message
+	}
+	return fmt.Sprintf("Signature of %s.%s failed to satisfy matcher.\n%s", matcher.actualType, matcher.Name, matcher.signatureMatcher.FailureMessage(matcher.signature.String()))
+}
+
+func (matcher *HaveMethodMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if matcher.signatureMatcher == nil {
+		return fmt.Sprintf("Expected %s not to have a method named %s, but it has:\n%s", matcher.actualType, matcher.Name, format.IndentString(matcher.signature.String(), 1))
+	}
+	return fmt.Sprintf("Signature of %s.%s satisfied matcher, but should not have.\n%s", matcher.actualType, matcher.Name, matcher.signatureMatcher.NegatedFailureMessage(matcher.signature.String()))
+}
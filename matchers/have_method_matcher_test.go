@@ -0,0 +1,105 @@
+package matchers_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+type testPlugin struct{}
+
+func (testPlugin) Init() {}
+
+func (testPlugin) Serve(ctx context.Context, addr string) error { return nil }
+
+func (*testPlugin) Close() error { return nil }
+
+var _ = Describe("HaveMethod", func() {
+	It("succeeds if actual's type has the method", func() {
+		Expect(testPlugin{}).Should(HaveMethod("Init"))
+		Expect(testPlugin{}).Should(HaveMethod("Serve"))
+		Expect(&testPlugin{}).Should(HaveMethod("Close"))
+		Expect(&testPlugin{}).Should(HaveMethod("Init"))
+
+		Expect(testPlugin{}).ShouldNot(HaveMethod("Close"))
+		Expect(testPlugin{}).ShouldNot(HaveMethod("Stop"))
+		Expect(testPlugin{}).ShouldNot(HaveMethod("init"))
+	})
+
+	It("accepts a reflect.Type", func() {
+		Expect(reflect.TypeOf(&testPlugin{})).Should(HaveMethod("Close"))
+		Expect(reflect.TypeOf((*io.Reader)(nil)).Elem()).Should(HaveMethod("Read", "func([]uint8) (int, error)"))
+	})
+
+	It("compares the signature, without the receiver, to a string", func() {
+		Expect(testPlugin{}).Should(HaveMethod("Serve", "func(context.Context, string) error"))
+		Expect(&testPlugin{}).Should(HaveMethod("Close", "func() error"))
+		Expect(testPlugin{}).ShouldNot(HaveMethod("Init", "func() error"))
+	})
+
+	It("matches the signature against a matcher", func() {
+		Expect(testPlugin{}).Should(HaveMethod("Serve", HavePrefix("func(context.Context")))
+		Expect(testPlugin{}).ShouldNot(HaveMethod("Serve", HaveSuffix("string")))
+	})
+
+	It("compares the signature to the type of a function", func() {
+		Expect(testPlugin{}).Should(HaveMethod("Serve", (func(context.Context, string) error)(nil)))
+		Expect(testPlugin{}).Should(HaveMethod("Init", func() {}))
+		Expect(&bytes.Buffer{}).Should(HaveMethod("Write", reflect.TypeOf(func([]byte) (int, error) { return 0, nil })))
+		Expect(testPlugin{}).ShouldNot(HaveMethod("Serve", (func(context.Context) error)(nil)))
+	})
+
+	It("errors when passed invalid arguments", func() {
+		_, err := HaveMethod("Init").Match(nil)
+		Expect(err).Should(MatchError("HaveMethod matcher expects a non-nil value or a reflect.Type.  Got nil"))
+
+		_, err = HaveMethod("Init", 3).Match(testPlugin{})
+		Expect(err).Should(MatchError(ContainSubstring("HaveMethod matcher expects the signature to be a string, a matcher, a function, or a reflect.Type of a function.  Got:")))
+
+		_, err = HaveMethod("Init", reflect.TypeOf(3)).Match(testPlugin{})
+		Expect(err).Should(HaveOccurred())
+
+		_, err = (&HaveMethodMatcher{Name: "Init", Signature: []interface{}{"a", "b"}}).Match(testPlugin{})
+		Expect(err).Should(MatchError(ContainSubstring("HaveMethod matcher expects at most one signature.")))
+	})
+
+	Describe("failure messages", func() {
+		It("reports missing methods", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(testPlugin{}).Should(HaveMethod("Stop"))
+			})
+			Expect(failures).Should(ConsistOf("Expected matchers_test.testPlugin to have a method named Stop, but it does not"))
+		})
+
+		It("suggests passing a pointer when the pointer has the method", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(testPlugin{}).Should(HaveMethod("Close"))
+			})
+			Expect(failures).Should(ConsistOf("Expected matchers_test.testPlugin to have a method named Close, but it does not\n*matchers_test.testPlugin does - did you mean to pass a pointer?"))
+		})
+
+		It("reports mismatched signatures", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(testPlugin{}).Should(HaveMethod("Init", "func() error"))
+			})
+			Expect(failures).Should(ConsistOf("Signature of matchers_test.testPlugin.Init failed to satisfy matcher.\nExpected\n    <string>: func()\nto equal\n    <string>: func() error"))
+		})
+
+		It("reports the negated failures", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(testPlugin{}).ShouldNot(HaveMethod("Init"))
+			})
+			Expect(failures).Should(ConsistOf("Expected matchers_test.testPlugin not to have a method named Init, but it has:\n    func()"))
+
+			failures = InterceptGomegaFailures(func() {
+				Expect(testPlugin{}).ShouldNot(HaveMethod("Init", "func()"))
+			})
+			Expect(failures).Should(ConsistOf("Signature of matchers_test.testPlugin.Init satisfied matcher, but should not have.\nExpected\n    <string>: func()\nnot to equal\n    <string>: func()"))
+		})
+	})
+})
@@ -0,0 +1,168 @@
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+type HaveNestedKeyWithValueMatcher struct {
+	Path  interface{}
+	Value interface{}
+
+	segments       []interface{}
+	missingReason  string
+	extractedValue interface{}
+	valueMatcher   omegaMatcher
+}
+
+func (matcher *HaveNestedKeyWithValueMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.missingReason = ""
+	matcher.extractedValue = nil
+
+	matcher.segments, err = nestedKeyPathSegments(matcher.Path)
+	if err != nil {
+		return false, err
+	}
+
+	if !isMap(actual) {
+		return false, fmt.Errorf("HaveNestedKeyWithValue matcher expects a map.  Got:%s", format.Object(actual, 1))
+	}
+
+	current := reflect.ValueOf(actual)
+	for i, segment := range matcher.segments {
+		for current.Kind() == reflect.Interface || current.Kind() == reflect.Ptr {
+			if current.IsNil() {
+				matcher.missingReason = fmt.Sprintf("the value at '%s' is nil", matcher.pathString(i))
+				return false, nil
+			}
+			current = current.Elem()
+		}
+
+		switch current.Kind() {
+		case reflect.Map:
+			key, err := nestedKeyForMap(segment, current.Type().Key())
+			if err != nil {
+				return false, err
+			}
+			next := current.MapIndex(key)
+			if !next.IsValid() {
+				matcher.missingReason = fmt.Sprintf("key '%v' is missing at '%s'", segment, matcher.pathString(i))
+				return false, nil
+			}
+			current = next
+		case reflect.Slice, reflect.Array:
+			index, ok := nestedIndexForSlice(segment)
+			if !ok {
+				return false, fmt.Errorf("HaveNestedKeyWithValue matcher found a %s at '%s' but the path segment '%v' is not an index", current.Type(), matcher.pathString(i), segment)
+			}
+			if index < 0 || index >= current.Len() {
+				matcher.missingReason = fmt.Sprintf("index %d is out of range at '%s' (length %d)", index, matcher.pathString(i), current.Len())
+				return false, nil
+			}
+			current = current.Index(index)
+		default:
+			matcher.missingReason = fmt.Sprintf("the value at '%s' is a %s, not a map or slice, so key '%v' cannot be found", matcher.pathString(i), current.Type(), segment)
+			return false, nil
+		}
+	}
+
+	matcher.extractedValue = current.Interface()
+
+	var isMatcher bool
+	matcher.valueMatcher, isMatcher = matcher.Value.(omegaMatcher)
+	if !isMatcher {
+		matcher.valueMatcher = &EqualMatcher{Expected: matcher.Value}
+	}
+
+	success, err = matcher.valueMatcher.Match(matcher.extractedValue)
+	if err != nil {
+		return false, fmt.Errorf("HaveNestedKeyWithValue's value matcher failed at '%s' with:\n%s%s", matcher.pathString(len(matcher.segments)), format.Indent, err.Error())
+	}
+	return success, nil
+}
+
+func (matcher *HaveNestedKeyWithValueMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.missingReason != "" {
+		return format.Message(actual, fmt.Sprintf("to have nested key '%s', but %s", matcher.pathString(len(matcher.segments)), matcher.missingReason))
+	}
+	message = fmt.Sprintf("Value for nested key '%s' failed to satisfy matcher.\n", matcher.pathString(len(matcher.segments)))
+	message += matcher.valueMatcher.FailureMessage(matcher.extractedValue)
+
+	return message
+}
+
+func (matcher *HaveNestedKeyWithValueMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Value for nested key '%s' satisfied matcher, but should not have.\n", matcher.pathString(len(matcher.segments)))
+	message += matcher.valueMatcher.NegatedFailureMessage(matcher.extractedValue)
+
+	return message
+}
+
+// pathString renders the first n segments of the path in dotted form
+func (matcher *HaveNestedKeyWithValueMatcher) pathString(n int) string {
+	if n == 0 {
+		return "<root>"
+	}
+	components := make([]string, n)
+	for i := 0; i < n; i++ {
+		components[i] = fmt.Sprint(matcher.segments[i])
+	}
+	return strings.Join(components, ".")
+}
+
+func nestedKeyPathSegments(path interface{}) ([]interface{}, error) {
+	var segments []interface{}
+	switch p := path.(type) {
+	case string:
+		if p == "" {
+			break
+		}
+		for _, component := range strings.Split(p, ".") {
+			segments = append(segments, component)
+		}
+	case []string:
+		for _, component := range p {
+			segments = append(segments, component)
+		}
+	case []interface{}:
+		segments = p
+	default:
+		return nil, fmt.Errorf("HaveNestedKeyWithValue matcher expects the path to be a dotted string, a []string, or an []interface{}.  Got:\n%s", format.Object(path, 1))
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("HaveNestedKeyWithValue matcher requires a non-empty path")
+	}
+	return segments, nil
+}
+
+func nestedKeyForMap(segment interface{}, keyType reflect.Type) (reflect.Value, error) {
+	key := reflect.ValueOf(segment)
+	if segment != nil && key.Type().AssignableTo(keyType) {
+		return key, nil
+	}
+	if _, ok := segment.(string); ok && keyType.Kind() == reflect.String {
+		return key.Convert(keyType), nil
+	}
+	if s, ok := segment.(string); ok && reflect.Int <= keyType.Kind() && keyType.Kind() <= reflect.Int64 {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return reflect.ValueOf(i).Convert(keyType), nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("HaveNestedKeyWithValue matcher cannot use path segment '%v' as a key of type %s", segment, keyType)
+}
+
+func nestedIndexForSlice(segment interface{}) (int, bool) {
+	switch s := segment.(type) {
+	case int:
+		return s, true
+	case string:
+		i, err := strconv.Atoi(s)
+		return i, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,110 @@
+package matchers_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("HaveNestedKeyWithValue", func() {
+	var decoded map[string]interface{}
+
+	BeforeEach(func() {
+		decoded = nil
+		err := json.Unmarshal([]byte(`{
+			"metadata": {"name": "web", "labels": {"app.kubernetes.io/name": "frontend"}},
+			"spec": {"replicas": 3, "containers": [{"image": "nginx"}, {"image": "envoy"}], "paused": null}
+		}`), &decoded)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	Context("when passed a dotted path", func() {
+		It("should follow nested maps and slices", func() {
+			Expect(decoded).Should(HaveNestedKeyWithValue("metadata.name", "web"))
+			Expect(decoded).Should(HaveNestedKeyWithValue("spec.containers.1.image", "envoy"))
+			Expect(decoded).ShouldNot(HaveNestedKeyWithValue("spec.containers.0.image", "envoy"))
+		})
+
+		It("should use the passed in matcher", func() {
+			Expect(decoded).Should(HaveNestedKeyWithValue("spec.replicas", BeNumerically(">=", 3)))
+			Expect(decoded).Should(HaveNestedKeyWithValue("spec.containers", HaveLen(2)))
+		})
+	})
+
+	Context("when passed a slice of keys", func() {
+		It("should not split keys on dots", func() {
+			Expect(decoded).Should(HaveNestedKeyWithValue([]string{"metadata", "labels", "app.kubernetes.io/name"}, "frontend"))
+			Expect(decoded).Should(HaveNestedKeyWithValue([]interface{}{"spec", "containers", 0, "image"}, "nginx"))
+		})
+	})
+
+	Context("with non-string map keys", func() {
+		It("should convert path segments to the key type", func() {
+			Expect(map[int]map[string]int{1: {"a": 2}}).Should(HaveNestedKeyWithValue("1.a", 2))
+		})
+	})
+
+	Context("when a segment is missing", func() {
+		It("should fail and report the missing segment", func() {
+			m := &HaveNestedKeyWithValueMatcher{Path: "spec.template.image", Value: "nginx"}
+			success, err := m.Match(decoded)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(success).Should(BeFalse())
+			Expect(m.FailureMessage(decoded)).Should(HaveSuffix("to have nested key 'spec.template.image', but key 'template' is missing at 'spec'"))
+		})
+
+		It("should report out of range indices", func() {
+			m := &HaveNestedKeyWithValueMatcher{Path: "spec.containers.2.image", Value: "nginx"}
+			Expect(m.Match(decoded)).Should(BeFalse())
+			Expect(m.FailureMessage(decoded)).Should(HaveSuffix("but index 2 is out of range at 'spec.containers' (length 2)"))
+		})
+
+		It("should report nil values along the path", func() {
+			m := &HaveNestedKeyWithValueMatcher{Path: "spec.paused.reason", Value: "nginx"}
+			Expect(m.Match(decoded)).Should(BeFalse())
+			Expect(m.FailureMessage(decoded)).Should(HaveSuffix("but the value at 'spec.paused' is nil"))
+		})
+
+		It("should report values that cannot be traversed", func() {
+			m := &HaveNestedKeyWithValueMatcher{Path: "metadata.name.first", Value: "nginx"}
+			Expect(m.Match(decoded)).Should(BeFalse())
+			Expect(m.FailureMessage(decoded)).Should(HaveSuffix("but the value at 'metadata.name' is a string, not a map or slice, so key 'first' cannot be found"))
+		})
+
+		It("should pass the negated assertion", func() {
+			Expect(decoded).ShouldNot(HaveNestedKeyWithValue("spec.template.image", "nginx"))
+		})
+	})
+
+	Context("when the value does not match", func() {
+		It("should report the path along with the value matcher's failure message", func() {
+			m := &HaveNestedKeyWithValueMatcher{Path: "metadata.name", Value: "api"}
+			Expect(m.Match(decoded)).Should(BeFalse())
+			Expect(m.FailureMessage(decoded)).Should(Equal("Value for nested key 'metadata.name' failed to satisfy matcher.\nExpected\n    <string>: web\nto equal\n    <string>: api"))
+		})
+
+		It("should report the path in the negated failure message", func() {
+			m := &HaveNestedKeyWithValueMatcher{Path: "metadata.name", Value: "web"}
+			Expect(m.Match(decoded)).Should(BeTrue())
+			Expect(m.NegatedFailureMessage(decoded)).Should(Equal("Value for nested key 'metadata.name' satisfied matcher, but should not have.\nExpected\n    <string>: web\nnot to equal\n    <string>: web"))
+		})
+	})
+
+	Context("when passed an invalid path or actual", func() {
+		It("should error", func() {
+			_, err := (&HaveNestedKeyWithValueMatcher{Path: "", Value: 1}).Match(decoded)
+			Expect(err).Should(HaveOccurred())
+
+			_, err = (&HaveNestedKeyWithValueMatcher{Path: 3, Value: 1}).Match(decoded)
+			Expect(err).Should(HaveOccurred())
+
+			_, err = (&HaveNestedKeyWithValueMatcher{Path: "a", Value: 1}).Match("not a map")
+			Expect(err).Should(HaveOccurred())
+
+			_, err = (&HaveNestedKeyWithValueMatcher{Path: "spec.containers.first", Value: 1}).Match(decoded)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,56 @@
+package matchers
+
+import (
+	"fmt"
+	"time"
+)
+
+// timestampedError is implemented by errors that record when they occurred
+type timestampedError interface {
+	Timestamp() time.Time
+}
+
+type HaveOccurredWithinMatcher struct {
+	Duration time.Duration
+
+	// state
+	chain   []error
+	depth   int
+	elapsed time.Duration
+}
+
+func (matcher *HaveOccurredWithinMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.chain, err = toErrorChain("HaveOccurredWithin", actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.depth = findInErrorChain(matcher.chain, func(err error) bool {
+		_, ok := err.(timestampedError)
+		return ok
+	})
+	if matcher.depth < 0 {
+		return false, nil
+	}
+	// errors timestamped in the (near) future, by a skewed clock, have just occurred
+	matcher.elapsed = time.Since(matcher.timestamp())
+	return matcher.elapsed <= matcher.Duration, nil
+}
+
+func (matcher *HaveOccurredWithinMatcher) timestamp() time.Time {
+	return matcher.chain[matcher.depth].(timestampedError).Timestamp()
+}
+
+func (matcher *HaveOccurredWithinMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.depth < 0 {
+		return fmt.Sprintf("Expected the error to have occurred within %s, but no error in the chain has a Timestamp() method\n%s", matcher.Duration, formatErrorChain(matcher.chain))
+	}
+	return fmt.Sprintf("Expected the error to have occurred within %s, but %s\n%s", matcher.Duration, matcher.occurrence(), formatErrorChain(matcher.chain))
+}
+
+func (matcher *HaveOccurredWithinMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Expected the error not to have occurred within %s, but %s\n%s", matcher.Duration, matcher.occurrence(), formatErrorChain(matcher.chain))
+}
+
+func (matcher *HaveOccurredWithinMatcher) occurrence() string {
+	return fmt.Sprintf("[%d] %T is timestamped %s ago (at %s)", matcher.depth, matcher.chain[matcher.depth], matcher.elapsed, matcher.timestamp().Format(time.RFC3339Nano))
+}
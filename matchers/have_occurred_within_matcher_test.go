@@ -0,0 +1,60 @@
+package matchers_test
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+type timestampedErr struct {
+	at time.Time
+}
+
+func (e timestampedErr) Error() string        { return "timestamped" }
+func (e timestampedErr) Timestamp() time.Time { return e.at }
+
+var _ = Describe("HaveOccurredWithin", func() {
+	It("succeeds if the first timestamped error in the chain occurred within the duration", func() {
+		Expect(timestampedErr{at: time.Now().Add(-time.Second)}).Should(HaveOccurredWithin(time.Minute))
+		Expect(timestampedErr{at: time.Now().Add(-time.Hour)}).ShouldNot(HaveOccurredWithin(time.Minute))
+		Expect(fmt.Errorf("outer: %w", timestampedErr{at: time.Now()})).Should(HaveOccurredWithin(time.Minute))
+	})
+
+	It("treats errors timestamped in the future as having just occurred", func() {
+		Expect(timestampedErr{at: time.Now().Add(time.Second)}).Should(HaveOccurredWithin(time.Minute))
+	})
+
+	It("fails if no error in the chain is timestamped", func() {
+		Expect(errors.New("boom")).ShouldNot(HaveOccurredWithin(time.Minute))
+	})
+
+	It("errors when ACTUAL is not an error", func() {
+		_, err := (&HaveOccurredWithinMatcher{Duration: time.Minute}).Match(nil)
+		Expect(err).Should(MatchError("HaveOccurredWithin matcher expects an error, got nil"))
+	})
+
+	Describe("failure messages", func() {
+		It("reports when no error is timestamped", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(errors.New("boom")).Should(HaveOccurredWithin(time.Minute))
+			})
+			Expect(failures).Should(ConsistOf("Expected the error to have occurred within 1m0s, but no error in the chain has a Timestamp() method\nError chain:\n    [0] *errors.errorString: boom"))
+		})
+
+		It("reports when the error occurred", func() {
+			at := time.Now().Add(-time.Hour)
+			failures := InterceptGomegaFailures(func() {
+				Expect(fmt.Errorf("outer: %w", timestampedErr{at: at})).Should(HaveOccurredWithin(time.Minute))
+				Expect(timestampedErr{at: at}).ShouldNot(HaveOccurredWithin(2 * time.Hour))
+			})
+			Expect(failures).Should(HaveLen(2))
+			Expect(failures[0]).Should(MatchRegexp(`^Expected the error to have occurred within 1m0s, but \[1\] matchers_test.timestampedErr is timestamped 1h0m0\.\d+s ago \(at %s\)\nError chain:\n`, regexp.QuoteMeta(at.Format(time.RFC3339Nano))))
+			Expect(failures[1]).Should(HavePrefix("Expected the error not to have occurred within 2h0m0s, but [0] matchers_test.timestampedErr is timestamped"))
+		})
+	})
+})
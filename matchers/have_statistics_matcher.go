@@ -0,0 +1,108 @@
+package matchers
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// Statistics summarizes a collection of numeric samples.  It is what HaveStatistics passes to its matcher.
+//
+// StdDev is the population standard deviation.  Median and the percentiles are computed by linearly
+// interpolating between the closest ranks.  time.Durations are converted to float64 nanoseconds so they can
+// be compared with BeNumerically("<", 20*time.Millisecond).
+type Statistics struct {
+	N      int
+	Min    float64
+	Max    float64
+	Mean   float64
+	Median float64
+	StdDev float64
+	P90    float64
+	P95    float64
+	P99    float64
+}
+
+type HaveStatisticsMatcher struct {
+	Matcher types.GomegaMatcher
+
+	// state
+	statistics Statistics
+}
+
+func (matcher *HaveStatisticsMatcher) Match(actual interface{}) (success bool, err error) {
+	matcher.statistics, err = computeStatistics(actual)
+	if err != nil {
+		return false, err
+	}
+	return matcher.Matcher.Match(matcher.statistics)
+}
+
+func (matcher *HaveStatisticsMatcher) FailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Statistics for %d samples failed to satisfy matcher.\n", matcher.statistics.N)
+	message += matcher.Matcher.FailureMessage(matcher.statistics)
+
+	return message
+}
+
+func (matcher *HaveStatisticsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	message = fmt.Sprintf("Statistics for %d samples satisfied matcher, but should not have.\n", matcher.statistics.N)
+	message += matcher.Matcher.NegatedFailureMessage(matcher.statistics)
+
+	return message
+}
+
+func computeStatistics(actual interface{}) (Statistics, error) {
+	if !isArrayOrSlice(actual) {
+		return Statistics{}, fmt.Errorf("HaveStatistics matcher expects an array or slice of numbers.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	value := reflect.ValueOf(actual)
+	if value.Len() == 0 {
+		return Statistics{}, fmt.Errorf("HaveStatistics matcher cannot compute statistics for an empty collection")
+	}
+
+	samples := make([]float64, value.Len())
+	for i := range samples {
+		sample := value.Index(i).Interface()
+		if !isNumber(sample) {
+			return Statistics{}, fmt.Errorf("HaveStatistics matcher expects an array or slice of numbers.  Element %d is:\n%s", i, format.Object(sample, 1))
+		}
+		samples[i] = toFloat(sample)
+	}
+	sort.Float64s(samples)
+
+	statistics := Statistics{
+		N:      len(samples),
+		Min:    samples[0],
+		Max:    samples[len(samples)-1],
+		Median: percentile(samples, 0.5),
+		P90:    percentile(samples, 0.9),
+		P95:    percentile(samples, 0.95),
+		P99:    percentile(samples, 0.99),
+	}
+
+	for _, sample := range samples {
+		statistics.Mean += sample
+	}
+	statistics.Mean /= float64(statistics.N)
+
+	for _, sample := range samples {
+		statistics.StdDev += (sample - statistics.Mean) * (sample - statistics.Mean)
+	}
+	statistics.StdDev = math.Sqrt(statistics.StdDev / float64(statistics.N))
+
+	return statistics, nil
+}
+
+// percentile expects sorted samples and interpolates linearly between the closest ranks
+func percentile(sorted []float64, p float64) float64 {
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
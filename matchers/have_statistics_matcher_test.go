@@ -0,0 +1,67 @@
+package matchers_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("HaveStatistics", func() {
+	It("should compute statistics and pass them to the matcher", func() {
+		Expect([]int{4, 1, 3, 2, 5}).Should(HaveStatistics(Equal(Statistics{
+			N:      5,
+			Min:    1,
+			Max:    5,
+			Mean:   3,
+			Median: 3,
+			StdDev: 1.4142135623730951,
+			P90:    4.6,
+			P95:    4.8,
+			P99:    4.96,
+		})))
+	})
+
+	It("should interpolate the median of an even number of samples", func() {
+		Expect([]float64{1, 2, 3, 10}).Should(HaveStatistics(HaveField("Median", Equal(2.5))))
+	})
+
+	It("should support time.Durations", func() {
+		latencies := []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond}
+		Expect(latencies).Should(HaveStatistics(And(
+			HaveField("Mean", BeNumerically("==", 2*time.Millisecond)),
+			HaveField("Max", BeNumerically("<", 5*time.Millisecond)),
+		)))
+	})
+
+	It("should handle a single sample", func() {
+		Expect([1]uint8{7}).Should(HaveStatistics(And(
+			HaveField("Median", Equal(7.0)),
+			HaveField("P99", Equal(7.0)),
+			HaveField("StdDev", Equal(0.0)),
+		)))
+	})
+
+	Describe("failure messages", func() {
+		It("should include the matcher's failure message", func() {
+			m := HaveStatistics(HaveField("Mean", BeNumerically("<", 2)))
+			Expect(m.Match([]int{1, 3})).Should(BeFalse())
+			Expect(m.FailureMessage([]int{1, 3})).Should(HavePrefix("Statistics for 2 samples failed to satisfy matcher.\nValue for field 'Mean' failed to satisfy matcher.\n"))
+			Expect(m.NegatedFailureMessage([]int{1, 3})).Should(HavePrefix("Statistics for 2 samples satisfied matcher, but should not have.\n"))
+		})
+	})
+
+	Context("when passed invalid input", func() {
+		It("should error", func() {
+			_, err := HaveStatistics(BeZero()).Match(3)
+			Expect(err).Should(MatchError(ContainSubstring("expects an array or slice of numbers")))
+
+			_, err = HaveStatistics(BeZero()).Match([]int{})
+			Expect(err).Should(MatchError(ContainSubstring("empty collection")))
+
+			_, err = HaveStatistics(BeZero()).Match([]interface{}{1, "two"})
+			Expect(err).Should(MatchError(ContainSubstring("Element 1 is")))
+		})
+	})
+})
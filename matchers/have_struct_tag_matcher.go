@@ -0,0 +1,120 @@
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+type HaveStructTagMatcher struct {
+	Field string
+	Key   string
+	Value []interface{}
+
+	// state
+	structType   reflect.Type
+	field        reflect.StructField
+	tagValue     string
+	hasTag       bool
+	valueMatcher types.GomegaMatcher
+}
+
+func (matcher *HaveStructTagMatcher) Match(actual interface{}) (success bool, err error) {
+	var actualType reflect.Type
+	switch a := actual.(type) {
+	case nil:
+		return false, fmt.Errorf("HaveStructTag matcher expects a struct, a pointer to a struct, or a reflect.Type of one.  Got nil")
+	case reflect.Type:
+		actualType = a
+	default:
+		actualType = reflect.TypeOf(actual)
+	}
+	if actualType.Kind() == reflect.Ptr {
+		actualType = actualType.Elem()
+	}
+	if actualType.Kind() != reflect.Struct {
+		return false, fmt.Errorf("HaveStructTag matcher expects a struct, a pointer to a struct, or a reflect.Type of one.  Got:\n%s", format.Object(actual, 1))
+	}
+	matcher.valueMatcher, err = matcher.getValueMatcher()
+	if err != nil {
+		return false, err
+	}
+	matcher.structType, matcher.field, err = matcher.lookupField(actualType)
+	if err != nil {
+		return false, err
+	}
+
+	matcher.tagValue, matcher.hasTag = matcher.field.Tag.Lookup(matcher.Key)
+	if !matcher.hasTag {
+		return false, nil
+	}
+	if matcher.valueMatcher == nil {
+		return true, nil
+	}
+	return matcher.valueMatcher.Match(matcher.tagValue)
+}
+
+// lookupField follows the dot-separated path in Field, through nested structs and pointers to structs, returning
+// the last field and the struct that declares it
+func (matcher *HaveStructTagMatcher) lookupField(t reflect.Type) (reflect.Type, reflect.StructField, error) {
+	names := strings.Split(matcher.Field, ".")
+	for i, name := range names {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return nil, reflect.StructField{}, fmt.Errorf("HaveStructTag matcher cannot look up field %s: %s is not a struct", matcher.Field, strings.Join(names[:i], "."))
+		}
+		field, ok := t.FieldByName(name)
+		if !ok {
+			return nil, reflect.StructField{}, fmt.Errorf("HaveStructTag matcher expects %s to have a field named %s, but it does not", t, name)
+		}
+		if i == len(names)-1 {
+			return t, field, nil
+		}
+		t = field.Type
+	}
+	return nil, reflect.StructField{}, nil
+}
+
+// getValueMatcher returns the matcher for the tag's value, or nil if any value will do
+func (matcher *HaveStructTagMatcher) getValueMatcher() (types.GomegaMatcher, error) {
+	switch len(matcher.Value) {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		return nil, fmt.Errorf("HaveStructTag matcher expects at most one value.  Got:\n%s", format.Object(matcher.Value, 1))
+	}
+	switch v := matcher.Value[0].(type) {
+	case types.GomegaMatcher:
+		return v, nil
+	case string:
+		return &EqualMatcher{Expected: v}, nil
+	}
+	return nil, fmt.Errorf("HaveStructTag matcher expects the value to be a string or a matcher.  Got:\n%s", format.Object(matcher.Value[0], 1))
+}
+
+func (matcher *HaveStructTagMatcher) fieldName() string {
+	return fmt.Sprintf("%s.%s", matcher.structType, matcher.field.Name)
+}
+
+func (matcher *HaveStructTagMatcher) FailureMessage(actual interface{}) (message string) {
+	if !matcher.hasTag {
+		if matcher.field.Tag == "" {
+			return fmt.Sprintf("Expected field %s to have a %s tag, but it has no tags", matcher.fieldName(), matcher.Key)
+		}
+		return fmt.Sprintf("Expected field %s to have a %s tag, but its tags are:\n%s", matcher.fieldName(), matcher.Key, format.IndentString(string(matcher.field.Tag), 1))
+	}
+	return fmt.Sprintf("%s tag of %s failed to satisfy matcher.\n%s", matcher.Key, matcher.fieldName(), matcher.valueMatcher.FailureMessage(matcher.tagValue))
+}
+
+func (matcher *HaveStructTagMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if matcher.valueMatcher == nil {
+		return fmt.Sprintf("Expected field %s not to have a %s tag, but it has:\n%s", matcher.fieldName(), matcher.Key, format.Object(matcher.tagValue, 1))
+	}
+	return fmt.Sprintf("%s tag of %s satisfied matcher, but should not have.\n%s", matcher.Key, matcher.fieldName(), matcher.valueMatcher.NegatedFailureMessage(matcher.tagValue))
+}
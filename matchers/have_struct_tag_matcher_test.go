@@ -0,0 +1,125 @@
+package matchers_test
+
+import (
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+type tagTestAddress struct {
+	Zip string `json:"zip_code" yaml:"zip"`
+}
+
+type tagTestAudit struct {
+	CreatedBy string `json:"created_by"`
+}
+
+type tagTestUser struct {
+	tagTestAudit
+	Name     string `json:"name,omitempty"`
+	Password string `yaml:"-"`
+	Nickname string `json:""`
+	Age      int
+	Address  *tagTestAddress
+}
+
+var _ = Describe("HaveStructTag", func() {
+	When("passed a struct", func() {
+		It("should succeed if the field has the tag", func() {
+			Expect(tagTestUser{}).Should(HaveStructTag("Name", "json"))
+			Expect(tagTestUser{}).Should(HaveStructTag("Nickname", "json"))
+			Expect(tagTestUser{}).ShouldNot(HaveStructTag("Password", "json"))
+			Expect(tagTestUser{}).ShouldNot(HaveStructTag("Age", "json"))
+		})
+
+		It("should match the value of the tag", func() {
+			Expect(tagTestUser{}).Should(HaveStructTag("Name", "json", "name,omitempty"))
+			Expect(tagTestUser{}).ShouldNot(HaveStructTag("Name", "json", "name"))
+			Expect(tagTestUser{}).Should(HaveStructTag("Name", "json", HaveSuffix(",omitempty")))
+			Expect(tagTestUser{}).ShouldNot(HaveStructTag("Password", "json", BeEmpty()))
+		})
+
+		It("should follow paths to nested and promoted fields", func() {
+			Expect(tagTestUser{}).Should(HaveStructTag("Address.Zip", "json", "zip_code"))
+			Expect(tagTestUser{}).Should(HaveStructTag("Address.Zip", "yaml", "zip"))
+			Expect(tagTestUser{}).Should(HaveStructTag("CreatedBy", "json", "created_by"))
+		})
+	})
+
+	When("passed a pointer to a struct or a reflect.Type", func() {
+		It("should check the struct", func() {
+			Expect(&tagTestUser{}).Should(HaveStructTag("Name", "json"))
+			Expect(reflect.TypeOf(tagTestUser{})).Should(HaveStructTag("Name", "json"))
+			Expect(reflect.TypeOf(&tagTestUser{})).Should(HaveStructTag("Name", "json"))
+		})
+	})
+
+	When("passed something else", func() {
+		It("should error", func() {
+			success, err := (&HaveStructTagMatcher{Field: "Name", Key: "json"}).Match(nil)
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError("HaveStructTag matcher expects a struct, a pointer to a struct, or a reflect.Type of one.  Got nil"))
+
+			success, err = (&HaveStructTagMatcher{Field: "Name", Key: "json"}).Match("user")
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	When("the field does not exist", func() {
+		It("should error", func() {
+			success, err := (&HaveStructTagMatcher{Field: "Email", Key: "json"}).Match(tagTestUser{})
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError("HaveStructTag matcher expects matchers_test.tagTestUser to have a field named Email, but it does not"))
+
+			success, err = (&HaveStructTagMatcher{Field: "Name.First", Key: "json"}).Match(tagTestUser{})
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError("HaveStructTag matcher cannot look up field Name.First: Name is not a struct"))
+		})
+	})
+
+	When("passed an invalid value", func() {
+		It("should error", func() {
+			success, err := (&HaveStructTagMatcher{Field: "Name", Key: "json", Value: []interface{}{3}}).Match(tagTestUser{})
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError(ContainSubstring("HaveStructTag matcher expects the value to be a string or a matcher.")))
+
+			success, err = (&HaveStructTagMatcher{Field: "Name", Key: "json", Value: []interface{}{"a", "b"}}).Match(tagTestUser{})
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError(ContainSubstring("HaveStructTag matcher expects at most one value.")))
+		})
+	})
+
+	Describe("failure messages", func() {
+		It("should list the field's tags when the tag is missing", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(tagTestUser{}).Should(HaveStructTag("Password", "json"))
+				Expect(tagTestUser{}).Should(HaveStructTag("Age", "json"))
+			})
+			Expect(failures).Should(Equal([]string{
+				"Expected field matchers_test.tagTestUser.Password to have a json tag, but its tags are:\n    yaml:\"-\"",
+				"Expected field matchers_test.tagTestUser.Age to have a json tag, but it has no tags",
+			}))
+		})
+
+		It("should report the value matcher's failure", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(tagTestUser{}).Should(HaveStructTag("Address.Zip", "json", "zip"))
+			})
+			Expect(failures).Should(ConsistOf("json tag of matchers_test.tagTestAddress.Zip failed to satisfy matcher.\nExpected\n    <string>: zip_code\nto equal\n    <string>: zip"))
+		})
+
+		It("should report the tag when it should be absent", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(tagTestUser{}).ShouldNot(HaveStructTag("Name", "json"))
+				Expect(tagTestUser{}).ShouldNot(HaveStructTag("Name", "json", ContainSubstring("omitempty")))
+			})
+			Expect(failures).Should(Equal([]string{
+				"Expected field matchers_test.tagTestUser.Name not to have a json tag, but it has:\n    <string>: name,omitempty",
+				"json tag of matchers_test.tagTestUser.Name satisfied matcher, but should not have.\nExpected\n    <string>: name,omitempty\nnot to contain substring\n    <string>: omitempty",
+			}))
+		})
+	})
+})
@@ -0,0 +1,37 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type HaveSubjectCNMatcher struct {
+	Expected interface{}
+
+	// state
+	expectedMatcher omegaMatcher
+	commonName      string
+}
+
+func (matcher *HaveSubjectCNMatcher) Match(actual interface{}) (success bool, err error) {
+	certificate, _, err := toCertificate("HaveSubjectCN", actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.commonName = certificate.Subject.CommonName
+	var isMatcher bool
+	matcher.expectedMatcher, isMatcher = matcher.Expected.(omegaMatcher)
+	if !isMatcher {
+		matcher.expectedMatcher = &EqualMatcher{Expected: matcher.Expected}
+	}
+	return matcher.expectedMatcher.Match(matcher.commonName)
+}
+
+func (matcher *HaveSubjectCNMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Certificate subject common name:\n%s", format.IndentString(matcher.expectedMatcher.FailureMessage(matcher.commonName), 1))
+}
+
+func (matcher *HaveSubjectCNMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Certificate subject common name:\n%s", format.IndentString(matcher.expectedMatcher.NegatedFailureMessage(matcher.commonName), 1))
+}
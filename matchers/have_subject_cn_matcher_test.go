@@ -0,0 +1,27 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveSubjectCN", func() {
+	leaf := newTestCertificate(1, "api.example.com", []string{"api.example.com"}, nil)
+
+	It("should match the common name against a value or a matcher", func() {
+		Expect(leaf.Certificate).Should(HaveSubjectCN("api.example.com"))
+		Expect(leaf.Certificate).Should(HaveSubjectCN(HaveSuffix(".example.com")))
+		Expect(leaf.Certificate).ShouldNot(HaveSubjectCN("www.example.com"))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect(leaf.Certificate).Should(HaveSubjectCN("www.example.com"))
+			Expect(leaf.Certificate).ShouldNot(HaveSubjectCN(HaveSuffix(".example.com")))
+		})
+		Expect(failures).Should(Equal([]string{
+			"Certificate subject common name:\n    Expected\n        <string>: api.example.com\n    to equal\n        <string>: www.example.com",
+			"Certificate subject common name:\n    Expected\n        <string>: api.example.com\n    not to have suffix\n        <string>: .example.com",
+		}))
+	})
+})
@@ -0,0 +1,60 @@
+package matchers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/onsi/gomega/format"
+)
+
+type HaveTimezoneMatcher struct {
+	Name   string
+	Offset []time.Duration
+
+	// state
+	actualName   string
+	actualOffset time.Duration
+}
+
+func (matcher *HaveTimezoneMatcher) Match(actual interface{}) (success bool, err error) {
+	actualTime, ok := actual.(time.Time)
+	if !ok {
+		return false, fmt.Errorf("HaveTimezone matcher expects a time.Time.  Got:\n%s", format.Object(actual, 1))
+	}
+	if len(matcher.Offset) > 1 {
+		return false, fmt.Errorf("HaveTimezone matcher takes at most one offset.  Got: %v", matcher.Offset)
+	}
+
+	name, offsetSeconds := actualTime.Zone()
+	matcher.actualName, matcher.actualOffset = name, time.Duration(offsetSeconds)*time.Second
+	if matcher.actualName != matcher.Name {
+		return false, nil
+	}
+	if len(matcher.Offset) == 1 && matcher.actualOffset != matcher.Offset[0] {
+		return false, nil
+	}
+	return true, nil
+}
+
+func (matcher *HaveTimezoneMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to have time zone", matcher.expectedZone()) + fmt.Sprintf("\nbut it has time zone\n%s", format.Object(describeZone(matcher.actualName, matcher.actualOffset), 1))
+}
+
+func (matcher *HaveTimezoneMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to have time zone", matcher.expectedZone())
+}
+
+func (matcher *HaveTimezoneMatcher) expectedZone() string {
+	if len(matcher.Offset) == 1 {
+		return describeZone(matcher.Name, matcher.Offset[0])
+	}
+	return matcher.Name
+}
+
+func describeZone(name string, offset time.Duration) string {
+	sign := "+"
+	if offset < 0 {
+		sign, offset = "-", -offset
+	}
+	return fmt.Sprintf("%s (UTC%s%02d:%02d)", name, sign, int(offset.Hours()), int(offset.Minutes())%60)
+}
@@ -0,0 +1,52 @@
+package matchers_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HaveTimezone", func() {
+	var t0 time.Time
+	var est *time.Location
+
+	BeforeEach(func() {
+		t0 = time.Date(2023, time.March, 1, 12, 0, 0, 0, time.UTC)
+		est = time.FixedZone("EST", -5*60*60)
+	})
+
+	It("should compare zone names and, optionally, offsets", func() {
+		Expect(t0).Should(HaveTimezone("UTC"))
+		Expect(t0).Should(HaveTimezone("UTC", 0))
+		Expect(t0.In(est)).Should(HaveTimezone("EST"))
+		Expect(t0.In(est)).Should(HaveTimezone("EST", -5*time.Hour))
+		Expect(t0.In(est)).ShouldNot(HaveTimezone("EST", -4*time.Hour))
+		Expect(t0.In(est)).ShouldNot(HaveTimezone("UTC"))
+	})
+
+	It("should report both zones", func() {
+		m := HaveTimezone("UTC", 0)
+		Expect(m.Match(t0.In(est))).Should(BeFalse())
+		Expect(m.FailureMessage(t0.In(est))).Should(Equal("Expected\n    <time.Time>: 2023-03-01T07:00:00-05:00\nto have time zone\n    <string>: UTC (UTC+00:00)\nbut it has time zone\n    <string>: EST (UTC-05:00)"))
+
+		m = HaveTimezone("EST")
+		Expect(m.Match(t0)).Should(BeFalse())
+		Expect(m.FailureMessage(t0)).Should(HaveSuffix("to have time zone\n    <string>: EST\nbut it has time zone\n    <string>: UTC (UTC+00:00)"))
+		Expect(m.NegatedFailureMessage(t0)).Should(HaveSuffix("not to have time zone\n    <string>: EST"))
+	})
+
+	It("should format fractional-hour offsets", func() {
+		m := HaveTimezone("IST")
+		Expect(m.Match(t0.In(time.FixedZone("NPT", 5*60*60+45*60)))).Should(BeFalse())
+		Expect(m.FailureMessage(t0.In(time.FixedZone("NPT", 5*60*60+45*60)))).Should(HaveSuffix("NPT (UTC+05:45)"))
+	})
+
+	It("should error on invalid input", func() {
+		_, err := HaveTimezone("UTC").Match("now")
+		Expect(err).Should(MatchError(ContainSubstring("expects a time.Time")))
+
+		_, err = HaveTimezone("UTC", 0, time.Hour).Match(t0)
+		Expect(err).Should(MatchError(ContainSubstring("at most one offset")))
+	})
+})
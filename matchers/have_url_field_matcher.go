@@ -0,0 +1,67 @@
+package matchers
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/onsi/gomega/format"
+)
+
+type HaveURLFieldMatcher struct {
+	Field    string
+	Expected interface{}
+
+	// state
+	value interface{}
+}
+
+func (matcher *HaveURLFieldMatcher) Match(actual interface{}) (success bool, err error) {
+	u, err := toURL("HaveURLField", actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.value, err = urlField(u, matcher.Field)
+	if err != nil {
+		return false, err
+	}
+	return matcher.subMatcher().Match(matcher.value)
+}
+
+func (matcher *HaveURLFieldMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("URL %s:\n%s", matcher.Field, format.IndentString(matcher.subMatcher().FailureMessage(matcher.value), 1))
+}
+
+func (matcher *HaveURLFieldMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("URL %s:\n%s", matcher.Field, format.IndentString(matcher.subMatcher().NegatedFailureMessage(matcher.value), 1))
+}
+
+func (matcher *HaveURLFieldMatcher) subMatcher() omegaMatcher {
+	if m, ok := matcher.Expected.(omegaMatcher); ok {
+		return m
+	}
+	return &EqualMatcher{Expected: matcher.Expected}
+}
+
+// urlField returns a component of u: strings for all but Query, which is the url.Values parsed from the query string
+func urlField(u *url.URL, field string) (interface{}, error) {
+	switch field {
+	case "Scheme":
+		return u.Scheme, nil
+	case "User":
+		return u.User.String(), nil
+	case "Host":
+		return u.Host, nil
+	case "Hostname":
+		return u.Hostname(), nil
+	case "Port":
+		return u.Port(), nil
+	case "Path":
+		return u.Path, nil
+	case "Query":
+		return u.Query(), nil
+	case "Fragment":
+		return u.Fragment, nil
+	default:
+		return nil, fmt.Errorf("HaveURLField matcher expects one of the fields Scheme, User, Host, Hostname, Port, Path, Query or Fragment.  Got:\n%s", format.Object(field, 1))
+	}
+}
@@ -0,0 +1,49 @@
+package matchers_test
+
+import (
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("HaveURLField", func() {
+	const location = "https://bob@Example.com:8443/log%20in?next=%2Fsettings&tag=a&tag=b#form"
+
+	It("should match each field", func() {
+		Expect(location).Should(HaveURLField("Scheme", "https"))
+		Expect(location).Should(HaveURLField("User", "bob"))
+		Expect(location).Should(HaveURLField("Host", "Example.com:8443"))
+		Expect(location).Should(HaveURLField("Hostname", "Example.com"))
+		Expect(location).Should(HaveURLField("Port", "8443"))
+		Expect(location).Should(HaveURLField("Path", "/log in"))
+		Expect(location).Should(HaveURLField("Query", HaveKeyWithValue("next", ConsistOf("/settings"))))
+		Expect(location).Should(HaveURLField("Query", HaveKeyWithValue("tag", []string{"a", "b"})))
+		Expect(location).Should(HaveURLField("Fragment", "form"))
+		Expect(location).ShouldNot(HaveURLField("Path", HavePrefix("/logout")))
+	})
+
+	It("should accept url.URLs and *url.URLs", func() {
+		u, err := url.Parse(location)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(u).Should(HaveURLField("Port", "8443"))
+		Expect(*u).Should(HaveURLField("Port", "8443"))
+	})
+
+	It("should report the field's failure", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect(location).Should(HaveURLField("Path", "/login"))
+			Expect(location).ShouldNot(HaveURLField("Port", "8443"))
+		})
+		Expect(failures).Should(Equal([]string{
+			"URL Path:\n    Expected\n        <string>: /log in\n    to equal\n        <string>: /login",
+			"URL Port:\n    Expected\n        <string>: 8443\n    not to equal\n        <string>: 8443",
+		}))
+	})
+
+	It("should error on unknown fields", func() {
+		_, err := (&HaveURLFieldMatcher{Field: "RawQuery", Expected: ""}).Match(location)
+		Expect(err).Should(MatchError(ContainSubstring("HaveURLField matcher expects one of the fields Scheme, User, Host, Hostname, Port, Path, Query or Fragment.")))
+	})
+})
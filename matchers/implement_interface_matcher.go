@@ -0,0 +1,78 @@
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+type ImplementInterfaceMatcher struct {
+	Interface reflect.Type
+}
+
+func (matcher *ImplementInterfaceMatcher) Match(actual interface{}) (success bool, err error) {
+	if matcher.Interface == nil || matcher.Interface.Kind() != reflect.Interface {
+		return false, fmt.Errorf("ImplementInterface matcher expects an interface type.  Got: %v", matcher.Interface)
+	}
+	if actual == nil {
+		return false, nil
+	}
+	return reflect.TypeOf(actual).Implements(matcher.Interface), nil
+}
+
+func (matcher *ImplementInterfaceMatcher) FailureMessage(actual interface{}) (message string) {
+	message = format.Message(actual, fmt.Sprintf("to implement %s", matcher.Interface))
+	if actual == nil {
+		return message
+	}
+	actualType := reflect.TypeOf(actual)
+	missing := missingMethods(actualType, matcher.Interface)
+	if len(missing) > 0 {
+		message += fmt.Sprintf("\n%s is missing the methods: %s", actualType, strings.Join(missing, ", "))
+	}
+	if actualType.Kind() != reflect.Ptr && reflect.PointerTo(actualType).Implements(matcher.Interface) {
+		message += fmt.Sprintf("\n%s does implement %s - did you mean to pass a pointer?", reflect.PointerTo(actualType), matcher.Interface)
+	}
+	return message
+}
+
+func (matcher *ImplementInterfaceMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("not to implement %s", matcher.Interface))
+}
+
+// missingMethods returns the names of the methods of iface that t lacks.  A method with the right name but the
+// wrong signature counts as missing.
+func missingMethods(t reflect.Type, iface reflect.Type) []string {
+	missing := []string{}
+	for i := 0; i < iface.NumMethod(); i++ {
+		expected := iface.Method(i)
+		method, ok := t.MethodByName(expected.Name)
+		if !ok {
+			missing = append(missing, expected.Name)
+			continue
+		}
+		methodType := methodSignature(t, method)
+		if methodType != expected.Type {
+			missing = append(missing, fmt.Sprintf("%s (has %s, wants %s)", expected.Name, methodType, expected.Type))
+		}
+	}
+	return missing
+}
+
+// methodSignature returns the type of method, one of t's methods, without its receiver
+func methodSignature(t reflect.Type, method reflect.Method) reflect.Type {
+	if t.Kind() == reflect.Interface {
+		return method.Type
+	}
+	in := []reflect.Type{}
+	for i := 1; i < method.Type.NumIn(); i++ {
+		in = append(in, method.Type.In(i))
+	}
+	out := []reflect.Type{}
+	for i := 0; i < method.Type.NumOut(); i++ {
+		out = append(out, method.Type.Out(i))
+	}
+	return reflect.FuncOf(in, out, method.Type.IsVariadic())
+}
@@ -0,0 +1,85 @@
+package matchers_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+type valueStringer struct{}
+
+func (valueStringer) String() string { return "value" }
+
+type pointerCloser struct{}
+
+func (*pointerCloser) Close() error { return nil }
+
+type wrongCloser struct{}
+
+func (wrongCloser) Close() {}
+
+var _ = Describe("ImplementInterface", func() {
+	It("succeeds if actual's type implements the interface", func() {
+		Expect(&bytes.Buffer{}).Should(ImplementInterface[io.Reader]())
+		Expect(&bytes.Buffer{}).Should(ImplementInterface[io.ReadWriter]())
+		Expect(valueStringer{}).Should(ImplementInterface[fmt.Stringer]())
+		Expect(&valueStringer{}).Should(ImplementInterface[fmt.Stringer]())
+		Expect(3).Should(ImplementInterface[interface{}]())
+
+		Expect(bytes.Buffer{}).ShouldNot(ImplementInterface[io.Reader]())
+		Expect(&bytes.Buffer{}).ShouldNot(ImplementInterface[io.Closer]())
+		Expect(pointerCloser{}).ShouldNot(ImplementInterface[io.Closer]())
+	})
+
+	It("fails when actual is nil", func() {
+		Expect(nil).ShouldNot(ImplementInterface[interface{}]())
+	})
+
+	It("errors when the type is not an interface", func() {
+		success, err := ImplementInterface[bytes.Buffer]().Match(&bytes.Buffer{})
+		Expect(success).Should(BeFalse())
+		Expect(err).Should(MatchError("ImplementInterface matcher expects an interface type.  Got: bytes.Buffer"))
+
+		_, err = (&ImplementInterfaceMatcher{}).Match(3)
+		Expect(err).Should(HaveOccurred())
+	})
+
+	Describe("failure messages", func() {
+		It("lists the missing methods", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(&bytes.Buffer{}).Should(ImplementInterface[io.ReadWriteCloser]())
+			})
+			Expect(failures).Should(ConsistOf(HaveSuffix("to implement io.ReadWriteCloser\n*bytes.Buffer is missing the methods: Close")))
+		})
+
+		It("reports methods with the wrong signature", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(wrongCloser{}).Should(ImplementInterface[io.Closer]())
+			})
+			Expect(failures).Should(ConsistOf("Expected\n    <matchers_test.wrongCloser>: {}\nto implement io.Closer\nmatchers_test.wrongCloser is missing the methods: Close (has func(), wants func() error)"))
+		})
+
+		It("suggests passing a pointer when the pointer implements the interface", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(pointerCloser{}).Should(ImplementInterface[io.Closer]())
+			})
+			Expect(failures).Should(ConsistOf("Expected\n    <matchers_test.pointerCloser>: {}\nto implement io.Closer\nmatchers_test.pointerCloser is missing the methods: Close\n*matchers_test.pointerCloser does implement io.Closer - did you mean to pass a pointer?"))
+		})
+
+		It("reports the negated failure", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(valueStringer{}).ShouldNot(ImplementInterface[fmt.Stringer]())
+			})
+			Expect(failures).Should(ConsistOf(HaveSuffix("not to implement fmt.Stringer")))
+		})
+	})
+
+	It("can be constructed from a reflect.Type", func() {
+		Expect(&bytes.Buffer{}).Should(&ImplementInterfaceMatcher{Interface: reflect.TypeOf((*io.Writer)(nil)).Elem()})
+	})
+})
@@ -0,0 +1,35 @@
+package matchers
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/onsi/gomega/format"
+)
+
+// toAddr converts a string, net.IP, *net.IPAddr or netip.Addr to a netip.Addr.  IPv4-mapped IPv6 addresses are
+// unmapped, so that they match IPv4 prefixes.  ok is false if actual is of any other type; err is set if it is of a
+// supported type but not a valid IP address.
+func toAddr(matcherName string, actual interface{}) (addr netip.Addr, ok bool, err error) {
+	switch a := actual.(type) {
+	case netip.Addr:
+		addr = a
+	case net.IP:
+		addr, _ = netip.AddrFromSlice(a)
+	case *net.IPAddr:
+		if a != nil {
+			addr, _ = netip.AddrFromSlice(a.IP)
+		}
+	default:
+		s, isString := toString(actual)
+		if !isString {
+			return netip.Addr{}, false, nil
+		}
+		addr, _ = netip.ParseAddr(s)
+	}
+	if !addr.IsValid() {
+		return netip.Addr{}, true, fmt.Errorf("%s matcher expects a valid IP address.  Got:\n%s", matcherName, format.Object(actual, 1))
+	}
+	return addr.Unmap(), true, nil
+}
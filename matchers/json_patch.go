@@ -0,0 +1,404 @@
+package matchers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+// decodeJSONDocument decodes value - a string, stringer, or []byte of JSON, or any other value to marshal as JSON
+func decodeJSONDocument(matcherName string, role string, value interface{}) (interface{}, error) {
+	s, ok := toString(value)
+	if !ok {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s matcher expects %s that marshals to JSON.  Got:\n%s\nUnderlying error: %s", matcherName, role, format.Object(value, 1), err)
+		}
+		s = string(encoded)
+	}
+	var document interface{}
+	if err := json.Unmarshal([]byte(s), &document); err != nil {
+		return nil, fmt.Errorf("%s matcher expects %s to be valid JSON.  Got:\n%s\nUnderlying error: %s", matcherName, role, format.Object(s, 1), err)
+	}
+	return document, nil
+}
+
+// applyPatch applies patch to document.  A JSON array is an RFC 6902 JSON Patch; anything else is an RFC 7386 JSON
+// Merge Patch, extended with the directives of Kubernetes' strategic merge patches.  document may be modified.
+func applyPatch(document interface{}, patch interface{}) (interface{}, error) {
+	if operations, ok := patch.([]interface{}); ok {
+		return applyJSONPatch(document, operations)
+	}
+	return applyMergePatch(document, patch)
+}
+
+func applyJSONPatch(document interface{}, operations []interface{}) (interface{}, error) {
+	for i, operation := range operations {
+		var err error
+		document, err = applyJSONPatchOperation(document, operation)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %s", i, err)
+		}
+	}
+	return document, nil
+}
+
+func applyJSONPatchOperation(document interface{}, operation interface{}) (interface{}, error) {
+	fields, ok := operation.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected an object, got %s", marshalJSON(operation))
+	}
+	op, _ := fields["op"].(string)
+	path, err := jsonPointerMember(fields, "path")
+	if err != nil {
+		return nil, err
+	}
+	value, hasValue := fields["value"]
+	switch op {
+	case "add", "replace", "test":
+		if !hasValue {
+			return nil, fmt.Errorf("%q operation is missing its value", op)
+		}
+	case "move", "copy":
+		from, err := jsonPointerMember(fields, "from")
+		if err != nil {
+			return nil, err
+		}
+		value, err = getJSONPointer(document, from)
+		if err != nil {
+			return nil, err
+		}
+		if op == "move" {
+			if isProperPrefix(from, path) {
+				return nil, fmt.Errorf("cannot move %s into itself", formatJSONPointer(from))
+			}
+			document, err = updateJSONPointer(document, from, removeMember)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			value = copyJSON(value)
+		}
+		op = "add"
+	case "remove":
+	default:
+		return nil, fmt.Errorf("unknown op %q", fields["op"])
+	}
+
+	switch op {
+	case "add":
+		return updateJSONPointer(document, path, func(container interface{}, token string) (interface{}, error) {
+			return addMember(container, token, value)
+		})
+	case "remove":
+		return updateJSONPointer(document, path, removeMember)
+	case "replace":
+		return updateJSONPointer(document, path, func(container interface{}, token string) (interface{}, error) {
+			container, err := removeMember(container, token)
+			if err != nil {
+				return nil, err
+			}
+			return addMember(container, token, value)
+		})
+	default: // test
+		current, err := getJSONPointer(document, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(current, value) {
+			return nil, fmt.Errorf("test failed: %s is %s, not %s", formatJSONPointer(path), marshalJSON(current), marshalJSON(value))
+		}
+		return document, nil
+	}
+}
+
+// jsonPointerMember parses the RFC 6901 JSON Pointer in the named member of an operation into its reference tokens
+func jsonPointerMember(fields map[string]interface{}, name string) ([]string, error) {
+	pointer, ok := fields[name].(string)
+	if !ok {
+		return nil, fmt.Errorf("expected %q to be a JSON pointer, got %s", name, marshalJSON(fields[name]))
+	}
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q: it must be empty or start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func formatJSONPointer(tokens []string) string {
+	if len(tokens) == 0 {
+		return "the document"
+	}
+	escaped := make([]string, len(tokens))
+	for i, token := range tokens {
+		escaped[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+	}
+	return "/" + strings.Join(escaped, "/")
+}
+
+func isProperPrefix(prefix, tokens []string) bool {
+	return len(prefix) < len(tokens) && reflect.DeepEqual(prefix, tokens[:len(prefix)])
+}
+
+func getJSONPointer(document interface{}, tokens []string) (interface{}, error) {
+	for i, token := range tokens {
+		var err error
+		document, err = member(document, token)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", formatJSONPointer(tokens[:i+1]), err)
+		}
+	}
+	return document, nil
+}
+
+// updateJSONPointer replaces the container that holds the member tokens points to with what update returns for it
+func updateJSONPointer(document interface{}, tokens []string, update func(container interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 0 {
+		// the document is held by a notional container of its own
+		container, err := update(map[string]interface{}{"": document}, "")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", formatJSONPointer(tokens), err)
+		}
+		updated, ok := container.(map[string]interface{})[""]
+		if !ok {
+			return nil, fmt.Errorf("cannot remove the document")
+		}
+		return updated, nil
+	}
+	updated, err := updateMember(document, tokens, update)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", formatJSONPointer(tokens), err)
+	}
+	return updated, nil
+}
+
+func updateMember(container interface{}, tokens []string, update func(container interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 1 {
+		return update(container, tokens[0])
+	}
+	child, err := member(container, tokens[0])
+	if err != nil {
+		return nil, err
+	}
+	child, err = updateMember(child, tokens[1:], update)
+	if err != nil {
+		return nil, err
+	}
+	return setMember(container, tokens[0], child)
+}
+
+func member(container interface{}, token string) (interface{}, error) {
+	switch container := container.(type) {
+	case map[string]interface{}:
+		value, ok := container[token]
+		if !ok {
+			return nil, fmt.Errorf("no such member")
+		}
+		return value, nil
+	case []interface{}:
+		index, err := arrayIndex(container, token, false)
+		if err != nil {
+			return nil, err
+		}
+		return container[index], nil
+	default:
+		return nil, fmt.Errorf("cannot descend into %s", marshalJSON(container))
+	}
+}
+
+func setMember(container interface{}, token string, value interface{}) (interface{}, error) {
+	if object, ok := container.(map[string]interface{}); ok {
+		object[token] = value
+		return object, nil
+	}
+	array := container.([]interface{})
+	index, _ := arrayIndex(array, token, false)
+	array[index] = value
+	return array, nil
+}
+
+func addMember(container interface{}, token string, value interface{}) (interface{}, error) {
+	switch container := container.(type) {
+	case map[string]interface{}:
+		container[token] = value
+		return container, nil
+	case []interface{}:
+		index, err := arrayIndex(container, token, true)
+		if err != nil {
+			return nil, err
+		}
+		added := append([]interface{}{}, container[:index]...)
+		added = append(added, value)
+		return append(added, container[index:]...), nil
+	default:
+		return nil, fmt.Errorf("cannot add a member to %s", marshalJSON(container))
+	}
+}
+
+func removeMember(container interface{}, token string) (interface{}, error) {
+	switch container := container.(type) {
+	case map[string]interface{}:
+		if _, ok := container[token]; !ok {
+			return nil, fmt.Errorf("no such member")
+		}
+		delete(container, token)
+		return container, nil
+	case []interface{}:
+		index, err := arrayIndex(container, token, false)
+		if err != nil {
+			return nil, err
+		}
+		return append(append([]interface{}{}, container[:index]...), container[index+1:]...), nil
+	default:
+		return nil, fmt.Errorf("cannot remove a member from %s", marshalJSON(container))
+	}
+}
+
+// arrayIndex parses an array index.  When adding, the index may be one past the end - or "-", which stands for it.
+func arrayIndex(array []interface{}, token string, adding bool) (int, error) {
+	last := len(array) - 1
+	if adding {
+		last = len(array)
+		if token == "-" {
+			return last, nil
+		}
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > last || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid index %q into an array of length %d", token, len(array))
+	}
+	return index, nil
+}
+
+// strategic merge patch directives
+const (
+	patchDirective                 = "$patch"
+	retainKeysDirective            = "$retainKeys"
+	deleteFromPrimitiveListPrefix  = "$deleteFromPrimitiveList/"
+	setElementOrderDirectivePrefix = "$setElementOrder/"
+)
+
+// deletedByPatch is returned in place of an object deleted by a $patch: delete directive
+type deletedByPatch struct{}
+
+func applyMergePatch(document interface{}, patch interface{}) (interface{}, error) {
+	patched, err := mergePatch(document, patch)
+	if _, deleted := patched.(deletedByPatch); deleted {
+		return nil, err
+	}
+	return patched, err
+}
+
+func mergePatch(document interface{}, patch interface{}) (interface{}, error) {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch, nil
+	}
+	object, ok := document.(map[string]interface{})
+	if !ok {
+		object = map[string]interface{}{}
+	}
+
+	switch directive := patchObject[patchDirective]; directive {
+	case nil, "merge":
+	case "replace":
+		object = map[string]interface{}{}
+	case "delete":
+		return deletedByPatch{}, nil
+	default:
+		return nil, fmt.Errorf("unknown %s directive %s", patchDirective, marshalJSON(directive))
+	}
+
+	keys := []string{}
+	for key := range patchObject {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := patchObject[key]
+		switch {
+		case key == patchDirective, key == retainKeysDirective, strings.HasPrefix(key, setElementOrderDirectivePrefix):
+		case strings.HasPrefix(key, deleteFromPrimitiveListPrefix):
+			name := strings.TrimPrefix(key, deleteFromPrimitiveListPrefix)
+			list, err := deleteFromPrimitiveList(object[name], value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", key, err)
+			}
+			object[name] = list
+		case value == nil:
+			delete(object, key)
+		default:
+			patched, err := mergePatch(object[key], value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", key, err)
+			}
+			if _, deleted := patched.(deletedByPatch); deleted {
+				delete(object, key)
+			} else {
+				object[key] = patched
+			}
+		}
+	}
+
+	if retainKeys, ok := patchObject[retainKeysDirective]; ok {
+		retained, ok := retainKeys.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected %s to be a list of keys, got %s", retainKeysDirective, marshalJSON(retainKeys))
+		}
+		for key := range object {
+			if !containsJSON(retained, key) {
+				delete(object, key)
+			}
+		}
+	}
+	return object, nil
+}
+
+func deleteFromPrimitiveList(list interface{}, deletions interface{}) (interface{}, error) {
+	toDelete, ok := deletions.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a list of values to delete, got %s", marshalJSON(deletions))
+	}
+	elements, ok := list.([]interface{})
+	if !ok {
+		return list, nil
+	}
+	kept := []interface{}{}
+	for _, element := range elements {
+		if !containsJSON(toDelete, element) {
+			kept = append(kept, element)
+		}
+	}
+	return kept, nil
+}
+
+func containsJSON(values []interface{}, value interface{}) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func copyJSON(value interface{}) interface{} {
+	var copied interface{}
+	json.Unmarshal([]byte(marshalJSON(value)), &copied)
+	return copied
+}
+
+func marshalJSON(value interface{}) string {
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
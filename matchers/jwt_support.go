@@ -0,0 +1,134 @@
+package matchers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// jwt is a decoded, but not yet verified, JSON Web Token in compact serialization
+type jwt struct {
+	header       map[string]interface{}
+	claims       map[string]interface{}
+	signingInput string
+	signature    []byte
+}
+
+func decodeJWT(token string) (jwt, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return jwt{}, fmt.Errorf("it has %d dot-separated parts, expected 3", len(parts))
+	}
+	decoded := jwt{signingInput: parts[0] + "." + parts[1]}
+	if err := decodeJWTSegment(parts[0], &decoded.header); err != nil {
+		return jwt{}, fmt.Errorf("its header is invalid: %w", err)
+	}
+	if err := decodeJWTSegment(parts[1], &decoded.claims); err != nil {
+		return jwt{}, fmt.Errorf("its claims are invalid: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return jwt{}, fmt.Errorf("its signature is invalid: %w", err)
+	}
+	decoded.signature = signature
+	return decoded, nil
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+var jwtHashes = map[string]crypto.Hash{"256": crypto.SHA256, "384": crypto.SHA384, "512": crypto.SHA512}
+
+// verify checks the token's signature with key, which must suit the token's algorithm: a []byte or string secret for
+// HS256/384/512, an RSA public key for RS256/384/512 and PS256/384/512, an ECDSA public key for ES256/384/512 and an
+// Ed25519 public key for EdDSA.  Private keys are accepted in place of their public keys.
+func (token jwt) verify(key interface{}) error {
+	alg, _ := token.header["alg"].(string)
+	if alg == "" {
+		return errors.New("its header has no alg")
+	}
+	if alg == "none" {
+		return errors.New("it is unsigned")
+	}
+	if signer, ok := key.(crypto.Signer); ok {
+		key = signer.Public()
+	}
+
+	if alg == "EdDSA" {
+		publicKey, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return fmt.Errorf("it is signed with EdDSA, which needs an ed25519.PublicKey, but the key is a %T", key)
+		}
+		if !ed25519.Verify(publicKey, []byte(token.signingInput), token.signature) {
+			return errors.New("its signature does not match")
+		}
+		return nil
+	}
+
+	hash, ok := jwtHashes[strings.TrimLeft(alg, "HRPES")]
+	if !ok || len(alg) != 5 {
+		return fmt.Errorf("it is signed with %s, which is not supported", alg)
+	}
+	hasher := hash.New()
+	hasher.Write([]byte(token.signingInput))
+	digest := hasher.Sum(nil)
+
+	var verified bool
+	switch alg[:2] {
+	case "HS":
+		var secret []byte
+		switch k := key.(type) {
+		case []byte:
+			secret = k
+		case string:
+			secret = []byte(k)
+		default:
+			return fmt.Errorf("it is signed with %s, which needs a []byte secret, but the key is a %T", alg, key)
+		}
+		mac := hmac.New(hash.New, secret)
+		mac.Write([]byte(token.signingInput))
+		verified = hmac.Equal(mac.Sum(nil), token.signature)
+	case "RS", "PS":
+		publicKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("it is signed with %s, which needs an *rsa.PublicKey, but the key is a %T", alg, key)
+		}
+		if alg[0] == 'R' {
+			verified = rsa.VerifyPKCS1v15(publicKey, hash, digest, token.signature) == nil
+		} else {
+			verified = rsa.VerifyPSS(publicKey, hash, digest, token.signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthAuto}) == nil
+		}
+	case "ES":
+		publicKey, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("it is signed with %s, which needs an *ecdsa.PublicKey, but the key is a %T", alg, key)
+		}
+		size := (publicKey.Curve.Params().BitSize + 7) / 8
+		if len(token.signature) == 2*size {
+			r := new(big.Int).SetBytes(token.signature[:size])
+			s := new(big.Int).SetBytes(token.signature[size:])
+			verified = ecdsa.Verify(publicKey, digest, r, s)
+		}
+	default:
+		return fmt.Errorf("it is signed with %s, which is not supported", alg)
+	}
+	if !verified {
+		return errors.New("its signature does not match")
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package matchers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type MatchAfterApplyingPatchMatcher struct {
+	Base     interface{}
+	Expected interface{}
+
+	// state
+	patched         string
+	expectedMatcher omegaMatcher
+}
+
+func (matcher *MatchAfterApplyingPatchMatcher) Match(actual interface{}) (success bool, err error) {
+	base, err := decodeJSONDocument("MatchAfterApplyingPatch", "the base", matcher.Base)
+	if err != nil {
+		return false, err
+	}
+	patch, err := decodeJSONDocument("MatchAfterApplyingPatch", "a patch", actual)
+	if err != nil {
+		return false, err
+	}
+	patched, err := applyPatch(base, patch)
+	if err != nil {
+		return false, fmt.Errorf("MatchAfterApplyingPatch matcher could not apply the patch to the base: %s\nPatch:\n%s", err, format.Object(actual, 1))
+	}
+	encoded, _ := json.MarshalIndent(patched, "", "  ")
+	matcher.patched = string(encoded)
+
+	var isMatcher bool
+	matcher.expectedMatcher, isMatcher = matcher.Expected.(omegaMatcher)
+	if !isMatcher {
+		matcher.expectedMatcher = &MatchJSONMatcher{JSONToMatch: matcher.Expected}
+	}
+	return matcher.expectedMatcher.Match(matcher.patched)
+}
+
+func (matcher *MatchAfterApplyingPatchMatcher) FailureMessage(actual interface{}) (message string) {
+	message = "Patched document failed to satisfy matcher.\n"
+	return message + matcher.expectedMatcher.FailureMessage(matcher.patched)
+}
+
+func (matcher *MatchAfterApplyingPatchMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	message = "Patched document satisfied matcher, but should not have.\n"
+	return message + matcher.expectedMatcher.NegatedFailureMessage(matcher.patched)
+}
@@ -0,0 +1,98 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("MatchAfterApplyingPatch", func() {
+	base := `{"name": "web", "replicas": 1, "labels": {"app": "web", "tier": "frontend"}, "ports": [80, 443]}`
+
+	Context("with a JSON Patch", func() {
+		It("should match the patched document", func() {
+			patch := `[
+				{"op": "replace", "path": "/replicas", "value": 3},
+				{"op": "add", "path": "/labels/env", "value": "prod"},
+				{"op": "remove", "path": "/labels/tier"},
+				{"op": "add", "path": "/ports/-", "value": 8080},
+				{"op": "test", "path": "/name", "value": "web"}
+			]`
+			Expect(patch).Should(MatchAfterApplyingPatch(base, `{"name": "web", "replicas": 3, "labels": {"app": "web", "env": "prod"}, "ports": [80, 443, 8080]}`))
+			Expect(patch).ShouldNot(MatchAfterApplyingPatch(base, `{"name": "web", "replicas": 1}`))
+		})
+
+		It("should move and copy values", func() {
+			patch := `[
+				{"op": "copy", "from": "/labels", "path": "/selector"},
+				{"op": "move", "from": "/ports/0", "path": "/ports/1"},
+				{"op": "add", "path": "/selector/app", "value": "api"}
+			]`
+			Expect(patch).Should(MatchAfterApplyingPatch(base, `{"name": "web", "replicas": 1, "labels": {"app": "web", "tier": "frontend"}, "selector": {"app": "api", "tier": "frontend"}, "ports": [443, 80]}`))
+		})
+
+		It("should unescape JSON pointers", func() {
+			patch := `[{"op": "add", "path": "/annotations", "value": {}}, {"op": "add", "path": "/annotations/example.com~1owner", "value": "me~0you"}]`
+			Expect(patch).Should(MatchAfterApplyingPatch(`{}`, `{"annotations": {"example.com/owner": "me~0you"}}`))
+		})
+
+		It("should replace the whole document", func() {
+			Expect(`[{"op": "replace", "path": "", "value": [1, 2]}]`).Should(MatchAfterApplyingPatch(base, `[1, 2]`))
+		})
+
+		It("should error when the patch cannot be applied", func() {
+			success, err := (&MatchAfterApplyingPatchMatcher{Base: base, Expected: `{}`}).Match(`[{"op": "remove", "path": "/labels/missing"}]`)
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError(ContainSubstring("could not apply the patch to the base: operation 0: /labels/missing: no such member")))
+
+			_, err = (&MatchAfterApplyingPatchMatcher{Base: base, Expected: `{}`}).Match(`[{"op": "test", "path": "/replicas", "value": 2}]`)
+			Expect(err).Should(MatchError(ContainSubstring("operation 0: test failed: /replicas is 1, not 2")))
+		})
+	})
+
+	Context("with a merge patch", func() {
+		It("should merge objects and delete null members", func() {
+			patch := map[string]interface{}{
+				"replicas": 3,
+				"labels":   map[string]interface{}{"tier": nil, "env": "prod"},
+				"ports":    []int{8080},
+			}
+			Expect(patch).Should(MatchAfterApplyingPatch(base, `{"name": "web", "replicas": 3, "labels": {"app": "web", "env": "prod"}, "ports": [8080]}`))
+		})
+
+		It("should honor strategic merge directives", func() {
+			patch := `{
+				"labels": {"$patch": "replace", "owner": "ops"},
+				"$deleteFromPrimitiveList/ports": [443],
+				"name": {"$patch": "delete"}
+			}`
+			Expect(patch).Should(MatchAfterApplyingPatch(base, `{"replicas": 1, "labels": {"owner": "ops"}, "ports": [80]}`))
+
+			Expect(`{"$retainKeys": ["name", "replicas"], "replicas": 2}`).Should(MatchAfterApplyingPatch(base, `{"name": "web", "replicas": 2}`))
+		})
+
+		It("should error on unknown directives", func() {
+			_, err := (&MatchAfterApplyingPatchMatcher{Base: base, Expected: `{}`}).Match(`{"labels": {"$patch": "shuffle"}}`)
+			Expect(err).Should(MatchError(ContainSubstring(`labels: unknown $patch directive "shuffle"`)))
+		})
+	})
+
+	It("should pass the patched document to a nested matcher", func() {
+		Expect(`{"replicas": 3}`).Should(MatchAfterApplyingPatch(base, ContainSubstring(`"replicas": 3`)))
+	})
+
+	It("should error when the base or patch is not JSON", func() {
+		_, err := (&MatchAfterApplyingPatchMatcher{Base: `{`, Expected: `{}`}).Match(`{}`)
+		Expect(err).Should(MatchError(ContainSubstring("MatchAfterApplyingPatch matcher expects the base to be valid JSON")))
+
+		_, err = (&MatchAfterApplyingPatchMatcher{Base: base, Expected: `{}`}).Match(make(chan int))
+		Expect(err).Should(MatchError(ContainSubstring("MatchAfterApplyingPatch matcher expects a patch that marshals to JSON")))
+	})
+
+	It("should report the nested matcher's failure", func() {
+		m := MatchAfterApplyingPatch(base, `{"replicas": 3}`)
+		Expect(m.Match(`{"replicas": 2}`)).Should(BeFalse())
+		Expect(m.FailureMessage(`{"replicas": 2}`)).Should(HavePrefix("Patched document failed to satisfy matcher.\nExpected\n"))
+		Expect(m.NegatedFailureMessage(`{"replicas": 2}`)).Should(HavePrefix("Patched document satisfied matcher, but should not have.\nExpected\n"))
+	})
+})
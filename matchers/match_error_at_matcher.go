@@ -0,0 +1,52 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/format"
+)
+
+type MatchErrorAtMatcher struct {
+	Depth    int
+	Expected interface{}
+
+	// state
+	chain []error
+}
+
+func (matcher *MatchErrorAtMatcher) Match(actual interface{}) (success bool, err error) {
+	if matcher.Depth < 0 {
+		return false, fmt.Errorf("MatchErrorAt matcher expects a non-negative depth.  Got: %d", matcher.Depth)
+	}
+	matcher.chain, err = toErrorChain("MatchErrorAt", actual)
+	if err != nil {
+		return false, err
+	}
+	elementMatcher, err := toErrorChainElementMatcher("MatchErrorAt", matcher.Expected)
+	if err != nil {
+		return false, err
+	}
+	if matcher.Depth >= len(matcher.chain) {
+		return false, nil
+	}
+	return elementMatcher.Match(matcher.chain[matcher.Depth])
+}
+
+func (matcher *MatchErrorAtMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.Depth >= len(matcher.chain) {
+		return fmt.Sprintf("Expected an error at depth %d matching\n%s\nbut the error chain only has depth %d\n%s", matcher.Depth, format.Object(matcher.Expected, 1), len(matcher.chain)-1, formatErrorChain(matcher.chain))
+	}
+	return fmt.Sprintf("Error at depth %d:\n%s\n%s", matcher.Depth, format.IndentString(matcher.elementFailureMessage(false), 1), formatErrorChain(matcher.chain))
+}
+
+func (matcher *MatchErrorAtMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Error at depth %d:\n%s\n%s", matcher.Depth, format.IndentString(matcher.elementFailureMessage(true), 1), formatErrorChain(matcher.chain))
+}
+
+func (matcher *MatchErrorAtMatcher) elementFailureMessage(negated bool) string {
+	elementMatcher, _ := toErrorChainElementMatcher("MatchErrorAt", matcher.Expected)
+	if negated {
+		return elementMatcher.NegatedFailureMessage(matcher.chain[matcher.Depth])
+	}
+	return elementMatcher.FailureMessage(matcher.chain[matcher.Depth])
+}
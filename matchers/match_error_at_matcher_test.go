@@ -0,0 +1,86 @@
+package matchers_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("MatchErrorAt", func() {
+	var pathErr *fs.PathError
+	var err error
+
+	BeforeEach(func() {
+		pathErr = &fs.PathError{Op: "open", Path: "config.yml", Err: fs.ErrNotExist}
+		err = fmt.Errorf("loading config: %w", pathErr)
+	})
+
+	It("matches errors by equality", func() {
+		Expect(err).Should(MatchErrorAt(0, err))
+		Expect(err).Should(MatchErrorAt(1, pathErr))
+		Expect(err).Should(MatchErrorAt(2, fs.ErrNotExist))
+		Expect(err).ShouldNot(MatchErrorAt(1, fs.ErrNotExist))
+		Expect(err).ShouldNot(MatchErrorAt(0, fs.ErrNotExist))
+	})
+
+	It("matches strings against the error's message", func() {
+		Expect(err).Should(MatchErrorAt(1, "open config.yml: file does not exist"))
+		Expect(err).ShouldNot(MatchErrorAt(1, "file does not exist"))
+	})
+
+	It("passes the error to matchers", func() {
+		Expect(err).Should(MatchErrorAt(1, BeAssignableToTypeOf(&fs.PathError{})))
+		Expect(err).Should(MatchErrorAt(1, HaveField("Op", "open")))
+		Expect(err).Should(MatchErrorAt(2, MatchError(ContainSubstring("not exist"))))
+		Expect(err).ShouldNot(MatchErrorAt(0, BeAssignableToTypeOf(&fs.PathError{})))
+	})
+
+	It("fails when the chain isn't deep enough", func() {
+		Expect(err).ShouldNot(MatchErrorAt(3, fs.ErrNotExist))
+	})
+
+	It("errors when ACTUAL is not an error", func() {
+		_, matchErr := (&MatchErrorAtMatcher{Depth: 0, Expected: "boom"}).Match(nil)
+		Expect(matchErr).Should(MatchError("MatchErrorAt matcher expects an error, got nil"))
+	})
+
+	It("errors when passed invalid arguments", func() {
+		_, matchErr := (&MatchErrorAtMatcher{Depth: -1, Expected: "boom"}).Match(err)
+		Expect(matchErr).Should(MatchError("MatchErrorAt matcher expects a non-negative depth.  Got: -1"))
+
+		_, matchErr = (&MatchErrorAtMatcher{Depth: 0, Expected: 3}).Match(err)
+		Expect(matchErr).Should(MatchError(ContainSubstring("MatchErrorAt matcher must be passed an error, a string, or a matcher.  Got:")))
+	})
+
+	Describe("failure messages", func() {
+		It("reports the failure at depth and prints the chain", func() {
+			err := fmt.Errorf("outer: %w", errors.New("boom"))
+			failures := InterceptGomegaFailures(func() {
+				Expect(err).Should(MatchErrorAt(1, "bang"))
+			})
+			Expect(failures).Should(ConsistOf(And(
+				HavePrefix("Error at depth 1:\n    Expected\n        <*errors.errorString | 0x"),
+				HaveSuffix(">: {s: \"boom\"}\n    to match error\n        <string>: bang\nError chain:\n    [0] *fmt.wrapError: outer: boom\n    [1] *errors.errorString: boom"),
+			)))
+		})
+
+		It("reports a chain that isn't deep enough", func() {
+			err := fmt.Errorf("outer: %w", errors.New("boom"))
+			failures := InterceptGomegaFailures(func() {
+				Expect(err).Should(MatchErrorAt(2, "boom"))
+			})
+			Expect(failures).Should(ConsistOf("Expected an error at depth 2 matching\n    <string>: boom\nbut the error chain only has depth 1\nError chain:\n    [0] *fmt.wrapError: outer: boom\n    [1] *errors.errorString: boom"))
+		})
+
+		It("reports the negated failure at depth", func() {
+			failures := InterceptGomegaFailures(func() {
+				Expect(err).ShouldNot(MatchErrorAt(1, BeAssignableToTypeOf(&fs.PathError{})))
+			})
+			Expect(failures).Should(ConsistOf(And(HavePrefix("Error at depth 1:\n    Expected\n"), ContainSubstring("not to be assignable to the type"), HaveSuffix("[2] *errors.errorString: file does not exist"))))
+		})
+	})
+})
@@ -0,0 +1,79 @@
+package matchers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/onsi/gomega/format"
+)
+
+// UpdateGoldenEnvVar is the environment variable that, when set to a true value, makes MatchGoldenFile
+// rewrite its golden files instead of comparing against them
+const UpdateGoldenEnvVar = "UPDATE_GOLDEN"
+
+type MatchGoldenFileMatcher struct {
+	Path string
+
+	// state
+	golden       string
+	actualString string
+}
+
+func (matcher *MatchGoldenFileMatcher) Match(actual interface{}) (success bool, err error) {
+	actualString, err := matcher.serialize(actual)
+	if err != nil {
+		return false, err
+	}
+	matcher.actualString = actualString
+
+	if shouldUpdateGoldenFiles() {
+		if err := os.MkdirAll(filepath.Dir(matcher.Path), 0755); err != nil {
+			return false, fmt.Errorf("MatchGoldenFile matcher could not create the directory for golden file %s:\n%s", matcher.Path, format.IndentString(err.Error(), 1))
+		}
+		if err := os.WriteFile(matcher.Path, []byte(actualString), 0644); err != nil {
+			return false, fmt.Errorf("MatchGoldenFile matcher could not update golden file %s:\n%s", matcher.Path, format.IndentString(err.Error(), 1))
+		}
+		matcher.golden = actualString
+		return true, nil
+	}
+
+	golden, err := os.ReadFile(matcher.Path)
+	if err != nil {
+		return false, fmt.Errorf("MatchGoldenFile matcher could not read golden file %s.  Set %s=1 to create it.\n%s", matcher.Path, UpdateGoldenEnvVar, format.IndentString(err.Error(), 1))
+	}
+	matcher.golden = string(golden)
+	return matcher.golden == actualString, nil
+}
+
+// serialize renders strings, byte slices, and stringers as is, and anything else as indented JSON
+func (matcher *MatchGoldenFileMatcher) serialize(actual interface{}) (string, error) {
+	if actualString, ok := toString(actual); ok {
+		return actualString, nil
+	}
+	encoded, err := json.MarshalIndent(actual, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("MatchGoldenFile matcher requires a string, stringer, or JSON-serializable value.  Got:\n%s\nwhich failed to serialize:\n%s", format.Object(actual, 1), format.IndentString(err.Error(), 1))
+	}
+	return string(encoded) + "\n", nil
+}
+
+func shouldUpdateGoldenFiles() bool {
+	value := os.Getenv(UpdateGoldenEnvVar)
+	if value == "" {
+		return false
+	}
+	update, err := strconv.ParseBool(value)
+	return err != nil || update
+}
+
+func (matcher *MatchGoldenFileMatcher) FailureMessage(actual interface{}) (message string) {
+	diff := unifiedDiff(matcher.Path, "actual", matcher.golden, matcher.actualString)
+	return fmt.Sprintf("Expected to match golden file %s, but it differs:\n%s\nSet %s=1 to update the golden file.", matcher.Path, format.IndentString(diff, 1), UpdateGoldenEnvVar)
+}
+
+func (matcher *MatchGoldenFileMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(matcher.actualString, "not to match golden file", matcher.Path)
+}
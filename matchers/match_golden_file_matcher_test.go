@@ -0,0 +1,123 @@
+package matchers_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("MatchGoldenFile", func() {
+	var dir string
+
+	setUpdateGolden := func(value string) {
+		original, wasSet := os.LookupEnv(UpdateGoldenEnvVar)
+		DeferCleanup(func() {
+			if wasSet {
+				os.Setenv(UpdateGoldenEnvVar, original)
+			} else {
+				os.Unsetenv(UpdateGoldenEnvVar)
+			}
+		})
+		os.Setenv(UpdateGoldenEnvVar, value)
+	}
+
+	writeGolden := func(name string, contents string) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte(contents), 0644)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		setUpdateGolden("")
+	})
+
+	Context("when actual is a string, []byte or stringer", func() {
+		It("should compare it to the golden file as is", func() {
+			path := writeGolden("foo.golden", "a\nb\n")
+			Expect("a\nb\n").To(MatchGoldenFile(path))
+			Expect([]byte("a\nb\n")).To(MatchGoldenFile(path))
+			Expect(&myStringer{a: "a\nb\n"}).To(MatchGoldenFile(path))
+			Expect("a\nb").NotTo(MatchGoldenFile(path))
+			Expect("a\nc\n").NotTo(MatchGoldenFile(path))
+		})
+	})
+
+	Context("when actual is any other value", func() {
+		It("should compare its indented JSON serialization to the golden file", func() {
+			path := writeGolden("foo.golden", "{\n  \"name\": \"sprocket\",\n  \"sizes\": [\n    1,\n    2\n  ]\n}\n")
+			Expect(map[string]interface{}{"name": "sprocket", "sizes": []int{1, 2}}).To(MatchGoldenFile(path))
+			Expect(struct {
+				Name  string `json:"name"`
+				Sizes []int  `json:"sizes"`
+			}{"sprocket", []int{1, 2}}).To(MatchGoldenFile(path))
+			Expect(map[string]interface{}{"name": "sprocket"}).NotTo(MatchGoldenFile(path))
+		})
+
+		It("should error if the value can't be serialized", func() {
+			path := writeGolden("foo.golden", "")
+			success, err := (&MatchGoldenFileMatcher{Path: path}).Match(make(chan int))
+			Expect(success).To(BeFalse())
+			Expect(err).To(MatchError(ContainSubstring("MatchGoldenFile matcher requires a string, stringer, or JSON-serializable value")))
+		})
+	})
+
+	Context("when the golden file doesn't match", func() {
+		It("should report a unified diff", func() {
+			path := writeGolden("foo.golden", "alpha\nbeta\ngamma\n")
+			m := MatchGoldenFile(path)
+			Expect(m.Match("alpha\nBETA\ngamma\n")).To(BeFalse())
+			Expect(m.FailureMessage("alpha\nBETA\ngamma\n")).To(Equal("Expected to match golden file " + path + ", but it differs:\n" +
+				"    --- " + path + "\n" +
+				"    +++ actual\n" +
+				"    @@ -1,3 +1,3 @@\n" +
+				"     alpha\n" +
+				"    -beta\n" +
+				"    +BETA\n" +
+				"     gamma\n" +
+				"Set UPDATE_GOLDEN=1 to update the golden file."))
+		})
+
+		It("should report the serialized value when negated", func() {
+			path := writeGolden("foo.golden", "[\n  1\n]\n")
+			m := MatchGoldenFile(path)
+			Expect(m.Match([]int{1})).To(BeTrue())
+			Expect(m.NegatedFailureMessage([]int{1})).To(Equal("Expected\n    <string>: [\n      1\n    ]\n    \nnot to match golden file\n    <string>: " + path))
+		})
+	})
+
+	Context("when the golden file doesn't exist", func() {
+		It("should error", func() {
+			path := filepath.Join(dir, "missing.golden")
+			success, err := MatchGoldenFile(path).Match("a")
+			Expect(success).To(BeFalse())
+			Expect(err).To(MatchError(ContainSubstring("MatchGoldenFile matcher could not read golden file " + path + ".  Set UPDATE_GOLDEN=1 to create it.")))
+		})
+	})
+
+	Context("when UPDATE_GOLDEN is set", func() {
+		It("should write the golden file, creating its directory, and succeed", func() {
+			setUpdateGolden("1")
+			path := filepath.Join(dir, "testdata", "nested", "foo.golden")
+			Expect("a\nb\n").To(MatchGoldenFile(path))
+			Expect(os.ReadFile(path)).To(Equal([]byte("a\nb\n")))
+
+			Expect([]int{1, 2}).To(MatchGoldenFile(path))
+			Expect(os.ReadFile(path)).To(Equal([]byte("[\n  1,\n  2\n]\n")))
+
+			setUpdateGolden("")
+			Expect([]int{1, 2}).To(MatchGoldenFile(path))
+			Expect("a\nb\n").NotTo(MatchGoldenFile(path))
+		})
+
+		It("should not write the golden file when set to a false value", func() {
+			setUpdateGolden("false")
+			path := writeGolden("foo.golden", "a\n")
+			Expect("b\n").NotTo(MatchGoldenFile(path))
+			Expect(os.ReadFile(path)).To(Equal([]byte("a\n")))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package matchers
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+type MatchRegexpWithCapturesMatcher struct {
+	Regexp   string
+	Captures map[interface{}]types.GomegaMatcher
+
+	// state
+	matched        bool
+	captured       map[string]string
+	failedGroup    string
+	failedMatcher  types.GomegaMatcher
+	failedSubmatch string
+}
+
+func (matcher *MatchRegexpWithCapturesMatcher) Match(actual interface{}) (success bool, err error) {
+	actualString, ok := toString(actual)
+	if !ok {
+		return false, fmt.Errorf("MatchRegexpWithCaptures matcher requires a string or stringer.  Got:\n%s", format.Object(actual, 1))
+	}
+
+	re, err := regexp.Compile(matcher.Regexp)
+	if err != nil {
+		return false, fmt.Errorf("MatchRegexpWithCaptures matcher failed to compile the regular expression:\n\t%s", err.Error())
+	}
+
+	groups := map[string]int{}
+	for i, name := range re.SubexpNames() {
+		if name != "" {
+			groups[name] = i
+		}
+	}
+
+	names := []string{}
+	for key := range matcher.Captures {
+		name, ok := key.(string)
+		if !ok {
+			return false, fmt.Errorf("MatchRegexpWithCaptures matcher expects capture group names to be strings.  Got:\n%s", format.Object(key, 1))
+		}
+		if _, ok := groups[name]; !ok {
+			return false, fmt.Errorf("MatchRegexpWithCaptures matcher was given a matcher for capture group '%s', but the regular expression has no group with that name", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	matcher.failedGroup, matcher.failedMatcher, matcher.failedSubmatch = "", nil, ""
+	submatches := re.FindStringSubmatch(actualString)
+	matcher.matched = submatches != nil
+	if !matcher.matched {
+		return false, nil
+	}
+
+	matcher.captured = map[string]string{}
+	for name, i := range groups {
+		matcher.captured[name] = submatches[i]
+	}
+
+	for _, name := range names {
+		groupMatcher := matcher.Captures[name]
+		success, err := groupMatcher.Match(matcher.captured[name])
+		if err != nil {
+			return false, fmt.Errorf("MatchRegexpWithCaptures matcher failed to match capture group '%s':\n%s", name, err.Error())
+		}
+		if !success {
+			matcher.failedGroup, matcher.failedMatcher, matcher.failedSubmatch = name, groupMatcher, matcher.captured[name]
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (matcher *MatchRegexpWithCapturesMatcher) FailureMessage(actual interface{}) (message string) {
+	if !matcher.matched {
+		return format.Message(actual, "to match regular expression", matcher.Regexp)
+	}
+	message = fmt.Sprintf("Capture group '%s' failed to satisfy matcher.\n", matcher.failedGroup)
+	message += matcher.failedMatcher.FailureMessage(matcher.failedSubmatch)
+	return message
+}
+
+func (matcher *MatchRegexpWithCapturesMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to match regular expression", matcher.Regexp) + "\nwith captures\n" + format.Object(matcher.captured, 1)
+}
@@ -0,0 +1,65 @@
+package matchers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("MatchRegexpWithCaptures", func() {
+	const line = "level=info id=42 status=ok"
+	const re = `id=(?P<id>\d+) status=(?P<status>\w+)`
+
+	It("should match the regexp and the captured values", func() {
+		Expect(line).Should(MatchRegexpWithCaptures(re, Keys{"id": Equal("42")}))
+		Expect(line).Should(MatchRegexpWithCaptures(re, Keys{"id": Equal("42"), "status": Equal("ok")}))
+		Expect(line).Should(MatchRegexpWithCaptures(re, map[interface{}]types.GomegaMatcher{"status": HavePrefix("o")}))
+		Expect(line).Should(MatchRegexpWithCaptures(re, nil))
+		Expect(&myStringer{a: line}).Should(MatchRegexpWithCaptures(re, Keys{"id": Equal("42")}))
+
+		Expect(line).ShouldNot(MatchRegexpWithCaptures(re, Keys{"id": Equal("43")}))
+		Expect("level=info").ShouldNot(MatchRegexpWithCaptures(re, Keys{"id": Equal("42")}))
+	})
+
+	It("should only consider the first match", func() {
+		Expect("id=1 status=ok id=2 status=ok").Should(MatchRegexpWithCaptures(re, Keys{"id": Equal("1")}))
+	})
+
+	Describe("failure messages", func() {
+		It("should report the regexp when there is no match", func() {
+			m := MatchRegexpWithCaptures(re, Keys{"id": Equal("42")})
+			Expect(m.Match("level=info")).Should(BeFalse())
+			Expect(m.FailureMessage("level=info")).Should(Equal("Expected\n    <string>: level=info\nto match regular expression\n    <string>: id=(?P<id>\\d+) status=(?P<status>\\w+)"))
+		})
+
+		It("should report the first capture group (by name) that fails its matcher", func() {
+			m := MatchRegexpWithCaptures(re, Keys{"status": Equal("degraded"), "id": Equal("43")})
+			Expect(m.Match(line)).Should(BeFalse())
+			Expect(m.FailureMessage(line)).Should(Equal("Capture group 'id' failed to satisfy matcher.\nExpected\n    <string>: 42\nto equal\n    <string>: 43"))
+		})
+
+		It("should report the captured values when negated", func() {
+			m := MatchRegexpWithCaptures(re, Keys{"id": Equal("42")})
+			Expect(m.Match(line)).Should(BeTrue())
+			Expect(m.NegatedFailureMessage(line)).Should(HaveSuffix("not to match regular expression\n    <string>: id=(?P<id>\\d+) status=(?P<status>\\w+)\nwith captures\n    <map[string]string | len:2>: {\"id\": \"42\", \"status\": \"ok\"}"))
+		})
+	})
+
+	It("should error on invalid input", func() {
+		_, err := MatchRegexpWithCaptures(re, nil).Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("requires a string or stringer")))
+
+		_, err = MatchRegexpWithCaptures("(?P<id", nil).Match(line)
+		Expect(err).Should(MatchError(ContainSubstring("failed to compile")))
+
+		_, err = MatchRegexpWithCaptures(re, Keys{"name": Equal("bob")}).Match(line)
+		Expect(err).Should(MatchError(ContainSubstring("no group with that name")))
+
+		_, err = MatchRegexpWithCaptures(re, Keys{1: Equal("bob")}).Match(line)
+		Expect(err).Should(MatchError(ContainSubstring("names to be strings")))
+
+		_, err = MatchRegexpWithCaptures(re, Keys{"id": BeNumerically(">", 3)}).Match(line)
+		Expect(err).Should(MatchError(ContainSubstring("failed to match capture group 'id'")))
+	})
+})
@@ -0,0 +1,136 @@
+package matchers
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+)
+
+type MatchURLMatcher struct {
+	Expected string
+
+	// state
+	differences []string
+}
+
+func (matcher *MatchURLMatcher) Match(actual interface{}) (success bool, err error) {
+	actualURL, err := toURL("MatchURL", actual)
+	if err != nil {
+		return false, err
+	}
+	expectedURL, err := url.Parse(matcher.Expected)
+	if err != nil {
+		return false, fmt.Errorf("MatchURL matcher expects a valid URL.  Parsing %q failed with:\n%s", matcher.Expected, format.IndentString(err.Error(), 1))
+	}
+
+	matcher.differences = urlDifferences(actualURL, expectedURL)
+	return len(matcher.differences) == 0, nil
+}
+
+func (matcher *MatchURLMatcher) FailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "to match URL", matcher.Expected) + "\nbut:\n" + format.IndentString(strings.Join(matcher.differences, "\n"), 1)
+}
+
+func (matcher *MatchURLMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to match URL", matcher.Expected)
+}
+
+// toURL accepts a string, a url.URL or a *url.URL
+func toURL(matcherName string, actual interface{}) (*url.URL, error) {
+	switch u := actual.(type) {
+	case *url.URL:
+		if u != nil {
+			return u, nil
+		}
+	case url.URL:
+		return &u, nil
+	default:
+		if s, ok := toString(actual); ok {
+			parsed, err := url.Parse(s)
+			if err != nil {
+				return nil, fmt.Errorf("%s matcher expects actual to be a valid URL.  Parsing %q failed with:\n%s", matcherName, s, format.IndentString(err.Error(), 1))
+			}
+			return parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("%s matcher expects a string, a url.URL or a *url.URL.  Got:\n%s", matcherName, format.Object(actual, 1))
+}
+
+// urlDifferences compares two URLs component by component.  Schemes and hosts are compared case-insensitively and
+// default ports are dropped; paths and fragments are compared percent-decoded, with an empty path equal to "/"; query
+// parameters are compared regardless of their order, but the order of a parameter's values matters.
+func urlDifferences(actual *url.URL, expected *url.URL) []string {
+	differences := []string{}
+	differ := func(component string, a string, e string) {
+		if a != e {
+			differences = append(differences, fmt.Sprintf("the %s is %q, expected %q", component, a, e))
+		}
+	}
+
+	differ("scheme", strings.ToLower(actual.Scheme), strings.ToLower(expected.Scheme))
+	differ("user", actual.User.String(), expected.User.String())
+	differ("host", normalizedHost(actual), normalizedHost(expected))
+	differ("path", normalizedPath(actual), normalizedPath(expected))
+	if actual.Opaque != "" || expected.Opaque != "" {
+		differ("opaque part", actual.Opaque, expected.Opaque)
+	}
+
+	actualQuery, expectedQuery := actual.Query(), expected.Query()
+	names := []string{}
+	for name := range actualQuery {
+		names = append(names, name)
+	}
+	for name := range expectedQuery {
+		if _, ok := actualQuery[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		a, inActual := actualQuery[name]
+		e, inExpected := expectedQuery[name]
+		switch {
+		case !inExpected:
+			differences = append(differences, fmt.Sprintf("the query parameter %q is unexpected, with %s", name, formatQueryValues(a)))
+		case !inActual:
+			differences = append(differences, fmt.Sprintf("the query parameter %q is missing, expected %s", name, formatQueryValues(e)))
+		case !reflect.DeepEqual(a, e):
+			differences = append(differences, fmt.Sprintf("the query parameter %q is %s, expected %s", name, formatQueryValues(a), formatQueryValues(e)))
+		}
+	}
+
+	differ("fragment", actual.Fragment, expected.Fragment)
+	return differences
+}
+
+var defaultPorts = map[string]string{"http": "80", "https": "443", "ws": "80", "wss": "443"}
+
+func normalizedHost(u *url.URL) string {
+	host := strings.ToLower(u.Hostname())
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	if port := u.Port(); port != "" && port != defaultPorts[strings.ToLower(u.Scheme)] {
+		host += ":" + port
+	}
+	return host
+}
+
+func normalizedPath(u *url.URL) string {
+	if u.Path == "" && u.Host != "" {
+		return "/"
+	}
+	return u.Path
+}
+
+func formatQueryValues(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
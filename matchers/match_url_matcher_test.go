@@ -0,0 +1,76 @@
+package matchers_test
+
+import (
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("MatchURL", func() {
+	It("should match identical URLs", func() {
+		Expect("https://example.com/a/b?x=1#top").Should(MatchURL("https://example.com/a/b?x=1#top"))
+		Expect("https://example.com/a/b").ShouldNot(MatchURL("https://example.com/a/c"))
+	})
+
+	It("should compare URLs semantically", func() {
+		Expect("HTTPS://Example.COM:443/a%20b?y=2&x=1").Should(MatchURL("https://example.com/a b?x=1&y=2"))
+		Expect("http://example.com").Should(MatchURL("http://example.com:80/"))
+		Expect("http://example.com:8080").ShouldNot(MatchURL("http://example.com"))
+		Expect("https://example.com:80").ShouldNot(MatchURL("https://example.com"))
+		Expect("/search?q=caf%C3%A9").Should(MatchURL("/search?q=café"))
+		Expect("/search?q=a%2Bb").ShouldNot(MatchURL("/search?q=a+b"))
+		Expect("https://example.com/#a%20b").Should(MatchURL("https://example.com/#a b"))
+	})
+
+	It("should respect the order of a repeated query parameter's values", func() {
+		Expect("/?tag=a&tag=b&page=2").Should(MatchURL("/?page=2&tag=a&tag=b"))
+		Expect("/?tag=a&tag=b").ShouldNot(MatchURL("/?tag=b&tag=a"))
+	})
+
+	It("should accept url.URLs and *url.URLs", func() {
+		u, err := url.Parse("https://user@example.com/path")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(u).Should(MatchURL("https://user@example.com/path"))
+		Expect(*u).Should(MatchURL("https://user@example.com/path"))
+		Expect(u).ShouldNot(MatchURL("https://other@example.com/path"))
+	})
+
+	It("should list every component that differs", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect("http://example.com/a?x=1&y=2&z=3#top").Should(MatchURL("https://example.org/b?x=1&y=3&w=4"))
+		})
+		Expect(failures).Should(ConsistOf(HaveSuffix(`
+but:
+    the scheme is "http", expected "https"
+    the host is "example.com", expected "example.org"
+    the path is "/a", expected "/b"
+    the query parameter "w" is missing, expected ["4"]
+    the query parameter "y" is ["2"], expected ["3"]
+    the query parameter "z" is unexpected, with ["3"]
+    the fragment is "top", expected ""`)))
+	})
+
+	It("should build a negated failure message", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect("https://example.com").ShouldNot(MatchURL("https://example.com/"))
+		})
+		Expect(failures).Should(ConsistOf("Expected\n    <string>: https://example.com\nnot to match URL\n    <string>: https://example.com/"))
+	})
+
+	It("should error when given something that isn't a URL", func() {
+		_, err := (&MatchURLMatcher{Expected: "/"}).Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("MatchURL matcher expects a string, a url.URL or a *url.URL.")))
+
+		var nilURL *url.URL
+		_, err = (&MatchURLMatcher{Expected: "/"}).Match(nilURL)
+		Expect(err).Should(HaveOccurred())
+
+		_, err = (&MatchURLMatcher{Expected: "/"}).Match("http://[::1")
+		Expect(err).Should(MatchError(ContainSubstring("MatchURL matcher expects actual to be a valid URL.")))
+
+		_, err = (&MatchURLMatcher{Expected: "http://[::1"}).Match("/")
+		Expect(err).Should(MatchError(ContainSubstring("MatchURL matcher expects a valid URL.")))
+	})
+})
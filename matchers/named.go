@@ -0,0 +1,32 @@
+package matchers
+
+import (
+	"fmt"
+
+	"github.com/onsi/gomega/types"
+)
+
+type NamedMatcher struct {
+	Name    string
+	Matcher types.GomegaMatcher
+}
+
+func (m *NamedMatcher) Match(actual interface{}) (success bool, err error) {
+	success, err = m.Matcher.Match(actual)
+	if err != nil {
+		return false, fmt.Errorf("Clause %q errored: %w", m.Name, err)
+	}
+	return success, nil
+}
+
+func (m *NamedMatcher) FailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Clause %q failed to satisfy matcher.\n%s", m.Name, m.Matcher.FailureMessage(actual))
+}
+
+func (m *NamedMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("Clause %q satisfied matcher, but should not have.\n%s", m.Name, m.Matcher.NegatedFailureMessage(actual))
+}
+
+func (m *NamedMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	return types.MatchMayChangeInTheFuture(m.Matcher, actual)
+}
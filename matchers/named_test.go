@@ -0,0 +1,58 @@
+package matchers_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("NamedMatcher", func() {
+	It("succeeds and fails with its matcher", func() {
+		Expect(input).To(Named("is short", true1))
+		Expect(input).NotTo(Named("is shorter", false1))
+	})
+
+	It("names the clause in its failure messages", func() {
+		m := Named("has one character", false1)
+		Expect(m.Match(input)).To(BeFalse())
+		Expect(m.FailureMessage(input)).To(Equal("Clause \"has one character\" failed to satisfy matcher.\nExpected\n    <string>: hi\nto have length 1"))
+
+		m = Named("says hi", true2)
+		Expect(m.Match(input)).To(BeTrue())
+		Expect(m.NegatedFailureMessage(input)).To(Equal("Clause \"says hi\" satisfied matcher, but should not have.\nExpected\n    <string>: hi\nnot to equal\n    <string>: hi"))
+	})
+
+	It("reports which clause of a composite matcher failed", func() {
+		m := SatisfyAll(
+			Named("is short", true1),
+			Named("says hi", true2),
+			Named("hopes", false3),
+		)
+		Expect(m.Match(input)).To(BeFalse())
+		Expect(m.FailureMessage(input)).To(HavePrefix("Clause \"hopes\" failed to satisfy matcher.\n"))
+
+		m = And(true1, Named("outer", Or(Named("first", false1), Named("second", false2))))
+		Expect(m.Match(input)).To(BeFalse())
+		message := m.FailureMessage(input)
+		Expect(message).To(HavePrefix("Clause \"outer\" failed to satisfy matcher.\n"))
+		Expect(message).To(ContainSubstring("Clause \"first\" failed to satisfy matcher."))
+		Expect(message).To(ContainSubstring("Clause \"second\" failed to satisfy matcher."))
+	})
+
+	It("names the clause in errors", func() {
+		_, err := Named("is true", BeTrue()).Match(input)
+		Expect(err).To(MatchError(HavePrefix("Clause \"is true\" errored: Expected a boolean")))
+
+		success, err := Named("succeeds", Succeed()).Match(errors.New("boom"))
+		Expect(success).To(BeFalse())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("delegates MatchMayChangeInTheFuture", func() {
+		m := Named("never", Or())
+		Expect(m.Match(input)).To(BeFalse())
+		Expect(m.(*NamedMatcher).MatchMayChangeInTheFuture(input)).To(BeFalse())
+	})
+})
@@ -17,11 +17,16 @@ func (m *NotMatcher) Match(actual interface{}) (bool, error) {
 }
 
 func (m *NotMatcher) FailureMessage(actual interface{}) (message string) {
-	return m.Matcher.NegatedFailureMessage(actual) // works beautifully
+	return types.FailureMessageFor(m.Matcher, actual, false)
 }
 
 func (m *NotMatcher) NegatedFailureMessage(actual interface{}) (message string) {
-	return m.Matcher.FailureMessage(actual) // works beautifully
+	return types.FailureMessageFor(m.Matcher, actual, true)
+}
+
+// LegacyFailureMessage makes NotMatcher a types.LegacyFailureMessager
+func (m *NotMatcher) LegacyFailureMessage(actual interface{}, desiredMatch bool) string {
+	return types.LegacyFailureMessageFor(m.Matcher, actual, !desiredMatch)
 }
 
 func (m *NotMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
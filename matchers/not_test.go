@@ -61,4 +61,16 @@ var _ = Describe("NotMatcher", func() {
 			Expect(m.(*NotMatcher).MatchMayChangeInTheFuture(2)).To(BeTrue()) // defaults to true
 		})
 	})
+
+	Context("legacy failure messages", func() {
+		It("flips the polarity of its matcher's legacy message", func() {
+			m := Not(And(true1, true2))
+			Expect(m.Match(input)).To(BeFalse())
+			Expect(m.(*NotMatcher).LegacyFailureMessage(input, true)).To(HavePrefix("Expected\n    <string>: hi\nTo not satisfy all of these matchers: ["))
+
+			m = Not(false1)
+			Expect(m.Match(input)).To(BeTrue())
+			Expect(m.(*NotMatcher).LegacyFailureMessage(input, false)).To(Equal("Expected\n    <string>: hi\nto have length 1"))
+		})
+	})
 })
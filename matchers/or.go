@@ -2,6 +2,7 @@ package matchers
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/onsi/gomega/format"
 	"github.com/onsi/gomega/types"
@@ -12,17 +13,18 @@ type OrMatcher struct {
 
 	// state
 	firstSuccessfulMatcher types.GomegaMatcher
+	firstSuccessfulIndex   int
 }
 
 func (m *OrMatcher) Match(actual interface{}) (success bool, err error) {
 	m.firstSuccessfulMatcher = nil
-	for _, matcher := range m.Matchers {
+	for i, matcher := range m.Matchers {
 		success, err := matcher.Match(actual)
 		if err != nil {
 			return false, err
 		}
 		if success {
-			m.firstSuccessfulMatcher = matcher
+			m.firstSuccessfulMatcher, m.firstSuccessfulIndex = matcher, i
 			return true, nil
 		}
 	}
@@ -30,12 +32,77 @@ func (m *OrMatcher) Match(actual interface{}) (success bool, err error) {
 }
 
 func (m *OrMatcher) FailureMessage(actual interface{}) (message string) {
-	// not the most beautiful list of matchers, but not bad either...
-	return format.Message(actual, fmt.Sprintf("To satisfy at least one of these matchers: %s", m.Matchers))
+	return anyOfFailureMessage("Or", m.Matchers, actual, true)
 }
 
 func (m *OrMatcher) NegatedFailureMessage(actual interface{}) (message string) {
-	return m.firstSuccessfulMatcher.NegatedFailureMessage(actual)
+	if len(m.Matchers) == 1 {
+		return types.FailureMessageFor(m.firstSuccessfulMatcher, actual, false)
+	}
+	// Or stops at the first matcher that matches, so those before it did not and those after it were not tried
+	outcomes := make([]branchOutcome, len(m.Matchers))
+	for i := range outcomes {
+		if i < m.firstSuccessfulIndex {
+			outcomes[i] = branchDidNotMatch
+		} else if i == m.firstSuccessfulIndex {
+			outcomes[i] = branchMatched
+		}
+	}
+	summary := fmt.Sprintf("Expected none of Or's %d matchers to match, but matcher %d did:", len(m.Matchers), m.firstSuccessfulIndex+1)
+	return branchesFailureMessage(summary, m.Matchers, outcomes, actual)
+}
+
+// LegacyFailureMessage makes OrMatcher a types.LegacyFailureMessager
+func (m *OrMatcher) LegacyFailureMessage(actual interface{}, desiredMatch bool) string {
+	if desiredMatch {
+		return format.Message(actual, fmt.Sprintf("To satisfy at least one of these matchers: %s", m.Matchers))
+	}
+	return types.LegacyFailureMessageFor(m.firstSuccessfulMatcher, actual, false)
+}
+
+// anyOfFailureMessage explains that none of matchers agreed with desiredMatch, listing each matcher's failure
+// message.  matcherName is the composite matcher reporting the failure.
+func anyOfFailureMessage(matcherName string, matchers []types.GomegaMatcher, actual interface{}, desiredMatch bool) string {
+	if len(matchers) == 0 {
+		if desiredMatch {
+			return format.Message(actual, fmt.Sprintf("to satisfy at least one matcher, but %s was passed none", matcherName))
+		}
+		return format.Message(actual, fmt.Sprintf("to fail at least one matcher, but %s was passed none", matcherName))
+	}
+	if len(matchers) == 1 {
+		return types.FailureMessageFor(matchers[0], actual, desiredMatch)
+	}
+	messages := make([]string, len(matchers))
+	for i, matcher := range matchers {
+		messages[i] = format.IndentString(types.FailureMessageFor(matcher, actual, desiredMatch), 1)
+	}
+	return fmt.Sprintf("Expected at least one of the following, but none held:\n%s", strings.Join(messages, "\n  or\n"))
+}
+
+// branchOutcome is what became of one of a composite matcher's matchers the last time it matched
+type branchOutcome int
+
+const (
+	branchNotTried branchOutcome = iota
+	branchMatched
+	branchDidNotMatch
+)
+
+// branchesFailureMessage explains why a composite matcher matched when it should not have by enumerating the outcome of
+// each of its matchers, along with the negated failure message of every matcher that matched
+func branchesFailureMessage(summary string, matchers []types.GomegaMatcher, outcomes []branchOutcome, actual interface{}) string {
+	lines := []string{summary}
+	for i, matcher := range matchers {
+		switch outcomes[i] {
+		case branchMatched:
+			lines = append(lines, fmt.Sprintf("  matcher %d matched:\n%s", i+1, format.IndentString(types.FailureMessageFor(matcher, actual, false), 1)))
+		case branchDidNotMatch:
+			lines = append(lines, fmt.Sprintf("  matcher %d did not match", i+1))
+		default:
+			lines = append(lines, fmt.Sprintf("  matcher %d was not tried", i+1))
+		}
+	}
+	return strings.Join(lines, "\n")
 }
 
 func (m *OrMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
@@ -40,18 +40,65 @@ var _ = Describe("OrMatcher", func() {
 	Context("failure messages", func() {
 		When("match fails", func() {
 			It("gives a descriptive message", func() {
-				verifyFailureMessage(Or(false1, false2), input,
-					"To satisfy at least one of these matchers: [%!s(*matchers.HaveLenMatcher=&{1}) %!s(*matchers.EqualMatcher=&{hip})]")
+				m := Or(false1, false2)
+				Expect(m.Match(input)).To(BeFalse())
+				Expect(m.FailureMessage(input)).To(Equal("Expected at least one of the following, but none held:\n" +
+					"    Expected\n        <string>: hi\n    to have length 1\n" +
+					"  or\n" +
+					"    Expected\n        <string>: hi\n    to equal\n        <string>: hip"))
+			})
+
+			It("explains that Or was passed no matchers", func() {
+				verifyFailureMessage(Or(), input, "to satisfy at least one matcher, but Or was passed none")
 			})
 		})
 
 		When("match succeeds, but expected it to fail", func() {
 			It("gives a descriptive message", func() {
-				verifyFailureMessage(Not(Or(true1, true2)), input, `not to have length 2`)
+				m := Not(Or(false1, true1, true2))
+				Expect(m.Match(input)).To(BeFalse())
+				Expect(m.FailureMessage(input)).To(Equal("Expected none of Or's 3 matchers to match, but matcher 2 did:\n" +
+					"  matcher 1 did not match\n" +
+					"  matcher 2 matched:\n    Expected\n        <string>: hi\n    not to have length 2\n" +
+					"  matcher 3 was not tried"))
+
+				verifyFailureMessage(Not(Or(true1)), input, `not to have length 2`)
+			})
+		})
+
+		When("negated more than once", func() {
+			It("keeps the polarity of the message", func() {
+				verifyFailureMessage(Not(Not(Or(false1))), input, "to have length 1")
+				m := Not(Not(Not(Or(false1, true2))))
+				Expect(m.Match(input)).To(BeFalse())
+				Expect(m.FailureMessage(input)).To(Equal("Expected none of Or's 2 matchers to match, but matcher 2 did:\n" +
+					"  matcher 1 did not match\n" +
+					"  matcher 2 matched:\n    Expected\n        <string>: hi\n    not to equal\n        <string>: hi"))
+
+				m = Or(Not(true1), Not(Not(false2)))
+				Expect(m.Match(input)).To(BeFalse())
+				Expect(m.FailureMessage(input)).To(Equal("Expected at least one of the following, but none held:\n" +
+					"    Expected\n        <string>: hi\n    not to have length 2\n" +
+					"  or\n" +
+					"    Expected\n        <string>: hi\n    to equal\n        <string>: hip"))
 			})
 		})
 	})
 
+	Context("legacy failure messages", func() {
+		It("describes all of its matchers when it should have succeeded", func() {
+			m := Or(false1, false2)
+			Expect(m.Match(input)).To(BeFalse())
+			Expect(m.(*OrMatcher).LegacyFailureMessage(input, true)).To(HavePrefix("Expected\n    <string>: hi\nTo satisfy at least one of these matchers: [%!s(*matchers.HaveLenMatcher="))
+		})
+
+		It("reports the legacy negated message of the first matcher that succeeded", func() {
+			m := Or(false1, true1, true2)
+			Expect(m.Match(input)).To(BeTrue())
+			Expect(m.(*OrMatcher).LegacyFailureMessage(input, false)).To(Equal("Expected\n    <string>: hi\nnot to have length 2"))
+		})
+	})
+
 	Context("MatchMayChangeInTheFuture", func() {
 		Context("Match returned false", func() {
 			It("returns true if any of the matchers could change", func() {
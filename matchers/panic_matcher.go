@@ -3,13 +3,21 @@ package matchers
 import (
 	"fmt"
 	"reflect"
+	"runtime/debug"
+	"strings"
 
 	"github.com/onsi/gomega/format"
 )
 
 type PanicMatcher struct {
 	Expected interface{}
-	object   interface{}
+
+	// CaptureStack, when true, includes the stack trace of the panicking goroutine in failure messages
+	CaptureStack bool
+
+	// state
+	object interface{}
+	stack  []byte
 }
 
 func (matcher *PanicMatcher) Match(actual interface{}) (success bool, err error) {
@@ -25,16 +33,27 @@ func (matcher *PanicMatcher) Match(actual interface{}) (success bool, err error)
 		return false, fmt.Errorf("PanicMatcher expects a function with no arguments and no return value.  Got:\n%s", format.Object(actual, 1))
 	}
 
+	matcher.object, matcher.stack = nil, nil
 	success = false
 	defer func() {
 		if e := recover(); e != nil {
 			matcher.object = e
+			if matcher.CaptureStack {
+				// deferred functions run on the panicking goroutine before it unwinds, so its stack still
+				// leads to the call to panic
+				matcher.stack = debug.Stack()
+			}
 
 			if matcher.Expected == nil {
 				success = true
 				return
 			}
 
+			if interfaceType, ok := matcher.expectedInterface(); ok {
+				success = reflect.TypeOf(e).Implements(interfaceType)
+				return
+			}
+
 			valueMatcher, valueIsMatcher := matcher.Expected.(omegaMatcher)
 			if !valueIsMatcher {
 				valueMatcher = &EqualMatcher{Expected: matcher.Expected}
@@ -52,18 +71,33 @@ func (matcher *PanicMatcher) Match(actual interface{}) (success bool, err error)
 	return
 }
 
+// expectedInterface returns the interface type when Expected is a pointer to an interface - such as
+// new(runtime.Error) - in which case any panic value that implements the interface matches
+func (matcher *PanicMatcher) expectedInterface() (reflect.Type, bool) {
+	t := reflect.TypeOf(matcher.Expected)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Interface {
+		return nil, false
+	}
+	return t.Elem(), true
+}
+
 func (matcher *PanicMatcher) FailureMessage(actual interface{}) (message string) {
 	if matcher.Expected == nil {
 		// We wanted any panic to occur, but none did.
 		return format.Message(actual, "to panic")
 	}
 
+	interfaceType, expectsInterface := matcher.expectedInterface()
+
 	if matcher.object == nil {
 		// We wanted a panic with a specific value to occur, but none did.
 		switch matcher.Expected.(type) {
 		case omegaMatcher:
 			return format.Message(actual, "to panic with a value matching", matcher.Expected)
 		default:
+			if expectsInterface {
+				return format.Message(actual, fmt.Sprintf("to panic with a value implementing %s", interfaceType))
+			}
 			return format.Message(actual, "to panic with", matcher.Expected)
 		}
 	}
@@ -76,16 +110,26 @@ func (matcher *PanicMatcher) FailureMessage(actual interface{}) (message string)
 			fmt.Sprintf(
 				"to panic with a value matching\n%s\nbut panicked with\n%s",
 				format.Object(matcher.Expected, 1),
-				format.Object(matcher.object, 1),
+				matcher.formattedPanic(),
 			),
 		)
 	default:
+		if expectsInterface {
+			return format.Message(
+				actual,
+				fmt.Sprintf(
+					"to panic with a value implementing %s\nbut panicked with\n%s",
+					interfaceType,
+					matcher.formattedPanic(),
+				),
+			)
+		}
 		return format.Message(
 			actual,
 			fmt.Sprintf(
 				"to panic with\n%s\nbut panicked with\n%s",
 				format.Object(matcher.Expected, 1),
-				format.Object(matcher.object, 1),
+				matcher.formattedPanic(),
 			),
 		)
 	}
@@ -94,7 +138,7 @@ func (matcher *PanicMatcher) FailureMessage(actual interface{}) (message string)
 func (matcher *PanicMatcher) NegatedFailureMessage(actual interface{}) (message string) {
 	if matcher.Expected == nil {
 		// We didn't want any panic to occur, but one did.
-		return format.Message(actual, fmt.Sprintf("not to panic, but panicked with\n%s", format.Object(matcher.object, 1)))
+		return format.Message(actual, fmt.Sprintf("not to panic, but panicked with\n%s", matcher.formattedPanic()))
 	}
 
 	// We wanted a to ensure a panic with a specific value did not occur, but it did.
@@ -105,10 +149,40 @@ func (matcher *PanicMatcher) NegatedFailureMessage(actual interface{}) (message
 			fmt.Sprintf(
 				"not to panic with a value matching\n%s\nbut panicked with\n%s",
 				format.Object(matcher.Expected, 1),
-				format.Object(matcher.object, 1),
+				matcher.formattedPanic(),
 			),
 		)
 	default:
+		if interfaceType, ok := matcher.expectedInterface(); ok {
+			return format.Message(
+				actual,
+				fmt.Sprintf(
+					"not to panic with a value implementing %s\nbut panicked with\n%s",
+					interfaceType,
+					matcher.formattedPanic(),
+				),
+			)
+		}
+		if matcher.stack != nil {
+			return format.Message(actual, "not to panic with", matcher.Expected) + matcher.formattedStack()
+		}
 		return format.Message(actual, "not to panic with", matcher.Expected)
 	}
 }
+
+// formattedPanic renders the value the function panicked with - along with the message of panics with errors, such
+// as the runtime.Errors raised by nil map writes, whose fields say little - and, if captured, the panic's stack
+func (matcher *PanicMatcher) formattedPanic() string {
+	formatted := format.Object(matcher.object, 1)
+	if err, ok := matcher.object.(error); ok {
+		formatted += "\n" + format.IndentString(err.Error(), 1)
+	}
+	return formatted + matcher.formattedStack()
+}
+
+func (matcher *PanicMatcher) formattedStack() string {
+	if matcher.stack == nil {
+		return ""
+	}
+	return "\nPanic stack trace:\n" + format.IndentString(strings.TrimRight(string(matcher.stack), "\n"), 1)
+}
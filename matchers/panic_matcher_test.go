@@ -1,6 +1,8 @@
 package matchers_test
 
 import (
+	"runtime"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/matchers"
@@ -162,3 +164,68 @@ var _ = Describe("PanicWith", func() {
 		})
 	})
 })
+
+var _ = Describe("PanicWith and runtime errors", func() {
+	writeToNilMap := func() {
+		var m map[string]int
+		m["a"] = 1
+	}
+
+	It("matches panics by the interface they implement", func() {
+		Expect(writeToNilMap).To(PanicWith(new(runtime.Error)))
+		Expect(func() { panic("ack!") }).NotTo(PanicWith(new(runtime.Error)))
+		Expect(func() {}).NotTo(PanicWith(new(runtime.Error)))
+	})
+
+	It("reports the expected interface", func() {
+		failuresMessages := InterceptGomegaFailures(func() {
+			Expect(func() { panic("ack!") }).To(PanicWith(new(runtime.Error)))
+			Expect(func() {}).To(PanicWith(new(runtime.Error)))
+		})
+		Expect(failuresMessages).To(ConsistOf(
+			MatchRegexp("to panic with a value implementing runtime.Error\nbut panicked with\n\\s+<string>: ack!$"),
+			MatchRegexp("to panic with a value implementing runtime.Error$"),
+		))
+	})
+
+	It("includes the message of error panic values", func() {
+		failuresMessages := InterceptGomegaFailures(func() {
+			Expect(writeToNilMap).NotTo(PanicWith(new(runtime.Error)))
+		})
+		Expect(failuresMessages).To(ConsistOf(
+			MatchRegexp("not to panic with a value implementing runtime.Error\nbut panicked with\n.+\n    assignment to entry in nil map$"),
+		))
+	})
+})
+
+var _ = Describe("PanicWithStack", func() {
+	It("matches like PanicWith", func() {
+		Expect(func() { panic("ack!") }).To(PanicWithStack("ack!"))
+		Expect(func() { panic("ack!") }).To(PanicWithStack(nil))
+		Expect(func() {}).NotTo(PanicWithStack(nil))
+	})
+
+	It("includes the stack of the panicking goroutine in failure messages", func() {
+		failuresMessages := InterceptGomegaFailures(func() {
+			Expect(panicDeepInside).NotTo(PanicWithStack(nil))
+			Expect(panicDeepInside).To(PanicWithStack("something else"))
+			Expect(panicDeepInside).NotTo(PanicWithStack("kaboom"))
+		})
+		Expect(failuresMessages).To(HaveLen(3))
+		for _, message := range failuresMessages {
+			Expect(message).To(ContainSubstring("Panic stack trace:\n"))
+			Expect(message).To(ContainSubstring("matchers_test.panicDeepInside"))
+		}
+	})
+
+	It("does not capture the stack with PanicWith", func() {
+		failuresMessages := InterceptGomegaFailures(func() {
+			Expect(panicDeepInside).NotTo(Panic())
+		})
+		Expect(failuresMessages).To(ConsistOf(Not(ContainSubstring("Panic stack trace"))))
+	})
+})
+
+func panicDeepInside() {
+	panic("kaboom")
+}
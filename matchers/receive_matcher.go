@@ -7,10 +7,16 @@ import (
 	"reflect"
 
 	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
 )
 
 type ReceiveMatcher struct {
-	Arg           interface{}
+	Arg interface{}
+
+	// Matcher, when set alongside a pointer Arg, is applied to the received value before it is assigned to Arg - or,
+	// when Arg points to a slice that received values are appended to, to the slice
+	Matcher types.GomegaMatcher
+
 	receivedValue reflect.Value
 	channelClosed bool
 }
@@ -39,6 +45,13 @@ func (matcher *ReceiveMatcher) Match(actual interface{}) (success bool, err erro
 			}
 		}
 	}
+	if matcher.Matcher != nil && (matcher.Arg == nil || hasSubMatcher) {
+		return false, fmt.Errorf("ReceiveMatcher can only be passed a second matcher after a pointer.  Got:\n%s", format.Object(matcher.Arg, 1))
+	}
+
+	if matcher.drainsInto(channelType) {
+		return matcher.drain(channelValue)
+	}
 
 	winnerIndex, value, open := reflect.Select([]reflect.SelectCase{
 		{Dir: reflect.SelectRecv, Chan: channelValue},
@@ -66,6 +79,13 @@ func (matcher *ReceiveMatcher) Match(actual interface{}) (success bool, err erro
 	}
 
 	if didReceive {
+		if matcher.Matcher != nil {
+			matcher.receivedValue = value
+			success, err := matcher.Matcher.Match(value.Interface())
+			if !success || err != nil {
+				return false, err
+			}
+		}
 		if matcher.Arg != nil {
 			outValue := reflect.ValueOf(matcher.Arg)
 
@@ -87,7 +107,49 @@ func (matcher *ReceiveMatcher) Match(actual interface{}) (success bool, err erro
 	return false, nil
 }
 
+// drainsInto tells whether Arg points to a slice that values received from the channel are appended to, rather than
+// assigned to
+func (matcher *ReceiveMatcher) drainsInto(channelType reflect.Type) bool {
+	argType := reflect.TypeOf(matcher.Arg)
+	if argType == nil || argType.Kind() != reflect.Ptr || argType.Elem().Kind() != reflect.Slice {
+		return false
+	}
+	elemType := channelType.Elem()
+	return !elemType.AssignableTo(argType.Elem()) && elemType.AssignableTo(argType.Elem().Elem())
+}
+
+// drain appends every value that can be received without blocking to the slice Arg points to and - if given a
+// Matcher - matches the slice
+func (matcher *ReceiveMatcher) drain(channelValue reflect.Value) (bool, error) {
+	collected := reflect.ValueOf(matcher.Arg).Elem()
+	didReceive := false
+	for {
+		winnerIndex, value, open := reflect.Select([]reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: channelValue},
+			{Dir: reflect.SelectDefault},
+		})
+		if winnerIndex != 0 {
+			break
+		}
+		if !open {
+			matcher.channelClosed = true
+			break
+		}
+		collected.Set(reflect.Append(collected, value))
+		didReceive = true
+	}
+	matcher.receivedValue = collected
+
+	if matcher.Matcher != nil {
+		return matcher.Matcher.Match(collected.Interface())
+	}
+	return didReceive, nil
+}
+
 func (matcher *ReceiveMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.Matcher != nil {
+		return matcher.pointerMatcherFailureMessage(true)
+	}
 	subMatcher, hasSubMatcher := (matcher.Arg).(omegaMatcher)
 
 	closedAddendum := ""
@@ -105,6 +167,9 @@ func (matcher *ReceiveMatcher) FailureMessage(actual interface{}) (message strin
 }
 
 func (matcher *ReceiveMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if matcher.Matcher != nil {
+		return matcher.pointerMatcherFailureMessage(false)
+	}
 	subMatcher, hasSubMatcher := (matcher.Arg).(omegaMatcher)
 
 	closedAddendum := ""
@@ -121,6 +186,13 @@ func (matcher *ReceiveMatcher) NegatedFailureMessage(actual interface{}) (messag
 	return format.Message(actual, "not to receive anything."+closedAddendum)
 }
 
+func (matcher *ReceiveMatcher) pointerMatcherFailureMessage(desiredMatch bool) string {
+	if !matcher.receivedValue.IsValid() {
+		return "When passed a matcher, ReceiveMatcher's channel *must* receive something."
+	}
+	return types.FailureMessageFor(matcher.Matcher, matcher.receivedValue.Interface(), desiredMatch)
+}
+
 func (matcher *ReceiveMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
 	if !isChan(actual) {
 		return false
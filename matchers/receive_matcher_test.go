@@ -301,4 +301,86 @@ var _ = Describe("ReceiveMatcher", func() {
 			Expect(failures).Should(HaveLen(1))
 		})
 	})
+
+	Describe("when passed a pointer and a matcher", func() {
+		It("should only assign values that satisfy the matcher", func() {
+			c := make(chan int, 2)
+			c <- 1
+			c <- 2
+
+			var received int
+			Expect(c).ShouldNot(Receive(&received, BeNumerically(">", 1)))
+			Expect(received).Should(BeZero())
+			Expect(c).Should(Receive(&received, BeNumerically(">", 1)))
+			Expect(received).Should(Equal(2))
+		})
+
+		It("should report the matcher's failure message", func() {
+			c := make(chan int, 1)
+			c <- 1
+			var received int
+			failures := InterceptGomegaFailures(func() {
+				Expect(c).Should(Receive(&received, Equal(2)))
+				Expect(c).Should(Receive(&received, Equal(2)))
+			})
+			Expect(failures).Should(HaveLen(2))
+			Expect(failures[0]).Should(Equal("Expected\n    <int>: 1\nto equal\n    <int>: 2"))
+		})
+
+		It("should error when the first argument is a matcher", func() {
+			_, err := (&ReceiveMatcher{Arg: Equal(1), Matcher: Equal(1)}).Match(make(chan int))
+			Expect(err).Should(MatchError(ContainSubstring("ReceiveMatcher can only be passed a second matcher after a pointer")))
+		})
+
+		It("should panic when passed more than two arguments", func() {
+			var received int
+			Expect(func() { Receive(&received, Equal(1), Equal(2)) }).Should(Panic())
+			Expect(func() { Receive(&received, 3) }).Should(Panic())
+		})
+	})
+
+	Describe("when passed a pointer to a slice", func() {
+		It("should drain the channel into the slice", func() {
+			c := make(chan string, 3)
+			c <- "a"
+			c <- "b"
+
+			var collected []string
+			Expect(c).Should(Receive(&collected))
+			Expect(collected).Should(Equal([]string{"a", "b"}))
+			Expect(c).ShouldNot(Receive(&collected))
+			Expect(collected).Should(Equal([]string{"a", "b"}))
+		})
+
+		It("should accumulate values across Eventually's attempts and match the slice", func() {
+			c := make(chan int)
+			go func() {
+				for i := 0; i < 3; i++ {
+					c <- i
+				}
+			}()
+
+			var collected []int
+			Eventually(c).Should(Receive(&collected, HaveLen(3)))
+			Expect(collected).Should(Equal([]int{0, 1, 2}))
+		})
+
+		It("should still assign channels of slices", func() {
+			c := make(chan []int, 1)
+			c <- []int{1, 2}
+			var received []int
+			Expect(c).Should(Receive(&received))
+			Expect(received).Should(Equal([]int{1, 2}))
+		})
+
+		It("should report the slice when the matcher fails", func() {
+			c := make(chan int, 1)
+			c <- 7
+			var collected []int
+			failures := InterceptGomegaFailures(func() {
+				Expect(c).Should(Receive(&collected, HaveLen(3)))
+			})
+			Expect(failures).Should(ConsistOf(ContainSubstring("<[]int | len:1, cap:1>: [7]\nto have length 3")))
+		})
+	})
 })
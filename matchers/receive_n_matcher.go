@@ -0,0 +1,96 @@
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+type ReceiveNMatcher struct {
+	Count   int
+	Matcher types.GomegaMatcher
+
+	// state
+	channel       reflect.Value
+	collected     reflect.Value
+	channelClosed bool
+}
+
+func (matcher *ReceiveNMatcher) Match(actual interface{}) (success bool, err error) {
+	if !isChan(actual) {
+		return false, fmt.Errorf("ReceiveN matcher expects a channel.  Got:\n%s", format.Object(actual, 1))
+	}
+	channelType := reflect.TypeOf(actual)
+	channelValue := reflect.ValueOf(actual)
+	if channelType.ChanDir() == reflect.SendDir {
+		return false, fmt.Errorf("ReceiveN matcher cannot be passed a send-only channel.  Got:\n%s", format.Object(actual, 1))
+	}
+	if matcher.Count < 0 {
+		return false, fmt.Errorf("ReceiveN matcher expects a non-negative count.  Got:\n%s", format.Object(matcher.Count, 1))
+	}
+
+	// values received by earlier polls of the same channel count towards Count
+	if !matcher.collected.IsValid() || matcher.channel.Pointer() != channelValue.Pointer() {
+		matcher.channel = channelValue
+		matcher.collected = reflect.MakeSlice(reflect.SliceOf(channelType.Elem()), 0, matcher.Count)
+		matcher.channelClosed = false
+	}
+
+	for matcher.collected.Len() < matcher.Count && !matcher.channelClosed {
+		winnerIndex, value, open := reflect.Select([]reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: channelValue},
+			{Dir: reflect.SelectDefault},
+		})
+		if winnerIndex != 0 {
+			break
+		}
+		if !open {
+			matcher.channelClosed = true
+			break
+		}
+		matcher.collected = reflect.Append(matcher.collected, value)
+	}
+
+	if matcher.collected.Len() < matcher.Count {
+		return false, nil
+	}
+	if matcher.Matcher == nil {
+		return true, nil
+	}
+	return matcher.Matcher.Match(matcher.collected.Interface())
+}
+
+func (matcher *ReceiveNMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.collected.Len() < matcher.Count {
+		closedAddendum := ""
+		if matcher.channelClosed {
+			closedAddendum = " The channel is closed."
+		}
+		return format.Message(actual, fmt.Sprintf("to receive %d %s, but received %d:\n%s%s", matcher.Count, pluralize("value", matcher.Count), matcher.collected.Len(), format.Object(matcher.collected.Interface(), 1), closedAddendum))
+	}
+	return matcher.Matcher.FailureMessage(matcher.collected.Interface())
+}
+
+func (matcher *ReceiveNMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if matcher.Matcher == nil {
+		return format.Message(actual, fmt.Sprintf("not to receive %d %s, but received\n%s", matcher.Count, pluralize("value", matcher.Count), format.Object(matcher.collected.Interface(), 1)))
+	}
+	return matcher.Matcher.NegatedFailureMessage(matcher.collected.Interface())
+}
+
+func (matcher *ReceiveNMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	if !isChan(actual) {
+		return false
+	}
+	if !matcher.collected.IsValid() || matcher.channel.Pointer() != reflect.ValueOf(actual).Pointer() {
+		// Eventually asks before the first Match
+		return true
+	}
+	if matcher.collected.Len() < matcher.Count {
+		return !matcher.channelClosed
+	}
+	// all the values are in, so only the matcher can change its mind
+	return matcher.Matcher != nil && types.MatchMayChangeInTheFuture(matcher.Matcher, matcher.collected.Interface())
+}
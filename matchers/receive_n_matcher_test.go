@@ -0,0 +1,95 @@
+package matchers_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+var _ = Describe("ReceiveN", func() {
+	It("should succeed once n values have been received", func() {
+		c := make(chan int, 3)
+		c <- 1
+		c <- 2
+		m := ReceiveN(3)
+		Expect(c).ShouldNot(m)
+		c <- 3
+		Expect(c).Should(m)
+	})
+
+	It("should accumulate values across Eventually's attempts", func() {
+		c := make(chan string)
+		go func() {
+			for _, s := range []string{"a", "b", "c", "d"} {
+				c <- s
+			}
+		}()
+		Eventually(c).Should(ReceiveN(3, Equal([]string{"a", "b", "c"})))
+		Eventually(c).Should(Receive(Equal("d")))
+	})
+
+	It("should apply the matcher to the received values", func() {
+		c := make(chan int, 2)
+		c <- 1
+		c <- 2
+		failures := InterceptGomegaFailures(func() {
+			Expect(c).Should(ReceiveN(2, ConsistOf(1, 3)))
+		})
+		Expect(failures).Should(ConsistOf(HavePrefix("Expected\n    <[]int | len:2, cap:2>: [1, 2]\nto consist of")))
+	})
+
+	It("should report the values received when too few arrive", func() {
+		c := make(chan int, 1)
+		c <- 1
+		failures := InterceptGomegaFailures(func() {
+			Expect(c).Should(ReceiveN(2))
+		})
+		Expect(failures).Should(ConsistOf(HaveSuffix("to receive 2 values, but received 1:\n    <[]int | len:1, cap:2>: [1]")))
+	})
+
+	It("should report the values received when it should not have", func() {
+		c := make(chan int, 1)
+		c <- 1
+		failures := InterceptGomegaFailures(func() {
+			Expect(c).ShouldNot(ReceiveN(1))
+		})
+		Expect(failures).Should(ConsistOf(HaveSuffix("not to receive 1 value, but received\n    <[]int | len:1, cap:1>: [1]")))
+	})
+
+	It("should bail early when the channel is closed", func() {
+		c := make(chan int, 1)
+		c <- 1
+		close(c)
+
+		t := time.Now()
+		failures := InterceptGomegaFailures(func() {
+			Eventually(c).Should(ReceiveN(2))
+		})
+		Expect(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+		Expect(failures).Should(ConsistOf(HaveSuffix("[1] The channel is closed.")))
+	})
+
+	It("should stop receiving once it has n values", func() {
+		c := make(chan int, 3)
+		c <- 1
+		c <- 2
+		c <- 3
+		Expect(c).Should(ReceiveN(2, Equal([]int{1, 2})))
+		Expect(c).Should(Receive(Equal(3)))
+	})
+
+	It("should error when passed something that isn't a readable channel", func() {
+		success, err := (&ReceiveNMatcher{Count: 1}).Match(3)
+		Expect(success).Should(BeFalse())
+		Expect(err).Should(HaveOccurred())
+
+		var writerChannel chan<- int = make(chan int)
+		_, err = (&ReceiveNMatcher{Count: 1}).Match(writerChannel)
+		Expect(err).Should(HaveOccurred())
+
+		_, err = (&ReceiveNMatcher{Count: -1}).Match(make(chan int))
+		Expect(err).Should(MatchError(ContainSubstring("ReceiveN matcher expects a non-negative count")))
+	})
+})
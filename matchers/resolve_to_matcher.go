@@ -0,0 +1,68 @@
+package matchers
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/onsi/gomega/format"
+)
+
+// HostResolver looks up the addresses of a host - *net.Resolver is one
+type HostResolver interface {
+	LookupHost(ctx context.Context, host string) (addrs []string, err error)
+}
+
+type ResolveToMatcher struct {
+	Expected interface{}
+
+	// Resolver looks up the host; net.DefaultResolver is used if it is nil
+	Resolver HostResolver
+
+	// state
+	addresses []string
+	lookupErr error
+}
+
+func (matcher *ResolveToMatcher) Match(actual interface{}) (success bool, err error) {
+	host, ok := toString(actual)
+	if !ok {
+		return false, fmt.Errorf("ResolveTo matcher expects a host name.  Got:\n%s", format.Object(actual, 1))
+	}
+	resolver := matcher.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	// a host that does not resolve does not resolve to anything - so Eventually keeps polling, and ShouldNot passes
+	matcher.addresses, matcher.lookupErr = resolver.LookupHost(context.Background(), host)
+	addressMatcher := matcher.addressMatcher()
+	for _, address := range matcher.addresses {
+		success, err := addressMatcher.Match(address)
+		if err != nil {
+			return false, err
+		}
+		if success {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (matcher *ResolveToMatcher) addressMatcher() omegaMatcher {
+	if m, ok := matcher.Expected.(omegaMatcher); ok {
+		return m
+	}
+	return &EqualMatcher{Expected: matcher.Expected}
+}
+
+func (matcher *ResolveToMatcher) FailureMessage(actual interface{}) (message string) {
+	if matcher.lookupErr != nil {
+		return format.Message(actual, fmt.Sprintf("to resolve to an address matching\n%s\nbut looking it up failed with:\n%s", format.Object(matcher.Expected, 1), format.IndentString(matcher.lookupErr.Error(), 1)))
+	}
+	return format.Message(actual, fmt.Sprintf("to resolve to an address matching\n%s\nbut it resolved to\n%s", format.Object(matcher.Expected, 1), format.Object(matcher.addresses, 1)))
+}
+
+func (matcher *ResolveToMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, fmt.Sprintf("not to resolve to an address matching\n%s\nbut it resolved to\n%s", format.Object(matcher.Expected, 1), format.Object(matcher.addresses, 1)))
+}
@@ -0,0 +1,56 @@
+package matchers_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+)
+
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, errors.New("lookup " + host + ": no such host")
+	}
+	return addrs, nil
+}
+
+var _ = Describe("ResolveTo", func() {
+	resolver := fakeResolver{"api.example.com": {"10.1.2.3", "fd00::1"}}
+
+	It("should succeed if any address matches", func() {
+		Expect("api.example.com").Should(&ResolveToMatcher{Expected: "fd00::1", Resolver: resolver})
+		Expect("api.example.com").Should(&ResolveToMatcher{Expected: BeInCIDR("10.0.0.0/8"), Resolver: resolver})
+		Expect("api.example.com").ShouldNot(&ResolveToMatcher{Expected: BeInCIDR("192.168.0.0/16"), Resolver: resolver})
+	})
+
+	It("should not resolve hosts that fail to resolve to anything", func() {
+		Expect("missing.example.com").ShouldNot(&ResolveToMatcher{Expected: Not(BeEmpty()), Resolver: resolver})
+	})
+
+	It("should use the default resolver", func() {
+		Expect("127.0.0.1").Should(ResolveTo("127.0.0.1"))
+	})
+
+	It("should error when actual is not a host name", func() {
+		_, err := ResolveTo("127.0.0.1").Match(3)
+		Expect(err).Should(MatchError(ContainSubstring("ResolveTo matcher expects a host name.")))
+	})
+
+	It("should build failure messages", func() {
+		failures := InterceptGomegaFailures(func() {
+			Expect("api.example.com").Should(&ResolveToMatcher{Expected: "10.9.9.9", Resolver: resolver})
+			Expect("missing.example.com").Should(&ResolveToMatcher{Expected: "10.9.9.9", Resolver: resolver})
+			Expect("api.example.com").ShouldNot(&ResolveToMatcher{Expected: "10.1.2.3", Resolver: resolver})
+		})
+		Expect(failures).Should(Equal([]string{
+			"Expected\n    <string>: api.example.com\nto resolve to an address matching\n    <string>: 10.9.9.9\nbut it resolved to\n    <[]string | len:2, cap:2>: [\"10.1.2.3\", \"fd00::1\"]",
+			"Expected\n    <string>: missing.example.com\nto resolve to an address matching\n    <string>: 10.9.9.9\nbut looking it up failed with:\n    lookup missing.example.com: no such host",
+			"Expected\n    <string>: api.example.com\nnot to resolve to an address matching\n    <string>: 10.1.2.3\nbut it resolved to\n    <[]string | len:2, cap:2>: [\"10.1.2.3\", \"fd00::1\"]",
+		}))
+	})
+})
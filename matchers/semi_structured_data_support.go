@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+
+	"github.com/onsi/gomega/internal/gutil"
 )
 
 func formattedMessage(comparisonMessage string, failurePath []interface{}) string {
@@ -59,7 +61,8 @@ func deepEqual(a interface{}, b interface{}) (bool, []interface{}) {
 			return false, errorPath
 		}
 
-		for k, v1 := range a.(map[interface{}]interface{}) {
+		for _, key := range gutil.SortedMapKeys(reflect.ValueOf(a)) {
+			k, v1 := key.Interface(), a.(map[interface{}]interface{})[key.Interface()]
 			v2, ok := b.(map[interface{}]interface{})[k]
 			if !ok {
 				return false, errorPath
@@ -76,7 +79,8 @@ func deepEqual(a interface{}, b interface{}) (bool, []interface{}) {
 			return false, errorPath
 		}
 
-		for k, v1 := range a.(map[string]interface{}) {
+		for _, key := range gutil.SortedMapKeys(reflect.ValueOf(a)) {
+			k, v1 := key.String(), a.(map[string]interface{})[key.String()]
 			v2, ok := b.(map[string]interface{})[k]
 			if !ok {
 				return false, errorPath
@@ -0,0 +1,276 @@
+package matchers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semVer is a semantic version, as specified by https://semver.org.  Build metadata is dropped as it does not affect
+// precedence.
+type semVer struct {
+	major, minor, patch uint64
+	preRelease          []string
+}
+
+// parseSemVer parses a semantic version, optionally prefixed with a "v"
+func parseSemVer(s string) (semVer, error) {
+	v, specified, err := parsePartialSemVer(s)
+	if err != nil {
+		return semVer{}, err
+	}
+	if specified < 3 {
+		return semVer{}, fmt.Errorf("%q does not have major, minor and patch versions", s)
+	}
+	return v, nil
+}
+
+// parsePartialSemVer parses a semantic version whose minor and patch versions may be omitted, as they can be in
+// version constraints.  specified is the number of the major, minor and patch versions that are present.
+func parsePartialSemVer(s string) (v semVer, specified int, err error) {
+	version := strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(version, '+'); i >= 0 {
+		if !validSemVerIdentifiers(version[i+1:], false) {
+			return semVer{}, 0, fmt.Errorf("%q has invalid build metadata", s)
+		}
+		version = version[:i]
+	}
+	if i := strings.IndexByte(version, '-'); i >= 0 {
+		if !validSemVerIdentifiers(version[i+1:], true) {
+			return semVer{}, 0, fmt.Errorf("%q has an invalid pre-release version", s)
+		}
+		v.preRelease = strings.Split(version[i+1:], ".")
+		version = version[:i]
+	}
+
+	parts := strings.Split(version, ".")
+	if len(parts) > 3 {
+		return semVer{}, 0, fmt.Errorf("%q has more than three version numbers", s)
+	}
+	numbers := []*uint64{&v.major, &v.minor, &v.patch}
+	for i, part := range parts {
+		if !isSemVerNumber(part) {
+			return semVer{}, 0, fmt.Errorf("%q has an invalid version number %q", s, part)
+		}
+		*numbers[i], err = strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return semVer{}, 0, fmt.Errorf("%q has an invalid version number %q", s, part)
+		}
+	}
+	if v.preRelease != nil && len(parts) < 3 {
+		return semVer{}, 0, fmt.Errorf("%q has a pre-release version but no patch version", s)
+	}
+	return v, len(parts), nil
+}
+
+func isSemVerNumber(s string) bool {
+	if s == "" || (len(s) > 1 && s[0] == '0') {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func validSemVerIdentifiers(s string, preRelease bool) bool {
+	for _, identifier := range strings.Split(s, ".") {
+		if identifier == "" {
+			return false
+		}
+		numeric := true
+		for _, r := range identifier {
+			switch {
+			case r >= '0' && r <= '9':
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '-':
+				numeric = false
+			default:
+				return false
+			}
+		}
+		if preRelease && numeric && !isSemVerNumber(identifier) {
+			return false
+		}
+	}
+	return true
+}
+
+// compare returns -1, 0 or 1 as v precedes, equals or follows other
+func (v semVer) compare(other semVer) int {
+	for _, pair := range [][2]uint64{{v.major, other.major}, {v.minor, other.minor}, {v.patch, other.patch}} {
+		if pair[0] != pair[1] {
+			if pair[0] < pair[1] {
+				return -1
+			}
+			return 1
+		}
+	}
+	// a pre-release precedes the release it leads up to
+	switch {
+	case len(v.preRelease) == 0 && len(other.preRelease) == 0:
+		return 0
+	case len(v.preRelease) == 0:
+		return 1
+	case len(other.preRelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.preRelease) && i < len(other.preRelease); i++ {
+		a, b := v.preRelease[i], other.preRelease[i]
+		if a == b {
+			continue
+		}
+		aNumber, aErr := strconv.ParseUint(a, 10, 64)
+		bNumber, bErr := strconv.ParseUint(b, 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNumber < bNumber {
+				return -1
+			}
+			return 1
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		case a < b:
+			return -1
+		default:
+			return 1
+		}
+	}
+	switch {
+	case len(v.preRelease) < len(other.preRelease):
+		return -1
+	case len(v.preRelease) > len(other.preRelease):
+		return 1
+	}
+	return 0
+}
+
+// bump returns the lowest release following every version that starts with the first n of v's version numbers
+func (v semVer) bump(n int) semVer {
+	switch n {
+	case 1:
+		return semVer{major: v.major + 1}
+	case 2:
+		return semVer{major: v.major, minor: v.minor + 1}
+	default:
+		return semVer{major: v.major, minor: v.minor, patch: v.patch + 1}
+	}
+}
+
+// semVerConstraint is a disjunction, separated by "||", of conjunctions of comparisons, separated by ","
+type semVerConstraint [][]semVerComparison
+
+type semVerComparison struct {
+	text      string
+	satisfied func(semVer) bool
+}
+
+var semVerOperators = []string{">=", "<=", "!=", "=", ">", "<", "~", "^"}
+
+func parseSemVerConstraint(s string) (semVerConstraint, error) {
+	constraint := semVerConstraint{}
+	for _, group := range strings.Split(s, "||") {
+		comparisons := []semVerComparison{}
+		for _, text := range strings.Split(group, ",") {
+			comparison, err := parseSemVerComparison(strings.TrimSpace(text))
+			if err != nil {
+				return nil, err
+			}
+			comparisons = append(comparisons, comparison)
+		}
+		constraint = append(constraint, comparisons)
+	}
+	return constraint, nil
+}
+
+// parseSemVerComparison parses an operator followed by a possibly partial version.  A partial version stands for every
+// version that starts with it, so "= 1.2" is satisfied by 1.2.7 and "> 1.2" is not.  "~" allows patch-level changes -
+// or minor-level changes if only the major version is specified - and "^" allows changes that keep the left-most
+// non-zero version number.
+func parseSemVerComparison(text string) (semVerComparison, error) {
+	if text == "" {
+		return semVerComparison{}, errors.New("it has an empty comparison")
+	}
+	operator := "="
+	version := text
+	for _, candidate := range semVerOperators {
+		if strings.HasPrefix(text, candidate) {
+			operator, version = candidate, strings.TrimSpace(text[len(candidate):])
+			break
+		}
+	}
+	lower, specified, err := parsePartialSemVer(version)
+	if err != nil {
+		return semVerComparison{}, fmt.Errorf("%q is not a valid comparison: %s", text, err)
+	}
+	upper := lower.bump(specified)
+
+	var satisfied func(semVer) bool
+	switch operator {
+	case "=", "!=":
+		if specified == 3 {
+			satisfied = func(v semVer) bool { return v.compare(lower) == 0 }
+		} else {
+			satisfied = func(v semVer) bool { return v.compare(lower) >= 0 && v.compare(upper) < 0 }
+		}
+		if operator == "!=" {
+			equal := satisfied
+			satisfied = func(v semVer) bool { return !equal(v) }
+		}
+	case ">":
+		if specified == 3 {
+			satisfied = func(v semVer) bool { return v.compare(lower) > 0 }
+		} else {
+			satisfied = func(v semVer) bool { return v.compare(upper) >= 0 }
+		}
+	case ">=":
+		satisfied = func(v semVer) bool { return v.compare(lower) >= 0 }
+	case "<":
+		satisfied = func(v semVer) bool { return v.compare(lower) < 0 }
+	case "<=":
+		if specified == 3 {
+			satisfied = func(v semVer) bool { return v.compare(lower) <= 0 }
+		} else {
+			satisfied = func(v semVer) bool { return v.compare(upper) < 0 }
+		}
+	case "~":
+		if specified > 2 {
+			upper = lower.bump(2)
+		}
+		satisfied = func(v semVer) bool { return v.compare(lower) >= 0 && v.compare(upper) < 0 }
+	case "^":
+		switch {
+		case lower.major > 0 || specified == 1:
+			upper = lower.bump(1)
+		case lower.minor > 0 || specified == 2:
+			upper = lower.bump(2)
+		default:
+			upper = lower.bump(3)
+		}
+		satisfied = func(v semVer) bool { return v.compare(lower) >= 0 && v.compare(upper) < 0 }
+	}
+	return semVerComparison{text: text, satisfied: satisfied}, nil
+}
+
+// unsatisfied returns the comparisons v fails to satisfy, one for each conjunction, or nil if v satisfies the constraint
+func (constraint semVerConstraint) unsatisfied(v semVer) []string {
+	failed := []string{}
+	for _, comparisons := range constraint {
+		groupFailed := ""
+		for _, comparison := range comparisons {
+			if !comparison.satisfied(v) {
+				groupFailed = comparison.text
+				break
+			}
+		}
+		if groupFailed == "" {
+			return nil
+		}
+		failed = append(failed, groupFailed)
+	}
+	return failed
+}
@@ -3,6 +3,8 @@ package matchers
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 
 	"github.com/onsi/gomega/format"
 )
@@ -12,6 +14,8 @@ type formattedGomegaError interface {
 }
 
 type SucceedMatcher struct {
+	// Error, if set, describes the error that actual must be when it is not nil: a matcher, an error or a string
+	Error interface{}
 }
 
 func (matcher *SucceedMatcher) Match(actual interface{}) (success bool, err error) {
@@ -26,7 +30,37 @@ func (matcher *SucceedMatcher) Match(actual interface{}) (success bool, err erro
 	}
 
 	// must be nil (or a pointer to a nil)
-	return isNil(actual), nil
+	if isNil(actual) {
+		return true, nil
+	}
+	if matcher.Error == nil {
+		return false, nil
+	}
+
+	// an error that isn't the expected one is neither success nor the expected failure
+	errorMatcher, err := matcher.errorMatcher()
+	if err != nil {
+		return false, err
+	}
+	isExpected, err := errorMatcher.Match(actual)
+	if err != nil {
+		return false, err
+	}
+	if !isExpected {
+		return false, fmt.Errorf("SucceedWith matcher expected success or an error that satisfies its matcher, but got an error that does not:\n%s\n%s", format.IndentString(errorMatcher.FailureMessage(actual), 1), describeError(actual.(error)))
+	}
+	return false, nil
+}
+
+func (matcher *SucceedMatcher) errorMatcher() (omegaMatcher, error) {
+	switch expected := matcher.Error.(type) {
+	case omegaMatcher:
+		return expected, nil
+	case error, string:
+		return &MatchErrorMatcher{Expected: expected}, nil
+	default:
+		return nil, fmt.Errorf("SucceedWith matcher must be passed an error, a string, or a matcher.  Got:\n%s", format.Object(expected, 1))
+	}
 }
 
 func (matcher *SucceedMatcher) FailureMessage(actual interface{}) (message string) {
@@ -34,9 +68,60 @@ func (matcher *SucceedMatcher) FailureMessage(actual interface{}) (message strin
 	if errors.As(actual.(error), &fgErr) {
 		return fgErr.FormattedGomegaError()
 	}
-	return fmt.Sprintf("Expected success, but got an error:\n%s\n%s", format.Object(actual, 1), format.IndentString(actual.(error).Error(), 1))
+	return "Expected success, but got an error:\n" + describeError(actual.(error))
 }
 
 func (matcher *SucceedMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	if matcher.Error != nil {
+		return fmt.Sprintf("Expected failure with an error satisfying\n%s\nbut got no error.", format.Object(matcher.Error, 1))
+	}
 	return "Expected failure, but got no error."
 }
+
+// LegacyFailureMessage makes SucceedMatcher a types.LegacyFailureMessager: it does not describe the error chain or
+// stack trace of the error
+func (matcher *SucceedMatcher) LegacyFailureMessage(actual interface{}, desiredMatch bool) string {
+	if !desiredMatch {
+		return matcher.NegatedFailureMessage(actual)
+	}
+	var fgErr formattedGomegaError
+	if errors.As(actual.(error), &fgErr) {
+		return fgErr.FormattedGomegaError()
+	}
+	return fmt.Sprintf("Expected success, but got an error:\n%s\n%s", format.Object(actual, 1), format.IndentString(actual.(error).Error(), 1))
+}
+
+// describeError renders err, its message and - when err wraps other errors - its chain and the stack trace of the
+// deepest error that has one
+func describeError(err error) string {
+	description := fmt.Sprintf("%s\n%s", format.Object(err, 1), format.IndentString(err.Error(), 1))
+
+	chain := []error{}
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		chain = append(chain, e)
+	}
+	if len(chain) > 1 {
+		description += "\n" + formatErrorChain(chain)
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		if stack, ok := stackTraceOf(chain[i]); ok {
+			description += "\nStack trace:\n" + format.IndentString(strings.TrimLeft(stack, "\n"), 1)
+			break
+		}
+	}
+	return description
+}
+
+// stackTraceOf renders the stack trace of errors with a StackTrace method - such as those created by
+// github.com/pkg/errors
+func stackTraceOf(err error) (string, bool) {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return "", false
+	}
+	stack := method.Call(nil)[0].Interface()
+	if s, ok := stack.(string); ok {
+		return s, s != ""
+	}
+	return fmt.Sprintf("%+v", stack), true
+}
@@ -2,13 +2,16 @@ package matchers_test
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"runtime"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
 	. "github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
 )
 
 func Erroring() error {
@@ -100,4 +103,77 @@ var _ = Describe("Succeed", func() {
 		actual := Succeed().NegatedFailureMessage(123)
 		Expect(actual).To(Equal("Expected failure, but got no error."))
 	})
+
+	It("includes the error chain when the error wraps others", func() {
+		actual := Succeed().FailureMessage(fmt.Errorf("outer: %w", errors.New("inner")))
+		Expect(actual).To(ContainSubstring("outer: inner\nError chain:\n"))
+		Expect(actual).To(ContainSubstring("[0] *fmt.wrapError: outer: inner"))
+		Expect(actual).To(ContainSubstring("[1] *errors.errorString: inner"))
+		Expect(actual).NotTo(ContainSubstring("Stack trace"))
+	})
+
+	It("includes the stack trace of the deepest error that has one", func() {
+		err := fmt.Errorf("outer: %w", stackErr{msg: "inner", stack: "main.go:12\nmain.go:3"})
+		actual := Succeed().FailureMessage(err)
+		Expect(actual).To(HaveSuffix("Stack trace:\n    main.go:12\n    main.go:3"))
+	})
+
+	It("renders the legacy failure message without the error chain or stack trace", func() {
+		err := fmt.Errorf("outer: %w", stackErr{msg: "inner", stack: "main.go:12\nmain.go:3"})
+		actual := types.LegacyFailureMessageFor(Succeed(), err, true)
+		Expect(actual).To(Equal(fmt.Sprintf("Expected success, but got an error:\n%s\n    outer: inner", format.Object(err, 1))))
+		Expect(types.LegacyFailureMessageFor(Succeed(), nil, false)).To(Equal("Expected failure, but got no error."))
+	})
+})
+
+type stackErr struct {
+	msg   string
+	stack string
+}
+
+func (e stackErr) Error() string      { return e.msg }
+func (e stackErr) StackTrace() string { return e.stack }
+
+var errNotFound = errors.New("not found")
+
+var _ = Describe("SucceedWith", func() {
+	It("succeeds when actual is nil", func() {
+		Expect(NotErroring()).Should(SucceedWith(errNotFound))
+	})
+
+	It("succeeds in the negated when actual satisfies the expected error", func() {
+		Expect(fmt.Errorf("fetching: %w", errNotFound)).ShouldNot(SucceedWith(errNotFound))
+		Expect(errNotFound).ShouldNot(SucceedWith(MatchError(errNotFound)))
+		Expect(errNotFound).ShouldNot(SucceedWith("not found"))
+	})
+
+	It("errors when actual is a different error", func() {
+		success, err := SucceedWith(errNotFound).Match(errors.New("timeout"))
+		Expect(success).Should(BeFalse())
+		Expect(err).Should(MatchError(HavePrefix("SucceedWith matcher expected success or an error that satisfies its matcher, but got an error that does not:\n")))
+		Expect(err.Error()).Should(ContainSubstring("    timeout"))
+	})
+
+	It("keeps Eventually polling until the expected error arrives", func() {
+		calls := 0
+		fetch := func() error {
+			calls++
+			if calls < 3 {
+				return errors.New("timeout")
+			}
+			return errNotFound
+		}
+		Eventually(fetch).WithPolling(time.Millisecond).ShouldNot(SucceedWith(MatchError(errNotFound)))
+		Expect(calls).Should(Equal(3))
+	})
+
+	It("errors when the expected error is not an error, string or matcher", func() {
+		_, err := SucceedWith(3).Match(errNotFound)
+		Expect(err).Should(MatchError("SucceedWith matcher must be passed an error, a string, or a matcher.  Got:\n    <int>: 3"))
+	})
+
+	It("builds negated failure message", func() {
+		actual := SucceedWith("not found").NegatedFailureMessage(nil)
+		Expect(actual).To(Equal("Expected failure with an error satisfying\n    <string>: not found\nbut got no error."))
+	})
 })
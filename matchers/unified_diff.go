@@ -0,0 +1,199 @@
+package matchers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// unifiedDiffContext is the number of unchanged lines shown around each change
+const unifiedDiffContext = 3
+
+// maxLCSCells bounds the size of the table used to diff the changed region of two texts.  Beyond it the
+// whole region is reported as removed and re-added - still correct, just less precise.
+const maxLCSCells = 4000000
+
+type diffOpKind int
+
+const (
+	diffEqual diffOpKind = iota
+	diffDelete
+	diffInsert
+)
+
+type diffOp struct {
+	kind diffOpKind
+	a, b int // indices into the a and b lines (the one that does not apply is the index the line would have)
+}
+
+// unifiedDiff renders the difference between a and b as a unified diff.  It returns "" if they are equal.
+func unifiedDiff(aName, bName, a, b string) string {
+	if a == b {
+		return ""
+	}
+	aLines, bLines := splitLinesKeepingNewlines(a), splitLinesKeepingNewlines(b)
+	ops := diffLines(aLines, bLines)
+
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "--- %s\n+++ %s\n", aName, bName)
+	for _, hunk := range groupHunks(ops) {
+		aStart, bStart := hunk[0].a, hunk[0].b
+		aCount, bCount := 0, 0
+		for _, op := range hunk {
+			if op.kind != diffInsert {
+				aCount++
+			}
+			if op.kind != diffDelete {
+				bCount++
+			}
+		}
+		fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(aStart, aCount), hunkRange(bStart, bCount))
+		for _, op := range hunk {
+			switch op.kind {
+			case diffEqual:
+				writeDiffLine(out, " ", aLines[op.a])
+			case diffDelete:
+				writeDiffLine(out, "-", aLines[op.a])
+			case diffInsert:
+				writeDiffLine(out, "+", bLines[op.b])
+			}
+		}
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// splitLinesKeepingNewlines splits s after each newline, so that a missing final newline is itself a difference
+func splitLinesKeepingNewlines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func writeDiffLine(out *strings.Builder, prefix string, line string) {
+	out.WriteString(prefix)
+	if strings.HasSuffix(line, "\n") {
+		out.WriteString(line)
+	} else {
+		out.WriteString(line + "\n\\ No newline at end of file\n")
+	}
+}
+
+// hunkRange formats a hunk's range the way diff -u does: 1-based, with an empty range starting at the line before it
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// diffLines returns the edit script that turns a into b.  Common prefixes and suffixes are matched directly, and
+// the remainder is aligned using a longest common subsequence.
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	ops := []diffOp{}
+	for i := 0; i < prefix; i++ {
+		ops = append(ops, diffOp{diffEqual, i, i})
+	}
+
+	aMid, bMid := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(aMid)*len(bMid) > maxLCSCells {
+		for i := range aMid {
+			ops = append(ops, diffOp{diffDelete, prefix + i, prefix})
+		}
+		for j := range bMid {
+			ops = append(ops, diffOp{diffInsert, prefix + len(aMid), prefix + j})
+		}
+	} else {
+		ops = append(ops, lcsDiff(aMid, bMid, prefix)...)
+	}
+
+	for i := 0; i < suffix; i++ {
+		ops = append(ops, diffOp{diffEqual, len(a) - suffix + i, len(b) - suffix + i})
+	}
+	return ops
+}
+
+func lcsDiff(a, b []string, offset int) []diffOp {
+	// lengths[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	ops := []diffOp{}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{diffEqual, offset + i, offset + j})
+			i, j = i+1, j+1
+		case j == len(b) || (i < len(a) && lengths[i+1][j] >= lengths[i][j+1]):
+			ops = append(ops, diffOp{diffDelete, offset + i, offset + j})
+			i++
+		default:
+			ops = append(ops, diffOp{diffInsert, offset + i, offset + j})
+			j++
+		}
+	}
+	return ops
+}
+
+// groupHunks splits an edit script into hunks of changes surrounded by up to unifiedDiffContext unchanged lines
+func groupHunks(ops []diffOp) [][]diffOp {
+	hunks := [][]diffOp{}
+	var hunk []diffOp
+	lastChange := -1
+	for i, op := range ops {
+		if op.kind == diffEqual {
+			continue
+		}
+		if hunk != nil && i-lastChange-1 <= 2*unifiedDiffContext {
+			hunk = append(hunk, ops[lastChange+1:i+1]...)
+		} else {
+			if hunk != nil {
+				hunks = append(hunks, append(hunk, trailingContext(ops, lastChange)...))
+			}
+			start := i - unifiedDiffContext
+			if start < 0 {
+				start = 0
+			}
+			hunk = append([]diffOp{}, ops[start:i+1]...)
+		}
+		lastChange = i
+	}
+	if hunk != nil {
+		hunks = append(hunks, append(hunk, trailingContext(ops, lastChange)...))
+	}
+	return hunks
+}
+
+func trailingContext(ops []diffOp, lastChange int) []diffOp {
+	end := lastChange + 1 + unifiedDiffContext
+	if end > len(ops) {
+		end = len(ops)
+	}
+	return ops[lastChange+1 : end]
+}
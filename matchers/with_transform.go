@@ -3,6 +3,9 @@ package matchers
 import (
 	"fmt"
 	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
 
 	"github.com/onsi/gomega/types"
 )
@@ -16,7 +19,10 @@ type WithTransformMatcher struct {
 	transformArgType reflect.Type
 
 	// state
-	transformedValue interface{}
+	transformedValue       interface{}
+	provenance             types.Provenance
+	trackedProvenance      types.Provenance
+	nestedTracksProvenance bool
 }
 
 // reflect.Type for error
@@ -43,7 +49,41 @@ func NewWithTransformMatcher(transform interface{}, matcher types.GomegaMatcher)
 	}
 }
 
+// NewWithTransformsMatcher chains transforms into a pipeline of nested WithTransformMatchers: the actual is passed
+// through each transform in turn and the final value is matched against the matcher, which comes last
+func NewWithTransformsMatcher(transformsAndMatcher ...interface{}) *WithTransformMatcher {
+	if len(transformsAndMatcher) < 2 {
+		panic("WithTransforms must be passed at least one transform function followed by a matcher")
+	}
+	last := len(transformsAndMatcher) - 1
+	matcher, ok := transformsAndMatcher[last].(types.GomegaMatcher)
+	if !ok {
+		panic(fmt.Sprintf("WithTransforms must be passed a matcher as its last argument.  Got:\n%#v", transformsAndMatcher[last]))
+	}
+	transforms := transformsAndMatcher[:last]
+
+	var nested *WithTransformMatcher
+	for i := len(transforms) - 1; i >= 0; i-- {
+		if _, isMatcher := transforms[i].(types.GomegaMatcher); isMatcher {
+			panic(fmt.Sprintf("WithTransforms must be passed transform functions followed by a single matcher, but argument %d is a matcher", i+1))
+		}
+		if nested == nil {
+			nested = NewWithTransformMatcher(transforms[i], matcher)
+			continue
+		}
+		outer := NewWithTransformMatcher(transforms[i], nested)
+		// outputs of interface type are only known to fit the next transform once it runs
+		if out := reflect.TypeOf(outer.Transform).Out(0); out.Kind() != reflect.Interface && !out.AssignableTo(nested.transformArgType) {
+			panic(fmt.Sprintf("WithTransforms transform %d returns '%s', but transform %d expects '%s'", i+1, out, i+2, nested.transformArgType))
+		}
+		nested = outer
+	}
+	return nested
+}
+
 func (m *WithTransformMatcher) Match(actual interface{}) (bool, error) {
+	// provenance only applies to the Match it was tracked for - a later, standalone, Match starts afresh
+	m.provenance, m.trackedProvenance = m.trackedProvenance, nil
 	// prepare a parameter to pass to the Transform function
 	var param reflect.Value
 	if actual != nil && reflect.TypeOf(actual).AssignableTo(m.transformArgType) {
@@ -69,15 +109,54 @@ func (m *WithTransformMatcher) Match(actual interface{}) (bool, error) {
 	}
 	m.transformedValue = result[0].Interface() // expect exactly one value
 
+	m.nestedTracksProvenance = types.TrackProvenance(m.Matcher, m.provenance.Append(m.provenanceStep()))
 	return m.Matcher.Match(m.transformedValue)
 }
 
 func (m *WithTransformMatcher) FailureMessage(_ interface{}) (message string) {
-	return m.Matcher.FailureMessage(m.transformedValue)
+	return m.annotate(types.FailureMessageFor(m.Matcher, m.transformedValue, true))
 }
 
 func (m *WithTransformMatcher) NegatedFailureMessage(_ interface{}) (message string) {
-	return m.Matcher.NegatedFailureMessage(m.transformedValue)
+	return m.annotate(types.FailureMessageFor(m.Matcher, m.transformedValue, false))
+}
+
+// LegacyFailureMessage makes WithTransformMatcher a types.LegacyFailureMessager: it renders the legacy message of the
+// matcher it wraps, without naming the provenance of the transformed value
+func (m *WithTransformMatcher) LegacyFailureMessage(_ interface{}, desiredMatch bool) string {
+	return types.LegacyFailureMessageFor(m.Matcher, m.transformedValue, desiredMatch)
+}
+
+// TrackProvenance makes WithTransformMatcher a types.ProvenanceTracker
+func (m *WithTransformMatcher) TrackProvenance(provenance types.Provenance) {
+	m.trackedProvenance = provenance
+}
+
+func (m *WithTransformMatcher) annotate(message string) string {
+	if len(m.provenance) == 0 || m.nestedTracksProvenance {
+		return message
+	}
+	return m.provenance.Append(m.provenanceStep()).Annotate(message)
+}
+
+var anonymousFuncRegexp = regexp.MustCompile(`(^|\.)func\d+(\.\d+)*$`)
+
+// provenanceStep names the transform by its function's name, or by its type if it is anonymous
+func (m *WithTransformMatcher) provenanceStep() string {
+	return "transformed by " + funcName(m.Transform)
+}
+
+// funcName returns the name of the function fn, or its type if it is anonymous
+func funcName(fn interface{}) string {
+	if f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()); f != nil {
+		name := f.Name()
+		name = name[strings.LastIndex(name, "/")+1:]
+		name = strings.TrimSuffix(name[strings.Index(name, ".")+1:], "-fm")
+		if !anonymousFuncRegexp.MatchString(name) {
+			return name
+		}
+	}
+	return reflect.TypeOf(fn).String()
 }
 
 func (m *WithTransformMatcher) MatchMayChangeInTheFuture(_ interface{}) bool {
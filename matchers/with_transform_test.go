@@ -2,6 +2,7 @@ package matchers_test
 
 import (
 	"errors"
+	"strconv"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -158,4 +159,65 @@ var _ = Describe("WithTransformMatcher", func() {
 			Expect(m.(*WithTransformMatcher).MatchMayChangeInTheFuture(1)).To(BeTrue()) // defaults to true
 		})
 	})
+
+	Context("provenance", func() {
+		It("names the transform in failures nested in another WithTransform", func() {
+			m := WithTransform(plus1, WithTransform(strconv.Itoa, Equal("3")))
+			Expect(m.Match(1)).To(BeFalse())
+			Expect(m.FailureMessage(1)).To(Equal("Provenance: actual → transformed by func(int) int → transformed by Itoa\nExpected\n    <string>: 2\nto equal\n    <string>: 3"))
+			Expect(m.Match(2)).To(BeTrue())
+			Expect(m.NegatedFailureMessage(2)).To(HavePrefix("Provenance: actual → transformed by func(int) int → transformed by Itoa\n"))
+		})
+
+		It("passes the provenance on to matchers that descend into the transformed value", func() {
+			type Wrapper struct{ Value int }
+			wrap := func(i int) Wrapper { return Wrapper{i} }
+			m := WithTransform(wrap, HaveField("Value", 3))
+			Expect(m.Match(1)).To(BeFalse())
+			Expect(m.FailureMessage(1)).To(HavePrefix("Provenance: actual → transformed by func(int) matchers_test.Wrapper → .Value\nValue for field 'Value' failed to satisfy matcher.\n"))
+		})
+
+		It("does not annotate failures that aren't nested", func() {
+			m := WithTransform(plus1, Equal(3))
+			Expect(m.Match(1)).To(BeFalse())
+			Expect(m.FailureMessage(1)).NotTo(ContainSubstring("Provenance"))
+		})
+	})
+
+	Context("WithTransforms", func() {
+		double := func(i int) int { return i * 2 }
+
+		It("applies the transforms in order before matching", func() {
+			Expect(1).To(WithTransforms(plus1, double, strconv.Itoa, Equal("4")))
+			Expect(1).NotTo(WithTransforms(double, plus1, Equal(4)))
+			Expect(1).To(WithTransforms(plus1, Equal(2)))
+		})
+
+		It("errors when a transform does", func() {
+			failing := func(i int) (int, error) { return 0, errors.New("boom") }
+			success, err := WithTransforms(plus1, failing, Equal(2)).Match(1)
+			Expect(success).To(BeFalse())
+			Expect(err).To(MatchError("Transform function failed: boom"))
+		})
+
+		It("names every transform that was applied when it fails", func() {
+			m := WithTransforms(plus1, double, strconv.Itoa, Equal("5"))
+			Expect(m.Match(1)).To(BeFalse())
+			Expect(m.FailureMessage(1)).To(Equal("Provenance: actual → transformed by func(int) int → transformed by func(int) int → transformed by Itoa\nExpected\n    <string>: 4\nto equal\n    <string>: 5"))
+		})
+
+		It("accepts transforms that return interfaces, checking them when they run", func() {
+			box := func(i int) interface{} { return i }
+			Expect(1).To(WithTransforms(box, plus1, Equal(2)))
+			_, err := WithTransforms(func(i int) interface{} { return "one" }, plus1, Equal(2)).Match(1)
+			Expect(err).To(MatchError("Transform function expects 'int' but we have 'string'"))
+		})
+
+		It("panics when misused", func() {
+			Expect(func() { WithTransforms(Equal(1)) }).To(PanicWith(ContainSubstring("at least one transform function")))
+			Expect(func() { WithTransforms(plus1, double) }).To(PanicWith(ContainSubstring("must be passed a matcher as its last argument")))
+			Expect(func() { WithTransforms(plus1, Equal(1), Equal(2)) }).To(PanicWith(ContainSubstring("argument 2 is a matcher")))
+			Expect(func() { WithTransforms(strconv.Itoa, plus1, Equal(2)) }).To(PanicWith("WithTransforms transform 1 returns 'string', but transform 2 expects 'int'"))
+		})
+	})
 })
@@ -0,0 +1,22 @@
+package types
+
+import "context"
+
+/*
+An AsyncActualAdapter teaches Eventually and Consistently to poll a new kind of actual - a future, an observable, an
+informer's cache - that would otherwise have to be wrapped in a function.  Gomega asks each registered adapter, in
+order, whether it Adapts the actual passed to Eventually or Consistently.  The first that does polls it in place of
+the actual:
+
+  - Poll returns the actual's current value, which is passed to the matcher.  ctx is the context passed to the
+    assertion, or context.Background().  A non-nil error fails the poll, just as it would if returned by a polled
+    function.  Poll can also return the StopTrying and TryAgainAfter signals.
+  - MayChange reports whether the actual's value may still change after Poll returned value - a resolved future, for
+    example, will not.  When it returns false the matcher's verdict on value is final, so Eventually and Consistently
+    stop polling.
+*/
+type AsyncActualAdapter interface {
+	Adapts(actual interface{}) bool
+	Poll(ctx context.Context, actual interface{}) (value interface{}, err error)
+	MayChange(actual interface{}, value interface{}) bool
+}
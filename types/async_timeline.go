@@ -0,0 +1,70 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+/*
+AsyncTimeline records how an Eventually or Consistently assertion converged (or failed to).  Gomega hands one to the
+registered AsyncTimelineReporter each time an asynchronous assertion completes.
+
+AsyncTimeline serializes to JSON and implements fmt.Stringer, so it can be attached to a Ginkgo report as is:
+
+	gomega.RegisterAsyncTimelineReporter(func(timeline types.AsyncTimeline) {
+		AddReportEntry(timeline.AsyncType+" timeline", timeline, ReportEntryVisibilityFailureOrVerbose)
+	})
+*/
+type AsyncTimeline struct {
+	// AsyncType is "Eventually" or "Consistently"
+	AsyncType string
+	// Description is the optional description passed to Should or ShouldNot
+	Description string `json:",omitempty"`
+	Start       time.Time
+	End         time.Time
+	Succeeded   bool
+	// Attempts lists the polls, with consecutive polls that produced the same result collapsed into one entry
+	Attempts []AsyncAttempt
+}
+
+/*
+AsyncAttempt describes one or more consecutive polls of an asynchronous assertion that produced the same result.
+*/
+type AsyncAttempt struct {
+	// First and Last are the times of the first and last polls in this run
+	First time.Time
+	Last  time.Time
+	// Polls is the number of polls in this run
+	Polls int
+	// Fingerprint identifies the polled value without recording it: polls that returned equal-looking values share a fingerprint
+	Fingerprint string `json:",omitempty"`
+	// Passed is true if the matcher gave the result the assertion wanted
+	Passed bool
+	// Error is the error returned by the polled function or the matcher, if any
+	Error string `json:",omitempty"`
+}
+
+func (t AsyncTimeline) String() string {
+	verdict := "failed"
+	if t.Succeeded {
+		verdict = "succeeded"
+	}
+	polls := 0
+	for _, attempt := range t.Attempts {
+		polls += attempt.Polls
+	}
+
+	out := &strings.Builder{}
+	fmt.Fprintf(out, "%s %s after %d polls over %s", t.AsyncType, verdict, polls, t.End.Sub(t.Start))
+	for _, attempt := range t.Attempts {
+		result := fmt.Sprintf("value %s did not pass", attempt.Fingerprint)
+		if attempt.Error != "" {
+			result = "errored: " + attempt.Error
+		} else if attempt.Passed {
+			result = fmt.Sprintf("value %s passed", attempt.Fingerprint)
+		}
+		fmt.Fprintf(out, "\n  +%s: %d polls, %s", attempt.First.Sub(t.Start), attempt.Polls, result)
+	}
+	return out.String()
+}
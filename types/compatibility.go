@@ -0,0 +1,38 @@
+package types
+
+/*
+CompatibilityMode lets a large suite written against an earlier version of Gomega opt out of behaviors that have since
+changed, one at a time, so that it can adopt them incrementally rather than all at once.  The zero value opts out of
+nothing.  Compatibility modes are set per Gomega - see SetCompatibilityMode.
+*/
+type CompatibilityMode struct {
+	// LegacyFailureMessages renders failure messages the way they were rendered before they were reworked.  And, Or,
+	// and Not - and so SatisfyAll and SatisfyAny - go back to describing their matchers generically rather than
+	// enumerating how each fared, BeZero stops naming the first non-zero field, BeElementOf and BeKeyOf stop suggesting
+	// the nearest candidates, and Succeed stops describing the error chain and stack trace of the error it fails on.  The matchers that wrap others - WithTransform, HaveField, and
+	// gstruct's PointTo, MatchFields, MatchElements, and MatchKeys - render the legacy messages of the matchers they
+	// wrap, and stop naming the provenance of the values they fail on.
+	LegacyFailureMessages bool
+
+	// LegacyTimeouts ignores the timeout scale set with SetTimeoutScale or GOMEGA_TIMEOUT_SCALE, so that Eventually
+	// and Consistently wait exactly as long as they are told to.
+	LegacyTimeouts bool
+}
+
+/*
+LegacyFailureMessager is implemented by matchers whose failure messages have changed.  LegacyFailureMessage renders the
+message the matcher used to render: its FailureMessage when desiredMatch is true and its NegatedFailureMessage when it
+is false.  Gomegas with LegacyFailureMessages set use it in place of the current message.
+*/
+type LegacyFailureMessager interface {
+	LegacyFailureMessage(actual interface{}, desiredMatch bool) string
+}
+
+// LegacyFailureMessageFor returns matcher's legacy failure message if it has one, and its current failure message if
+// it does not
+func LegacyFailureMessageFor(matcher GomegaMatcher, actual interface{}, desiredMatch bool) string {
+	if legacy, ok := matcher.(LegacyFailureMessager); ok {
+		return legacy.LegacyFailureMessage(actual, desiredMatch)
+	}
+	return FailureMessageFor(matcher, actual, desiredMatch)
+}
@@ -0,0 +1,25 @@
+package types
+
+/*
+A CursorSource is an actual that Eventually and Consistently read incrementally - a log file, an event API - rather
+than re-fetching in full on every attempt.  Each attempt calls ReadFrom with the cursor returned by the previous
+successful attempt (nil on the first) and passes only the values read since then to the matcher:
+
+  - values holds whatever was read past cursor, typically a slice.
+  - newCursor marks how far ReadFrom got; it is passed to the next attempt's ReadFrom.
+  - A non-nil error fails the attempt, just as it would if returned by a polled function, and leaves the cursor where
+    it was.  ReadFrom can also return the StopTrying and TryAgainAfter signals.
+
+The cursor is kept for the duration of a single Eventually or Consistently - each assertion starts reading from nil.
+*/
+type CursorSource interface {
+	ReadFrom(cursor interface{}) (values interface{}, newCursor interface{}, err error)
+}
+
+// CursorSourceFunc adapts a function into a CursorSource
+type CursorSourceFunc func(cursor interface{}) (values interface{}, newCursor interface{}, err error)
+
+// ReadFrom calls f
+func (f CursorSourceFunc) ReadFrom(cursor interface{}) (interface{}, interface{}, error) {
+	return f(cursor)
+}
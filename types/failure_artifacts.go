@@ -0,0 +1,33 @@
+package types
+
+import "time"
+
+/*
+FailureMetadata describes a failed assertion.  It is passed to each registered FailureArtifactProvider.
+*/
+type FailureMetadata struct {
+	// Message is the failure message, as it will be reported
+	Message string
+	// File and Line locate the failed assertion
+	File string
+	Line int
+	Time time.Time
+}
+
+/*
+A FailureArtifactProvider collects diagnostics - a snapshot of a debug endpoint, a log file, a screenshot - when an
+assertion fails.  It returns a description of each artifact it collected (typically a path) and Gomega appends these to
+the failure message.  An error is reported alongside the failure, but does not otherwise affect it.
+*/
+type FailureArtifactProvider interface {
+	CollectFailureArtifacts(failure FailureMetadata) (artifacts []string, err error)
+}
+
+/*
+FailureArtifactProviderFunc adapts a function into a FailureArtifactProvider.
+*/
+type FailureArtifactProviderFunc func(failure FailureMetadata) (artifacts []string, err error)
+
+func (f FailureArtifactProviderFunc) CollectFailureArtifacts(failure FailureMetadata) ([]string, error) {
+	return f(failure)
+}
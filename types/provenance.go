@@ -0,0 +1,63 @@
+package types
+
+import "strings"
+
+/*
+Provenance records how a value was derived from the actual passed to an assertion: the fields, elements, and keys
+descended into and the transforms applied along the way.  It renders as a path from the actual:
+
+	actual.Spec.Containers[2].Image → transformed by normalizeImage
+
+Steps that start with "." or "[" descend into the value and are appended as is.  Any other step - a transform - is
+joined with an arrow, as is the first step to descend into a transformed value.
+*/
+type Provenance []string
+
+// Append returns a copy of p with steps appended
+func (p Provenance) Append(steps ...string) Provenance {
+	provenance := make(Provenance, 0, len(p)+len(steps))
+	return append(append(provenance, p...), steps...)
+}
+
+func (p Provenance) String() string {
+	s, transformed := "actual", false
+	for _, step := range p {
+		descends := strings.HasPrefix(step, ".") || strings.HasPrefix(step, "[")
+		if descends && !transformed {
+			s += step
+		} else {
+			s += " → " + step
+		}
+		transformed = !descends
+	}
+	return s
+}
+
+// Annotate prefixes a failure message with a line naming the provenance of the value that failed
+func (p Provenance) Annotate(message string) string {
+	return "Provenance: " + p.String() + "\n" + message
+}
+
+/*
+ProvenanceTracker is implemented by matchers that descend into their actual and pass what they derive from it to a
+nested matcher - WithTransform, HaveField, and gstruct's matchers.  A ProvenanceTracker calls TrackProvenance on its
+nested matcher before matching, passing on the provenance it was given with its own steps appended.  The provenance
+only applies to the next call to Match: a ProvenanceTracker reused on its own afterwards does not report it.
+
+When a ProvenanceTracker was given a provenance - that is, when it is nested in another - and its own nested matcher
+is not a ProvenanceTracker, it is the innermost along the chain and annotates its failure messages with the full
+provenance of the value that failed.  Deeply derived failures can then be traced back to the part of the actual they
+came from.
+*/
+type ProvenanceTracker interface {
+	TrackProvenance(provenance Provenance)
+}
+
+// TrackProvenance passes provenance on to matcher if it is a ProvenanceTracker, and reports whether it was
+func TrackProvenance(matcher interface{}, provenance Provenance) bool {
+	tracker, ok := matcher.(ProvenanceTracker)
+	if ok {
+		tracker.TrackProvenance(provenance)
+	}
+	return ok
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"sync"
 	"time"
 )
 
@@ -62,6 +63,25 @@ func MatchMayChangeInTheFuture(matcher GomegaMatcher, value interface{}) bool {
 	return oracleMatcher.MatchMayChangeInTheFuture(value)
 }
 
+/*
+FailureMessageFor returns the message explaining why matcher's verdict on actual was not desiredMatch: the matcher's
+FailureMessage when desiredMatch is true, and its NegatedFailureMessage when it is false.
+
+Matchers that compose other matchers should build their failure messages from their children's with
+FailureMessageFor, flipping desiredMatch whenever they negate a child.  Tracking polarity this way keeps messages
+logically correct however deeply Not is nested:
+
+	func (m *NotMatcher) FailureMessage(actual interface{}) string {
+		return types.FailureMessageFor(m.Matcher, actual, false)
+	}
+*/
+func FailureMessageFor(matcher GomegaMatcher, actual interface{}, desiredMatch bool) string {
+	if desiredMatch {
+		return matcher.FailureMessage(actual)
+	}
+	return matcher.NegatedFailureMessage(actual)
+}
+
 // AsyncAssertions are returned by Eventually and Consistently and enable matchers to be polled repeatedly to ensure
 // they are eventually satisfied
 type AsyncAssertion interface {
@@ -78,6 +98,51 @@ type AsyncAssertion interface {
 	MustPassRepeatedly(count int) AsyncAssertion
 }
 
+// The AsyncAssertions returned by Eventually and Consistently implement the optional interfaces below too.  They are
+// kept off AsyncAssertion so that other implementations of it keep compiling - type-assert to them instead:
+//
+//	Eventually(cluster.Status).(types.NamedTimeoutAsyncAssertion).WithTimeoutNamed("cluster-converge").Should(Equal("Ready"))
+
+// NamedTimeoutAsyncAssertion is an AsyncAssertion whose timeout can be a duration registered by name
+type NamedTimeoutAsyncAssertion interface {
+	AsyncAssertion
+	// WithTimeoutNamed sets the timeout to the duration registered under name, which is looked up when the assertion runs
+	WithTimeoutNamed(name string) AsyncAssertion
+}
+
+// SerializedAccessAsyncAssertion is an AsyncAssertion whose polls can be synchronized with the code under test
+type SerializedAccessAsyncAssertion interface {
+	AsyncAssertion
+	// WithSerializedAccess makes each poll - calling the polled function and the matcher - hold lock, so that polling a
+	// non-thread-safe actual is synchronized with other code that holds lock while it touches the actual
+	WithSerializedAccess(lock sync.Locker) AsyncAssertion
+}
+
+// AttemptLimitedAsyncAssertion is an AsyncAssertion that can give up after a number of polls
+type AttemptLimitedAsyncAssertion interface {
+	AsyncAssertion
+	// StopTryingAfterAttempts caps the number of times the actual is polled.  An Eventually that has not succeeded after
+	// attempts polls fails without waiting for its timeout; a Consistently that has passed attempts polls succeeds.
+	StopTryingAfterAttempts(attempts int) AsyncAssertion
+}
+
+// AsyncAssertionInspector is an AsyncAssertion that reports how it has been configured, and can be cloned
+type AsyncAssertionInspector interface {
+	AsyncAssertion
+	// Timeout returns the timeout of an Eventually, or the duration of a Consistently, before any timeout scale is
+	// applied.  ok is false when there is no timeout: when an Eventually waits only on its context, or is given a
+	// named timeout with no registered duration.
+	Timeout() (timeout time.Duration, ok bool)
+	// PollingInterval returns the interval between polls
+	PollingInterval() time.Duration
+	// Context returns the context passed to the assertion, if any
+	Context() context.Context
+	// Arguments returns a copy of the arguments forwarded to the polled function
+	Arguments() []interface{}
+	// Clone returns a copy of the assertion, which can be configured without affecting the original
+	Clone() AsyncAssertion
+}
+
 // Assertions are returned by Ω and Expect and enable assertions against Gomega matchers
 type Assertion interface {
 	Should(matcher GomegaMatcher, optionalDescription ...interface{}) bool
@@ -91,3 +156,20 @@ type Assertion interface {
 
 	Error() Assertion
 }
+
+/*
+Buffers that implement the StreamingBuffer interface can be used with gbytes.Say and gbytes.Detector.
+
+StreamingBuffers maintain a read cursor: the offset of the first unread byte relative to the start of the stream.  ScanUnread must
+invoke scan with the current read cursor and the data that appears after it, and then fast-forward the read cursor by the number
+of bytes scan returns.  The two steps must happen atomically with respect to other calls to ScanUnread.  scan must not retain the
+slice it is passed.
+
+Closed returns true once the buffer will no longer be written to.  This allows Eventually to abort early.
+
+*gbytes.Buffer is a StreamingBuffer.
+*/
+type StreamingBuffer interface {
+	ScanUnread(scan func(cursor uint64, unread []byte) (advance uint64))
+	Closed() bool
+}